@@ -415,6 +415,7 @@ describe('getCompletionItems()', () => {
               "has.commit.after(\${1:1 month ago}) ",
               "has.description(\${1}) ",
               "has.meta(\${1:key}:\${2:value}) ",
+              "has.dependency(name:\${1:lodash} ecosystem:\${2:npm} version:\${3:<4.17.21}) ",
               "^repo/with\\\\ a\\\\ space$ "
             ]
         `)
@@ -438,7 +439,8 @@ describe('getCompletionItems()', () => {
               "has.topic(\${1}) ",
               "has.commit.after(\${1:1 month ago}) ",
               "has.description(\${1}) ",
-              "has.meta(\${1:key}:\${2:value}) "
+              "has.meta(\${1:key}:\${2:value}) ",
+              "has.dependency(name:\${1:lodash} ecosystem:\${2:npm} version:\${3:<4.17.21}) "
             ]
         `)
     })
@@ -171,6 +171,8 @@ const toPredicateHover = (token: MetaPredicate): string => {
             return `**Built-in predicate**. Search only inside repositories that contain **file content** matching the regular expression \`${parameters}\`.`
         case 'has.topic':
             return `**Built-in predicate**. Search only inside repositories that have the github topic \`${parameters}\`.`
+        case 'has.dependency':
+            return `**Built-in predicate**. Search only inside repositories whose lockfiles declare a dependency matching \`${parameters}\`.`
         case 'contains.commit.after':
         case 'has.commit.after':
             return `**Built-in predicate**. Search only inside repositories that have been committed to since \`${parameters}\`.`
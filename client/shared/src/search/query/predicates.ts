@@ -42,6 +42,7 @@ export const PREDICATES: Access[] = [
                     { name: 'key' },
                     { name: 'meta' },
                     { name: 'topic' },
+                    { name: 'dependency' },
                 ],
             },
         ],
@@ -219,6 +220,12 @@ export const predicateCompletion = (field: string): Completion[] => {
                     'Search only inside repositories having ({key}:{value}) pair, or ({key}) with any value or ({key}:) with no value metadata',
                 asSnippet: true,
             },
+            {
+                label: 'has.dependency(...)',
+                insertText: 'has.dependency(name:${1:lodash} ecosystem:${2:npm} version:${3:<4.17.21})',
+                description: 'Search only inside repositories whose lockfiles declare a matching dependency',
+                asSnippet: true,
+            },
         ]
     }
     if (field === 'file') {
@@ -139,6 +139,13 @@ export interface CommitMatch {
     content: MarkdownText
     // Array of [line, character, length] triplets
     ranges: number[][]
+    // Only set for `type:diff select:symbol` searches
+    changedSymbols?: ChangedSymbol[]
+}
+
+export interface ChangedSymbol extends MatchedSymbol {
+    path: string
+    change: 'ADDED' | 'REMOVED' | 'MODIFIED'
 }
 
 export interface RepositoryMatch {
@@ -18,6 +18,7 @@ import gitoliteSchemaJSON from '../../../../schema/gitolite.schema.json'
 import goModulesSchemaJSON from '../../../../schema/go-modules.schema.json'
 import jvmPackagesSchemaJSON from '../../../../schema/jvm-packages.schema.json'
 import localGitSchemaJSON from '../../../../schema/localgit.schema.json'
+import mercurialSchemaJSON from '../../../../schema/mercurial.schema.json'
 import npmPackagesSchemaJSON from '../../../../schema/npm-packages.schema.json'
 import otherExternalServiceSchemaJSON from '../../../../schema/other_external_service.schema.json'
 import pagureSchemaJSON from '../../../../schema/pagure.schema.json'
@@ -62,6 +63,7 @@ const externalServices: Record<ExternalServiceKind, JSONSchema> = {
     PHABRICATOR: phabricatorSchemaJSON,
     PAGURE: pagureSchemaJSON,
     LOCALGIT: localGitSchemaJSON,
+    MERCURIAL: mercurialSchemaJSON,
 }
 
 const allConfigSchema = {
@@ -24,3 +24,16 @@ func (symbols) ListTags(ctx context.Context, args search.SymbolsParameters) (res
 	}
 	return symbols, nil
 }
+
+// ParseFiles returns the symbols of individual files at a commit from ctags,
+// without indexing the rest of the repository.
+func (symbols) ParseFiles(ctx context.Context, args search.ParseFilesParameters) (result.Symbols, error) {
+	symbols, err := symbolsclient.DefaultClient.ParseFiles(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	for i := range symbols {
+		symbols[i].Line += 1 // callers expect 1-indexed lines
+	}
+	return symbols, nil
+}
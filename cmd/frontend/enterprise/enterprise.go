@@ -29,6 +29,9 @@ type Services struct {
 	BatchesChangesFileGetHandler    http.Handler
 	BatchesChangesFileExistsHandler http.Handler
 	BatchesChangesFileUploadHandler http.Handler
+	BatchesStepCacheGetHandler      http.Handler
+	BatchesStepCacheExistsHandler   http.Handler
+	BatchesStepCacheUploadHandler   http.Handler
 
 	// Repo related webhook handlers, currently only handle `push` events.
 	ReposGithubWebhook          webhooks.Registerer
@@ -40,6 +43,9 @@ type Services struct {
 
 	// Handler for exporting code insights data.
 	CodeInsightsDataExportHandler http.Handler
+	// Handlers for exporting and importing all recorded points of code insights.
+	CodeInsightsDataExportPointsHandler http.Handler
+	CodeInsightsDataImportPointsHandler http.Handler
 
 	// Handler for completions stream.
 	NewChatCompletionsStreamHandler NewChatCompletionsStreamHandler
@@ -95,30 +101,35 @@ type NewDotcomLicenseCheckHandler func() http.Handler
 // DefaultServices creates a new Services value that has default implementations for all services.
 func DefaultServices() Services {
 	return Services{
-		ReposGithubWebhook:              &emptyWebhookHandler{name: "github sync webhook"},
-		ReposGitLabWebhook:              &emptyWebhookHandler{name: "gitlab sync webhook"},
-		ReposBitbucketServerWebhook:     &emptyWebhookHandler{name: "bitbucket server sync webhook"},
-		ReposBitbucketCloudWebhook:      &emptyWebhookHandler{name: "bitbucket cloud sync webhook"},
-		PermissionsGitHubWebhook:        &emptyWebhookHandler{name: "permissions github webhook"},
-		BatchesGitHubWebhook:            &emptyWebhookHandler{name: "batches github webhook"},
-		BatchesGitLabWebhook:            &emptyWebhookHandler{name: "batches gitlab webhook"},
-		BatchesBitbucketServerWebhook:   &emptyWebhookHandler{name: "batches bitbucket server webhook"},
-		BatchesBitbucketCloudWebhook:    &emptyWebhookHandler{name: "batches bitbucket cloud webhook"},
-		BatchesAzureDevOpsWebhook:       &emptyWebhookHandler{name: "batches azure devops webhook"},
-		BatchesChangesFileGetHandler:    makeNotFoundHandler("batches file get handler"),
-		BatchesChangesFileExistsHandler: makeNotFoundHandler("batches file exists handler"),
-		BatchesChangesFileUploadHandler: makeNotFoundHandler("batches file upload handler"),
-		SCIMHandler:                     makeNotFoundHandler("SCIM handler"),
-		NewCodeIntelUploadHandler:       func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
-		RankingService:                  stubRankingService{},
-		NewExecutorProxyHandler:         func() http.Handler { return makeNotFoundHandler("executor proxy") },
-		NewGitHubAppSetupHandler:        func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
-		NewComputeStreamHandler:         func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
-		CodeInsightsDataExportHandler:   makeNotFoundHandler("code insights data export handler"),
-		NewDotcomLicenseCheckHandler:    func() http.Handler { return makeNotFoundHandler("dotcom license check handler") },
-		NewChatCompletionsStreamHandler: func() http.Handler { return makeNotFoundHandler("chat completions streaming endpoint") },
-		NewCodeCompletionsHandler:       func() http.Handler { return makeNotFoundHandler("code completions streaming endpoint") },
-		EnterpriseSearchJobs:            jobutil.NewUnimplementedEnterpriseJobs(),
+		ReposGithubWebhook:                  &emptyWebhookHandler{name: "github sync webhook"},
+		ReposGitLabWebhook:                  &emptyWebhookHandler{name: "gitlab sync webhook"},
+		ReposBitbucketServerWebhook:         &emptyWebhookHandler{name: "bitbucket server sync webhook"},
+		ReposBitbucketCloudWebhook:          &emptyWebhookHandler{name: "bitbucket cloud sync webhook"},
+		PermissionsGitHubWebhook:            &emptyWebhookHandler{name: "permissions github webhook"},
+		BatchesGitHubWebhook:                &emptyWebhookHandler{name: "batches github webhook"},
+		BatchesGitLabWebhook:                &emptyWebhookHandler{name: "batches gitlab webhook"},
+		BatchesBitbucketServerWebhook:       &emptyWebhookHandler{name: "batches bitbucket server webhook"},
+		BatchesBitbucketCloudWebhook:        &emptyWebhookHandler{name: "batches bitbucket cloud webhook"},
+		BatchesAzureDevOpsWebhook:           &emptyWebhookHandler{name: "batches azure devops webhook"},
+		BatchesChangesFileGetHandler:        makeNotFoundHandler("batches file get handler"),
+		BatchesChangesFileExistsHandler:     makeNotFoundHandler("batches file exists handler"),
+		BatchesChangesFileUploadHandler:     makeNotFoundHandler("batches file upload handler"),
+		BatchesStepCacheGetHandler:          makeNotFoundHandler("batches step cache get handler"),
+		BatchesStepCacheExistsHandler:       makeNotFoundHandler("batches step cache exists handler"),
+		BatchesStepCacheUploadHandler:       makeNotFoundHandler("batches step cache upload handler"),
+		SCIMHandler:                         makeNotFoundHandler("SCIM handler"),
+		NewCodeIntelUploadHandler:           func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
+		RankingService:                      stubRankingService{},
+		NewExecutorProxyHandler:             func() http.Handler { return makeNotFoundHandler("executor proxy") },
+		NewGitHubAppSetupHandler:            func() http.Handler { return makeNotFoundHandler("Sourcegraph GitHub App setup") },
+		NewComputeStreamHandler:             func() http.Handler { return makeNotFoundHandler("compute streaming endpoint") },
+		CodeInsightsDataExportHandler:       makeNotFoundHandler("code insights data export handler"),
+		CodeInsightsDataExportPointsHandler: makeNotFoundHandler("code insights points export handler"),
+		CodeInsightsDataImportPointsHandler: makeNotFoundHandler("code insights points import handler"),
+		NewDotcomLicenseCheckHandler:        func() http.Handler { return makeNotFoundHandler("dotcom license check handler") },
+		NewChatCompletionsStreamHandler:     func() http.Handler { return makeNotFoundHandler("chat completions streaming endpoint") },
+		NewCodeCompletionsHandler:           func() http.Handler { return makeNotFoundHandler("code completions streaming endpoint") },
+		EnterpriseSearchJobs:                jobutil.NewUnimplementedEnterpriseJobs(),
 	}
 }
 
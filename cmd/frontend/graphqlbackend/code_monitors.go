@@ -81,6 +81,7 @@ type MonitorAction interface {
 	ToMonitorEmail() (MonitorEmailResolver, bool)
 	ToMonitorWebhook() (MonitorWebhookResolver, bool)
 	ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool)
+	ToMonitorIssue() (MonitorIssueResolver, bool)
 }
 
 type MonitorEmailResolver interface {
@@ -98,6 +99,7 @@ type MonitorWebhookResolver interface {
 	Enabled() bool
 	IncludeResults() bool
 	URL() string
+	PayloadTemplate() string
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
@@ -109,6 +111,16 @@ type MonitorSlackWebhookResolver interface {
 	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
 }
 
+type MonitorIssueResolver interface {
+	ID() graphql.ID
+	Enabled() bool
+	IncludeResults() bool
+	Repository() string
+	TitleTemplate() string
+	BodyTemplate() string
+	Events(ctx context.Context, args *ListEventsArgs) (MonitorActionEventConnectionResolver, error)
+}
+
 type MonitorEmailRecipient interface {
 	ToUser() (*UserResolver, bool)
 }
@@ -166,6 +178,7 @@ type CreateActionArgs struct {
 	Email        *CreateActionEmailArgs
 	Webhook      *CreateActionWebhookArgs
 	SlackWebhook *CreateActionSlackWebhookArgs
+	Issue        *CreateActionIssueArgs
 }
 
 type CreateActionEmailArgs struct {
@@ -177,15 +190,24 @@ type CreateActionEmailArgs struct {
 }
 
 type CreateActionWebhookArgs struct {
+	Enabled         bool
+	IncludeResults  bool
+	URL             string
+	PayloadTemplate *string
+}
+
+type CreateActionSlackWebhookArgs struct {
 	Enabled        bool
 	IncludeResults bool
 	URL            string
 }
 
-type CreateActionSlackWebhookArgs struct {
+type CreateActionIssueArgs struct {
 	Enabled        bool
 	IncludeResults bool
-	URL            string
+	Repository     string
+	TitleTemplate  string
+	BodyTemplate   *string
 }
 
 type ToggleCodeMonitorArgs struct {
@@ -240,10 +262,16 @@ type EditActionSlackWebhookArgs struct {
 	Update *CreateActionSlackWebhookArgs
 }
 
+type EditActionIssueArgs struct {
+	Id     *graphql.ID
+	Update *CreateActionIssueArgs
+}
+
 type EditActionArgs struct {
 	Email        *EditActionEmailArgs
 	Webhook      *EditActionWebhookArgs
 	SlackWebhook *EditActionSlackWebhookArgs
+	Issue        *EditActionIssueArgs
 }
 
 type EditTriggerArgs struct {
@@ -89,4 +89,8 @@ type RepoEmbeddingJobStatsResolver interface {
 	FilesEmbedded() int32
 	FilesScheduled() int32
 	FilesSkipped() int32
+	IsIncremental() bool
+	FilesAdded() int32
+	FilesModified() int32
+	FilesDeleted() int32
 }
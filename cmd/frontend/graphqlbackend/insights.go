@@ -45,11 +45,14 @@ type InsightsResolver interface {
 	InsightSeriesQueryStatus(ctx context.Context) ([]InsightSeriesQueryStatusResolver, error)
 	InsightViewDebug(ctx context.Context, args InsightViewDebugArgs) (InsightViewDebugResolver, error)
 	InsightAdminBackfillQueue(ctx context.Context, args *AdminBackfillQueueArgs) (*graphqlutil.ConnectionResolver[*BackfillQueueItemResolver], error)
+	InsightSeriesAlerts(ctx context.Context, args *InsightSeriesAlertsArgs) ([]InsightSeriesAlertResolver, error)
 	// Admin Mutations
 	UpdateInsightSeries(ctx context.Context, args *UpdateInsightSeriesArgs) (InsightSeriesMetadataPayloadResolver, error)
 	RetryInsightSeriesBackfill(ctx context.Context, args *BackfillArgs) (*BackfillQueueItemResolver, error)
 	MoveInsightSeriesBackfillToFrontOfQueue(ctx context.Context, args *BackfillArgs) (*BackfillQueueItemResolver, error)
 	MoveInsightSeriesBackfillToBackOfQueue(ctx context.Context, args *BackfillArgs) (*BackfillQueueItemResolver, error)
+	CreateInsightSeriesAlert(ctx context.Context, args *CreateInsightSeriesAlertArgs) (InsightSeriesAlertResolver, error)
+	DeleteInsightSeriesAlert(ctx context.Context, args *DeleteInsightSeriesAlertArgs) (*EmptyResponse, error)
 }
 
 type SearchInsightLivePreviewArgs struct {
@@ -239,6 +242,13 @@ type SearchInsightDataSeriesDefinitionResolver interface {
 	GeneratedFromCaptureGroups() (bool, error)
 	IsCalculated() (bool, error)
 	GroupBy() (*string, error)
+	Derived() (DerivedSeriesDefinitionResolver, error)
+}
+
+type DerivedSeriesDefinitionResolver interface {
+	Operation() string
+	Numerator() []string
+	Denominator() []string
 }
 
 type InsightPresentation interface {
@@ -310,6 +320,38 @@ type InsightSeriesMetadataPayloadResolver interface {
 	Series(ctx context.Context) InsightSeriesMetadataResolver
 }
 
+type InsightSeriesAlertsArgs struct {
+	SeriesId string
+}
+
+type CreateInsightSeriesAlertArgs struct {
+	Input CreateInsightSeriesAlertInput
+}
+
+type CreateInsightSeriesAlertInput struct {
+	SeriesId        string
+	Condition       string
+	Threshold       float64
+	Email           *bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+}
+
+type DeleteInsightSeriesAlertArgs struct {
+	Id graphql.ID
+}
+
+type InsightSeriesAlertResolver interface {
+	ID() graphql.ID
+	SeriesId() string
+	Condition() string
+	Threshold() float64
+	Email() bool
+	SlackWebhookURL() *string
+	WebhookURL() *string
+	LastTriggeredAt() *gqlutil.DateTime
+}
+
 type InsightSeriesQueryStatusResolver interface {
 	SeriesId(ctx context.Context) (string, error)
 	Query(ctx context.Context) (string, error)
@@ -429,6 +471,13 @@ type LineChartSearchInsightDataSeriesInput struct {
 	Options                    LineChartDataSeriesOptionsInput
 	GeneratedFromCaptureGroups *bool
 	GroupBy                    *string
+	Derived                    *DerivedSeriesInput
+}
+
+type DerivedSeriesInput struct {
+	Operation   string
+	Numerator   []int32
+	Denominator []int32
 }
 
 type LineChartDataSeriesOptionsInput struct {
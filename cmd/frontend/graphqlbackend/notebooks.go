@@ -27,6 +27,8 @@ type NotebooksResolver interface {
 	CreateNotebookStar(ctx context.Context, args CreateNotebookStarInputArgs) (NotebookStarResolver, error)
 	DeleteNotebookStar(ctx context.Context, args DeleteNotebookStarInputArgs) (*EmptyResponse, error)
 
+	ImportNotebookFromRepository(ctx context.Context, args ImportNotebookFromRepositoryArgs) (NotebookResolver, error)
+
 	NodeResolvers() map[string]NodeByIDFunc
 }
 
@@ -60,6 +62,14 @@ type NotebookResolver interface {
 	ViewerCanManage(ctx context.Context) (bool, error)
 	ViewerHasStarred(ctx context.Context) (bool, error)
 	Stars(ctx context.Context, args ListNotebookStarsArgs) (NotebookStarConnectionResolver, error)
+	Source(ctx context.Context) (NotebookSourceResolver, error)
+	Export(ctx context.Context, args NotebookExportArgs) (string, error)
+}
+
+type NotebookSourceResolver interface {
+	Repository() *RepositoryResolver
+	Path() string
+	Commit() string
 }
 
 type NotebookBlockResolver interface {
@@ -67,6 +77,8 @@ type NotebookBlockResolver interface {
 	ToQueryBlock() (QueryBlockResolver, bool)
 	ToFileBlock() (FileBlockResolver, bool)
 	ToSymbolBlock() (SymbolBlockResolver, bool)
+	ToComputeBlock() (ComputeBlockResolver, bool)
+	ToInsightBlock() (InsightBlockResolver, bool)
 }
 
 type MarkdownBlockResolver interface {
@@ -111,6 +123,29 @@ type FileBlockLineRangeResolver interface {
 	EndLine() int32
 }
 
+type ComputeBlockResolver interface {
+	ID() string
+	ComputeInput() string
+	Output(ctx context.Context) (NotebookBlockTableResolver, error)
+}
+
+type InsightBlockResolver interface {
+	ID() string
+	InsightInput() InsightBlockInputResolver
+	Output(ctx context.Context) (NotebookBlockTableResolver, error)
+}
+
+type InsightBlockInputResolver interface {
+	InsightViewID() graphql.ID
+	SeriesIDs() *[]string
+}
+
+type NotebookBlockTableResolver interface {
+	Columns() []string
+	Rows() [][]string
+	LimitHit() bool
+}
+
 type NotebookBlockType string
 
 const (
@@ -118,6 +153,8 @@ const (
 	NotebookQueryBlockType    NotebookBlockType = "QUERY"
 	NotebookFileBlockType     NotebookBlockType = "FILE"
 	NotebookSymbolBlockType   NotebookBlockType = "SYMBOL"
+	NotebookComputeBlockType  NotebookBlockType = "COMPUTE"
+	NotebookInsightBlockType  NotebookBlockType = "INSIGHT"
 )
 
 type CreateNotebookInputArgs struct {
@@ -141,12 +178,14 @@ type NotebookInputArgs struct {
 }
 
 type CreateNotebookBlockInputArgs struct {
-	ID            string                  `json:"id"`
-	Type          NotebookBlockType       `json:"type"`
-	MarkdownInput *string                 `json:"markdownInput"`
-	QueryInput    *string                 `json:"queryInput"`
-	FileInput     *CreateFileBlockInput   `json:"fileInput"`
-	SymbolInput   *CreateSymbolBlockInput `json:"symbolInput"`
+	ID            string                   `json:"id"`
+	Type          NotebookBlockType        `json:"type"`
+	MarkdownInput *string                  `json:"markdownInput"`
+	QueryInput    *string                  `json:"queryInput"`
+	FileInput     *CreateFileBlockInput    `json:"fileInput"`
+	SymbolInput   *CreateSymbolBlockInput  `json:"symbolInput"`
+	ComputeInput  *string                  `json:"computeInput"`
+	InsightInput  *CreateInsightBlockInput `json:"insightInput"`
 }
 
 type CreateFileBlockInput struct {
@@ -166,6 +205,11 @@ type CreateSymbolBlockInput struct {
 	SymbolKind          string  `json:"symbolKind"`
 }
 
+type CreateInsightBlockInput struct {
+	InsightViewID graphql.ID `json:"insightViewID"`
+	SeriesIDs     *[]string  `json:"seriesIDs"`
+}
+
 type CreateFileBlockLineRangeInput struct {
 	StartLine int32 `json:"startLine"`
 	EndLine   int32 `json:"endLine"`
@@ -187,6 +231,24 @@ type ListNotebookStarsArgs struct {
 	After *string `json:"after"`
 }
 
+type NotebookExportFormat string
+
+const (
+	NotebookExportFormatMarkdown NotebookExportFormat = "MARKDOWN"
+	NotebookExportFormatJSON     NotebookExportFormat = "JSON"
+)
+
+type NotebookExportArgs struct {
+	Format NotebookExportFormat `json:"format"`
+}
+
+type ImportNotebookFromRepositoryArgs struct {
+	Repository graphql.ID `json:"repository"`
+	Path       string     `json:"path"`
+	Namespace  graphql.ID `json:"namespace"`
+	Public     bool       `json:"public"`
+}
+
 type CreateNotebookStarInputArgs struct {
 	NotebookID graphql.ID
 }
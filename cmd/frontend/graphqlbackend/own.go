@@ -23,6 +23,7 @@ const (
 	AssignedOwner                    OwnershipReasonType = "ASSIGNED_OWNER"
 	RecentContributorOwnershipSignal OwnershipReasonType = "RECENT_CONTRIBUTOR_OWNERSHIP_SIGNAL"
 	RecentViewOwnershipSignal        OwnershipReasonType = "RECENT_VIEW_OWNERSHIP_SIGNAL"
+	BlameOwnershipSignal             OwnershipReasonType = "BLAME_OWNERSHIP_SIGNAL"
 )
 
 func (args *ListOwnershipArgs) IncludeReason(reason OwnershipReasonType) bool {
@@ -110,6 +111,7 @@ type OwnershipReasonResolver interface {
 	ToCodeownersFileEntry() (CodeownersFileEntryResolver, bool)
 	ToRecentContributorOwnershipSignal() (RecentContributorOwnershipSignalResolver, bool)
 	ToRecentViewOwnershipSignal() (RecentViewOwnershipSignalResolver, bool)
+	ToBlameOwnershipSignal() (BlameOwnershipSignalResolver, bool)
 	ToAssignedOwner() (AssignedOwnerResolver, bool)
 }
 
@@ -135,6 +137,13 @@ type RecentViewOwnershipSignalResolver interface {
 	Description() (string, error)
 }
 
+type BlameOwnershipSignalResolver interface {
+	Title() (string, error)
+	Description() (string, error)
+	Share() float64
+	LinesCount() int32
+}
+
 type AssignedOwnerResolver interface {
 	Title() (string, error)
 	Description() (string, error)
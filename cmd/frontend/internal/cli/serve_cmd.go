@@ -275,26 +275,31 @@ func makeExternalAPI(db database.DB, logger sglog.Logger, schema *graphql.Schema
 		schema,
 		rateLimiter,
 		&httpapi.Handlers{
-			GitHubSyncWebhook:               enterprise.ReposGithubWebhook,
-			GitLabSyncWebhook:               enterprise.ReposGitLabWebhook,
-			BitbucketServerSyncWebhook:      enterprise.ReposBitbucketServerWebhook,
-			BitbucketCloudSyncWebhook:       enterprise.ReposBitbucketCloudWebhook,
-			PermissionsGitHubWebhook:        enterprise.PermissionsGitHubWebhook,
-			BatchesGitHubWebhook:            enterprise.BatchesGitHubWebhook,
-			BatchesGitLabWebhook:            enterprise.BatchesGitLabWebhook,
-			BatchesBitbucketServerWebhook:   enterprise.BatchesBitbucketServerWebhook,
-			BatchesBitbucketCloudWebhook:    enterprise.BatchesBitbucketCloudWebhook,
-			BatchesAzureDevOpsWebhook:       enterprise.BatchesAzureDevOpsWebhook,
-			BatchesChangesFileGetHandler:    enterprise.BatchesChangesFileGetHandler,
-			BatchesChangesFileExistsHandler: enterprise.BatchesChangesFileExistsHandler,
-			BatchesChangesFileUploadHandler: enterprise.BatchesChangesFileUploadHandler,
-			SCIMHandler:                     enterprise.SCIMHandler,
-			NewCodeIntelUploadHandler:       enterprise.NewCodeIntelUploadHandler,
-			NewComputeStreamHandler:         enterprise.NewComputeStreamHandler,
-			CodeInsightsDataExportHandler:   enterprise.CodeInsightsDataExportHandler,
-			NewDotcomLicenseCheckHandler:    enterprise.NewDotcomLicenseCheckHandler,
-			NewChatCompletionsStreamHandler: enterprise.NewChatCompletionsStreamHandler,
-			NewCodeCompletionsHandler:       enterprise.NewCodeCompletionsHandler,
+			GitHubSyncWebhook:                   enterprise.ReposGithubWebhook,
+			GitLabSyncWebhook:                   enterprise.ReposGitLabWebhook,
+			BitbucketServerSyncWebhook:          enterprise.ReposBitbucketServerWebhook,
+			BitbucketCloudSyncWebhook:           enterprise.ReposBitbucketCloudWebhook,
+			PermissionsGitHubWebhook:            enterprise.PermissionsGitHubWebhook,
+			BatchesGitHubWebhook:                enterprise.BatchesGitHubWebhook,
+			BatchesGitLabWebhook:                enterprise.BatchesGitLabWebhook,
+			BatchesBitbucketServerWebhook:       enterprise.BatchesBitbucketServerWebhook,
+			BatchesBitbucketCloudWebhook:        enterprise.BatchesBitbucketCloudWebhook,
+			BatchesAzureDevOpsWebhook:           enterprise.BatchesAzureDevOpsWebhook,
+			BatchesChangesFileGetHandler:        enterprise.BatchesChangesFileGetHandler,
+			BatchesChangesFileExistsHandler:     enterprise.BatchesChangesFileExistsHandler,
+			BatchesChangesFileUploadHandler:     enterprise.BatchesChangesFileUploadHandler,
+			BatchesStepCacheGetHandler:          enterprise.BatchesStepCacheGetHandler,
+			BatchesStepCacheExistsHandler:       enterprise.BatchesStepCacheExistsHandler,
+			BatchesStepCacheUploadHandler:       enterprise.BatchesStepCacheUploadHandler,
+			SCIMHandler:                         enterprise.SCIMHandler,
+			NewCodeIntelUploadHandler:           enterprise.NewCodeIntelUploadHandler,
+			NewComputeStreamHandler:             enterprise.NewComputeStreamHandler,
+			CodeInsightsDataExportHandler:       enterprise.CodeInsightsDataExportHandler,
+			CodeInsightsDataExportPointsHandler: enterprise.CodeInsightsDataExportPointsHandler,
+			CodeInsightsDataImportPointsHandler: enterprise.CodeInsightsDataImportPointsHandler,
+			NewDotcomLicenseCheckHandler:        enterprise.NewDotcomLicenseCheckHandler,
+			NewChatCompletionsStreamHandler:     enterprise.NewChatCompletionsStreamHandler,
+			NewCodeCompletionsHandler:           enterprise.NewCodeCompletionsHandler,
 		},
 		enterprise.NewExecutorProxyHandler,
 		enterprise.NewGitHubAppSetupHandler,
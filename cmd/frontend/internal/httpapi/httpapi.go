@@ -61,6 +61,9 @@ type Handlers struct {
 	BatchesChangesFileGetHandler    http.Handler
 	BatchesChangesFileExistsHandler http.Handler
 	BatchesChangesFileUploadHandler http.Handler
+	BatchesStepCacheGetHandler      http.Handler
+	BatchesStepCacheExistsHandler   http.Handler
+	BatchesStepCacheUploadHandler   http.Handler
 
 	// SCIM
 	SCIMHandler http.Handler
@@ -72,7 +75,9 @@ type Handlers struct {
 	NewComputeStreamHandler enterprise.NewComputeStreamHandler
 
 	// Code Insights
-	CodeInsightsDataExportHandler http.Handler
+	CodeInsightsDataExportHandler       http.Handler
+	CodeInsightsDataExportPointsHandler http.Handler
+	CodeInsightsDataImportPointsHandler http.Handler
 
 	// Dotcom license check
 	NewDotcomLicenseCheckHandler enterprise.NewDotcomLicenseCheckHandler
@@ -151,6 +156,9 @@ func NewHandler(
 	m.Get(apirouter.BatchesFileGet).Handler(trace.Route(handlers.BatchesChangesFileGetHandler))
 	m.Get(apirouter.BatchesFileExists).Handler(trace.Route(handlers.BatchesChangesFileExistsHandler))
 	m.Get(apirouter.BatchesFileUpload).Handler(trace.Route(handlers.BatchesChangesFileUploadHandler))
+	m.Get(apirouter.BatchesStepCacheGet).Handler(trace.Route(handlers.BatchesStepCacheGetHandler))
+	m.Get(apirouter.BatchesStepCacheExists).Handler(trace.Route(handlers.BatchesStepCacheExistsHandler))
+	m.Get(apirouter.BatchesStepCacheUpload).Handler(trace.Route(handlers.BatchesStepCacheUploadHandler))
 	m.Get(apirouter.LSIFUpload).Handler(trace.Route(lsifDeprecationHandler))
 	m.Get(apirouter.SCIPUpload).Handler(trace.Route(handlers.NewCodeIntelUploadHandler(true)))
 	m.Get(apirouter.SCIPUploadExists).Handler(trace.Route(noopHandler))
@@ -159,6 +167,8 @@ func NewHandler(
 	m.Get(apirouter.CodeCompletions).Handler(trace.Route(handlers.NewCodeCompletionsHandler()))
 
 	m.Get(apirouter.CodeInsightsDataExport).Handler(trace.Route(handlers.CodeInsightsDataExportHandler))
+	m.Get(apirouter.CodeInsightsDataExportPoints).Handler(trace.Route(handlers.CodeInsightsDataExportPointsHandler))
+	m.Get(apirouter.CodeInsightsDataImportPoints).Handler(trace.Route(handlers.CodeInsightsDataImportPointsHandler))
 
 	if envvar.SourcegraphDotComMode() {
 		m.Path("/app/check/update").Name(updatecheck.RouteAppUpdateCheck).Handler(trace.Route(updatecheck.AppUpdateHandler(logger)))
@@ -41,7 +41,13 @@ const (
 	BatchesFileExists = "batches.file.exists"
 	BatchesFileUpload = "batches.file.upload"
 
-	CodeInsightsDataExport = "insights.data.export"
+	BatchesStepCacheGet    = "batches.stepcache.get"
+	BatchesStepCacheExists = "batches.stepcache.exists"
+	BatchesStepCacheUpload = "batches.stepcache.upload"
+
+	CodeInsightsDataExport       = "insights.data.export"
+	CodeInsightsDataExportPoints = "insights.data.export.points"
+	CodeInsightsDataImportPoints = "insights.data.import.points"
 
 	ExternalURL            = "internal.app-url"
 	SendEmail              = "internal.send-email"
@@ -77,6 +83,9 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/files/batch-changes/{spec}/{file}").Methods("GET").Name(BatchesFileGet)
 	base.Path("/files/batch-changes/{spec}/{file}").Methods("HEAD").Name(BatchesFileExists)
 	base.Path("/files/batch-changes/{spec}").Methods("POST").Name(BatchesFileUpload)
+	base.Path("/files/batch-changes-step-cache/{key}").Methods("GET").Name(BatchesStepCacheGet)
+	base.Path("/files/batch-changes-step-cache/{key}").Methods("HEAD").Name(BatchesStepCacheExists)
+	base.Path("/files/batch-changes-step-cache/{key}").Methods("PUT").Name(BatchesStepCacheUpload)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/scip/upload").Methods("POST").Name(SCIPUpload)
 	base.Path("/scip/upload").Methods("HEAD").Name(SCIPUploadExists)
@@ -86,6 +95,8 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/src-cli/versions/{rest:.*}").Methods("GET", "POST").Name(SrcCliVersionCache)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCli)
 	base.Path("/insights/export/{id}").Methods("GET").Name(CodeInsightsDataExport)
+	base.Path("/insights/export/{id}/points").Methods("GET").Name(CodeInsightsDataExportPoints)
+	base.Path("/insights/import/{id}/points").Methods("POST").Name(CodeInsightsDataImportPoints)
 	base.Path("/completions/stream").Methods("POST").Name(ChatCompletionsStream)
 	base.Path("/completions/code").Methods("POST").Name(CodeCompletions)
 
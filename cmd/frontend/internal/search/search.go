@@ -486,6 +486,26 @@ func fromCommit(commit *result.CommitMatch, repoCache map[api.RepoID]*types.Sear
 		commitEvent.RepoLastFetched = r.LastFetched
 	}
 
+	for _, sym := range commit.ChangedSymbols {
+		kind := sym.Symbol.LSPKind()
+		kindString := "UNKNOWN"
+		if kind != 0 {
+			kindString = strings.ToUpper(kind.String())
+		}
+
+		commitEvent.ChangedSymbols = append(commitEvent.ChangedSymbols, streamhttp.ChangedSymbol{
+			Symbol: streamhttp.Symbol{
+				URL:           sym.URL(commit.Repo).String(),
+				Name:          sym.Symbol.Name,
+				ContainerName: sym.Symbol.Parent,
+				Kind:          kindString,
+				Line:          int32(sym.Symbol.Line),
+			},
+			Path:   sym.Symbol.Path,
+			Change: sym.Change.String(),
+		})
+	}
+
 	return commitEvent
 }
 
@@ -222,13 +222,18 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 		size := dirSize(dir.Path("."))
 		stats.GitDirBytes += size
 		name := s.name(dir)
-		repoToSize[name] = size
+		// Sizes are only recorded by the gitserver a repo is assigned to, see
+		// isReplica.
+		if !s.isReplica(name) {
+			repoToSize[name] = size
+		}
 
 		// Record the number and disk usage used of repos that should
 		// not belong on this instance and remove up to SRC_WRONG_SHARD_DELETE_LIMIT in a single Janitor run.
+		// Replicas of repos belong on this instance as well.
 		addr := s.addrForRepo(name, gitServerAddrs)
 
-		if !s.hostnameMatch(addr) {
+		if !s.storesRepo(name, gitServerAddrs) {
 			wrongShardRepoCount++
 			wrongShardRepoSize += size
 
@@ -255,10 +260,12 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 			return false, err
 		}
 
-		err = s.DB.GitserverRepos().LogCorruption(ctx, s.name(dir), fmt.Sprintf("sourcegraph detected corrupt repo: %s", reason), s.Hostname)
-		if err != nil {
-			repoName := string(s.name(dir))
-			logger.Warn("failed to log repo corruption", log.String("repo", repoName), log.Error(err))
+		if !s.isReplica(s.name(dir)) {
+			err = s.DB.GitserverRepos().LogCorruption(ctx, s.name(dir), fmt.Sprintf("sourcegraph detected corrupt repo: %s", reason), s.Hostname)
+			if err != nil {
+				repoName := string(s.name(dir))
+				logger.Warn("failed to log repo corruption", log.String("repo", repoName), log.Error(err))
+			}
 		}
 
 		logger.Info("removing corrupt repo", log.String("repo", string(dir)), log.String("reason", reason))
@@ -335,10 +342,11 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 			}
 		}
 
-		// We believe converting a Perforce depot to a Git repository is generally a
-		// very expensive operation, therefore we do not try to re-clone/redo the
-		// conversion only because it is old or slow to do "git gc".
-		if repoType == "perforce" && reason != maybeCorrupt {
+		// We believe converting a Perforce depot or a Mercurial repository to a Git
+		// repository is generally a very expensive operation, therefore we do not
+		// try to re-clone/redo the conversion only because it is old or slow to do
+		// "git gc".
+		if (repoType == "perforce" || repoType == "mercurial") && reason != maybeCorrupt {
 			reason = ""
 		}
 
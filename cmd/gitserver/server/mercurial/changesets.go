@@ -0,0 +1,157 @@
+package mercurial
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// shortChangesetLength is the length of the abbreviated changeset IDs that
+// Mercurial displays by default.
+const shortChangesetLength = 12
+
+// IsConverted returns true if dir was converted from a Mercurial repository.
+func IsConverted(dir common.GitDir) bool {
+	_, err := os.Stat(dir.Path(changesetsFile))
+	return err == nil
+}
+
+// readChangesets calls fn with every converted changeset of dir and the commit
+// it was converted to, until fn returns false.
+func readChangesets(dir common.GitDir, fn func(changeset, commit string) bool) error {
+	f, err := os.Open(dir.Path(changesetsFile))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		changeset, commit, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		if !fn(changeset, commit) {
+			break
+		}
+	}
+	return scanner.Err()
+}
+
+// indexLineLength is the length of the lines of the changeset index, which
+// consist of a full changeset ID and commit ID separated by a space.
+const indexLineLength = 40 + 1 + 40 + 1
+
+// indexChangesets writes the lines of the changesets file of dir sorted by
+// changeset ID to the changeset index, such that changesets can be resolved by
+// a binary search rather than by reading the changesets file.
+func indexChangesets(dir common.GitDir) error {
+	var lines []string
+	err := readChangesets(dir, func(changeset, commit string) bool {
+		lines = append(lines, changeset+" "+commit+"\n")
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(lines)
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		if len(line) != indexLineLength {
+			return errors.Newf("unexpected changeset line %q", line)
+		}
+		buf.WriteString(line)
+	}
+	// The index is replaced atomically, since it is read concurrently.
+	tmp := dir.Path(changesetIndexFile + ".tmp")
+	if err := os.WriteFile(tmp, buf.Bytes(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dir.Path(changesetIndexFile))
+}
+
+// ResolveChangeset returns the commit that the changeset with the given ID was
+// converted to. The ID may be abbreviated to at least 12 characters, as long as
+// it is unambiguous.
+func ResolveChangeset(dir common.GitDir, id string) (string, bool) {
+	if len(id) < shortChangesetLength || len(id) > 40 || !isHex(id) {
+		return "", false
+	}
+
+	f, err := os.Open(dir.Path(changesetIndexFile))
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return "", false
+	}
+	n := int(fi.Size() / indexLineLength)
+
+	var readErr error
+	line := make([]byte, indexLineLength)
+	read := func(i int) (changeset, commit string) {
+		if _, err := f.ReadAt(line, int64(i)*indexLineLength); err != nil {
+			readErr = err
+			return "", ""
+		}
+		return string(line[:40]), string(line[41 : indexLineLength-1])
+	}
+
+	// The changesets that start with id are adjacent in the index, starting
+	// with the first changeset that is not less than id.
+	i := sort.Search(n, func(i int) bool {
+		changeset, _ := read(i)
+		return changeset >= id
+	})
+	if i == n || readErr != nil {
+		return "", false
+	}
+	changeset, commit := read(i)
+	if readErr != nil || !strings.HasPrefix(changeset, id) {
+		return "", false
+	}
+	if i+1 < n {
+		if next, _ := read(i + 1); readErr != nil || strings.HasPrefix(next, id) {
+			return "", false
+		}
+	}
+	return commit, true
+}
+
+// ResolveRevspec replaces a changeset ID at the start of the Git revspec with
+// the commit it was converted to, such that "<changeset>^0" resolves to the
+// commit of the changeset. Other revspecs are returned unchanged.
+func ResolveRevspec(dir common.GitDir, spec string) string {
+	end := len(spec)
+	for i, c := range spec {
+		if !isHexDigit(c) {
+			end = i
+			break
+		}
+	}
+	if commit, ok := ResolveChangeset(dir, spec[:end]); ok {
+		return commit + spec[end:]
+	}
+	return spec
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !isHexDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isHexDigit(c rune) bool {
+	return ('0' <= c && c <= '9') || ('a' <= c && c <= 'f')
+}
@@ -0,0 +1,552 @@
+// Package mercurial converts Mercurial repositories into Git repositories.
+//
+// The Mercurial repository a Git repository is converted from is kept in the
+// Git directory, together with the state of the conversion, so that updates
+// only convert the changesets pulled since the previous conversion.
+package mercurial
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// HgDir is the directory in the Git directory that contains the Mercurial
+	// repository it is converted from.
+	HgDir = "hg"
+
+	// marksFile contains the git fast-import marks of the converted
+	// changesets. The mark of a changeset is its revision number plus one.
+	marksFile = "hg-marks"
+
+	// changesetsFile maps the IDs of the converted changesets to the commits
+	// they were converted to, with one "<changeset> <commit>" line per
+	// changeset in the order of their revision numbers.
+	changesetsFile = "hg-changesets"
+
+	// changesetIndexFile contains the lines of the changesets file sorted by
+	// changeset ID.
+	changesetIndexFile = "hg-changesets-index"
+
+	// headsRefPrefix is the prefix of the references to the commits of the
+	// heads of the Mercurial repository. A Mercurial branch can have multiple
+	// heads, while a Git branch only points to one of them, so these keep the
+	// commits of the other heads reachable.
+	headsRefPrefix = "refs/hg/heads/"
+)
+
+// changesetTemplate is the hg log template of changesets. Fields are separated
+// by NUL bytes, and file names by \x01.
+const changesetTemplate = `{rev}\0{node}\0{p1.rev}\0{p2.rev}\0{branch}\0{author}\0{date|hgdate}\0` +
+	`{join(file_adds, "\x01")}\0{join(file_mods, "\x01")}\0{join(file_dels, "\x01")}\0{desc}\0`
+
+const changesetFields = 11
+
+// Env returns the environment of hg commands. HGPLAIN disables the user
+// configuration that changes the output of commands.
+func Env() []string {
+	return append(os.Environ(), "HGPLAIN=1")
+}
+
+// changeset is a Mercurial changeset, with the files it changed compared to its
+// first parent.
+type changeset struct {
+	Rev      int
+	Node     string
+	P1, P2   int
+	Branch   string
+	Author   string
+	Time     int64
+	Offset   int // seconds west of UTC
+	Added    []string
+	Modified []string
+	Removed  []string
+	Desc     string
+}
+
+// file is a file of a changeset, with its Git file mode.
+type file struct {
+	Mode    string
+	Content []byte
+}
+
+// readFilesFunc calls fn with the files at the given paths in revision rev.
+type readFilesFunc func(ctx context.Context, rev int, paths []string, fn func(path string, f file) error) error
+
+// Convert converts the changesets of the Mercurial repository in the HgDir of
+// dir that were not converted yet, and updates the tags of dir. It returns the
+// number of changesets it converted.
+//
+// Revision numbers are local to a Mercurial repository, and only stable as long
+// as changesets are only ever pulled into it, which is the case for HgDir.
+func Convert(ctx context.Context, dir common.GitDir) (int, error) {
+	hgDir := dir.Path(HgDir)
+
+	next, err := convertedChangesets(dir)
+	if err != nil {
+		return 0, err
+	}
+	changesets, err := logChangesets(ctx, hgDir, next)
+	if err != nil {
+		return 0, err
+	}
+	if len(changesets) > 0 {
+		if err := importChangesets(ctx, dir, changesets, archiveFiles(hgDir)); err != nil {
+			return 0, err
+		}
+		if err := updateHeads(ctx, dir, changesets); err != nil {
+			return 0, err
+		}
+		if err := recordChangesets(dir, changesets); err != nil {
+			return 0, err
+		}
+	}
+	// The index is rebuilt on every conversion, which also repairs it if a
+	// previous conversion failed after recording its changesets.
+	if err := indexChangesets(dir); err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	if err := updateTags(ctx, dir); err != nil {
+		return 0, err
+	}
+	return len(changesets), nil
+}
+
+// logChangesets returns the changesets of the Mercurial repository in hgDir,
+// starting at revision number start.
+func logChangesets(ctx context.Context, hgDir string, start int) ([]changeset, error) {
+	tip, err := runHg(ctx, hgDir, "log", "--rev", "tip", "--template", "{rev}")
+	if err != nil {
+		return nil, err
+	}
+	if n, err := strconv.Atoi(string(tip)); err != nil {
+		return nil, errors.Wrapf(err, "unexpected tip revision %q", tip)
+	} else if n < start {
+		return nil, nil
+	}
+
+	out, err := runHg(ctx, hgDir, "log", "--rev", fmt.Sprintf("%d:tip", start), "--template", changesetTemplate)
+	if err != nil {
+		return nil, err
+	}
+	return parseChangesets(out)
+}
+
+// parseChangesets parses the output of hg log with changesetTemplate.
+func parseChangesets(out []byte) ([]changeset, error) {
+	fields := strings.Split(string(out), "\x00")
+	// Every changeset is terminated by a separator, which leaves an empty
+	// trailing field.
+	fields = fields[:len(fields)-1]
+	if len(fields)%changesetFields != 0 {
+		return nil, errors.Newf("unexpected hg log output with %d fields", len(fields))
+	}
+
+	changesets := make([]changeset, 0, len(fields)/changesetFields)
+	for i := 0; i < len(fields); i += changesetFields {
+		f := fields[i : i+changesetFields]
+		cs := changeset{
+			Node:     f[1],
+			Branch:   f[4],
+			Author:   f[5],
+			Added:    splitFiles(f[7]),
+			Modified: splitFiles(f[8]),
+			Removed:  splitFiles(f[9]),
+			Desc:     f[10],
+		}
+		var err error
+		if cs.Rev, err = strconv.Atoi(f[0]); err != nil {
+			return nil, errors.Wrap(err, "invalid revision")
+		}
+		if cs.P1, err = strconv.Atoi(f[2]); err != nil {
+			return nil, errors.Wrap(err, "invalid first parent")
+		}
+		if cs.P2, err = strconv.Atoi(f[3]); err != nil {
+			return nil, errors.Wrap(err, "invalid second parent")
+		}
+		date := strings.Fields(f[6])
+		if len(date) != 2 {
+			return nil, errors.Newf("invalid date %q", f[6])
+		}
+		if cs.Time, err = strconv.ParseInt(date[0], 10, 64); err != nil {
+			return nil, errors.Wrap(err, "invalid date")
+		}
+		if cs.Offset, err = strconv.Atoi(date[1]); err != nil {
+			return nil, errors.Wrap(err, "invalid date offset")
+		}
+		changesets = append(changesets, cs)
+	}
+	return changesets, nil
+}
+
+func splitFiles(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\x01")
+}
+
+// importChangesets converts changesets into commits of dir with git
+// fast-import.
+func importChangesets(ctx context.Context, dir common.GitDir, changesets []changeset, readFiles readFilesFunc) error {
+	marks := dir.Path(marksFile)
+	cmd := exec.CommandContext(ctx, "git", "fast-import", "--quiet", "--force",
+		"--import-marks-if-exists="+marks, "--export-marks="+marks)
+	dir.Set(cmd)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "git fast-import")
+	}
+
+	w := bufio.NewWriter(stdin)
+	writeErr := func() error {
+		for _, cs := range changesets {
+			if err := writeCommit(ctx, w, cs, readFiles); err != nil {
+				return err
+			}
+		}
+		return w.Flush()
+	}()
+	stdin.Close()
+
+	if err := cmd.Wait(); err != nil {
+		return errors.Wrapf(err, "git fast-import failed with output %q", stderr.String())
+	}
+	return writeErr
+}
+
+// writeCommit writes the git fast-import commands that convert cs into a
+// commit.
+func writeCommit(ctx context.Context, w io.Writer, cs changeset, readFiles readFilesFunc) error {
+	ref := "refs/heads/" + gitRefName(cs.Branch)
+	if cs.P1 < 0 {
+		// Start a new history, rather than continue the history of the branch.
+		fmt.Fprintf(w, "reset %s\n\n", ref)
+	}
+	fmt.Fprintf(w, "commit %s\nmark :%d\n", ref, cs.Rev+1)
+	fmt.Fprintf(w, "committer %s %d %s\n", gitIdent(cs.Author), cs.Time, gitTimezone(cs.Offset))
+	fmt.Fprintf(w, "data %d\n%s\n", len(cs.Desc), cs.Desc)
+	if cs.P1 >= 0 {
+		fmt.Fprintf(w, "from :%d\n", cs.P1+1)
+	}
+	if cs.P2 >= 0 {
+		fmt.Fprintf(w, "merge :%d\n", cs.P2+1)
+	}
+	for _, path := range cs.Removed {
+		fmt.Fprintf(w, "D %s\n", quotePath(path))
+	}
+	if changed := append(append([]string{}, cs.Added...), cs.Modified...); len(changed) > 0 {
+		err := readFiles(ctx, cs.Rev, changed, func(path string, f file) error {
+			_, err := fmt.Fprintf(w, "M %s inline %s\ndata %d\n%s\n", f.Mode, quotePath(path), len(f.Content), f.Content)
+			return err
+		})
+		if err != nil {
+			return errors.Wrapf(err, "read files of revision %d", cs.Rev)
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// archiveFiles reads the files of a revision from the Mercurial repository in
+// hgDir by archiving them into a temporary directory, which preserves their
+// executable bits and symbolic links.
+func archiveFiles(hgDir string) readFilesFunc {
+	return func(ctx context.Context, rev int, paths []string, fn func(path string, f file) error) error {
+		tmp, err := os.MkdirTemp("", "hg-archive-")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmp)
+
+		var list bytes.Buffer
+		for _, path := range paths {
+			list.WriteString("path:" + path + "\n")
+		}
+		listFile := filepath.Join(tmp, "files")
+		if err := os.WriteFile(listFile, list.Bytes(), 0o600); err != nil {
+			return err
+		}
+		dest := filepath.Join(tmp, "archive")
+		if _, err := runHg(ctx, hgDir, "archive", "--rev", strconv.Itoa(rev), "--type", "files", "--no-decode",
+			"--config", "ui.archivemeta=false", "--include", "listfile:"+listFile, "--", dest); err != nil {
+			return err
+		}
+
+		for _, path := range paths {
+			name := filepath.Join(dest, filepath.FromSlash(path))
+			fi, err := os.Lstat(name)
+			if err != nil {
+				return err
+			}
+			var f file
+			switch {
+			case fi.Mode()&os.ModeSymlink != 0:
+				target, err := os.Readlink(name)
+				if err != nil {
+					return err
+				}
+				f = file{Mode: "120000", Content: []byte(target)}
+			case fi.Mode()&0o111 != 0:
+				f.Mode = "100755"
+			default:
+				f.Mode = "100644"
+			}
+			if f.Content == nil {
+				if f.Content, err = os.ReadFile(name); err != nil {
+					return err
+				}
+			}
+			if err := fn(path, f); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// updateHeads points the head references of dir to the commits of the
+// changesets that have no children yet, and deletes the head references of the
+// previously converted changesets that changesets are children of.
+func updateHeads(ctx context.Context, dir common.GitDir, changesets []changeset) error {
+	marks, err := readMarks(dir)
+	if err != nil {
+		return err
+	}
+	// The changesets file has a line for every revision before the first
+	// changeset.
+	first := changesets[0].Rev
+	nodes := make([]string, 0, first)
+	if first > 0 {
+		err := readChangesets(dir, func(changeset, _ string) bool {
+			nodes = append(nodes, changeset)
+			return len(nodes) < first
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	parents := map[int]bool{}
+	for _, cs := range changesets {
+		parents[cs.P1] = true
+		parents[cs.P2] = true
+	}
+
+	var updates bytes.Buffer
+	for _, cs := range changesets {
+		if parents[cs.Rev] {
+			continue
+		}
+		commit, ok := marks[cs.Rev+1]
+		if !ok {
+			return errors.Newf("no commit for changeset %s", cs.Node)
+		}
+		fmt.Fprintf(&updates, "update %s%s %s\n", headsRefPrefix, cs.Node, commit)
+	}
+	for rev := range parents {
+		if rev >= 0 && rev < len(nodes) {
+			fmt.Fprintf(&updates, "delete %s%s\n", headsRefPrefix, nodes[rev])
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "update-ref", "--stdin")
+	dir.Set(cmd)
+	cmd.Stdin = &updates
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "updating heads failed with output %q", out)
+	}
+	return nil
+}
+
+// recordChangesets appends the commits that changesets were converted to, as
+// recorded in the marks file, to the changesets file.
+func recordChangesets(dir common.GitDir, changesets []changeset) error {
+	marks, err := readMarks(dir)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	for _, cs := range changesets {
+		commit, ok := marks[cs.Rev+1]
+		if !ok {
+			return errors.Newf("no commit for changeset %s", cs.Node)
+		}
+		fmt.Fprintf(&buf, "%s %s\n", cs.Node, commit)
+	}
+
+	f, err := os.OpenFile(dir.Path(changesetsFile), os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf.Bytes()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readMarks returns the commits of the marks in the marks file of dir.
+func readMarks(dir common.GitDir) (map[int]string, error) {
+	f, err := os.Open(dir.Path(marksFile))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	marks := map[int]string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		mark, commit, ok := strings.Cut(scanner.Text(), " ")
+		if !ok || !strings.HasPrefix(mark, ":") {
+			continue
+		}
+		n, err := strconv.Atoi(mark[1:])
+		if err != nil {
+			continue
+		}
+		marks[n] = commit
+	}
+	return marks, scanner.Err()
+}
+
+// convertedChangesets returns the number of changesets that were converted
+// into commits of dir, which is also the revision number of the next changeset
+// to convert.
+func convertedChangesets(dir common.GitDir) (int, error) {
+	n := 0
+	err := readChangesets(dir, func(_, _ string) bool {
+		n++
+		return true
+	})
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	return n, err
+}
+
+// updateTags points the tags of dir to the commits of the tags of the
+// Mercurial repository.
+func updateTags(ctx context.Context, dir common.GitDir) error {
+	out, err := runHg(ctx, dir.Path(HgDir), "tags", "--template", `{tag}\0{node}\0`)
+	if err != nil {
+		return err
+	}
+	// A changeset can have multiple tags.
+	tags := map[string][]string{}
+	fields := strings.Split(string(out), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		if fields[i] == "tip" {
+			continue
+		}
+		tags[fields[i+1]] = append(tags[fields[i+1]], fields[i])
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	var updates bytes.Buffer
+	err = readChangesets(dir, func(changeset, commit string) bool {
+		for _, tag := range tags[changeset] {
+			fmt.Fprintf(&updates, "update refs/tags/%s %s\n", gitRefName(tag), commit)
+		}
+		return true
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "update-ref", "--stdin")
+	dir.Set(cmd)
+	cmd.Stdin = &updates
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "updating tags failed with output %q", out)
+	}
+	return nil
+}
+
+// runHg runs hg with the given arguments on the Mercurial repository in hgDir,
+// and returns its output.
+func runHg(ctx context.Context, hgDir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "hg", append([]string{"--repository", hgDir, "--noninteractive"}, args...)...)
+	cmd.Env = Env()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrapf(err, "hg %s failed with output %q", args[0], stderr.String())
+	}
+	return out, nil
+}
+
+// gitIdent converts a Mercurial user, which is free-form but usually of the form
+// "Name <email>", into a Git identity.
+func gitIdent(user string) string {
+	name, email := user, ""
+	if i := strings.LastIndex(user, "<"); i >= 0 && strings.HasSuffix(user, ">") {
+		name, email = user[:i], user[i+1:len(user)-1]
+	} else if strings.Contains(user, "@") && !strings.Contains(user, " ") {
+		name, email = "", user
+	}
+	name = strings.TrimSpace(strings.NewReplacer("<", "", ">", "", "\n", " ").Replace(name))
+	email = strings.NewReplacer("<", "", ">", "", "\n", "").Replace(email)
+	if name == "" {
+		name = email
+	}
+	return fmt.Sprintf("%s <%s>", name, email)
+}
+
+// gitTimezone converts a Mercurial timezone offset in seconds west of UTC into a
+// Git timezone.
+func gitTimezone(offset int) string {
+	sign := '+'
+	if offset > 0 {
+		sign = '-'
+	} else {
+		offset = -offset
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, offset/3600, offset%3600/60)
+}
+
+var refNameReplacer = strings.NewReplacer(
+	" ", "_", "~", "_", "^", "_", ":", "_", "?", "_", "*", "_", "[", "_", "\\", "_", "..", "_", "@{", "_",
+)
+
+// gitRefName converts a Mercurial branch or tag name into a valid Git reference
+// name. The default branch of Mercurial is converted into master.
+func gitRefName(name string) string {
+	if name == "default" {
+		return "master"
+	}
+	name = strings.Trim(refNameReplacer.Replace(name), "/.")
+	name = strings.TrimSuffix(name, ".lock")
+	if name == "" {
+		return "_"
+	}
+	return name
+}
+
+// quotePath quotes a path for git fast-import if necessary.
+func quotePath(path string) string {
+	if !strings.HasPrefix(path, `"`) && !strings.Contains(path, "\n") {
+		return path
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(path) + `"`
+}
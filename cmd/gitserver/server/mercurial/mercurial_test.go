@@ -0,0 +1,227 @@
+package mercurial
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+)
+
+func TestParseChangesets(t *testing.T) {
+	out := "0\x00aaaa\x00-1\x00-1\x00default\x00Alice <alice@example.com>\x001686218400 -7200\x00a.txt\x01b.txt\x00\x00\x00initial\x00" +
+		"1\x00bbbb\x000\x00-1\x00stable\x00bob\x001686218460 0\x00\x00a.txt\x00b.txt\x00fix\nmultiple lines\x00"
+	got, err := parseChangesets([]byte(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []changeset{
+		{Rev: 0, Node: "aaaa", P1: -1, P2: -1, Branch: "default", Author: "Alice <alice@example.com>", Time: 1686218400, Offset: -7200, Added: []string{"a.txt", "b.txt"}, Desc: "initial"},
+		{Rev: 1, Node: "bbbb", P1: 0, P2: -1, Branch: "stable", Author: "bob", Time: 1686218460, Modified: []string{"a.txt"}, Removed: []string{"b.txt"}, Desc: "fix\nmultiple lines"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected changesets (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseChangesets([]byte("0\x00aaaa\x00")); err == nil {
+		t.Fatal("expected an error for truncated output")
+	}
+}
+
+func TestImportChangesets(t *testing.T) {
+	ctx := context.Background()
+	dir := common.GitDir(t.TempDir())
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		dir.Set(cmd)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s failed: %s\n%s", args[0], err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	git("init", "--bare", ".")
+
+	contents := map[int]map[string]file{
+		0: {"README": {Mode: "100644", Content: []byte("hello\n")}, "run.sh": {Mode: "100755", Content: []byte("#!/bin/sh\n")}},
+		1: {"README": {Mode: "100644", Content: []byte("hello world\n")}},
+		2: {"feature.txt": {Mode: "100644", Content: []byte("feature\n")}},
+		3: {"feature.txt": {Mode: "100644", Content: []byte("feature\n")}},
+		4: {"README": {Mode: "100644", Content: []byte("hello again\n")}},
+	}
+	readFiles := func(_ context.Context, rev int, paths []string, fn func(string, file) error) error {
+		for _, path := range paths {
+			if err := fn(path, contents[rev][path]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	node := func(rev int) string { return strings.Repeat(string(rune('a'+rev)), 40) }
+	convert := func(changesets ...changeset) {
+		t.Helper()
+		if err := importChangesets(ctx, dir, changesets, readFiles); err != nil {
+			t.Fatal(err)
+		}
+		if err := updateHeads(ctx, dir, changesets); err != nil {
+			t.Fatal(err)
+		}
+		if err := recordChangesets(dir, changesets); err != nil {
+			t.Fatal(err)
+		}
+		if err := indexChangesets(dir); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	convert(
+		changeset{Rev: 0, Node: node(0), P1: -1, P2: -1, Branch: "default", Author: "Alice <alice@example.com>", Time: 1686218400, Offset: -7200, Added: []string{"README", "run.sh"}, Desc: "initial"},
+		changeset{Rev: 1, Node: node(1), P1: 0, P2: -1, Branch: "default", Author: "Alice <alice@example.com>", Time: 1686218460, Modified: []string{"README"}, Removed: []string{"run.sh"}, Desc: "update"},
+		changeset{Rev: 2, Node: node(2), P1: 0, P2: -1, Branch: "feature branch", Author: "bob", Time: 1686218520, Added: []string{"feature.txt"}, Desc: "feature"},
+	)
+	if n, err := convertedChangesets(dir); err != nil || n != 3 {
+		t.Fatalf("expected 3 converted changesets, got %d: %v", n, err)
+	}
+
+	// Later conversions refer to the marks of earlier conversions.
+	convert(changeset{Rev: 3, Node: node(3), P1: 1, P2: 2, Branch: "default", Author: "Alice <alice@example.com>", Time: 1686218580, Added: []string{"feature.txt"}, Desc: "merge"})
+
+	if got, want := git("log", "--format=%s|%an <%ae>|%ad", "--date=raw", "master"), strings.Join([]string{
+		"merge|Alice <alice@example.com>|1686218580 +0000",
+		"feature|bob <>|1686218520 +0000",
+		"update|Alice <alice@example.com>|1686218460 +0000",
+		"initial|Alice <alice@example.com>|1686218400 +0200",
+	}, "\n"); got != want {
+		t.Fatalf("unexpected log:\n%s", got)
+	}
+	if got := git("ls-tree", "-r", "--format=%(objectmode) %(path)", "master"); got != "100644 README\n100644 feature.txt" {
+		t.Fatalf("unexpected tree:\n%s", got)
+	}
+	if got := git("ls-tree", "-r", "--format=%(objectmode) %(path)", "master~1~1"); got != "100644 README\n100755 run.sh" {
+		t.Fatalf("unexpected tree of initial commit:\n%s", got)
+	}
+	if got := git("rev-parse", "feature_branch"); got != git("rev-parse", "master^2") {
+		t.Fatalf("unexpected feature branch %s", got)
+	}
+
+	for spec, want := range map[string]string{
+		node(2):                    git("rev-parse", "master^2"),
+		node(3)[:12] + "^0":        git("rev-parse", "master") + "^0",
+		node(3)[:11]:               node(3)[:11],
+		"HEAD~1":                   "HEAD~1",
+		strings.Repeat("f", 40):    strings.Repeat("f", 40),
+		git("rev-parse", "master"): git("rev-parse", "master"),
+	} {
+		if got := ResolveRevspec(dir, spec); got != want {
+			t.Errorf("ResolveRevspec(%q) = %q, want %q", spec, got, want)
+		}
+	}
+	if got, want := git("for-each-ref", "--format=%(refname) %(objectname)", "refs/hg/heads/"),
+		"refs/hg/heads/"+node(3)+" "+git("rev-parse", "master"); got != want {
+		t.Fatalf("unexpected heads:\n%s", got)
+	}
+
+	// A second head of the default branch moves master, but the commit of
+	// the first head stays reachable.
+	merge := git("rev-parse", "master")
+	convert(changeset{Rev: 4, Node: node(4), P1: 1, P2: -1, Branch: "default", Author: "Alice <alice@example.com>", Time: 1686218640, Modified: []string{"README"}, Desc: "second head"})
+	git("gc", "--quiet", "--prune=now")
+
+	if got := git("log", "--format=%s", "-1", "master"); got != "second head" {
+		t.Fatalf("unexpected master %q", got)
+	}
+	if got, want := git("for-each-ref", "--format=%(refname) %(objectname)", "refs/hg/heads/"), strings.Join([]string{
+		"refs/hg/heads/" + node(3) + " " + merge,
+		"refs/hg/heads/" + node(4) + " " + git("rev-parse", "master"),
+	}, "\n"); got != want {
+		t.Fatalf("unexpected heads:\n%s", got)
+	}
+	if got := ResolveRevspec(dir, node(3)); got != merge {
+		t.Fatalf("ResolveRevspec(%q) = %q, want %q", node(3), got, merge)
+	}
+	if got := git("cat-file", "-t", merge); got != "commit" {
+		t.Fatalf("commit of first head was pruned: %s", got)
+	}
+}
+
+func TestResolveChangeset(t *testing.T) {
+	dir := common.GitDir(t.TempDir())
+	changesets := []string{
+		"aaaaaaaaaaaa" + strings.Repeat("0", 28),
+		"aaaaaaaaaaab" + strings.Repeat("0", 28),
+		"aaaaaaaaaaab" + strings.Repeat("1", 28),
+		"ffffffffffff" + strings.Repeat("0", 28),
+	}
+	var lines string
+	for i, changeset := range changesets {
+		lines += changeset + " " + strings.Repeat(string(rune('0'+i)), 40) + "\n"
+	}
+	if err := os.WriteFile(dir.Path(changesetsFile), []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := indexChangesets(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	for id, want := range map[string]string{
+		changesets[0]:      strings.Repeat("0", 40),
+		changesets[0][:12]: strings.Repeat("0", 40),
+		changesets[1][:13]: strings.Repeat("1", 40),
+		changesets[2][:13]: strings.Repeat("2", 40),
+		changesets[3][:12]: strings.Repeat("3", 40),
+		changesets[1][:12]: "",
+		"bbbbbbbbbbbb":     "",
+		"000000000000":     "",
+		changesets[0][:11]: "",
+		"AAAAAAAAAAAA":     "",
+	} {
+		got, ok := ResolveChangeset(dir, id)
+		if got != want || ok != (want != "") {
+			t.Errorf("ResolveChangeset(%q) = %q, %t, want %q", id, got, ok, want)
+		}
+	}
+}
+
+func TestGitIdent(t *testing.T) {
+	for user, want := range map[string]string{
+		"Alice <alice@example.com>": "Alice <alice@example.com>",
+		"alice@example.com":         "alice@example.com <alice@example.com>",
+		"Alice":                     "Alice <>",
+		"<alice@example.com>":       "alice@example.com <alice@example.com>",
+		"Alice <alice":              "Alice alice <>",
+	} {
+		if got := gitIdent(user); got != want {
+			t.Errorf("gitIdent(%q) = %q, want %q", user, got, want)
+		}
+	}
+}
+
+func TestGitTimezone(t *testing.T) {
+	for offset, want := range map[int]string{0: "+0000", -7200: "+0200", 18000: "-0500", -19800: "+0530"} {
+		if got := gitTimezone(offset); got != want {
+			t.Errorf("gitTimezone(%d) = %q, want %q", offset, got, want)
+		}
+	}
+}
+
+func TestGitRefName(t *testing.T) {
+	for name, want := range map[string]string{
+		"default":        "master",
+		"stable":         "stable",
+		"feature branch": "feature_branch",
+		"release/1.0":    "release/1.0",
+		"a..b":           "a_b",
+		"fix:bug?":       "fix_bug_",
+		".hidden.lock":   "hidden",
+		"/":              "_",
+	} {
+		if got := gitRefName(name); got != want {
+			t.Errorf("gitRefName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// promisorRemote is the remote partial clones fetch their missing blobs from.
+// Its URL is never written to the repository config, since it may contain
+// credentials. It is passed to the git commands that need it through the
+// environment instead, see promisorRemoteEnv.
+const promisorRemote = "origin"
+
+const defaultPartialCloneBlobSizeLimit = "1m"
+
+var partialClones = conf.Cached(func() map[string]string {
+	return buildPartialCloneFilters(conf.ExperimentalFeatures().GitServerPartialClones)
+})
+
+// buildPartialCloneFilters maps the Git clone URL domain/path of repositories
+// to the object filter of their partial clone.
+func buildPartialCloneFilters(c []*schema.PartialCloneMapping) map[string]string {
+	filters := make(map[string]string, len(c))
+	for _, mapping := range c {
+		limit := mapping.BlobSizeLimit
+		if limit == "" {
+			limit = defaultPartialCloneBlobSizeLimit
+		}
+		filters[mapping.DomainPath] = "blob:limit=" + limit
+	}
+	return filters
+}
+
+// partialCloneFilter returns the object filter to clone the repository at
+// remoteURL with, or an empty string if it is not configured for a partial
+// clone.
+func partialCloneFilter(remoteURL *vcs.URL) string {
+	return partialClones()[path.Join(remoteURL.Host, remoteURL.Path)]
+}
+
+// setupPartialClone configures the freshly initialized repository at dir to
+// be a partial clone of the promisor remote, using the given object filter.
+func setupPartialClone(dir common.GitDir, filter string) error {
+	for _, kv := range [][2]string{
+		// Extensions are only honored by version 1 repositories.
+		{"core.repositoryformatversion", "1"},
+		{"extensions.partialClone", promisorRemote},
+		{"remote." + promisorRemote + ".promisor", "true"},
+		{"remote." + promisorRemote + ".partialclonefilter", filter},
+	} {
+		if err := gitConfigSet(dir, kv[0], kv[1]); err != nil {
+			return errors.Wrapf(err, "partial clone setup failed")
+		}
+	}
+	return nil
+}
+
+// isPartialClone returns true if the repository at dir is a partial clone. It
+// reads the config file directly rather than running git, since it is called
+// for every exec request.
+func isPartialClone(dir common.GitDir) bool {
+	f, err := os.Open(dir.Path("config"))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	var section string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			section = strings.ToLower(strings.Trim(line, "[]"))
+			continue
+		}
+		if section != "extensions" {
+			continue
+		}
+		key, value, _ := strings.Cut(line, "=")
+		if strings.EqualFold(strings.TrimSpace(key), "partialclone") && strings.TrimSpace(value) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// partialFetchCommand returns the command that fetches the refs of a partial
+// clone from the promisor remote. If filter is empty, the filter the
+// repository was cloned with is used.
+func partialFetchCommand(ctx context.Context, remoteURL *vcs.URL, filter string) *exec.Cmd {
+	args := []string{"fetch", "--progress", "--prune"}
+	if filter != "" {
+		args = append(args, "--filter="+filter)
+	}
+	args = append(args, promisorRemote)
+	cmd := exec.CommandContext(ctx, "git", append(args, fetchRefspecs...)...)
+	cmd.Env = append(os.Environ(), promisorRemoteEnv(remoteURL)...)
+	return cmd
+}
+
+// promisorRemoteEnv returns the environment that configures the URL of the
+// promisor remote for a git command.
+func promisorRemoteEnv(remoteURL *vcs.URL) []string {
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=remote." + promisorRemote + ".url",
+		"GIT_CONFIG_VALUE_0=" + remoteURL.String(),
+	}
+}
+
+// lazyFetchEnv returns the environment a git command reading the repository
+// at dir needs to fetch missing blobs from the code host, or nil if the
+// repository is not a partial clone.
+func (s *Server) lazyFetchEnv(ctx context.Context, repo api.RepoName, dir common.GitDir) ([]string, error) {
+	if !isPartialClone(dir) {
+		return nil, nil
+	}
+	remoteURL, err := s.getRemoteURL(ctx, repo)
+	if err != nil {
+		return nil, errors.Wrap(err, "get remote URL for lazy fetch")
+	}
+	// Lazy fetches are run by git as child processes, which inherit the
+	// environment but not the arguments of the command.
+	cmd := exec.Command("git")
+	configureRemoteGitCommand(cmd, tlsExternal())
+	return append(cmd.Env, promisorRemoteEnv(remoteURL)...), nil
+}
+
+// promisorPackSizes returns the sizes of the packfiles of the repository at
+// dir that were fetched from the promisor remote.
+func promisorPackSizes(dir common.GitDir) map[string]int64 {
+	promisors, _ := filepath.Glob(dir.Path("objects", "pack", "*.promisor"))
+	sizes := make(map[string]int64, len(promisors))
+	for _, promisor := range promisors {
+		pack := strings.TrimSuffix(promisor, ".promisor") + ".pack"
+		if fi, err := os.Stat(pack); err == nil {
+			sizes[pack] = fi.Size()
+		}
+	}
+	return sizes
+}
+
+// startLazyFetchMeasurement snapshots the promisor packfiles of a partial
+// clone before running cmd, and returns a function that reports the bytes
+// fetched while it ran. Packfiles written concurrently by other commands or
+// by a fetch of the repository are attributed to cmd as well.
+func startLazyFetchMeasurement(dir common.GitDir, cmd string) func() {
+	before := promisorPackSizes(dir)
+	return func() {
+		var fetched int64
+		for pack, size := range promisorPackSizes(dir) {
+			if _, ok := before[pack]; !ok {
+				fetched += size
+			}
+		}
+		if fetched > 0 {
+			lazyFetchCounter.WithLabelValues(cmd).Inc()
+			lazyFetchBytes.WithLabelValues(cmd).Add(float64(fetched))
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestBuildPartialCloneFilters(t *testing.T) {
+	got := buildPartialCloneFilters([]*schema.PartialCloneMapping{
+		{DomainPath: "github.com/foo/default"},
+		{DomainPath: "github.com/foo/blobless", BlobSizeLimit: "0"},
+		{DomainPath: "github.com/foo/limited", BlobSizeLimit: "10m"},
+	})
+	want := map[string]string{
+		"github.com/foo/default":  "blob:limit=1m",
+		"github.com/foo/blobless": "blob:limit=0",
+		"github.com/foo/limited":  "blob:limit=10m",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected filters (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsPartialClone(t *testing.T) {
+	for name, config := range map[string]string{
+		"full clone":    "[core]\n\trepositoryformatversion = 0\n\tbare = true\n",
+		"partial clone": "[core]\n\trepositoryformatversion = 1\n[extensions]\n\tpartialClone = origin\n",
+		"other section": "[remote \"origin\"]\n\tpartialclone = origin\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "config"), []byte(config), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := isPartialClone(common.GitDir(dir)), name == "partial clone"; got != want {
+				t.Fatalf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPartialClone(t *testing.T) {
+	ctx := context.Background()
+
+	remote := t.TempDir()
+	runRemote := func(name string, arg ...string) string {
+		return runCmd(t, remote, name, arg...)
+	}
+	runRemote("git", "init", ".")
+	runRemote("git", "config", "uploadpack.allowFilter", "true")
+	runRemote("git", "config", "uploadpack.allowAnySHA1InWant", "true")
+	runRemote("sh", "-c", "echo hello world > hello.txt && head -c 4096 /dev/zero | tr '\\0' x > big.txt")
+	runRemote("git", "add", "big.txt")
+	head := strings.TrimSpace(addCommitToRepo(runRemote))
+
+	remoteURL, err := vcs.ParseURL("file://" + remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldCustomGitFetch, oldPartialClones := customGitFetch, partialClones
+	t.Cleanup(func() { customGitFetch, partialClones = oldCustomGitFetch, oldPartialClones })
+	customGitFetch = func() map[string][]string { return nil }
+	partialClones = func() map[string]string {
+		return buildPartialCloneFilters([]*schema.PartialCloneMapping{
+			{DomainPath: path.Join(remoteURL.Host, remoteURL.Path), BlobSizeLimit: "1k"},
+		})
+	}
+
+	tmpPath := filepath.Join(t.TempDir(), ".git")
+	cmd, err := NewGitRepoSyncer(wrexec.NewNoOpRecordingCommandFactory()).CloneCommand(ctx, remoteURL, tmpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("clone failed: %s\n%s", err, out)
+	}
+	dir := common.GitDir(tmpPath)
+	if !isPartialClone(dir) {
+		t.Fatal("expected a partial clone")
+	}
+
+	catFile := func(env ...string) (string, error) {
+		cmd := exec.Command("git", "cat-file", "-p", head+":big.txt")
+		dir.Set(cmd)
+		cmd.Env = append(cmd.Env, env...)
+		out, err := cmd.Output()
+		return string(out), err
+	}
+
+	// The large blob is not part of the clone and can not be fetched without
+	// the URL of the promisor remote.
+	if _, err := catFile(); err == nil {
+		t.Fatal("expected reading the large blob to fail without the promisor remote")
+	}
+
+	done := startLazyFetchMeasurement(dir, "cat-file")
+	out, err := catFile(promisorRemoteEnv(remoteURL)...)
+	if err != nil {
+		t.Fatalf("lazy fetch failed: %s", err)
+	}
+	done()
+	if out != strings.Repeat("x", 4096) {
+		t.Fatalf("unexpected blob content %q", out)
+	}
+	if got := testutil.ToFloat64(lazyFetchBytes.WithLabelValues("cat-file")); got <= 0 {
+		t.Fatalf("expected lazily fetched bytes to be reported, got %v", got)
+	}
+
+	// Updates of a partial clone fetch from the promisor remote as well.
+	runRemote("sh", "-c", "echo hello again > hello.txt")
+	head = strings.TrimSpace(addCommitToRepo(runRemote))
+	if _, err := NewGitRepoSyncer(wrexec.NewNoOpRecordingCommandFactory()).Fetch(ctx, remoteURL, dir, ""); err != nil {
+		t.Fatalf("fetch failed: %s", err)
+	}
+	if out, err := catFile(); err != nil || out != strings.Repeat("x", 4096) {
+		t.Fatalf("unexpected blob content after fetch %q: %v", out, err)
+	}
+}
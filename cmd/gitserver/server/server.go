@@ -38,6 +38,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/accesslog"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/mercurial"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -61,6 +62,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/internal/wrexec"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -552,6 +554,32 @@ func (s *Server) addrForRepo(repoName api.RepoName, gitServerAddrs gitserver.Git
 	return gitServerAddrs.AddrForRepo(filepath.Base(os.Args[0]), repoName)
 }
 
+// storesRepo returns true if the repo is stored on this gitserver, either
+// because it is assigned to it or because this gitserver is one of its
+// replicas.
+func (s *Server) storesRepo(repoName api.RepoName, gitServerAddrs gitserver.GitserverAddresses) bool {
+	for _, addr := range gitServerAddrs.AddrsForRepo(filepath.Base(os.Args[0]), repoName) {
+		if s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReplica returns true if this gitserver stores the repo as a replica of the
+// gitserver it is assigned to. The gitserver_repos table tracks the state of a
+// repo on its assigned gitserver only, so replicas must not write to it:
+// otherwise the shard, clone status and size of the repo would flap between
+// the gitservers that store it.
+func (s *Server) isReplica(repoName api.RepoName) bool {
+	gitServerAddrs := gitserver.NewGitserverAddressesFromConf(conf.Get())
+	if gitServerAddrs.ReplicationFactor < 2 || len(gitServerAddrs.Addresses) == 0 {
+		return false
+	}
+	repoName = api.UndeletedRepoName(repoName)
+	return !s.hostnameMatch(s.addrForRepo(repoName, gitServerAddrs)) && s.storesRepo(repoName, gitServerAddrs)
+}
+
 // StartClonePipeline clones repos asynchronously. It creates a producer-consumer
 // pipeline.
 func (s *Server) StartClonePipeline(ctx context.Context) {
@@ -1359,6 +1387,15 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, onMat
 		onMatch(match)
 	}
 
+	// Diff searches over partial clones need the missing blobs of the diffs.
+	lazyFetchEnv, err := s.lazyFetchEnv(ctx, args.Repo, dir)
+	if err != nil {
+		s.Logger.Warn("failed to enable lazy fetching of missing blobs", log.String("repo", string(args.Repo)), log.Error(err))
+	}
+	if lazyFetchEnv != nil {
+		defer startLazyFetchMeasurement(dir, "search")()
+	}
+
 	searcher := &search.CommitSearcher{
 		Logger:               s.Logger,
 		RepoName:             args.Repo,
@@ -1367,6 +1404,7 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, onMat
 		Query:                mt,
 		IncludeDiff:          args.IncludeDiff,
 		IncludeModifiedFiles: args.IncludeModifiedFiles || hasDiffModifiesFile,
+		Env:                  lazyFetchEnv,
 	}
 
 	return hitLimit.Load(), searcher.Search(ctx, limitedOnMatch)
@@ -1709,6 +1747,20 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 	}
 
 	dir := s.dir(req.Repo)
+
+	// Changeset IDs of Mercurial repositories resolve to the commits the
+	// changesets were converted to.
+	if mercurial.IsConverted(dir) {
+		req.EnsureRevision = mercurial.ResolveRevspec(dir, req.EnsureRevision)
+		if len(req.Args) > 0 && req.Args[0] == "rev-parse" {
+			args := make([]string, len(req.Args))
+			for i, arg := range req.Args {
+				args[i] = mercurial.ResolveRevspec(dir, arg)
+			}
+			req.Args = args
+		}
+	}
+
 	if s.ensureRevision(ctx, req.Repo, req.EnsureRevision, dir) {
 		ensureRevisionStatus = "fetched"
 	}
@@ -1733,6 +1785,12 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 		}
 	}
 
+	lazyFetchEnv, err := s.lazyFetchEnv(ctx, req.Repo, dir)
+	if err != nil {
+		// Commands that do not need missing blobs still succeed.
+		logger.Warn("failed to enable lazy fetching of missing blobs", log.Error(err))
+	}
+
 	var stderrBuf bytes.Buffer
 	stdoutW := &writeCounter{w: w}
 	stderrW := &writeCounter{w: &limitWriter{W: &stderrBuf, N: 1024}}
@@ -1744,6 +1802,11 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 	cmd.Unwrap().Stderr = stderrW
 	cmd.Unwrap().Stdin = bytes.NewReader(req.Stdin)
 
+	if lazyFetchEnv != nil {
+		cmd.Unwrap().Env = append(cmd.Unwrap().Env, lazyFetchEnv...)
+		defer startLazyFetchMeasurement(dir, req.Args[0])()
+	}
+
 	exitStatus, execErr = runCommand(ctx, cmd)
 
 	status = strconv.Itoa(exitStatus)
@@ -1983,6 +2046,10 @@ func (s *Server) p4Exec(ctx context.Context, logger log.Logger, req *protocol.P4
 }
 
 func (s *Server) setLastFetched(ctx context.Context, name api.RepoName) error {
+	if s.isReplica(name) {
+		return nil
+	}
+
 	dir := s.dir(name)
 
 	lastFetched, err := repoLastFetched(dir)
@@ -2004,6 +2071,10 @@ func (s *Server) setLastFetched(ctx context.Context, name api.RepoName) error {
 
 // setLastErrorNonFatal will set the last_error column for the repo in the gitserver table.
 func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, err error) {
+	if s.isReplica(name) {
+		return
+	}
+
 	var errString string
 	if err != nil {
 		errString = err.Error()
@@ -2015,12 +2086,18 @@ func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, er
 }
 
 func (s *Server) setLastOutput(ctx context.Context, name api.RepoName, output string) {
+	if s.isReplica(name) {
+		return
+	}
 	if err := s.DB.GitserverRepos().SetLastOutput(ctx, name, output); err != nil {
 		s.Logger.Warn("Setting last output in DB", log.Error(err))
 	}
 }
 
 func (s *Server) setCloneStatus(ctx context.Context, name api.RepoName, status types.CloneStatus) (err error) {
+	if s.isReplica(name) {
+		return nil
+	}
 	return s.DB.GitserverRepos().SetCloneStatus(ctx, name, status, s.Hostname)
 }
 
@@ -2033,11 +2110,14 @@ func (s *Server) setCloneStatusNonFatal(ctx context.Context, name api.RepoName,
 
 // setRepoSize calculates the size of the repo and stores it in the database.
 func (s *Server) setRepoSize(ctx context.Context, name api.RepoName) error {
+	if s.isReplica(name) {
+		return nil
+	}
 	return s.DB.GitserverRepos().SetRepoSize(ctx, name, dirSize(s.dir(name).Path(".")), s.Hostname)
 }
 
 func (s *Server) logIfCorrupt(ctx context.Context, repo api.RepoName, dir common.GitDir, stderr string) {
-	if checkMaybeCorruptRepo(s.Logger, repo, dir, stderr) {
+	if checkMaybeCorruptRepo(s.Logger, repo, dir, stderr) && !s.isReplica(repo) {
 		reason := stderr
 		if err := s.DB.GitserverRepos().LogCorruption(ctx, repo, reason, s.Hostname); err != nil {
 			s.Logger.Warn("failed to log repo corruption", log.String("repo", string(repo)), log.Error(err))
@@ -2203,11 +2283,19 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir common.GitDir, syncer VCSSyncer, lock *RepositoryLock, remoteURL *vcs.URL, opts *cloneOptions) (err error) {
 	logger := s.Logger.Scoped("doClone", "").With(log.String("repo", string(repo)))
 
+	// Webhook events are only enqueued for clones that were attempted, not if
+	// waiting for the rate limiter is canceled or the repo already exists.
+	attempted := false
+
 	defer lock.Release()
 	defer func() {
 		if err != nil {
 			repoCloneFailedCounter.Inc()
 		}
+		if attempted {
+			// Use a background context to ensure we still enqueue the event even if we time out
+			s.enqueueCloneWebhookEvent(context.Background(), logger, repo, err)
+		}
 	}()
 	if err := s.rpsLimiter.Wait(ctx); err != nil {
 		return err
@@ -2228,6 +2316,7 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir common.GitD
 			}
 		}
 	}
+	attempted = true
 
 	tmpPath, err := s.tempDir("clone-")
 	if err != nil {
@@ -2340,6 +2429,48 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir common.GitD
 	return nil
 }
 
+// enqueueCloneWebhookEvent enqueues a repo:cloned outbound webhook event, or a
+// repo:clone_failed event if cloneErr is non-nil. The repo is only looked up if
+// an outbound webhook is subscribed to the event type, since the event is
+// produced for every clone.
+func (s *Server) enqueueCloneWebhookEvent(ctx context.Context, logger log.Logger, repo api.RepoName, cloneErr error) {
+	// The event is only produced by the gitserver the repo is assigned to,
+	// such that subscribers are notified once per clone.
+	if s.isReplica(repo) {
+		return
+	}
+
+	eventType := events.RepoCloned
+	if cloneErr != nil {
+		eventType = events.RepoCloneFailed
+	}
+
+	subscribed, err := s.DB.OutboundWebhooks(nil).Count(ctx, database.OutboundWebhookCountOpts{
+		EventTypes: []database.FilterEventType{{EventType: eventType}},
+	})
+	if err != nil {
+		logger.Warn("failed to count outbound webhooks for clone webhook event", log.Error(err))
+		return
+	}
+	if subscribed == 0 {
+		return
+	}
+
+	// We need an internal actor in case the repo is private.
+	r, err := s.DB.Repos().GetByName(actor.WithInternalActor(ctx), repo)
+	if err != nil || r == nil {
+		logger.Warn("failed to get repo for clone webhook event", log.Error(err))
+		return
+	}
+
+	payload := events.NewRepo(r.ID, r.Name)
+	if cloneErr != nil {
+		payload.Error = cloneErr.Error()
+	}
+
+	database.EnqueueOutboundWebhookEvent(ctx, logger, s.DB, eventType, payload)
+}
+
 // readCloneProgress scans the reader and saves the most recent line of output
 // as the lock status.
 func readCloneProgress(db database.DB, logger log.Logger, redactor *urlRedactor, lock *RepositoryLock, pr io.Reader, repo api.RepoName) {
@@ -26,6 +26,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/perforce"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -40,6 +42,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/internal/wrexec"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sync/semaphore"
@@ -703,6 +706,7 @@ func makeTestServer(ctx context.Context, t *testing.T, repoDir, remote string, d
 		repoStore.GetByNameFunc.SetDefaultReturn(nil, &database.RepoNotFoundErr{})
 
 		mDB.ReposFunc.SetDefaultReturn(repoStore)
+		mDB.OutboundWebhooksFunc.SetDefaultReturn(database.NewMockOutboundWebhookStore())
 
 		db = mDB
 	}
@@ -1528,6 +1532,47 @@ func TestHostnameMatch(t *testing.T) {
 	}
 }
 
+func TestIsReplica(t *testing.T) {
+	addrs := []string{"gitserver-0:3178", "gitserver-1:3178", "gitserver-2:3178"}
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				GitServerReplicationFactor: 2,
+			},
+		},
+		ServiceConnectionConfig: conftypes.ServiceConnections{
+			GitServers: addrs,
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+	repoAddrs := gitserver.GitserverAddresses{Addresses: addrs, ReplicationFactor: 2}.AddrsForRepo("test", repo)
+
+	isReplica := func(addr string) bool {
+		s := Server{
+			Logger:         logtest.Scoped(t),
+			ObservationCtx: observation.TestContextTB(t),
+			Hostname:       strings.TrimSuffix(addr, ":3178"),
+			DB:             database.NewMockDB(),
+		}
+		return s.isReplica(repo)
+	}
+
+	for _, addr := range addrs {
+		want := addr == repoAddrs[1]
+		if got := isReplica(addr); got != want {
+			t.Errorf("isReplica on %s: got %v, want %v", addr, got, want)
+		}
+	}
+
+	// Deleted repos are stored under their original name.
+	s := Server{Logger: logtest.Scoped(t), Hostname: strings.TrimSuffix(repoAddrs[1], ":3178")}
+	if !s.isReplica(api.RepoName("DELETED-1650360042.603863-" + string(repo))) {
+		t.Fatal("expected deleted repo to be a replica")
+	}
+}
+
 func TestSyncRepoState(t *testing.T) {
 	logger := logtest.Scoped(t)
 	ctx, cancel := context.WithCancel(context.Background())
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/ricochet2200/go-disk-usage/du"
 
 	"github.com/sourcegraph/log"
@@ -84,3 +85,14 @@ func (s *Server) RegisterMetrics(observationCtx *observation.Context, db dbutil.
 	// Register uniform observability via internal/observation
 	s.operations = newOperations(observationCtx)
 }
+
+var (
+	lazyFetchCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_lazy_fetch_total",
+		Help: "number of commands on partial clones that fetched missing blobs from the code host.",
+	}, []string{"cmd"})
+	lazyFetchBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_lazy_fetch_bytes_total",
+		Help: "number of bytes of missing blobs fetched from the code host by commands on partial clones.",
+	}, []string{"cmd"})
+)
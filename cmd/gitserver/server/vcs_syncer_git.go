@@ -65,6 +65,15 @@ func (s *gitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 		return nil, errors.Wrapf(&common.GitCommandError{Err: err}, "clone setup failed")
 	}
 
+	if filter := s.partialCloneFilter(ctx, remoteURL); filter != "" {
+		if err := setupPartialClone(common.GitDir(tmpPath), filter); err != nil {
+			return nil, err
+		}
+		cmd = partialFetchCommand(ctx, remoteURL, filter)
+		cmd.Dir = tmpPath
+		return cmd, nil
+	}
+
 	cmd, _ = s.fetchCommand(ctx, remoteURL)
 	cmd.Dir = tmpPath
 	return cmd, nil
@@ -72,7 +81,15 @@ func (s *gitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 
 // Fetch tries to fetch updates of a Git repository.
 func (s *gitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir common.GitDir, revspec string) ([]byte, error) {
-	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
+	var cmd *exec.Cmd
+	var configRemoteOpts bool
+	if isPartialClone(dir) {
+		// Partial clones keep fetching from the promisor remote with the
+		// filter they were cloned with, until they are recloned.
+		cmd, configRemoteOpts = partialFetchCommand(ctx, remoteURL, ""), true
+	} else {
+		cmd, configRemoteOpts = s.fetchCommand(ctx, remoteURL)
+	}
 	dir.Set(cmd)
 	if output, err := runRemoteGitCommand(ctx, s.recordingCommandFactory.Wrap(ctx, log.NoOp(), cmd), configRemoteOpts, nil); err != nil {
 		return nil, &common.GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}
@@ -93,20 +110,33 @@ func (s *gitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 	} else if useRefspecOverrides() {
 		cmd = refspecOverridesFetchCmd(ctx, remoteURL)
 	} else {
-		cmd = exec.CommandContext(ctx, "git", "fetch",
-			"--progress", "--prune", remoteURL.String(),
-			// Normal git refs
-			"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
-			// GitHub pull requests
-			"+refs/pull/*:refs/pull/*",
-			// GitLab merge requests
-			"+refs/merge-requests/*:refs/merge-requests/*",
-			// Bitbucket pull requests
-			"+refs/pull-requests/*:refs/pull-requests/*",
-			// Gerrit changesets
-			"+refs/changes/*:refs/changes/*",
-			// Possibly deprecated refs for sourcegraph zap experiment?
-			"+refs/sourcegraph/*:refs/sourcegraph/*")
+		cmd = exec.CommandContext(ctx, "git", append([]string{"fetch", "--progress", "--prune", remoteURL.String()}, fetchRefspecs...)...)
 	}
 	return cmd, configRemoteOpts
 }
+
+// partialCloneFilter returns the object filter to clone the repository at
+// remoteURL with, or an empty string for a full clone. Custom fetch commands
+// and refspec overrides take precedence over partial clones.
+func (s *gitRepoSyncer) partialCloneFilter(ctx context.Context, remoteURL *vcs.URL) string {
+	if customFetchCmd(ctx, remoteURL) != nil || useRefspecOverrides() {
+		return ""
+	}
+	return partialCloneFilter(remoteURL)
+}
+
+// fetchRefspecs are the refspecs fetched from code hosts by default.
+var fetchRefspecs = []string{
+	// Normal git refs
+	"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
+	// GitHub pull requests
+	"+refs/pull/*:refs/pull/*",
+	// GitLab merge requests
+	"+refs/merge-requests/*:refs/merge-requests/*",
+	// Bitbucket pull requests
+	"+refs/pull-requests/*:refs/pull-requests/*",
+	// Gerrit changesets
+	"+refs/changes/*:refs/changes/*",
+	// Possibly deprecated refs for sourcegraph zap experiment?
+	"+refs/sourcegraph/*:refs/sourcegraph/*",
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"os"
+	"os/exec"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/mercurial"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// MercurialSyncer is a syncer for Mercurial repositories. A Mercurial
+// repository is pulled into the Git directory and converted into the Git
+// repository incrementally, see the mercurial package.
+type MercurialSyncer struct{}
+
+func NewMercurialSyncer() *MercurialSyncer {
+	return &MercurialSyncer{}
+}
+
+func (s *MercurialSyncer) Type() string {
+	return "mercurial"
+}
+
+// IsCloneable checks to see if the Mercurial remote URL is cloneable.
+func (s *MercurialSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) error {
+	ctx, cancel := context.WithTimeout(ctx, shortGitCommandTimeout([]string{"ls-remote"}))
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "hg", "identify", "--noninteractive", "--", remoteURL.String())
+	cmd.Env = mercurial.Env()
+	out, err := runCommandCombinedOutput(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd))
+	if err != nil {
+		if ctxerr := ctx.Err(); ctxerr != nil {
+			err = ctxerr
+		}
+		if len(out) > 0 {
+			err = &common.GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(out))}
+		}
+		return err
+	}
+	return nil
+}
+
+// CloneCommand clones and converts the Mercurial repository, and returns a
+// no-op command, since the conversion can not be done with a single command.
+func (s *MercurialSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tmpPath string) (*exec.Cmd, error) {
+	if err := os.MkdirAll(tmpPath, os.ModePerm); err != nil {
+		return nil, errors.Wrapf(err, "clone failed to create tmp dir")
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = tmpPath
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(&common.GitCommandError{Err: err}, "clone setup failed")
+	}
+
+	if _, err := s.Fetch(ctx, remoteURL, common.GitDir(tmpPath), ""); err != nil {
+		return nil, err
+	}
+
+	return exec.CommandContext(ctx, "git", "--version"), nil
+}
+
+// Fetch pulls the new changesets of the Mercurial repository and converts them
+// into commits.
+func (s *MercurialSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir common.GitDir, _ string) ([]byte, error) {
+	redactor := newURLRedactor(remoteURL)
+	hgDir := dir.Path(mercurial.HgDir)
+
+	if _, err := os.Stat(hgDir); os.IsNotExist(err) {
+		cmd := exec.CommandContext(ctx, "hg", "init", "--", hgDir)
+		cmd.Env = mercurial.Env()
+		if output, err := runCommandCombinedOutput(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd)); err != nil {
+			return nil, errors.Wrapf(err, "failed to initialize Mercurial repository with output %q", string(output))
+		}
+	}
+
+	// The remote URL is passed on every pull, rather than stored in the
+	// repository, since it may contain credentials.
+	cmd := exec.CommandContext(ctx, "hg", "--repository", hgDir, "pull", "--noninteractive", "--", remoteURL.String())
+	cmd.Env = mercurial.Env()
+	output, err := runCommandCombinedOutput(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to pull with output %q", redactor.redact(string(output)))
+	}
+
+	if _, err := mercurial.Convert(ctx, dir); err != nil {
+		return nil, errors.Wrap(err, "failed to convert Mercurial repository")
+	}
+	return output, nil
+}
+
+// RemoteShowCommand returns the command to be executed for showing the Git
+// remote of a converted Mercurial repository.
+func (s *MercurialSyncer) RemoteShowCommand(ctx context.Context, _ *vcs.URL) (cmd *exec.Cmd, err error) {
+	// Remote info is encoded as in the current repository
+	return exec.CommandContext(ctx, "git", "remote", "show", "./"), nil
+}
@@ -446,6 +446,8 @@ func getVCSSyncer(ctx context.Context, opts *newVCSSyncerOpts) (server.VCSSyncer
 			FusionConfig: configureFusionClient(c),
 			P4Home:       p4Home,
 		}, nil
+	case extsvc.VariantMercurial.AsType():
+		return server.NewMercurialSyncer(), nil
 	case extsvc.TypeJVMPackages:
 		var c schema.JVMPackagesConnection
 		if _, err := extractOptions(&c); err != nil {
@@ -23,9 +23,10 @@ import (
 const maxNumSymbolResults = 500
 
 type grpcService struct {
-	searchFunc   types.SearchFunc
-	readFileFunc func(context.Context, internaltypes.RepoCommitPath) ([]byte, error)
-	ctagsBinary  string
+	searchFunc     types.SearchFunc
+	parseFilesFunc types.ParseFilesFunc
+	readFileFunc   func(context.Context, internaltypes.RepoCommitPath) ([]byte, error)
+	ctagsBinary    string
 	proto.UnimplementedSymbolsServiceServer
 	logger logger.Logger
 }
@@ -76,6 +77,7 @@ func (s *grpcService) Healthz(ctx context.Context, _ *proto.HealthzRequest) (*pr
 
 func NewHandler(
 	searchFunc types.SearchFunc,
+	parseFilesFunc types.ParseFilesFunc,
 	readFileFunc func(context.Context, internaltypes.RepoCommitPath) ([]byte, error),
 	handleStatus func(http.ResponseWriter, *http.Request),
 	ctagsBinary string,
@@ -95,10 +97,11 @@ func NewHandler(
 	// Initialize the gRPC server
 	grpcServer := defaults.NewServer(rootLogger)
 	proto.RegisterSymbolsServiceServer(grpcServer, &grpcService{
-		searchFunc:   searchFuncWrapper,
-		readFileFunc: readFileFunc,
-		ctagsBinary:  ctagsBinary,
-		logger:       rootLogger.Scoped("grpc", "grpc server implementation"),
+		searchFunc:     searchFuncWrapper,
+		parseFilesFunc: parseFilesFunc,
+		readFileFunc:   readFileFunc,
+		ctagsBinary:    ctagsBinary,
+		logger:         rootLogger.Scoped("grpc", "grpc server implementation"),
 	})
 
 	jsonLogger := rootLogger.Scoped("jsonrpc", "json server implementation")
@@ -106,6 +109,7 @@ func NewHandler(
 	// Initialize the legacy JSON API server
 	mux := http.NewServeMux()
 	mux.HandleFunc("/search", handleSearchWith(jsonLogger, searchFuncWrapper))
+	mux.HandleFunc("/parse-files", handleParseFilesWith(jsonLogger, parseFilesFunc))
 	mux.HandleFunc("/healthz", handleHealthCheck(jsonLogger))
 	mux.HandleFunc("/list-languages", handleListLanguages(ctagsBinary))
 
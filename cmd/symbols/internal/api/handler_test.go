@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/http/httptest"
+	"sort"
 	"testing"
 	"time"
 
@@ -70,7 +71,7 @@ func TestHandler(t *testing.T) {
 	symbolParser := parser.NewParser(&observation.TestContext, parserPool, fetcher.NewRepositoryFetcher(&observation.TestContext, gitserverClient, 1000, 1_000_000), 0, 10)
 	databaseWriter := writer.NewDatabaseWriter(observation.TestContextTB(t), tmpDir, gitserverClient, symbolParser, semaphore.NewWeighted(1))
 	cachedDatabaseWriter := writer.NewCachedDatabaseWriter(databaseWriter, cache)
-	handler := NewHandler(MakeSqliteSearchFunc(observation.TestContextTB(t), cachedDatabaseWriter, database.NewMockDB()), gitserverClient.ReadFile, nil, "")
+	handler := NewHandler(MakeSqliteSearchFunc(observation.TestContextTB(t), cachedDatabaseWriter, database.NewMockDB()), MakeParseFilesFunc(symbolParser), gitserverClient.ReadFile, nil, "")
 
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -149,6 +150,17 @@ func TestHandler(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("parse files", func(t *testing.T) {
+		resultSymbols, err := client.ParseFiles(context.Background(), search.ParseFilesParameters{Paths: []string{"a.js"}})
+		if err != nil {
+			t.Fatalf("unexpected error parsing files: %s", err)
+		}
+		sort.Slice(resultSymbols, func(i, j int) bool { return resultSymbols[i].Line < resultSymbols[j].Line })
+		if diff := cmp.Diff([]result.Symbol{x, y}, []result.Symbol(resultSymbols)); diff != "" {
+			t.Errorf("unexpected parse result. diff: %s", diff)
+		}
+	})
 }
 
 type mockParser struct {
@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	logger "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/parser"
+	"github.com/sourcegraph/sourcegraph/cmd/symbols/types"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	proto "github.com/sourcegraph/sourcegraph/internal/symbols/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxParseFilesPaths bounds the number of files parsed by a single ParseFiles request.
+const maxParseFilesPaths = 100
+
+// MakeParseFilesFunc returns a function that fetches the given files from gitserver and parses them with the given
+// parser. Unlike a symbols search, nothing is indexed or cached, so this is only suitable for a handful of files, such
+// as the files changed by a commit.
+func MakeParseFilesFunc(symbolParser parser.Parser) types.ParseFilesFunc {
+	return func(ctx context.Context, args search.ParseFilesParameters) (result.Symbols, error) {
+		if len(args.Paths) == 0 {
+			return nil, nil
+		}
+		if len(args.Paths) > maxParseFilesPaths {
+			return nil, errors.Newf("too many paths to parse: %d (max %d)", len(args.Paths), maxParseFilesPaths)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		symbolOrErrors, err := symbolParser.Parse(ctx, search.SymbolsParameters{Repo: args.Repo, CommitID: args.CommitID}, args.Paths)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			symbols  result.Symbols
+			parseErr error
+		)
+		for symbolOrError := range symbolOrErrors {
+			if symbolOrError.Err != nil {
+				if parseErr == nil {
+					parseErr = symbolOrError.Err
+					// Stop the parser, the channel is drained until it is closed.
+					cancel()
+				}
+				continue
+			}
+			symbols = append(symbols, symbolOrError.Symbol)
+		}
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		return symbols, nil
+	}
+}
+
+func (s *grpcService) ParseFiles(ctx context.Context, r *proto.ParseFilesRequest) (*proto.SearchResponse, error) {
+	var response proto.SearchResponse
+
+	params := r.ToInternal()
+	symbols, err := s.parseFilesFunc(ctx, params)
+	if err != nil {
+		s.logger.Error("parsing files failed",
+			logger.String("arguments", fmt.Sprintf("%+v", params)),
+			logger.Error(err),
+		)
+
+		response.FromInternal(&search.SymbolsResponse{Err: err.Error()})
+	} else {
+		response.FromInternal(&search.SymbolsResponse{Symbols: symbols})
+	}
+
+	return &response, nil
+}
+
+func handleParseFilesWith(l logger.Logger, parseFilesFunc types.ParseFilesFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var args search.ParseFilesParameters
+		if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resultSymbols, err := parseFilesFunc(r.Context(), args)
+		if err != nil {
+			// Ignore reporting errors where client disconnected
+			if r.Context().Err() == context.Canceled && errors.Is(err, context.Canceled) {
+				return
+			}
+
+			l.Error("parsing files failed",
+				logger.String("arguments", fmt.Sprintf("%+v", args)),
+				logger.Error(err),
+			)
+
+			if err := json.NewEncoder(w).Encode(search.SymbolsResponse{Err: err.Error()}); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(search.SymbolsResponse{Symbols: resultSymbols}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}
+}
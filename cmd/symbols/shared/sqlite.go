@@ -36,7 +36,7 @@ func LoadConfig() {
 
 var config types.SqliteConfig
 
-func SetupSqlite(observationCtx *observation.Context, db database.DB, gitserverClient gitserver.GitserverClient, repositoryFetcher fetcher.RepositoryFetcher) (types.SearchFunc, func(http.ResponseWriter, *http.Request), []goroutine.BackgroundRoutine, string, error) {
+func SetupSqlite(observationCtx *observation.Context, db database.DB, gitserverClient gitserver.GitserverClient, repositoryFetcher fetcher.RepositoryFetcher) (types.SearchFunc, types.ParseFilesFunc, func(http.ResponseWriter, *http.Request), []goroutine.BackgroundRoutine, string, error) {
 	logger := observationCtx.Logger.Scoped("sqlite.setup", "SQLite setup")
 
 	if err := baseConfig.Validate(); err != nil {
@@ -52,7 +52,10 @@ func SetupSqlite(observationCtx *observation.Context, db database.DB, gitserverC
 		searchFunc := func(ctx context.Context, params search.SymbolsParameters) (result.Symbols, error) {
 			return nil, nil
 		}
-		return searchFunc, nil, []goroutine.BackgroundRoutine{}, "", nil
+		parseFilesFunc := func(ctx context.Context, params search.ParseFilesParameters) (result.Symbols, error) {
+			return nil, nil
+		}
+		return searchFunc, parseFilesFunc, nil, []goroutine.BackgroundRoutine{}, "", nil
 	}
 
 	parserFactory := func(source ctags_config.ParserType) (ctags.Parser, error) {
@@ -73,12 +76,13 @@ func SetupSqlite(observationCtx *observation.Context, db database.DB, gitserverC
 	databaseWriter := writer.NewDatabaseWriter(observationCtx, config.CacheDir, gitserverClient, parser, semaphore.NewWeighted(int64(config.MaxConcurrentlyIndexing)))
 	cachedDatabaseWriter := writer.NewCachedDatabaseWriter(databaseWriter, cache)
 	searchFunc := api.MakeSqliteSearchFunc(observationCtx, cachedDatabaseWriter, db)
+	parseFilesFunc := api.MakeParseFilesFunc(parser)
 
 	evictionInterval := time.Second * 10
 	cacheSizeBytes := int64(config.CacheSizeMB) * 1000 * 1000
 	cacheEvicter := janitor.NewCacheEvicter(evictionInterval, cache, cacheSizeBytes, janitor.NewMetrics(observationCtx))
 
-	return searchFunc, nil, []goroutine.BackgroundRoutine{cacheEvicter}, config.Ctags.UniversalCommand, nil
+	return searchFunc, parseFilesFunc, nil, []goroutine.BackgroundRoutine{cacheEvicter}, config.Ctags.UniversalCommand, nil
 }
 
 func parserTypesForDeployment() []ctags_config.ParserType {
@@ -110,3 +110,6 @@ func LoadRepositoryFetcherConfig(baseConfig env.BaseConfig) RepositoryFetcherCon
 }
 
 type SearchFunc func(ctx context.Context, args search.SymbolsParameters) (results result.Symbols, err error)
+
+// ParseFilesFunc parses the symbols of individual files at a commit, without indexing the rest of the repository.
+type ParseFilesFunc func(ctx context.Context, args search.ParseFilesParameters) (results result.Symbols, err error)
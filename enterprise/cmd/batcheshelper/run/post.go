@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/batcheshelper/log"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/batcheshelper/util"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
@@ -40,8 +41,18 @@ func Post(
 		}
 	}
 
+	stepCache, err := readStepCache(workingDirectory)
+	if err != nil {
+		return err
+	}
+	// Apply the result from the step cache if the step did not run.
+	cached, err := applyCachedStepResult(ctx, runner, workingDirectory, stepIdx)
+	if err != nil {
+		return err
+	}
+
 	// Generate the diff.
-	if _, err := runner.Git(ctx, gitDir, "add", "--all"); err != nil {
+	if _, err = runner.Git(ctx, gitDir, "add", "--all"); err != nil {
 		return errors.Wrap(err, "failed to add all files to git")
 	}
 	diff, err := runner.Git(ctx, gitDir, "diff", "--cached", "--no-prefix", "--binary")
@@ -109,7 +120,7 @@ func Post(
 	err = logger.WriteEvent(
 		batcheslib.LogEventOperationTaskStep,
 		batcheslib.LogEventStatusSuccess,
-		&batcheslib.TaskStepMetadata{Version: 2, Step: stepIdx, Diff: diff, Outputs: outputs},
+		&batcheslib.TaskStepMetadata{Version: 2, Step: stepIdx, Diff: diff, Outputs: outputs, Cached: cached},
 	)
 	if err != nil {
 		return err
@@ -124,23 +135,20 @@ func Post(
 		return errors.Wrap(err, "failed to write step result file")
 	}
 
+	// Hand the step result to the executor to store in the step cache.
+	if stepCache != nil {
+		if cached {
+			// Nothing to store, the result came from the step cache.
+			if err = os.Remove(filepath.Join(workingDirectory, types.StepCacheFile)); err != nil {
+				return errors.Wrap(err, "removing step cache file")
+			}
+		} else if err = storeStepResult(workingDirectory, *stepCache, stepResult); err != nil {
+			return err
+		}
+	}
+
 	// Build and write the cache key
-	key := cache.KeyForWorkspace(
-		&executionInput.BatchChangeAttributes,
-		batcheslib.Repository{
-			ID:          executionInput.Repository.ID,
-			Name:        executionInput.Repository.Name,
-			BaseRef:     executionInput.Branch.Name,
-			BaseRev:     executionInput.Branch.Target.OID,
-			FileMatches: executionInput.SearchResultPaths,
-		},
-		executionInput.Path,
-		os.Environ(),
-		executionInput.OnlyFetchWorkspace,
-		executionInput.Steps,
-		stepIdx,
-		fileMetadataRetriever{workingDirectory: workspaceFilesPath},
-	)
+	key := cacheKey(executionInput, stepIdx, workspaceFilesPath)
 
 	k, err := key.Key()
 	if err != nil {
@@ -160,6 +168,27 @@ func Post(
 	return cleanupWorkspace(workingDirectory, stepIdx, workspaceFilesPath)
 }
 
+// cacheKey returns the key of the result of the steps up to and including the
+// step in the execution cache.
+func cacheKey(executionInput batcheslib.WorkspacesExecutionInput, stepIdx int, workspaceFilesPath string) cache.CacheKey {
+	return cache.KeyForWorkspace(
+		&executionInput.BatchChangeAttributes,
+		batcheslib.Repository{
+			ID:          executionInput.Repository.ID,
+			Name:        executionInput.Repository.Name,
+			BaseRef:     executionInput.Branch.Name,
+			BaseRev:     executionInput.Branch.Target.OID,
+			FileMatches: executionInput.SearchResultPaths,
+		},
+		executionInput.Path,
+		os.Environ(),
+		executionInput.OnlyFetchWorkspace,
+		executionInput.Steps,
+		stepIdx,
+		fileMetadataRetriever{workingDirectory: workspaceFilesPath},
+	)
+}
+
 type fileMetadataRetriever struct {
 	workingDirectory string
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/batcheshelper/log"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/batcheshelper/run"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/batcheshelper/util"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
 )
@@ -210,6 +211,105 @@ func TestPost(t *testing.T) {
 				assert.True(t, os.IsNotExist(err))
 			},
 		},
+		{
+			name: "Step cache miss",
+			setupFunc: func(t *testing.T, dir string, workspaceFileDir string, executionInput batcheslib.WorkspacesExecutionInput) {
+				err := os.WriteFile(filepath.Join(dir, "step-cache.json"), []byte(`{"key":"step-key","nextStep":"step.0.post"}`), os.ModePerm)
+				require.NoError(t, err)
+			},
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("Git", mock.Anything, "", []string{"config", "--global", "--add", "safe.directory", "/job/repository"}).
+					Return("", nil)
+				runner.On("Git", mock.Anything, "repository", []string{"add", "--all"}).
+					Return("", nil)
+				runner.On("Git", mock.Anything, "repository", []string{"diff", "--cached", "--no-prefix", "--binary"}).
+					Return("git diff", nil)
+			},
+			step: 1,
+			executionInput: batcheslib.WorkspacesExecutionInput{
+				Steps: []batcheslib.Step{
+					{Run: "echo hello"},
+					{Run: "echo hello world"},
+				},
+				StepCache: true,
+			},
+			previousResult: execution.AfterStepResult{},
+			stdoutLogs:     "hello world",
+			stderrLogs:     "error",
+			assertFunc: func(t *testing.T, logEntries []batcheslib.LogEvent, dir string, runner *fakeCmdRunner) {
+				require.Len(t, logEntries, 2)
+				assert.False(t, logEntries[0].Metadata.(*batcheslib.TaskStepMetadata).Cached)
+
+				b, err := os.ReadFile(filepath.Join(dir, "step-cache.json"))
+				require.NoError(t, err)
+				var stepCache types.StepCache
+				err = json.Unmarshal(b, &stepCache)
+				require.NoError(t, err)
+				assert.Equal(t, "step-key", stepCache.Key)
+
+				var result execution.AfterStepResult
+				err = json.Unmarshal(stepCache.Result, &result)
+				require.NoError(t, err)
+				assert.Equal(
+					t,
+					execution.AfterStepResult{
+						Version:   2,
+						Stdout:    "hello world",
+						Stderr:    "error",
+						StepIndex: 1,
+						Diff:      []byte("git diff"),
+						Outputs:   map[string]interface{}{},
+					},
+					result,
+				)
+			},
+		},
+		{
+			name: "Step cache hit",
+			setupFunc: func(t *testing.T, dir string, workspaceFileDir string, executionInput batcheslib.WorkspacesExecutionInput) {
+				err := os.WriteFile(filepath.Join(dir, "step-cache.json"), []byte(`{"key":"step-key","nextStep":"step.0.post"}`), os.ModePerm)
+				require.NoError(t, err)
+				err = os.WriteFile(filepath.Join(dir, "step-cache-hit.json"), []byte(`{"version":2,"stdout":"cached out","stderr":"cached err","diff":"c3RlcCBkaWZm"}`), os.ModePerm)
+				require.NoError(t, err)
+			},
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("Git", mock.Anything, "", []string{"config", "--global", "--add", "safe.directory", "/job/repository"}).
+					Return("", nil)
+				runner.On("Git", mock.Anything, "repository", []string{"reset", "--hard"}).
+					Return("", nil)
+				runner.On("Git", mock.Anything, "repository", []string{"clean", "-fd"}).
+					Return("", nil)
+				runner.On("Git", mock.Anything, "repository", mock.MatchedBy(func(args []string) bool {
+					return len(args) == 3 && args[0] == "apply" && args[1] == "-p0" && strings.HasSuffix(args[2], "step0.diff")
+				})).
+					Return("", nil)
+				runner.On("Git", mock.Anything, "repository", []string{"add", "--all"}).
+					Return("", nil)
+				runner.On("Git", mock.Anything, "repository", []string{"diff", "--cached", "--no-prefix", "--binary"}).
+					Return("git diff", nil)
+			},
+			step: 0,
+			executionInput: batcheslib.WorkspacesExecutionInput{
+				Steps: []batcheslib.Step{
+					{Run: "echo hello world"},
+				},
+				StepCache: true,
+			},
+			previousResult: execution.AfterStepResult{},
+			assertFunc: func(t *testing.T, logEntries []batcheslib.LogEvent, dir string, runner *fakeCmdRunner) {
+				require.Len(t, logEntries, 2)
+				assert.True(t, logEntries[0].Metadata.(*batcheslib.TaskStepMetadata).Cached)
+				assert.Equal(t, "cached out", logEntries[1].Metadata.(*batcheslib.CacheAfterStepResultMetadata).Value.Stdout)
+				assert.Equal(t, "cached err", logEntries[1].Metadata.(*batcheslib.CacheAfterStepResultMetadata).Value.Stderr)
+				runner.AssertExpectations(t)
+
+				for _, name := range []string{"step-cache.json", "step-cache-hit.json", "step0.diff"} {
+					_, err := os.Stat(filepath.Join(dir, name))
+					require.Error(t, err)
+					assert.True(t, os.IsNotExist(err))
+				}
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
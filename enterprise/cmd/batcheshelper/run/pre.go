@@ -154,6 +154,12 @@ func Pre(
 		return errors.Wrap(err, "failed to write step script file")
 	}
 
+	if executionInput.StepCache {
+		if err = writeStepCache(stepIdx, executionInput, workingDirectory, workspaceFilesPath); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -15,9 +15,12 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/batcheshelper/log"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/batcheshelper/run"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
 	"github.com/sourcegraph/sourcegraph/lib/batches/env"
 	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
+	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 )
 
 func TestPre(t *testing.T) {
@@ -190,6 +193,122 @@ func TestPre(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "Step cache",
+			setupFunc: func(t *testing.T, dir string, executionInput batcheslib.WorkspacesExecutionInput) {
+				err := os.WriteFile(filepath.Join(dir, "step-cache-images.json"), []byte(`{"alpine:3":"sha256:abc"}`), os.ModePerm)
+				require.NoError(t, err)
+			},
+			step: 0,
+			executionInput: batcheslib.WorkspacesExecutionInput{
+				Repository: batcheslib.WorkspaceRepo{Name: "github.com/sourcegraph/sourcegraph"},
+				Steps: []batcheslib.Step{
+					{
+						Run:       "echo hello",
+						Container: "alpine:3",
+					},
+				},
+				StepCache: true,
+			},
+			previousResult: execution.AfterStepResult{},
+			assertFunc: func(t *testing.T, logEntries []batcheslib.LogEvent, dir string) {
+				require.Len(t, logEntries, 1)
+
+				dirEntries, err := os.ReadDir(dir)
+				require.NoError(t, err)
+				require.Len(t, dirEntries, 3)
+
+				b, err := os.ReadFile(filepath.Join(dir, "step-cache.json"))
+				require.NoError(t, err)
+				var stepCache types.StepCache
+				err = json.Unmarshal(b, &stepCache)
+				require.NoError(t, err)
+
+				cacheKey := cache.KeyForWorkspace(
+					&template.BatchChangeAttributes{},
+					batcheslib.Repository{Name: "github.com/sourcegraph/sourcegraph"},
+					"",
+					os.Environ(),
+					false,
+					[]batcheslib.Step{{Run: "echo hello", Container: "alpine:3"}},
+					0,
+					nil,
+				)
+				cacheKey.ImageDigests = map[string]string{"alpine:3": "sha256:abc"}
+				key, err := cacheKey.Key()
+				require.NoError(t, err)
+				assert.Equal(t, types.StepCache{
+					Key:      key,
+					NextStep: "step.0.post",
+				}, stepCache)
+			},
+		},
+		{
+			name: "Step cache with pinned image",
+			step: 0,
+			executionInput: batcheslib.WorkspacesExecutionInput{
+				Repository: batcheslib.WorkspaceRepo{Name: "github.com/sourcegraph/sourcegraph"},
+				Steps: []batcheslib.Step{
+					{
+						Run:       "echo hello",
+						Container: "alpine@sha256:abc",
+					},
+				},
+				StepCache: true,
+			},
+			previousResult: execution.AfterStepResult{},
+			assertFunc: func(t *testing.T, logEntries []batcheslib.LogEvent, dir string) {
+				require.Len(t, logEntries, 1)
+
+				b, err := os.ReadFile(filepath.Join(dir, "step-cache.json"))
+				require.NoError(t, err)
+				var stepCache types.StepCache
+				err = json.Unmarshal(b, &stepCache)
+				require.NoError(t, err)
+
+				// The key is the same as the one src-cli computes.
+				key, err := cache.KeyForWorkspace(
+					&template.BatchChangeAttributes{},
+					batcheslib.Repository{Name: "github.com/sourcegraph/sourcegraph"},
+					"",
+					os.Environ(),
+					false,
+					[]batcheslib.Step{{Run: "echo hello", Container: "alpine@sha256:abc"}},
+					0,
+					nil,
+				).Key()
+				require.NoError(t, err)
+				assert.Equal(t, types.StepCache{
+					Key:      key,
+					NextStep: "step.0.post",
+				}, stepCache)
+			},
+		},
+		{
+			name: "Step cache without image digest",
+			setupFunc: func(t *testing.T, dir string, executionInput batcheslib.WorkspacesExecutionInput) {
+				err := os.WriteFile(filepath.Join(dir, "step-cache-images.json"), []byte(`{"alpine:3":""}`), os.ModePerm)
+				require.NoError(t, err)
+			},
+			step: 0,
+			executionInput: batcheslib.WorkspacesExecutionInput{
+				Repository: batcheslib.WorkspaceRepo{Name: "github.com/sourcegraph/sourcegraph"},
+				Steps: []batcheslib.Step{
+					{
+						Run:       "echo hello",
+						Container: "alpine:3",
+					},
+				},
+				StepCache: true,
+			},
+			previousResult: execution.AfterStepResult{},
+			assertFunc: func(t *testing.T, logEntries []batcheslib.LogEvent, dir string) {
+				require.Len(t, logEntries, 1)
+
+				_, err := os.Stat(filepath.Join(dir, "step-cache.json"))
+				assert.True(t, os.IsNotExist(err))
+			},
+		},
 		{
 			name: "Environment Variables",
 			setupFunc: func(t *testing.T, dir string, executionInput batcheslib.WorkspacesExecutionInput) {
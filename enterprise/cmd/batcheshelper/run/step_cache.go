@@ -0,0 +1,156 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/batcheshelper/util"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+	executorutil "github.com/sourcegraph/sourcegraph/enterprise/internal/executor/util"
+	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// writeStepCache writes the step cache file for the step. The executor looks up
+// the key in the step cache before running the step, and continues with the
+// post step if it finds a result.
+//
+// The key is the execution cache key of the steps up to and including the step,
+// which src-cli uses for its cache too. When a step refers to its image by tag,
+// the key is extended with the digest the executor resolved for the image, which
+// makes it a versioned key that src-cli does not compute. Without the digest of
+// such an image, a moved tag would reuse stale results, so the step is not
+// cached. Steps whose images are pinned to a digest share their keys with src-cli.
+func writeStepCache(stepIdx int, executionInput batcheslib.WorkspacesExecutionInput, workingDirectory string, workspaceFilesPath string) error {
+	images, err := readStepCacheImages(workingDirectory)
+	if err != nil {
+		return err
+	}
+	for _, step := range executionInput.Steps[:stepIdx+1] {
+		if !cache.IsImagePinned(step.Container) && images[step.Container] == "" {
+			return nil
+		}
+	}
+
+	key := cacheKey(executionInput, stepIdx, workspaceFilesPath)
+	key.ImageDigests = images
+	k, err := key.Key()
+	if err != nil {
+		return errors.Wrap(err, "failed to compute step cache key")
+	}
+
+	return writeStepCacheFile(workingDirectory, types.StepCache{
+		Key:      k,
+		NextStep: executorutil.FormatPostKey(stepIdx),
+	})
+}
+
+func readStepCacheImages(workingDirectory string) (types.StepCacheImages, error) {
+	b, err := os.ReadFile(filepath.Join(workingDirectory, types.StepCacheImagesFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading step cache images file")
+	}
+	var images types.StepCacheImages
+	if err = json.Unmarshal(b, &images); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling step cache images file")
+	}
+	return images, nil
+}
+
+// readStepCache reads the step cache file written by the pre step. It returns
+// nil if the result of the step is not cached.
+func readStepCache(workingDirectory string) (*types.StepCache, error) {
+	b, err := os.ReadFile(filepath.Join(workingDirectory, types.StepCacheFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading step cache file")
+	}
+	var stepCache types.StepCache
+	if err = json.Unmarshal(b, &stepCache); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling step cache file")
+	}
+	return &stepCache, nil
+}
+
+func writeStepCacheFile(workingDirectory string, stepCache types.StepCache) error {
+	b, err := json.Marshal(stepCache)
+	if err != nil {
+		return errors.Wrap(err, "marshalling step cache file")
+	}
+	if err = os.WriteFile(filepath.Join(workingDirectory, types.StepCacheFile), b, os.ModePerm); err != nil {
+		return errors.Wrap(err, "writing step cache file")
+	}
+	return nil
+}
+
+// applyCachedStepResult applies the step result the executor found in the step
+// cache instead of running the step. The cached diff holds the changes of all
+// steps up to and including the step, so the repository is reset before it is
+// applied. The output is written to the log files of the step, so that the
+// result can be processed as if the step ran. It returns false if the executor
+// did not find a result.
+func applyCachedStepResult(ctx context.Context, runner util.CmdRunner, workingDirectory string, stepIdx int) (bool, error) {
+	hitPath := filepath.Join(workingDirectory, types.StepCacheHitFile)
+	b, err := os.ReadFile(hitPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "reading cached step result")
+	}
+	var result execution.AfterStepResult
+	if err = json.Unmarshal(b, &result); err != nil {
+		return false, errors.Wrap(err, "unmarshalling cached step result")
+	}
+
+	if _, err = runner.Git(ctx, gitDir, "reset", "--hard"); err != nil {
+		return false, errors.Wrap(err, "failed to reset repository")
+	}
+	if _, err = runner.Git(ctx, gitDir, "clean", "-fd"); err != nil {
+		return false, errors.Wrap(err, "failed to clean repository")
+	}
+	if len(result.Diff) > 0 {
+		patchPath := filepath.Join(workingDirectory, fmt.Sprintf("step%d.diff", stepIdx))
+		if err = os.WriteFile(patchPath, result.Diff, os.ModePerm); err != nil {
+			return false, errors.Wrap(err, "writing cached diff")
+		}
+		if _, err = runner.Git(ctx, gitDir, "apply", "-p0", patchPath); err != nil {
+			return false, errors.Wrap(err, "failed to apply cached diff")
+		}
+		if err = os.Remove(patchPath); err != nil {
+			return false, errors.Wrap(err, "removing cached diff")
+		}
+	}
+
+	if err = os.WriteFile(filepath.Join(workingDirectory, fmt.Sprintf("stdout%d.log", stepIdx)), []byte(result.Stdout), os.ModePerm); err != nil {
+		return false, errors.Wrap(err, "writing cached stdout")
+	}
+	if err = os.WriteFile(filepath.Join(workingDirectory, fmt.Sprintf("stderr%d.log", stepIdx)), []byte(result.Stderr), os.ModePerm); err != nil {
+		return false, errors.Wrap(err, "writing cached stderr")
+	}
+
+	return true, os.Remove(hitPath)
+}
+
+// storeStepResult sets the result of the step in the step cache file, for the
+// executor to store in the step cache. The result is the same as the one src-cli
+// caches for the key.
+func storeStepResult(workingDirectory string, stepCache types.StepCache, stepResult execution.AfterStepResult) error {
+	result, err := json.Marshal(stepResult)
+	if err != nil {
+		return errors.Wrap(err, "marshalling step result")
+	}
+	stepCache.Result = result
+
+	return writeStepCacheFile(workingDirectory, stepCache)
+}
@@ -40,6 +40,8 @@ type Config struct {
 	KeepWorkspaces                                 bool
 	DockerHostMountPath                            string
 	UseFirecracker                                 bool
+	UsePodman                                      bool
+	PodmanPath                                     string
 	JobNumCPUs                                     int
 	JobMemory                                      string
 	FirecrackerDiskSpace                           string
@@ -118,6 +120,8 @@ func (c *Config) Load() {
 	c.QueuePollInterval = c.GetInterval("EXECUTOR_QUEUE_POLL_INTERVAL", "1s", "Interval between dequeue requests.")
 	c.MaximumNumJobs = c.GetInt("EXECUTOR_MAXIMUM_NUM_JOBS", "1", "Number of virtual machines or containers that can be running at once.")
 	c.UseFirecracker = c.GetBool("EXECUTOR_USE_FIRECRACKER", strconv.FormatBool(runtime.GOOS == "linux" && !IsKubernetes()), "Whether to isolate commands in virtual machines. Requires ignite and firecracker. Linux hosts only. Kubernetes is not supported.")
+	c.UsePodman = c.GetBool("EXECUTOR_USE_PODMAN", "false", "Whether to run commands in rootless Podman containers instead of Docker containers. Requires EXECUTOR_USE_FIRECRACKER to be disabled.")
+	c.PodmanPath = c.Get("EXECUTOR_PODMAN_PATH", "podman", "The Podman binary to use when EXECUTOR_USE_PODMAN is enabled. Any OCI container CLI compatible with the podman run command line, such as nerdctl, can be used.")
 	c.FirecrackerImage = c.Get("EXECUTOR_FIRECRACKER_IMAGE", DefaultFirecrackerImage, "The base image to use for virtual machines.")
 	c.FirecrackerKernelImage = c.Get("EXECUTOR_FIRECRACKER_KERNEL_IMAGE", DefaultFirecrackerKernelImage, "The base image containing the kernel binary to use for virtual machines.")
 	c.FirecrackerSandboxImage = c.Get("EXECUTOR_FIRECRACKER_SANDBOX_IMAGE", DefaultFirecrackerSandboxImage, "The OCI image for the ignite VM sandbox.")
@@ -314,6 +318,15 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	if c.UsePodman {
+		if c.UseFirecracker {
+			c.AddError(errors.New("EXECUTOR_USE_PODMAN and EXECUTOR_USE_FIRECRACKER cannot both be enabled"))
+		}
+		if IsKubernetes() {
+			c.AddError(errors.New("EXECUTOR_USE_PODMAN is not supported in Kubernetes"))
+		}
+	}
+
 	if len(c.KubernetesNodeSelector) > 0 {
 		nodeSelectorValues := strings.Split(c.KubernetesNodeSelector, ",")
 		for _, value := range nodeSelectorValues {
@@ -50,7 +50,11 @@ func StandaloneRun(ctx context.Context, runner util.CmdRunner, logger log.Logger
 	// TODO: This is too similar to the RunValidate func. Make it share even more code.
 	if runVerifyChecks {
 		// Then, validate all tools that are required are installed.
-		if err := util.ValidateRequiredTools(runner, cfg.UseFirecracker); err != nil {
+		if cfg.UsePodman {
+			if err := util.ValidatePodmanTools(runner, cfg.PodmanPath); err != nil {
+				return err
+			}
+		} else if err := util.ValidateRequiredTools(runner, cfg.UseFirecracker); err != nil {
 			return err
 		}
 
@@ -70,6 +70,7 @@ func apiWorkerOptions(c *config.Config, queueTelemetryOptions queue.TelemetryOpt
 			DockerOptions:      dockerOptions(c),
 			FirecrackerOptions: firecrackerOptions(c),
 			KubernetesOptions:  kubernetesOptions(c),
+			PodmanOptions:      podmanOptions(c),
 		},
 		GitServicePath: "/.executors/git",
 		QueueOptions:   queueOptions(c, queueTelemetryOptions),
@@ -108,6 +109,18 @@ func dockerOptions(c *config.Config) command.DockerOptions {
 	}
 }
 
+func podmanOptions(c *config.Config) runner.PodmanOptions {
+	return runner.PodmanOptions{
+		Enabled: c.UsePodman,
+		ContainerOptions: command.PodmanOptions{
+			Path:             c.PodmanPath,
+			DockerAuthConfig: c.DockerAuthConfig,
+			AddHostGateway:   c.DockerAddHostGateway,
+			Resources:        resourceOptions(c),
+		},
+	}
+}
+
 func firecrackerOptions(c *config.Config) runner.FirecrackerOptions {
 	var dockerMirrors []string
 	if len(c.DockerRegistryMirrorURL) > 0 {
@@ -34,7 +34,11 @@ func Validate(cliCtx *cli.Context, runner util.CmdRunner, logger log.Logger, con
 
 	if !config.IsKubernetes() {
 		// Then, validate all tools that are required are installed.
-		if err = util.ValidateRequiredTools(runner, conf.UseFirecracker); err != nil {
+		if conf.UsePodman {
+			if err = util.ValidatePodmanTools(runner, conf.PodmanPath); err != nil {
+				return err
+			}
+		} else if err = util.ValidateRequiredTools(runner, conf.UseFirecracker); err != nil {
 			return err
 		}
 
@@ -107,6 +107,31 @@ func ValidateDockerTools(runner CmdRunner) error {
 	return nil
 }
 
+// ValidatePodmanTools validates that the tools required to run Podman are installed.
+// The given path is the Podman binary, which replaces docker in the required tools.
+func ValidatePodmanTools(runner CmdRunner, podmanPath string) error {
+	var missingTools []string
+	tools := []string{podmanPath}
+	for t := range config.RequiredCLITools {
+		if t != "docker" {
+			tools = append(tools, t)
+		}
+	}
+	sort.Strings(tools[1:])
+
+	for _, tool := range tools {
+		if found, err := ExistsPath(runner, tool); err != nil {
+			return err
+		} else if !found {
+			missingTools = append(missingTools, tool)
+		}
+	}
+	if len(missingTools) > 0 {
+		return &ErrMissingTools{missingTools}
+	}
+	return nil
+}
+
 // ValidateFirecrackerTools validates that the tools required to run Firecracker are installed.
 func ValidateFirecrackerTools(runner CmdRunner) error {
 	var missingTools []string
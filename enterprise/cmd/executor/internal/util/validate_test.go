@@ -274,6 +274,81 @@ func TestValidateDockerTools(t *testing.T) {
 	}
 }
 
+func TestValidatePodmanTools(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		podmanPath  string
+		mockFunc    func(runner *fakeCmdRunner)
+		expectedErr error
+	}{
+		{
+			name:       "Podman is valid",
+			podmanPath: "podman",
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("LookPath", "podman").
+					Return("", nil)
+				runner.On("LookPath", "git").
+					Return("", nil)
+				runner.On("LookPath", "src").
+					Return("", nil)
+			},
+		},
+		{
+			name:       "Custom path missing",
+			podmanPath: "/usr/local/bin/podman",
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("LookPath", "/usr/local/bin/podman").
+					Return("", exec.ErrNotFound)
+				runner.On("LookPath", "git").
+					Return("", nil)
+				runner.On("LookPath", "src").
+					Return("", nil)
+			},
+			expectedErr: errors.New("/usr/local/bin/podman not found in PATH, is it installed?"),
+		},
+		{
+			name:       "Podman error",
+			podmanPath: "podman",
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("LookPath", "podman").
+					Return("", errors.New("failed to find podman"))
+			},
+			expectedErr: errors.New("failed to find podman"),
+		},
+		{
+			name:       "Git missing",
+			podmanPath: "podman",
+			mockFunc: func(runner *fakeCmdRunner) {
+				runner.On("LookPath", "podman").
+					Return("", nil)
+				runner.On("LookPath", "git").
+					Return("", exec.ErrNotFound)
+				runner.On("LookPath", "src").
+					Return("", nil)
+			},
+			expectedErr: errors.New("git not found in PATH, is it installed?\nUse your package manager, or build from source."),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := new(fakeCmdRunner)
+			if test.mockFunc != nil {
+				test.mockFunc(runner)
+			}
+
+			err := util.ValidatePodmanTools(runner, test.podmanPath)
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestValidateFirecrackerTools(t *testing.T) {
 	t.Parallel()
 
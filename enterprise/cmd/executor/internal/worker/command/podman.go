@@ -0,0 +1,90 @@
+package command
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+)
+
+// PodmanOptions are the options that are specific to running a container with
+// Podman.
+type PodmanOptions struct {
+	// Path is the Podman binary. Any OCI container CLI that is compatible with
+	// the `podman run` command line can be used.
+	Path             string
+	DockerAuthConfig types.DockerAuthConfig
+	// AuthFile is the path to the registry credentials file, in the same format
+	// as the Docker config.json file.
+	AuthFile       string
+	AddHostGateway bool
+	Resources      ResourceOptions
+}
+
+// NewPodmanSpec constructs the command to run on the host in order to invoke
+// the given spec. If the spec does not specify an image, then the command will
+// be run _directly_ on the host. Otherwise, the command will be run inside a
+// one-shot Podman container subject to the resource limits specified in the
+// given options.
+//
+// Podman runs containers without a daemon, and as the executor user when the
+// executor does not run as root. The workspace is mounted the same way as with
+// Docker, so the same scripts can be run with either runtime.
+func NewPodmanSpec(workingDir string, image string, scriptPath string, spec Spec, options PodmanOptions) Spec {
+	if image == "" {
+		env := spec.Env
+		if options.AuthFile != "" {
+			env = append(env, fmt.Sprintf("REGISTRY_AUTH_FILE=%s", options.AuthFile))
+		}
+		return Spec{
+			Key:       spec.Key,
+			Command:   spec.Command,
+			Dir:       filepath.Join(workingDir, spec.Dir),
+			Env:       env,
+			Operation: spec.Operation,
+		}
+	}
+
+	hostDir := workingDir
+	if options.Resources.DockerHostMountPath != "" {
+		hostDir = filepath.Join(options.Resources.DockerHostMountPath, filepath.Base(workingDir))
+	}
+
+	return Spec{
+		Key:       spec.Key,
+		Command:   formatPodmanCommand(hostDir, image, scriptPath, spec, options),
+		Operation: spec.Operation,
+	}
+}
+
+func formatPodmanCommand(hostDir string, image string, scriptPath string, spec Spec, options PodmanOptions) []string {
+	return Flatten(
+		podmanPath(options.Path),
+		"run",
+		"--rm",
+		podmanAuthFileFlag(options.AuthFile),
+		dockerHostGatewayFlag(options.AddHostGateway),
+		dockerResourceFlags(options.Resources),
+		dockerVolumeFlags(hostDir),
+		dockerWorkingDirectoryFlags(spec.Dir),
+		dockerEnvFlags(spec.Env),
+		dockerEntrypointFlags,
+		image,
+		filepath.Join("/data", files.ScriptsPath, scriptPath),
+	)
+}
+
+func podmanPath(path string) string {
+	if path == "" {
+		return "podman"
+	}
+	return path
+}
+
+func podmanAuthFileFlag(authFile string) []string {
+	if authFile == "" {
+		return nil
+	}
+	return []string{"--authfile", authFile}
+}
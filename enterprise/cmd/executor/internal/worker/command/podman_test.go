@@ -0,0 +1,153 @@
+package command_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+)
+
+func TestNewPodmanSpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		workingDir   string
+		image        string
+		scriptPath   string
+		spec         command.Spec
+		options      command.PodmanOptions
+		expectedSpec command.Spec
+	}{
+		{
+			name:       "Converts to podman spec",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "script/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"podman",
+					"run",
+					"--rm",
+					"-v",
+					"/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"-e",
+					"FOO=BAR",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/script/path",
+				},
+			},
+		},
+		{
+			name:       "Custom binary",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+			},
+			options: command.PodmanOptions{
+				Path: "/usr/local/bin/nerdctl",
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"/usr/local/bin/nerdctl",
+					"run",
+					"--rm",
+					"-v",
+					"/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+		{
+			name:       "Auth file, host gateway and resources",
+			workingDir: "/workingDirectory",
+			image:      "some-image",
+			scriptPath: "some/path",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"some", "command"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			options: command.PodmanOptions{
+				AuthFile:       "/podman/auth.json",
+				AddHostGateway: true,
+				Resources: command.ResourceOptions{
+					NumCPUs:             10,
+					Memory:              "10G",
+					DockerHostMountPath: "/host/mount/path",
+				},
+			},
+			expectedSpec: command.Spec{
+				Key: "some-key",
+				Command: []string{
+					"podman",
+					"run",
+					"--rm",
+					"--authfile",
+					"/podman/auth.json",
+					"--add-host=host.docker.internal:host-gateway",
+					"--cpus",
+					"10",
+					"--memory",
+					"10G",
+					"-v",
+					"/host/mount/path/workingDirectory:/data",
+					"-w",
+					"/data/some/dir",
+					"-e",
+					"FOO=BAR",
+					"--entrypoint",
+					"/bin/sh",
+					"some-image",
+					"/data/.sourcegraph-executor/some/path",
+				},
+			},
+		},
+		{
+			name:       "src-cli Spec with auth file",
+			workingDir: "/workingDirectory",
+			spec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"src", "exec", "-f", "batch.yml"},
+				Dir:     "/some/dir",
+				Env:     []string{"FOO=BAR"},
+			},
+			options: command.PodmanOptions{
+				AuthFile: "/podman/auth.json",
+			},
+			expectedSpec: command.Spec{
+				Key:     "some-key",
+				Command: []string{"src", "exec", "-f", "batch.yml"},
+				Dir:     "/workingDirectory/some/dir",
+				Env:     []string{"FOO=BAR", "REGISTRY_AUTH_FILE=/podman/auth.json"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actualSpec := command.NewPodmanSpec(test.workingDir, test.image, test.scriptPath, test.spec, test.options)
+			assert.Equal(t, test.expectedSpec, actualSpec)
+		})
+	}
+}
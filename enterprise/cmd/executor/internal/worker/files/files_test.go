@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files"
+	filesmocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -21,15 +22,15 @@ func TestGetWorkspaceFiles(t *testing.T) {
 	tests := []struct {
 		name                   string
 		job                    types.Job
-		mockFunc               func(store *files.MockStore)
-		assertFunc             func(t *testing.T, store *files.MockStore)
+		mockFunc               func(store *filesmocks.MockStore)
+		assertFunc             func(t *testing.T, store *filesmocks.MockStore)
 		expectedWorkspaceFiles []files.WorkspaceFile
 		expectedErr            error
 	}{
 		{
 			name: "No files or steps",
 			job:  types.Job{},
-			assertFunc: func(t *testing.T, store *files.MockStore) {
+			assertFunc: func(t *testing.T, store *filesmocks.MockStore) {
 				require.Len(t, store.GetFunc.History(), 0)
 			},
 			expectedWorkspaceFiles: nil,
@@ -49,7 +50,7 @@ func TestGetWorkspaceFiles(t *testing.T) {
 					},
 				},
 			},
-			assertFunc: func(t *testing.T, store *files.MockStore) {
+			assertFunc: func(t *testing.T, store *filesmocks.MockStore) {
 				require.Len(t, store.GetFunc.History(), 0)
 			},
 			expectedWorkspaceFiles: []files.WorkspaceFile{
@@ -80,7 +81,7 @@ func TestGetWorkspaceFiles(t *testing.T) {
 					},
 				},
 			},
-			assertFunc: func(t *testing.T, store *files.MockStore) {
+			assertFunc: func(t *testing.T, store *filesmocks.MockStore) {
 				require.Len(t, store.GetFunc.History(), 0)
 			},
 			expectedWorkspaceFiles: []files.WorkspaceFile{
@@ -115,7 +116,7 @@ func TestGetWorkspaceFiles(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(store *files.MockStore) {
+			mockFunc: func(store *filesmocks.MockStore) {
 				store.GetFunc.SetDefaultHook(func(ctx context.Context, job types.Job, bucket string, key string) (io.ReadCloser, error) {
 					if key == "foo.sh" {
 						return io.NopCloser(bytes.NewBufferString("echo hello")), nil
@@ -126,7 +127,7 @@ func TestGetWorkspaceFiles(t *testing.T) {
 					return nil, errors.New("unexpected key")
 				})
 			},
-			assertFunc: func(t *testing.T, store *files.MockStore) {
+			assertFunc: func(t *testing.T, store *filesmocks.MockStore) {
 				require.Len(t, store.GetFunc.History(), 2)
 				assert.Equal(t, "my-bucket", store.GetFunc.History()[0].Arg2)
 				assert.Contains(t, []string{"foo.sh", "bar.sh"}, store.GetFunc.History()[0].Arg3)
@@ -151,7 +152,7 @@ func TestGetWorkspaceFiles(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			store := files.NewMockStore()
+			store := filesmocks.NewMockStore()
 			if test.mockFunc != nil {
 				test.mockFunc(store)
 			}
@@ -35,6 +35,10 @@ type handler struct {
 	cloneOptions workspace.CloneOptions
 	operations   *command.Operations
 	jobRuntime   runtime.Runtime
+
+	// resolveImageDigest resolves the digests of the images of the steps whose
+	// results are cached.
+	resolveImageDigest imageDigestResolver
 }
 
 var (
@@ -152,6 +156,8 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job types.Job)
 		return errors.Wrap(err, "creating commands")
 	}
 
+	logStepCacheError(logger, h.resolveStepCacheImages(ctx, logger, job, ws.WorkingDirectory()))
+
 	// Run all the things.
 	logger.Info("Running commands")
 	skipKey := ""
@@ -171,10 +177,17 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job types.Job)
 			if err != nil {
 				return errors.Wrap(err, "checking for skip file")
 			}
+			if len(nextStep) == 0 {
+				// Check if the result of the step is cached.
+				nextStep, err = h.lookupStepCache(ctx, job, ws.WorkingDirectory())
+				logStepCacheError(logger, err)
+			}
 			if len(nextStep) > 0 {
 				skipKey = runtime.CommandKey(h.jobRuntime.Name(), nextStep, i)
 				logger.Info("Skipping to step", log.String("key", skipKey))
 			}
+		} else if executorutil.IsPostStepKey(spec.CommandSpecs[0].Key) {
+			logStepCacheError(logger, h.storeStepCache(ctx, job, ws.WorkingDirectory()))
 		}
 	}
 
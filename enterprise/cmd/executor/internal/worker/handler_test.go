@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/janitor"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	filesmocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/runner"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -151,18 +152,18 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 		name           string
 		options        Options
 		job            types.Job
-		mockFunc       func(cmdRunner *MockCmdRunner, command *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore)
+		mockFunc       func(cmdRunner *MockCmdRunner, command *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore)
 		expectedErr    error
-		assertMockFunc func(t *testing.T, cmdRunner *MockCmdRunner, command *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore)
+		assertMockFunc func(t *testing.T, cmdRunner *MockCmdRunner, command *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore)
 	}{
 		{
 			name:    "Success with no steps",
 			options: Options{},
 			job:     types.Job{ID: 42, RepositoryName: "my-repo", Commit: "cool-commit"},
-			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 6)
 				require.Len(t, filesStore.GetFunc.History(), 0)
@@ -184,10 +185,10 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
 
 				require.Len(t, cmd.RunFunc.History(), 7)
@@ -216,10 +217,10 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
 
 				require.Len(t, cmd.RunFunc.History(), 7)
@@ -245,10 +246,10 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
 
 				require.Len(t, cmd.RunFunc.History(), 7)
@@ -280,10 +281,10 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
 
 				require.Len(t, cmd.RunFunc.History(), 7)
@@ -296,11 +297,11 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 			name:    "failed to setup workspace",
 			options: Options{},
 			job:     types.Job{ID: 42, RepositoryName: "my-repo", Commit: "cool-commit"},
-			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				// fail on first clone step
 				cmd.RunFunc.PushReturn(errors.New("failed"))
 			},
-			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 1)
 				require.Len(t, filesStore.GetFunc.History(), 0)
@@ -323,7 +324,7 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				// cloning repo needs to be successful
 				cmd.RunFunc.PushReturn(nil)
 				cmd.RunFunc.PushReturn(nil)
@@ -334,7 +335,7 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 				// Error on running the actual command
 				cmd.RunFunc.PushReturn(errors.New("failed"))
 			},
-			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 7)
 				require.Len(t, filesStore.GetFunc.History(), 0)
@@ -358,7 +359,7 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			mockFunc: func(cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				// cloning repo needs to be successful
 				cmd.RunFunc.PushReturn(nil)
 				cmd.RunFunc.PushReturn(nil)
@@ -369,7 +370,7 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 				// Error on running the actual command
 				cmd.RunFunc.PushReturn(errors.New("failed"))
 			},
-			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *MockStore) {
+			assertMockFunc: func(t *testing.T, cmdRunner *MockCmdRunner, cmd *MockCommand, logStore *MockExecutionLogEntryStore, filesStore *filesmocks.MockStore) {
 				require.Len(t, cmdRunner.CombinedOutputFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 7)
 				require.Len(t, filesStore.GetFunc.History(), 0)
@@ -387,7 +388,7 @@ func TestHandler_Handle_Legacy(t *testing.T) {
 			// Used in NewLogger
 			logStore := NewMockExecutionLogEntryStore()
 			// Used in prepareWorkspace
-			filesStore := NewMockStore()
+			filesStore := filesmocks.NewMockStore()
 
 			h := &handler{
 				nameSet:    nameSet,
@@ -0,0 +1,130 @@
+package worker
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/distribution/distribution/v3/reference"
+	"github.com/distribution/distribution/v3/registry/client"
+	"github.com/distribution/distribution/v3/registry/client/auth"
+	"github.com/distribution/distribution/v3/registry/client/auth/challenge"
+	"github.com/distribution/distribution/v3/registry/client/transport"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// imageDigestResolver resolves the digest of the manifest an image reference
+// points to.
+type imageDigestResolver func(ctx context.Context, image string, authConfig types.DockerAuthConfig) (string, error)
+
+// resolveImageDigest resolves the digest of the image from its registry, without
+// pulling the image.
+func resolveImageDigest(ctx context.Context, image string, authConfig types.DockerAuthConfig) (string, error) {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing image reference")
+	}
+	if digested, ok := named.(reference.Digested); ok {
+		return digested.Digest().String(), nil
+	}
+	tag := "latest"
+	if tagged, ok := named.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	}
+
+	domain := reference.Domain(named)
+	endpoint := "https://" + domain
+	if domain == "docker.io" {
+		endpoint = "https://registry-1.docker.io"
+	}
+
+	// Find out how the registry wants to be authenticated with.
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/v2/", nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		return "", errors.Wrap(err, "pinging registry")
+	}
+	resp.Body.Close()
+	challengeManager := challenge.NewSimpleManager()
+	if err := challengeManager.AddResponse(resp); err != nil {
+		return "", errors.Wrap(err, "reading registry challenge")
+	}
+
+	creds := registryCredentials(authConfig, domain)
+	path := reference.Path(named)
+	rt := transport.NewTransport(http.DefaultTransport, auth.NewAuthorizer(
+		challengeManager,
+		auth.NewTokenHandler(http.DefaultTransport, creds, path, "pull"),
+		auth.NewBasicHandler(creds),
+	))
+
+	name, err := reference.WithName(path)
+	if err != nil {
+		return "", err
+	}
+	repo, err := client.NewRepository(name, endpoint, rt)
+	if err != nil {
+		return "", errors.Wrap(err, "creating registry client")
+	}
+	desc, err := repo.Tags(ctx).Get(ctx, tag)
+	if err != nil {
+		return "", errors.Wrapf(err, "getting manifest of %s", image)
+	}
+	return desc.Digest.String(), nil
+}
+
+// registryCredentials returns the credentials for the registry from the docker
+// auth config, which is keyed by the URL or the host of the registry.
+func registryCredentials(authConfig types.DockerAuthConfig, domain string) auth.CredentialStore {
+	for registry, registryAuth := range authConfig.Auths {
+		host := registry
+		if u, err := url.Parse(registry); err == nil && u.Host != "" {
+			host = u.Host
+		}
+		if normalizeRegistryHost(host) != domain {
+			continue
+		}
+		username, password, _ := strings.Cut(string(registryAuth.Auth), ":")
+		return &basicCredentials{username: username, password: password}
+	}
+	return &basicCredentials{}
+}
+
+// normalizeRegistryHost maps the hosts docker uses for Docker Hub to the domain of
+// normalized image references.
+func normalizeRegistryHost(host string) string {
+	switch host {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return host
+}
+
+type basicCredentials struct {
+	username      string
+	password      string
+	refreshTokens map[string]string
+}
+
+var _ auth.CredentialStore = &basicCredentials{}
+
+func (c *basicCredentials) Basic(*url.URL) (string, string) {
+	return c.username, c.password
+}
+
+func (c *basicCredentials) RefreshToken(_ *url.URL, service string) string {
+	return c.refreshTokens[service]
+}
+
+func (c *basicCredentials) SetRefreshToken(_ *url.URL, service, token string) {
+	if c.refreshTokens == nil {
+		c.refreshTokens = map[string]string{}
+	}
+	c.refreshTokens[service] = token
+}
@@ -0,0 +1,35 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+)
+
+func TestRegistryCredentials(t *testing.T) {
+	authConfig := types.DockerAuthConfig{
+		Auths: types.DockerAuthConfigAuths{
+			"https://index.docker.io/v1/": {Auth: []byte("hub-user:hub-password")},
+			"ghcr.io":                     {Auth: []byte("gh-user:gh-password")},
+		},
+	}
+
+	tests := []struct {
+		domain           string
+		expectedUsername string
+		expectedPassword string
+	}{
+		{domain: "docker.io", expectedUsername: "hub-user", expectedPassword: "hub-password"},
+		{domain: "ghcr.io", expectedUsername: "gh-user", expectedPassword: "gh-password"},
+		{domain: "quay.io"},
+	}
+	for _, test := range tests {
+		t.Run(test.domain, func(t *testing.T) {
+			username, password := registryCredentials(authConfig, test.domain).Basic(nil)
+			assert.Equal(t, test.expectedUsername, username)
+			assert.Equal(t, test.expectedPassword, password)
+		})
+	}
+}
@@ -8,7 +8,6 @@ package worker
 
 import (
 	"context"
-	"io"
 	"io/fs"
 	"os/exec"
 	"sync"
@@ -1754,292 +1753,6 @@ func (c CmdRunnerStatFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockStore is a mock implementation of the Store interface (from the
-// package
-// github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files)
-// used for unit testing.
-type MockStore struct {
-	// ExistsFunc is an instance of a mock function object controlling the
-	// behavior of the method Exists.
-	ExistsFunc *StoreExistsFunc
-	// GetFunc is an instance of a mock function object controlling the
-	// behavior of the method Get.
-	GetFunc *StoreGetFunc
-}
-
-// NewMockStore creates a new mock of the Store interface. All methods
-// return zero values for all results, unless overwritten.
-func NewMockStore() *MockStore {
-	return &MockStore{
-		ExistsFunc: &StoreExistsFunc{
-			defaultHook: func(context.Context, types.Job, string, string) (r0 bool, r1 error) {
-				return
-			},
-		},
-		GetFunc: &StoreGetFunc{
-			defaultHook: func(context.Context, types.Job, string, string) (r0 io.ReadCloser, r1 error) {
-				return
-			},
-		},
-	}
-}
-
-// NewStrictMockStore creates a new mock of the Store interface. All methods
-// panic on invocation, unless overwritten.
-func NewStrictMockStore() *MockStore {
-	return &MockStore{
-		ExistsFunc: &StoreExistsFunc{
-			defaultHook: func(context.Context, types.Job, string, string) (bool, error) {
-				panic("unexpected invocation of MockStore.Exists")
-			},
-		},
-		GetFunc: &StoreGetFunc{
-			defaultHook: func(context.Context, types.Job, string, string) (io.ReadCloser, error) {
-				panic("unexpected invocation of MockStore.Get")
-			},
-		},
-	}
-}
-
-// NewMockStoreFrom creates a new mock of the MockStore interface. All
-// methods delegate to the given implementation, unless overwritten.
-func NewMockStoreFrom(i files.Store) *MockStore {
-	return &MockStore{
-		ExistsFunc: &StoreExistsFunc{
-			defaultHook: i.Exists,
-		},
-		GetFunc: &StoreGetFunc{
-			defaultHook: i.Get,
-		},
-	}
-}
-
-// StoreExistsFunc describes the behavior when the Exists method of the
-// parent MockStore instance is invoked.
-type StoreExistsFunc struct {
-	defaultHook func(context.Context, types.Job, string, string) (bool, error)
-	hooks       []func(context.Context, types.Job, string, string) (bool, error)
-	history     []StoreExistsFuncCall
-	mutex       sync.Mutex
-}
-
-// Exists delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockStore) Exists(v0 context.Context, v1 types.Job, v2 string, v3 string) (bool, error) {
-	r0, r1 := m.ExistsFunc.nextHook()(v0, v1, v2, v3)
-	m.ExistsFunc.appendCall(StoreExistsFuncCall{v0, v1, v2, v3, r0, r1})
-	return r0, r1
-}
-
-// SetDefaultHook sets function that is called when the Exists method of the
-// parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreExistsFunc) SetDefaultHook(hook func(context.Context, types.Job, string, string) (bool, error)) {
-	f.defaultHook = hook
-}
-
-// PushHook adds a function to the end of hook queue. Each invocation of the
-// Exists method of the parent MockStore instance invokes the hook at the
-// front of the queue and discards it. After the queue is empty, the default
-// hook function is invoked for any future action.
-func (f *StoreExistsFunc) PushHook(hook func(context.Context, types.Job, string, string) (bool, error)) {
-	f.mutex.Lock()
-	f.hooks = append(f.hooks, hook)
-	f.mutex.Unlock()
-}
-
-// SetDefaultReturn calls SetDefaultHook with a function that returns the
-// given values.
-func (f *StoreExistsFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context, types.Job, string, string) (bool, error) {
-		return r0, r1
-	})
-}
-
-// PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreExistsFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context, types.Job, string, string) (bool, error) {
-		return r0, r1
-	})
-}
-
-func (f *StoreExistsFunc) nextHook() func(context.Context, types.Job, string, string) (bool, error) {
-	f.mutex.Lock()
-	defer f.mutex.Unlock()
-
-	if len(f.hooks) == 0 {
-		return f.defaultHook
-	}
-
-	hook := f.hooks[0]
-	f.hooks = f.hooks[1:]
-	return hook
-}
-
-func (f *StoreExistsFunc) appendCall(r0 StoreExistsFuncCall) {
-	f.mutex.Lock()
-	f.history = append(f.history, r0)
-	f.mutex.Unlock()
-}
-
-// History returns a sequence of StoreExistsFuncCall objects describing the
-// invocations of this function.
-func (f *StoreExistsFunc) History() []StoreExistsFuncCall {
-	f.mutex.Lock()
-	history := make([]StoreExistsFuncCall, len(f.history))
-	copy(history, f.history)
-	f.mutex.Unlock()
-
-	return history
-}
-
-// StoreExistsFuncCall is an object that describes an invocation of method
-// Exists on an instance of MockStore.
-type StoreExistsFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 types.Job
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 bool
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
-}
-
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c StoreExistsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
-}
-
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c StoreExistsFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
-}
-
-// StoreGetFunc describes the behavior when the Get method of the parent
-// MockStore instance is invoked.
-type StoreGetFunc struct {
-	defaultHook func(context.Context, types.Job, string, string) (io.ReadCloser, error)
-	hooks       []func(context.Context, types.Job, string, string) (io.ReadCloser, error)
-	history     []StoreGetFuncCall
-	mutex       sync.Mutex
-}
-
-// Get delegates to the next hook function in the queue and stores the
-// parameter and result values of this invocation.
-func (m *MockStore) Get(v0 context.Context, v1 types.Job, v2 string, v3 string) (io.ReadCloser, error) {
-	r0, r1 := m.GetFunc.nextHook()(v0, v1, v2, v3)
-	m.GetFunc.appendCall(StoreGetFuncCall{v0, v1, v2, v3, r0, r1})
-	return r0, r1
-}
-
-// SetDefaultHook sets function that is called when the Get method of the
-// parent MockStore instance is invoked and the hook queue is empty.
-func (f *StoreGetFunc) SetDefaultHook(hook func(context.Context, types.Job, string, string) (io.ReadCloser, error)) {
-	f.defaultHook = hook
-}
-
-// PushHook adds a function to the end of hook queue. Each invocation of the
-// Get method of the parent MockStore instance invokes the hook at the front
-// of the queue and discards it. After the queue is empty, the default hook
-// function is invoked for any future action.
-func (f *StoreGetFunc) PushHook(hook func(context.Context, types.Job, string, string) (io.ReadCloser, error)) {
-	f.mutex.Lock()
-	f.hooks = append(f.hooks, hook)
-	f.mutex.Unlock()
-}
-
-// SetDefaultReturn calls SetDefaultHook with a function that returns the
-// given values.
-func (f *StoreGetFunc) SetDefaultReturn(r0 io.ReadCloser, r1 error) {
-	f.SetDefaultHook(func(context.Context, types.Job, string, string) (io.ReadCloser, error) {
-		return r0, r1
-	})
-}
-
-// PushReturn calls PushHook with a function that returns the given values.
-func (f *StoreGetFunc) PushReturn(r0 io.ReadCloser, r1 error) {
-	f.PushHook(func(context.Context, types.Job, string, string) (io.ReadCloser, error) {
-		return r0, r1
-	})
-}
-
-func (f *StoreGetFunc) nextHook() func(context.Context, types.Job, string, string) (io.ReadCloser, error) {
-	f.mutex.Lock()
-	defer f.mutex.Unlock()
-
-	if len(f.hooks) == 0 {
-		return f.defaultHook
-	}
-
-	hook := f.hooks[0]
-	f.hooks = f.hooks[1:]
-	return hook
-}
-
-func (f *StoreGetFunc) appendCall(r0 StoreGetFuncCall) {
-	f.mutex.Lock()
-	f.history = append(f.history, r0)
-	f.mutex.Unlock()
-}
-
-// History returns a sequence of StoreGetFuncCall objects describing the
-// invocations of this function.
-func (f *StoreGetFunc) History() []StoreGetFuncCall {
-	f.mutex.Lock()
-	history := make([]StoreGetFuncCall, len(f.history))
-	copy(history, f.history)
-	f.mutex.Unlock()
-
-	return history
-}
-
-// StoreGetFuncCall is an object that describes an invocation of method Get
-// on an instance of MockStore.
-type StoreGetFuncCall struct {
-	// Arg0 is the value of the 1st argument passed to this method
-	// invocation.
-	Arg0 context.Context
-	// Arg1 is the value of the 2nd argument passed to this method
-	// invocation.
-	Arg1 types.Job
-	// Arg2 is the value of the 3rd argument passed to this method
-	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 string
-	// Result0 is the value of the 1st result returned from this method
-	// invocation.
-	Result0 io.ReadCloser
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 error
-}
-
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c StoreGetFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
-}
-
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c StoreGetFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0, c.Result1}
-}
-
 // MockRuntime is a mock implementation of the Runtime interface (from the
 // package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/runtime)
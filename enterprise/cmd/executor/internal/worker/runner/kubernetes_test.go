@@ -17,6 +17,7 @@ import (
 	k8stesting "k8s.io/client-go/testing"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	filesmocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/runner"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -24,7 +25,7 @@ import (
 )
 
 func TestKubernetesRunner_Setup(t *testing.T) {
-	filesStore := runner.NewMockStore()
+	filesStore := filesmocks.NewMockStore()
 	kubernetesRunner := runner.NewKubernetesRunner(nil, nil, "", filesStore, command.KubernetesContainerOptions{})
 
 	ctx := context.Background()
@@ -33,7 +34,7 @@ func TestKubernetesRunner_Setup(t *testing.T) {
 }
 
 func TestKubernetesRunner_TempDir(t *testing.T) {
-	filesStore := runner.NewMockStore()
+	filesStore := filesmocks.NewMockStore()
 	kubernetesRunner := runner.NewKubernetesRunner(nil, nil, "", filesStore, command.KubernetesContainerOptions{})
 	dir := kubernetesRunner.TempDir()
 	assert.Empty(t, dir)
@@ -122,7 +123,7 @@ func TestKubernetesRunner_Run(t *testing.T) {
 			teardownLogEntry := runner.NewMockLogEntry()
 			logger.LogEntryFunc.PushReturn(logEntry)
 			logger.LogEntryFunc.PushReturn(teardownLogEntry)
-			fileStore := runner.NewMockStore()
+			fileStore := filesmocks.NewMockStore()
 
 			dir := t.TempDir()
 			options := command.KubernetesContainerOptions{
@@ -185,7 +186,7 @@ func TestKubernetesRunner_Teardown(t *testing.T) {
 	logger := runner.NewMockLogger()
 	logEntry := runner.NewMockLogEntry()
 	logger.LogEntryFunc.PushReturn(logEntry)
-	filesStore := runner.NewMockStore()
+	filesStore := filesmocks.NewMockStore()
 	kubernetesRunner := runner.NewKubernetesRunner(cmd, logger, "", filesStore, command.KubernetesContainerOptions{})
 
 	err := kubernetesRunner.Teardown(context.Background())
@@ -0,0 +1,149 @@
+package runner_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/runner"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+)
+
+func TestPodmanRunner_Run(t *testing.T) {
+	tests := []struct {
+		name             string
+		options          command.PodmanOptions
+		dockerAuthConfig types.DockerAuthConfig
+		expectedCommand  []string
+		expectedAuth     string
+	}{
+		{
+			name: "Resources and host gateway",
+			options: command.PodmanOptions{
+				AddHostGateway: true,
+				Resources: command.ResourceOptions{
+					NumCPUs:   10,
+					Memory:    "1G",
+					DiskSpace: "10G",
+				},
+			},
+			expectedCommand: []string{
+				"podman",
+				"run",
+				"--rm",
+				"--add-host=host.docker.internal:host-gateway",
+				"--cpus",
+				"10",
+				"--memory",
+				"1G",
+				"-v",
+				"/some/dir:/data",
+				"-w",
+				"/data/workingdir",
+				"-e",
+				"FOO=bar",
+				"--entrypoint",
+				"/bin/sh",
+				"alpine",
+				"/data/.sourcegraph-executor/some/script",
+			},
+		},
+		{
+			name: "Docker auth",
+			options: command.PodmanOptions{
+				Path: "/usr/bin/podman",
+				DockerAuthConfig: types.DockerAuthConfig{
+					Auths: map[string]types.DockerAuthConfigAuth{
+						"index.docker.io": {
+							Auth: []byte("foobar"),
+						},
+					},
+				},
+			},
+			dockerAuthConfig: types.DockerAuthConfig{
+				Auths: map[string]types.DockerAuthConfigAuth{
+					"index.docker.io": {
+						Auth: []byte("fazbaz"),
+					},
+				},
+			},
+			expectedCommand: []string{
+				"/usr/bin/podman",
+				"run",
+				"--rm",
+				"--authfile",
+				"AUTH_FILE",
+				"-v",
+				"/some/dir:/data",
+				"-w",
+				"/data/workingdir",
+				"-e",
+				"FOO=bar",
+				"--entrypoint",
+				"/bin/sh",
+				"alpine",
+				"/data/.sourcegraph-executor/some/script",
+			},
+			expectedAuth: `{"auths":{"index.docker.io":{"auth":"ZmF6YmF6"}}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := runner.NewMockCommand()
+			logger := runner.NewMockLogger()
+			spec := runner.Spec{
+				CommandSpecs: []command.Spec{
+					{
+						Key:     "some-key",
+						Command: []string{"echo", "hello"},
+						Dir:     "/workingdir",
+						Env:     []string{"FOO=bar"},
+					},
+				},
+				Image:      "alpine",
+				ScriptPath: "/some/script",
+			}
+
+			podmanRunner := runner.NewPodmanRunner(cmd, logger, "/some/dir", test.options, test.dockerAuthConfig)
+			ctx := context.Background()
+			require.NoError(t, podmanRunner.Setup(ctx))
+			defer podmanRunner.Teardown(ctx)
+
+			cmd.RunFunc.PushReturn(nil)
+			require.NoError(t, podmanRunner.Run(ctx, spec))
+
+			require.Len(t, cmd.RunFunc.History(), 1)
+			actual := cmd.RunFunc.History()[0].Arg2
+			assert.Equal(t, "some-key", actual.Key)
+			if test.expectedAuth != "" {
+				// The auth file is written to a temporary directory.
+				authFile := actual.Command[4]
+				f, err := os.ReadFile(authFile)
+				require.NoError(t, err)
+				assert.JSONEq(t, test.expectedAuth, string(f))
+				actual.Command[4] = "AUTH_FILE"
+			}
+			assert.Equal(t, test.expectedCommand, actual.Command)
+		})
+	}
+}
+
+func TestPodmanRunner_Teardown(t *testing.T) {
+	podmanRunner := runner.NewPodmanRunner(nil, nil, "", command.PodmanOptions{}, types.DockerAuthConfig{})
+	ctx := context.Background()
+	require.NoError(t, podmanRunner.Setup(ctx))
+
+	dir := podmanRunner.TempDir()
+	_, err := os.Stat(dir)
+	require.NoError(t, err)
+
+	require.NoError(t, podmanRunner.Teardown(ctx))
+
+	_, err = os.Stat(dir)
+	require.Error(t, err)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -48,6 +48,12 @@ func (r *dockerRuntime) NewRunner(ctx context.Context, logger cmdlogger.Logger,
 }
 
 func (r *dockerRuntime) NewRunnerSpecs(ws workspace.Workspace, job types.Job) ([]runner.Spec, error) {
+	return newContainerRunnerSpecs(r.operations, ws, job), nil
+}
+
+// newContainerRunnerSpecs returns the specs to run every step of the job in a
+// one-shot container on the host.
+func newContainerRunnerSpecs(operations *command.Operations, ws workspace.Workspace, job types.Job) []runner.Spec {
 	runnerSpecs := make([]runner.Spec, len(job.DockerSteps))
 	for i, step := range job.DockerSteps {
 		runnerSpecs[i] = runner.Spec{
@@ -58,7 +64,7 @@ func (r *dockerRuntime) NewRunnerSpecs(ws workspace.Workspace, job types.Job) ([
 					Command:   nil,
 					Dir:       step.Dir,
 					Env:       step.Env,
-					Operation: r.operations.Exec,
+					Operation: operations.Exec,
 				},
 			},
 			Image:      step.Image,
@@ -66,7 +72,7 @@ func (r *dockerRuntime) NewRunnerSpecs(ws workspace.Workspace, job types.Job) ([
 		}
 	}
 
-	return runnerSpecs, nil
+	return runnerSpecs
 }
 
 func dockerKey(stepKey string, index int) string {
@@ -0,0 +1,53 @@
+package runtime
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/cmdlogger"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/runner"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/workspace"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type podmanRuntime struct {
+	cmd          command.Command
+	operations   *command.Operations
+	filesStore   files.Store
+	cloneOptions workspace.CloneOptions
+	podmanOpts   command.PodmanOptions
+}
+
+var _ Runtime = &podmanRuntime{}
+
+func (r *podmanRuntime) Name() Name {
+	return NamePodman
+}
+
+func (r *podmanRuntime) PrepareWorkspace(ctx context.Context, logger cmdlogger.Logger, job types.Job) (workspace.Workspace, error) {
+	// The workspace is a directory on the host that is mounted into the
+	// containers, the same as with Docker.
+	return workspace.NewDockerWorkspace(
+		ctx,
+		r.filesStore,
+		job,
+		r.cmd,
+		logger,
+		r.cloneOptions,
+		r.operations,
+	)
+}
+
+func (r *podmanRuntime) NewRunner(ctx context.Context, logger cmdlogger.Logger, filesStore files.Store, options RunnerOptions) (runner.Runner, error) {
+	run := runner.NewPodmanRunner(r.cmd, logger, options.Path, r.podmanOpts, options.DockerAuthConfig)
+	if err := run.Setup(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to setup podman runner")
+	}
+	return run, nil
+}
+
+func (r *podmanRuntime) NewRunnerSpecs(ws workspace.Workspace, job types.Job) ([]runner.Spec, error) {
+	return newContainerRunnerSpecs(r.operations, ws, job), nil
+}
@@ -0,0 +1,56 @@
+package runtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestPodmanRuntime_Name(t *testing.T) {
+	r := podmanRuntime{}
+	assert.Equal(t, "podman", string(r.Name()))
+}
+
+func TestPodmanRuntime_NewRunnerSpecs(t *testing.T) {
+	operations := command.NewOperations(&observation.TestContext)
+
+	ws := NewMockWorkspace()
+	ws.ScriptFilenamesFunc.SetDefaultReturn([]string{"script1.sh", "script2.sh"})
+
+	r := &podmanRuntime{operations: operations}
+	actual, err := r.NewRunnerSpecs(ws, types.Job{
+		DockerSteps: []types.DockerStep{
+			{
+				Key:      "key-1",
+				Image:    "my-image",
+				Commands: []string{"echo", "hello"},
+				Dir:      ".",
+				Env:      []string{"FOO=bar"},
+			},
+			{
+				Image:    "my-image",
+				Commands: []string{"echo", "hello"},
+				Dir:      ".",
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, actual, 2)
+
+	assert.Equal(t, "my-image", actual[0].Image)
+	assert.Equal(t, "script1.sh", actual[0].ScriptPath)
+	assert.Equal(t, command.Spec{
+		Key:       "step.docker.key-1",
+		Dir:       ".",
+		Env:       []string{"FOO=bar"},
+		Operation: operations.Exec,
+	}, actual[0].CommandSpecs[0])
+
+	assert.Equal(t, "script2.sh", actual[1].ScriptPath)
+	assert.Equal(t, "step.docker.1", actual[1].CommandSpecs[0].Key)
+}
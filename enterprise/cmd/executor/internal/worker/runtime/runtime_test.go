@@ -103,6 +103,47 @@ func TestNew(t *testing.T) {
 			},
 			expectedErr: errors.New("4 errors occurred:\n\t* dmsetup not found in PATH, is it installed?\n\t* losetup not found in PATH, is it installed?\n\t* mkfs.ext4 not found in PATH, is it installed?\n\t* strings not found in PATH, is it installed?"),
 		},
+		{
+			name: "Podman",
+			runnerOpts: runner.Options{
+				PodmanOptions: runner.PodmanOptions{
+					Enabled: true,
+					ContainerOptions: command.PodmanOptions{
+						Path: "/usr/bin/podman",
+					},
+				},
+			},
+			mockFunc: func(cmdRunner *runtime.MockCmdRunner) {
+				cmdRunner.LookPathFunc.SetDefaultReturn("", nil)
+			},
+			expectedName: runtime.NamePodman,
+			assertMockFunc: func(t *testing.T, cmdRunner *runtime.MockCmdRunner) {
+				require.Len(t, cmdRunner.LookPathFunc.History(), 3)
+				assert.Equal(t, "/usr/bin/podman", cmdRunner.LookPathFunc.History()[0].Arg0)
+				assert.Equal(t, "git", cmdRunner.LookPathFunc.History()[1].Arg0)
+				assert.Equal(t, "src", cmdRunner.LookPathFunc.History()[2].Arg0)
+			},
+		},
+		{
+			name: "Missing Podman",
+			runnerOpts: runner.Options{
+				PodmanOptions: runner.PodmanOptions{
+					Enabled: true,
+					ContainerOptions: command.PodmanOptions{
+						Path: "podman",
+					},
+				},
+			},
+			mockFunc: func(cmdRunner *runtime.MockCmdRunner) {
+				cmdRunner.LookPathFunc.PushReturn("", exec.ErrNotFound)
+				cmdRunner.LookPathFunc.SetDefaultReturn("", nil)
+			},
+			expectedName: runtime.NamePodman,
+			assertMockFunc: func(t *testing.T, cmdRunner *runtime.MockCmdRunner) {
+				require.Len(t, cmdRunner.LookPathFunc.History(), 3)
+			},
+			expectedErr: errors.New("podman not found in PATH, is it installed?"),
+		},
 		{
 			name: "Ignite not installed",
 			runnerOpts: runner.Options{
@@ -293,6 +334,13 @@ func TestCommandKey(t *testing.T) {
 			index:       1,
 			expectedKey: "step.docker.1",
 		},
+		{
+			name:        "Podman",
+			runtimeName: runtime.NamePodman,
+			key:         "step.1.pre",
+			index:       0,
+			expectedKey: "step.docker.step.1.pre",
+		},
 		{
 			name:        "Kubernetes",
 			runtimeName: runtime.NameKubernetes,
@@ -0,0 +1,125 @@
+package worker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// resolveStepCacheImages resolves the digests of the images in the step cache images
+// file of the job, so that the step cache keys change when a tag is moved to another
+// image. Images whose digest cannot be resolved are left without a digest, and the
+// results of their steps are not cached.
+func (h *handler) resolveStepCacheImages(ctx context.Context, logger log.Logger, job types.Job, workingDirectory string) error {
+	file, ok := job.VirtualMachineFiles[types.StepCacheImagesFile]
+	if !ok {
+		return nil
+	}
+	var images types.StepCacheImages
+	if err := json.Unmarshal(file.Content, &images); err != nil {
+		return errors.Wrap(err, "unmarshalling step cache images file")
+	}
+
+	authConfig := h.options.RunnerOptions.DockerOptions.DockerAuthConfig
+	if len(job.DockerAuthConfig.Auths) > 0 {
+		authConfig = job.DockerAuthConfig
+	}
+	for image := range images {
+		digest, err := h.resolveImageDigest(ctx, image, authConfig)
+		if err != nil {
+			logger.Warn("Failed to resolve image digest", log.String("image", image), log.Error(err))
+			continue
+		}
+		images[image] = digest
+	}
+
+	b, err := json.Marshal(images)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(os.WriteFile(filepath.Join(workingDirectory, types.StepCacheImagesFile), b, os.ModePerm), "writing step cache images file")
+}
+
+// lookupStepCache checks if the step cache holds a result for the step the pre step
+// wrote the step cache file for. If it does, the result is written to the working
+// directory for the post step to apply, and the key of the post step is returned so
+// that running the step itself can be skipped.
+func (h *handler) lookupStepCache(ctx context.Context, job types.Job, workingDirectory string) (string, error) {
+	stepCache, err := readStepCacheFile(workingDirectory)
+	if err != nil || stepCache == nil || len(stepCache.Result) > 0 {
+		return "", err
+	}
+
+	exists, err := h.filesStore.Exists(ctx, job, types.StepCacheBucket, stepCache.Key)
+	if err != nil {
+		return "", errors.Wrap(err, "checking step cache")
+	}
+	if !exists {
+		return "", nil
+	}
+
+	content, err := h.filesStore.Get(ctx, job, types.StepCacheBucket, stepCache.Key)
+	if err != nil {
+		return "", errors.Wrap(err, "getting cached step result")
+	}
+	defer content.Close()
+	b, err := io.ReadAll(content)
+	if err != nil {
+		return "", errors.Wrap(err, "reading cached step result")
+	}
+	if err = os.WriteFile(filepath.Join(workingDirectory, types.StepCacheHitFile), b, os.ModePerm); err != nil {
+		return "", errors.Wrap(err, "writing cached step result")
+	}
+
+	return stepCache.NextStep, nil
+}
+
+// storeStepCache uploads the result of the step the post step wrote to the step
+// cache file to the step cache.
+func (h *handler) storeStepCache(ctx context.Context, job types.Job, workingDirectory string) error {
+	stepCache, err := readStepCacheFile(workingDirectory)
+	if err != nil || stepCache == nil {
+		return err
+	}
+	// Try to remove the step cache file, so it is not picked up by the next step.
+	defer os.Remove(filepath.Join(workingDirectory, types.StepCacheFile))
+
+	if len(stepCache.Result) == 0 {
+		return nil
+	}
+	if err = h.filesStore.Upload(ctx, job, types.StepCacheBucket, stepCache.Key, bytes.NewReader(stepCache.Result)); err != nil {
+		return errors.Wrap(err, "uploading step result")
+	}
+	return nil
+}
+
+func readStepCacheFile(workingDirectory string) (*types.StepCache, error) {
+	b, err := os.ReadFile(filepath.Join(workingDirectory, types.StepCacheFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "reading step cache file")
+	}
+	var stepCache types.StepCache
+	if err = json.Unmarshal(b, &stepCache); err != nil {
+		return nil, errors.Wrap(err, "unmarshalling step cache file")
+	}
+	return &stepCache, nil
+}
+
+// logStepCacheError logs errors interacting with the step cache. The step cache is
+// an optimization, so failing to use it does not fail the job.
+func logStepCacheError(logger log.Logger, err error) {
+	if err != nil {
+		logger.Warn("Failed to use step cache", log.Error(err))
+	}
+}
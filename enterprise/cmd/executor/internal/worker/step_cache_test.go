@@ -0,0 +1,245 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	filesmocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files/mocks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestHandler_LookupStepCache(t *testing.T) {
+	tests := []struct {
+		name              string
+		stepCache         string
+		mockFunc          func(filesStore *filesmocks.MockStore)
+		expectedNextStep  string
+		expectedHitFile   string
+		expectedNoHitFile bool
+		expectedErr       error
+		assertMockFunc    func(t *testing.T, filesStore *filesmocks.MockStore)
+	}{
+		{
+			name:              "No step cache file",
+			expectedNoHitFile: true,
+			assertMockFunc: func(t *testing.T, filesStore *filesmocks.MockStore) {
+				require.Len(t, filesStore.ExistsFunc.History(), 0)
+			},
+		},
+		{
+			name:              "Step result already set",
+			stepCache:         `{"key":"some-key","nextStep":"step.1.post","result":{"stdout":"hello"}}`,
+			expectedNoHitFile: true,
+			assertMockFunc: func(t *testing.T, filesStore *filesmocks.MockStore) {
+				require.Len(t, filesStore.ExistsFunc.History(), 0)
+			},
+		},
+		{
+			name:      "Cache miss",
+			stepCache: `{"key":"some-key","nextStep":"step.1.post"}`,
+			mockFunc: func(filesStore *filesmocks.MockStore) {
+				filesStore.ExistsFunc.PushReturn(false, nil)
+			},
+			expectedNoHitFile: true,
+			assertMockFunc: func(t *testing.T, filesStore *filesmocks.MockStore) {
+				require.Len(t, filesStore.ExistsFunc.History(), 1)
+				assert.Equal(t, types.StepCacheBucket, filesStore.ExistsFunc.History()[0].Arg2)
+				assert.Equal(t, "some-key", filesStore.ExistsFunc.History()[0].Arg3)
+				require.Len(t, filesStore.GetFunc.History(), 0)
+			},
+		},
+		{
+			name:      "Cache hit",
+			stepCache: `{"key":"some-key","nextStep":"step.1.post"}`,
+			mockFunc: func(filesStore *filesmocks.MockStore) {
+				filesStore.ExistsFunc.PushReturn(true, nil)
+				filesStore.GetFunc.PushReturn(io.NopCloser(strings.NewReader(`{"stdout":"hello"}`)), nil)
+			},
+			expectedNextStep: "step.1.post",
+			expectedHitFile:  `{"stdout":"hello"}`,
+			assertMockFunc: func(t *testing.T, filesStore *filesmocks.MockStore) {
+				require.Len(t, filesStore.GetFunc.History(), 1)
+				assert.Equal(t, types.StepCacheBucket, filesStore.GetFunc.History()[0].Arg2)
+				assert.Equal(t, "some-key", filesStore.GetFunc.History()[0].Arg3)
+			},
+		},
+		{
+			name:      "Failed to check cache",
+			stepCache: `{"key":"some-key","nextStep":"step.1.post"}`,
+			mockFunc: func(filesStore *filesmocks.MockStore) {
+				filesStore.ExistsFunc.PushReturn(false, errors.New("failed"))
+			},
+			expectedNoHitFile: true,
+			expectedErr:       errors.New("checking step cache: failed"),
+		},
+		{
+			name:              "Invalid step cache file",
+			stepCache:         `{`,
+			expectedNoHitFile: true,
+			expectedErr:       errors.New("unmarshalling step cache file: unexpected end of JSON input"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if test.stepCache != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, types.StepCacheFile), []byte(test.stepCache), os.ModePerm))
+			}
+
+			filesStore := filesmocks.NewMockStore()
+			if test.mockFunc != nil {
+				test.mockFunc(filesStore)
+			}
+
+			h := &handler{filesStore: filesStore}
+			nextStep, err := h.lookupStepCache(context.Background(), types.Job{ID: 42}, dir)
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedNextStep, nextStep)
+
+			b, err := os.ReadFile(filepath.Join(dir, types.StepCacheHitFile))
+			if test.expectedNoHitFile {
+				assert.True(t, os.IsNotExist(err))
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedHitFile, string(b))
+			}
+
+			if test.assertMockFunc != nil {
+				test.assertMockFunc(t, filesStore)
+			}
+		})
+	}
+}
+
+func TestHandler_StoreStepCache(t *testing.T) {
+	tests := []struct {
+		name           string
+		stepCache      string
+		mockFunc       func(filesStore *filesmocks.MockStore)
+		expectedErr    error
+		assertMockFunc func(t *testing.T, filesStore *filesmocks.MockStore)
+	}{
+		{
+			name: "No step cache file",
+			assertMockFunc: func(t *testing.T, filesStore *filesmocks.MockStore) {
+				require.Len(t, filesStore.UploadFunc.History(), 0)
+			},
+		},
+		{
+			name:      "No step result",
+			stepCache: `{"key":"some-key","nextStep":"step.1.post"}`,
+			assertMockFunc: func(t *testing.T, filesStore *filesmocks.MockStore) {
+				require.Len(t, filesStore.UploadFunc.History(), 0)
+			},
+		},
+		{
+			name:      "Upload step result",
+			stepCache: `{"key":"some-key","nextStep":"step.1.post","result":{"stdout":"hello"}}`,
+			mockFunc: func(filesStore *filesmocks.MockStore) {
+				filesStore.UploadFunc.PushReturn(nil)
+			},
+			assertMockFunc: func(t *testing.T, filesStore *filesmocks.MockStore) {
+				require.Len(t, filesStore.UploadFunc.History(), 1)
+				assert.Equal(t, types.StepCacheBucket, filesStore.UploadFunc.History()[0].Arg2)
+				assert.Equal(t, "some-key", filesStore.UploadFunc.History()[0].Arg3)
+				b, err := io.ReadAll(filesStore.UploadFunc.History()[0].Arg4)
+				require.NoError(t, err)
+				assert.Equal(t, `{"stdout":"hello"}`, string(b))
+			},
+		},
+		{
+			name:      "Failed to upload step result",
+			stepCache: `{"key":"some-key","nextStep":"step.1.post","result":{"stdout":"hello"}}`,
+			mockFunc: func(filesStore *filesmocks.MockStore) {
+				filesStore.UploadFunc.PushReturn(errors.New("failed"))
+			},
+			expectedErr: errors.New("uploading step result: failed"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if test.stepCache != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(dir, types.StepCacheFile), []byte(test.stepCache), os.ModePerm))
+			}
+
+			filesStore := filesmocks.NewMockStore()
+			if test.mockFunc != nil {
+				test.mockFunc(filesStore)
+			}
+
+			h := &handler{filesStore: filesStore}
+			err := h.storeStepCache(context.Background(), types.Job{ID: 42}, dir)
+			if test.expectedErr != nil {
+				require.Error(t, err)
+				assert.EqualError(t, err, test.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+
+			_, err = os.Stat(filepath.Join(dir, types.StepCacheFile))
+			assert.True(t, os.IsNotExist(err))
+
+			if test.assertMockFunc != nil {
+				test.assertMockFunc(t, filesStore)
+			}
+		})
+	}
+}
+
+func TestHandler_ResolveStepCacheImages(t *testing.T) {
+	tests := []struct {
+		name           string
+		job            types.Job
+		expectedImages string
+	}{
+		{
+			name: "No step cache images file",
+		},
+		{
+			name: "Resolves digests",
+			job: types.Job{
+				VirtualMachineFiles: map[string]types.VirtualMachineFile{
+					types.StepCacheImagesFile: {Content: []byte(`{"alpine:3":"","private/image:1":""}`)},
+				},
+			},
+			expectedImages: `{"alpine:3":"sha256:abc","private/image:1":""}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			h := &handler{resolveImageDigest: func(ctx context.Context, image string, authConfig types.DockerAuthConfig) (string, error) {
+				if image == "alpine:3" {
+					return "sha256:abc", nil
+				}
+				return "", errors.New("unauthorized")
+			}}
+			err := h.resolveStepCacheImages(context.Background(), logtest.Scoped(t), test.job, dir)
+			require.NoError(t, err)
+
+			b, err := os.ReadFile(filepath.Join(dir, types.StepCacheImagesFile))
+			if test.expectedImages == "" {
+				assert.True(t, os.IsNotExist(err))
+			} else {
+				require.NoError(t, err)
+				assert.JSONEq(t, test.expectedImages, string(b))
+			}
+		})
+	}
+}
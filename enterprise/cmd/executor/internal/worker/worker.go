@@ -115,15 +115,16 @@ func NewWorker(observationCtx *observation.Context, nameSet *janitor.NameSet, op
 	}
 
 	h := &handler{
-		nameSet:      nameSet,
-		cmdRunner:    cmdRunner,
-		cmd:          cmd,
-		logStore:     queueClient,
-		filesStore:   filesClient,
-		options:      options,
-		cloneOptions: cloneOptions,
-		operations:   commandOps,
-		jobRuntime:   jobRuntime,
+		nameSet:            nameSet,
+		cmdRunner:          cmdRunner,
+		cmd:                cmd,
+		logStore:           queueClient,
+		filesStore:         filesClient,
+		options:            options,
+		cloneOptions:       cloneOptions,
+		operations:         commandOps,
+		jobRuntime:         jobRuntime,
+		resolveImageDigest: resolveImageDigest,
 	}
 
 	return workerutil.NewWorker[types.Job](context.Background(), queueClient, h, options.WorkerOptions), nil
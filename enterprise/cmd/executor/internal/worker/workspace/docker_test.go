@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	filesmocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/workspace"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -26,8 +27,8 @@ func TestNewDockerWorkspace(t *testing.T) {
 		name                   string
 		job                    types.Job
 		cloneOptions           workspace.CloneOptions
-		mockFunc               func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand)
-		assertMockFunc         func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string)
+		mockFunc               func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand)
+		assertMockFunc         func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string)
 		expectedWorkspaceFiles map[string]string
 		expectedDockerScripts  map[string][]string
 		expectedErr            error
@@ -39,10 +40,10 @@ func TestNewDockerWorkspace(t *testing.T) {
 				Token:  "token",
 				Commit: "commit",
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 0)
 			},
@@ -55,11 +56,11 @@ func TestNewDockerWorkspace(t *testing.T) {
 				Commit:         "commit",
 				RepositoryName: "my-repo",
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 6)
 				// Init
@@ -149,11 +150,11 @@ func TestNewDockerWorkspace(t *testing.T) {
 				Commit:         "commit",
 				RepositoryName: "my-repo",
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				cmd.RunFunc.SetDefaultReturn(errors.New("failed"))
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 1)
 			},
@@ -168,11 +169,11 @@ func TestNewDockerWorkspace(t *testing.T) {
 				RepositoryName:      "my-repo",
 				RepositoryDirectory: "/my/dir",
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 6)
 				repoDir := path.Join(tempDir, "/my/dir")
@@ -241,11 +242,11 @@ func TestNewDockerWorkspace(t *testing.T) {
 				RepositoryName: "my-repo",
 				FetchTags:      true,
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 6)
 				assert.Equal(t, []string{
@@ -272,11 +273,11 @@ func TestNewDockerWorkspace(t *testing.T) {
 				RepositoryName: "my-repo",
 				ShallowClone:   true,
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 6)
 				assert.Equal(t, []string{
@@ -304,11 +305,11 @@ func TestNewDockerWorkspace(t *testing.T) {
 				RepositoryName: "my-repo",
 				SparseCheckout: []string{"foo/bar/**"},
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 8)
 				// Fetch
@@ -384,11 +385,11 @@ func TestNewDockerWorkspace(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				filesStore.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("content2")), nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, logger.LogEntryFunc.History(), 1)
 				require.Len(t, cmd.RunFunc.History(), 0)
 				require.Len(t, filesStore.GetFunc.History(), 1)
@@ -424,11 +425,11 @@ func TestNewDockerWorkspace(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				filesStore.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("content2")), nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, logger.LogEntryFunc.History(), 1)
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 0)
@@ -441,7 +442,7 @@ func TestNewDockerWorkspace(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			filesStore := workspace.NewMockStore()
+			filesStore := filesmocks.NewMockStore()
 			cmd := workspace.NewMockCommand()
 			logger := workspace.NewMockLogger()
 
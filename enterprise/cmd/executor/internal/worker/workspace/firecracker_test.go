@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/command"
+	filesmocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/files/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/executor/internal/worker/workspace"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -26,8 +27,8 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 		name                   string
 		job                    types.Job
 		cloneOptions           workspace.CloneOptions
-		mockFunc               func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string)
-		assertMockFunc         func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string)
+		mockFunc               func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string)
+		assertMockFunc         func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string)
 		expectedWorkspaceFiles map[string]string
 		expectedDockerScripts  map[string][]string
 		expectedErr            error
@@ -39,7 +40,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				Token:  "token",
 				Commit: "commit",
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				// losetup --find
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte(tempDir), nil)
@@ -50,7 +51,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				// losetup --detach
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte{}, nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 0)
 			},
@@ -63,7 +64,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				Commit:         "commit",
 				RepositoryName: "my-repo",
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				// losetup --find
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte(tempDir), nil)
@@ -75,7 +76,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte{}, nil)
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 6)
 				// Init
@@ -165,7 +166,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				Commit:         "commit",
 				RepositoryName: "my-repo",
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				// losetup --find
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte(tempDir), nil)
@@ -177,7 +178,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte{}, nil)
 				cmd.RunFunc.SetDefaultReturn(errors.New("failed"))
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 1)
 			},
@@ -192,7 +193,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				RepositoryName:      "my-repo",
 				RepositoryDirectory: "/my/dir",
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				// losetup --find
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte(tempDir), nil)
@@ -204,7 +205,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte{}, nil)
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 6)
 				repoDir := path.Join(tempDir, "/my/dir")
@@ -273,7 +274,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				RepositoryName: "my-repo",
 				FetchTags:      true,
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				// losetup --find
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte(tempDir), nil)
@@ -285,7 +286,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte{}, nil)
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 6)
 				assert.Equal(t, []string{
@@ -312,7 +313,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				RepositoryName: "my-repo",
 				ShallowClone:   true,
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				// losetup --find
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte(tempDir), nil)
@@ -324,7 +325,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte{}, nil)
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 6)
 				assert.Equal(t, []string{
@@ -352,7 +353,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				RepositoryName: "my-repo",
 				SparseCheckout: []string{"foo/bar/**"},
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				// losetup --find
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte(tempDir), nil)
@@ -364,7 +365,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte{}, nil)
 				cmd.RunFunc.SetDefaultReturn(nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 8)
 				// Fetch
@@ -440,7 +441,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				// losetup --find
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte(tempDir), nil)
@@ -452,7 +453,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte{}, nil)
 				filesStore.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("content2")), nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, logger.LogEntryFunc.History(), 2)
 				require.Len(t, cmd.RunFunc.History(), 0)
 				require.Len(t, filesStore.GetFunc.History(), 1)
@@ -488,7 +489,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 					},
 				},
 			},
-			mockFunc: func(logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			mockFunc: func(logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				logger.LogEntryFunc.SetDefaultReturn(workspace.NewMockLogEntry())
 				// losetup --find
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte(tempDir), nil)
@@ -500,7 +501,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 				cmdRunner.CombinedOutputFunc.PushReturn([]byte{}, nil)
 				filesStore.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader("content2")), nil)
 			},
-			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *workspace.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
+			assertMockFunc: func(t *testing.T, logger *workspace.MockLogger, filesStore *filesmocks.MockStore, cmdRunner *workspace.MockCmdRunner, cmd *workspace.MockCommand, tempDir string) {
 				require.Len(t, logger.LogEntryFunc.History(), 2)
 				require.Len(t, filesStore.GetFunc.History(), 0)
 				require.Len(t, cmd.RunFunc.History(), 0)
@@ -514,7 +515,7 @@ func TestNewFirecrackerWorkspace(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			cmdRunner := workspace.NewMockCmdRunner()
-			filesStore := workspace.NewMockStore()
+			filesStore := filesmocks.NewMockStore()
 			cmd := workspace.NewMockCommand()
 			logger := workspace.NewMockLogger()
 
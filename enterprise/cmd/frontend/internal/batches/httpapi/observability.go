@@ -11,6 +11,10 @@ type Operations struct {
 	get    *observation.Operation
 	exists *observation.Operation
 	upload *observation.Operation
+
+	stepCacheGet    *observation.Operation
+	stepCacheExists *observation.Operation
+	stepCacheUpload *observation.Operation
 }
 
 func NewOperations(observationCtx *observation.Context) *Operations {
@@ -33,5 +37,9 @@ func NewOperations(observationCtx *observation.Context) *Operations {
 		get:    op("get"),
 		exists: op("exists"),
 		upload: op("upload"),
+
+		stepCacheGet:    op("stepCacheGet"),
+		stepCacheExists: op("stepCacheExists"),
+		stepCacheUpload: op("stepCacheUpload"),
 	}
 }
@@ -0,0 +1,222 @@
+package httpapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-enry/go-enry/v2/regex"
+	"github.com/gorilla/mux"
+	sglog "github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// StepCacheHandler handles retrieving and uploading of cached step results. It
+// serves both executors, which authenticate with the token of the job they run,
+// and users running src-cli, so that both share the cached results of a user.
+type StepCacheHandler struct {
+	logger      sglog.Logger
+	store       StepCacheStore
+	uploadStore uploadstore.Store
+	operations  *Operations
+}
+
+type StepCacheStore interface {
+	GetBatchSpecWorkspaceExecutionJob(context.Context, store.GetBatchSpecWorkspaceExecutionJobOpts) (*btypes.BatchSpecWorkspaceExecutionJob, error)
+}
+
+// NewStepCacheHandler creates a new StepCacheHandler.
+func NewStepCacheHandler(store StepCacheStore, uploadStore uploadstore.Store, operations *Operations) *StepCacheHandler {
+	return &StepCacheHandler{
+		logger:      sglog.Scoped("StepCacheHandler", "Batch Changes step cache REST API handler"),
+		store:       store,
+		uploadStore: uploadStore,
+		operations:  operations,
+	}
+}
+
+// Get retrieves the cached step result.
+func (h *StepCacheHandler) Get() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		responseBody, statusCode, err := h.get(r)
+
+		if err != nil {
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		w.WriteHeader(statusCode)
+
+		if responseBody != nil {
+			defer responseBody.Close()
+			w.Header().Set("Content-Type", "application/json")
+
+			if _, err := io.Copy(w, responseBody); err != nil {
+				h.logger.Error("failed to write payload to client", sglog.Error(err))
+			}
+		}
+	})
+}
+
+func (h *StepCacheHandler) get(r *http.Request) (_ io.ReadCloser, statusCode int, err error) {
+	ctx, _, endObservation := h.operations.stepCacheGet.With(r.Context(), &err, observation.Args{})
+	defer func() {
+		endObservation(1, observation.Args{Attrs: []attribute.KeyValue{
+			attribute.Int("statusCode", statusCode),
+		}})
+	}()
+
+	key, statusCode, err := h.objectKey(ctx, r)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	// The upload store only reports that an object does not exist once it is read,
+	// so check for it first to respond with the right status code.
+	exists, err := h.uploadStore.Exists(ctx, key)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "checking cached step result")
+	}
+	if !exists {
+		return nil, http.StatusNotFound, errors.New("cached step result does not exist")
+	}
+
+	content, err := h.uploadStore.Get(ctx, key)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.Wrap(err, "getting cached step result")
+	}
+	return content, http.StatusOK, nil
+}
+
+// Exists checks if the cached step result exists.
+func (h *StepCacheHandler) Exists() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statusCode, err := h.exists(r)
+
+		if err != nil {
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		w.WriteHeader(statusCode)
+	})
+}
+
+func (h *StepCacheHandler) exists(r *http.Request) (statusCode int, err error) {
+	ctx, _, endObservation := h.operations.stepCacheExists.With(r.Context(), &err, observation.Args{})
+	defer func() {
+		endObservation(1, observation.Args{Attrs: []attribute.KeyValue{
+			attribute.Int("statusCode", statusCode),
+		}})
+	}()
+
+	key, statusCode, err := h.objectKey(ctx, r)
+	if err != nil {
+		return statusCode, err
+	}
+
+	exists, err := h.uploadStore.Exists(ctx, key)
+	if err != nil {
+		return http.StatusInternalServerError, errors.Wrap(err, "checking cached step result")
+	}
+	if !exists {
+		return http.StatusNotFound, nil
+	}
+	return http.StatusOK, nil
+}
+
+const maxStepCacheUploadSize = 100 << 20 // 100MB
+
+// Upload stores the step result in the step cache.
+func (h *StepCacheHandler) Upload() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = http.MaxBytesReader(w, r.Body, maxStepCacheUploadSize)
+		statusCode, err := h.upload(r)
+
+		if err != nil {
+			http.Error(w, err.Error(), statusCode)
+			return
+		}
+
+		w.WriteHeader(statusCode)
+	})
+}
+
+func (h *StepCacheHandler) upload(r *http.Request) (statusCode int, err error) {
+	ctx, _, endObservation := h.operations.stepCacheUpload.With(r.Context(), &err, observation.Args{})
+	defer func() {
+		endObservation(1, observation.Args{Attrs: []attribute.KeyValue{
+			attribute.Int("statusCode", statusCode),
+		}})
+	}()
+
+	key, statusCode, err := h.objectKey(ctx, r)
+	if err != nil {
+		return statusCode, err
+	}
+
+	if _, err = h.uploadStore.Upload(ctx, key, r.Body); err != nil {
+		return http.StatusInternalServerError, errors.Wrap(err, "uploading step result")
+	}
+	return http.StatusOK, nil
+}
+
+var stepCacheKeyRegex = regex.MustCompile("^[A-Za-z0-9_-]+$")
+
+// objectKey returns the key of the object in the upload store for the step cache key
+// of the request.
+func (h *StepCacheHandler) objectKey(ctx context.Context, r *http.Request) (string, int, error) {
+	key := mux.Vars(r)["key"]
+	if key == "" {
+		return "", http.StatusBadRequest, errors.New("key not provided")
+	}
+	if !stepCacheKeyRegex.MatchString(key) {
+		return "", http.StatusBadRequest, errors.New("invalid key")
+	}
+
+	userID, statusCode, err := h.userID(ctx, r)
+	if err != nil {
+		return "", statusCode, err
+	}
+
+	// 🚨 SECURITY: The step results are cached per user, so that a user cannot
+	// read the results of steps of other users or poison their cache.
+	return fmt.Sprintf("%d/%s", userID, key), http.StatusOK, nil
+}
+
+// userID returns the ID of the user whose step results are requested. Executors
+// request the results for the user that created the job they run, and src-cli for
+// the authenticated user.
+func (h *StepCacheHandler) userID(ctx context.Context, r *http.Request) (int32, int, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsInternal() {
+		if !a.IsAuthenticated() {
+			return 0, http.StatusUnauthorized, errors.New("not authenticated")
+		}
+		return a.UID, http.StatusOK, nil
+	}
+
+	// The request was authenticated with the token of the job, so the header can
+	// be trusted.
+	jobID, err := strconv.ParseInt(r.Header.Get("X-Sourcegraph-Job-ID"), 10, 64)
+	if err != nil {
+		return 0, http.StatusBadRequest, errors.New("job ID not provided")
+	}
+	job, err := h.store.GetBatchSpecWorkspaceExecutionJob(ctx, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: jobID, ExcludeRank: true})
+	if err != nil {
+		if errors.Is(err, store.ErrNoResults) {
+			return 0, http.StatusNotFound, errors.New("job does not exist")
+		}
+		return 0, http.StatusInternalServerError, errors.Wrap(err, "looking up job")
+	}
+	return job.UserID, http.StatusOK, nil
+}
@@ -0,0 +1,291 @@
+package httpapi_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/httpapi"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestStepCacheHandler_ServeHTTP(t *testing.T) {
+	operations := httpapi.NewOperations(&observation.TestContext)
+
+	tests := []struct {
+		name string
+
+		method string
+		path   string
+		jobID  string
+		userID int32
+		body   string
+
+		mockInvokes func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore)
+
+		expectedStatusCode   int
+		expectedResponseBody string
+		assertUploadStore    func(t *testing.T, uploadStore *uploadstoremocks.MockStore)
+	}{
+		{
+			name:   "Get cached result",
+			method: http.MethodGet,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			jobID:  "42",
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				mockStore.
+					On("GetBatchSpecWorkspaceExecutionJob", mock.Anything, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: 42, ExcludeRank: true}).
+					Return(&btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 7}, nil).
+					Once()
+				uploadStore.ExistsFunc.SetDefaultReturn(true, nil)
+				uploadStore.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader(`{"stdout":"hello"}`)), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"stdout":"hello"}`,
+			assertUploadStore: func(t *testing.T, uploadStore *uploadstoremocks.MockStore) {
+				require.Len(t, uploadStore.GetFunc.History(), 1)
+				assert.Equal(t, "7/abc-tree", uploadStore.GetFunc.History()[0].Arg1)
+			},
+		},
+		{
+			name:   "Get missing result",
+			method: http.MethodGet,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			jobID:  "42",
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				mockStore.
+					On("GetBatchSpecWorkspaceExecutionJob", mock.Anything, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: 42, ExcludeRank: true}).
+					Return(&btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 7}, nil).
+					Once()
+				uploadStore.ExistsFunc.SetDefaultReturn(false, nil)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "cached step result does not exist\n",
+			assertUploadStore: func(t *testing.T, uploadStore *uploadstoremocks.MockStore) {
+				assert.Empty(t, uploadStore.GetFunc.History())
+			},
+		},
+		{
+			name:   "Result exists",
+			method: http.MethodHead,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			jobID:  "42",
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				mockStore.
+					On("GetBatchSpecWorkspaceExecutionJob", mock.Anything, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: 42, ExcludeRank: true}).
+					Return(&btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 7}, nil).
+					Once()
+				uploadStore.ExistsFunc.SetDefaultReturn(true, nil)
+			},
+			expectedStatusCode: http.StatusOK,
+			assertUploadStore: func(t *testing.T, uploadStore *uploadstoremocks.MockStore) {
+				require.Len(t, uploadStore.ExistsFunc.History(), 1)
+				assert.Equal(t, "7/abc-tree", uploadStore.ExistsFunc.History()[0].Arg1)
+				assert.Empty(t, uploadStore.GetFunc.History())
+			},
+		},
+		{
+			name:   "Result does not exist",
+			method: http.MethodHead,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			jobID:  "42",
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				mockStore.
+					On("GetBatchSpecWorkspaceExecutionJob", mock.Anything, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: 42, ExcludeRank: true}).
+					Return(&btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 7}, nil).
+					Once()
+				uploadStore.ExistsFunc.SetDefaultReturn(false, nil)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "Failed to check result",
+			method: http.MethodHead,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			jobID:  "42",
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				mockStore.
+					On("GetBatchSpecWorkspaceExecutionJob", mock.Anything, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: 42, ExcludeRank: true}).
+					Return(&btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 7}, nil).
+					Once()
+				uploadStore.ExistsFunc.SetDefaultReturn(false, errors.New("failed"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "checking cached step result: failed\n",
+		},
+		{
+			name:   "Upload result",
+			method: http.MethodPut,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			jobID:  "42",
+			body:   `{"stdout":"hello"}`,
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				mockStore.
+					On("GetBatchSpecWorkspaceExecutionJob", mock.Anything, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: 42, ExcludeRank: true}).
+					Return(&btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 7}, nil).
+					Once()
+				uploadStore.UploadFunc.SetDefaultHook(func(ctx context.Context, key string, r io.Reader) (int64, error) {
+					b, err := io.ReadAll(r)
+					return int64(len(b)), err
+				})
+			},
+			expectedStatusCode: http.StatusOK,
+			assertUploadStore: func(t *testing.T, uploadStore *uploadstoremocks.MockStore) {
+				require.Len(t, uploadStore.UploadFunc.History(), 1)
+				assert.Equal(t, "7/abc-tree", uploadStore.UploadFunc.History()[0].Arg1)
+			},
+		},
+		{
+			name:   "Failed to upload result",
+			method: http.MethodPut,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			jobID:  "42",
+			body:   `{"stdout":"hello"}`,
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				mockStore.
+					On("GetBatchSpecWorkspaceExecutionJob", mock.Anything, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: 42, ExcludeRank: true}).
+					Return(&btypes.BatchSpecWorkspaceExecutionJob{ID: 42, UserID: 7}, nil).
+					Once()
+				uploadStore.UploadFunc.SetDefaultReturn(0, errors.New("failed"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: "uploading step result: failed\n",
+		},
+		{
+			name:   "Get cached result of user",
+			method: http.MethodGet,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			userID: 9,
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				uploadStore.ExistsFunc.SetDefaultReturn(true, nil)
+				uploadStore.GetFunc.SetDefaultReturn(io.NopCloser(strings.NewReader(`{"stdout":"hello"}`)), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"stdout":"hello"}`,
+			assertUploadStore: func(t *testing.T, uploadStore *uploadstoremocks.MockStore) {
+				require.Len(t, uploadStore.GetFunc.History(), 1)
+				assert.Equal(t, "9/abc-tree", uploadStore.GetFunc.History()[0].Arg1)
+			},
+		},
+		{
+			name:   "Upload result of user ignores job ID",
+			method: http.MethodPut,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			jobID:  "42",
+			userID: 9,
+			body:   `{"stdout":"hello"}`,
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				uploadStore.UploadFunc.SetDefaultHook(func(ctx context.Context, key string, r io.Reader) (int64, error) {
+					b, err := io.ReadAll(r)
+					return int64(len(b)), err
+				})
+			},
+			expectedStatusCode: http.StatusOK,
+			assertUploadStore: func(t *testing.T, uploadStore *uploadstoremocks.MockStore) {
+				require.Len(t, uploadStore.UploadFunc.History(), 1)
+				assert.Equal(t, "9/abc-tree", uploadStore.UploadFunc.History()[0].Arg1)
+			},
+		},
+		{
+			name:                 "Invalid key",
+			method:               http.MethodGet,
+			path:                 "/files/batch-changes-step-cache/abc.tree",
+			jobID:                "42",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "invalid key\n",
+		},
+		{
+			name:                 "Missing job ID",
+			method:               http.MethodGet,
+			path:                 "/files/batch-changes-step-cache/abc-tree",
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: "job ID not provided\n",
+		},
+		{
+			name:   "Job does not exist",
+			method: http.MethodGet,
+			path:   "/files/batch-changes-step-cache/abc-tree",
+			jobID:  "42",
+			mockInvokes: func(mockStore *mockStepCacheStore, uploadStore *uploadstoremocks.MockStore) {
+				mockStore.
+					On("GetBatchSpecWorkspaceExecutionJob", mock.Anything, store.GetBatchSpecWorkspaceExecutionJobOpts{ID: 42, ExcludeRank: true}).
+					Return(nil, store.ErrNoResults).
+					Once()
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: "job does not exist\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockStore := new(mockStepCacheStore)
+			uploadStore := uploadstoremocks.NewMockStore()
+
+			if test.mockInvokes != nil {
+				test.mockInvokes(mockStore, uploadStore)
+			}
+
+			handler := httpapi.NewStepCacheHandler(mockStore, uploadStore, operations)
+
+			r, err := http.NewRequest(test.method, test.path, strings.NewReader(test.body))
+			require.NoError(t, err)
+			if test.jobID != "" {
+				r.Header.Set("X-Sourcegraph-Job-ID", test.jobID)
+			}
+
+			if test.userID != 0 {
+				r = r.WithContext(actor.WithActor(r.Context(), actor.FromUser(test.userID)))
+			} else {
+				r = r.WithContext(actor.WithInternalActor(r.Context()))
+			}
+
+			w := httptest.NewRecorder()
+
+			router := mux.NewRouter()
+			router.Methods(http.MethodGet).Path("/files/batch-changes-step-cache/{key}").Handler(handler.Get())
+			router.Methods(http.MethodHead).Path("/files/batch-changes-step-cache/{key}").Handler(handler.Exists())
+			router.Methods(http.MethodPut).Path("/files/batch-changes-step-cache/{key}").Handler(handler.Upload())
+			router.ServeHTTP(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+			assert.Equal(t, test.expectedStatusCode, res.StatusCode)
+
+			responseBody, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponseBody, string(responseBody))
+
+			if test.assertUploadStore != nil {
+				test.assertUploadStore(t, uploadStore)
+			}
+
+			mockStore.AssertExpectations(t)
+		})
+	}
+}
+
+type mockStepCacheStore struct {
+	mock.Mock
+}
+
+func (m *mockStepCacheStore) GetBatchSpecWorkspaceExecutionJob(ctx context.Context, opts store.GetBatchSpecWorkspaceExecutionJobOpts) (*btypes.BatchSpecWorkspaceExecutionJob, error) {
+	args := m.Called(ctx, opts)
+	var obj *btypes.BatchSpecWorkspaceExecutionJob
+	if args.Get(0) != nil {
+		obj = args.Get(0).(*btypes.BatchSpecWorkspaceExecutionJob)
+	}
+	return obj, args.Error(1)
+}
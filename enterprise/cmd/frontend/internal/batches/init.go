@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/httpapi"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/resolvers"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/batches/webhooks"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/stepcache"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
@@ -19,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Init initializes the given enterpriseServices to include the required
@@ -60,5 +62,21 @@ func Init(
 	enterpriseServices.BatchesChangesFileExistsHandler = fileHandler.Exists()
 	enterpriseServices.BatchesChangesFileUploadHandler = fileHandler.Upload()
 
+	if stepcache.Enabled {
+		stepCacheConfig := &stepcache.Config{}
+		stepCacheConfig.Load()
+		if err := stepCacheConfig.Validate(); err != nil {
+			return errors.Wrap(err, "validating step cache config")
+		}
+		stepCacheStore, err := stepcache.New(ctx, observationCtx, stepCacheConfig)
+		if err != nil {
+			return errors.Wrap(err, "creating step cache store")
+		}
+		stepCacheHandler := httpapi.NewStepCacheHandler(bstore, stepCacheStore, operations)
+		enterpriseServices.BatchesStepCacheGetHandler = stepCacheHandler.Get()
+		enterpriseServices.BatchesStepCacheExistsHandler = stepCacheHandler.Exists()
+		enterpriseServices.BatchesStepCacheUploadHandler = stepCacheHandler.Upload()
+	}
+
 	return nil
 }
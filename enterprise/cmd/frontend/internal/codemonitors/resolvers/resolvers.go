@@ -3,6 +3,7 @@ package resolvers
 import (
 	"context"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/graph-gophers/graphql-go"
@@ -283,7 +284,11 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 				return err
 			}
 		case a.Webhook != nil:
-			_, err := r.db.CodeMonitors().CreateWebhookAction(ctx, monitorID, a.Webhook.Enabled, a.Webhook.IncludeResults, a.Webhook.URL)
+			payloadTemplate := pointers.Deref(a.Webhook.PayloadTemplate, "")
+			if err := validateActionTemplate("payload", payloadTemplate); err != nil {
+				return err
+			}
+			_, err := r.db.CodeMonitors().CreateWebhookAction(ctx, monitorID, a.Webhook.Enabled, a.Webhook.IncludeResults, a.Webhook.URL, payloadTemplate)
 			if err != nil {
 				return err
 			}
@@ -295,15 +300,24 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err != nil {
 				return err
 			}
+		case a.Issue != nil:
+			issueArgs, err := issueActionArgs(a.Issue)
+			if err != nil {
+				return err
+			}
+			_, err = r.db.CodeMonitors().CreateIssueAction(ctx, monitorID, issueArgs)
+			if err != nil {
+				return err
+			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, or Issue must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, issue []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -318,8 +332,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionIssueKind:
+			issue = append(issue, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, or issue")
 		}
 	}
 
@@ -335,6 +351,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteIssueActions(ctx, monitorID, issue...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -395,7 +415,7 @@ func (r *Resolver) TriggerTestWebhookAction(ctx context.Context, args *graphqlba
 		return nil, err
 	}
 
-	if err := background.SendTestWebhook(ctx, httpcli.ExternalDoer, args.Description, args.Webhook.URL); err != nil {
+	if err := background.SendTestWebhook(ctx, httpcli.ExternalDoer, args.Description, pointers.Deref(args.Webhook.PayloadTemplate, ""), args.Webhook.URL); err != nil {
 		return nil, err
 	}
 
@@ -446,7 +466,11 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	issueActions, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(issueActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -456,6 +480,9 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, issueAction := range issueActions {
+		ids = append(ids, (&monitorIssue{IssueAction: issueAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -500,6 +527,16 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.Issue != nil:
+			if a.Issue.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Issue: a.Issue.Update})
+				continue
+			}
+			if _, ok := aMap[*a.Issue.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.Issue.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.Issue.Id)
 		}
 	}
 
@@ -579,8 +616,10 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.Issue != nil:
+			err = r.updateIssueAction(ctx, *action.Issue)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, or issue")
 		}
 		if err != nil {
 			return nil, err
@@ -621,7 +660,12 @@ func (r *Resolver) updateWebhookAction(ctx context.Context, args graphqlbackend.
 		return err
 	}
 
-	_, err = r.db.CodeMonitors().UpdateWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL)
+	payloadTemplate := pointers.Deref(args.Update.PayloadTemplate, "")
+	if err := validateActionTemplate("payload", payloadTemplate); err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateWebhookAction(ctx, id, args.Update.Enabled, args.Update.IncludeResults, args.Update.URL, payloadTemplate)
 	return err
 }
 
@@ -636,6 +680,58 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 	return err
 }
 
+func (r *Resolver) updateIssueAction(ctx context.Context, args graphqlbackend.EditActionIssueArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	issueArgs, err := issueActionArgs(args.Update)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateIssueAction(ctx, id, issueArgs)
+	return err
+}
+
+// issueActionArgs validates the templates of an issue action and converts the
+// GraphQL arguments into the arguments of the store.
+func issueActionArgs(args *graphqlbackend.CreateActionIssueArgs) (*edb.IssueActionArgs, error) {
+	if args.Repository == "" {
+		return nil, errors.New("repository must be set")
+	}
+	if strings.TrimSpace(args.TitleTemplate) == "" {
+		return nil, errors.New("title template must not be empty")
+	}
+	if err := validateActionTemplate("title", args.TitleTemplate); err != nil {
+		return nil, err
+	}
+	bodyTemplate := pointers.Deref(args.BodyTemplate, "")
+	if err := validateActionTemplate("body", bodyTemplate); err != nil {
+		return nil, err
+	}
+
+	return &edb.IssueActionArgs{
+		Enabled:        args.Enabled,
+		IncludeResults: args.IncludeResults,
+		Repository:     args.Repository,
+		TitleTemplate:  args.TitleTemplate,
+		BodyTemplate:   bodyTemplate,
+	}, nil
+}
+
+func validateActionTemplate(name, text string) error {
+	if text == "" {
+		return nil
+	}
+	if err := background.ValidateActionTemplate(text); err != nil {
+		return errors.Wrapf(err, "invalid %s template", name)
+	}
+	return nil
+}
+
 func (r *Resolver) withTransact(ctx context.Context, f func(*Resolver) error) error {
 	return r.db.WithTransact(ctx, func(tx database.DB) error {
 		return f(&Resolver{
@@ -720,9 +816,11 @@ const (
 	monitorActionEmailKind             = "CodeMonitorActionEmail"
 	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
 	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
+	monitorActionIssueKind             = "CodeMonitorActionIssue"
 	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
 	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
 	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
+	monitorActionIssueEventKind        = "CodeMonitorActionIssueEvent"
 	monitorActionEmailRecipientKind    = "CodeMonitorActionEmailRecipient"
 )
 
@@ -815,7 +913,12 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	is, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(is))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -843,6 +946,15 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, i := range is {
+		actions = append(actions, &action{
+			issue: &monitorIssue{
+				Resolver:       r,
+				IssueAction:    i,
+				triggerEventID: triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -1019,6 +1131,7 @@ type action struct {
 	email        graphqlbackend.MonitorEmailResolver
 	webhook      graphqlbackend.MonitorWebhookResolver
 	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	issue        graphqlbackend.MonitorIssueResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1029,6 +1142,8 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.issue != nil:
+		return a.issue.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1046,6 +1161,10 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorIssue() (graphqlbackend.MonitorIssueResolver, bool) {
+	return a.issue, a.issue != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1175,6 +1294,10 @@ func (m *monitorWebhook) URL() string {
 	return m.WebhookAction.URL
 }
 
+func (m *monitorWebhook) PayloadTemplate() string {
+	return m.WebhookAction.PayloadTemplate
+}
+
 func (m *monitorWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
@@ -1261,6 +1384,70 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorIssue struct {
+	*Resolver
+	*edb.IssueAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorIssue) ID() graphql.ID {
+	return relay.MarshalID(monitorActionIssueKind, m.IssueAction.ID)
+}
+
+func (m *monitorIssue) Enabled() bool {
+	return m.IssueAction.Enabled
+}
+
+func (m *monitorIssue) IncludeResults() bool {
+	return m.IssueAction.IncludeResults
+}
+
+func (m *monitorIssue) Repository() string {
+	return m.IssueAction.Repository
+}
+
+func (m *monitorIssue) TitleTemplate() string {
+	return m.IssueAction.TitleTemplate
+}
+
+func (m *monitorIssue) BodyTemplate() string {
+	return m.IssueAction.BodyTemplate
+}
+
+func (m *monitorIssue) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, edb.ListActionJobsOpts{
+		IssueID:        pointers.Ptr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          pointers.Ptr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, edb.ListActionJobsOpts{
+		IssueID:        pointers.Ptr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
 		return nil
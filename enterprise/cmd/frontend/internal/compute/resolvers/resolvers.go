@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func NewResolver(logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJobs) gql.ComputeResolver {
@@ -229,6 +230,9 @@ func NewBatchComputeImplementer(ctx context.Context, logger log.Logger, db datab
 	if err != nil {
 		return nil, err
 	}
+	if aggregate, ok := computeQuery.Command.(*compute.Aggregate); ok {
+		return nil, errors.Errorf("the %s command is only supported by the streaming compute API", aggregate.Kind)
+	}
 
 	searchQuery, err := computeQuery.ToSearchQuery()
 	if err != nil {
@@ -38,6 +38,15 @@ func NewComputeStream(ctx context.Context, logger log.Logger, db database.DB, en
 	eventsC := make(chan Event, 8)
 	errorC := make(chan error, 1)
 	s := stream.New().WithMaxGoroutines(8)
+
+	// Aggregations of individual matches are combined and sent as a single
+	// result once the search completes. Callbacks run sequentially, so the
+	// aggregator is not accessed concurrently.
+	var aggregator *compute.Aggregator
+	if aggregate, ok := computeCommand.(*compute.Aggregate); ok {
+		aggregator = compute.NewAggregator(aggregate, compute.DefaultMaxAggregationBytes)
+	}
+
 	cb := func(ev Event, err error) stream.Callback {
 		return func() {
 			if err != nil {
@@ -45,9 +54,18 @@ func NewComputeStream(ctx context.Context, logger log.Logger, db database.DB, en
 				case errorC <- err:
 				default:
 				}
-			} else {
-				eventsC <- ev
+				return
 			}
+			if aggregator != nil {
+				for _, r := range ev.Results {
+					aggregator.Add(r)
+				}
+				ev.Results = nil
+				if ev.Stats.Zero() {
+					return
+				}
+			}
+			eventsC <- ev
 		}
 	}
 	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
@@ -91,9 +109,12 @@ func NewComputeStream(ctx context.Context, logger log.Logger, db database.DB, en
 		defer close(final)
 		defer close(eventsC)
 		defer close(errorC)
-		defer s.Wait()
 
 		alert, err := searchClient.Execute(ctx, stream, inputs)
+		s.Wait()
+		if aggregator != nil {
+			eventsC <- Event{Results: []compute.Result{aggregator.Result()}}
+		}
 		final <- finalResult{alert: alert, err: err}
 	}()
 
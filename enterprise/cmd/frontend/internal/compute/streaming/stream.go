@@ -76,6 +76,11 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Only aggregate returns the top groups, count.by returns all of them.
+	if aggregate, ok := computeQuery.Command.(*compute.Aggregate); ok && aggregate.Kind == "aggregate" && args.Display > 0 {
+		aggregate.Limit = args.Display
+	}
+
 	progress := &streamclient.ProgressAggregator{
 		Start:     start,
 		RepoNamer: streamclient.RepoNamer(ctx, h.db),
@@ -119,11 +124,15 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	defer pingTicker.Stop()
 
 	first := true
+	aggregationLimitHit := false
 	handleEvent := func(event Event) {
 		progress.Dirty = true
 		progress.Stats.Update(&event.Stats)
 
 		for _, result := range event.Results {
+			if aggregation, ok := result.(*compute.Aggregation); ok && aggregation.LimitHit {
+				aggregationLimitHit = true
+			}
 			_ = matchesBuf.Append(result)
 		}
 
@@ -163,6 +172,12 @@ LOOP:
 			Description: "This data is incomplete! We ran this query for 1 minute and we'd need more time to compute all the results. This isn't supported yet, so please reach out to support@sourcegraph.com if you're interested in running longer queries.",
 		})
 	}
+	if aggregationLimitHit {
+		_ = eventWriter.Event("alert", streamhttp.EventAlert{
+			Title:       "Incomplete aggregation",
+			Description: "This aggregation found too many distinct groups to hold in memory. Values of groups found after the limit was hit are only included in the count of other values, so groups with high counts may be missing. Try grouping by a template with fewer distinct values.",
+		})
+	}
 	if alert != nil {
 		var pqs []streamhttp.QueryDescription
 		for _, pq := range alert.ProposedQueries {
@@ -203,7 +218,7 @@ func parseURLQuery(q url.Values) (*args, error) {
 		return nil, errors.New("no query found")
 	}
 
-	display := get("display", "-1") // TODO(rvantonder): Currently unused; implement a limit for compute results.
+	display := get("display", "-1") // Limits the groups of aggregations. TODO(rvantonder): implement a limit for other compute results.
 	var err error
 	if a.Display, err = strconv.Atoi(display); err != nil {
 		return nil, errors.Errorf("display must be an integer, got %q: %w", display, err)
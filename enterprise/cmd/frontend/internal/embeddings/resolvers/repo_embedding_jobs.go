@@ -251,3 +251,19 @@ func (r *repoEmbeddingJobStatsResolver) FilesSkipped() int32 {
 	}
 	return int32(skipped)
 }
+
+func (r *repoEmbeddingJobStatsResolver) IsIncremental() bool {
+	return r.stats.IsIncremental
+}
+
+func (r *repoEmbeddingJobStatsResolver) FilesAdded() int32 {
+	return int32(r.stats.FilesAdded)
+}
+
+func (r *repoEmbeddingJobStatsResolver) FilesModified() int32 {
+	return int32(r.stats.FilesModified)
+}
+
+func (r *repoEmbeddingJobStatsResolver) FilesDeleted() int32 {
+	return int32(r.stats.FilesDeleted)
+}
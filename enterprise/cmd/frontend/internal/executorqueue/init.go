@@ -22,6 +22,9 @@ func Init(
 	codeintelUploadHandler := enterpriseServices.NewCodeIntelUploadHandler(false)
 	batchesWorkspaceFileGetHandler := enterpriseServices.BatchesChangesFileGetHandler
 	batchesWorkspaceFileExistsHandler := enterpriseServices.BatchesChangesFileGetHandler
+	batchesStepCacheGetHandler := enterpriseServices.BatchesStepCacheGetHandler
+	batchesStepCacheExistsHandler := enterpriseServices.BatchesStepCacheExistsHandler
+	batchesStepCacheUploadHandler := enterpriseServices.BatchesStepCacheUploadHandler
 
 	accessToken := func() string {
 		if deploy.IsApp() {
@@ -40,6 +43,9 @@ func Init(
 		codeintelUploadHandler,
 		batchesWorkspaceFileGetHandler,
 		batchesWorkspaceFileExistsHandler,
+		batchesStepCacheGetHandler,
+		batchesStepCacheExistsHandler,
+		batchesStepCacheUploadHandler,
 	)
 
 	enterpriseServices.NewExecutorProxyHandler = queueHandler
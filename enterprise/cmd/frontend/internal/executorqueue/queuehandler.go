@@ -31,6 +31,9 @@ func newExecutorQueuesHandler(
 	uploadHandler http.Handler,
 	batchesWorkspaceFileGetHandler http.Handler,
 	batchesWorkspaceFileExistsHandler http.Handler,
+	batchesStepCacheGetHandler http.Handler,
+	batchesStepCacheExistsHandler http.Handler,
+	batchesStepCacheUploadHandler http.Handler,
 ) func() http.Handler {
 	metricsStore := metricsstore.NewDistributedStore("executors:")
 	executorStore := db.Executors()
@@ -114,6 +117,10 @@ func newExecutorQueuesHandler(
 		batchChangesRouter := filesRouter.PathPrefix("/batch-changes").Subrouter()
 		batchChangesRouter.Path("/{spec}/{file}").Methods(http.MethodGet).Handler(batchesWorkspaceFileGetHandler)
 		batchChangesRouter.Path("/{spec}/{file}").Methods(http.MethodHead).Handler(batchesWorkspaceFileExistsHandler)
+		batchesStepCacheRouter := filesRouter.PathPrefix("/batch-changes-step-cache").Subrouter()
+		batchesStepCacheRouter.Path("/{key}").Methods(http.MethodGet).Handler(batchesStepCacheGetHandler)
+		batchesStepCacheRouter.Path("/{key}").Methods(http.MethodHead).Handler(batchesStepCacheExistsHandler)
+		batchesStepCacheRouter.Path("/{key}").Methods(http.MethodPut).Handler(batchesStepCacheUploadHandler)
 		// The files route are treated as an internal actor and require the executor access token to authenticate.
 		filesRouter.Use(withInternalActor, jobAuthMiddleware(logger, routeFiles, jobTokenStore, executorStore))
 
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/stepcache"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor/types"
@@ -22,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/lib/api"
 	batcheslib "github.com/sourcegraph/sourcegraph/lib/batches"
+	"github.com/sourcegraph/sourcegraph/lib/batches/execution/cache"
 	"github.com/sourcegraph/sourcegraph/lib/batches/template"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -141,6 +143,10 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 		}
 	}
 
+	// Only the batcheshelper of the Version 2 jobs looks up the results of the
+	// individual steps in the step cache.
+	executionInput.StepCache = job.Version == 2 && stepcache.Enabled && !batchSpec.NoCache
+
 	skipped, err := batcheslib.SkippedStepsForRepo(batchSpec.Spec, string(repo.Name), workspace.FileMatches)
 	if err != nil {
 		return apiclient.Job{}, err
@@ -159,6 +165,25 @@ func transformRecord(ctx context.Context, logger log.Logger, s BatchesStore, job
 		},
 	}
 
+	// The step cache keys depend on the digests of the step images that are not
+	// pinned to a digest, which the executor resolves from the registries before
+	// running the steps.
+	if executionInput.StepCache {
+		images := make(apiclient.StepCacheImages, len(batchSpec.Spec.Steps))
+		for _, step := range batchSpec.Spec.Steps {
+			if !cache.IsImagePinned(step.Container) {
+				images[step.Container] = ""
+			}
+		}
+		marshaledImages, err := json.Marshal(images)
+		if err != nil {
+			return apiclient.Job{}, err
+		}
+		files[apiclient.StepCacheImagesFile] = apiclient.VirtualMachineFile{
+			Content: marshaledImages,
+		}
+	}
+
 	workspaceFiles, _, err := s.ListBatchSpecWorkspaceFiles(ctx, store.ListBatchSpecWorkspaceFileOpts{BatchSpecRandID: batchSpec.RandID})
 	if err != nil {
 		return apiclient.Job{}, errors.Wrap(err, "fetching workspace files")
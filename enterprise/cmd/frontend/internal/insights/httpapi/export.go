@@ -7,23 +7,27 @@ import (
 	"encoding/csv"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/grafana/regexp"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-// ExportHandler handles retrieving and exporting code insights data.
+// ExportHandler handles retrieving, exporting and importing code insights data.
 type ExportHandler struct {
+	logger    log.Logger
 	primaryDB database.DB
 
 	seriesStore          *store.Store
@@ -41,6 +45,7 @@ func NewExportHandler(db database.DB, insightsDB edb.InsightsDB) *ExportHandler
 	searchContextHandler := store.NewSearchContextHandler(db)
 
 	return &ExportHandler{
+		logger:               log.Scoped("insightsExportHandler", "exports and imports code insights data"),
 		primaryDB:            db,
 		seriesStore:          seriesStore,
 		permStore:            insightPermStore,
@@ -106,28 +111,9 @@ func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*
 		return nil, err
 	}
 
-	licenseError := licensing.Check(licensing.FeatureCodeInsights)
-	if licenseError != nil {
-		return nil, invalidLicenseError
-	}
-
-	var insightViewId string
-	if err := relay.UnmarshalSpec(graphql.ID(id), &insightViewId); err != nil {
-		return nil, errors.Wrap(err, "could not unmarshal insight view ID")
-	}
-
-	visibleViewSeries, err := h.insightStore.GetAll(ctx, store.InsightQueryArgs{
-		UniqueIDs:            []string{insightViewId},
-		UserID:               userID,
-		OrgID:                orgIDs,
-		WithoutAuthorization: false,
-	})
+	insightViewId, visibleViewSeries, err := h.visibleViewSeries(ctx, id, store.InsightQueryArgs{UserID: userID, OrgID: orgIDs})
 	if err != nil {
-		return nil, errors.New("could not fetch insight information")
-	}
-	// 🚨 SECURITY: if the user context doesn't get any response here that means they should not be able to access this insight.
-	if len(visibleViewSeries) == 0 {
-		return nil, notFoundError
+		return nil, err
 	}
 
 	opts := store.ExportOpts{}
@@ -200,6 +186,34 @@ func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*
 			return nil, err
 		}
 	}
+
+	// Derived series have no recorded points, so their points are computed from the points of their operands the same
+	// way as when the insight is viewed. They are not recorded for a repository.
+	for _, series := range visibleViewSeries {
+		if series.GenerationMethod != types.Derived {
+			continue
+		}
+		points, err := h.seriesStore.LoadDerivedSeriesPoints(ctx, series, visibleViewSeries, store.SeriesPointsOpts{
+			IncludeRepoRegex: opts.IncludeRepoRegex,
+			ExcludeRepoRegex: opts.ExcludeRepoRegex,
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to compute derived series points")
+		}
+		for _, point := range points {
+			if err := dataWriter.Write([]string{
+				series.Title,
+				series.Label,
+				series.Query,
+				point.Time.String(),
+				"",
+				strconv.FormatFloat(point.Value, 'f', -1, 64),
+				"",
+			}); err != nil {
+				return nil, err
+			}
+		}
+	}
 	dataWriter.Flush()
 
 	if err := zw.Close(); err != nil {
@@ -211,6 +225,31 @@ func (h *ExportHandler) exportCodeInsightData(ctx context.Context, id string) (*
 	}, nil
 }
 
+// visibleViewSeries returns the unique ID and the series of the insight view with the given GraphQL ID, if it is
+// visible given the user, org and authorization fields of args.
+func (h *ExportHandler) visibleViewSeries(ctx context.Context, id string, args store.InsightQueryArgs) (string, []types.InsightViewSeries, error) {
+	licenseError := licensing.Check(licensing.FeatureCodeInsights)
+	if licenseError != nil {
+		return "", nil, invalidLicenseError
+	}
+
+	var insightViewId string
+	if err := relay.UnmarshalSpec(graphql.ID(id), &insightViewId); err != nil {
+		return "", nil, errors.Wrap(err, "could not unmarshal insight view ID")
+	}
+
+	args.UniqueIDs = []string{insightViewId}
+	visibleViewSeries, err := h.insightStore.GetAll(ctx, args)
+	if err != nil {
+		return "", nil, errors.New("could not fetch insight information")
+	}
+	// 🚨 SECURITY: if the user context doesn't get any response here that means they should not be able to access this insight.
+	if len(visibleViewSeries) == 0 {
+		return "", nil, notFoundError
+	}
+	return insightViewId, visibleViewSeries, nil
+}
+
 func emptyStringIfNil(s *string) string {
 	if s == nil {
 		return ""
@@ -0,0 +1,705 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/parquet"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// pointsColumns are the columns of exported series points. Imports read the columns by name, so that files
+// produced by other tools can order them differently.
+var pointsColumns = []parquet.Column{
+	{Name: "series_id", Type: parquet.String},
+	{Name: "label", Type: parquet.String},
+	{Name: "query", Type: parquet.String},
+	{Name: "time", Type: parquet.Timestamp},
+	{Name: "repo_id", Type: parquet.Int64, Optional: true},
+	{Name: "repo_name", Type: parquet.String, Optional: true},
+	{Name: "capture", Type: parquet.String, Optional: true},
+	{Name: "value", Type: parquet.Double},
+	{Name: "snapshot", Type: parquet.Boolean},
+}
+
+// pointsEncoder writes exported series points in a file format.
+type pointsEncoder interface {
+	Write(series types.InsightViewSeries, point store.RecordedSeriesPoint) error
+	Close() error
+}
+
+type csvPointsEncoder struct {
+	w    *csv.Writer
+	rows int
+}
+
+func newCSVPointsEncoder(w io.Writer) (*csvPointsEncoder, error) {
+	header := make([]string, 0, len(pointsColumns))
+	for _, column := range pointsColumns {
+		header = append(header, column.Name)
+	}
+	e := &csvPointsEncoder{w: csv.NewWriter(w)}
+	if err := e.w.Write(header); err != nil {
+		return nil, errors.Wrap(err, "failed to write csv header")
+	}
+	return e, nil
+}
+
+func (e *csvPointsEncoder) Write(series types.InsightViewSeries, point store.RecordedSeriesPoint) error {
+	var repoID string
+	if point.RepoID != nil {
+		repoID = strconv.Itoa(int(*point.RepoID))
+	}
+	if err := e.w.Write([]string{
+		point.SeriesID,
+		series.Label,
+		series.Query,
+		point.Time.UTC().Format(time.RFC3339),
+		repoID,
+		emptyStringIfNil(point.RepoName),
+		emptyStringIfNil(point.Capture),
+		strconv.FormatFloat(point.Value, 'f', -1, 64),
+		strconv.FormatBool(point.Snapshot),
+	}); err != nil {
+		return err
+	}
+	// Flush regularly so that the points are streamed to the client.
+	e.rows++
+	if e.rows%1000 == 0 {
+		e.w.Flush()
+		return e.w.Error()
+	}
+	return nil
+}
+
+func (e *csvPointsEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type parquetPointsEncoder struct {
+	w *parquet.Writer
+}
+
+func (e *parquetPointsEncoder) Write(series types.InsightViewSeries, point store.RecordedSeriesPoint) error {
+	var repoID *int64
+	if point.RepoID != nil {
+		id := int64(*point.RepoID)
+		repoID = &id
+	}
+	return e.w.Write(
+		point.SeriesID,
+		series.Label,
+		series.Query,
+		point.Time,
+		repoID,
+		point.RepoName,
+		point.Capture,
+		point.Value,
+		point.Snapshot,
+	)
+}
+
+func (e *parquetPointsEncoder) Close() error {
+	return e.w.Close()
+}
+
+// ExportPointsFunc returns a handler that streams all points recorded for the series of an insight view, followed by
+// the points of its derived series, as CSV, or as Parquet if the format query parameter is "parquet".
+func (h *ExportHandler) ExportPointsFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if !actor.FromContext(ctx).IsAuthenticated() {
+			http.Error(w, authenticationError.Error(), http.StatusUnauthorized)
+			return
+		}
+		userID, orgIDs, err := h.permStore.GetUserPermissions(ctx)
+		if err != nil {
+			http.Error(w, authenticationError.Error(), http.StatusUnauthorized)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "csv"
+		}
+		if format != "csv" && format != "parquet" {
+			http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+			return
+		}
+
+		insightViewId, viewSeries, err := h.visibleViewSeries(ctx, mux.Vars(r)["id"], store.InsightQueryArgs{UserID: userID, OrgID: orgIDs})
+		if err != nil {
+			writeExportError(w, err)
+			return
+		}
+
+		seriesByID := make(map[string]types.InsightViewSeries, len(viewSeries))
+		seriesIDs := make([]string, 0, len(viewSeries))
+		for _, series := range viewSeries {
+			if _, ok := seriesByID[series.SeriesID]; !ok {
+				seriesIDs = append(seriesIDs, series.SeriesID)
+			}
+			seriesByID[series.SeriesID] = series
+		}
+
+		var encoder pointsEncoder
+		if format == "parquet" {
+			w.Header().Set("Content-Type", "application/vnd.apache.parquet")
+			encoder = &parquetPointsEncoder{w: parquet.NewWriter(w, pointsColumns)}
+		} else {
+			w.Header().Set("Content-Type", "text/csv")
+			encoder, err = newCSVPointsEncoder(w)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s-points.%s\"", insightViewId, format))
+
+		// The response is streamed, so errors past this point can not change the response status anymore.
+		err = h.seriesStore.StreamRecordedSeriesPoints(ctx, seriesIDs, func(point store.RecordedSeriesPoint) error {
+			return encoder.Write(seriesByID[point.SeriesID], point)
+		})
+		if err == nil {
+			err = h.writeDerivedPoints(ctx, encoder, seriesIDs, seriesByID, viewSeries)
+		}
+		if err == nil {
+			err = encoder.Close()
+		}
+		if err != nil {
+			h.logger.Error("failed to export code insights points", log.String("insightViewID", insightViewId), log.Error(err))
+		}
+	}
+}
+
+// writeDerivedPoints writes the points of the derived series among seriesIDs, which are not recorded but computed from
+// the points of their operand series the same way as when the insight is viewed. Derived points are not recorded for a
+// repository.
+func (h *ExportHandler) writeDerivedPoints(ctx context.Context, encoder pointsEncoder, seriesIDs []string, seriesByID map[string]types.InsightViewSeries, viewSeries []types.InsightViewSeries) error {
+	for _, seriesID := range seriesIDs {
+		series := seriesByID[seriesID]
+		if series.GenerationMethod != types.Derived {
+			continue
+		}
+		points, err := h.seriesStore.LoadDerivedSeriesPoints(ctx, series, viewSeries, store.SeriesPointsOpts{})
+		if err != nil {
+			return errors.Wrap(err, "LoadDerivedSeriesPoints")
+		}
+		for _, point := range points {
+			if err := encoder.Write(series, store.RecordedSeriesPoint{
+				SeriesID: point.SeriesID,
+				Time:     point.Time,
+				Value:    point.Value,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// maxImportSize is the maximum size of an imported file.
+const maxImportSize = 100 << 20 // 100MB
+
+// ImportPointsFunc returns a handler that records the points of a CSV file, or a Parquet file if the format query
+// parameter is "parquet", with the columns of exported points for the series of an insight view. The points are
+// validated against the definitions of their series and imported atomically, replacing the points already recorded
+// for the same series, repository, time and capture. Only site admins can import points, and files larger than
+// maxImportSize are rejected.
+func (h *ExportHandler) ImportPointsFunc() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		// 🚨 SECURITY: imported points are visible to all users who can see the repositories they are recorded for,
+		// so only site admins can import them.
+		if err := auth.CheckCurrentUserIsSiteAdmin(ctx, h.primaryDB); err != nil {
+			if errors.Is(err, auth.ErrMustBeSiteAdmin) {
+				http.Error(w, err.Error(), http.StatusForbidden)
+			} else {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+			}
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "csv"
+		}
+		if format != "csv" && format != "parquet" {
+			http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+			return
+		}
+
+		_, viewSeries, err := h.visibleViewSeries(ctx, mux.Vars(r)["id"], store.InsightQueryArgs{WithoutAuthorization: true})
+		if err != nil {
+			writeExportError(w, err)
+			return
+		}
+
+		var reader pointsReader
+		if format == "parquet" {
+			// Parquet files are read starting from their footer, so the body is spooled to a temporary file first.
+			f, err := os.CreateTemp("", "insights-points-*.parquet")
+			if err != nil {
+				http.Error(w, fmt.Sprintf("failed to import data: %v", err), http.StatusInternalServerError)
+				return
+			}
+			defer func() {
+				_ = f.Close()
+				_ = os.Remove(f.Name())
+			}()
+			size, err := io.Copy(f, r.Body)
+			if err != nil {
+				writeImportError(w, err)
+				return
+			}
+			reader, err = newParquetPointsReader(f, size)
+			if err != nil {
+				writeImportError(w, err)
+				return
+			}
+		} else {
+			reader, err = newCSVPointsReader(r.Body)
+			if err != nil {
+				writeImportError(w, err)
+				return
+			}
+		}
+
+		imported, err := h.importPoints(ctx, viewSeries, reader)
+		if err != nil {
+			writeImportError(w, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			Imported int `json:"imported"`
+		}{Imported: imported})
+	}
+}
+
+func writeImportError(w http.ResponseWriter, err error) {
+	var validationErr *importValidationError
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, fmt.Sprintf("file exceeds the import size limit of %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+	} else if errors.As(err, &validationErr) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	} else {
+		http.Error(w, fmt.Sprintf("failed to import data: %v", err), http.StatusInternalServerError)
+	}
+}
+
+func writeExportError(w http.ResponseWriter, err error) {
+	if errors.Is(err, notFoundError) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+	} else if errors.Is(err, invalidLicenseError) {
+		http.Error(w, err.Error(), http.StatusForbidden)
+	} else {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// importBatchSize is the number of points recorded at once during an import.
+const importBatchSize = 5000
+
+// importedPointKey identifies the points of an import that replace each other.
+type importedPointKey struct {
+	seriesID string
+	repoID   api.RepoID
+	time     int64
+	capture  string
+	snapshot bool
+}
+
+func (h *ExportHandler) importPoints(ctx context.Context, viewSeries []types.InsightViewSeries, reader pointsReader) (_ int, err error) {
+	seriesByID := make(map[string]types.InsightViewSeries, len(viewSeries))
+	for _, series := range viewSeries {
+		seriesByID[series.SeriesID] = series
+	}
+
+	tx, err := h.seriesStore.Transact(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	repoIDs := make(map[string]api.RepoID)
+	recordingTimes := make(map[int]map[types.RecordingTime]struct{})
+	var batch []store.RecordSeriesPointArgs
+	// batchIndex is the index of the points in the batch, so that a point read twice replaces the earlier one instead
+	// of being recorded twice. Points of earlier batches are replaced by the upsert.
+	batchIndex := make(map[importedPointKey]int)
+	imported := 0
+	now := time.Now()
+	for {
+		point, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		series, ok := seriesByID[point.SeriesID]
+		if !ok {
+			return 0, reader.validationError(errors.Newf("series %q is not part of the insight", point.SeriesID))
+		}
+		if err := validateImportedPoint(series, point, now); err != nil {
+			return 0, reader.validationError(err)
+		}
+
+		repoID, ok := repoIDs[*point.RepoName]
+		if !ok {
+			repo, err := h.primaryDB.Repos().GetByName(ctx, api.RepoName(*point.RepoName))
+			if err != nil {
+				if errcode.IsNotFound(err) {
+					return 0, reader.validationError(errors.Newf("repository %q not found", *point.RepoName))
+				}
+				return 0, errors.Wrap(err, "GetByName")
+			}
+			repoID = repo.ID
+			repoIDs[*point.RepoName] = repoID
+		}
+
+		persistMode := store.RecordMode
+		if point.Snapshot {
+			persistMode = store.SnapshotMode
+		}
+		key := importedPointKey{
+			seriesID: point.SeriesID,
+			repoID:   repoID,
+			time:     point.Time.UnixNano(),
+			capture:  emptyStringIfNil(point.Capture),
+			snapshot: point.Snapshot,
+		}
+		args := store.RecordSeriesPointArgs{
+			SeriesID: point.SeriesID,
+			Point: store.SeriesPoint{
+				SeriesID: point.SeriesID,
+				Time:     point.Time,
+				Value:    point.Value,
+				Capture:  point.Capture,
+			},
+			RepoName:    point.RepoName,
+			RepoID:      &repoID,
+			PersistMode: persistMode,
+		}
+		if i, ok := batchIndex[key]; ok {
+			batch[i] = args
+		} else {
+			batchIndex[key] = len(batch)
+			batch = append(batch, args)
+		}
+		if recordingTimes[series.InsightSeriesID] == nil {
+			recordingTimes[series.InsightSeriesID] = make(map[types.RecordingTime]struct{})
+		}
+		recordingTimes[series.InsightSeriesID][types.RecordingTime{Timestamp: point.Time, Snapshot: point.Snapshot}] = struct{}{}
+
+		if len(batch) >= importBatchSize {
+			if err := tx.UpsertSeriesPoints(ctx, batch); err != nil {
+				return 0, errors.Wrap(err, "UpsertSeriesPoints")
+			}
+			imported += len(batch)
+			batch = batch[:0]
+			batchIndex = make(map[importedPointKey]int)
+		}
+	}
+	if err := tx.UpsertSeriesPoints(ctx, batch); err != nil {
+		return 0, errors.Wrap(err, "UpsertSeriesPoints")
+	}
+	imported += len(batch)
+
+	seriesRecordingTimes := make([]types.InsightSeriesRecordingTimes, 0, len(recordingTimes))
+	for insightSeriesID, times := range recordingTimes {
+		current := types.InsightSeriesRecordingTimes{InsightSeriesID: insightSeriesID}
+		for recordingTime := range times {
+			current.RecordingTimes = append(current.RecordingTimes, recordingTime)
+		}
+		seriesRecordingTimes = append(seriesRecordingTimes, current)
+	}
+	if err := tx.SetInsightSeriesRecordingTimes(ctx, seriesRecordingTimes); err != nil {
+		return 0, errors.Wrap(err, "SetInsightSeriesRecordingTimes")
+	}
+	return imported, nil
+}
+
+// importedPoint is a point read from an imported file.
+type importedPoint struct {
+	SeriesID string
+	Time     time.Time
+	Value    float64
+	RepoName *string
+	Capture  *string
+	Snapshot bool
+}
+
+// importValidationError is returned for imported files with invalid points or that can not be parsed.
+type importValidationError struct {
+	// position is the line or row of the file the error occurred on, if any.
+	position string
+	err      error
+}
+
+func (e *importValidationError) Error() string {
+	if e.position == "" {
+		return e.err.Error()
+	}
+	return fmt.Sprintf("%s: %s", e.position, e.err)
+}
+
+func (e *importValidationError) Unwrap() error {
+	return e.err
+}
+
+// pointsReader reads points from an imported file with the columns of exported points. The label, query and repo_id
+// columns are ignored, since repositories are matched by name.
+type pointsReader interface {
+	// Read returns the next point, or io.EOF once all points are read.
+	Read() (importedPoint, error)
+	// validationError returns an importValidationError for the last point that was read.
+	validationError(err error) error
+}
+
+// csvPointsReader reads points from a CSV file.
+type csvPointsReader struct {
+	r       *csv.Reader
+	columns map[string]int
+	// line is the line of the last record that was read.
+	line int
+}
+
+func newCSVPointsReader(r io.Reader) (*csvPointsReader, error) {
+	reader := &csvPointsReader{r: csv.NewReader(r), columns: make(map[string]int)}
+	reader.r.ReuseRecord = true
+	header, err := reader.r.Read()
+	if err != nil {
+		return nil, reader.validationError(errors.Wrap(err, "reading header"))
+	}
+	reader.line, _ = reader.r.FieldPos(0)
+	for i, name := range header {
+		reader.columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{"series_id", "time", "value", "repo_name"} {
+		if _, ok := reader.columns[required]; !ok {
+			return nil, reader.validationError(errors.Newf("missing column %q", required))
+		}
+	}
+	return reader, nil
+}
+
+func (p *csvPointsReader) Read() (importedPoint, error) {
+	record, err := p.r.Read()
+	if err == io.EOF {
+		return importedPoint{}, err
+	}
+	if err != nil {
+		// parse errors include the line they occurred on.
+		return importedPoint{}, &importValidationError{err: err}
+	}
+	p.line, _ = p.r.FieldPos(0)
+	column := func(name string) string {
+		if i, ok := p.columns[name]; ok {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	optional := func(name string) *string {
+		if v := column(name); v != "" {
+			return &v
+		}
+		return nil
+	}
+
+	point := importedPoint{
+		SeriesID: column("series_id"),
+		RepoName: optional("repo_name"),
+		Capture:  optional("capture"),
+	}
+	if point.Time, err = time.Parse(time.RFC3339, column("time")); err != nil {
+		return importedPoint{}, p.validationError(errors.Wrap(err, "invalid time"))
+	}
+	point.Time = point.Time.UTC()
+	if point.Value, err = strconv.ParseFloat(column("value"), 64); err != nil {
+		return importedPoint{}, p.validationError(errors.Wrap(err, "invalid value"))
+	}
+	if snapshot := column("snapshot"); snapshot != "" {
+		if point.Snapshot, err = strconv.ParseBool(snapshot); err != nil {
+			return importedPoint{}, p.validationError(errors.Wrap(err, "invalid snapshot"))
+		}
+	}
+	return point, nil
+}
+
+func (p *csvPointsReader) validationError(err error) error {
+	if p.line == 0 {
+		return &importValidationError{err: err}
+	}
+	return &importValidationError{position: fmt.Sprintf("line %d", p.line), err: err}
+}
+
+// parquetPointsReader reads points from a Parquet file. Times can be timestamps or RFC 3339 strings, and values
+// doubles or integers.
+type parquetPointsReader struct {
+	r       *parquet.Reader
+	columns map[string]int
+	// row is the number of the last row that was read, starting at 1.
+	row int
+}
+
+func newParquetPointsReader(r io.ReaderAt, size int64) (*parquetPointsReader, error) {
+	pr, err := parquet.NewReader(r, size)
+	if err != nil {
+		return nil, &importValidationError{err: err}
+	}
+	reader := &parquetPointsReader{r: pr, columns: make(map[string]int)}
+	columnTypes := make(map[string]parquet.Type)
+	for i, column := range pr.Columns() {
+		reader.columns[column.Name] = i
+		columnTypes[column.Name] = column.Type
+	}
+	for _, required := range []string{"series_id", "time", "value", "repo_name"} {
+		if _, ok := reader.columns[required]; !ok {
+			return nil, &importValidationError{err: errors.Newf("missing column %q", required)}
+		}
+	}
+	for name, allowed := range map[string][]parquet.Type{
+		"series_id": {parquet.String},
+		"time":      {parquet.Timestamp, parquet.String},
+		"value":     {parquet.Double, parquet.Int64},
+		"repo_name": {parquet.String},
+		"capture":   {parquet.String},
+		"snapshot":  {parquet.Boolean},
+	} {
+		typ, ok := columnTypes[name]
+		if !ok {
+			continue
+		}
+		supported := false
+		for _, t := range allowed {
+			supported = supported || t == typ
+		}
+		if !supported {
+			return nil, &importValidationError{err: errors.Newf("column %q has an unsupported type", name)}
+		}
+	}
+	return reader, nil
+}
+
+func (p *parquetPointsReader) Read() (importedPoint, error) {
+	row, err := p.r.Read()
+	if err == io.EOF {
+		return importedPoint{}, err
+	}
+	p.row++
+	if err != nil {
+		return importedPoint{}, p.validationError(err)
+	}
+	value := func(name string) any {
+		if i, ok := p.columns[name]; ok {
+			return row[i]
+		}
+		return nil
+	}
+	optional := func(name string) *string {
+		if v, ok := value(name).(string); ok {
+			return &v
+		}
+		return nil
+	}
+
+	point := importedPoint{
+		RepoName: optional("repo_name"),
+		Capture:  optional("capture"),
+	}
+	point.SeriesID, _ = value("series_id").(string)
+	switch v := value("time").(type) {
+	case time.Time:
+		point.Time = v
+	case string:
+		if point.Time, err = time.Parse(time.RFC3339, v); err != nil {
+			return importedPoint{}, p.validationError(errors.Wrap(err, "invalid time"))
+		}
+	default:
+		return importedPoint{}, p.validationError(errors.New("missing time"))
+	}
+	point.Time = point.Time.UTC()
+	switch v := value("value").(type) {
+	case float64:
+		point.Value = v
+	case int64:
+		point.Value = float64(v)
+	default:
+		return importedPoint{}, p.validationError(errors.New("missing value"))
+	}
+	point.Snapshot, _ = value("snapshot").(bool)
+	return point, nil
+}
+
+func (p *parquetPointsReader) validationError(err error) error {
+	return &importValidationError{position: fmt.Sprintf("row %d", p.row), err: err}
+}
+
+// validateImportedPoint validates an imported point against the definition of its series.
+func validateImportedPoint(series types.InsightViewSeries, point importedPoint, now time.Time) error {
+	if series.JustInTime {
+		return errors.New("points can not be imported for series that are not recorded")
+	}
+	if series.GenerationMethod == types.Derived {
+		return errors.New("points can not be imported for derived series, which are computed from other series")
+	}
+	if point.Time.After(now) {
+		return errors.Newf("time %s is in the future", point.Time.Format(time.RFC3339))
+	}
+	if point.Value < 0 || math.IsNaN(point.Value) || math.IsInf(point.Value, 0) {
+		return errors.Newf("invalid value %v", point.Value)
+	}
+
+	if point.RepoName == nil {
+		return errors.New("a repository is required")
+	}
+	if len(series.Repositories) > 0 {
+		found := false
+		for _, repo := range series.Repositories {
+			if repo == *point.RepoName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Newf("repository %q is not part of the series", *point.RepoName)
+		}
+	}
+
+	switch {
+	case series.GeneratedFromCaptureGroups:
+		if point.Capture == nil {
+			return errors.New("points of capture group series require a capture")
+		}
+	default:
+		if point.Capture != nil {
+			return errors.New("points of this series can not have a capture")
+		}
+	}
+	return nil
+}
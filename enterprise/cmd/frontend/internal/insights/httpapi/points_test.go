@@ -0,0 +1,319 @@
+package httpapi
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/parquet"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestValidateImportedPoint(t *testing.T) {
+	now := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+	percentage := types.Percentage
+
+	cases := []struct {
+		name   string
+		series types.InsightViewSeries
+		point  importedPoint
+		want   autogold.Value
+	}{
+		{
+			name:   "valid",
+			series: types.InsightViewSeries{},
+			point:  importedPoint{Time: past, Value: 1, RepoName: pointers.Ptr("repo")},
+			want:   autogold.Expect("<nil>"),
+		},
+		{
+			name:   "just in time series",
+			series: types.InsightViewSeries{JustInTime: true},
+			point:  importedPoint{Time: past, Value: 1, RepoName: pointers.Ptr("repo")},
+			want:   autogold.Expect("points can not be imported for series that are not recorded"),
+		},
+		{
+			name:   "future time",
+			series: types.InsightViewSeries{},
+			point:  importedPoint{Time: now.Add(time.Hour), Value: 1, RepoName: pointers.Ptr("repo")},
+			want:   autogold.Expect("time 2023-06-01T01:00:00Z is in the future"),
+		},
+		{
+			name:   "negative value",
+			series: types.InsightViewSeries{},
+			point:  importedPoint{Time: past, Value: -1, RepoName: pointers.Ptr("repo")},
+			want:   autogold.Expect("invalid value -1"),
+		},
+		{
+			name:   "missing repository",
+			series: types.InsightViewSeries{},
+			point:  importedPoint{Time: past, Value: 1},
+			want:   autogold.Expect("a repository is required"),
+		},
+		{
+			name:   "repository not in series",
+			series: types.InsightViewSeries{Repositories: []string{"a", "b"}},
+			point:  importedPoint{Time: past, Value: 1, RepoName: pointers.Ptr("c")},
+			want:   autogold.Expect(`repository "c" is not part of the series`),
+		},
+		{
+			name:   "capture for search series",
+			series: types.InsightViewSeries{},
+			point:  importedPoint{Time: past, Value: 1, RepoName: pointers.Ptr("repo"), Capture: pointers.Ptr("1.2")},
+			want:   autogold.Expect("points of this series can not have a capture"),
+		},
+		{
+			name:   "missing capture for capture group series",
+			series: types.InsightViewSeries{GeneratedFromCaptureGroups: true},
+			point:  importedPoint{Time: past, Value: 1, RepoName: pointers.Ptr("repo")},
+			want:   autogold.Expect("points of capture group series require a capture"),
+		},
+		{
+			name: "derived series",
+			series: types.InsightViewSeries{
+				GenerationMethod:   types.Derived,
+				DerivedOperation:   &percentage,
+				DerivedNumerator:   []string{"new"},
+				DerivedDenominator: []string{"old", "new"},
+			},
+			point: importedPoint{Time: past, Value: 1, RepoName: pointers.Ptr("repo")},
+			want:  autogold.Expect("points can not be imported for derived series, which are computed from other series"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := "<nil>"
+			if err := validateImportedPoint(tc.series, tc.point, now); err != nil {
+				got = err.Error()
+			}
+			tc.want.Equal(t, got)
+		})
+	}
+}
+
+func TestCSVPointsReader(t *testing.T) {
+	t.Run("reads columns by name", func(t *testing.T) {
+		input := "value,series_id,repo_name,time,capture,snapshot\n" +
+			"2.5,s1,github.com/a/b,2023-01-01T00:00:00Z,,false\n" +
+			"3,s1,github.com/a/b,2023-02-01T00:00:00Z,1.2,true\n"
+		reader, err := newCSVPointsReader(strings.NewReader(input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []importedPoint
+		for {
+			point, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, point)
+		}
+		autogold.Expect([]importedPoint{
+			{
+				SeriesID: "s1",
+				Time:     time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Value:    2.5,
+				RepoName: pointers.Ptr("github.com/a/b"),
+			},
+			{
+				SeriesID: "s1",
+				Time:     time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+				Value:    3,
+				RepoName: pointers.Ptr("github.com/a/b"),
+				Capture:  pointers.Ptr("1.2"),
+				Snapshot: true,
+			},
+		}).Equal(t, got)
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		_, err := newCSVPointsReader(strings.NewReader("series_id,time,value\n"))
+		autogold.Expect(`line 1: missing column "repo_name"`).Equal(t, err.Error())
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		reader, err := newCSVPointsReader(strings.NewReader("series_id,time,value,repo_name\ns1,2023-01-01T00:00:00Z,1,r\ns1,2023-01-01T00:00:00Z,x,r\n"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := reader.Read(); err != nil {
+			t.Fatal(err)
+		}
+		_, err = reader.Read()
+		autogold.Expect(`line 3: invalid value: strconv.ParseFloat: parsing "x": invalid syntax`).Equal(t, err.Error())
+	})
+}
+
+func TestParquetPointsReader(t *testing.T) {
+	t.Run("reads exported points", func(t *testing.T) {
+		var buf bytes.Buffer
+		encoder := &parquetPointsEncoder{w: parquet.NewWriter(&buf, pointsColumns)}
+		repoID := api.RepoID(7)
+		series := types.InsightViewSeries{SeriesID: "s1", Label: "Go files", Query: "lang:go"}
+		points := []store.RecordedSeriesPoint{
+			{SeriesID: "s1", Time: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), Value: 2.5, RepoID: &repoID, RepoName: pointers.Ptr("github.com/a/b")},
+			{SeriesID: "s1", Time: time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC), Value: 3, RepoName: pointers.Ptr("github.com/a/b"), Capture: pointers.Ptr("1.2"), Snapshot: true},
+		}
+		for _, point := range points {
+			if err := encoder.Write(series, point); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := encoder.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		reader, err := newParquetPointsReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []importedPoint
+		for {
+			point, err := reader.Read()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, point)
+		}
+		autogold.Expect([]importedPoint{
+			{
+				SeriesID: "s1",
+				Time:     time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+				Value:    2.5,
+				RepoName: pointers.Ptr("github.com/a/b"),
+			},
+			{
+				SeriesID: "s1",
+				Time:     time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC),
+				Value:    3,
+				RepoName: pointers.Ptr("github.com/a/b"),
+				Capture:  pointers.Ptr("1.2"),
+				Snapshot: true,
+			},
+		}).Equal(t, got)
+	})
+
+	t.Run("string times and integer values", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := parquet.NewWriter(&buf, []parquet.Column{
+			{Name: "series_id", Type: parquet.String},
+			{Name: "time", Type: parquet.String},
+			{Name: "value", Type: parquet.Int64},
+			{Name: "repo_name", Type: parquet.String},
+		})
+		if err := w.Write("s1", "2023-01-01T02:00:00+02:00", int64(4), "github.com/a/b"); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Write("s1", "yesterday", int64(4), "github.com/a/b"); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		reader, err := newParquetPointsReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		point, err := reader.Read()
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect(importedPoint{
+			SeriesID: "s1",
+			Time:     time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Value:    4,
+			RepoName: pointers.Ptr("github.com/a/b"),
+		}).Equal(t, point)
+		_, err = reader.Read()
+		autogold.Expect(`row 2: invalid time: parsing time "yesterday" as "2006-01-02T15:04:05Z07:00": cannot parse "yesterday" as "2006"`).Equal(t, err.Error())
+	})
+
+	t.Run("missing column", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := parquet.NewWriter(&buf, []parquet.Column{
+			{Name: "series_id", Type: parquet.String},
+			{Name: "time", Type: parquet.Timestamp},
+			{Name: "value", Type: parquet.Double},
+		})
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		_, err := newParquetPointsReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		autogold.Expect(`missing column "repo_name"`).Equal(t, err.Error())
+	})
+
+	t.Run("unsupported column type", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := parquet.NewWriter(&buf, []parquet.Column{
+			{Name: "series_id", Type: parquet.String},
+			{Name: "time", Type: parquet.Timestamp},
+			{Name: "value", Type: parquet.String},
+			{Name: "repo_name", Type: parquet.String},
+		})
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		_, err := newParquetPointsReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		autogold.Expect(`column "value" has an unsupported type`).Equal(t, err.Error())
+	})
+}
+
+func TestCSVPointsEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	encoder, err := newCSVPointsEncoder(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repoID := api.RepoID(7)
+	series := types.InsightViewSeries{SeriesID: "s1", Label: "Go files", Query: "lang:go"}
+	points := []store.RecordedSeriesPoint{
+		{SeriesID: "s1", Time: time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC), Value: 2.5, RepoID: &repoID, RepoName: pointers.Ptr("github.com/a/b")},
+		{SeriesID: "s1", Time: time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC), Value: 3, Capture: pointers.Ptr("1.2"), Snapshot: true},
+	}
+	for _, point := range points {
+		if err := encoder.Write(series, point); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := encoder.Close(); err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect(`series_id,label,query,time,repo_id,repo_name,capture,value,snapshot
+s1,Go files,lang:go,2023-01-01T00:00:00Z,7,github.com/a/b,,2.5,false
+s1,Go files,lang:go,2023-02-01T00:00:00Z,,,1.2,3,true
+`).Equal(t, buf.String())
+}
+
+func TestWriteImportError(t *testing.T) {
+	t.Run("too large", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		body := http.MaxBytesReader(w, io.NopCloser(strings.NewReader("series_id,time,value,repo_name\n")), 10)
+		_, err := newCSVPointsReader(body)
+		writeImportError(w, err)
+		autogold.Expect(http.StatusRequestEntityTooLarge).Equal(t, w.Code)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		_, err := newCSVPointsReader(strings.NewReader("series_id,time,value\n"))
+		writeImportError(w, err)
+		autogold.Expect(http.StatusBadRequest).Equal(t, w.Code)
+	})
+}
@@ -38,7 +38,10 @@ func Init(
 		return err
 	}
 	enterpriseServices.InsightsResolver = resolvers.New(rawInsightsDB, db)
-	enterpriseServices.CodeInsightsDataExportHandler = httpapi.NewExportHandler(db, rawInsightsDB).ExportFunc()
+	exportHandler := httpapi.NewExportHandler(db, rawInsightsDB)
+	enterpriseServices.CodeInsightsDataExportHandler = exportHandler.ExportFunc()
+	enterpriseServices.CodeInsightsDataExportPointsHandler = exportHandler.ExportPointsFunc()
+	enterpriseServices.CodeInsightsDataImportPointsHandler = exportHandler.ImportPointsFunc()
 
 	return nil
 }
@@ -14,8 +14,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/querybuilder"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/query/streaming"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	ownsearch "github.com/sourcegraph/sourcegraph/enterprise/internal/own/search"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
@@ -38,6 +40,8 @@ const invalidQueryMsg = "Grouping is disabled because the search query is not va
 const fileUnsupportedFieldValueFmt = `Grouping by file is not available for searches with "%s:%s".`
 const authNotCommitDiffMsg = "Grouping by author is only available for diff and commit searches."
 const repoMetadataNotRepoSelectMsg = "Grouping by repo metadata is only available for repository searches."
+const ownerUnsupportedFieldValueFmt = `Grouping by owner is not available for searches with "%s:%s".`
+const commitDateNotCommitDiffMsg = "Grouping by commit date is only available for diff and commit searches."
 const cgInvalidQueryMsg = "Grouping by capture group is only available for regexp searches that contain a capturing group."
 const cgMultipleQueryPatternMsg = "Grouping by capture group does not support search patterns with the following: and, or, negation."
 const cgUnsupportedSelectFmt = `Grouping by capture group is not available for searches with "%s:%s".`
@@ -55,6 +59,7 @@ const unableToCountGroupsMsg = "The search results were unable to be grouped suc
 type searchAggregateResolver struct {
 	postgresDB     database.DB
 	enterpriseJobs jobutil.EnterpriseJobs
+	gitserver      gitserver.Client
 
 	searchQuery string
 	patternType string
@@ -142,7 +147,17 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 		cappedAggregator.Add(amr.Key.Group, int32(amr.Count))
 	}
 
-	countingFunc, err := aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode)
+	requestContext, cancelReqContext := context.WithTimeout(ctx, time.Second*time.Duration(searchTimelimit))
+	defer cancelReqContext()
+
+	var countingFunc aggregation.AggregationCountFunc
+	if aggregationMode == types.OWNER_AGGREGATION_MODE {
+		// Owners are counted from the same ownership data that the has.owner() drilldown filter uses.
+		rules := ownsearch.NewRulesCache(r.gitserver, r.postgresDB)
+		countingFunc = aggregation.NewOwnerCountFunc(requestContext, &rules)
+	} else {
+		countingFunc, err = aggregation.GetCountFuncForMode(r.searchQuery, r.patternType, aggregationMode)
+	}
 	if err != nil {
 		r.getLogger().Debug("no aggregation counting function for mode", log.String("mode", string(aggregationMode)), log.Error(err))
 		return &searchAggregationResultResolver{
@@ -151,9 +166,6 @@ func (r *searchAggregateResolver) Aggregations(ctx context.Context, args graphql
 				aggregationMode),
 		}, nil
 	}
-
-	requestContext, cancelReqContext := context.WithTimeout(ctx, time.Second*time.Duration(searchTimelimit))
-	defer cancelReqContext()
 	searchClient := streaming.NewInsightsSearchClient(r.postgresDB, r.enterpriseJobs)
 	searchResultsAggregator := aggregation.NewSearchResultsAggregatorWithContext(requestContext, tabulationFunc, countingFunc, r.postgresDB, aggregationMode)
 
@@ -374,6 +386,9 @@ func getAggregateBy(mode types.SearchAggregationMode) canAggregateBy {
 		types.AUTHOR_AGGREGATION_MODE:        canAggregateByAuthor,
 		types.CAPTURE_GROUP_AGGREGATION_MODE: canAggregateByCaptureGroup,
 		types.REPO_METADATA_AGGREGATION_MODE: canAggregateByRepoMetadata,
+		types.OWNER_AGGREGATION_MODE:         canAggregateByOwner,
+		types.COMMIT_WEEK_AGGREGATION_MODE:   canAggregateByCommitDate,
+		types.COMMIT_MONTH_AGGREGATION_MODE:  canAggregateByCommitDate,
 	}
 	canAggregateByFunc, ok := checkByMode[mode]
 	if !ok {
@@ -506,6 +521,43 @@ func canAggregateByRepoMetadata(searchQuery, patternType string) (bool, *notAvai
 	return false, &notAvailableReason{reason: repoMetadataNotRepoSelectMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
 }
 
+func canAggregateByOwner(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
+	if err != nil {
+		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
+	}
+	parameters := querybuilder.ParametersFromQueryPlan(plan)
+	// owners are resolved per file, so we cannot aggregate over:
+	// - searches by commit, diff or repo
+	for _, parameter := range parameters {
+		if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
+			if strings.EqualFold(parameter.Value, "commit") || strings.EqualFold(parameter.Value, "diff") || strings.EqualFold(parameter.Value, "repo") {
+				reason := fmt.Sprintf(ownerUnsupportedFieldValueFmt,
+					parameter.Field, parameter.Value)
+				return false, &notAvailableReason{reason: reason, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
+			}
+		}
+	}
+	return true, nil, nil
+}
+
+func canAggregateByCommitDate(searchQuery, patternType string) (bool, *notAvailableReason, error) {
+	plan, err := querybuilder.ParseQuery(searchQuery, patternType)
+	if err != nil {
+		return false, &notAvailableReason{reason: invalidQueryMsg, reasonType: types.INVALID_QUERY}, errors.Wrapf(err, "ParseQuery")
+	}
+	parameters := querybuilder.ParametersFromQueryPlan(plan)
+	// can only aggregate over type:diff and select/type:commit searches, which are the only ones with a commit date.
+	for _, parameter := range parameters {
+		if parameter.Field == query.FieldSelect || parameter.Field == query.FieldType {
+			if parameter.Value == "diff" || parameter.Value == "commit" {
+				return true, nil, nil
+			}
+		}
+	}
+	return false, &notAvailableReason{reason: commitDateNotCommitDiffMsg, reasonType: types.INVALID_AGGREGATION_MODE_FOR_QUERY}, nil
+}
+
 // A  type to represent the GraphQL union SearchAggregationResult
 type searchAggregationResultResolver struct {
 	resolver any
@@ -610,6 +662,16 @@ func buildDrilldownQuery(mode types.SearchAggregationMode, originalQuery string,
 		modifierFunc = querybuilder.AddFileFilter
 	case types.AUTHOR_AGGREGATION_MODE:
 		modifierFunc = querybuilder.AddAuthorFilter
+	case types.OWNER_AGGREGATION_MODE:
+		modifierFunc = querybuilder.AddOwnerFilter
+	case types.COMMIT_WEEK_AGGREGATION_MODE, types.COMMIT_MONTH_AGGREGATION_MODE:
+		modifierFunc = func(basicQuery querybuilder.BasicQuery, s string) (querybuilder.BasicQuery, error) {
+			start, end, err := aggregation.CommitDateRange(mode, s)
+			if err != nil {
+				return "", err
+			}
+			return querybuilder.AddCommitDateFilter(basicQuery, start, end)
+		}
 	case types.CAPTURE_GROUP_AGGREGATION_MODE:
 		searchType, err := client.SearchTypeFromString(patternType)
 		if err != nil {
@@ -189,6 +189,66 @@ func Test_canAggregateByAuthor(t *testing.T) {
 	suite.Test_canAggregateBy()
 }
 
+func Test_canAggregateByOwner(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "can aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for query with select:file parameter",
+			query:        "func(t *testing.T) select:file",
+			canAggregate: true,
+		},
+		{
+			name:         "cannot aggregate for query with type:diff parameter",
+			query:        "fix type:diff",
+			reason:       `Grouping by owner is not available for searches with "type:diff".`,
+			canAggregate: false,
+		},
+		{
+			name:         "cannot aggregate for query with select:repo parameter",
+			query:        "repo:contains.path(README) select:repo",
+			reason:       `Grouping by owner is not available for searches with "select:repo".`,
+			canAggregate: false,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByOwner,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
+func Test_canAggregateByCommitDate(t *testing.T) {
+	testCases := []canAggregateTestCase{
+		{
+			name:         "cannot aggregate for query without parameters",
+			query:        "func(t *testing.T)",
+			reason:       commitDateNotCommitDiffMsg,
+			canAggregate: false,
+		},
+		{
+			name:         "can aggregate for query with type:commit parameter",
+			query:        "type:commit fix",
+			canAggregate: true,
+		},
+		{
+			name:         "can aggregate for query with type:diff parameter",
+			query:        "type:diff fix",
+			canAggregate: true,
+		},
+	}
+	suite := canAggregateBySuite{
+		canAggregateByFunc: canAggregateByCommitDate,
+		testCases:          testCases,
+		t:                  t,
+	}
+	suite.Test_canAggregateBy()
+}
+
 func Test_canAggregateByCaptureGroup(t *testing.T) {
 	testCases := []canAggregateTestCase{
 		{
@@ -470,6 +530,20 @@ func Test_buildDrilldownQuery(t *testing.T) {
 			patternType: "standard",
 			mode:        types.CAPTURE_GROUP_AGGREGATION_MODE,
 		},
+		{
+			want:        autogold.Expect("file:has.owner(@backend) findme"),
+			query:       "findme",
+			drilldown:   "@backend",
+			patternType: "standard",
+			mode:        types.OWNER_AGGREGATION_MODE,
+		},
+		{
+			want:        autogold.Expect("type:diff after:2022-03-31T23:59:59Z before:2022-05-01T00:00:00Z findme"),
+			query:       "findme type:diff",
+			drilldown:   "2022-04-01",
+			patternType: "standard",
+			mode:        types.COMMIT_MONTH_AGGREGATION_MODE,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.query, func(t *testing.T) {
@@ -0,0 +1,167 @@
+package resolvers
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ graphqlbackend.InsightSeriesAlertResolver = &insightSeriesAlertResolver{}
+
+const insightSeriesAlertKind = "InsightSeriesAlert"
+
+// 🚨 SECURITY: Alerts are restricted to site admins, since the value of a series they compare to the threshold is
+// summed across all repositories, without filtering the repositories the user can not see.
+
+func (r *Resolver) InsightSeriesAlerts(ctx context.Context, args *graphqlbackend.InsightSeriesAlertsArgs) ([]graphqlbackend.InsightSeriesAlertResolver, error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	series, err := r.loadAlertSeries(ctx, args.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+	alerts, err := r.insightStore.GetSeriesAlerts(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]graphqlbackend.InsightSeriesAlertResolver, 0, len(alerts))
+	for _, alert := range alerts {
+		resolvers = append(resolvers, &insightSeriesAlertResolver{alert: alert, seriesID: series.SeriesID})
+	}
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateInsightSeriesAlert(ctx context.Context, args *graphqlbackend.CreateInsightSeriesAlertArgs) (graphqlbackend.InsightSeriesAlertResolver, error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	series, err := r.loadAlertSeries(ctx, args.Input.SeriesId)
+	if err != nil {
+		return nil, err
+	}
+	if series.JustInTime || series.GenerationMethod == types.Derived {
+		return nil, errors.New("alerts are only supported on series that are recorded in the background")
+	}
+	alert, err := seriesAlertFromInput(args.Input)
+	if err != nil {
+		return nil, err
+	}
+	alert.SeriesID = series.ID
+	alert.UserID = actr.UID
+
+	created, err := r.insightStore.CreateSeriesAlert(ctx, alert)
+	if err != nil {
+		return nil, errors.Wrap(err, "CreateSeriesAlert")
+	}
+	return &insightSeriesAlertResolver{alert: created, seriesID: series.SeriesID}, nil
+}
+
+func (r *Resolver) DeleteInsightSeriesAlert(ctx context.Context, args *graphqlbackend.DeleteInsightSeriesAlertArgs) (*graphqlbackend.EmptyResponse, error) {
+	actr := actor.FromContext(ctx)
+	if err := auth.CheckUserIsSiteAdmin(ctx, r.postgresDB, actr.UID); err != nil {
+		return nil, err
+	}
+
+	var id int
+	if err := relay.UnmarshalSpec(args.Id, &id); err != nil {
+		return nil, errors.Wrap(err, "error unmarshalling the alert id")
+	}
+	if err := r.insightStore.DeleteSeriesAlert(ctx, id); err != nil {
+		return nil, errors.Wrap(err, "DeleteSeriesAlert")
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) loadAlertSeries(ctx context.Context, seriesID string) (types.InsightSeries, error) {
+	series, err := r.insightStore.GetDataSeries(ctx, store.GetDataSeriesArgs{SeriesID: seriesID})
+	if err != nil {
+		return types.InsightSeries{}, err
+	}
+	if len(series) == 0 {
+		return types.InsightSeries{}, errors.Newf("unable to fetch series with series_id: %v", seriesID)
+	}
+	return series[0], nil
+}
+
+// seriesAlertFromInput validates the input of an alert and returns the alert it describes.
+func seriesAlertFromInput(input graphqlbackend.CreateInsightSeriesAlertInput) (types.InsightSeriesAlert, error) {
+	alert := types.InsightSeriesAlert{
+		Condition:       types.AlertCondition(input.Condition),
+		Threshold:       input.Threshold,
+		Email:           input.Email != nil && *input.Email,
+		SlackWebhookURL: input.SlackWebhookURL,
+		WebhookURL:      input.WebhookURL,
+	}
+	switch alert.Condition {
+	case types.AlertAbove, types.AlertIncrease:
+	default:
+		return alert, errors.Newf("invalid alert condition %q", input.Condition)
+	}
+	if alert.Condition == types.AlertIncrease && alert.Threshold < 0 {
+		return alert, errors.New("the threshold of an INCREASE alert can not be negative")
+	}
+	if !alert.Email && alert.SlackWebhookURL == nil && alert.WebhookURL == nil {
+		return alert, errors.New("an alert requires at least one of email, slackWebhookURL or webhookURL")
+	}
+	for _, u := range []*string{alert.SlackWebhookURL, alert.WebhookURL} {
+		if u == nil {
+			continue
+		}
+		if parsed, err := url.Parse(*u); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return alert, errors.Newf("invalid webhook URL %q", *u)
+		}
+	}
+	return alert, nil
+}
+
+type insightSeriesAlertResolver struct {
+	alert    types.InsightSeriesAlert
+	seriesID string
+}
+
+func (a *insightSeriesAlertResolver) ID() graphql.ID {
+	return relay.MarshalID(insightSeriesAlertKind, a.alert.ID)
+}
+
+func (a *insightSeriesAlertResolver) SeriesId() string {
+	return a.seriesID
+}
+
+func (a *insightSeriesAlertResolver) Condition() string {
+	return string(a.alert.Condition)
+}
+
+func (a *insightSeriesAlertResolver) Threshold() float64 {
+	return a.alert.Threshold
+}
+
+func (a *insightSeriesAlertResolver) Email() bool {
+	return a.alert.Email
+}
+
+func (a *insightSeriesAlertResolver) SlackWebhookURL() *string {
+	return a.alert.SlackWebhookURL
+}
+
+func (a *insightSeriesAlertResolver) WebhookURL() *string {
+	return a.alert.WebhookURL
+}
+
+func (a *insightSeriesAlertResolver) LastTriggeredAt() *gqlutil.DateTime {
+	return gqlutil.DateTimeOrNil(a.alert.LastTriggeredAt)
+}
@@ -0,0 +1,58 @@
+package resolvers
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestSeriesAlertFromInput(t *testing.T) {
+	cases := []struct {
+		name  string
+		input graphqlbackend.CreateInsightSeriesAlertInput
+		want  autogold.Value
+	}{
+		{
+			name:  "email alert",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Condition: "ABOVE", Threshold: 10, Email: pointers.Ptr(true)},
+			want:  autogold.Expect("<nil>"),
+		},
+		{
+			name:  "webhook alert",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Condition: "INCREASE", WebhookURL: pointers.Ptr("https://example.com/hook")},
+			want:  autogold.Expect("<nil>"),
+		},
+		{
+			name:  "invalid condition",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Condition: "BELOW", Email: pointers.Ptr(true)},
+			want:  autogold.Expect(`invalid alert condition "BELOW"`),
+		},
+		{
+			name:  "negative increase",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Condition: "INCREASE", Threshold: -1, Email: pointers.Ptr(true)},
+			want:  autogold.Expect("the threshold of an INCREASE alert can not be negative"),
+		},
+		{
+			name:  "no recipient",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Condition: "ABOVE", Email: pointers.Ptr(false)},
+			want:  autogold.Expect("an alert requires at least one of email, slackWebhookURL or webhookURL"),
+		},
+		{
+			name:  "invalid webhook URL",
+			input: graphqlbackend.CreateInsightSeriesAlertInput{Condition: "ABOVE", SlackWebhookURL: pointers.Ptr("file:///etc/passwd")},
+			want:  autogold.Expect(`invalid webhook URL "file:///etc/passwd"`),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := "<nil>"
+			if _, err := seriesAlertFromInput(tc.input); err != nil {
+				got = err.Error()
+			}
+			tc.want.Equal(t, got)
+		})
+	}
+}
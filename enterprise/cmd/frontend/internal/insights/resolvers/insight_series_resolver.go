@@ -226,6 +226,10 @@ func (p *precalculatedInsightSeriesResolver) Points(ctx context.Context, _ *grap
 				SearchQuery:        querybuilder.BasicQuery(query),
 			},
 		}
+		if p.series.GenerationMethod == types.Derived {
+			// derived series are computed from several queries, so there is no single query to diff.
+			pointResolver.diffInfo = nil
+		}
 		resolvers = append(resolvers, pointResolver)
 	}
 
@@ -424,6 +428,48 @@ func recordedSeries(ctx context.Context, definition types.InsightViewSeries, r b
 	return resolvers, nil
 }
 
+// derivedSeries returns a generator of derived series, which computes their points from the points of their operand
+// series among siblings. The siblings are the other series of the same insight view, so the filters and display
+// options of the view apply to the operands as well.
+func derivedSeries(siblings []types.InsightViewSeries) resolverGenerator {
+	return func(ctx context.Context, definition types.InsightViewSeries, r baseInsightResolver, filters types.InsightViewFilters, options types.SeriesDisplayOptions) ([]graphqlbackend.InsightSeriesResolver, error) {
+		operandPoints := make(map[string][]store.SeriesPoint)
+		for _, seriesID := range append(append([]string{}, definition.DerivedNumerator...), definition.DerivedDenominator...) {
+			if _, ok := operandPoints[seriesID]; ok {
+				continue
+			}
+			operand, ok := store.DerivedOperandSeries(siblings, seriesID)
+			if !ok {
+				// The operand series was removed from the view, so it contributes nothing.
+				operandPoints[seriesID] = nil
+				continue
+			}
+			points, err := fetchSeries(ctx, operand, filters, options, &r)
+			if err != nil {
+				return nil, errors.Wrapf(err, "fetchSeries for operand seriesID: %s", operand.SeriesID)
+			}
+			operandPoints[seriesID] = points
+		}
+
+		statusResolver := NewStatusResolver(&r, definition)
+
+		var resolvers []graphqlbackend.InsightSeriesResolver
+
+		resolvers = append(resolvers, &precalculatedInsightSeriesResolver{
+			insightsStore:   r.timeSeriesStore,
+			workerBaseStore: r.workerBaseStore,
+			series:          definition,
+			metadataStore:   r.insightStore,
+			points:          store.DerivedSeriesPoints(definition, operandPoints),
+			label:           definition.Label,
+			filters:         filters,
+			seriesId:        definition.SeriesID,
+			statusResolver:  statusResolver,
+		})
+		return resolvers, nil
+	}
+}
+
 func expandCaptureGroupSeriesRecorded(ctx context.Context, definition types.InsightViewSeries, r baseInsightResolver, filters types.InsightViewFilters, options types.SeriesDisplayOptions) ([]graphqlbackend.InsightSeriesResolver, error) {
 	allPoints, err := fetchSeries(ctx, definition, filters, options, &r)
 	if err != nil {
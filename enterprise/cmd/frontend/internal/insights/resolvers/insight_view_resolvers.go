@@ -11,6 +11,7 @@ import (
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/segmentio/ksuid"
+	"golang.org/x/exp/slices"
 
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
@@ -149,6 +150,12 @@ func (i *insightViewResolver) registerDataSeriesGenerators() {
 		},
 		expandCaptureGroupSeriesRecorded,
 	)
+	derivedGenerator := newSeriesResolverGenerator(
+		func(series types.InsightViewSeries) bool {
+			return series.GenerationMethod == types.Derived
+		},
+		derivedSeries(i.view.Series),
+	)
 	recordedGenerator := newSeriesResolverGenerator(
 		func(series types.InsightViewSeries) bool {
 			return !series.JustInTime && !series.GeneratedFromCaptureGroups
@@ -156,7 +163,8 @@ func (i *insightViewResolver) registerDataSeriesGenerators() {
 		recordedSeries,
 	)
 	// build the chain of generators
-	recordedCaptureGroupGenerator.SetNext(recordedGenerator)
+	recordedCaptureGroupGenerator.SetNext(derivedGenerator)
+	derivedGenerator.SetNext(recordedGenerator)
 
 	// set the struct variable to the first generator in the chain
 	i.dataSeriesGenerator = recordedCaptureGroupGenerator
@@ -346,6 +354,29 @@ func (s *searchInsightDataSeriesDefinitionResolver) GroupBy() (*string, error) {
 	return s.series.GroupBy, nil
 }
 
+func (s *searchInsightDataSeriesDefinitionResolver) Derived() (graphqlbackend.DerivedSeriesDefinitionResolver, error) {
+	if s.series.DerivedOperation == nil {
+		return nil, nil
+	}
+	return &derivedSeriesDefinitionResolver{series: s.series}, nil
+}
+
+type derivedSeriesDefinitionResolver struct {
+	series *types.InsightViewSeries
+}
+
+func (d *derivedSeriesDefinitionResolver) Operation() string {
+	return string(*d.series.DerivedOperation)
+}
+
+func (d *derivedSeriesDefinitionResolver) Numerator() []string {
+	return d.series.DerivedNumerator
+}
+
+func (d *derivedSeriesDefinitionResolver) Denominator() []string {
+	return d.series.DerivedDenominator
+}
+
 type insightIntervalTimeScopeResolver struct {
 	unit  string
 	value int32
@@ -454,6 +485,11 @@ func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graph
 		if err != nil {
 			return nil, err
 		}
+		if args.Input.DataSeries[i].Derived != nil {
+			if err := validateDerivedSeries(*args.Input.DataSeries[i].Derived, args.Input.DataSeries); err != nil {
+				return nil, err
+			}
+		}
 
 		if len(args.Input.DataSeries[i].RepositoryScope.Repositories) > 0 {
 			err := validateRepositoryList(ctx, args.Input.DataSeries[i].RepositoryScope.Repositories, r.postgresDB.Repos())
@@ -508,9 +544,17 @@ func (r *Resolver) CreateLineChartSearchInsight(ctx context.Context, args *graph
 
 	seriesFillStrategy := makeFillSeriesStrategy(insightTx, r.scheduler, r.insightEnqueuer)
 
-	for _, series := range args.Input.DataSeries {
-		if err := createAndAttachSeries(ctx, insightTx, seriesFillStrategy, view, series); err != nil {
-			return nil, errors.Wrap(err, "createAndAttachSeries")
+	// Derived series are created after the other series, so that they can refer to their operands by series ID.
+	seriesIDs := make([]string, len(args.Input.DataSeries))
+	for _, derived := range []bool{false, true} {
+		for i, series := range args.Input.DataSeries {
+			if (series.Derived != nil) != derived {
+				continue
+			}
+			seriesIDs[i], err = createAndAttachSeries(ctx, insightTx, seriesFillStrategy, view, series, seriesIDs)
+			if err != nil {
+				return nil, errors.Wrap(err, "createAndAttachSeries")
+			}
 		}
 	}
 
@@ -550,6 +594,11 @@ func (r *Resolver) UpdateLineChartSearchInsight(ctx context.Context, args *graph
 		if err != nil {
 			return nil, err
 		}
+		if args.Input.DataSeries[i].Derived != nil {
+			if err := validateDerivedSeries(*args.Input.DataSeries[i].Derived, args.Input.DataSeries); err != nil {
+				return nil, err
+			}
+		}
 
 		if len(args.Input.DataSeries[i].RepositoryScope.Repositories) > 0 {
 			err := validateRepositoryList(ctx, args.Input.DataSeries[i].RepositoryScope.Repositories, r.postgresDB.Repos())
@@ -667,14 +716,14 @@ func isCaptureGroupSeries(generatedFromCaptureGroups *bool) bool {
 func updateCaptureGroupInsight(ctx context.Context, input graphqlbackend.LineChartSearchInsightDataSeriesInput, existingSeries []types.InsightViewSeries, view types.InsightView, tx *store.InsightStore, seriesFillStrategy fillSeriesStrategy) error {
 	if len(existingSeries) == 0 {
 		// This should not happen, but if we somehow have no existing series for an insight, create one.
-		if err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, input); err != nil {
+		if _, err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, input, nil); err != nil {
 			return errors.Wrap(err, "createAndAttachSeries")
 		}
-	} else if existingSeriesHasChanged(input, existingSeries[0]) {
+	} else if existingSeriesHasChanged(input, existingSeries[0], nil) {
 		if err := tx.RemoveSeriesFromView(ctx, existingSeries[0].SeriesID, view.ID); err != nil {
 			return errors.Wrap(err, "RemoveSeriesFromView")
 		}
-		if err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, input); err != nil {
+		if _, err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, input, nil); err != nil {
 			return errors.Wrap(err, "createAndAttachSeries")
 		}
 	} else {
@@ -699,47 +748,81 @@ func updateSearchOrComputeInsight(ctx context.Context, input graphqlbackend.Upda
 			existingSeriesMap[existing.SeriesID] = existing
 		}
 	}
-	for _, series := range input.DataSeries {
-		if series.SeriesId == nil {
-			// If this is a newly added series, create and attach it.
-			// Note: the frontend always generates a series ID so this path is never hit at the moment.
-			if err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, series); err != nil {
-				return errors.Wrap(err, "createAndAttachSeries")
+	// Derived series are updated after the other series, so that they can refer to the IDs of their operands, which
+	// change when the operands are recreated.
+	seriesIDs := make([]string, len(input.DataSeries))
+	for _, derived := range []bool{false, true} {
+		for i, series := range input.DataSeries {
+			if (series.Derived != nil) != derived {
+				continue
 			}
-		} else {
-			if existing, ok := existingSeriesMap[*series.SeriesId]; ok {
-				// We check whether the series has changed such that it needs to be recalculated.
-				if existingSeriesHasChanged(series, existing) {
-					if err := tx.RemoveSeriesFromView(ctx, *series.SeriesId, view.ID); err != nil {
-						return errors.Wrap(err, "RemoveViewSeries")
-					}
-					if err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, series); err != nil {
-						return errors.Wrap(err, "createAndAttachSeries")
-					}
-				} else {
-					// Otherwise we simply update the series' presentation metadata.
-					if err := tx.UpdateViewSeries(ctx, *series.SeriesId, view.ID, types.InsightViewSeriesMetadata{
-						Label:  emptyIfNil(series.Options.Label),
-						Stroke: emptyIfNil(series.Options.LineColor),
-					}); err != nil {
-						return errors.Wrap(err, "UpdateViewSeries")
-					}
-				}
-			} else {
-				// This is a new series, so it needs to be calculated and attached.
-				if err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, series); err != nil {
-					return errors.Wrap(err, "createAndAttachSeries")
-				}
+			seriesID, err := updateSearchOrComputeSeries(ctx, series, existingSeriesMap, seriesIDs, view, tx, seriesFillStrategy)
+			if err != nil {
+				return err
 			}
+			seriesIDs[i] = seriesID
 		}
 	}
 	return nil
 }
 
+// updateSearchOrComputeSeries updates a data series of an insight view and returns the ID of the series attached to
+// the view. The series IDs of the other data series of the input are used to resolve the operands of derived series.
+func updateSearchOrComputeSeries(ctx context.Context, series graphqlbackend.LineChartSearchInsightDataSeriesInput, existingSeriesMap map[string]types.InsightViewSeries, seriesIDs []string, view types.InsightView, tx *store.InsightStore, seriesFillStrategy fillSeriesStrategy) (string, error) {
+	if series.SeriesId == nil {
+		// If this is a newly added series, create and attach it.
+		// Note: the frontend always generates a series ID so this path is never hit at the moment.
+		seriesID, err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, series, seriesIDs)
+		if err != nil {
+			return "", errors.Wrap(err, "createAndAttachSeries")
+		}
+		return seriesID, nil
+	}
+	existing, ok := existingSeriesMap[*series.SeriesId]
+	if !ok {
+		// This is a new series, so it needs to be calculated and attached.
+		seriesID, err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, series, seriesIDs)
+		if err != nil {
+			return "", errors.Wrap(err, "createAndAttachSeries")
+		}
+		return seriesID, nil
+	}
+	// We check whether the series has changed such that it needs to be recalculated.
+	if existingSeriesHasChanged(series, existing, seriesIDs) {
+		if err := tx.RemoveSeriesFromView(ctx, *series.SeriesId, view.ID); err != nil {
+			return "", errors.Wrap(err, "RemoveViewSeries")
+		}
+		seriesID, err := createAndAttachSeries(ctx, tx, seriesFillStrategy, view, series, seriesIDs)
+		if err != nil {
+			return "", errors.Wrap(err, "createAndAttachSeries")
+		}
+		return seriesID, nil
+	}
+	// Otherwise we simply update the series' presentation metadata.
+	if err := tx.UpdateViewSeries(ctx, *series.SeriesId, view.ID, types.InsightViewSeriesMetadata{
+		Label:  emptyIfNil(series.Options.Label),
+		Stroke: emptyIfNil(series.Options.LineColor),
+	}); err != nil {
+		return "", errors.Wrap(err, "UpdateViewSeries")
+	}
+	return *series.SeriesId, nil
+}
+
 // existingSeriesHasChanged returns a bool indicating if the series was changed in a way that would invalid the existing data.
+// The series IDs of the other data series of the input are used to resolve the operands of derived series.
 // This function assumes that the input has already been validated
-func existingSeriesHasChanged(new graphqlbackend.LineChartSearchInsightDataSeriesInput, existing types.InsightViewSeries) bool {
-	if new.Query != existing.Query {
+func existingSeriesHasChanged(new graphqlbackend.LineChartSearchInsightDataSeriesInput, existing types.InsightViewSeries, seriesIDs []string) bool {
+	if (new.Derived != nil) != (existing.DerivedOperation != nil) {
+		return true
+	}
+	if new.Derived != nil {
+		numerator, denominator := derivedSeriesOperands(*new.Derived, seriesIDs)
+		if types.DerivedOperation(new.Derived.Operation) != *existing.DerivedOperation ||
+			!slices.Equal(numerator, existing.DerivedNumerator) ||
+			!slices.Equal(denominator, existing.DerivedDenominator) {
+			return true
+		}
+	} else if new.Query != existing.Query {
 		return true
 	}
 	if new.TimeScope.StepInterval.Unit != existing.SampleIntervalUnit {
@@ -1274,6 +1357,9 @@ type fillSeriesStrategy func(context.Context, types.InsightSeries) error
 
 func makeFillSeriesStrategy(tx *store.InsightStore, scheduler *scheduler.Scheduler, insightEnqueuer *background.InsightEnqueuer) fillSeriesStrategy {
 	return func(ctx context.Context, series types.InsightSeries) error {
+		if series.GenerationMethod == types.Derived {
+			return derivedSeriesFill(ctx, series, tx)
+		}
 		if series.GroupBy != nil {
 			return groupBySeriesFill(ctx, series, tx, insightEnqueuer)
 		}
@@ -1294,6 +1380,15 @@ func groupBySeriesFill(ctx context.Context, series types.InsightSeries, tx *stor
 	return nil
 }
 
+// derivedSeriesFill does not queue any searches, since derived series are computed from the points of their operand
+// series when they are read. The backfill is stamped so that the series does not appear to be loading.
+func derivedSeriesFill(ctx context.Context, series types.InsightSeries, tx *store.InsightStore) error {
+	if _, err := tx.StampBackfill(ctx, series); err != nil {
+		return errors.Wrap(err, "Derived.StampBackfill")
+	}
+	return nil
+}
+
 func historicFill(ctx context.Context, series types.InsightSeries, tx *store.InsightStore, backfillScheduler *scheduler.Scheduler) error {
 	backfillScheduler = backfillScheduler.With(tx)
 	_, err := backfillScheduler.InitialBackfill(ctx, series)
@@ -1308,19 +1403,31 @@ func historicFill(ctx context.Context, series types.InsightSeries, tx *store.Ins
 	return nil
 }
 
-func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSeriesFill fillSeriesStrategy, view types.InsightView, series graphqlbackend.LineChartSearchInsightDataSeriesInput) error {
+// createAndAttachSeries creates a data series, or finds a matching one, and attaches it to the view. It returns the ID of
+// the attached series. The operands of a derived series are resolved to the series IDs of the other data series of the
+// input, which must have been attached before.
+func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSeriesFill fillSeriesStrategy, view types.InsightView, series graphqlbackend.LineChartSearchInsightDataSeriesInput, seriesIDs []string) (string, error) {
 	var seriesToAdd, matchingSeries types.InsightSeries
 	var foundSeries bool
 	var err error
 	var dynamic bool
+	var derivedOperation *types.DerivedOperation
+	var numerator, denominator []string
 	// Validate the query before creating anything; we don't want faulty insights running pointlessly.
-	if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
+	if series.Derived != nil {
+		// The operands of a derived series are other data series of the insight, which are validated when those
+		// series are created.
+		numerator, denominator = derivedSeriesOperands(*series.Derived, seriesIDs)
+		operation := types.DerivedOperation(series.Derived.Operation)
+		derivedOperation = &operation
+		series.Query = ""
+	} else if series.GroupBy != nil || series.GeneratedFromCaptureGroups != nil {
 		if _, err := querybuilder.ParseComputeQuery(series.Query); err != nil {
-			return errors.Wrap(err, "query validation")
+			return "", errors.Wrap(err, "query validation")
 		}
 	} else {
 		if _, err := querybuilder.ParseQuery(series.Query, "literal"); err != nil {
-			return errors.Wrap(err, "query validation")
+			return "", errors.Wrap(err, "query validation")
 		}
 	}
 
@@ -1341,7 +1448,8 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 	// Don't try to match on non-global series, since they are always replaced
 	// Also don't try to match on series that use repo criteria
 	// TODO: Reconsider matching on criteria based series. If so the edit case would need work to ensure other insights remain the same.
-	if len(series.RepositoryScope.Repositories) == 0 && series.RepositoryScope.RepositoryCriteria == nil {
+	// Derived series are not matched either, since matching only considers the query.
+	if len(series.RepositoryScope.Repositories) == 0 && series.RepositoryScope.RepositoryCriteria == nil && series.Derived == nil {
 		matchingSeries, foundSeries, err = tx.FindMatchingSeries(ctx, store.MatchSeriesArgs{
 			Query:                     series.Query,
 			StepIntervalUnit:          series.TimeScope.StepInterval.Unit,
@@ -1350,7 +1458,7 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			GroupBy:                   groupBy,
 		})
 		if err != nil {
-			return errors.Wrap(err, "FindMatchingSeries")
+			return "", errors.Wrap(err, "FindMatchingSeries")
 		}
 	}
 
@@ -1370,13 +1478,16 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 			NextRecordingAfter:         nextRecordingAfter,
 			OldestHistoricalAt:         oldestHistoricalAt,
 			RepositoryCriteria:         series.RepositoryScope.RepositoryCriteria,
+			DerivedOperation:           derivedOperation,
+			DerivedNumerator:           numerator,
+			DerivedDenominator:         denominator,
 		})
 		if err != nil {
-			return errors.Wrap(err, "CreateSeries")
+			return "", errors.Wrap(err, "CreateSeries")
 		}
 		err := startSeriesFill(ctx, seriesToAdd)
 		if err != nil {
-			return errors.Wrap(err, "startSeriesFill")
+			return "", errors.Wrap(err, "startSeriesFill")
 		}
 	} else {
 		seriesToAdd = matchingSeries
@@ -1390,13 +1501,16 @@ func createAndAttachSeries(ctx context.Context, tx *store.InsightStore, startSer
 		Stroke: emptyIfNil(series.Options.LineColor),
 	})
 	if err != nil {
-		return errors.Wrap(err, "AttachSeriesToView")
+		return "", errors.Wrap(err, "AttachSeriesToView")
 	}
 
-	return nil
+	return seriesToAdd.SeriesID, nil
 }
 
 func searchGenerationMethod(series graphqlbackend.LineChartSearchInsightDataSeriesInput) types.GenerationMethod {
+	if series.Derived != nil {
+		return types.Derived
+	}
 	if series.GeneratedFromCaptureGroups != nil && *series.GeneratedFromCaptureGroups {
 		if series.GroupBy != nil {
 			return types.MappingCompute
@@ -1626,6 +1740,17 @@ func isValidSeriesInput(seriesInput graphqlbackend.LineChartSearchInsightDataSer
 		return errors.New("group by series require a list of repositories to be specified.")
 	}
 
+	if seriesInput.Derived != nil {
+		if seriesInput.GroupBy != nil || isCaptureGroupSeries(seriesInput.GeneratedFromCaptureGroups) {
+			return errors.New("derived series can not be generated from capture groups or grouped.")
+		}
+		switch types.DerivedOperation(seriesInput.Derived.Operation) {
+		case types.Percentage, types.Ratio:
+		default:
+			return errors.Newf("unsupported derived series operation: %s", seriesInput.Derived.Operation)
+		}
+	}
+
 	if repoCriteriaSpecified {
 		plan, err := querybuilder.ParseQuery(*seriesInput.RepositoryScope.RepositoryCriteria, "literal")
 		if err != nil {
@@ -1639,3 +1764,40 @@ func isValidSeriesInput(seriesInput graphqlbackend.LineChartSearchInsightDataSer
 
 	return nil
 }
+
+// validateDerivedSeries validates the numerator and denominator of a derived series input, given as indexes into the
+// data series of the insight. Only plain search series can be operands.
+func validateDerivedSeries(derived graphqlbackend.DerivedSeriesInput, dataSeries []graphqlbackend.LineChartSearchInsightDataSeriesInput) error {
+	validate := func(indexes []int32) error {
+		if len(indexes) == 0 {
+			return errors.New("derived series require at least one numerator and one denominator series.")
+		}
+		for _, index := range indexes {
+			if index < 0 || int(index) >= len(dataSeries) {
+				return errors.Newf("derived series operand %d does not exist.", index)
+			}
+			operand := dataSeries[index]
+			if operand.Derived != nil || operand.GroupBy != nil || isCaptureGroupSeries(operand.GeneratedFromCaptureGroups) {
+				return errors.Newf("derived series operand %d must be a search series.", index)
+			}
+		}
+		return nil
+	}
+	if err := validate(derived.Numerator); err != nil {
+		return err
+	}
+	return validate(derived.Denominator)
+}
+
+// derivedSeriesOperands resolves the numerator and denominator of a validated derived series input to the series IDs
+// of the data series at their indexes.
+func derivedSeriesOperands(derived graphqlbackend.DerivedSeriesInput, seriesIDs []string) (numerator, denominator []string) {
+	resolve := func(indexes []int32) []string {
+		ids := make([]string, 0, len(indexes))
+		for _, index := range indexes {
+			ids = append(ids, seriesIDs[index])
+		}
+		return ids
+	}
+	return resolve(derived.Numerator), resolve(derived.Denominator)
+}
@@ -485,3 +485,41 @@ func TestInsightRepoScopeResolver(t *testing.T) {
 	}
 
 }
+
+func TestDerivedSeriesOperands(t *testing.T) {
+	dataSeries := []graphqlbackend.LineChartSearchInsightDataSeriesInput{
+		{Query: "lang:go"},
+		// Operands are resolved by position, so series with the same query are told apart.
+		{Query: "lang:go"},
+		{Derived: &graphqlbackend.DerivedSeriesInput{Operation: "RATIO", Numerator: []int32{1}, Denominator: []int32{0, 1}}},
+	}
+	if err := validateDerivedSeries(*dataSeries[2].Derived, dataSeries); err != nil {
+		t.Fatal(err)
+	}
+	for _, invalid := range []graphqlbackend.DerivedSeriesInput{
+		{Numerator: []int32{0}},
+		{Numerator: []int32{0}, Denominator: []int32{3}},
+		{Numerator: []int32{2}, Denominator: []int32{0}},
+	} {
+		if err := validateDerivedSeries(invalid, dataSeries); err == nil {
+			t.Errorf("expected an error for %+v", invalid)
+		}
+	}
+
+	seriesIDs := []string{"first", "second", ""}
+	numerator, denominator := derivedSeriesOperands(*dataSeries[2].Derived, seriesIDs)
+	autogold.Expect([][]string{{"second"}, {"first", "second"}}).Equal(t, [][]string{numerator, denominator})
+
+	operation := types.Ratio
+	existing := types.InsightViewSeries{DerivedOperation: &operation, DerivedNumerator: []string{"second"}, DerivedDenominator: []string{"first", "second"}}
+	input := dataSeries[2]
+	input.TimeScope = &graphqlbackend.TimeScopeInput{StepInterval: &graphqlbackend.TimeIntervalStepInput{}}
+	input.RepositoryScope = &graphqlbackend.RepositoryScopeInput{}
+	if existingSeriesHasChanged(input, existing, seriesIDs) {
+		t.Error("expected the derived series to be unchanged")
+	}
+	// An operand that was recreated has a new series ID.
+	if !existingSeriesHasChanged(input, existing, []string{"first", "recreated", ""}) {
+		t.Error("expected the derived series to be changed")
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
@@ -122,6 +123,7 @@ func getUserPermissions(ctx context.Context, orgStore database.OrgStore) (userId
 type AggregationResolver struct {
 	postgresDB     database.DB
 	enterpriseJobs jobutil.EnterpriseJobs
+	gitserver      gitserver.Client
 	logger         log.Logger
 	operations     *aggregationsOperations
 }
@@ -131,6 +133,7 @@ func NewAggregationResolver(observationCtx *observation.Context, postgres databa
 		logger:         log.Scoped("AggregationResolver", ""),
 		postgresDB:     postgres,
 		enterpriseJobs: enterpriseJobs,
+		gitserver:      gitserver.NewClient(),
 		operations:     newAggregationsOperations(observationCtx),
 	}
 }
@@ -139,6 +142,7 @@ func (r *AggregationResolver) SearchQueryAggregate(ctx context.Context, args gra
 	return &searchAggregateResolver{
 		postgresDB:     r.postgresDB,
 		enterpriseJobs: r.enterpriseJobs,
+		gitserver:      r.gitserver,
 		searchQuery:    args.Query,
 		patternType:    args.PatternType,
 		operations:     r.operations,
@@ -4,11 +4,13 @@ import (
 	"context"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/notebooks/resolvers"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
 
@@ -20,6 +22,10 @@ func Init(
 	_ conftypes.UnifiedWatchable,
 	enterpriseServices *enterprise.Services,
 ) error {
-	enterpriseServices.NotebooksResolver = resolvers.NewResolver(db)
+	// The insights resolver may be initialized after the notebooks resolver, so
+	// it is read lazily when an insight block is executed.
+	insightsResolver := func() graphqlbackend.InsightsResolver { return enterpriseServices.InsightsResolver }
+	executor := resolvers.NewBlockExecutor(observationCtx.Logger.Scoped("notebooks", "notebook block execution"), db, enterpriseServices.EnterpriseSearchJobs, insightsResolver)
+	enterpriseServices.NotebooksResolver = resolvers.NewResolver(db, gitserver.NewClient(), executor)
 	return nil
 }
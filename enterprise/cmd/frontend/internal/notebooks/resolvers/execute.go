@@ -0,0 +1,285 @@
+package resolvers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxBlockTableRows is the maximum number of rows returned when executing a
+// block. Rows beyond this limit are dropped and the table is marked as
+// truncated.
+const maxBlockTableRows = 1000
+
+// BlockExecutor executes compute and insight blocks on the server, so that
+// their output can be returned alongside the notebook.
+type BlockExecutor interface {
+	ExecuteBlock(ctx context.Context, block notebooks.NotebookBlock) (*notebooks.NotebookBlockTable, error)
+}
+
+// NewBlockExecutor returns a BlockExecutor. Insight blocks are executed with
+// the resolver returned by insightsResolver, which is called lazily since the
+// insights resolver may be initialized after the notebooks resolver.
+func NewBlockExecutor(logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJobs, insightsResolver func() graphqlbackend.InsightsResolver) BlockExecutor {
+	return &blockExecutor{
+		logger:           logger,
+		db:               db,
+		enterpriseJobs:   enterpriseJobs,
+		insightsResolver: insightsResolver,
+	}
+}
+
+type blockExecutor struct {
+	logger           log.Logger
+	db               database.DB
+	enterpriseJobs   jobutil.EnterpriseJobs
+	insightsResolver func() graphqlbackend.InsightsResolver
+}
+
+func (e *blockExecutor) ExecuteBlock(ctx context.Context, block notebooks.NotebookBlock) (*notebooks.NotebookBlockTable, error) {
+	switch {
+	case block.Type == notebooks.NotebookComputeBlockType && block.ComputeInput != nil:
+		return e.executeCompute(ctx, *block.ComputeInput)
+	case block.Type == notebooks.NotebookInsightBlockType && block.InsightInput != nil:
+		return e.executeInsight(ctx, *block.InsightInput)
+	default:
+		return nil, errors.Errorf("block with id %s cannot be executed", block.ID)
+	}
+}
+
+// computeBlockSearchLimit is the number of search results the output of a
+// compute block is computed from, unless its query has a count: filter. It is
+// set explicitly since the default limit of searches is too low for aggregations
+// to be meaningful. Memory stays bounded regardless, since matches are computed
+// as they are streamed, and the search is stopped once the table is full.
+const computeBlockSearchLimit = 10000
+
+func (e *blockExecutor) executeCompute(ctx context.Context, input notebooks.NotebookComputeBlockInput) (*notebooks.NotebookBlockTable, error) {
+	computeQuery, err := compute.Parse(input.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	searchQuery, err := computeQuery.ToSearchQuery()
+	if err != nil {
+		return nil, err
+	}
+
+	patternType := "regexp"
+	searchClient := client.New(e.logger, e.db, e.enterpriseJobs)
+	inputs, err := searchClient.Plan(ctx, "", &patternType, searchQuery, search.Precise, search.Streaming)
+	if err != nil {
+		return nil, err
+	}
+	inputs.Plan = inputs.Plan.WithDefaultCount(computeBlockSearchLimit)
+
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Events can be sent concurrently, while the table is built sequentially.
+	var (
+		mu       sync.Mutex
+		builder  = newComputeTableBuilder(computeQuery.Command)
+		limitHit bool
+		runErr   error
+	)
+	stream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		limitHit = limitHit || event.Stats.IsLimitHit
+		for _, m := range event.Results {
+			if runErr != nil {
+				return
+			}
+			full, err := builder.add(searchCtx, m)
+			if err != nil || full {
+				runErr = err
+				cancel()
+				return
+			}
+		}
+	})
+	_, err = searchClient.Execute(searchCtx, stream, inputs)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if runErr != nil {
+		return nil, runErr
+	}
+	table := builder.result(limitHit)
+	// The search is canceled once the table is full.
+	if err != nil && (ctx.Err() != nil || !table.LimitHit) {
+		return nil, err
+	}
+	return table, nil
+}
+
+// computeTableBuilder builds the table of a compute block from the matches of
+// its search.
+type computeTableBuilder struct {
+	command    compute.Command
+	aggregator *compute.Aggregator
+	table      *notebooks.NotebookBlockTable
+}
+
+func newComputeTableBuilder(command compute.Command) *computeTableBuilder {
+	if aggregate, ok := command.(*compute.Aggregate); ok {
+		return &computeTableBuilder{
+			command:    command,
+			aggregator: compute.NewAggregator(aggregate, compute.DefaultMaxAggregationBytes),
+		}
+	}
+	return &computeTableBuilder{
+		command: command,
+		table:   newBlockTable("repository", "path", "value"),
+	}
+}
+
+// add adds the output of the compute command for a match to the table. It
+// returns true once the table is full.
+func (b *computeTableBuilder) add(ctx context.Context, m result.Match) (bool, error) {
+	computeResult, err := b.command.Run(ctx, m)
+	if err != nil {
+		return false, err
+	}
+	if b.aggregator != nil {
+		b.aggregator.Add(computeResult)
+		return false, nil
+	}
+
+	repo := string(m.RepoName().Name)
+	path := ""
+	if fm, ok := m.(*result.FileMatch); ok {
+		path = fm.Path
+	}
+	for _, value := range computeResultValues(computeResult) {
+		if !addBlockTableRow(b.table, repo, path, value) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// result returns the table. The table of an aggregation has the count of every
+// group, ordered by descending count. searchLimitHit marks the table as
+// truncated, since the output is then computed from a subset of the matches.
+func (b *computeTableBuilder) result(searchLimitHit bool) *notebooks.NotebookBlockTable {
+	table := b.table
+	if b.aggregator != nil {
+		aggregation := b.aggregator.Result()
+		table = newBlockTable("value", "count")
+		for _, group := range aggregation.Groups {
+			if !addBlockTableRow(table, group.Value, strconv.Itoa(group.Count)) {
+				break
+			}
+		}
+		if aggregation.LimitHit {
+			table.LimitHit = true
+		}
+	}
+	if searchLimitHit {
+		table.LimitHit = true
+	}
+	return table
+}
+
+// computeResultValues returns the values of a compute result, one per row.
+func computeResultValues(r compute.Result) []string {
+	switch v := r.(type) {
+	case *compute.MatchContext:
+		values := make([]string, 0, len(v.Matches))
+		for _, m := range v.Matches {
+			values = append(values, m.Value)
+		}
+		return values
+	case *compute.Text:
+		return []string{v.Value}
+	case *compute.TextExtra:
+		return []string{v.Value}
+	}
+	// We processed a match that compute doesn't generate a result for.
+	return nil
+}
+
+func (e *blockExecutor) executeInsight(ctx context.Context, input notebooks.NotebookInsightBlockInput) (*notebooks.NotebookBlockTable, error) {
+	var insightsResolver graphqlbackend.InsightsResolver
+	if e.insightsResolver != nil {
+		insightsResolver = e.insightsResolver()
+	}
+	if insightsResolver == nil {
+		return nil, errors.New("code insights are not available")
+	}
+
+	// 🚨 SECURITY: The insights resolver only returns insight views that are
+	// visible to the current user.
+	id := graphql.ID(input.InsightViewID)
+	views, err := insightsResolver.InsightViews(ctx, &graphqlbackend.InsightViewQueryArgs{Id: &id})
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := views.Nodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, errors.Errorf("insight view %s not found", input.InsightViewID)
+	}
+
+	series, err := nodes[0].DataSeries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	include := make(map[string]struct{}, len(input.SeriesIDs))
+	for _, id := range input.SeriesIDs {
+		include[id] = struct{}{}
+	}
+
+	table := newBlockTable("series", "date", "value")
+	for _, s := range series {
+		if _, ok := include[s.SeriesId()]; len(include) > 0 && !ok {
+			continue
+		}
+
+		points, err := s.Points(ctx, &graphqlbackend.InsightsPointsArgs{})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range points {
+			if !addBlockTableRow(table, s.Label(), p.DateTime().Format(time.RFC3339), strconv.FormatFloat(p.Value(), 'f', -1, 64)) {
+				return table, nil
+			}
+		}
+	}
+	return table, nil
+}
+
+func newBlockTable(columns ...string) *notebooks.NotebookBlockTable {
+	return &notebooks.NotebookBlockTable{Columns: columns, Rows: [][]string{}}
+}
+
+// addBlockTableRow appends a row to the table. It returns false, and marks the
+// table as truncated, if the table is already full.
+func addBlockTableRow(t *notebooks.NotebookBlockTable, values ...string) bool {
+	if len(t.Rows) >= maxBlockTableRows {
+		t.LimitHit = true
+		return false
+	}
+	t.Rows = append(t.Rows, values)
+	return true
+}
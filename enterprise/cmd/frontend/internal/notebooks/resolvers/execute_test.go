@@ -0,0 +1,141 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestComputeResultValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		result compute.Result
+		want   []string
+	}{
+		{
+			name: "match context",
+			result: &compute.MatchContext{Matches: []compute.Match{
+				{Value: "foo"},
+				{Value: "bar"},
+			}},
+			want: []string{"foo", "bar"},
+		},
+		{
+			name:   "text",
+			result: &compute.Text{Value: "foo"},
+			want:   []string{"foo"},
+		},
+		{
+			name:   "text extra",
+			result: &compute.TextExtra{Text: compute.Text{Value: "foo"}},
+			want:   []string{"foo"},
+		},
+		{
+			name:   "no result",
+			result: nil,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, computeResultValues(tt.result)); diff != "" {
+				t.Fatalf("unexpected values (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAggregateBlockTable(t *testing.T) {
+	computeQuery, err := compute.Parse(`content:count.by((\w+)Error -> $1)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileMatch := func(content string) result.Match {
+		return &result.FileMatch{ChunkMatches: result.ChunkMatches{{
+			Content: content,
+			Ranges:  result.Ranges{{End: result.Location{Offset: len(content)}}},
+		}}}
+	}
+
+	builder := newComputeTableBuilder(computeQuery.Command)
+	for _, m := range []result.Match{fileMatch("parseError ioError"), fileMatch("parseError")} {
+		if _, err := builder.add(context.Background(), m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := &notebooks.NotebookBlockTable{
+		Columns: []string{"value", "count"},
+		Rows:    [][]string{{"parse", "2"}, {"io", "1"}},
+	}
+	if diff := cmp.Diff(want, builder.result(false)); diff != "" {
+		t.Fatalf("unexpected table (-want +got):\n%s", diff)
+	}
+
+	want.LimitHit = true
+	if diff := cmp.Diff(want, builder.result(true)); diff != "" {
+		t.Fatalf("unexpected table when the search limit is hit (-want +got):\n%s", diff)
+	}
+}
+
+func TestComputeTableBuilderFull(t *testing.T) {
+	computeQuery, err := compute.Parse(`word`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := strings.Repeat("word ", maxBlockTableRows/2+1)
+	fileMatch := &result.FileMatch{
+		File: result.File{Path: "a.txt"},
+		ChunkMatches: result.ChunkMatches{{
+			Content: content,
+			Ranges:  result.Ranges{{End: result.Location{Offset: len(content)}}},
+		}},
+	}
+
+	builder := newComputeTableBuilder(computeQuery.Command)
+	for i, wantFull := range []bool{false, true} {
+		full, err := builder.add(context.Background(), fileMatch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if full != wantFull {
+			t.Fatalf("match %d: got full %t, want %t", i, full, wantFull)
+		}
+	}
+	table := builder.result(false)
+	if len(table.Rows) != maxBlockTableRows || !table.LimitHit {
+		t.Fatalf("expected a full table, got %d rows and limit hit %t", len(table.Rows), table.LimitHit)
+	}
+}
+
+func TestAddBlockTableRow(t *testing.T) {
+	table := newBlockTable("a")
+	for i := 0; i < maxBlockTableRows; i++ {
+		if !addBlockTableRow(table, "x") {
+			t.Fatalf("row %d was not added", i)
+		}
+	}
+	if table.LimitHit {
+		t.Fatal("expected limit not to be hit")
+	}
+	if addBlockTableRow(table, "x") {
+		t.Fatal("expected row beyond the limit not to be added")
+	}
+	if !table.LimitHit || len(table.Rows) != maxBlockTableRows {
+		t.Fatalf("expected %d rows with limit hit, got %d rows with limitHit=%t", maxBlockTableRows, len(table.Rows), table.LimitHit)
+	}
+}
+
+func TestExecuteBlockInvalidType(t *testing.T) {
+	executor := NewBlockExecutor(nil, nil, nil, nil)
+	_, err := executor.ExecuteBlock(context.Background(), notebooks.NotebookBlock{ID: "1", Type: notebooks.NotebookMarkdownBlockType})
+	if err == nil {
+		t.Fatal("expected error executing a markdown block")
+	}
+}
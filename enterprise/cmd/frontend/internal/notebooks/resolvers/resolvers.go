@@ -1,7 +1,10 @@
 package resolvers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"strings"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
@@ -10,18 +13,22 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func NewResolver(db database.DB) graphqlbackend.NotebooksResolver {
-	return &Resolver{db: db}
+func NewResolver(db database.DB, gitserverClient gitserver.Client, executor BlockExecutor) graphqlbackend.NotebooksResolver {
+	return &Resolver{db: db, gitserverClient: gitserverClient, executor: executor}
 }
 
 type Resolver struct {
-	db database.DB
+	db              database.DB
+	gitserverClient gitserver.Client
+	executor        BlockExecutor
 }
 
 func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
@@ -58,7 +65,7 @@ func (r *Resolver) NotebookByID(ctx context.Context, id graphql.ID) (graphqlback
 		return nil, err
 	}
 
-	return &notebookResolver{notebook, r.db}, nil
+	return &notebookResolver{notebook, r.db, r.gitserverClient, r.executor}, nil
 }
 
 func convertLineRangeInput(inputLineRage *graphqlbackend.CreateFileBlockLineRangeInput) *notebooks.LineRange {
@@ -108,6 +115,21 @@ func convertNotebookBlockInput(inputBlock graphqlbackend.CreateNotebookBlockInpu
 			SymbolContainerName: inputBlock.SymbolInput.SymbolContainerName,
 			SymbolKind:          inputBlock.SymbolInput.SymbolKind,
 		}
+	case graphqlbackend.NotebookComputeBlockType:
+		if inputBlock.ComputeInput == nil {
+			return nil, errors.Errorf("compute block with id %s is missing input", inputBlock.ID)
+		}
+		block.Type = notebooks.NotebookComputeBlockType
+		block.ComputeInput = &notebooks.NotebookComputeBlockInput{Text: *inputBlock.ComputeInput}
+	case graphqlbackend.NotebookInsightBlockType:
+		if inputBlock.InsightInput == nil {
+			return nil, errors.Errorf("insight block with id %s is missing input", inputBlock.ID)
+		}
+		block.Type = notebooks.NotebookInsightBlockType
+		block.InsightInput = &notebooks.NotebookInsightBlockInput{InsightViewID: string(inputBlock.InsightInput.InsightViewID)}
+		if inputBlock.InsightInput.SeriesIDs != nil {
+			block.InsightInput.SeriesIDs = *inputBlock.InsightInput.SeriesIDs
+		}
 	default:
 		return nil, errors.Newf("invalid block type: %s", inputBlock.Type)
 	}
@@ -150,7 +172,63 @@ func (r *Resolver) CreateNotebook(ctx context.Context, args graphqlbackend.Creat
 	if err != nil {
 		return nil, err
 	}
-	return &notebookResolver{createdNotebook, r.db}, nil
+	return &notebookResolver{createdNotebook, r.db, r.gitserverClient, r.executor}, nil
+}
+
+func (r *Resolver) ImportNotebookFromRepository(ctx context.Context, args graphqlbackend.ImportNotebookFromRepositoryArgs) (graphqlbackend.NotebookResolver, error) {
+	user, err := r.db.Users().GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Repos().Get and ReadFile ensure the user has access to the
+	// repository and the notebook file.
+	repo, err := r.db.Repos().Get(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	if err := notebooks.ValidateSourceVisibility(repo, args.Public); err != nil {
+		return nil, err
+	}
+	commit, err := notebooks.ResolveSourceCommit(ctx, r.gitserverClient, repo.Name)
+	if err != nil {
+		return nil, err
+	}
+	filePath := strings.TrimPrefix(args.Path, "/")
+	blocks, err := notebooks.ReadSourceBlocks(ctx, r.gitserverClient, repo.Name, commit, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	notebook := &notebooks.Notebook{
+		Title:         notebooks.NotebookTitleFromPath(filePath),
+		Public:        args.Public,
+		CreatorUserID: user.ID,
+		UpdaterUserID: user.ID,
+		Blocks:        blocks,
+		SourceRepoID:  repo.ID,
+		SourcePath:    filePath,
+		SourceCommit:  commit,
+	}
+	err = graphqlbackend.UnmarshalNamespaceID(args.Namespace, &notebook.NamespaceUserID, &notebook.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+	err = validateNotebookWritePermissionsForUser(ctx, r.db, notebook, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	createdNotebook, err := notebooks.Notebooks(r.db).CreateNotebook(ctx, notebook)
+	if err != nil {
+		return nil, err
+	}
+	return &notebookResolver{createdNotebook, r.db, r.gitserverClient, r.executor}, nil
 }
 
 func (r *Resolver) UpdateNotebook(ctx context.Context, args graphqlbackend.UpdateNotebookInputArgs) (graphqlbackend.NotebookResolver, error) {
@@ -184,6 +262,11 @@ func (r *Resolver) UpdateNotebook(ctx context.Context, args graphqlbackend.Updat
 		}
 		blocks = append(blocks, *block)
 	}
+	if notebook.SourceRepoID != 0 {
+		if err := validateSourceNotebookUpdate(ctx, r.db, notebook, blocks, notebookInput.Public); err != nil {
+			return nil, err
+		}
+	}
 
 	notebook.Title = notebookInput.Title
 	notebook.Public = notebookInput.Public
@@ -206,7 +289,36 @@ func (r *Resolver) UpdateNotebook(ctx context.Context, args graphqlbackend.Updat
 	if err != nil {
 		return nil, err
 	}
-	return &notebookResolver{updatedNotebook, r.db}, nil
+	return &notebookResolver{updatedNotebook, r.db, r.gitserverClient, r.executor}, nil
+}
+
+// validateSourceNotebookUpdate returns an error if an update of a notebook
+// imported from a repository changes its blocks, which would be overwritten by
+// the next sync with the source file, or makes the notebook public although the
+// source repository is private.
+func validateSourceNotebookUpdate(ctx context.Context, db database.DB, notebook *notebooks.Notebook, blocks notebooks.NotebookBlocks, public bool) error {
+	oldBlocks, err := json.Marshal(notebook.Blocks)
+	if err != nil {
+		return err
+	}
+	newBlocks, err := json.Marshal(blocks)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(oldBlocks, newBlocks) {
+		return errors.Errorf("notebook is synced with %s in a repository and its blocks cannot be edited, edit the file in the repository instead", notebook.SourcePath)
+	}
+
+	if !public {
+		return nil
+	}
+	// The user updating the notebook may not have access to the source
+	// repository, we only check whether it is private.
+	repo, err := db.Repos().Get(actor.WithInternalActor(ctx), notebook.SourceRepoID)
+	if err != nil {
+		return err
+	}
+	return notebooks.ValidateSourceVisibility(repo, public)
 }
 
 func (r *Resolver) DeleteNotebook(ctx context.Context, args graphqlbackend.DeleteNotebookArgs) (*graphqlbackend.EmptyResponse, error) {
@@ -334,7 +446,7 @@ func (r *Resolver) Notebooks(ctx context.Context, args graphqlbackend.ListNotebo
 func (r *Resolver) notebooksToResolvers(notebooks []*notebooks.Notebook) []graphqlbackend.NotebookResolver {
 	notebookResolvers := make([]graphqlbackend.NotebookResolver, len(notebooks))
 	for idx, notebook := range notebooks {
-		notebookResolvers[idx] = &notebookResolver{notebook, r.db}
+		notebookResolvers[idx] = &notebookResolver{notebook, r.db, r.gitserverClient, r.executor}
 	}
 	return notebookResolvers
 }
@@ -363,8 +475,10 @@ func (n *notebookConnectionResolver) PageInfo(ctx context.Context) *graphqlutil.
 }
 
 type notebookResolver struct {
-	notebook *notebooks.Notebook
-	db       database.DB
+	notebook        *notebooks.Notebook
+	db              database.DB
+	gitserverClient gitserver.Client
+	executor        BlockExecutor
 }
 
 func (r *notebookResolver) ID() graphql.ID {
@@ -378,7 +492,7 @@ func (r *notebookResolver) Title(ctx context.Context) string {
 func (r *notebookResolver) Blocks(ctx context.Context) []graphqlbackend.NotebookBlockResolver {
 	blockResolvers := make([]graphqlbackend.NotebookBlockResolver, 0, len(r.notebook.Blocks))
 	for _, block := range r.notebook.Blocks {
-		blockResolvers = append(blockResolvers, &notebookBlockResolver{block})
+		blockResolvers = append(blockResolvers, &notebookBlockResolver{block, r.executor})
 	}
 	return blockResolvers
 }
@@ -481,8 +595,85 @@ func (r *notebookResolver) ViewerHasStarred(ctx context.Context) (bool, error) {
 	return star != nil, nil
 }
 
+func (r *notebookResolver) Source(ctx context.Context) (graphqlbackend.NotebookSourceResolver, error) {
+	if r.notebook.SourceRepoID == 0 {
+		return nil, nil
+	}
+	// 🚨 SECURITY: Repos().Get only returns repositories the viewer has access to.
+	repo, err := r.db.Repos().Get(ctx, r.notebook.SourceRepoID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &notebookSourceResolver{
+		repository: graphqlbackend.NewRepositoryResolver(r.db, r.gitserverClient, repo),
+		path:       r.notebook.SourcePath,
+		commit:     string(r.notebook.SourceCommit),
+	}, nil
+}
+
+func (r *notebookResolver) Export(ctx context.Context, args graphqlbackend.NotebookExportArgs) (string, error) {
+	switch args.Format {
+	case graphqlbackend.NotebookExportFormatMarkdown:
+		return notebooks.BlocksToMarkdown(r.notebook.Blocks), nil
+	case graphqlbackend.NotebookExportFormatJSON:
+		outputs, err := r.executeBlocks(ctx)
+		if err != nil {
+			return "", err
+		}
+		snapshot, err := notebooks.MarshalSnapshot(r.notebook, outputs)
+		if err != nil {
+			return "", err
+		}
+		return string(snapshot), nil
+	}
+	return "", errors.Errorf("invalid notebook export format: %s", args.Format)
+}
+
+// executeBlocks returns the output of the compute and insight blocks of the
+// notebook, keyed by block ID. No outputs are returned if block execution is not
+// available.
+func (r *notebookResolver) executeBlocks(ctx context.Context) (map[string]*notebooks.NotebookBlockTable, error) {
+	if r.executor == nil {
+		return nil, nil
+	}
+	outputs := make(map[string]*notebooks.NotebookBlockTable)
+	for _, block := range r.notebook.Blocks {
+		if block.Type != notebooks.NotebookComputeBlockType && block.Type != notebooks.NotebookInsightBlockType {
+			continue
+		}
+		table, err := r.executor.ExecuteBlock(ctx, block)
+		if err != nil {
+			return nil, errors.Wrapf(err, "executing block %s", block.ID)
+		}
+		outputs[block.ID] = table
+	}
+	return outputs, nil
+}
+
+type notebookSourceResolver struct {
+	repository *graphqlbackend.RepositoryResolver
+	path       string
+	commit     string
+}
+
+func (r *notebookSourceResolver) Repository() *graphqlbackend.RepositoryResolver {
+	return r.repository
+}
+
+func (r *notebookSourceResolver) Path() string {
+	return r.path
+}
+
+func (r *notebookSourceResolver) Commit() string {
+	return r.commit
+}
+
 type notebookBlockResolver struct {
-	block notebooks.NotebookBlock
+	block    notebooks.NotebookBlock
+	executor BlockExecutor
 }
 
 func (r *notebookBlockResolver) ToMarkdownBlock() (graphqlbackend.MarkdownBlockResolver, bool) {
@@ -513,6 +704,20 @@ func (r *notebookBlockResolver) ToSymbolBlock() (graphqlbackend.SymbolBlockResol
 	return nil, false
 }
 
+func (r *notebookBlockResolver) ToComputeBlock() (graphqlbackend.ComputeBlockResolver, bool) {
+	if r.block.Type == notebooks.NotebookComputeBlockType {
+		return &computeBlockResolver{r.block, r.executor}, true
+	}
+	return nil, false
+}
+
+func (r *notebookBlockResolver) ToInsightBlock() (graphqlbackend.InsightBlockResolver, bool) {
+	if r.block.Type == notebooks.NotebookInsightBlockType {
+		return &insightBlockResolver{r.block, r.executor}, true
+	}
+	return nil, false
+}
+
 type markdownBlockResolver struct {
 	// block.type == NotebookMarkdownBlockType
 	block notebooks.NotebookBlock
@@ -631,3 +836,81 @@ func (r *symbolBlockInputResolver) SymbolContainerName() string {
 func (r *symbolBlockInputResolver) SymbolKind() string {
 	return r.input.SymbolKind
 }
+
+type computeBlockResolver struct {
+	// block.type == NotebookComputeBlockType
+	block    notebooks.NotebookBlock
+	executor BlockExecutor
+}
+
+func (r *computeBlockResolver) ID() string {
+	return r.block.ID
+}
+
+func (r *computeBlockResolver) ComputeInput() string {
+	return r.block.ComputeInput.Text
+}
+
+func (r *computeBlockResolver) Output(ctx context.Context) (graphqlbackend.NotebookBlockTableResolver, error) {
+	return executeBlock(ctx, r.executor, r.block)
+}
+
+type insightBlockResolver struct {
+	// block.type == NotebookInsightBlockType
+	block    notebooks.NotebookBlock
+	executor BlockExecutor
+}
+
+func (r *insightBlockResolver) ID() string {
+	return r.block.ID
+}
+
+func (r *insightBlockResolver) InsightInput() graphqlbackend.InsightBlockInputResolver {
+	return &insightBlockInputResolver{*r.block.InsightInput}
+}
+
+func (r *insightBlockResolver) Output(ctx context.Context) (graphqlbackend.NotebookBlockTableResolver, error) {
+	return executeBlock(ctx, r.executor, r.block)
+}
+
+type insightBlockInputResolver struct {
+	input notebooks.NotebookInsightBlockInput
+}
+
+func (r *insightBlockInputResolver) InsightViewID() graphql.ID {
+	return graphql.ID(r.input.InsightViewID)
+}
+
+func (r *insightBlockInputResolver) SeriesIDs() *[]string {
+	if len(r.input.SeriesIDs) == 0 {
+		return nil
+	}
+	return &r.input.SeriesIDs
+}
+
+func executeBlock(ctx context.Context, executor BlockExecutor, block notebooks.NotebookBlock) (graphqlbackend.NotebookBlockTableResolver, error) {
+	if executor == nil {
+		return nil, errors.New("notebook block execution is not available")
+	}
+	table, err := executor.ExecuteBlock(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+	return &notebookBlockTableResolver{table}, nil
+}
+
+type notebookBlockTableResolver struct {
+	table *notebooks.NotebookBlockTable
+}
+
+func (r *notebookBlockTableResolver) Columns() []string {
+	return r.table.Columns
+}
+
+func (r *notebookBlockTableResolver) Rows() [][]string {
+	return r.table.Rows
+}
+
+func (r *notebookBlockTableResolver) LimitHit() bool {
+	return r.table.LimitHit
+}
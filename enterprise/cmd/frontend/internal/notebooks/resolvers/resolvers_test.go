@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -199,7 +200,7 @@ func TestSingleNotebookCRUD(t *testing.T) {
 		t.Fatalf("Expected no error, got %s", err)
 	}
 
-	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db))
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db, nil, nil))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -592,7 +593,7 @@ func TestListNotebooks(t *testing.T) {
 		return ids
 	}
 
-	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db))
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db, nil, nil))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -729,7 +730,7 @@ func TestGetNotebookWithSoftDeletedUserColumns(t *testing.T) {
 		t.Fatalf("Expected no error, got %s", err)
 	}
 
-	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db))
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db, nil, nil))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -738,3 +739,110 @@ func TestGetNotebookWithSoftDeletedUserColumns(t *testing.T) {
 	var response struct{ Node notebooksapitest.Notebook }
 	apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(user1.ID)), t, schema, input, &response, queryNotebook)
 }
+
+const importNotebookFromRepositoryMutation = `
+mutation ImportNotebookFromRepository($repository: ID!, $path: String!, $namespace: ID!) {
+	importNotebookFromRepository(repository: $repository, path: $path, namespace: $namespace, public: false) {
+		id
+		title
+		source {
+			repository {
+				name
+			}
+			path
+			commit
+		}
+		export(format: MARKDOWN)
+	}
+}
+`
+
+func TestImportNotebookFromRepository(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	internalCtx := actor.WithInternalActor(context.Background())
+
+	user, err := db.Users().Create(internalCtx, database.NewUser{Username: "u", Password: "p"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+	repo := &types.Repo{Name: "github.com/sourcegraph/notebooks"}
+	if err := db.Repos().Create(internalCtx, repo); err != nil {
+		t.Fatalf("Expected no error, got %s", err)
+	}
+
+	const markdown = "# Onboarding\n\n```sourcegraph-query\nrepo:sourcegraph lang:go\n```\n"
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.GetDefaultBranchFunc.SetDefaultReturn("main", "c1", nil)
+	gitserverClient.ReadFileFunc.SetDefaultReturn([]byte(markdown), nil)
+
+	schema, err := graphqlbackend.NewSchemaWithNotebooksResolver(db, NewResolver(db, gitserverClient, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+	input := map[string]any{
+		"repository": graphqlbackend.MarshalRepositoryID(repo.ID),
+		"path":       "/docs/Onboarding.snb.md",
+		"namespace":  graphqlbackend.MarshalUserID(user.ID),
+	}
+	var response struct {
+		ImportNotebookFromRepository struct {
+			ID     string
+			Title  string
+			Source struct {
+				Repository struct{ Name string }
+				Path       string
+				Commit     string
+			}
+			Export string
+		}
+	}
+	apitest.MustExec(userCtx, t, schema, input, &response, importNotebookFromRepositoryMutation)
+
+	got := response.ImportNotebookFromRepository
+	if got.Title != "Onboarding" {
+		t.Fatalf("expected title Onboarding, got %q", got.Title)
+	}
+	if got.Source.Repository.Name != string(repo.Name) || got.Source.Path != "docs/Onboarding.snb.md" || got.Source.Commit != "c1" {
+		t.Fatalf("unexpected notebook source: %+v", got.Source)
+	}
+	// Exported Markdown marks the blocks parsed from the file.
+	wantExport := "<!-- sourcegraph-block type=md id=1 -->\n# Onboarding\n\n<!-- sourcegraph-block type=query id=2 -->\n```sourcegraph-query\nrepo:sourcegraph lang:go\n```\n"
+	if got.Export != wantExport {
+		t.Fatalf("unexpected exported markdown:\n%s", got.Export)
+	}
+
+	id, err := unmarshalNotebookID(graphql.ID(got.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	notebook, err := notebooks.Notebooks(db).GetNotebook(internalCtx, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Blocks of imported notebooks are synced with the source file and cannot
+	// be edited, other fields can.
+	notebook.Title = "Renamed"
+	var updateResponse struct{ UpdateNotebook notebooksapitest.Notebook }
+	updateInput := map[string]any{"id": got.ID, "notebook": notebooksapitest.NotebookToAPIInput(notebook)}
+	apitest.MustExec(userCtx, t, schema, updateInput, &updateResponse, updateNotebookMutation)
+	if updateResponse.UpdateNotebook.Title != "Renamed" {
+		t.Fatalf("expected title Renamed, got %q", updateResponse.UpdateNotebook.Title)
+	}
+
+	notebook.Blocks = notebook.Blocks[:1]
+	updateInput["notebook"] = notebooksapitest.NotebookToAPIInput(notebook)
+	gotErrors := apitest.Exec(userCtx, t, schema, updateInput, &updateResponse, updateNotebookMutation)
+	if len(gotErrors) != 1 || !strings.Contains(gotErrors[0].Message, "blocks cannot be edited") {
+		t.Fatalf("expected an error editing blocks of an imported notebook, got %v", gotErrors)
+	}
+
+	input["path"] = "README.md"
+	gotErrors = apitest.Exec(userCtx, t, schema, input, &response, importNotebookFromRepositoryMutation)
+	if len(gotErrors) != 1 || !strings.Contains(gotErrors[0].Message, "must have the .snb.md extension") {
+		t.Fatalf("expected an error for a non-notebook file, got %v", gotErrors)
+	}
+}
@@ -0,0 +1,67 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+)
+
+func computeBlameOwnershipSignals(ctx context.Context, db edb.EnterpriseDB, path string, repoID api.RepoID) ([]reasonAndReference, error) {
+	enabled, err := db.OwnSignalConfigurations().IsEnabled(ctx, types.SignalBlameOwnership)
+	if err != nil {
+		return nil, errors.Wrap(err, "IsEnabled")
+	}
+	if !enabled {
+		return nil, nil
+	}
+
+	blameOwners, err := db.BlameOwnershipSignals().FindBlameOwners(ctx, repoID, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "FindBlameOwners")
+	}
+
+	var rrs []reasonAndReference
+	for _, b := range blameOwners {
+		rrs = append(rrs, reasonAndReference{
+			reason: ownershipReason{
+				blameOwnershipShare: b.Share,
+				blameLinesCount:     b.LinesCount,
+			},
+			reference: own.Reference{
+				// Just use the email.
+				Email: b.AuthorEmail,
+			},
+		})
+	}
+	return rrs, nil
+}
+
+type blameOwnershipSignal struct {
+	share      float64
+	linesCount int32
+	// makesAnOwner is whether the signal makes an owner, which is only the
+	// case for paths without CODEOWNERS rules or assigned owners.
+	makesAnOwner bool
+}
+
+func (b *blameOwnershipSignal) Title() (string, error) {
+	return "blame owner", nil
+}
+
+func (b *blameOwnershipSignal) Description() (string, error) {
+	return fmt.Sprintf("Associated because they last changed %.0f%% of the lines, as attributed by git blame.", b.share*100), nil
+}
+
+func (b *blameOwnershipSignal) Share() float64 {
+	return b.share
+}
+
+func (b *blameOwnershipSignal) LinesCount() int32 {
+	return b.linesCount
+}
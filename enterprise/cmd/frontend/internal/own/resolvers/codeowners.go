@@ -48,7 +48,7 @@ func (r *ownResolver) computeCodeowners(ctx context.Context, blob *graphqlbacken
 		rrs = append(rrs, reasonAndReference{
 			reason: ownershipReason{
 				codeownersRule:   rule,
-				codeownersSource: ruleset.GetSource(),
+				codeownersSource: ruleset.GetRuleSource(rule),
 			},
 			reference: own.Reference{
 				RepoContext: repoContext,
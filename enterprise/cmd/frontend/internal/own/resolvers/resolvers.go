@@ -57,6 +57,8 @@ var (
 	_ graphqlbackend.SimpleOwnReasonResolver                  = &recentContributorOwnershipSignal{}
 	_ graphqlbackend.RecentViewOwnershipSignalResolver        = &recentViewOwnershipSignal{}
 	_ graphqlbackend.SimpleOwnReasonResolver                  = &recentViewOwnershipSignal{}
+	_ graphqlbackend.BlameOwnershipSignalResolver             = &blameOwnershipSignal{}
+	_ graphqlbackend.SimpleOwnReasonResolver                  = &blameOwnershipSignal{}
 	_ graphqlbackend.AssignedOwnerResolver                    = &assignedOwner{}
 	_ graphqlbackend.SimpleOwnReasonResolver                  = &assignedOwner{}
 	_ graphqlbackend.SimpleOwnReasonResolver                  = &codeownersFileEntryResolver{}
@@ -78,9 +80,24 @@ type ownershipReason struct {
 	codeownersSource         codeowners.RulesetSource
 	recentContributionsCount int
 	recentViewsCount         int
+	blameOwnershipShare      float64
+	blameLinesCount          int
 	assignedOwnerPath        []string
 }
 
+// isExplicitOwnership returns whether the reason is a CODEOWNERS rule or an
+// assigned owner.
+func (r ownershipReason) isExplicitOwnership() bool {
+	return len(r.assignedOwnerPath) > 0 || r.codeownersRule != nil
+}
+
+// makesAnOwner returns whether the reason makes an owner. As in search, blame
+// ownership only makes owners of paths that have no CODEOWNERS rule and no
+// assigned owner, so explicitOwners tells whether the path has any.
+func (r ownershipReason) makesAnOwner(explicitOwners bool) bool {
+	return r.isExplicitOwnership() || (!explicitOwners && r.blameOwnershipShare > 0)
+}
+
 type ownershipReasonResolver struct {
 	resolver graphqlbackend.SimpleOwnReasonResolver
 }
@@ -108,6 +125,11 @@ func (o *ownershipReasonResolver) ToRecentViewOwnershipSignal() (res graphqlback
 	return
 }
 
+func (o *ownershipReasonResolver) ToBlameOwnershipSignal() (res graphqlbackend.BlameOwnershipSignalResolver, ok bool) {
+	res, ok = o.resolver.(*blameOwnershipSignal)
+	return
+}
+
 func (o *ownershipReasonResolver) ToAssignedOwner() (res graphqlbackend.AssignedOwnerResolver, ok bool) {
 	res, ok = o.resolver.(*assignedOwner)
 	return
@@ -116,7 +138,8 @@ func (o *ownershipReasonResolver) ToAssignedOwner() (res graphqlbackend.Assigned
 func (o *ownershipReasonResolver) makesAnOwner() bool {
 	_, makesAnOwner := o.resolver.(*codeownersFileEntryResolver)
 	_, makesAnAssignedOwner := o.resolver.(*assignedOwner)
-	return makesAnOwner || makesAnAssignedOwner
+	blame, isBlame := o.resolver.(*blameOwnershipSignal)
+	return makesAnOwner || makesAnAssignedOwner || (isBlame && blame.makesAnOwner)
 }
 
 func (r *ownResolver) GitBlobOwnership(
@@ -155,6 +178,15 @@ func (r *ownResolver) GitBlobOwnership(
 		rrs = append(rrs, viewerResolvers...)
 	}
 
+	// Retrieve blame ownership signals.
+	if args.IncludeReason(graphqlbackend.BlameOwnershipSignal) {
+		blameResolvers, err := computeBlameOwnershipSignals(ctx, r.db, blob.Path(), repoID)
+		if err != nil {
+			return nil, err
+		}
+		rrs = append(rrs, blameResolvers...)
+	}
+
 	if args.IncludeReason(graphqlbackend.AssignedOwner) {
 		// Retrieve assigned owners.
 		assignedOwners, err := r.computeAssignedOwners(ctx, blob, repoID)
@@ -205,6 +237,13 @@ func (r *ownResolver) GitCommitOwnership(
 	}
 	rrs = append(rrs, viewerResolvers...)
 
+	// Retrieve blame ownership signals.
+	blameResolvers, err := computeBlameOwnershipSignals(ctx, r.db, repoRootPath, repoID)
+	if err != nil {
+		return nil, err
+	}
+	rrs = append(rrs, blameResolvers...)
+
 	return r.ownershipConnection(ctx, args, rrs, commit.Repository(), "")
 }
 
@@ -227,6 +266,13 @@ func (r *ownResolver) GitTreeOwnership(
 	}
 	rrs = append(rrs, viewerResolvers...)
 
+	// Retrieve blame ownership signals.
+	blameResolvers, err := computeBlameOwnershipSignals(ctx, r.db, tree.Path(), repoID)
+	if err != nil {
+		return nil, err
+	}
+	rrs = append(rrs, blameResolvers...)
+
 	// Retrieve assigned owners.
 	assignedOwners, err := r.computeAssignedOwners(ctx, tree, repoID)
 	if err != nil {
@@ -309,30 +355,37 @@ func (ro reasonsAndOwner) String() string {
 		if r.recentViewsCount > 0 {
 			fmt.Fprint(&b, " recent-viewer")
 		}
+		if r.blameOwnershipShare > 0 {
+			fmt.Fprint(&b, " blame-owner")
+		}
 	}
 	return b.String()
 }
 
 func (ro reasonsAndOwner) order() int {
-	var ownershipReasons, reasons, contributions, views int
+	var ownershipReasons, blameOwnership, reasons, contributions, views int
 	for _, r := range ro.reasons {
 		if len(r.assignedOwnerPath) > 0 || r.codeownersRule != nil {
 			ownershipReasons++
 		}
+		if r.blameOwnershipShare > 0 {
+			blameOwnership++
+		}
 		reasons++
 		contributions += r.recentContributionsCount
 		views += r.recentViewsCount
 	}
 	// Smaller numbers are ordered in front, so take negative score.
 	return -(100000*ownershipReasons +
+		10000*blameOwnership +
 		1000*reasons +
 		10*contributions +
 		views)
 }
 
-func (ro reasonsAndOwner) isOwner() bool {
+func (ro reasonsAndOwner) isOwner(explicitOwners bool) bool {
 	for _, r := range ro.reasons {
-		if len(r.assignedOwnerPath) > 0 || r.codeownersRule != nil {
+		if r.makesAnOwner(explicitOwners) {
 			return true
 		}
 	}
@@ -355,7 +408,9 @@ func (r *ownResolver) ownershipConnection(
 	bag.Resolve(ctx, r.db)
 	// 2. Group reasons by resolved owners
 	ownersByKey := map[string]*reasonsAndOwner{}
+	var explicitOwners bool
 	for _, r := range ownerships {
+		explicitOwners = explicitOwners || r.reason.isExplicitOwnership()
 		resolvedOwner, found := bag.FindResolved(r.reference)
 		if !found {
 			if guess := r.reference.ResolutionGuess(); guess != nil {
@@ -392,7 +447,7 @@ func (r *ownResolver) ownershipConnection(
 	total := len(owners)
 	var totalOwners int
 	for _, o := range owners {
-		if o.isOwner() {
+		if o.isOwner(explicitOwners) {
 			totalOwners++
 		}
 	}
@@ -414,14 +469,15 @@ func (r *ownResolver) ownershipConnection(
 
 	// 6. Assemble the connection resolver object:
 	return &ownershipConnectionResolver{
-		db:          r.db,
-		total:       total,
-		totalOwners: totalOwners,
-		next:        next,
-		owners:      owners,
-		gitserver:   r.gitserver,
-		repo:        repo,
-		path:        path,
+		db:             r.db,
+		total:          total,
+		totalOwners:    totalOwners,
+		next:           next,
+		owners:         owners,
+		explicitOwners: explicitOwners,
+		gitserver:      r.gitserver,
+		repo:           repo,
+		path:           path,
 	}, nil
 }
 
@@ -482,9 +538,12 @@ type ownershipConnectionResolver struct {
 	totalOwners int
 	next        *string
 	owners      []reasonsAndOwner
-	gitserver   gitserver.Client
-	repo        *graphqlbackend.RepositoryResolver
-	path        string
+	// explicitOwners is whether any owner of the path comes from a
+	// CODEOWNERS rule or an assignment.
+	explicitOwners bool
+	gitserver      gitserver.Client
+	repo           *graphqlbackend.RepositoryResolver
+	path           string
 }
 
 func (r *ownershipConnectionResolver) TotalCount(_ context.Context) (int32, error) {
@@ -503,12 +562,13 @@ func (r *ownershipConnectionResolver) Nodes(_ context.Context) ([]graphqlbackend
 	var rs []graphqlbackend.OwnershipResolver
 	for _, o := range r.owners {
 		rs = append(rs, &ownershipResolver{
-			db:            r.db,
-			gitserver:     r.gitserver,
-			repo:          r.repo,
-			resolvedOwner: o.owner,
-			reasons:       o.reasons,
-			path:          r.path,
+			db:             r.db,
+			gitserver:      r.gitserver,
+			repo:           r.repo,
+			resolvedOwner:  o.owner,
+			reasons:        o.reasons,
+			explicitOwners: r.explicitOwners,
+			path:           r.path,
 		})
 	}
 	return rs, nil
@@ -521,6 +581,9 @@ type ownershipResolver struct {
 	path          string
 	repo          *graphqlbackend.RepositoryResolver
 	reasons       []ownershipReason
+	// explicitOwners is whether any owner of the path comes from a
+	// CODEOWNERS rule or an assignment.
+	explicitOwners bool
 }
 
 func (r *ownershipResolver) Owner(ctx context.Context) (graphqlbackend.OwnerResolver, error) {
@@ -566,6 +629,15 @@ func (r *ownershipResolver) Reasons(_ context.Context) ([]graphqlbackend.Ownersh
 				},
 			})
 		}
+		if reason.blameOwnershipShare > 0 {
+			rs = append(rs, &ownershipReasonResolver{
+				resolver: &blameOwnershipSignal{
+					share:        reason.blameOwnershipShare,
+					linesCount:   int32(reason.blameLinesCount),
+					makesAnOwner: reason.makesAnOwner(r.explicitOwners),
+				},
+			})
+		}
 	}
 	return rs, nil
 }
@@ -85,6 +85,10 @@ func (s fakeOwnService) AssignedTeams(context.Context, api.RepoID, api.CommitID)
 	return s.Teams, nil
 }
 
+func (s fakeOwnService) BlameOwnership(context.Context, api.RepoID, api.CommitID) (own.BlameOwners, error) {
+	return nil, nil
+}
+
 // fakeGitServer is a limited gitserver.Client that returns a file for every Stat call.
 type fakeGitserver struct {
 	gitserver.Client
@@ -100,6 +104,7 @@ type repoPath struct {
 func fakeOwnDb() *database.MockDB {
 	db := database.NewMockDB()
 	db.RecentContributionSignalsFunc.SetDefaultReturn(database.NewMockRecentContributionSignalStore())
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(database.NewMockBlameOwnershipSignalStore())
 	db.RecentViewSignalFunc.SetDefaultReturn(database.NewMockRecentViewSignalStore())
 	db.AssignedOwnersFunc.SetDefaultReturn(database.NewMockAssignedOwnersStore())
 
@@ -380,6 +385,7 @@ func TestBlobOwnershipPanelQueryTeamResolved(t *testing.T) {
 	db.UsersFunc.SetDefaultReturn(fakeDB.UserStore)
 	db.CodeownersFunc.SetDefaultReturn(enterprisedb.NewMockCodeownersStore())
 	db.RecentContributionSignalsFunc.SetDefaultReturn(database.NewMockRecentContributionSignalStore())
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(database.NewMockBlameOwnershipSignalStore())
 	db.RecentViewSignalFunc.SetDefaultReturn(database.NewMockRecentViewSignalStore())
 	db.AssignedOwnersFunc.SetDefaultReturn(database.NewMockAssignedOwnersStore())
 	db.AssignedTeamsFunc.SetDefaultReturn(database.NewMockAssignedTeamsStore())
@@ -467,6 +473,7 @@ func TestBlobOwnershipPanelQueryExternalTeamResolved(t *testing.T) {
 	db.TeamsFunc.SetDefaultReturn(fakeDB.TeamStore)
 	db.CodeownersFunc.SetDefaultReturn(enterprisedb.NewMockCodeownersStore())
 	db.RecentContributionSignalsFunc.SetDefaultReturn(database.NewMockRecentContributionSignalStore())
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(database.NewMockBlameOwnershipSignalStore())
 	db.RecentViewSignalFunc.SetDefaultReturn(database.NewMockRecentViewSignalStore())
 	db.AssignedOwnersFunc.SetDefaultReturn(database.NewMockAssignedOwnersStore())
 	db.AssignedTeamsFunc.SetDefaultReturn(database.NewMockAssignedTeamsStore())
@@ -780,6 +787,7 @@ func TestOwnership_WithSignals(t *testing.T) {
 		ContributionCount: 5,
 	}}, nil)
 	db.RecentContributionSignalsFunc.SetDefaultReturn(recentContribStore)
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(database.NewMockBlameOwnershipSignalStore())
 
 	recentViewStore := database.NewMockRecentViewSignalStore()
 	recentViewStore.ListFunc.SetDefaultReturn([]database.RecentViewSummary{{
@@ -935,6 +943,199 @@ func TestOwnership_WithSignals(t *testing.T) {
 	})
 }
 
+func TestOwnership_WithBlameOwnershipSignal(t *testing.T) {
+	logger := logtest.Scoped(t)
+	fakeDB := fakedb.New()
+	db := fakeOwnDb()
+
+	blameStore := database.NewMockBlameOwnershipSignalStore()
+	blameStore.FindBlameOwnersFunc.SetDefaultHook(func(_ context.Context, _ api.RepoID, path string) ([]database.BlameOwnership, error) {
+		if path != "foo/bar.js" {
+			return nil, nil
+		}
+		return []database.BlameOwnership{{
+			FilePath:    path,
+			AuthorName:  santaName,
+			AuthorEmail: santaEmail,
+			LinesCount:  42,
+			Share:       0.75,
+		}}, nil
+	})
+	db.RecentContributionSignalsFunc.SetDefaultReturn(database.NewMockRecentContributionSignalStore())
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(blameStore)
+	db.RecentViewSignalFunc.SetDefaultReturn(database.NewMockRecentViewSignalStore())
+
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+
+	fakeDB.Wire(db)
+	repoID := api.RepoID(1)
+	ctx := userCtx(fakeDB.AddUser(types.User{SiteAdmin: true}))
+	repos := database.NewMockRepoStore()
+	db.ReposFunc.SetDefaultReturn(repos)
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: repoID, Name: "github.com/sourcegraph/own"}, nil)
+	backend.Mocks.Repos.ResolveRev = func(_ context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return "deadbeef", nil
+	}
+	git := fakeGitserver{}
+	schema, err := graphqlbackend.NewSchema(db, git, nil, []graphqlbackend.OptionalResolver{{OwnResolver: resolvers.NewWithService(db, git, fakeOwnService{}, logger)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graphqlbackend.RunTest(t, &graphqlbackend.Test{
+		Schema:  schema,
+		Context: ctx,
+		Query: `
+			query FetchOwnership($repo: ID!, $revision: String!, $currentPath: String!) {
+				node(id: $repo) {
+					... on Repository {
+						commit(rev: $revision) {
+							blob(path: $currentPath) {
+								ownership {
+									totalOwners
+									totalCount
+									nodes {
+										owner {
+											...on Person {
+												email
+											}
+										}
+										reasons {
+											...on BlameOwnershipSignal {
+												title
+												description
+												share
+												linesCount
+											}
+										}
+									}
+								}
+							}
+						}
+					}
+				}
+			}`,
+		ExpectedResult: `{
+			"node": {
+				"commit": {
+					"blob": {
+						"ownership": {
+							"totalOwners": 1,
+							"totalCount": 1,
+							"nodes": [
+								{
+									"owner": {
+										"email": "santa@northpole.com"
+									},
+									"reasons": [
+										{
+											"title": "blame owner",
+											"description": "Associated because they last changed 75% of the lines, as attributed by git blame.",
+											"share": 0.75,
+											"linesCount": 42
+										}
+									]
+								}
+							]
+						}
+					}
+				}
+			}
+		}`,
+		Variables: map[string]any{
+			"repo":        string(graphqlbackend.MarshalRepositoryID(repoID)),
+			"revision":    "revision",
+			"currentPath": "foo/bar.js",
+		},
+	})
+}
+
+// TestOwnership_BlameOwnershipSignalWithCodeowners checks that blame
+// ownership does not make owners of files that have CODEOWNERS rules.
+func TestOwnership_BlameOwnershipSignalWithCodeowners(t *testing.T) {
+	logger := logtest.Scoped(t)
+	fakeDB := fakedb.New()
+	db := fakeOwnDb()
+
+	blameStore := database.NewMockBlameOwnershipSignalStore()
+	blameStore.FindBlameOwnersFunc.SetDefaultReturn([]database.BlameOwnership{{
+		FilePath:    "foo/bar.js",
+		AuthorName:  santaName,
+		AuthorEmail: santaEmail,
+		LinesCount:  42,
+		Share:       0.75,
+	}}, nil)
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(blameStore)
+	db.UserEmailsFunc.SetDefaultReturn(database.NewMockUserEmailsStore())
+	db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
+
+	fakeDB.Wire(db)
+	repoID := api.RepoID(1)
+	own := fakeOwnService{
+		Ruleset: codeowners.NewRuleset(
+			codeowners.GitRulesetSource{Repo: repoID, Commit: "deadbeef", Path: "CODEOWNERS"},
+			&codeownerspb.File{
+				Rule: []*codeownerspb.Rule{
+					{
+						Pattern:    "*.js",
+						Owner:      []*codeownerspb.Owner{{Handle: "js-owner"}},
+						LineNumber: 1,
+					},
+				},
+			}),
+	}
+	ctx := userCtx(fakeDB.AddUser(types.User{SiteAdmin: true}))
+	repos := database.NewMockRepoStore()
+	db.ReposFunc.SetDefaultReturn(repos)
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: repoID, Name: "github.com/sourcegraph/own"}, nil)
+	backend.Mocks.Repos.ResolveRev = func(_ context.Context, repo *types.Repo, rev string) (api.CommitID, error) {
+		return "deadbeef", nil
+	}
+	git := fakeGitserver{}
+	schema, err := graphqlbackend.NewSchema(db, git, nil, []graphqlbackend.OptionalResolver{{OwnResolver: resolvers.NewWithService(db, git, own, logger)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	graphqlbackend.RunTest(t, &graphqlbackend.Test{
+		Schema:  schema,
+		Context: ctx,
+		Query: `
+			query FetchOwnership($repo: ID!, $revision: String!, $currentPath: String!) {
+				node(id: $repo) {
+					... on Repository {
+						commit(rev: $revision) {
+							blob(path: $currentPath) {
+								ownership {
+									totalOwners
+									totalCount
+								}
+							}
+						}
+					}
+				}
+			}`,
+		ExpectedResult: `{
+			"node": {
+				"commit": {
+					"blob": {
+						"ownership": {
+							"totalOwners": 1,
+							"totalCount": 2
+						}
+					}
+				}
+			}
+		}`,
+		Variables: map[string]any{
+			"repo":        string(graphqlbackend.MarshalRepositoryID(repoID)),
+			"revision":    "revision",
+			"currentPath": "foo/bar.js",
+		},
+	})
+}
+
 func TestTreeOwnershipSignals(t *testing.T) {
 	logger := logtest.Scoped(t)
 	fakeDB := fakedb.New()
@@ -947,6 +1148,7 @@ func TestTreeOwnershipSignals(t *testing.T) {
 		ContributionCount: 5,
 	}}, nil)
 	db.RecentContributionSignalsFunc.SetDefaultReturn(recentContribStore)
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(database.NewMockBlameOwnershipSignalStore())
 
 	recentViewStore := database.NewMockRecentViewSignalStore()
 	recentViewStore.ListFunc.SetDefaultReturn([]database.RecentViewSummary{{
@@ -1176,6 +1378,7 @@ func TestCommitOwnershipSignals(t *testing.T) {
 		ContributionCount: 5,
 	}}, nil)
 	db.RecentContributionSignalsFunc.SetDefaultReturn(recentContribStore)
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(database.NewMockBlameOwnershipSignalStore())
 
 	fakeDB.Wire(db)
 	repoID := api.RepoID(1)
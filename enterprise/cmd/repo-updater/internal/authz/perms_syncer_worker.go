@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -72,7 +73,31 @@ func (h *permsSyncerWorker) Handle(ctx context.Context, _ log.Logger, record *da
 		log.Int("priority", int(record.Priority)),
 	)
 
-	return h.handlePermsSync(ctx, reqType, reqID, record.ID, record.NoPerms, record.InvalidateCaches)
+	err := h.handlePermsSync(ctx, reqType, reqID, record.ID, record.NoPerms, record.InvalidateCaches)
+	h.enqueueWebhookEvent(ctx, record, err)
+	return err
+}
+
+// enqueueWebhookEvent enqueues a permission_sync:completed outbound webhook
+// event for the given job, or a permission_sync:failed event if syncErr is
+// non-nil.
+func (h *permsSyncerWorker) enqueueWebhookEvent(ctx context.Context, record *database.PermissionSyncJob, syncErr error) {
+	eventType := events.PermissionSyncCompleted
+	payload := events.PermissionSync{
+		JobID:  record.ID,
+		Reason: string(record.Reason),
+	}
+	if record.RepositoryID != 0 {
+		payload.Repository = events.MarshalRepositoryID(api.RepoID(record.RepositoryID))
+	} else {
+		payload.User = events.MarshalUserID(int32(record.UserID))
+	}
+	if syncErr != nil {
+		eventType = events.PermissionSyncFailed
+		payload.Error = syncErr.Error()
+	}
+
+	database.EnqueueOutboundWebhookEvent(ctx, h.logger, h.jobsStore, eventType, payload)
 }
 
 // handlePermsSync is effectively a sync version of `perms_syncer.syncPerms`
@@ -40,17 +40,17 @@ func CreateSetup(config rockskipConfig) shared.SetupFunc {
 	repoToSize := map[string]int64{}
 
 	if useRockskip {
-		return func(observationCtx *observation.Context, db database.DB, gitserverClient symbolsGitserver.GitserverClient, repositoryFetcher fetcher.RepositoryFetcher) (types.SearchFunc, func(http.ResponseWriter, *http.Request), []goroutine.BackgroundRoutine, string, error) {
+		return func(observationCtx *observation.Context, db database.DB, gitserverClient symbolsGitserver.GitserverClient, repositoryFetcher fetcher.RepositoryFetcher) (types.SearchFunc, types.ParseFilesFunc, func(http.ResponseWriter, *http.Request), []goroutine.BackgroundRoutine, string, error) {
 			rockskipSearchFunc, rockskipHandleStatus, rockskipCtagsCommand, err := setupRockskip(observationCtx, config, gitserverClient, repositoryFetcher)
 			if err != nil {
-				return nil, nil, nil, "", err
+				return nil, nil, nil, nil, "", err
 			}
 
 			// The blanks are the SQLite status endpoint (it's always nil) and the ctags command (same as
-			// Rockskip's).
-			sqliteSearchFunc, _, sqliteBackgroundRoutines, _, err := shared.SetupSqlite(observationCtx, db, gitserverClient, repositoryFetcher)
+			// Rockskip's). Files are parsed with the SQLite parser, since parsing files does not touch the index.
+			sqliteSearchFunc, sqliteParseFilesFunc, _, sqliteBackgroundRoutines, _, err := shared.SetupSqlite(observationCtx, db, gitserverClient, repositoryFetcher)
 			if err != nil {
-				return nil, nil, nil, "", err
+				return nil, nil, nil, nil, "", err
 			}
 
 			searchFunc := func(ctx context.Context, args search.SymbolsParameters) (results result.Symbols, err error) {
@@ -85,7 +85,7 @@ func CreateSetup(config rockskipConfig) shared.SetupFunc {
 				return sqliteSearchFunc(ctx, args)
 			}
 
-			return searchFunc, rockskipHandleStatus, sqliteBackgroundRoutines, rockskipCtagsCommand, nil
+			return searchFunc, sqliteParseFilesFunc, rockskipHandleStatus, sqliteBackgroundRoutines, rockskipCtagsCommand, nil
 		}
 	} else {
 		return shared.SetupSqlite
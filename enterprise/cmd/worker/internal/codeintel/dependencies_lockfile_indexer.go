@@ -0,0 +1,41 @@
+package codeintel
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/shared/init/codeintel"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type lockfileIndexerJob struct{}
+
+func NewLockfileIndexerJob() job.Job {
+	return &lockfileIndexerJob{}
+}
+
+func (j *lockfileIndexerJob) Description() string {
+	return "repository lockfile dependency indexer"
+}
+
+func (j *lockfileIndexerJob) Config() []env.Config {
+	return nil
+}
+
+func (j *lockfileIndexerJob) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	services, err := codeintel.InitServices(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	return dependencies.LockfileIndexerJob(observationCtx, db, services.GitserverClient), nil
+}
@@ -66,17 +66,17 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *bgrepo.
 		return err
 	}
 
-	// lastSuccessfulJobRevision is the revision of the last successful embeddings
-	// job for this repo. If we can find one, we'll attempt an incremental index,
-	// otherwise we fall back to a full index.
-	var lastSuccessfulJobRevision api.CommitID
+	// indexedRevision is the revision of the previous embeddings index of this
+	// repo. If we can find one, we'll attempt an incremental index by diffing it
+	// against the new revision, otherwise we fall back to a full index.
+	var indexedRevision api.CommitID
 	var previousIndex *embeddings.RepoEmbeddingIndex
 	if embeddingsConfig.Incremental {
-		lastSuccessfulJobRevision, previousIndex = h.getPreviousEmbeddingIndex(ctx, logger, repo)
+		indexedRevision, previousIndex = h.getPreviousEmbeddingIndex(ctx, logger, repo)
 
 		if previousIndex != nil && !previousIndex.IsModelCompatible(embeddingsClient.GetModelIdentifier()) {
 			logger.Info("Embeddings model has changed in config. Performing a full index")
-			lastSuccessfulJobRevision, previousIndex = "", nil
+			indexedRevision, previousIndex = "", nil
 		}
 	}
 
@@ -93,7 +93,7 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *bgrepo.
 		SplitOptions:      splitOptions,
 		MaxCodeEmbeddings: embeddingsConfig.MaxCodeEmbeddingsPerRepo,
 		MaxTextEmbeddings: embeddingsConfig.MaxTextEmbeddingsPerRepo,
-		IndexedRevision:   lastSuccessfulJobRevision,
+		IndexedRevision:   indexedRevision,
 	}
 
 	ranks, err := getDocumentRanks(ctx, string(repo.Name))
@@ -134,6 +134,7 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *bgrepo.
 	if stats.IsIncremental {
 		return embeddings.UpdateRepoEmbeddingIndex(ctx, h.uploadStore, indexName, previousIndex, repoEmbeddingIndex, toRemove, ranks)
 	} else {
+		repoEmbeddingIndex.BuildANNIndexes()
 		return embeddings.UploadRepoEmbeddingIndex(ctx, h.uploadStore, indexName, repoEmbeddingIndex)
 	}
 }
@@ -148,9 +149,9 @@ func getExcludedFilePathPatterns(embeddingsConfig *conftypes.EmbeddingsConfig) [
 	return excludedGlobPatterns
 }
 
-// getPreviousEmbeddingIndex checks the last successfully indexed revision and returns its embeddings index. If there
-// is no previous revision, or if there's a problem downloading the index, then it returns a nil index. This means we
-// need to do a full (non-incremental) reindex.
+// getPreviousEmbeddingIndex checks the last successfully indexed revision and returns the embeddings index and the
+// revision it was created for. If there is no previous revision, or if there's a problem downloading the index, then
+// it returns a nil index. This means we need to do a full (non-incremental) reindex.
 func (h *handler) getPreviousEmbeddingIndex(ctx context.Context, logger log.Logger, repo *types.Repo) (api.CommitID, *embeddings.RepoEmbeddingIndex) {
 	lastSuccessfulJob, err := h.repoEmbeddingJobsStore.GetLastCompletedRepoEmbeddingJob(ctx, repo.ID)
 	if err != nil {
@@ -165,11 +166,18 @@ func (h *handler) getPreviousEmbeddingIndex(ctx context.Context, logger log.Logg
 		return "", nil
 	}
 
+	// Diff against the revision the index was embedded at, which is the one that
+	// matches its contents. Only very old indexes do not record it.
+	revision := index.Revision
+	if revision == "" {
+		revision = lastSuccessfulJob.Revision
+	}
+
 	logger.Info(
 		"Found previous successful embeddings job. Attempting incremental index",
-		log.String("old revision", string(lastSuccessfulJob.Revision)),
+		log.String("old revision", string(revision)),
 	)
-	return lastSuccessfulJob.Revision, index
+	return revision, index
 }
 
 type revisionFetcher struct {
@@ -0,0 +1,110 @@
+package notebooks
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/job"
+	workerdb "github.com/sourcegraph/sourcegraph/cmd/worker/shared/init/db"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/notebooks"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var _ job.Job = (*repositorySyncer)(nil)
+
+// repositorySyncer is a worker responsible for keeping notebooks imported from
+// repositories in sync with their source files.
+type repositorySyncer struct{}
+
+func NewRepositorySyncerJob() job.Job {
+	return &repositorySyncer{}
+}
+
+func (j *repositorySyncer) Description() string {
+	return "Syncs notebooks imported from repositories with their source files."
+}
+
+func (j *repositorySyncer) Config() []env.Config {
+	return nil
+}
+
+func (j *repositorySyncer) Routines(_ context.Context, observationCtx *observation.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := workerdb.InitDB(observationCtx)
+	if err != nil {
+		return nil, errors.Wrap(err, "init DB")
+	}
+
+	return []goroutine.BackgroundRoutine{
+		goroutine.NewPeriodicGoroutine(
+			context.Background(),
+			&repositorySyncHandler{
+				logger:          observationCtx.Logger.Scoped("notebooks-repository-syncer", "syncs notebooks imported from repositories"),
+				db:              db,
+				gitserverClient: gitserver.NewClient(),
+			},
+			goroutine.WithName("notebooks.repository-syncer"),
+			goroutine.WithDescription("syncs notebooks imported from repositories with their source files"),
+			goroutine.WithInterval(5*time.Minute),
+		),
+	}, nil
+}
+
+// syncBatchSize is the number of notebooks loaded from the database at once.
+const syncBatchSize = 100
+
+var _ goroutine.Handler = (*repositorySyncHandler)(nil)
+
+type repositorySyncHandler struct {
+	logger          log.Logger
+	db              database.DB
+	gitserverClient gitserver.Client
+}
+
+// Handle syncs the blocks of every notebook imported from a repository with
+// its source file on the default branch of the repository. Notebooks whose
+// source is no longer accessible to their creator, was deleted or is not a
+// valid notebook are skipped until it is fixed.
+func (h *repositorySyncHandler) Handle(ctx context.Context) error {
+	// 🚨 SECURITY: The internal actor is only used to list notebooks.
+	// SyncNotebookSource reads the source file of each notebook as its
+	// creator.
+	ctx = actor.WithInternalActor(ctx)
+
+	store := notebooks.Notebooks(h.db)
+	opts := notebooks.ListNotebooksOptions{HasSource: true, OrderBy: notebooks.NotebooksOrderByID}
+
+	var errs error
+	for offset := int64(0); ; offset += syncBatchSize {
+		page, err := store.ListNotebooks(ctx, notebooks.ListNotebooksPageOptions{First: syncBatchSize, After: offset}, opts)
+		if err != nil {
+			return errors.Wrap(err, "listing notebooks")
+		}
+
+		for _, notebook := range page {
+			synced, err := notebooks.SyncNotebookSource(ctx, h.db, h.gitserverClient, notebook)
+			if err != nil {
+				if errors.IsAny(err, notebooks.ErrSourceNotAccessible, notebooks.ErrInvalidSource) {
+					h.logger.Warn("skipping notebook sync", log.Int64("notebookID", notebook.ID), log.Error(err))
+					continue
+				}
+				errs = errors.Append(errs, errors.Wrapf(err, "syncing notebook %d", notebook.ID))
+				continue
+			}
+			if synced.SourceCommit != notebook.SourceCommit {
+				h.logger.Debug("synced notebook", log.Int64("notebookID", notebook.ID), log.String("commit", string(synced.SourceCommit)))
+			}
+		}
+
+		if len(page) < syncBatchSize {
+			return errs
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/executormultiqueue"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/executors"
 	workerinsights "github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/insights"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/notebooks"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/permissions"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/telemetry"
 	eiauthz "github.com/sourcegraph/sourcegraph/enterprise/internal/authz"
@@ -65,6 +66,7 @@ var additionalJobs = map[string]job.Job{
 	"codeintel-crates-syncer":                     codeintel.NewCratesSyncerJob(),
 	"codeintel-sentinel-cve-scanner":              codeintel.NewSentinelCVEScannerJob(),
 	"codeintel-package-filter-applicator":         codeintel.NewPackagesFilterApplicatorJob(),
+	"codeintel-lockfile-indexer":                  codeintel.NewLockfileIndexerJob(),
 
 	"auth-sourcegraph-operator-cleaner": auth.NewSourcegraphOperatorCleaner(),
 
@@ -75,6 +77,8 @@ var additionalJobs = map[string]job.Job{
 	"own-repo-indexing-queue": own.NewOwnRepoIndexingQueue(),
 
 	"github-apps-installation-validation-job": githubapps.NewGitHubApsInstallationJob(),
+
+	"notebooks-repository-syncer": notebooks.NewRepositorySyncerJob(),
 }
 
 // SetAuthzProviders waits for the database to be initialized, then periodically refreshes the
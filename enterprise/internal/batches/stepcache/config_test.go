@@ -0,0 +1,80 @@
+package stepcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestConfigDefaults(t *testing.T) {
+	config := Config{}
+	config.SetMockGetter(mapGetter(nil))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.Backend != "blobstore" {
+		t.Errorf("unexpected value for Backend. want=%s have=%s", "blobstore", config.Backend)
+	}
+	if config.Bucket != "batch-changes-step-cache" {
+		t.Errorf("unexpected value for Bucket. want=%s have=%s", "batch-changes-step-cache", config.Bucket)
+	}
+	if config.TTL != 24*7*time.Hour {
+		t.Errorf("unexpected value for TTL. want=%v have=%v", 24*7*time.Hour, config.TTL)
+	}
+	if config.S3Endpoint != "http://blobstore:9000" {
+		t.Errorf("unexpected value for S3.Endpoint. want=%s have=%s", "http://blobstore:9000", config.S3Endpoint)
+	}
+}
+
+func TestConfigS3(t *testing.T) {
+	env := map[string]string{
+		"BATCHES_STEP_CACHE_UPLOAD_BACKEND":               "S3",
+		"BATCHES_STEP_CACHE_UPLOAD_BUCKET":                "step-cache",
+		"BATCHES_STEP_CACHE_UPLOAD_TTL":                   "8h",
+		"BATCHES_STEP_CACHE_UPLOAD_AWS_ACCESS_KEY_ID":     "access-key-id",
+		"BATCHES_STEP_CACHE_UPLOAD_AWS_SECRET_ACCESS_KEY": "secret-access-key",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.Bucket != "step-cache" {
+		t.Errorf("unexpected value for Bucket. want=%s have=%s", "step-cache", config.Bucket)
+	}
+	if config.TTL != 8*time.Hour {
+		t.Errorf("unexpected value for TTL. want=%v have=%v", 8*time.Hour, config.TTL)
+	}
+	if config.S3AccessKeyID != "access-key-id" {
+		t.Errorf("unexpected value for S3.AccessKeyID. want=%s have=%s", "access-key-id", config.S3AccessKeyID)
+	}
+	if config.S3SecretAccessKey != "secret-access-key" {
+		t.Errorf("unexpected value for S3.SecretAccessKey. want=%s have=%s", "secret-access-key", config.S3SecretAccessKey)
+	}
+}
+
+func TestConfigInvalidBackend(t *testing.T) {
+	config := Config{}
+	config.SetMockGetter(mapGetter(map[string]string{"BATCHES_STEP_CACHE_UPLOAD_BACKEND": "ftp"}))
+	config.Load()
+
+	if err := config.Validate(); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
+
+func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
+	return func(name, defaultValue, description string) string {
+		if v, ok := env[name]; ok {
+			return v
+		}
+
+		return defaultValue
+	}
+}
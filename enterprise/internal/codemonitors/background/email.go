@@ -32,7 +32,7 @@ func SendEmailForNewSearchResult(ctx context.Context, db database.DB, userID int
 	if MockSendEmailForNewSearchResult != nil {
 		return MockSendEmailForNewSearchResult(ctx, db, userID, data)
 	}
-	return sendEmail(ctx, db, userID, newSearchResultsEmailTemplates, data)
+	return sendEmail(ctx, db, userID, "code-monitor", newSearchResultsEmailTemplates, data)
 }
 
 var (
@@ -121,7 +121,7 @@ func NewTestTemplateDataForNewSearchResults(monitorDescription string) *Template
 	}
 }
 
-func sendEmail(ctx context.Context, db database.DB, userID int32, template txtypes.Templates, data any) error {
+func sendEmail(ctx context.Context, db database.DB, userID int32, source string, template txtypes.Templates, data any) error {
 	email, verified, err := db.UserEmails().GetPrimaryEmail(ctx, userID)
 	if err != nil {
 		if errcode.IsNotFound(err) {
@@ -133,7 +133,7 @@ func sendEmail(ctx context.Context, db database.DB, userID int32, template txtyp
 		return errors.Newf("unable to send email to user ID %d's unverified primary email address", userID)
 	}
 
-	if err := internalapi.Client.SendEmail(ctx, "code-monitor", txtypes.Message{
+	if err := internalapi.Client.SendEmail(ctx, source, txtypes.Message{
 		To:       []string{email},
 		Template: template,
 		Data:     data,
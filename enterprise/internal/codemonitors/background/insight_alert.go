@@ -0,0 +1,187 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/slack-go/slack"
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Code insights alerts on series values are delivered through the same channels as code monitor notifications, by
+// action jobs that are processed by the action runner of code monitors.
+
+const utmSourceInsightAlert = "code-insights-alert"
+
+// InsightAlert is a triggered threshold rule on the value of a code insights series.
+type InsightAlert struct {
+	SeriesID  string    `json:"seriesId"`
+	Query     string    `json:"query"`
+	Condition string    `json:"condition"` // either ABOVE or INCREASE
+	Threshold float64   `json:"threshold"`
+	Time      time.Time `json:"time"`
+	Value     float64   `json:"value"`
+	// Previous is the value of the previous recording of the series, if there is one.
+	Previous *float64 `json:"previous,omitempty"`
+}
+
+// Description summarizes why the alert was triggered.
+func (a InsightAlert) Description() string {
+	if a.Condition == "INCREASE" && a.Previous != nil {
+		return fmt.Sprintf("The code insight for %q increased by %s from %s to %s, which is more than the threshold of %s.",
+			a.Query, formatFloat(a.Value-*a.Previous), formatFloat(*a.Previous), formatFloat(a.Value), formatFloat(a.Threshold))
+	}
+	return fmt.Sprintf("The code insight for %q is at %s, which is above the threshold of %s.",
+		a.Query, formatFloat(a.Value), formatFloat(a.Threshold))
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// InsightAlertChannels are the channels a triggered insight alert is delivered through.
+type InsightAlertChannels struct {
+	// EmailUserID, if set, is the user whose primary email address the alert is sent to.
+	EmailUserID     *int32
+	SlackWebhookURL *string
+	WebhookURL      *string
+}
+
+// EnqueueInsightAlert enqueues an action job for each of the channels the alert is delivered through. The jobs are
+// retried like the actions of code monitors if the alert cannot be delivered.
+func EnqueueInsightAlert(ctx context.Context, store edb.CodeMonitorStore, channels InsightAlertChannels, alert InsightAlert) error {
+	raw, err := json.Marshal(alert)
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+	var actions []edb.InsightAlertAction
+	if channels.EmailUserID != nil {
+		actions = append(actions, edb.InsightAlertAction{EmailUserID: channels.EmailUserID, Alert: raw})
+	}
+	if channels.SlackWebhookURL != nil {
+		actions = append(actions, edb.InsightAlertAction{SlackWebhookURL: channels.SlackWebhookURL, Alert: raw})
+	}
+	if channels.WebhookURL != nil {
+		actions = append(actions, edb.InsightAlertAction{WebhookURL: channels.WebhookURL, Alert: raw})
+	}
+	_, err = store.EnqueueInsightAlertActionJobs(ctx, actions)
+	return err
+}
+
+// handleInsightAlert delivers the insight alert of an action job through the channel of the job.
+func (r *actionRunner) handleInsightAlert(ctx context.Context, j *edb.ActionJob) error {
+	var alert InsightAlert
+	if err := json.Unmarshal(j.InsightAlert.Alert, &alert); err != nil {
+		return errors.Wrap(err, "unmarshal failed")
+	}
+	switch action := j.InsightAlert; {
+	case action.EmailUserID != nil:
+		return sendInsightAlertEmail(ctx, database.NewDBWith(log.Scoped("handleInsightAlert", ""), r.CodeMonitorStore), *action.EmailUserID, alert)
+	case action.SlackWebhookURL != nil:
+		return sendInsightAlertSlackWebhook(ctx, httpcli.ExternalDoer, *action.SlackWebhookURL, alert)
+	case action.WebhookURL != nil:
+		return sendInsightAlertWebhook(ctx, httpcli.ExternalDoer, *action.WebhookURL, alert)
+	default:
+		return errors.New("insight alert job must have an email recipient, slack webhook or webhook")
+	}
+}
+
+var insightAlertEmailTemplates = txemail.MustValidate(txtypes.Templates{
+	Subject: `Sourcegraph code insight alert for {{.Query}}`,
+	Text: `{{.Description}}
+
+View the search results: {{.SearchURL}}
+`,
+	HTML: `<p>{{.Description}}</p>
+
+<p><a href="{{.SearchURL}}">View the search results</a></p>
+`,
+})
+
+type templateDataInsightAlert struct {
+	Query       string
+	Description string
+	SearchURL   string
+}
+
+// sendInsightAlertEmail emails the alert to the primary email address of the user.
+func sendInsightAlertEmail(ctx context.Context, db database.DB, userID int32, alert InsightAlert) error {
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+	return sendEmail(ctx, db, userID, "code-insights", insightAlertEmailTemplates, &templateDataInsightAlert{
+		Query:       alert.Query,
+		Description: alert.Description(),
+		SearchURL:   getSearchURL(externalURL, alert.Query, utmSourceInsightAlert),
+	})
+}
+
+// sendInsightAlertSlackWebhook posts the alert to a Slack incoming webhook.
+func sendInsightAlertSlackWebhook(ctx context.Context, doer httpcli.Doer, url string, alert InsightAlert) error {
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+	return postSlackWebhook(ctx, doer, url, insightAlertSlackPayload(alert, externalURL))
+}
+
+func insightAlertSlackPayload(alert InsightAlert, externalURL *url.URL) *slack.WebhookMessage {
+	newMarkdownSection := func(s string) slack.Block {
+		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	}
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: []slack.Block{
+		newMarkdownSection(alert.Description()),
+		newMarkdownSection(fmt.Sprintf("<%s|View the search results>", getSearchURL(externalURL, alert.Query, utmSourceInsightAlert))),
+	}}}
+}
+
+// sendInsightAlertWebhook posts the alert as JSON to a webhook.
+func sendInsightAlertWebhook(ctx context.Context, doer httpcli.Doer, url string, alert InsightAlert) error {
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return err
+	}
+	raw, err := json.Marshal(insightAlertWebhookPayload(alert, externalURL))
+	if err != nil {
+		return errors.Wrap(err, "marshal failed")
+	}
+	return postWebhookBody(ctx, doer, url, raw)
+}
+
+type insightAlertPayload struct {
+	Description string   `json:"description"`
+	SeriesID    string   `json:"seriesId"`
+	Query       string   `json:"query"`
+	SearchURL   string   `json:"searchURL"`
+	Condition   string   `json:"condition"`
+	Threshold   float64  `json:"threshold"`
+	Time        string   `json:"time"`
+	Value       float64  `json:"value"`
+	Previous    *float64 `json:"previous,omitempty"`
+}
+
+func insightAlertWebhookPayload(alert InsightAlert, externalURL *url.URL) insightAlertPayload {
+	return insightAlertPayload{
+		Description: alert.Description(),
+		SeriesID:    alert.SeriesID,
+		Query:       alert.Query,
+		SearchURL:   getSearchURL(externalURL, alert.Query, utmSourceInsightAlert),
+		Condition:   alert.Condition,
+		Threshold:   alert.Threshold,
+		Time:        alert.Time.UTC().Format(time.RFC3339),
+		Value:       alert.Value,
+		Previous:    alert.Previous,
+	}
+}
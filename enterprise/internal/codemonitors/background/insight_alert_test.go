@@ -0,0 +1,93 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+func TestInsightAlert(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	above := InsightAlert{
+		SeriesID:  "s1",
+		Query:     "deprecatedFunc(",
+		Condition: "ABOVE",
+		Threshold: 100,
+		Time:      time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC),
+		Value:     120,
+	}
+	increase := above
+	increase.Condition = "INCREASE"
+	increase.Threshold = 0
+	increase.Previous = pointers.Ptr(112.5)
+
+	t.Run("description", func(t *testing.T) {
+		autogold.Expect(`The code insight for "deprecatedFunc(" is at 120, which is above the threshold of 100.`).Equal(t, above.Description())
+		autogold.Expect(`The code insight for "deprecatedFunc(" increased by 7.5 from 112.5 to 120, which is more than the threshold of 0.`).Equal(t, increase.Description())
+	})
+
+	t.Run("webhook payload", func(t *testing.T) {
+		b, err := json.MarshalIndent(insightAlertWebhookPayload(increase, eu), "", "  ")
+		require.NoError(t, err)
+		autogold.Expect(`{
+  "description": "The code insight for \"deprecatedFunc(\" increased by 7.5 from 112.5 to 120, which is more than the threshold of 0.",
+  "seriesId": "s1",
+  "query": "deprecatedFunc(",
+  "searchURL": "https://sourcegraph.com/search?q=deprecatedFunc%28\u0026utm_source=code-insights-alert",
+  "condition": "INCREASE",
+  "threshold": 0,
+  "time": "2023-06-01T00:00:00Z",
+  "value": 120,
+  "previous": 112.5
+}`).Equal(t, string(b))
+	})
+
+	t.Run("slack payload", func(t *testing.T) {
+		b, err := json.Marshal(insightAlertSlackPayload(above, eu))
+		require.NoError(t, err)
+		autogold.Expect(`{"blocks":[{"type":"section","text":{"type":"mrkdwn","text":"The code insight for \"deprecatedFunc(\" is at 120, which is above the threshold of 100."}},{"type":"section","text":{"type":"mrkdwn","text":"\u003chttps://sourcegraph.com/search?q=deprecatedFunc%28\u0026utm_source=code-insights-alert|View the search results\u003e"}}]}`).Equal(t, string(b))
+	})
+}
+
+func TestEnqueueInsightAlert(t *testing.T) {
+	alert := InsightAlert{
+		SeriesID:  "s1",
+		Query:     "deprecatedFunc(",
+		Condition: "INCREASE",
+		Threshold: 5,
+		Time:      time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC),
+		Value:     120,
+		Previous:  pointers.Ptr(112.5),
+	}
+
+	store := edb.NewMockCodeMonitorStore()
+	err := EnqueueInsightAlert(context.Background(), store, InsightAlertChannels{
+		EmailUserID: pointers.Ptr(int32(1)),
+		WebhookURL:  pointers.Ptr("https://example.com/webhook"),
+	}, alert)
+	require.NoError(t, err)
+
+	history := store.EnqueueInsightAlertActionJobsFunc.History()
+	require.Len(t, history, 1)
+	actions := history[0].Arg1
+	require.Len(t, actions, 2)
+	autogold.Expect([]bool{true, false, false}).Equal(t, []bool{actions[0].EmailUserID != nil, actions[0].SlackWebhookURL != nil, actions[0].WebhookURL != nil})
+	autogold.Expect([]bool{false, false, true}).Equal(t, []bool{actions[1].EmailUserID != nil, actions[1].SlackWebhookURL != nil, actions[1].WebhookURL != nil})
+
+	// The action runner delivers the alert that was enqueued.
+	for _, action := range actions {
+		var got InsightAlert
+		require.NoError(t, json.Unmarshal(action.Alert, &got))
+		require.Equal(t, alert, got)
+	}
+}
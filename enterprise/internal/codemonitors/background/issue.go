@@ -0,0 +1,170 @@
+package background
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/sourcegraph/log"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const utmSourceIssue = "code-monitor-issue"
+
+const defaultIssueBodyTemplate = `Sourcegraph code monitor [{{.MonitorDescription}}]({{.MonitorURL}}) detected {{.ResultCount}} new {{if eq .ResultCount 1}}match{{else}}matches{{end}} for the query ` + "`{{.Query}}`" + `.
+{{range .Results}}
+- [{{.Repository}}@{{printf "%.10s" .Commit}}]({{.URL}}){{end}}
+
+[View search results]({{.SearchURL}})
+`
+
+var MockOpenIssue func(ctx context.Context, db database.DB, repoName, title, body string) (string, error)
+
+// issueMatchKey identifies a match that an issue was opened for, so that no
+// further issues are opened for the same match.
+func issueMatchKey(match *result.CommitMatch) string {
+	return string(match.Repo.Name) + "@" + string(match.Commit.ID)
+}
+
+// claimIssueMatches claims the matches that the issue action did not open an
+// issue for yet, and returns them with their keys.
+func claimIssueMatches(ctx context.Context, s edb.CodeMonitorStore, issueID int64, matches []*result.CommitMatch) ([]*result.CommitMatch, []string, error) {
+	var (
+		keys       []string
+		keyMatches = make(map[string]*result.CommitMatch, len(matches))
+	)
+	for _, match := range matches {
+		key := issueMatchKey(match)
+		// A commit can match more than once, for example on both its message
+		// and its diff.
+		if _, ok := keyMatches[key]; ok {
+			continue
+		}
+		keyMatches[key] = match
+		keys = append(keys, key)
+	}
+
+	claimed, err := s.ClaimIssueMatches(ctx, issueID, keys)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "ClaimIssueMatches")
+	}
+	isClaimed := make(map[string]struct{}, len(claimed))
+	for _, key := range claimed {
+		isClaimed[key] = struct{}{}
+	}
+
+	var (
+		newMatches []*result.CommitMatch
+		newKeys    []string
+	)
+	for _, key := range keys {
+		if _, ok := isClaimed[key]; !ok {
+			continue
+		}
+		newMatches = append(newMatches, keyMatches[key])
+		newKeys = append(newKeys, key)
+	}
+	return newMatches, newKeys, nil
+}
+
+func renderIssue(issue *edb.IssueAction, args actionArgs) (title, body string, err error) {
+	data := newActionTemplateData(args)
+
+	title, err = renderActionTemplate("title", issue.TitleTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+
+	bodyTemplate := issue.BodyTemplate
+	if bodyTemplate == "" {
+		bodyTemplate = defaultIssueBodyTemplate
+	}
+	body, err = renderActionTemplate("body", bodyTemplate, data)
+	if err != nil {
+		return "", "", err
+	}
+	return title, body, nil
+}
+
+// openIssue opens an issue in the GitHub or GitLab repository with the given name
+// and returns its URL. The issue is opened with the code host credential the user
+// of the actor in ctx configured for Batch Changes.
+func openIssue(ctx context.Context, db database.DB, repoName, title, body string) (string, error) {
+	if MockOpenIssue != nil {
+		return MockOpenIssue(ctx, db, repoName, title, body)
+	}
+
+	// 🚨 SECURITY: The repository is looked up as the actor in ctx, so that issues
+	// can only be opened in repositories the user has access to.
+	repo, err := db.Repos().GetByName(ctx, api.RepoName(repoName))
+	if err != nil {
+		return "", errors.Wrap(err, "getting repository")
+	}
+
+	cred, err := db.UserCredentials(keyring.Default().BatchChangesCredentialKey).GetByScope(ctx, database.UserCredentialScope{
+		Domain:              database.UserCredentialDomainBatches,
+		UserID:              actor.FromContext(ctx).UID,
+		ExternalServiceType: repo.ExternalRepo.ServiceType,
+		ExternalServiceID:   repo.ExternalRepo.ServiceID,
+	})
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return "", errors.Newf("no code host credential configured for %s", repo.ExternalRepo.ServiceID)
+		}
+		return "", errors.Wrap(err, "getting code host credential")
+	}
+	a, err := cred.Authenticator(ctx)
+	if err != nil {
+		return "", errors.Wrap(err, "getting authenticator")
+	}
+
+	baseURL, err := url.Parse(repo.ExternalRepo.ServiceID)
+	if err != nil {
+		return "", errors.Wrap(err, "parsing code host URL")
+	}
+
+	switch repo.ExternalRepo.ServiceType {
+	case extsvc.TypeGitHub:
+		metadata, ok := repo.Metadata.(*github.Repository)
+		if !ok {
+			return "", errors.Newf("unexpected metadata of type %T for repository %s", repo.Metadata, repo.Name)
+		}
+		owner, name, err := github.SplitRepositoryNameWithOwner(metadata.NameWithOwner)
+		if err != nil {
+			return "", err
+		}
+		apiURL, _ := github.APIRoot(baseURL)
+		client := github.NewV3Client(log.Scoped("codemonitors.issue", "opens issues for code monitors"), "", apiURL, a, httpcli.ExternalDoer)
+		issue, err := client.CreateIssue(ctx, owner, name, title, body)
+		if err != nil {
+			return "", errors.Wrap(err, "creating GitHub issue")
+		}
+		return issue.HTMLURL, nil
+
+	case extsvc.TypeGitLab:
+		project, ok := repo.Metadata.(*gitlab.Project)
+		if !ok {
+			return "", errors.Newf("unexpected metadata of type %T for repository %s", repo.Metadata, repo.Name)
+		}
+		client := gitlab.NewClientProvider("", baseURL, httpcli.ExternalDoer).GetAuthenticatorClient(a)
+		issue, err := client.CreateIssue(ctx, project, gitlab.CreateIssueOpts{Title: title, Description: body})
+		if err != nil {
+			return "", errors.Wrap(err, "creating GitLab issue")
+		}
+		return issue.WebURL, nil
+
+	default:
+		return "", errors.Newf("opening issues on code hosts of type %s is not supported", repo.ExternalRepo.ServiceType)
+	}
+}
@@ -0,0 +1,147 @@
+package background
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestClaimIssueMatches(t *testing.T) {
+	otherResultMock := result.CommitMatch{
+		Commit: gitdomain.Commit{ID: api.CommitID("9cb4a43a052f8178566")},
+		Repo:   types.MinimalRepo{Name: api.RepoName("github.com/test/other")},
+	}
+
+	s := edb.NewMockCodeMonitorStore()
+	s.ClaimIssueMatchesFunc.SetDefaultHook(func(_ context.Context, issueID int64, keys []string) ([]string, error) {
+		require.Equal(t, int64(42), issueID)
+		require.Equal(t, []string{
+			"github.com/test/test@7815187511872asbasdfgasd",
+			"github.com/test/other@9cb4a43a052f8178566",
+		}, keys)
+		return []string{"github.com/test/test@7815187511872asbasdfgasd"}, nil
+	})
+
+	matches, keys, err := claimIssueMatches(context.Background(), s, 42, []*result.CommitMatch{&diffResultMock, &commitResultMock, &otherResultMock})
+	require.NoError(t, err)
+	// The diff and commit matches are for the same commit, and the other match
+	// was already claimed.
+	require.Equal(t, []*result.CommitMatch{&diffResultMock}, matches)
+	require.Equal(t, []string{"github.com/test/test@7815187511872asbasdfgasd"}, keys)
+}
+
+func TestHandleIssue(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+	MockExternalURL = func() *url.URL { return eu }
+	t.Cleanup(func() { MockExternalURL = nil })
+
+	issueID := int64(42)
+	newStore := func() *edb.MockCodeMonitorStore {
+		s := edb.NewMockCodeMonitorStore()
+		s.GetActionJobMetadataFunc.SetDefaultReturn(&edb.ActionJobMetadata{
+			Description: "My test monitor",
+			Results:     []*result.CommitMatch{&diffResultMock},
+			OwnerID:     1,
+		}, nil)
+		s.GetIssueActionFunc.SetDefaultReturn(&edb.IssueAction{ID: issueID, TitleTemplate: "New matches", Repository: "github.com/test/test"}, nil)
+		s.ClaimIssueMatchesFunc.SetDefaultHook(func(_ context.Context, _ int64, keys []string) ([]string, error) {
+			return keys, nil
+		})
+		return s
+	}
+	job := &edb.ActionJob{ID: 1, Issue: &issueID}
+
+	t.Run("issue opened", func(t *testing.T) {
+		s := newStore()
+		MockOpenIssue = func(context.Context, database.DB, string, string, string) (string, error) {
+			// The matches are claimed before the issue is opened.
+			require.Len(t, s.ClaimIssueMatchesFunc.History(), 1)
+			return "https://github.com/test/test/issues/1", nil
+		}
+		t.Cleanup(func() { MockOpenIssue = nil })
+
+		r := &actionRunner{s}
+		require.NoError(t, r.handleIssue(context.Background(), job))
+
+		require.Len(t, s.SetIssueMatchesURLFunc.History(), 1)
+		call := s.SetIssueMatchesURLFunc.History()[0]
+		require.Equal(t, "https://github.com/test/test/issues/1", call.Arg2)
+		require.Equal(t, []string{"github.com/test/test@7815187511872asbasdfgasd"}, call.Arg3)
+		require.Empty(t, s.DeleteIssueMatchesFunc.History())
+	})
+
+	t.Run("matches already claimed", func(t *testing.T) {
+		s := newStore()
+		s.ClaimIssueMatchesFunc.SetDefaultReturn(nil, nil)
+		MockOpenIssue = func(context.Context, database.DB, string, string, string) (string, error) {
+			t.Fatal("unexpected issue opened")
+			return "", nil
+		}
+		t.Cleanup(func() { MockOpenIssue = nil })
+
+		r := &actionRunner{s}
+		require.NoError(t, r.handleIssue(context.Background(), job))
+		require.Empty(t, s.SetIssueMatchesURLFunc.History())
+	})
+
+	t.Run("issue not opened", func(t *testing.T) {
+		s := newStore()
+		MockOpenIssue = func(context.Context, database.DB, string, string, string) (string, error) {
+			return "", errors.New("rate limited")
+		}
+		t.Cleanup(func() { MockOpenIssue = nil })
+
+		r := &actionRunner{s}
+		require.Error(t, r.handleIssue(context.Background(), job))
+
+		// The claims are released, so that the retried job opens the issue.
+		require.Empty(t, s.SetIssueMatchesURLFunc.History())
+		require.Len(t, s.DeleteIssueMatchesFunc.History(), 1)
+		require.Equal(t, []string{"github.com/test/test@7815187511872asbasdfgasd"}, s.DeleteIssueMatchesFunc.History()[0].Arg2)
+	})
+}
+
+func TestRenderIssue(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	args := actionArgs{
+		MonitorDescription: "My test monitor",
+		ExternalURL:        eu,
+		MonitorID:          42,
+		UTMSource:          utmSourceIssue,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock},
+		IncludeResults:     true,
+	}
+
+	t.Run("default body", func(t *testing.T) {
+		title, body, err := renderIssue(&edb.IssueAction{TitleTemplate: "{{.MonitorDescription}}: {{.ResultCount}} new matches"}, args)
+		require.NoError(t, err)
+		require.Equal(t, "My test monitor: 1 new matches", title)
+		require.Contains(t, body, "detected 1 new match for the query `repo:camdentest -file:id_rsa.pub BEGIN`")
+		require.Contains(t, body, "- [github.com/test/test@7815187511]")
+	})
+
+	t.Run("custom body", func(t *testing.T) {
+		_, body, err := renderIssue(&edb.IssueAction{TitleTemplate: "title", BodyTemplate: "{{range .Results}}{{.Commit}}{{end}}"}, args)
+		require.NoError(t, err)
+		require.Equal(t, "7815187511872asbasdfgasd", body)
+	})
+
+	t.Run("invalid title", func(t *testing.T) {
+		_, _, err := renderIssue(&edb.IssueAction{TitleTemplate: "{{.DoesNotExist}}"}, args)
+		require.Error(t, err)
+	})
+}
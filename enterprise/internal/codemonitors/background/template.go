@@ -0,0 +1,115 @@
+package background
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"text/template"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// actionTemplateData is the data user-defined templates of webhook and issue
+// actions are executed with.
+type actionTemplateData struct {
+	MonitorDescription string
+	MonitorURL         string
+	SearchURL          string
+	Query              string
+	ResultCount        int
+	// Results is only set if the action includes results.
+	Results []actionTemplateResult
+}
+
+type actionTemplateResult struct {
+	Repository string
+	Commit     string
+	URL        string
+	Message    string
+	Diff       string
+}
+
+func newActionTemplateData(args actionArgs) actionTemplateData {
+	d := actionTemplateData{
+		MonitorDescription: args.MonitorDescription,
+		MonitorURL:         getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+		SearchURL:          getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+		Query:              args.Query,
+		ResultCount:        len(args.Results),
+	}
+
+	if args.IncludeResults {
+		d.Results = make([]actionTemplateResult, 0, len(args.Results))
+		for _, match := range args.Results {
+			res := actionTemplateResult{
+				Repository: string(match.Repo.Name),
+				Commit:     string(match.Commit.ID),
+				URL:        getCommitURL(args.ExternalURL, string(match.Repo.Name), string(match.Commit.ID), args.UTMSource),
+			}
+			if match.MessagePreview != nil {
+				res.Message = match.MessagePreview.Content
+			}
+			if match.DiffPreview != nil {
+				res.Diff = match.DiffPreview.Content
+			}
+			d.Results = append(d.Results, res)
+		}
+	}
+
+	return d
+}
+
+var actionTemplateFuncs = template.FuncMap{
+	// json encodes a value as JSON, so that templates rendering JSON payloads can
+	// safely embed arbitrary strings.
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+func parseActionTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(actionTemplateFuncs).Parse(text)
+}
+
+func renderActionTemplate(name, text string, data actionTemplateData) (string, error) {
+	tmpl, err := parseActionTemplate(name, text)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing %s template", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, "executing %s template", name)
+	}
+	return buf.String(), nil
+}
+
+// ValidateActionTemplate returns an error if text is not a valid template for
+// the webhook or issue actions of code monitors. The template is executed
+// against sample data, so that references to fields that do not exist are
+// caught before the action first runs.
+func ValidateActionTemplate(text string) error {
+	_, err := renderActionTemplate("action", text, newActionTemplateData(sampleActionArgs))
+	return err
+}
+
+var sampleActionArgs = actionArgs{
+	MonitorDescription: "Sample monitor",
+	ExternalURL:        &url.URL{},
+	Query:              "sample query",
+	Results: []*result.CommitMatch{{
+		Commit: gitdomain.Commit{
+			ID:      api.CommitID("0000000000000000000000000000000000000000"),
+			Message: gitdomain.Message("Sample commit"),
+		},
+		Repo:           types.MinimalRepo{Name: api.RepoName("example.com/sample/repo")},
+		MessagePreview: &result.MatchedString{Content: "Sample commit"},
+		DiffPreview:    &result.MatchedString{Content: "sample.go sample.go\n@@ -1,1 +1,1 @@\n-old\n+new\n"},
+	}},
+	IncludeResults: true,
+}
@@ -0,0 +1,64 @@
+package background
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestValidateActionTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  bool
+	}{
+		{name: "empty", template: ""},
+		{name: "fields", template: "{{.MonitorDescription}} {{.Query}} {{.ResultCount}} {{.MonitorURL}} {{.SearchURL}}"},
+		{name: "results", template: "{{range .Results}}{{.Repository}}@{{.Commit}} {{.URL}} {{json .Message}} {{json .Diff}}{{end}}"},
+		{name: "parse error", template: "{{.MonitorDescription", wantErr: true},
+		{name: "unknown field", template: "{{.DoesNotExist}}", wantErr: true},
+		{name: "unknown function", template: "{{doesNotExist .Query}}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateActionTemplate(tt.template)
+			if tt.wantErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRenderActionTemplate(t *testing.T) {
+	eu, err := url.Parse("https://sourcegraph.com")
+	require.NoError(t, err)
+
+	args := actionArgs{
+		MonitorDescription: `My "test" monitor`,
+		ExternalURL:        eu,
+		MonitorID:          42,
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+	}
+
+	t.Run("without results", func(t *testing.T) {
+		got, err := renderActionTemplate("test", "{{json .MonitorDescription}}: {{.ResultCount}} results, {{len .Results}} included", newActionTemplateData(args))
+		require.NoError(t, err)
+		require.Equal(t, `"My \"test\" monitor": 2 results, 0 included`, got)
+	})
+
+	t.Run("with results", func(t *testing.T) {
+		argsCopy := args
+		argsCopy.IncludeResults = true
+
+		got, err := renderActionTemplate("test", "{{range .Results}}{{.Repository}}@{{.Commit}}\n{{end}}", newActionTemplateData(argsCopy))
+		require.NoError(t, err)
+		require.Equal(t, "github.com/test/test@7815187511872asbasdfgasd\ngithub.com/test/test@7815187511872asbasdfgasd\n", got)
+	})
+}
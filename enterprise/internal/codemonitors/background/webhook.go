@@ -13,7 +13,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func sendWebhookNotification(ctx context.Context, url string, args actionArgs) error {
+func sendWebhookNotification(ctx context.Context, url, payloadTemplate string, args actionArgs) error {
+	if payloadTemplate != "" {
+		return postTemplatedWebhook(ctx, httpcli.ExternalDoer, url, payloadTemplate, args)
+	}
 	return postWebhook(ctx, httpcli.ExternalDoer, url, generateWebhookPayload(args))
 }
 
@@ -22,7 +25,20 @@ func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload web
 	if err != nil {
 		return errors.Wrap(err, "marshal failed")
 	}
+	return postWebhookBody(ctx, doer, url, raw)
+}
 
+// postTemplatedWebhook posts the payload rendered from the user-defined payload
+// template instead of the default JSON payload.
+func postTemplatedWebhook(ctx context.Context, doer httpcli.Doer, url, payloadTemplate string, args actionArgs) error {
+	payload, err := renderActionTemplate("payload", payloadTemplate, newActionTemplateData(args))
+	if err != nil {
+		return err
+	}
+	return postWebhookBody(ctx, doer, url, []byte(payload))
+}
+
+func postWebhookBody(ctx context.Context, doer httpcli.Doer, url string, raw []byte) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(raw))
 	if err != nil {
 		return errors.Wrap(err, "failed new request")
@@ -47,12 +63,15 @@ func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload web
 	return nil
 }
 
-func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string, u string) error {
+func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description, payloadTemplate string, u string) error {
 	args := actionArgs{
 		ExternalURL:        &url.URL{},
 		MonitorDescription: description,
 		Query:              "test query",
 	}
+	if payloadTemplate != "" {
+		return postTemplatedWebhook(ctx, httpcli.ExternalDoer, u, payloadTemplate, args)
+	}
 	return postWebhook(ctx, httpcli.ExternalDoer, u, generateWebhookPayload(args))
 }
 
@@ -54,6 +54,24 @@ func TestWebhook(t *testing.T) {
 		autogold.ExpectFile(t, autogold.Raw(j))
 	})
 
+	t.Run("templated payload", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			autogold.ExpectFile(t, autogold.Raw(b))
+			w.WriteHeader(200)
+		}))
+		defer s.Close()
+
+		actionCopy := action
+		actionCopy.IncludeResults = true
+
+		payloadTemplate := `{"text": {{json .MonitorDescription}}, "count": {{.ResultCount}}, "commits": [{{range $i, $r := .Results}}{{if $i}}, {{end}}{{json $r.Commit}}{{end}}]}`
+		client := s.Client()
+		err := postTemplatedWebhook(context.Background(), client, s.URL, payloadTemplate, actionCopy)
+		require.NoError(t, err)
+	})
+
 	t.Run("error is returned", func(t *testing.T) {
 		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			b, err := io.ReadAll(r.Body)
@@ -79,6 +97,6 @@ func TestTriggerTestWebhookAction(t *testing.T) {
 	defer s.Close()
 
 	client := s.Client()
-	err := SendTestWebhook(context.Background(), client, "My test monitor", s.URL)
+	err := SendTestWebhook(context.Background(), client, "My test monitor", "", s.URL)
 	require.NoError(t, err)
 }
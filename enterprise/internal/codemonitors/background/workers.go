@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -80,13 +81,16 @@ func newTriggerQueryResetter(_ context.Context, observationCtx *observation.Cont
 func newTriggerJobsLogDeleter(ctx context.Context, store edb.CodeMonitorStore) goroutine.BackgroundRoutine {
 	deleteLogs := goroutine.HandlerFunc(
 		func(ctx context.Context) error {
-			return store.DeleteOldTriggerJobs(ctx, eventRetentionInDays)
+			if err := store.DeleteOldTriggerJobs(ctx, eventRetentionInDays); err != nil {
+				return err
+			}
+			return store.DeleteStaleMatchFingerprints(ctx, eventRetentionInDays)
 		})
 	return goroutine.NewPeriodicGoroutine(
 		ctx,
 		deleteLogs,
 		goroutine.WithName("code_monitors.trigger_jobs_log_deleter"),
-		goroutine.WithDescription("deletes code job logs from code monitor triggers"),
+		goroutine.WithDescription("deletes code job logs and stale match fingerprints from code monitor triggers"),
 		goroutine.WithInterval(60*time.Minute),
 	)
 }
@@ -182,7 +186,7 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 	ctx = actor.WithActor(ctx, actor.FromUser(m.UserID))
 	ctx = featureflag.WithFlags(ctx, r.db.FeatureFlags())
 
-	results, searchErr := codemonitors.Search(ctx, logger, r.db, r.enterpriseJobs, q.QueryString, m.ID)
+	results, fingerprints, searchErr := codemonitors.Search(ctx, logger, r.db, r.enterpriseJobs, q.QueryString, m.ID)
 
 	// Log next_run and latest_result to table cm_queries.
 	newLatestResult := latestResultTime(q.LatestResult, results, searchErr)
@@ -196,18 +200,49 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 		return errors.Wrap(searchErr, "execute search")
 	}
 
+	if err := r.enqueueActions(ctx, triggerJob.ID, m.ID, q.QueryString, results, fingerprints); err != nil {
+		return err
+	}
+
+	if len(results) > 0 {
+		database.EnqueueOutboundWebhookEvent(ctx, logger, r.db, events.CodeMonitorFired, events.CodeMonitor{
+			ID:          events.MarshalCodeMonitorID(m.ID),
+			Description: m.Description,
+			Owner:       events.MarshalUserID(m.UserID),
+			Query:       q.QueryString,
+			ResultCount: len(results),
+		})
+	}
+	return nil
+}
+
+// enqueueActions records the results of the trigger job and enqueues the actions
+// of the monitor if there are new results. The fingerprints of the matches of
+// content searches are recorded in the same transaction, so that the matches
+// trigger the monitor again if the actions cannot be enqueued.
+func (r *queryRunner) enqueueActions(ctx context.Context, triggerJobID int32, monitorID int64, queryString string, results []*result.CommitMatch, fingerprints []string) (err error) {
+	tx, err := r.db.CodeMonitors().Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
 	// Log the actual query we ran and whether we got any new results.
-	err = cm.UpdateTriggerJobWithResults(ctx, triggerJob.ID, q.QueryString, results)
+	err = tx.UpdateTriggerJobWithResults(ctx, triggerJobID, queryString, results)
 	if err != nil {
 		return errors.Wrap(err, "UpdateTriggerJobWithResults")
 	}
 
 	if len(results) > 0 {
-		_, err := cm.EnqueueActionJobsForMonitor(ctx, m.ID, triggerJob.ID)
+		_, err := tx.EnqueueActionJobsForMonitor(ctx, monitorID, triggerJobID)
 		if err != nil {
 			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
 		}
 	}
+
+	if err := tx.UpsertMatchFingerprints(ctx, monitorID, fingerprints); err != nil {
+		return errors.Wrap(err, "UpsertMatchFingerprints")
+	}
 	return nil
 }
 
@@ -230,8 +265,12 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, j *edb.Act
 		return r.handleWebhook(ctx, j)
 	case j.SlackWebhook != nil:
 		return r.handleSlackWebhook(ctx, j)
+	case j.Issue != nil:
+		return r.handleIssue(ctx, j)
+	case j.InsightAlert != nil:
+		return r.handleInsightAlert(ctx, j)
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, issue, or insight alert")
 	}
 }
 
@@ -326,7 +365,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *edb.ActionJob) erro
 		IncludeResults:     w.IncludeResults,
 	}
 
-	return sendWebhookNotification(ctx, w.URL, args)
+	return sendWebhookNotification(ctx, w.URL, w.PayloadTemplate, args)
 }
 
 func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob) error {
@@ -365,6 +404,70 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *edb.ActionJob)
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+// handleIssue opens an issue for the matches that the issue action did not open
+// an issue for yet. Opening an issue cannot be rolled back, so the matches are
+// claimed before the issue is opened, and the URL of the issue is recorded
+// after. If the issue cannot be opened, the claims are released so that the job
+// opens the issue when it is retried.
+func (r *actionRunner) handleIssue(ctx context.Context, j *edb.ActionJob) error {
+	m, err := r.CodeMonitorStore.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	i, err := r.CodeMonitorStore.GetIssueAction(ctx, *j.Issue)
+	if err != nil {
+		return errors.Wrap(err, "GetIssueAction")
+	}
+
+	results, keys, err := claimIssueMatches(ctx, r.CodeMonitorStore, i.ID, m.Results)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		// An issue was already opened for all of the matches.
+		return nil
+	}
+
+	issueURL, err := r.openIssueForMatches(ctx, m, i, results)
+	if err != nil {
+		if deleteErr := r.CodeMonitorStore.DeleteIssueMatches(ctx, i.ID, keys...); deleteErr != nil {
+			err = errors.Append(err, errors.Wrap(deleteErr, "DeleteIssueMatches"))
+		}
+		return err
+	}
+
+	return r.CodeMonitorStore.SetIssueMatchesURL(ctx, i.ID, issueURL, keys...)
+}
+
+func (r *actionRunner) openIssueForMatches(ctx context.Context, m *edb.ActionJobMetadata, i *edb.IssueAction, results []*result.CommitMatch) (string, error) {
+	externalURL, err := getExternalURL(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          i.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          utmSourceIssue,
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            results,
+		IncludeResults:     i.IncludeResults,
+	}
+
+	title, body, err := renderIssue(i, args)
+	if err != nil {
+		return "", err
+	}
+
+	// 🚨 SECURITY: The issue is opened as the owner of the code monitor, so that
+	// it is subject to their permissions and uses their credentials.
+	ownerCtx := actor.WithActor(ctx, actor.FromUser(m.OwnerID))
+	return openIssue(ownerCtx, database.NewDBWith(log.Scoped("handleIssue", ""), r.CodeMonitorStore), i.Repository, title, body)
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
@@ -0,0 +1,239 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Code monitors with queries that do not search commits or diffs run a content
+// search on every run. The file and line matches are identified by fingerprints,
+// and only matches whose fingerprints were not recorded by a previous run trigger
+// the actions of the monitor.
+
+// contentSearchLimit is the limit on the number of results of content searches
+// without a count: filter. It is explicitly higher than the default limit of
+// streaming searches, as the matches which were not returned because of the
+// limit would trigger the monitor once they are returned.
+const contentSearchLimit = 100000
+
+// errContentSearchLimitHit is returned when a content search hits its limit. The
+// matches are then an arbitrary subset of all matches, so they can neither be
+// told apart from the matches of previous runs nor recorded for later runs.
+var errContentSearchLimitHit = errors.New("the search of the code monitor returned too many results to detect new matches, use a more specific query")
+
+// isCommitSearch returns whether the job searches commits or diffs.
+func isCommitSearch(j job.Job) bool {
+	return job.HasDescendent[*commit.SearchJob](j)
+}
+
+// planContentSearch returns the job of a content search, with an explicit limit
+// for the queries of the plan that do not set one.
+func planContentSearch(inputs *search.Inputs, enterpriseJobs jobutil.EnterpriseJobs) (job.Job, error) {
+	return jobutil.NewPlanJob(inputs, inputs.Plan.WithDefaultCount(contentSearchLimit), enterpriseJobs)
+}
+
+// searchContent runs the content search of a code monitor and returns the file
+// and line matches that were not found by a previous run. The new matches are
+// returned as diff matches which add the matched lines, so that they can be
+// rendered by the actions like the results of commit searches.
+//
+// The fingerprints of all matches are returned too. They must be recorded once
+// the actions of the monitor are enqueued, so that the matches are found again
+// by the next run if that fails.
+func searchContent(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) ([]*result.CommitMatch, []string, error) {
+	matches, err := runContentSearch(ctx, clients, planJob)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fingerprints := make([]string, 0, len(matches))
+	for _, m := range matches {
+		fingerprints = append(fingerprints, m.fingerprint)
+	}
+	known, err := edb.NewEnterpriseDB(db).CodeMonitors().ListMatchFingerprints(ctx, monitorID, fingerprints)
+	if err != nil {
+		return nil, nil, err
+	}
+	seen := make(map[string]struct{}, len(known))
+	for _, fingerprint := range known {
+		seen[fingerprint] = struct{}{}
+	}
+
+	var newMatches []contentMatch
+	for _, m := range matches {
+		if _, ok := seen[m.fingerprint]; !ok {
+			newMatches = append(newMatches, m)
+		}
+	}
+
+	// All fingerprints are returned, so that matches which are still present are
+	// not considered stale.
+	return toCommitMatches(newMatches), fingerprints, nil
+}
+
+// snapshotContent records the fingerprints of the matches the content search of a
+// code monitor currently finds, so that only matches found after that trigger the
+// actions of the monitor.
+func snapshotContent(ctx context.Context, db database.DB, clients job.RuntimeClients, planJob job.Job, monitorID int64) error {
+	matches, err := runContentSearch(ctx, clients, planJob)
+	if err != nil {
+		return err
+	}
+
+	fingerprints := make([]string, 0, len(matches))
+	for _, m := range matches {
+		fingerprints = append(fingerprints, m.fingerprint)
+	}
+
+	cm := edb.NewEnterpriseDB(db).CodeMonitors()
+	if err := cm.DeleteMatchFingerprints(ctx, monitorID); err != nil {
+		return err
+	}
+	return cm.UpsertMatchFingerprints(ctx, monitorID, fingerprints)
+}
+
+func runContentSearch(ctx context.Context, clients job.RuntimeClients, planJob job.Job) ([]contentMatch, error) {
+	agg := streaming.NewAggregatingStream()
+	_, err := planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, err
+	}
+	if agg.Stats.IsLimitHit {
+		return nil, errContentSearchLimitHit
+	}
+	return contentMatches(agg.Results), nil
+}
+
+// contentMatch is a single line matched by a content search, or a file whose
+// path matched.
+type contentMatch struct {
+	file *result.File
+	// line is nil if only the path of the file matched.
+	line        *result.LineMatch
+	fingerprint string
+}
+
+// contentMatches splits the file matches of a content search into line matches.
+// Other types of matches are ignored.
+func contentMatches(matches result.Matches) []contentMatch {
+	var res []contentMatch
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+
+		var lines []*result.LineMatch
+		for _, line := range fm.ChunkMatches.AsLineMatches() {
+			// Chunk matches can contain lines without any matched ranges.
+			if len(line.OffsetAndLengths) > 0 {
+				lines = append(lines, line)
+			}
+		}
+
+		if len(lines) == 0 {
+			res = append(res, contentMatch{
+				file:        &fm.File,
+				fingerprint: matchFingerprint(&fm.File, nil, 0),
+			})
+			continue
+		}
+
+		// Identical lines in the same file are told apart by the number of
+		// identical lines before them.
+		occurrences := make(map[string]int, len(lines))
+		for _, line := range lines {
+			n := occurrences[line.Preview]
+			occurrences[line.Preview]++
+			res = append(res, contentMatch{
+				file:        &fm.File,
+				line:        line,
+				fingerprint: matchFingerprint(&fm.File, line, n),
+			})
+		}
+	}
+	return res
+}
+
+// matchFingerprint identifies a matched line by its repository, path, content and
+// occurrence in the file. Unlike the line number, none of these change when lines
+// are added or removed above the matched line.
+func matchFingerprint(file *result.File, line *result.LineMatch, occurrence int) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d\x00%s\x00", file.Repo.ID, file.Path)
+	if line != nil {
+		fmt.Fprintf(h, "%s\x00%d", line.Preview, occurrence)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// toCommitMatches converts content matches into diff matches, one per file, which
+// add the matched lines at the commit that was searched.
+func toCommitMatches(matches []contentMatch) []*result.CommitMatch {
+	var (
+		res    []*result.CommitMatch
+		byFile = make(map[*result.File]int)
+	)
+	for _, m := range matches {
+		i, ok := byFile[m.file]
+		if !ok {
+			i = len(res)
+			byFile[m.file] = i
+			res = append(res, &result.CommitMatch{
+				Commit: gitdomain.Commit{ID: m.file.CommitID},
+				Repo:   m.file.Repo,
+				DiffPreview: &result.MatchedString{
+					Content: m.file.Path + " " + m.file.Path + "\n",
+				},
+			})
+		}
+		if m.line != nil {
+			appendLine(res[i].DiffPreview, m.line)
+		}
+	}
+	return res
+}
+
+// appendLine appends a hunk adding the matched line to the diff preview.
+func appendLine(diff *result.MatchedString, line *result.LineMatch) {
+	lineNumber := int(line.LineNumber) + 1
+	diff.Content += fmt.Sprintf("@@ -%d,0 +%d,1 @@\n", lineNumber, lineNumber)
+
+	start := result.Location{
+		Offset: len(diff.Content),
+		Line:   strings.Count(diff.Content, "\n"),
+	}
+	diff.Content += "+" + line.Preview + "\n"
+
+	runes := []rune(line.Preview)
+	for _, ol := range line.OffsetAndLengths {
+		startColumn, endColumn := int(ol[0]), int(ol[0]+ol[1])
+		if endColumn > len(runes) {
+			endColumn = len(runes)
+		}
+		if startColumn > endColumn {
+			continue
+		}
+		// The "+" prefix of the line shifts all offsets and columns by one.
+		startOffset := 1 + len(string(runes[:startColumn]))
+		endOffset := 1 + len(string(runes[:endColumn]))
+		diff.MatchedRanges = append(diff.MatchedRanges, result.Range{
+			Start: start.Add(result.Location{Offset: startOffset, Column: startColumn + 1}),
+			End:   start.Add(result.Location{Offset: endOffset, Column: endColumn + 1}),
+		})
+	}
+}
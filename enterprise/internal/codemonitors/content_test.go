@@ -0,0 +1,210 @@
+package codemonitors
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestIsCommitSearch(t *testing.T) {
+	require.True(t, isCommitSearch(&commit.SearchJob{}))
+	require.True(t, isCommitSearch(jobutil.NewTimeoutJob(0, jobutil.NewLimitJob(1000, &commit.SearchJob{}))))
+	require.True(t, isCommitSearch(jobutil.NewParallelJob(&jobutil.RepoSearchJob{}, &commit.SearchJob{})))
+	require.False(t, isCommitSearch(&jobutil.RepoSearchJob{}))
+	require.False(t, isCommitSearch(jobutil.NewTimeoutJob(0, jobutil.NewLimitJob(1000, &jobutil.RepoSearchJob{}))))
+}
+
+func fileMatch(path string, chunks ...result.ChunkMatch) *result.FileMatch {
+	return &result.FileMatch{
+		File: result.File{
+			Repo:     types.MinimalRepo{ID: 1, Name: "github.com/test/test"},
+			CommitID: api.CommitID("deadbeef"),
+			Path:     path,
+		},
+		ChunkMatches: chunks,
+	}
+}
+
+// chunk returns a chunk match of a single line, matching the given rune range of
+// the line.
+func chunk(line int, content string, start, end int) result.ChunkMatch {
+	return result.ChunkMatch{
+		Content:      content,
+		ContentStart: result.Location{Line: line},
+		Ranges: result.Ranges{{
+			Start: result.Location{Line: line, Column: start},
+			End:   result.Location{Line: line, Column: end},
+		}},
+	}
+}
+
+func fingerprints(matches []contentMatch) []string {
+	res := make([]string, 0, len(matches))
+	for _, m := range matches {
+		res = append(res, m.fingerprint)
+	}
+	return res
+}
+
+func TestContentMatches(t *testing.T) {
+	t.Run("fingerprints do not depend on line numbers", func(t *testing.T) {
+		before := contentMatches(result.Matches{fileMatch("a.go", chunk(3, "// TODO(security): fix", 3, 17))})
+		after := contentMatches(result.Matches{fileMatch("a.go", chunk(10, "// TODO(security): fix", 3, 17))})
+		require.Len(t, before, 1)
+		require.Equal(t, fingerprints(before), fingerprints(after))
+	})
+
+	t.Run("fingerprints depend on path and content", func(t *testing.T) {
+		matches := contentMatches(result.Matches{
+			fileMatch("a.go", chunk(3, "// TODO(security): fix", 3, 17)),
+			fileMatch("b.go", chunk(3, "// TODO(security): fix", 3, 17)),
+			fileMatch("a.go", chunk(3, "// TODO(security): fix this", 3, 17)),
+		})
+		fps := fingerprints(matches)
+		require.Len(t, fps, 3)
+		require.NotEqual(t, fps[0], fps[1])
+		require.NotEqual(t, fps[0], fps[2])
+	})
+
+	t.Run("identical lines in a file have distinct fingerprints", func(t *testing.T) {
+		matches := contentMatches(result.Matches{fileMatch("a.go",
+			chunk(3, "// TODO(security)", 3, 17),
+			chunk(8, "// TODO(security)", 3, 17),
+		)})
+		fps := fingerprints(matches)
+		require.Len(t, fps, 2)
+		require.NotEqual(t, fps[0], fps[1])
+	})
+
+	t.Run("path matches and lines without ranges", func(t *testing.T) {
+		matches := contentMatches(result.Matches{
+			fileMatch("a.go"),
+			fileMatch("b.go", result.ChunkMatch{
+				Content:      "context\n// TODO(security)",
+				ContentStart: result.Location{Line: 2},
+				Ranges: result.Ranges{{
+					Start: result.Location{Line: 3, Column: 3},
+					End:   result.Location{Line: 3, Column: 17},
+				}},
+			}),
+			&result.RepoMatch{Name: "github.com/test/test", ID: 1},
+		})
+		require.Len(t, matches, 2)
+		require.Nil(t, matches[0].line)
+		require.Equal(t, "// TODO(security)", matches[1].line.Preview)
+	})
+}
+
+func TestToCommitMatches(t *testing.T) {
+	matches := contentMatches(result.Matches{
+		fileMatch("a.go",
+			chunk(3, "// TODO(security): fix", 3, 17),
+			chunk(8, "// TODO(security): ünïcode", 3, 17),
+		),
+		fileMatch("b.go"),
+	})
+
+	cms := toCommitMatches(matches)
+	require.Len(t, cms, 2)
+
+	a := cms[0]
+	require.Equal(t, api.CommitID("deadbeef"), a.Commit.ID)
+	require.Equal(t, api.RepoName("github.com/test/test"), a.Repo.Name)
+	require.Equal(t, "a.go a.go\n@@ -4,0 +4,1 @@\n+// TODO(security): fix\n@@ -9,0 +9,1 @@\n+// TODO(security): ünïcode\n", a.DiffPreview.Content)
+	require.Len(t, a.DiffPreview.MatchedRanges, 2)
+	for _, r := range a.DiffPreview.MatchedRanges {
+		require.Equal(t, "TODO(security)", a.DiffPreview.Content[r.Start.Offset:r.End.Offset])
+		require.Equal(t, r.Start.Line, r.End.Line)
+		require.Equal(t, 4, r.Start.Column)
+		require.Equal(t, 18, r.End.Column)
+	}
+	require.Equal(t, 2, a.DiffPreview.MatchedRanges[0].Start.Line)
+	require.Equal(t, 4, a.DiffPreview.MatchedRanges[1].Start.Line)
+
+	b := cms[1]
+	require.Equal(t, "b.go b.go\n", b.DiffPreview.Content)
+	require.Empty(t, b.DiffPreview.MatchedRanges)
+}
+
+func TestSearchContent(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	u, err := db.Users().Create(ctx, database.NewUser{Email: "test", Username: "test", EmailVerificationCode: "test"})
+	require.NoError(t, err)
+	ctx = actor.WithActor(ctx, actor.FromUser(u.ID))
+	m, err := edb.NewEnterpriseDB(db).CodeMonitors().CreateMonitor(ctx, edb.MonitorArgs{NamespaceUserID: &u.ID})
+	require.NoError(t, err)
+
+	var results result.Matches
+	j := mockjob.NewMockJob()
+	j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: results})
+		return nil, nil
+	})
+
+	// Matches that exist when the monitor is created do not trigger it.
+	results = result.Matches{fileMatch("a.go", chunk(3, "// TODO(security): fix", 3, 17))}
+	err = snapshotContent(ctx, db, job.RuntimeClients{}, j, m.ID)
+	require.NoError(t, err)
+
+	cms, fingerprints, err := searchContent(ctx, db, job.RuntimeClients{}, j, m.ID)
+	require.NoError(t, err)
+	require.Empty(t, cms)
+	require.Len(t, fingerprints, 1)
+
+	// A new line triggers the monitor, the old line does not, even though it moved.
+	results = result.Matches{fileMatch("a.go",
+		chunk(5, "// TODO(security): fix", 3, 17),
+		chunk(9, "// TODO(security): new", 3, 17),
+	)}
+	cms, fingerprints, err = searchContent(ctx, db, job.RuntimeClients{}, j, m.ID)
+	require.NoError(t, err)
+	require.Len(t, cms, 1)
+	require.Equal(t, "a.go a.go\n@@ -10,0 +10,1 @@\n+// TODO(security): new\n", cms[0].DiffPreview.Content)
+
+	// The new line triggers the monitor until its fingerprint is recorded.
+	cms, _, err = searchContent(ctx, db, job.RuntimeClients{}, j, m.ID)
+	require.NoError(t, err)
+	require.Len(t, cms, 1)
+
+	err = edb.NewEnterpriseDB(db).CodeMonitors().UpsertMatchFingerprints(ctx, m.ID, fingerprints)
+	require.NoError(t, err)
+	cms, _, err = searchContent(ctx, db, job.RuntimeClients{}, j, m.ID)
+	require.NoError(t, err)
+	require.Empty(t, cms)
+}
+
+func TestRunContentSearchLimitHit(t *testing.T) {
+	j := mockjob.NewMockJob()
+	j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{
+			Results: result.Matches{fileMatch("a.go", chunk(3, "// TODO(security): fix", 3, 17))},
+			Stats:   streaming.Stats{IsLimitHit: true},
+		})
+		return nil, nil
+	})
+
+	_, err := runContentSearch(context.Background(), job.RuntimeClients{}, j)
+	require.ErrorIs(t, err, errContentSearchLimitHit)
+}
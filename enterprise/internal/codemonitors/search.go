@@ -24,7 +24,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func Search(ctx context.Context, logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJobs, query string, monitorID int64) (_ []*result.CommitMatch, err error) {
+// Search runs the search of a code monitor and returns the new matches. For
+// content searches, it also returns the fingerprints of all matches, which the
+// caller must record with UpsertMatchFingerprints once the actions of the
+// monitor are enqueued.
+func Search(ctx context.Context, logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJobs, query string, monitorID int64) (_ []*result.CommitMatch, fingerprints []string, err error) {
 	searchClient := client.New(logger, db, enterpriseJobs)
 	inputs, err := searchClient.Plan(
 		ctx,
@@ -35,14 +39,26 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, enterpriseJo
 		search.Streaming,
 	)
 	if err != nil {
-		return nil, errcode.MakeNonRetryable(err)
+		return nil, nil, errcode.MakeNonRetryable(err)
 	}
 
 	// Inline job creation so we can mutate the commit job before running it
 	clients := searchClient.JobClients()
 	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan, enterpriseJobs)
 	if err != nil {
-		return nil, errcode.MakeNonRetryable(err)
+		return nil, nil, errcode.MakeNonRetryable(err)
+	}
+
+	if !isCommitSearch(planJob) {
+		planJob, err = planContentSearch(inputs, enterpriseJobs)
+		if err != nil {
+			return nil, nil, errcode.MakeNonRetryable(err)
+		}
+		matches, fingerprints, err := searchContent(ctx, db, clients, planJob, monitorID)
+		if errors.Is(err, errContentSearchLimitHit) {
+			err = errcode.MakeNonRetryable(err)
+		}
+		return matches, fingerprints, err
 	}
 
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, doSearch commit.DoSearchFunc) error {
@@ -50,31 +66,32 @@ func Search(ctx context.Context, logger log.Logger, db database.DB, enterpriseJo
 	}
 	planJob, err = addCodeMonitorHook(planJob, hook)
 	if err != nil {
-		return nil, errcode.MakeNonRetryable(err)
+		return nil, nil, errcode.MakeNonRetryable(err)
 	}
 
 	// Execute the search
 	agg := streaming.NewAggregatingStream()
 	_, err = planJob.Run(ctx, clients, agg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	results := make([]*result.CommitMatch, len(agg.Results))
 	for i, res := range agg.Results {
 		cm, ok := res.(*result.CommitMatch)
 		if !ok {
-			return nil, errors.Errorf("expected search to only return commit matches, but got type %T", res)
+			return nil, nil, errors.Errorf("expected search to only return commit matches, but got type %T", res)
 		}
 		results[i] = cm
 	}
 
-	return results, nil
+	return results, nil, nil
 }
 
 // Snapshot runs a dummy search that just saves the current state of the searched repos in the database.
 // On subsequent runs, this allows us to treat all new repos or sets of args as something new that should
-// be searched from the beginning.
+// be searched from the beginning. For content searches, the matches that are currently found are saved
+// instead.
 func Snapshot(ctx context.Context, logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJobs, query string, monitorID int64) error {
 	searchClient := client.New(logger, db, enterpriseJobs)
 	inputs, err := searchClient.Plan(
@@ -95,6 +112,14 @@ func Snapshot(ctx context.Context, logger log.Logger, db database.DB, enterprise
 		return err
 	}
 
+	if !isCommitSearch(planJob) {
+		planJob, err = planContentSearch(inputs, enterpriseJobs)
+		if err != nil {
+			return err
+		}
+		return snapshotContent(ctx, db, clients, planJob, monitorID)
+	}
+
 	hook := func(ctx context.Context, db database.DB, gs commit.GitserverClient, args *gitprotocol.SearchRequest, repoID api.RepoID, _ commit.DoSearchFunc) error {
 		return snapshotHook(ctx, db, gs, args, monitorID, repoID)
 	}
@@ -147,7 +172,7 @@ func addCodeMonitorHook(in job.Job, hook commit.CodeMonitorHook) (_ job.Job, err
 		default:
 			if len(j.Children()) == 0 {
 				if err == nil {
-					err = errors.New("all branches of query must be of type:diff or type:commit. If you have an AND/OR operator in your query, ensure that both sides have type:commit or type:diff.")
+					err = errors.New("all branches of query must be of type:diff or type:commit, or none of them. If you have an AND/OR operator in your query, ensure that both sides have type:commit or type:diff.")
 				}
 			}
 			return j
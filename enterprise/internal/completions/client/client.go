@@ -3,22 +3,34 @@ package client
 import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/completions/client/anthropic"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/completions/client/codygateway"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/completions/client/custom"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/completions/client/openai"
 	"github.com/sourcegraph/sourcegraph/internal/completions/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
-func Get(endpoint string, provider conftypes.CompletionsProviderName, accessToken string) (types.CompletionsClient, error) {
-	client, err := getBasic(endpoint, provider, accessToken)
+func Get(config *conftypes.CompletionsConfig) (types.CompletionsClient, error) {
+	if len(config.Fallbacks) > 0 {
+		client, err := newFailoverClient(config, func(b conftypes.CompletionsBackend) (types.CompletionsClient, error) {
+			return getBasic(b.Endpoint, b.Provider, b.AccessToken, b.Custom)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return newObservedClient(client, string(config.Provider)), nil
+	}
+
+	client, err := getBasic(config.Endpoint, config.Provider, config.AccessToken, config.Custom)
 	if err != nil {
 		return nil, err
 	}
-	return newObservedClient(client), nil
+	return newObservedClient(client, string(config.Provider)), nil
 }
 
-func getBasic(endpoint string, provider conftypes.CompletionsProviderName, accessToken string) (types.CompletionsClient, error) {
+func getBasic(endpoint string, provider conftypes.CompletionsProviderName, accessToken string, customConfig *schema.CustomCompletionsProvider) (types.CompletionsClient, error) {
 	switch provider {
 	case conftypes.CompletionsProviderNameAnthropic:
 		return anthropic.NewClient(httpcli.ExternalDoer, endpoint, accessToken), nil
@@ -26,6 +38,8 @@ func getBasic(endpoint string, provider conftypes.CompletionsProviderName, acces
 		return openai.NewClient(httpcli.ExternalDoer, endpoint, accessToken), nil
 	case conftypes.CompletionsProviderNameSourcegraph:
 		return codygateway.NewClient(httpcli.ExternalDoer, endpoint, accessToken)
+	case conftypes.CompletionsProviderNameCustom:
+		return custom.NewClient(httpcli.ExternalDoer, endpoint, accessToken, customConfig)
 	default:
 		return nil, errors.Newf("unknown completion stream provider: %s", provider)
 	}
@@ -0,0 +1,221 @@
+// Package custom implements a completions client for self-hosted model servers.
+// The request body and the location of the completion in responses are
+// configurable, so that any HTTP endpoint that streams completions as
+// server-sent events or newline-delimited JSON can be used.
+package custom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// maxResponseSize bounds the size of non-streaming responses.
+const maxResponseSize = 10 * 1024 * 1024 // 10mb
+
+func NewClient(cli httpcli.Doer, endpoint, accessToken string, config *schema.CustomCompletionsProvider) (types.CompletionsClient, error) {
+	config = withDefaults(config)
+
+	tmpl, err := parseRequestTemplate(config.RequestTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var limits *tokenLimits
+	if config.ModelsEndpoint != "" {
+		limits = getTokenLimits(cli, config, accessToken)
+	}
+
+	return &customClient{
+		cli:         cli,
+		endpoint:    endpoint,
+		accessToken: accessToken,
+		config:      config,
+		tmpl:        tmpl,
+		limits:      limits,
+	}, nil
+}
+
+type customClient struct {
+	cli         httpcli.Doer
+	endpoint    string
+	accessToken string
+	config      *schema.CustomCompletionsProvider
+	tmpl        *requestTemplate
+	limits      *tokenLimits
+}
+
+func (c *customClient) Complete(
+	ctx context.Context,
+	feature types.CompletionsFeature,
+	requestParams types.CompletionRequestParameters,
+) (*types.CompletionResponse, error) {
+	resp, err := c.makeRequest(ctx, requestParams, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	var response any
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode response")
+	}
+
+	return &types.CompletionResponse{
+		Completion: lookupString(response, c.config.CompletionPath),
+		StopReason: lookupString(response, c.config.StopReasonPath),
+	}, nil
+}
+
+func (c *customClient) Stream(
+	ctx context.Context,
+	feature types.CompletionsFeature,
+	requestParams types.CompletionRequestParameters,
+	sendEvent types.SendCompletionEvent,
+) error {
+	resp, err := c.makeRequest(ctx, requestParams, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	dec := newDecoder(resp.Body, c.config.StreamFormat, c.config.StreamDoneSentinel)
+	var content string
+	for dec.Scan() {
+		if ctx.Err() != nil && ctx.Err() == context.Canceled {
+			return nil
+		}
+
+		data := dec.Data()
+		// Gracefully skip over any data that isn't JSON-like.
+		if !bytes.HasPrefix(data, []byte("{")) {
+			continue
+		}
+
+		var event any
+		if err := json.Unmarshal(data, &event); err != nil {
+			return errors.Errorf("failed to decode event payload: %w - body: %s", err, string(data))
+		}
+
+		completion := lookupString(event, c.config.StreamCompletionPath)
+		if c.config.StreamCumulative {
+			content = completion
+		} else {
+			content += completion
+		}
+
+		err = sendEvent(types.CompletionResponse{
+			Completion: content,
+			StopReason: lookupString(event, c.config.StopReasonPath),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return dec.Err()
+}
+
+func (c *customClient) makeRequest(ctx context.Context, requestParams types.CompletionRequestParameters, stream bool) (*http.Response, error) {
+	data, err := newTemplateData(requestParams, stream)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.limits != nil {
+		limit, err := c.limits.get(ctx, requestParams.Model)
+		if err != nil {
+			// Discovery is best-effort: the server will reject requests that
+			// exceed its limits anyway.
+			limit = 0
+		}
+		data.ModelMaxTokens = limit
+		if limit > 0 && data.MaxTokens > limit {
+			data.MaxTokens = limit
+		}
+	}
+
+	reqBody, err := c.tmpl.render(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	setHeaders(req, c.config, c.accessToken)
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, types.NewErrStatusNotOK("Custom", resp)
+	}
+
+	return resp, nil
+}
+
+func setHeaders(req *http.Request, config *schema.CustomCompletionsProvider, accessToken string) {
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	for name, value := range config.Headers {
+		req.Header.Set(name, value)
+	}
+}
+
+// withDefaults returns a copy of the given configuration with unset fields
+// defaulting to the OpenAI chat completions protocol.
+func withDefaults(config *schema.CustomCompletionsProvider) *schema.CustomCompletionsProvider {
+	var c schema.CustomCompletionsProvider
+	if config != nil {
+		c = *config
+	}
+
+	if c.RequestTemplate == "" {
+		c.RequestTemplate = defaultRequestTemplate
+	}
+	if c.CompletionPath == "" {
+		c.CompletionPath = "choices.0.message.content"
+	}
+	if c.StreamCompletionPath == "" {
+		c.StreamCompletionPath = "choices.0.delta.content"
+	}
+	if c.StopReasonPath == "" {
+		c.StopReasonPath = "choices.0.finish_reason"
+	}
+	if c.StreamFormat == "" {
+		c.StreamFormat = streamFormatSSE
+	}
+	if c.StreamDoneSentinel == "" {
+		c.StreamDoneSentinel = "[DONE]"
+	}
+	if c.ModelsPath == "" {
+		c.ModelsPath = "data"
+	}
+	if c.ModelIDField == "" {
+		c.ModelIDField = "id"
+	}
+	if c.TokenLimitField == "" {
+		c.TokenLimitField = "max_model_len"
+	}
+
+	return &c
+}
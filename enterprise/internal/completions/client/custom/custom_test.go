@@ -0,0 +1,212 @@
+package custom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// fakeModelServer records the requests it receives and replies with the
+// configured body.
+type fakeModelServer struct {
+	*httptest.Server
+	requests    []map[string]any
+	headers     []http.Header
+	modelsCalls int
+}
+
+func newFakeModelServer(t *testing.T, body string) *fakeModelServer {
+	s := &fakeModelServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/models" {
+			s.modelsCalls++
+			fmt.Fprint(w, `{"object": "list", "data": [{"id": "llama", "max_model_len": 512}, {"id": "other"}]}`)
+			return
+		}
+
+		var req map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		s.requests = append(s.requests, req)
+		s.headers = append(s.headers, r.Header.Clone())
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newTestClient(t *testing.T, server *fakeModelServer, config *schema.CustomCompletionsProvider) types.CompletionsClient {
+	client, err := NewClient(http.DefaultClient, server.URL+"/v1/chat/completions", "secret", config)
+	require.NoError(t, err)
+	return client
+}
+
+var testParams = types.CompletionRequestParameters{
+	Model: "llama",
+	Messages: []types.Message{
+		{Speaker: types.HUMAN_MESSAGE_SPEAKER, Text: "Write a haiku"},
+		{Speaker: types.ASISSTANT_MESSAGE_SPEAKER, Text: ""},
+	},
+	MaxTokensToSample: 1000,
+	Temperature:       0.2,
+	StopSequences:     []string{"\n\nHuman:"},
+}
+
+func collectStream(t *testing.T, client types.CompletionsClient) []types.CompletionResponse {
+	var events []types.CompletionResponse
+	err := client.Stream(context.Background(), types.CompletionsFeatureChat, testParams, func(event types.CompletionResponse) error {
+		events = append(events, event)
+		return nil
+	})
+	require.NoError(t, err)
+	return events
+}
+
+func TestCompleteDefaults(t *testing.T) {
+	server := newFakeModelServer(t, `{"choices": [{"message": {"role": "assistant", "content": "Autumn moonlight"}, "finish_reason": "stop"}]}`)
+	client := newTestClient(t, server, nil)
+
+	resp, err := client.Complete(context.Background(), types.CompletionsFeatureChat, testParams)
+	require.NoError(t, err)
+	assert.Equal(t, &types.CompletionResponse{Completion: "Autumn moonlight", StopReason: "stop"}, resp)
+
+	require.Len(t, server.requests, 1)
+	assert.Equal(t, map[string]any{
+		"model": "llama",
+		"messages": []any{
+			map[string]any{"role": "user", "content": "Write a haiku"},
+			map[string]any{"role": "assistant", "content": ""},
+		},
+		"max_tokens":  float64(1000),
+		"temperature": 0.2,
+		"stop":        []any{"\n\nHuman:"},
+		"stream":      false,
+	}, server.requests[0])
+	assert.Equal(t, "Bearer secret", server.headers[0].Get("Authorization"))
+	assert.Equal(t, "application/json", server.headers[0].Get("Content-Type"))
+}
+
+func TestStreamSSE(t *testing.T) {
+	server := newFakeModelServer(t, strings.Join([]string{
+		": keep-alive",
+		"",
+		"event: completion\r\ndata: {\"choices\": [{\"delta\": {\"content\": \"Autumn\"}}]}",
+		"",
+		"data: not json",
+		"",
+		"data: {\"choices\": [{\"delta\": {\"content\": \" moonlight\"}, \"finish_reason\": \"stop\"}]}",
+		"",
+		"data: [DONE]",
+		"",
+		"data: {\"choices\": [{\"delta\": {\"content\": \"ignored\"}}]}",
+		"",
+	}, "\n"))
+	client := newTestClient(t, server, nil)
+
+	events := collectStream(t, client)
+	assert.Equal(t, []types.CompletionResponse{
+		{Completion: "Autumn"},
+		{Completion: "Autumn moonlight", StopReason: "stop"},
+	}, events)
+	assert.Equal(t, true, server.requests[0]["stream"])
+}
+
+func TestStreamNDJSONCumulative(t *testing.T) {
+	server := newFakeModelServer(t, strings.Join([]string{
+		`{"generated_text": "Autumn", "done": false}`,
+		`{"generated_text": "Autumn moonlight", "done": false}`,
+		`{"generated_text": "Autumn moonlight", "done": true, "reason": "eos"}`,
+	}, "\n"))
+	client := newTestClient(t, server, &schema.CustomCompletionsProvider{
+		RequestTemplate:      `{"inputs": {{json .Prompt}}, "parameters": {"max_new_tokens": {{.MaxTokens}}}, "stream": {{.Stream}}}`,
+		StreamFormat:         "ndjson",
+		StreamCumulative:     true,
+		StreamCompletionPath: "generated_text",
+		StopReasonPath:       "reason",
+		Headers:              map[string]string{"Authorization": "Token other", "X-Tenant": "cody"},
+	})
+
+	events := collectStream(t, client)
+	assert.Equal(t, []types.CompletionResponse{
+		{Completion: "Autumn"},
+		{Completion: "Autumn moonlight"},
+		{Completion: "Autumn moonlight", StopReason: "eos"},
+	}, events)
+
+	assert.Equal(t, map[string]any{
+		"inputs":     "\n\nHuman: Write a haiku\n\nAssistant:",
+		"parameters": map[string]any{"max_new_tokens": float64(1000)},
+		"stream":     true,
+	}, server.requests[0])
+	assert.Equal(t, "Token other", server.headers[0].Get("Authorization"))
+	assert.Equal(t, "cody", server.headers[0].Get("X-Tenant"))
+}
+
+func TestTokenLimitDiscovery(t *testing.T) {
+	server := newFakeModelServer(t, `{"choices": [{"message": {"content": "ok"}}]}`)
+	config := &schema.CustomCompletionsProvider{ModelsEndpoint: server.URL + "/v1/models"}
+
+	for _, model := range []string{"llama", "other", "llama"} {
+		// Create a new client for every request, like the completions handlers do.
+		client := newTestClient(t, server, config)
+		params := testParams
+		params.Model = model
+		_, err := client.Complete(context.Background(), types.CompletionsFeatureChat, params)
+		require.NoError(t, err)
+	}
+
+	// The limit of llama is applied, other has no known limit.
+	assert.Equal(t, float64(512), server.requests[0]["max_tokens"])
+	assert.Equal(t, float64(1000), server.requests[1]["max_tokens"])
+	assert.Equal(t, float64(512), server.requests[2]["max_tokens"])
+	assert.Equal(t, 1, server.modelsCalls, "limits should be cached")
+
+	// Limits are refreshed once they expire.
+	limits := getTokenLimits(http.DefaultClient, withDefaults(config), "secret")
+	limits.now = func() time.Time { return time.Now().Add(2 * tokenLimitsTTL) }
+	limit, err := limits.get(context.Background(), "llama")
+	require.NoError(t, err)
+	assert.Equal(t, 512, limit)
+	assert.Equal(t, 2, server.modelsCalls)
+}
+
+func TestErrors(t *testing.T) {
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := NewClient(http.DefaultClient, "", "", &schema.CustomCompletionsProvider{RequestTemplate: "{{.Model"})
+		require.Error(t, err)
+	})
+
+	t.Run("template renders invalid JSON", func(t *testing.T) {
+		server := newFakeModelServer(t, `{}`)
+		client := newTestClient(t, server, &schema.CustomCompletionsProvider{RequestTemplate: `{"prompt": {{.Prompt}}}`})
+		_, err := client.Complete(context.Background(), types.CompletionsFeatureChat, testParams)
+		require.ErrorContains(t, err, "invalid JSON")
+		assert.Empty(t, server.requests)
+	})
+
+	t.Run("status not OK", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = io.WriteString(w, "model is loading")
+		}))
+		t.Cleanup(server.Close)
+		client, err := NewClient(http.DefaultClient, server.URL, "", nil)
+		require.NoError(t, err)
+
+		_, err = client.Complete(context.Background(), types.CompletionsFeatureChat, testParams)
+		statusErr, ok := types.IsErrStatusNotOK(err)
+		require.True(t, ok)
+		assert.Contains(t, statusErr.Error(), "model is loading")
+	})
+}
@@ -0,0 +1,111 @@
+package custom
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+)
+
+const maxPayloadSize = 10 * 1024 * 1024 // 10mb
+
+const (
+	streamFormatSSE    = "sse"
+	streamFormatNDJSON = "ndjson"
+)
+
+// decoder decodes the events of a streamed completion. Server-sent events are
+// decoded leniently: only the data field is considered, comments and other
+// fields such as event or id are ignored, and multi-line data is joined with
+// newlines. For newline-delimited JSON every non-empty line is an event.
+//
+// Adapted from enterprise/internal/completions/client/openai/decoder.go.
+type decoder struct {
+	scanner *bufio.Scanner
+	sse     bool
+	done    []byte
+	data    []byte
+	err     error
+}
+
+func newDecoder(r io.Reader, format, doneSentinel string) *decoder {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxPayloadSize)
+	sse := format != streamFormatNDJSON
+	if sse {
+		// bufio.ScanLines, except we look for two newlines which separate events.
+		scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+			if atEOF && len(data) == 0 {
+				return 0, nil, nil
+			}
+			if i, n := eventBoundary(data); i >= 0 {
+				return i + n, data[:i], nil
+			}
+			// If we're at EOF, we have a final, non-terminated event.
+			if atEOF {
+				return len(data), data, nil
+			}
+			// Request more data.
+			return 0, nil, nil
+		})
+	}
+	return &decoder{
+		scanner: scanner,
+		sse:     sse,
+		done:    []byte(doneSentinel),
+	}
+}
+
+// eventBoundary returns the index and length of the first blank line in data,
+// accepting both \n and \r\n line endings.
+func eventBoundary(data []byte) (int, int) {
+	index, length := -1, 0
+	for _, sep := range [][]byte{[]byte("\r\n\r\n"), []byte("\n\n")} {
+		if i := bytes.Index(data, sep); i >= 0 && (index < 0 || i < index) {
+			index, length = i, len(sep)
+		}
+	}
+	return index, length
+}
+
+// Scan advances the decoder to the next event in the stream. It returns
+// false when it either hits the end of the stream or an error.
+func (d *decoder) Scan() bool {
+	for d.scanner.Scan() {
+		data := bytes.TrimSpace(d.scanner.Bytes())
+		if d.sse {
+			data = sseData(data)
+		}
+		if len(data) == 0 {
+			continue
+		}
+		if d.sse && bytes.Equal(data, d.done) {
+			return false
+		}
+		d.data = data
+		return true
+	}
+
+	d.err = d.scanner.Err()
+	return false
+}
+
+// Data returns the data of the last decoded event.
+func (d *decoder) Data() []byte {
+	return d.data
+}
+
+// Err returns the last encountered error.
+func (d *decoder) Err() error {
+	return d.err
+}
+
+func sseData(event []byte) []byte {
+	var data [][]byte
+	for _, line := range bytes.Split(event, []byte("\n")) {
+		field, value, _ := bytes.Cut(bytes.TrimRight(line, "\r"), []byte(":"))
+		if string(field) == "data" {
+			data = append(data, bytes.TrimPrefix(value, []byte(" ")))
+		}
+	}
+	return bytes.Join(data, []byte("\n"))
+}
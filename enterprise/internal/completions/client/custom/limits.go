@@ -0,0 +1,134 @@
+package custom
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// tokenLimitsTTL is how long discovered token limits are cached. Self-hosted
+// model servers rarely change the models they serve, but they do get
+// redeployed.
+const tokenLimitsTTL = 10 * time.Minute
+
+// tokenLimits discovers the token limits of the models served by a model
+// server by listing its models.
+type tokenLimits struct {
+	cli         httpcli.Doer
+	config      *schema.CustomCompletionsProvider
+	accessToken string
+
+	mu        sync.Mutex
+	limits    map[string]int
+	fetchedAt time.Time
+	now       func() time.Time
+}
+
+type tokenLimitsKey struct {
+	modelsEndpoint, modelsPath, modelIDField, tokenLimitField, accessToken string
+}
+
+var (
+	tokenLimitsMu    sync.Mutex
+	tokenLimitsCache = map[tokenLimitsKey]*tokenLimits{}
+)
+
+// getTokenLimits returns the token limits for the given configuration. Clients
+// are constructed for every completions request, so the discovered limits are
+// shared between all clients with the same configuration.
+func getTokenLimits(cli httpcli.Doer, config *schema.CustomCompletionsProvider, accessToken string) *tokenLimits {
+	key := tokenLimitsKey{
+		modelsEndpoint:  config.ModelsEndpoint,
+		modelsPath:      config.ModelsPath,
+		modelIDField:    config.ModelIDField,
+		tokenLimitField: config.TokenLimitField,
+		accessToken:     accessToken,
+	}
+
+	tokenLimitsMu.Lock()
+	defer tokenLimitsMu.Unlock()
+
+	if l, ok := tokenLimitsCache[key]; ok {
+		return l
+	}
+	l := newTokenLimits(cli, config, accessToken)
+	tokenLimitsCache[key] = l
+	return l
+}
+
+func newTokenLimits(cli httpcli.Doer, config *schema.CustomCompletionsProvider, accessToken string) *tokenLimits {
+	return &tokenLimits{
+		cli:         cli,
+		config:      config,
+		accessToken: accessToken,
+		now:         time.Now,
+	}
+}
+
+// get returns the token limit of the given model, or 0 if the model server
+// does not report one.
+func (l *tokenLimits) get(ctx context.Context, model string) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.limits == nil || l.now().Sub(l.fetchedAt) > tokenLimitsTTL {
+		limits, err := l.fetch(ctx)
+		// Remember failures too, so that an unavailable models endpoint doesn't
+		// add a round trip to every request.
+		l.limits = limits
+		l.fetchedAt = l.now()
+		if err != nil {
+			l.limits = map[string]int{}
+			return 0, err
+		}
+	}
+
+	return l.limits[model], nil
+}
+
+func (l *tokenLimits) fetch(ctx context.Context) (map[string]int, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", l.config.ModelsEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	setHeaders(req, l.config, l.accessToken)
+
+	resp, err := l.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, types.NewErrStatusNotOK("Custom", resp)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	var response any
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode models response")
+	}
+
+	models, _ := lookup(response, l.config.ModelsPath).([]any)
+	limits := make(map[string]int, len(models))
+	for _, model := range models {
+		id, _ := lookup(model, l.config.ModelIDField).(string)
+		// JSON numbers are decoded as float64.
+		limit, _ := lookup(model, l.config.TokenLimitField).(float64)
+		if id != "" && limit > 0 {
+			limits[id] = int(limit)
+		}
+	}
+	return limits, nil
+}
@@ -0,0 +1,168 @@
+package custom
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// defaultRequestTemplate renders an OpenAI chat completions request.
+const defaultRequestTemplate = `{
+	"model": {{json .Model}},
+	"messages": [{{range $i, $m := .Messages}}{{if $i}}, {{end}}{"role": {{json $m.Role}}, "content": {{json $m.Text}}}{{end}}],
+	{{- if .MaxTokens}}
+	"max_tokens": {{.MaxTokens}},
+	{{- end}}
+	{{- if .Temperature}}
+	"temperature": {{.Temperature}},
+	{{- end}}
+	{{- if .TopP}}
+	"top_p": {{.TopP}},
+	{{- end}}
+	{{- if .StopSequences}}
+	"stop": {{json .StopSequences}},
+	{{- end}}
+	"stream": {{.Stream}}
+}`
+
+const (
+	humanPrompt     = "\n\nHuman:"
+	assistantPrompt = "\n\nAssistant:"
+)
+
+// templateData is the data that request templates are executed with.
+type templateData struct {
+	Model    string
+	Messages []templateMessage
+	// Prompt is the conversation rendered as a single Anthropic-style
+	// "Human: ... Assistant:" prompt, for servers that only support plain text
+	// completion.
+	Prompt        string
+	MaxTokens     int
+	Temperature   float32
+	TopK          int
+	TopP          float32
+	StopSequences []string
+	Stream        bool
+	// ModelMaxTokens is the discovered token limit of the model, or 0 if
+	// unknown.
+	ModelMaxTokens int
+}
+
+type templateMessage struct {
+	// Speaker is either "human" or "assistant".
+	Speaker string
+	// Role is the OpenAI role of the speaker, either "user" or "assistant".
+	Role string
+	Text string
+}
+
+func newTemplateData(requestParams types.CompletionRequestParameters, stream bool) (templateData, error) {
+	data := templateData{
+		Model:         requestParams.Model,
+		Prompt:        requestParams.Prompt,
+		MaxTokens:     requestParams.MaxTokensToSample,
+		Temperature:   requestParams.Temperature,
+		TopK:          requestParams.TopK,
+		TopP:          requestParams.TopP,
+		StopSequences: requestParams.StopSequences,
+		Stream:        stream,
+	}
+	if data.TopK < 0 {
+		data.TopK = 0
+	}
+	if data.TopP < 0 {
+		data.TopP = 0
+	}
+
+	var prompt strings.Builder
+	for _, m := range requestParams.Messages {
+		messagePrompt, err := m.GetPrompt(humanPrompt, assistantPrompt)
+		if err != nil {
+			return templateData{}, err
+		}
+		prompt.WriteString(messagePrompt)
+
+		role := "user"
+		if m.Speaker == types.ASISSTANT_MESSAGE_SPEAKER {
+			role = "assistant"
+		}
+		data.Messages = append(data.Messages, templateMessage{
+			Speaker: m.Speaker,
+			Role:    role,
+			Text:    m.Text,
+		})
+	}
+	if data.Prompt == "" {
+		data.Prompt = prompt.String()
+	}
+
+	return data, nil
+}
+
+type requestTemplate struct {
+	tmpl *template.Template
+}
+
+func parseRequestTemplate(text string) (*requestTemplate, error) {
+	tmpl, err := template.New("request").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"json": marshalJSON}).
+		Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid request template")
+	}
+	return &requestTemplate{tmpl: tmpl}, nil
+}
+
+// render executes the template and checks that it produced valid JSON, so that
+// mistakes in the template are reported as such rather than as an opaque error
+// from the model server.
+func (t *requestTemplate) render(data templateData) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.tmpl.Execute(&buf, data); err != nil {
+		return nil, errors.Wrap(err, "failed to render request template")
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, errors.Newf("request template rendered invalid JSON: %s", buf.String())
+	}
+	return buf.Bytes(), nil
+}
+
+func marshalJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// lookup returns the value at the given dot-separated path in a decoded JSON
+// value, e.g. "choices.0.text". It returns nil if the path does not exist.
+func lookup(v any, path string) any {
+	if path == "" {
+		return v
+	}
+	for _, key := range strings.Split(path, ".") {
+		switch node := v.(type) {
+		case map[string]any:
+			v = node[key]
+		case []any:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil
+			}
+			v = node[i]
+		default:
+			return nil
+		}
+	}
+	return v
+}
+
+func lookupString(v any, path string) string {
+	s, _ := lookup(v, path).(string)
+	return s
+}
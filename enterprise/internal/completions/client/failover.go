@@ -0,0 +1,235 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// breakerFailureThreshold is the number of consecutive failures after which a
+	// backend is skipped.
+	breakerFailureThreshold = 3
+	// breakerCooldown is how long a backend is skipped before it is tried again.
+	breakerCooldown = 30 * time.Second
+)
+
+// backend is one of the providers a failoverClient sends requests to.
+type backend struct {
+	name    string
+	client  types.CompletionsClient
+	breaker *circuitBreaker
+
+	chatModel       string
+	fastChatModel   string
+	completionModel string
+}
+
+// failoverClient sends requests to the first of its backends that is available,
+// and fails over to the next one when a backend is rate limited or returns a
+// server error.
+type failoverClient struct {
+	backends   []*backend
+	roundRobin bool
+
+	// Models of the primary backend, which requests are made with.
+	chatModel     string
+	fastChatModel string
+}
+
+var _ types.CompletionsClient = (*failoverClient)(nil)
+
+// roundRobinCounter determines the first backend tried by the next request when
+// routing round-robin. Clients are constructed for every request, so it must be
+// shared between them.
+var roundRobinCounter atomic.Uint64
+
+func newFailoverClient(config *conftypes.CompletionsConfig, getClient func(conftypes.CompletionsBackend) (types.CompletionsClient, error)) (*failoverClient, error) {
+	primary := conftypes.CompletionsBackend{
+		Provider:        config.Provider,
+		Endpoint:        config.Endpoint,
+		AccessToken:     config.AccessToken,
+		ChatModel:       config.ChatModel,
+		FastChatModel:   config.FastChatModel,
+		CompletionModel: config.CompletionModel,
+		Custom:          config.Custom,
+	}
+
+	c := &failoverClient{
+		roundRobin:    config.Routing == conftypes.CompletionsRoutingRoundRobin,
+		chatModel:     config.ChatModel,
+		fastChatModel: config.FastChatModel,
+	}
+	for _, b := range append([]conftypes.CompletionsBackend{primary}, config.Fallbacks...) {
+		client, err := getClient(b)
+		if err != nil {
+			return nil, errors.Wrapf(err, "completions provider %s", b.Provider)
+		}
+		c.backends = append(c.backends, &backend{
+			name:            string(b.Provider),
+			client:          client,
+			breaker:         getCircuitBreaker(b.Provider, b.Endpoint),
+			chatModel:       b.ChatModel,
+			fastChatModel:   b.FastChatModel,
+			completionModel: b.CompletionModel,
+		})
+	}
+	return c, nil
+}
+
+func (c *failoverClient) Stream(ctx context.Context, feature types.CompletionsFeature, params types.CompletionRequestParameters, send types.SendCompletionEvent) error {
+	return c.do(ctx, feature, params, func(b *backend, params types.CompletionRequestParameters) (bool, error) {
+		sent := false
+		err := b.client.Stream(ctx, feature, params, func(event types.CompletionResponse) error {
+			sent = true
+			return send(event)
+		})
+		// Once events have been sent, we can't start over with another backend.
+		return !sent, err
+	})
+}
+
+func (c *failoverClient) Complete(ctx context.Context, feature types.CompletionsFeature, params types.CompletionRequestParameters) (*types.CompletionResponse, error) {
+	var resp *types.CompletionResponse
+	err := c.do(ctx, feature, params, func(b *backend, params types.CompletionRequestParameters) (bool, error) {
+		var err error
+		resp, err = b.client.Complete(ctx, feature, params)
+		return true, err
+	})
+	return resp, err
+}
+
+// do calls the given function with each backend in turn until a backend serves
+// the request, or fails with an error that isn't worth failing over for. If all
+// backends fail, the error of the last one is returned.
+func (c *failoverClient) do(ctx context.Context, feature types.CompletionsFeature, params types.CompletionRequestParameters, call func(*backend, types.CompletionRequestParameters) (retryable bool, _ error)) (err error) {
+	for _, b := range c.order() {
+		p := params
+		if b != c.backends[0] {
+			// Requests are made with the models of the primary backend.
+			p.Model = b.model(feature, params.Model, c.chatModel, c.fastChatModel)
+		}
+
+		var retryable bool
+		retryable, err = call(b, p)
+		if err == nil || !retryable || !shouldFailover(err) {
+			if err == nil {
+				b.breaker.success()
+			}
+			recordBackend(ctx, b.name)
+			return err
+		}
+
+		b.breaker.failure()
+		recordFailover(ctx, b.name, err)
+	}
+	return err
+}
+
+// order returns the backends in the order they should be tried. Backends whose
+// circuit breaker is open are skipped, unless all of them are open.
+func (c *failoverClient) order() []*backend {
+	backends := c.backends
+	if c.roundRobin {
+		start := int(roundRobinCounter.Add(1)-1) % len(backends)
+		backends = append(append([]*backend{}, backends[start:]...), backends[:start]...)
+	}
+
+	available := make([]*backend, 0, len(backends))
+	for _, b := range backends {
+		if b.breaker.allow() {
+			available = append(available, b)
+		}
+	}
+	if len(available) == 0 {
+		// Rather than failing outright, give every backend a chance.
+		return backends
+	}
+	return available
+}
+
+// model returns the model of this backend that corresponds to the model of the
+// primary backend that the request was made with.
+func (b *backend) model(feature types.CompletionsFeature, model, primaryChatModel, primaryFastChatModel string) string {
+	switch {
+	case feature == types.CompletionsFeatureCode:
+		return b.completionModel
+	case model == primaryChatModel:
+		return b.chatModel
+	case model == primaryFastChatModel:
+		return b.fastChatModel
+	default:
+		return b.chatModel
+	}
+}
+
+// shouldFailover returns true if the error indicates that the backend is
+// overloaded or unavailable, so that another backend might serve the request.
+func shouldFailover(err error) bool {
+	statusErr, ok := types.IsErrStatusNotOK(err)
+	if !ok {
+		return false
+	}
+	code := statusErr.StatusCode()
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// circuitBreaker tracks the consecutive failures of a backend. After
+// breakerFailureThreshold failures, the breaker opens and the backend is
+// skipped for breakerCooldown. After that, requests are let through again, and
+// the next failure opens the breaker again.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+	now       func() time.Time
+}
+
+var (
+	circuitBreakersMu sync.Mutex
+	circuitBreakers   = map[string]*circuitBreaker{}
+)
+
+// getCircuitBreaker returns the circuit breaker of the given backend, which is
+// shared between all clients.
+func getCircuitBreaker(provider conftypes.CompletionsProviderName, endpoint string) *circuitBreaker {
+	key := string(provider) + " " + endpoint
+
+	circuitBreakersMu.Lock()
+	defer circuitBreakersMu.Unlock()
+
+	if b, ok := circuitBreakers[key]; ok {
+		return b
+	}
+	b := &circuitBreaker{now: time.Now}
+	circuitBreakers[key] = b
+	return b
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return !b.now().Before(b.openUntil)
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.openUntil = time.Time{}
+}
+
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.failures >= breakerFailureThreshold {
+		b.openUntil = b.now().Add(breakerCooldown)
+	}
+}
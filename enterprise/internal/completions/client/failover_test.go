@@ -0,0 +1,242 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// fakeClient serves completions with a fixed response, or fails with the given
+// status code.
+type fakeClient struct {
+	statusCode int
+	// failAfterEvent makes Stream fail after sending the first event.
+	failAfterEvent bool
+	models         []string
+}
+
+func (c *fakeClient) Stream(_ context.Context, _ types.CompletionsFeature, params types.CompletionRequestParameters, send types.SendCompletionEvent) error {
+	c.models = append(c.models, params.Model)
+	if c.failAfterEvent {
+		if err := send(types.CompletionResponse{Completion: "partial"}); err != nil {
+			return err
+		}
+		return c.err()
+	}
+	if err := c.err(); err != nil {
+		return err
+	}
+	return send(types.CompletionResponse{Completion: params.Model, StopReason: "stop"})
+}
+
+func (c *fakeClient) Complete(_ context.Context, _ types.CompletionsFeature, params types.CompletionRequestParameters) (*types.CompletionResponse, error) {
+	c.models = append(c.models, params.Model)
+	if err := c.err(); err != nil {
+		return nil, err
+	}
+	return &types.CompletionResponse{Completion: params.Model}, nil
+}
+
+func (c *fakeClient) err() error {
+	if c.statusCode == 0 || c.statusCode == http.StatusOK {
+		return nil
+	}
+	return types.NewErrStatusNotOK("Fake", &http.Response{
+		StatusCode: c.statusCode,
+		Body:       io.NopCloser(strings.NewReader("fake error")),
+	})
+}
+
+var testConfig = conftypes.CompletionsConfig{
+	Provider:        conftypes.CompletionsProviderNameAnthropic,
+	Endpoint:        "https://anthropic.test",
+	ChatModel:       "claude-v1",
+	FastChatModel:   "claude-instant-v1",
+	CompletionModel: "claude-instant-v1",
+	Fallbacks: []conftypes.CompletionsBackend{
+		{
+			Provider:        conftypes.CompletionsProviderNameOpenAI,
+			Endpoint:        "https://openai.test",
+			ChatModel:       "gpt-4",
+			FastChatModel:   "gpt-3.5-turbo",
+			CompletionModel: "gpt-3.5-turbo-instruct",
+		},
+		{
+			Provider:        conftypes.CompletionsProviderNameCustom,
+			Endpoint:        "https://custom.test",
+			ChatModel:       "llama",
+			FastChatModel:   "llama",
+			CompletionModel: "llama",
+		},
+	},
+}
+
+// newTestFailoverClient returns a failover client over the given fake clients,
+// whose circuit breakers are not shared with other tests.
+func newTestFailoverClient(t *testing.T, config conftypes.CompletionsConfig, clients map[conftypes.CompletionsProviderName]*fakeClient) *failoverClient {
+	c, err := newFailoverClient(&config, func(b conftypes.CompletionsBackend) (types.CompletionsClient, error) {
+		return clients[b.Provider], nil
+	})
+	require.NoError(t, err)
+	for _, b := range c.backends {
+		b.breaker = &circuitBreaker{now: time.Now}
+	}
+	return c
+}
+
+func withTestRequestInfo(ctx context.Context) (context.Context, *requestInfo) {
+	o := &observedClient{backend: "anthropic"}
+	return o.withRequestInfo(ctx, observation.TestTraceLogger(logtest.NoOp(nil)))
+}
+
+func TestFailoverClient(t *testing.T) {
+	t.Run("primary serves", func(t *testing.T) {
+		anthropic, openai := &fakeClient{}, &fakeClient{}
+		c := newTestFailoverClient(t, testConfig, map[conftypes.CompletionsProviderName]*fakeClient{
+			"anthropic": anthropic, "openai": openai, "custom": {},
+		})
+
+		ctx, info := withTestRequestInfo(context.Background())
+		resp, err := c.Complete(ctx, types.CompletionsFeatureChat, types.CompletionRequestParameters{Model: "claude-v1"})
+		require.NoError(t, err)
+		assert.Equal(t, "claude-v1", resp.Completion)
+		assert.Empty(t, openai.models)
+		assert.Equal(t, "anthropic", info.backend)
+		assert.Equal(t, 0, info.failovers)
+	})
+
+	t.Run("fails over on rate limits and server errors", func(t *testing.T) {
+		anthropic := &fakeClient{statusCode: http.StatusTooManyRequests}
+		openai := &fakeClient{statusCode: http.StatusBadGateway}
+		custom := &fakeClient{}
+		c := newTestFailoverClient(t, testConfig, map[conftypes.CompletionsProviderName]*fakeClient{
+			"anthropic": anthropic, "openai": openai, "custom": custom,
+		})
+
+		ctx, info := withTestRequestInfo(context.Background())
+		var events []types.CompletionResponse
+		err := c.Stream(ctx, types.CompletionsFeatureChat, types.CompletionRequestParameters{Model: "claude-instant-v1"}, func(event types.CompletionResponse) error {
+			events = append(events, event)
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []types.CompletionResponse{{Completion: "llama", StopReason: "stop"}}, events)
+		assert.Equal(t, []string{"claude-instant-v1"}, anthropic.models)
+		assert.Equal(t, []string{"gpt-3.5-turbo"}, openai.models, "fast chat model should be mapped")
+		assert.Equal(t, "custom", info.backend)
+		assert.Equal(t, 2, info.failovers)
+	})
+
+	t.Run("maps code completion model", func(t *testing.T) {
+		anthropic := &fakeClient{statusCode: http.StatusServiceUnavailable}
+		openai := &fakeClient{}
+		c := newTestFailoverClient(t, testConfig, map[conftypes.CompletionsProviderName]*fakeClient{
+			"anthropic": anthropic, "openai": openai, "custom": {},
+		})
+
+		resp, err := c.Complete(context.Background(), types.CompletionsFeatureCode, types.CompletionRequestParameters{Model: "claude-instant-v1"})
+		require.NoError(t, err)
+		assert.Equal(t, "gpt-3.5-turbo-instruct", resp.Completion)
+	})
+
+	t.Run("does not fail over on client errors", func(t *testing.T) {
+		anthropic := &fakeClient{statusCode: http.StatusBadRequest}
+		openai := &fakeClient{}
+		c := newTestFailoverClient(t, testConfig, map[conftypes.CompletionsProviderName]*fakeClient{
+			"anthropic": anthropic, "openai": openai, "custom": {},
+		})
+
+		_, err := c.Complete(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{Model: "claude-v1"})
+		statusErr, ok := types.IsErrStatusNotOK(err)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusBadRequest, statusErr.StatusCode())
+		assert.Empty(t, openai.models)
+	})
+
+	t.Run("does not fail over after streaming started", func(t *testing.T) {
+		anthropic := &fakeClient{statusCode: http.StatusInternalServerError, failAfterEvent: true}
+		openai := &fakeClient{}
+		c := newTestFailoverClient(t, testConfig, map[conftypes.CompletionsProviderName]*fakeClient{
+			"anthropic": anthropic, "openai": openai, "custom": {},
+		})
+
+		err := c.Stream(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{Model: "claude-v1"}, func(types.CompletionResponse) error { return nil })
+		require.Error(t, err)
+		assert.Empty(t, openai.models)
+	})
+
+	t.Run("returns the last error if all backends fail", func(t *testing.T) {
+		c := newTestFailoverClient(t, testConfig, map[conftypes.CompletionsProviderName]*fakeClient{
+			"anthropic": {statusCode: http.StatusServiceUnavailable},
+			"openai":    {statusCode: http.StatusServiceUnavailable},
+			"custom":    {statusCode: http.StatusTooManyRequests},
+		})
+
+		_, err := c.Complete(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{Model: "claude-v1"})
+		statusErr, ok := types.IsErrStatusNotOK(err)
+		require.True(t, ok)
+		assert.Equal(t, http.StatusTooManyRequests, statusErr.StatusCode())
+	})
+}
+
+func TestFailoverClientCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	anthropic, openai := &fakeClient{statusCode: http.StatusServiceUnavailable}, &fakeClient{}
+	config := testConfig
+	config.Fallbacks = config.Fallbacks[:1]
+	c := newTestFailoverClient(t, config, map[conftypes.CompletionsProviderName]*fakeClient{
+		"anthropic": anthropic, "openai": openai,
+	})
+	c.backends[0].breaker.now = func() time.Time { return now }
+
+	complete := func() {
+		t.Helper()
+		_, err := c.Complete(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{Model: "claude-v1"})
+		require.NoError(t, err)
+	}
+
+	for i := 0; i < breakerFailureThreshold; i++ {
+		complete()
+	}
+	assert.Len(t, anthropic.models, breakerFailureThreshold)
+
+	// The breaker is open, anthropic is skipped.
+	complete()
+	assert.Len(t, anthropic.models, breakerFailureThreshold)
+	assert.Len(t, openai.models, breakerFailureThreshold+1)
+
+	// After the cooldown, anthropic is tried again.
+	now = now.Add(breakerCooldown)
+	anthropic.statusCode = http.StatusOK
+	complete()
+	assert.Len(t, anthropic.models, breakerFailureThreshold+1)
+	assert.Len(t, openai.models, breakerFailureThreshold+1)
+}
+
+func TestFailoverClientRoundRobin(t *testing.T) {
+	anthropic, openai := &fakeClient{}, &fakeClient{}
+	config := testConfig
+	config.Fallbacks = config.Fallbacks[:1]
+	config.Routing = conftypes.CompletionsRoutingRoundRobin
+	c := newTestFailoverClient(t, config, map[conftypes.CompletionsProviderName]*fakeClient{
+		"anthropic": anthropic, "openai": openai,
+	})
+
+	for i := 0; i < 4; i++ {
+		_, err := c.Complete(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{Model: "claude-v1"})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"claude-v1", "claude-v1"}, anthropic.models)
+	assert.Equal(t, []string{"gpt-4", "gpt-4"}, openai.models)
+}
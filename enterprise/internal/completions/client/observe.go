@@ -11,18 +11,22 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 )
 
-func newObservedClient(inner types.CompletionsClient) *observedClient {
+func newObservedClient(inner types.CompletionsClient, backend string) *observedClient {
 	observationCtx := observation.NewContext(log.Scoped("completions", "completions client"))
 	ops := newOperations(observationCtx)
 	return &observedClient{
-		inner: inner,
-		ops:   ops,
+		inner:   inner,
+		ops:     ops,
+		backend: backend,
 	}
 }
 
 type observedClient struct {
 	inner types.CompletionsClient
 	ops   *operations
+	// backend is the name of the backend that serves requests, unless the inner
+	// client reports otherwise with recordBackend.
+	backend string
 }
 
 var _ types.CompletionsClient = (*observedClient)(nil)
@@ -32,7 +36,8 @@ func (o *observedClient) Stream(ctx context.Context, feature types.CompletionsFe
 		Attrs:             append(params.Attrs(), attribute.String("feature", string(feature))),
 		MetricLabelValues: []string{params.Model},
 	})
-	defer endObservation(1, observation.Args{})
+	ctx, info := o.withRequestInfo(ctx, tr)
+	defer func() { endObservation(1, info.args()) }()
 
 	tracedSend := func(event types.CompletionResponse) error {
 		if event.StopReason != "" {
@@ -47,15 +52,57 @@ func (o *observedClient) Stream(ctx context.Context, feature types.CompletionsFe
 }
 
 func (o *observedClient) Complete(ctx context.Context, feature types.CompletionsFeature, params types.CompletionRequestParameters) (resp *types.CompletionResponse, err error) {
-	ctx, _, endObservation := o.ops.stream.With(ctx, &err, observation.Args{
+	ctx, tr, endObservation := o.ops.complete.With(ctx, &err, observation.Args{
 		Attrs:             append(params.Attrs(), attribute.String("feature", string(feature))),
 		MetricLabelValues: []string{params.Model},
 	})
-	defer endObservation(1, observation.Args{})
+	ctx, info := o.withRequestInfo(ctx, tr)
+	defer func() { endObservation(1, info.args()) }()
 
 	return o.inner.Complete(ctx, feature, params)
 }
 
+// requestInfo collects which backends were involved in serving a request.
+type requestInfo struct {
+	tr        observation.TraceLogger
+	backend   string
+	failovers int
+}
+
+type requestInfoKey struct{}
+
+func (o *observedClient) withRequestInfo(ctx context.Context, tr observation.TraceLogger) (context.Context, *requestInfo) {
+	info := &requestInfo{tr: tr, backend: o.backend}
+	return context.WithValue(ctx, requestInfoKey{}, info), info
+}
+
+func (i *requestInfo) args() observation.Args {
+	return observation.Args{
+		MetricLabelValues: []string{i.backend},
+		Attrs: []attribute.KeyValue{
+			attribute.String("backend", i.backend),
+			attribute.Int("failovers", i.failovers),
+		},
+	}
+}
+
+// recordBackend records the backend that served, or finally failed, the
+// request in the observation of the request.
+func recordBackend(ctx context.Context, backend string) {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		info.backend = backend
+	}
+}
+
+// recordFailover records that the given backend failed to serve the request, and
+// that another backend is tried.
+func recordFailover(ctx context.Context, backend string, err error) {
+	if info, ok := ctx.Value(requestInfoKey{}).(*requestInfo); ok {
+		info.failovers++
+		info.tr.AddEvent("failover", attribute.String("backend", backend), attribute.String("error", err.Error()))
+	}
+}
+
 type operations struct {
 	stream   *observation.Operation
 	complete *observation.Operation
@@ -66,13 +113,13 @@ var (
 	streamMetrics   = metrics.NewREDMetrics(
 		prometheus.DefaultRegisterer,
 		"completions_stream",
-		metrics.WithLabels("model"),
+		metrics.WithLabels("model", "backend"),
 		metrics.WithDurationBuckets(durationBuckets),
 	)
 	completeMetrics = metrics.NewREDMetrics(
 		prometheus.DefaultRegisterer,
 		"completions_complete",
-		metrics.WithLabels("model"),
+		metrics.WithLabels("model", "backend"),
 		metrics.WithDurationBuckets(durationBuckets),
 	)
 )
@@ -83,7 +130,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		Name:    "completions.stream",
 	})
 	completeOp := observationCtx.Operation(observation.Op{
-		Metrics: streamMetrics,
+		Metrics: completeMetrics,
 		Name:    "completions.complete",
 	})
 	return &operations{
@@ -0,0 +1,61 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultAggregateLimit is the number of groups returned by the aggregate
+// command when no explicit limit is set.
+const DefaultAggregateLimit = 10
+
+// Aggregate groups the values of a search pattern by a template and counts the
+// values in each group. Run returns the counts for a single match, which are
+// combined over all matches of a query with an Aggregator.
+type Aggregate struct {
+	SearchPattern MatchPattern
+	GroupPattern  string
+
+	// Limit is the number of groups with the highest counts that are
+	// returned. All groups are returned if Limit is 0.
+	Limit int
+	Kind  string
+}
+
+func (c *Aggregate) ToSearchPattern() string {
+	return c.SearchPattern.String()
+}
+
+func (c *Aggregate) String() string {
+	return fmt.Sprintf("Aggregate: (%s) -> (%s) limit: %d", c.SearchPattern.String(), c.GroupPattern, c.Limit)
+}
+
+func (c *Aggregate) Run(_ context.Context, r result.Match) (Result, error) {
+	rp, ok := c.SearchPattern.(*Regexp)
+	if !ok {
+		return nil, errors.Errorf("%s command only supports regular expression patterns", c.Kind)
+	}
+
+	counts := make(map[string]int)
+	for _, content := range resultChunks(r, c.Kind, false) {
+		env := NewMetaEnvironment(r, content)
+		groupPattern, err := substituteMetaVariables(c.GroupPattern, env)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, submatches := range rp.Value.FindAllStringSubmatchIndex(content, -1) {
+			group := rp.Value.ExpandString([]byte{}, groupPattern, content, submatches)
+			counts[string(group)]++
+		}
+	}
+
+	aggregator := &Aggregator{kind: c.Kind, counts: counts}
+	for _, count := range counts {
+		aggregator.total += count
+	}
+	return aggregator.Result(), nil
+}
@@ -0,0 +1,65 @@
+package compute
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+func TestAggregateRun(t *testing.T) {
+	test := func(q string, m result.Match) string {
+		computeQuery, err := Parse(q)
+		if err != nil {
+			return err.Error()
+		}
+		commandResult, err := computeQuery.Command.Run(context.Background(), m)
+		if err != nil {
+			return err.Error()
+		}
+		v, _ := json.Marshal(commandResult)
+		return string(v)
+	}
+
+	autogold.Expect(`{"kind":"count.by","groups":[{"value":"Error","count":2},{"value":"Warning","count":1}],"totalCount":3,"otherCount":0,"limitHit":false}`).
+		Equal(t, test(`content:count.by(\b[a-z]+(Error|Warning)\b -> $1)`, fileMatch("parseError ioError", "nameWarning")))
+
+	autogold.Expect(`{"kind":"aggregate","groups":[{"value":"my/awesome/repo","count":3}],"totalCount":3,"otherCount":0,"limitHit":false}`).
+		Equal(t, test(`content:aggregate(\d -> $repo)`, fileMatch("a 1 b 2 c 3")))
+
+	autogold.Expect(`{"kind":"count.by","groups":[{"value":"bob: a","count":1}],"totalCount":1,"otherCount":0,"limitHit":false}`).
+		Equal(t, test(`content:count.by(^(\w) -> $author: $1)`, commitMatch("a 1 b 2 c 3")))
+
+	autogold.Expect("invalid arrow statement, no left and right hand sides of `->`").
+		Equal(t, test(`content:count.by(\w+)`, fileMatch("a")))
+}
+
+func TestAggregator(t *testing.T) {
+	add := func(a *Aggregator, groups ...Group) {
+		a.Add(&Aggregation{Groups: groups})
+	}
+
+	t.Run("top groups", func(t *testing.T) {
+		a := NewAggregator(&Aggregate{Kind: "aggregate", Limit: 2}, DefaultMaxAggregationBytes)
+		add(a, Group{Value: "a", Count: 1}, Group{Value: "b", Count: 2})
+		add(a, Group{Value: "c", Count: 1}, Group{Value: "a", Count: 2})
+		a.Add(&Text{Value: "ignored"})
+
+		v, _ := json.Marshal(a.Result())
+		autogold.Expect(`{"kind":"aggregate","groups":[{"value":"a","count":3},{"value":"b","count":2}],"totalCount":6,"otherCount":1,"limitHit":false}`).
+			Equal(t, string(v))
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		a := NewAggregator(&Aggregate{Kind: "count.by"}, 2*(1+groupOverheadBytes))
+		add(a, Group{Value: "a", Count: 1}, Group{Value: "b", Count: 1})
+		add(a, Group{Value: "c", Count: 5}, Group{Value: "a", Count: 1})
+
+		v, _ := json.Marshal(a.Result())
+		autogold.Expect(`{"kind":"count.by","groups":[{"value":"a","count":2},{"value":"b","count":1}],"totalCount":8,"otherCount":5,"limitHit":true}`).
+			Equal(t, string(v))
+	})
+}
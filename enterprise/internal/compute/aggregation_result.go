@@ -0,0 +1,116 @@
+package compute
+
+import "sort"
+
+// DefaultMaxAggregationBytes is the approximate amount of memory an Aggregator
+// may use to hold groups before it starts dropping new groups.
+const DefaultMaxAggregationBytes = 16 * 1024 * 1024
+
+// groupOverheadBytes approximates the memory used to hold a group in addition
+// to the length of its value.
+const groupOverheadBytes = 64
+
+// Aggregation is the result of the aggregate and count.by commands.
+type Aggregation struct {
+	Kind   string  `json:"kind"`
+	Groups []Group `json:"groups"`
+
+	// TotalCount is the number of values that were aggregated, including
+	// values of groups that are not part of Groups.
+	TotalCount int `json:"totalCount"`
+
+	// OtherCount is the number of values of groups that are not part of
+	// Groups, either because they are beyond the limit of the command or
+	// because they were dropped when the memory limit was hit.
+	OtherCount int `json:"otherCount"`
+
+	// LimitHit is true if groups were dropped because the aggregation hit
+	// its memory limit. The counts of returned groups are exact, but groups
+	// with higher counts may be missing.
+	LimitHit bool `json:"limitHit"`
+}
+
+type Group struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Aggregator combines the aggregations of individual matches into a single
+// aggregation. Once the groups held by the aggregator exceed its memory limit,
+// values of groups that were not seen before are only counted towards the
+// OtherCount of the result. An Aggregator is not safe for concurrent use.
+type Aggregator struct {
+	kind     string
+	limit    int
+	maxBytes int
+
+	counts  map[string]int
+	bytes   int
+	total   int
+	dropped int
+}
+
+// NewAggregator returns an Aggregator for the results of cmd, which holds at
+// most maxBytes worth of groups.
+func NewAggregator(cmd *Aggregate, maxBytes int) *Aggregator {
+	return &Aggregator{
+		kind:     cmd.Kind,
+		limit:    cmd.Limit,
+		maxBytes: maxBytes,
+		counts:   make(map[string]int),
+	}
+}
+
+// Add adds the groups of r to the aggregator. Results that are not
+// aggregations are ignored.
+func (a *Aggregator) Add(r Result) {
+	aggregation, ok := r.(*Aggregation)
+	if !ok || aggregation == nil {
+		return
+	}
+
+	for _, group := range aggregation.Groups {
+		a.total += group.Count
+		if _, ok := a.counts[group.Value]; !ok {
+			size := len(group.Value) + groupOverheadBytes
+			if a.bytes+size > a.maxBytes {
+				a.dropped += group.Count
+				continue
+			}
+			a.bytes += size
+		}
+		a.counts[group.Value] += group.Count
+	}
+	a.total += aggregation.OtherCount
+	a.dropped += aggregation.OtherCount
+}
+
+// Result returns the groups aggregated so far, ordered by descending count.
+func (a *Aggregator) Result() *Aggregation {
+	groups := make([]Group, 0, len(a.counts))
+	for value, count := range a.counts {
+		groups = append(groups, Group{Value: value, Count: count})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Value < groups[j].Value
+	})
+
+	other := a.dropped
+	if a.limit > 0 && len(groups) > a.limit {
+		for _, group := range groups[a.limit:] {
+			other += group.Count
+		}
+		groups = groups[:a.limit]
+	}
+
+	return &Aggregation{
+		Kind:       a.kind,
+		Groups:     groups,
+		TotalCount: a.total,
+		OtherCount: other,
+		LimitHit:   a.dropped > 0,
+	}
+}
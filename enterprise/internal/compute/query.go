@@ -2,6 +2,7 @@ package compute
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/regexp"
 
@@ -104,15 +105,31 @@ var ComputePredicateRegistry = query.PredicateRegistry{
 		"output.regexp":      func() query.Predicate { return query.EmptyPredicate{} },
 		"output.structural":  func() query.Predicate { return query.EmptyPredicate{} },
 		"output.extra":       func() query.Predicate { return query.EmptyPredicate{} },
+		"aggregate":          func() query.Predicate { return query.EmptyPredicate{} },
+		"count.by":           func() query.Predicate { return query.EmptyPredicate{} },
 	},
 }
 
+// predicateAliases maps alternative names of predicates to the names in
+// ComputePredicateRegistry. Predicate names of the registry can only contain
+// letters and dots.
+var predicateAliases = map[string]string{
+	"count-by": "count.by",
+}
+
 func parseContentPredicate(pattern *query.Pattern) (string, string, bool) {
 	if !pattern.Annotation.Labels.IsSet(query.IsAlias) {
 		// pattern is not set via `content:`, so it cannot be a replace command.
 		return "", "", false
 	}
-	value, _, ok := query.ScanPredicate("content", []byte(pattern.Value), ComputePredicateRegistry)
+	value := pattern.Value
+	for alias, name := range predicateAliases {
+		if strings.HasPrefix(value, alias+"(") {
+			value = name + strings.TrimPrefix(value, alias)
+			break
+		}
+	}
+	value, _, ok := query.ScanPredicate("content", []byte(value), ComputePredicateRegistry)
 	if !ok {
 		return "", "", false
 	}
@@ -217,6 +234,46 @@ func parseOutput(q *query.Basic) (Command, bool, error) {
 	}, true, nil
 }
 
+func parseAggregate(q *query.Basic) (Command, bool, error) {
+	pattern, err := extractPattern(q)
+	if err != nil {
+		return nil, false, err
+	}
+
+	name, args, ok := parseContentPredicate(pattern)
+	if !ok {
+		return nil, false, nil
+	}
+
+	var limit int
+	switch name {
+	case "aggregate":
+		limit = DefaultAggregateLimit
+	case "count.by":
+		// count.by returns the counts of all groups.
+		limit = 0
+	default:
+		// unrecognized name
+		return nil, false, nil
+	}
+
+	left, right, err := parseArrowSyntax(args)
+	if err != nil {
+		return nil, false, err
+	}
+	matchPattern, err := toRegexpPattern(left)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "%s command", name)
+	}
+
+	return &Aggregate{
+		SearchPattern: matchPattern,
+		GroupPattern:  right,
+		Limit:         limit,
+		Kind:          name,
+	}, true, nil
+}
+
 func parseMatchOnly(q *query.Basic) (Command, bool, error) {
 	pattern, err := extractPattern(q)
 	if err != nil {
@@ -260,6 +317,7 @@ func first(parsers ...commandParser) commandParser {
 var parseCommand = first(
 	parseReplace,
 	parseOutput,
+	parseAggregate,
 	parseMatchOnly,
 )
 
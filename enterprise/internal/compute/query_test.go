@@ -41,6 +41,15 @@ func TestParse(t *testing.T) {
 
 	autogold.Expect("Command: `Replace in place: () -> (b)`").
 		Equal(t, test("content:replace(->b)"))
+
+	autogold.Expect("Command: `Aggregate: (\\w+Error) -> ($repo) limit: 10`, Parameters: `lang:go`").
+		Equal(t, test(`content:aggregate(\w+Error -> $repo) lang:go`))
+
+	autogold.Expect("Command: `Aggregate: ((\\w+)Error) -> ($1) limit: 0`").
+		Equal(t, test(`content:count.by((\w+)Error -> $1)`))
+
+	autogold.Expect("Command: `Aggregate: ((\\w+)Error) -> ($1) limit: 0`").
+		Equal(t, test(`content:count-by((\w+)Error -> $1)`))
 }
 
 func TestToSearchQuery(t *testing.T) {
@@ -18,6 +18,10 @@ type ActionJob struct {
 	Email        *int64
 	Webhook      *int64
 	SlackWebhook *int64
+	Issue        *int64
+	InsightAlert *InsightAlertAction
+	// TriggerEvent is zero for insight alert jobs, which are not triggered by a
+	// code monitor.
 	TriggerEvent int32
 
 	// Fields demanded by any dbworker.
@@ -39,11 +43,22 @@ func (a *ActionJob) RecordUID() string {
 	return strconv.FormatInt(int64(a.ID), 10)
 }
 
+// InsightAlertAction is a triggered code insights alert that is delivered by an
+// action job, through exactly one of an email to the user with EmailUserID, a
+// Slack webhook or a webhook. Alert is the JSON encoding of the alert.
+type InsightAlertAction struct {
+	EmailUserID     *int32          `json:"emailUserId,omitempty"`
+	SlackWebhookURL *string         `json:"slackWebhookUrl,omitempty"`
+	WebhookURL      *string         `json:"webhookUrl,omitempty"`
+	Alert           json.RawMessage `json:"alert"`
+}
+
 type ActionJobMetadata struct {
 	Description string
 	MonitorID   int64
 	Results     []*result.CommitMatch
 	OwnerName   string
+	OwnerID     int32
 
 	// The query with after: filter.
 	Query string
@@ -56,6 +71,8 @@ var ActionJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.email"),
 	sqlf.Sprintf("cm_action_jobs.webhook"),
 	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
+	sqlf.Sprintf("cm_action_jobs.issue"),
+	sqlf.Sprintf("cm_action_jobs.insight_alert"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
@@ -87,6 +104,10 @@ type ListActionJobsOpts struct {
 	// the given slack webhook action. Refers to cm_slack_webhooks(id)
 	SlackWebhookID *int
 
+	// IssueID, if set, will filter to only actions jobs that are executing the
+	// given issue action. Refers to cm_issues(id)
+	IssueID *int
+
 	// First, if defined, limits the operation to only the first n results
 	First *int
 
@@ -110,6 +131,9 @@ func (o ListActionJobsOpts) Conds() *sqlf.Query {
 	if o.SlackWebhookID != nil {
 		conds = append(conds, sqlf.Sprintf("slack_webhook = %s", *o.SlackWebhookID))
 	}
+	if o.IssueID != nil {
+		conds = append(conds, sqlf.Sprintf("issue = %s", *o.IssueID))
+	}
 	if o.After != nil {
 		conds = append(conds, sqlf.Sprintf("id > %s", *o.After))
 	}
@@ -197,14 +221,25 @@ WITH due_emails AS (
 	SELECT DISTINCT slack_webhook as id FROM cm_action_jobs
 	WHERE state = 'queued'
 		OR state = 'processing'
+), due_issues AS (
+	SELECT id
+	FROM cm_issues
+	WHERE monitor = %s
+		AND enabled = true
+	EXCEPT
+	SELECT DISTINCT issue as id FROM cm_action_jobs
+	WHERE state = 'queued'
+		OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, webhook, slack_webhook, trigger_event)
-SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+INSERT INTO cm_action_jobs (email, webhook, slack_webhook, issue, trigger_event)
+SELECT id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_emails
+UNION
+SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), %s::integer from due_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_webhooks
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, CAST(NULL AS BIGINT), %s::integer from due_slack_webhooks
 UNION
-SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_slack_webhooks
-ORDER BY 1, 2, 3
+SELECT CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), CAST(NULL AS BIGINT), id, %s::integer from due_issues
+ORDER BY 1, 2, 3, 4
 RETURNING %s
 `
 
@@ -214,9 +249,44 @@ func (s *codeMonitorStore) EnqueueActionJobsForMonitor(ctx context.Context, moni
 		monitorID,
 		monitorID,
 		monitorID,
+		monitorID,
 		triggerJobID,
 		triggerJobID,
 		triggerJobID,
+		triggerJobID,
+		sqlf.Join(ActionJobColumns, ","),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanActionJobs(rows)
+}
+
+const enqueueInsightAlertActionJobsFmtStr = `
+INSERT INTO cm_action_jobs (insight_alert)
+VALUES %s
+RETURNING %s
+`
+
+// EnqueueInsightAlertActionJobs enqueues an action job for each of the given
+// insight alert actions.
+func (s *codeMonitorStore) EnqueueInsightAlertActionJobs(ctx context.Context, actions []InsightAlertAction) ([]*ActionJob, error) {
+	if len(actions) == 0 {
+		return nil, nil
+	}
+	values := make([]*sqlf.Query, 0, len(actions))
+	for _, action := range actions {
+		raw, err := json.Marshal(action)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, sqlf.Sprintf("(%s)", raw))
+	}
+	q := sqlf.Sprintf(
+		enqueueInsightAlertActionJobsFmtStr,
+		sqlf.Join(values, ","),
 		sqlf.Join(ActionJobColumns, ","),
 	)
 	rows, err := s.Query(ctx, q)
@@ -233,7 +303,8 @@ SELECT
 	ctj.query_string,
 	cm.id AS monitorID,
 	ctj.search_results,
-	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END
+	CASE WHEN LENGTH(users.display_name) > 0 THEN users.display_name ELSE users.username END,
+	users.id
 FROM cm_action_jobs caj
 INNER JOIN cm_trigger_jobs ctj on caj.trigger_event = ctj.id
 INNER JOIN cm_queries cq on cq.id = ctj.query
@@ -247,7 +318,7 @@ func (s *codeMonitorStore) GetActionJobMetadata(ctx context.Context, jobID int32
 	row := s.Store.QueryRow(ctx, sqlf.Sprintf(getActionJobMetadataFmtStr, jobID))
 	var resultsJSON []byte
 	m := &ActionJobMetadata{}
-	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &m.OwnerName)
+	err := row.Scan(&m.Description, &m.Query, &m.MonitorID, &resultsJSON, &m.OwnerName, &m.OwnerID)
 	if err != nil {
 		return nil, err
 	}
@@ -283,12 +354,15 @@ func scanActionJobs(rows *sql.Rows) ([]*ActionJob, error) {
 
 func ScanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 	aj := &ActionJob{}
-	return aj, row.Scan(
+	var insightAlert dbutil.NullJSONRawMessage
+	if err := row.Scan(
 		&aj.ID,
 		&aj.Email,
 		&aj.Webhook,
 		&aj.SlackWebhook,
-		&aj.TriggerEvent,
+		&aj.Issue,
+		&insightAlert,
+		&dbutil.NullInt32{N: &aj.TriggerEvent},
 		&aj.State,
 		&aj.FailureMessage,
 		&aj.StartedAt,
@@ -297,5 +371,14 @@ func ScanActionJob(row dbutil.Scanner) (*ActionJob, error) {
 		&aj.NumResets,
 		&aj.NumFailures,
 		&aj.LogContents,
-	)
+	); err != nil {
+		return aj, err
+	}
+	if insightAlert.Raw != nil {
+		aj.InsightAlert = &InsightAlertAction{}
+		if err := json.Unmarshal(insightAlert.Raw, aj.InsightAlert); err != nil {
+			return aj, err
+		}
+	}
+	return aj, nil
 }
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -39,9 +40,38 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 	require.Equal(t, want, actionJobs[0])
 }
 
+func TestEnqueueInsightAlertActionJobs(t *testing.T) {
+	ctx, _, s := newTestStore(t)
+
+	webhookURL := "https://example.com/webhook"
+	actions := []InsightAlertAction{
+		{WebhookURL: &webhookURL, Alert: json.RawMessage(`{"seriesId":"s1"}`)},
+	}
+	actionJobs, err := s.EnqueueInsightAlertActionJobs(ctx, actions)
+	require.NoError(t, err)
+	require.Len(t, actionJobs, 1)
+
+	// Insight alert jobs do not belong to a trigger job.
+	got, err := s.GetActionJob(ctx, actionJobs[0].ID)
+	require.NoError(t, err)
+	want := &ActionJob{
+		ID:           actionJobs[0].ID,
+		InsightAlert: &actions[0],
+		State:        "queued",
+	}
+	require.Equal(t, want, got)
+
+	// Insight alert jobs are deleted along with old trigger jobs.
+	require.NoError(t, s.Exec(ctx, sqlf.Sprintf("UPDATE cm_action_jobs SET finished_at = NOW() - '31 days'::interval")))
+	require.NoError(t, s.DeleteOldTriggerJobs(ctx, 30))
+	count, err := s.CountActionJobs(ctx, ListActionJobsOpts{})
+	require.NoError(t, err)
+	require.Zero(t, count)
+}
+
 func TestGetActionJobMetadata(t *testing.T) {
 	ctx, db, s := newTestStore(t)
-	userName, _, userCTX := newTestUser(ctx, t, db)
+	userName, userID, userCTX := newTestUser(ctx, t, db)
 	fixtures := s.insertTestMonitor(userCTX, t)
 
 	triggerJobs, err := s.EnqueueQueryTriggerJobs(ctx)
@@ -69,6 +99,7 @@ func TestGetActionJobMetadata(t *testing.T) {
 		Results:     wantResults,
 		MonitorID:   fixtures.monitor.ID,
 		OwnerName:   userName,
+		OwnerID:     userID,
 	}
 	require.Equal(t, want, got)
 }
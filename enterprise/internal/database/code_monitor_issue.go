@@ -0,0 +1,288 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+type IssueAction struct {
+	ID             int64
+	Monitor        int64
+	Enabled        bool
+	IncludeResults bool
+
+	// Repository is the name of the GitHub or GitLab repository the issues are
+	// opened in.
+	Repository string
+
+	// TitleTemplate and BodyTemplate are Go text/templates used to render the
+	// title and body of the issues. When BodyTemplate is empty, a default body
+	// is used.
+	TitleTemplate string
+	BodyTemplate  string
+
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+type IssueActionArgs struct {
+	Enabled        bool
+	IncludeResults bool
+	Repository     string
+	TitleTemplate  string
+	BodyTemplate   string
+}
+
+const updateIssueActionQuery = `
+UPDATE cm_issues
+SET enabled = %s,
+	include_results = %s,
+	repository = %s,
+	title_template = %s,
+	body_template = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE
+	id = %s
+	AND EXISTS (
+		SELECT 1 FROM cm_monitors
+		WHERE cm_monitors.id = cm_issues.monitor
+			AND cm_monitors.namespace_user_id = %s
+	)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) UpdateIssueAction(ctx context.Context, id int64, args *IssueActionArgs) (*IssueAction, error) {
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		updateIssueActionQuery,
+		args.Enabled,
+		args.IncludeResults,
+		args.Repository,
+		args.TitleTemplate,
+		args.BodyTemplate,
+		a.UID,
+		s.Now(),
+		id,
+		a.UID,
+		sqlf.Join(issueActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const createIssueActionQuery = `
+INSERT INTO cm_issues
+(monitor, enabled, include_results, repository, title_template, body_template, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *codeMonitorStore) CreateIssueAction(ctx context.Context, monitorID int64, args *IssueActionArgs) (*IssueAction, error) {
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	q := sqlf.Sprintf(
+		createIssueActionQuery,
+		monitorID,
+		args.Enabled,
+		args.IncludeResults,
+		args.Repository,
+		args.TitleTemplate,
+		args.BodyTemplate,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(issueActionColumns, ","),
+	)
+
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const deleteIssueActionQuery = `
+DELETE FROM cm_issues
+WHERE id in (%s)
+	AND MONITOR = %s
+`
+
+func (s *codeMonitorStore) DeleteIssueActions(ctx context.Context, monitorID int64, issueIDs ...int64) error {
+	if len(issueIDs) == 0 {
+		return nil
+	}
+
+	deleteIDs := make([]*sqlf.Query, 0, len(issueIDs))
+	for _, ids := range issueIDs {
+		deleteIDs = append(deleteIDs, sqlf.Sprintf("%d", ids))
+	}
+	q := sqlf.Sprintf(
+		deleteIssueActionQuery,
+		sqlf.Join(deleteIDs, ","),
+		monitorID,
+	)
+
+	return s.Exec(ctx, q)
+}
+
+const countIssueActionsQuery = `
+SELECT COUNT(*)
+FROM cm_issues
+WHERE monitor = %s;
+`
+
+func (s *codeMonitorStore) CountIssueActions(ctx context.Context, monitorID int64) (int, error) {
+	var count int
+	err := s.QueryRow(ctx, sqlf.Sprintf(countIssueActionsQuery, monitorID)).Scan(&count)
+	return count, err
+}
+
+const getIssueActionQuery = `
+SELECT %s -- IssueActionColumns
+FROM cm_issues
+WHERE id = %s
+`
+
+func (s *codeMonitorStore) GetIssueAction(ctx context.Context, issueID int64) (*IssueAction, error) {
+	q := sqlf.Sprintf(
+		getIssueActionQuery,
+		sqlf.Join(issueActionColumns, ","),
+		issueID,
+	)
+	row := s.QueryRow(ctx, q)
+	return scanIssueAction(row)
+}
+
+const listIssueActionsQuery = `
+SELECT %s -- IssueActionColumns
+FROM cm_issues
+WHERE %s
+ORDER BY id ASC
+LIMIT %s;
+`
+
+func (s *codeMonitorStore) ListIssueActions(ctx context.Context, opts ListActionsOpts) ([]*IssueAction, error) {
+	q := sqlf.Sprintf(
+		listIssueActionsQuery,
+		sqlf.Join(issueActionColumns, ","),
+		opts.Conds(),
+		opts.Limit(),
+	)
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanIssueActions(rows)
+}
+
+const claimIssueMatchesQuery = `
+INSERT INTO cm_issue_matches (issue, match_key)
+SELECT %s, match_key
+FROM UNNEST(%s::text[]) AS match_key
+ON CONFLICT (issue, match_key) DO NOTHING
+RETURNING match_key
+`
+
+// ClaimIssueMatches records that the issue action is opening an issue for the
+// matches with the given keys, and returns the subset of the keys that were not
+// claimed before. The claims are recorded before the issue is opened, so that
+// the issue action never opens another issue for the same match, even if it
+// fails after opening the issue.
+func (s *codeMonitorStore) ClaimIssueMatches(ctx context.Context, issueID int64, keys []string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+	return basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(claimIssueMatchesQuery, issueID, pq.Array(keys))))
+}
+
+const setIssueMatchesURLQuery = `
+UPDATE cm_issue_matches
+SET issue_url = %s
+WHERE issue = %s
+	AND match_key = ANY(%s)
+`
+
+// SetIssueMatchesURL records that the issue at issueURL was opened for the
+// claimed matches with the given keys.
+func (s *codeMonitorStore) SetIssueMatchesURL(ctx context.Context, issueID int64, issueURL string, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(setIssueMatchesURLQuery, issueURL, issueID, pq.Array(keys)))
+}
+
+const deleteIssueMatchesQuery = `
+DELETE FROM cm_issue_matches
+WHERE issue = %s
+	AND match_key = ANY(%s)
+	AND issue_url IS NULL
+`
+
+// DeleteIssueMatches releases the claims on the matches with the given keys for
+// which no issue was opened, so that the issue action opens an issue for them
+// when it is retried.
+func (s *codeMonitorStore) DeleteIssueMatches(ctx context.Context, issueID int64, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteIssueMatchesQuery, issueID, pq.Array(keys)))
+}
+
+// issueActionColumns is the set of columns in the cm_issues table
+// This must be kept in sync with scanIssueAction
+var issueActionColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_issues.id"),
+	sqlf.Sprintf("cm_issues.monitor"),
+	sqlf.Sprintf("cm_issues.enabled"),
+	sqlf.Sprintf("cm_issues.include_results"),
+	sqlf.Sprintf("cm_issues.repository"),
+	sqlf.Sprintf("cm_issues.title_template"),
+	sqlf.Sprintf("cm_issues.body_template"),
+	sqlf.Sprintf("cm_issues.created_by"),
+	sqlf.Sprintf("cm_issues.created_at"),
+	sqlf.Sprintf("cm_issues.changed_by"),
+	sqlf.Sprintf("cm_issues.changed_at"),
+}
+
+func scanIssueActions(rows *sql.Rows) ([]*IssueAction, error) {
+	var is []*IssueAction
+	for rows.Next() {
+		i, err := scanIssueAction(rows)
+		if err != nil {
+			return nil, err
+		}
+		is = append(is, i)
+	}
+	return is, rows.Err()
+}
+
+// scanIssueAction scans an IssueAction from a *sql.Row or *sql.Rows.
+// It must be kept in sync with issueActionColumns.
+func scanIssueAction(scanner dbutil.Scanner) (*IssueAction, error) {
+	var i IssueAction
+	err := scanner.Scan(
+		&i.ID,
+		&i.Monitor,
+		&i.Enabled,
+		&i.IncludeResults,
+		&i.Repository,
+		&i.TitleTemplate,
+		&i.BodyTemplate,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.ChangedBy,
+		&i.ChangedAt,
+	)
+	return &i, err
+}
@@ -0,0 +1,205 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreIssues(t *testing.T) {
+	ctx := context.Background()
+	args1 := &IssueActionArgs{
+		Enabled:       true,
+		Repository:    "github.com/sourcegraph/sourcegraph",
+		TitleTemplate: "New match for {{.MonitorDescription}}",
+	}
+	args2 := &IssueActionArgs{
+		Enabled:        false,
+		IncludeResults: true,
+		Repository:     "gitlab.com/sourcegraph/sourcegraph",
+		TitleTemplate:  "Match",
+		BodyTemplate:   "{{range .Results}}{{.Commit}}{{end}}",
+	}
+
+	logger := logtest.Scoped(t)
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, args1)
+		require.NoError(t, err)
+		require.Equal(t, args1.Repository, action.Repository)
+		require.Equal(t, args1.TitleTemplate, action.TitleTemplate)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+
+		require.Equal(t, action, got)
+	})
+
+	t.Run("CreateUpdateGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, args1)
+		require.NoError(t, err)
+
+		updated, err := s.UpdateIssueAction(ctx, action.ID, args2)
+		require.NoError(t, err)
+		require.Equal(t, false, updated.Enabled)
+		require.Equal(t, true, updated.IncludeResults)
+		require.Equal(t, args2.Repository, updated.Repository)
+		require.Equal(t, args2.TitleTemplate, updated.TitleTemplate)
+		require.Equal(t, args2.BodyTemplate, updated.BodyTemplate)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, updated, got)
+	})
+
+	t.Run("ErrorOnUpdateNonexistent", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+
+		_, err := s.UpdateIssueAction(ctx, 383838, args2)
+		require.Error(t, err)
+	})
+
+	t.Run("CreateDeleteGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action1, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, args1)
+		require.NoError(t, err)
+
+		action2, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, args1)
+		require.NoError(t, err)
+
+		err = s.DeleteIssueActions(ctx, fixtures.monitor.ID, action1.ID)
+		require.NoError(t, err)
+
+		_, err = s.GetIssueAction(ctx, action1.ID)
+		require.Error(t, err)
+
+		_, err = s.GetIssueAction(ctx, action2.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("CountListCreateList", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		actions, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+		require.NoError(t, err)
+		require.Len(t, actions, 0)
+
+		_, err = s.CreateIssueAction(ctx, fixtures.monitor.ID, args1)
+		require.NoError(t, err)
+
+		_, err = s.CreateIssueAction(ctx, fixtures.monitor.ID, args2)
+		require.NoError(t, err)
+
+		count, err := s.CountIssueActions(ctx, fixtures.monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		actions2, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+		require.NoError(t, err)
+		require.Len(t, actions2, 2)
+
+		first := 1
+		actions3, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID, First: &first})
+		require.NoError(t, err)
+		require.Len(t, actions3, 1)
+	})
+
+	t.Run("Update permissions", func(t *testing.T) {
+		ctx, db, s := newTestStore(t)
+		uid1 := insertTestUser(ctx, t, db, "u1", false)
+		ctx1 := actor.WithActor(ctx, actor.FromUser(uid1))
+		uid2 := insertTestUser(ctx, t, db, "u2", false)
+		ctx2 := actor.WithActor(ctx, actor.FromUser(uid2))
+		fixtures := s.insertTestMonitor(ctx1, t)
+		_ = s.insertTestMonitor(ctx2, t)
+
+		ia, err := s.CreateIssueAction(ctx1, fixtures.monitor.ID, args1)
+		require.NoError(t, err)
+
+		// User1 can update it
+		_, err = s.UpdateIssueAction(ctx1, ia.ID, args2)
+		require.NoError(t, err)
+
+		// User2 cannot update it
+		_, err = s.UpdateIssueAction(ctx2, ia.ID, args1)
+		require.Error(t, err)
+
+		ia, err = s.GetIssueAction(ctx1, ia.ID)
+		require.NoError(t, err)
+		require.Equal(t, args2.Repository, ia.Repository)
+	})
+
+	t.Run("IssueMatches", func(t *testing.T) {
+		t.Parallel()
+
+		db := database.NewDB(logger, dbtest.NewDB(logger, t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitors(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+
+		action1, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, args1)
+		require.NoError(t, err)
+		action2, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, args1)
+		require.NoError(t, err)
+
+		keys, err := s.ClaimIssueMatches(ctx, action1.ID, []string{"repo@a", "repo@b"})
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"repo@a", "repo@b"}, keys)
+
+		// Claimed matches are not claimed again, whether an issue was opened for
+		// them or not.
+		err = s.SetIssueMatchesURL(ctx, action1.ID, "https://github.com/sourcegraph/sourcegraph/issues/1", "repo@a")
+		require.NoError(t, err)
+		keys, err = s.ClaimIssueMatches(ctx, action1.ID, []string{"repo@a", "repo@b", "repo@c"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"repo@c"}, keys)
+
+		// Releasing the claims only deletes the matches without an issue.
+		err = s.DeleteIssueMatches(ctx, action1.ID, "repo@a", "repo@c")
+		require.NoError(t, err)
+		keys, err = s.ClaimIssueMatches(ctx, action1.ID, []string{"repo@a", "repo@c"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"repo@c"}, keys)
+
+		// Matches are claimed per issue action.
+		keys, err = s.ClaimIssueMatches(ctx, action2.ID, []string{"repo@a"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"repo@a"}, keys)
+	})
+}
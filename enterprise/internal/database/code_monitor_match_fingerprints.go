@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+const listMatchFingerprintsQuery = `
+SELECT fingerprint
+FROM cm_match_fingerprints
+WHERE monitor_id = %s
+	AND fingerprint = ANY(%s)
+ORDER BY fingerprint ASC
+`
+
+// ListMatchFingerprints returns the subset of the given fingerprints that were
+// previously recorded for the code monitor.
+func (s *codeMonitorStore) ListMatchFingerprints(ctx context.Context, monitorID int64, fingerprints []string) ([]string, error) {
+	if len(fingerprints) == 0 {
+		return nil, nil
+	}
+	return basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(listMatchFingerprintsQuery, monitorID, pq.Array(fingerprints))))
+}
+
+const upsertMatchFingerprintsQuery = `
+INSERT INTO cm_match_fingerprints (monitor_id, fingerprint, last_seen_at)
+SELECT %s, fingerprint, %s
+FROM UNNEST(%s::text[]) AS fingerprint
+ON CONFLICT (monitor_id, fingerprint) DO UPDATE
+SET last_seen_at = EXCLUDED.last_seen_at
+`
+
+// UpsertMatchFingerprints records the given fingerprints for the code monitor,
+// and marks them as seen now.
+func (s *codeMonitorStore) UpsertMatchFingerprints(ctx context.Context, monitorID int64, fingerprints []string) error {
+	if len(fingerprints) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(upsertMatchFingerprintsQuery, monitorID, s.Now(), pq.Array(fingerprints)))
+}
+
+const deleteMatchFingerprintsQuery = `
+DELETE FROM cm_match_fingerprints
+WHERE monitor_id = %s
+`
+
+// DeleteMatchFingerprints deletes all fingerprints of the code monitor.
+func (s *codeMonitorStore) DeleteMatchFingerprints(ctx context.Context, monitorID int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteMatchFingerprintsQuery, monitorID))
+}
+
+const deleteStaleMatchFingerprintsQuery = `
+DELETE FROM cm_match_fingerprints
+WHERE last_seen_at < (NOW() - (%s * '1 day'::interval));
+`
+
+// DeleteStaleMatchFingerprints deletes fingerprints which have not been seen in
+// the last 'retention' days, so that matches that reappear after that are
+// reported again.
+func (s *codeMonitorStore) DeleteStaleMatchFingerprints(ctx context.Context, retentionInDays int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteStaleMatchFingerprintsQuery, retentionInDays))
+}
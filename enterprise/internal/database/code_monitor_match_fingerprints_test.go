@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreMatchFingerprints(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("upsert list delete", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// Nothing recorded yet
+		known, err := cm.ListMatchFingerprints(ctx, fixtures.Monitor.ID, []string{"a", "b"})
+		require.NoError(t, err)
+		require.Empty(t, known)
+
+		err = cm.UpsertMatchFingerprints(ctx, fixtures.Monitor.ID, []string{"a", "b"})
+		require.NoError(t, err)
+
+		// Upserting the same fingerprints again is not an error
+		err = cm.UpsertMatchFingerprints(ctx, fixtures.Monitor.ID, []string{"b", "c"})
+		require.NoError(t, err)
+
+		known, err = cm.ListMatchFingerprints(ctx, fixtures.Monitor.ID, []string{"c", "b", "d", "a"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b", "c"}, known)
+
+		err = cm.DeleteMatchFingerprints(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+
+		known, err = cm.ListMatchFingerprints(ctx, fixtures.Monitor.ID, []string{"a", "b", "c"})
+		require.NoError(t, err)
+		require.Empty(t, known)
+	})
+
+	t.Run("delete stale", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewEnterpriseDB(database.NewDB(logger, dbtest.NewDB(logger, t)))
+		fixtures := populateCodeMonitorFixtures(t, db)
+
+		old := CodeMonitorsWithClock(db, func() time.Time { return time.Now().Add(-48 * time.Hour) })
+		err := old.UpsertMatchFingerprints(ctx, fixtures.Monitor.ID, []string{"stale", "seen"})
+		require.NoError(t, err)
+
+		cm := db.CodeMonitors()
+		err = cm.UpsertMatchFingerprints(ctx, fixtures.Monitor.ID, []string{"seen"})
+		require.NoError(t, err)
+
+		err = cm.DeleteStaleMatchFingerprints(ctx, 1)
+		require.NoError(t, err)
+
+		known, err := cm.ListMatchFingerprints(ctx, fixtures.Monitor.ID, []string{"stale", "seen"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"seen"}, known)
+	})
+}
@@ -95,10 +95,20 @@ DELETE FROM cm_trigger_jobs
 WHERE finished_at < (NOW() - (%s * '1 day'::interval));
 `
 
+const deleteOldInsightAlertJobsFmtStr = `
+DELETE FROM cm_action_jobs
+WHERE insight_alert IS NOT NULL
+	AND finished_at < (NOW() - (%s * '1 day'::interval));
+`
+
 // DeleteOldTriggerJobs deletes trigger jobs which have finished and are older than
-// 'retention' days. Due to cascading, action jobs will be deleted as well.
+// 'retention' days. Due to cascading, action jobs will be deleted as well. Insight
+// alert jobs, which do not belong to a trigger job, are deleted along with them.
 func (s *codeMonitorStore) DeleteOldTriggerJobs(ctx context.Context, retentionInDays int) error {
-	return s.Store.Exec(ctx, sqlf.Sprintf(deleteOldJobLogsFmtStr, retentionInDays))
+	if err := s.Store.Exec(ctx, sqlf.Sprintf(deleteOldJobLogsFmtStr, retentionInDays)); err != nil {
+		return err
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(deleteOldInsightAlertJobsFmtStr, retentionInDays))
 }
 
 type ListTriggerJobsOpts struct {
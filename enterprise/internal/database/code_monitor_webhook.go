@@ -18,6 +18,10 @@ type WebhookAction struct {
 	URL            string
 	IncludeResults bool
 
+	// PayloadTemplate is a Go text/template used to render the request body. When
+	// empty, the default JSON payload is sent.
+	PayloadTemplate string
+
 	CreatedBy int32
 	CreatedAt time.Time
 	ChangedBy int32
@@ -29,6 +33,7 @@ UPDATE cm_webhooks
 SET enabled = %s,
     include_results = %s,
 	url = %s,
+	payload_template = %s,
 	changed_by = %s,
 	changed_at = %s
 WHERE
@@ -41,13 +46,14 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, enabled, includeResults bool, url, payloadTemplate string) (*WebhookAction, error) {
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		updateWebhookActionQuery,
 		enabled,
 		includeResults,
 		url,
+		payloadTemplate,
 		a.UID,
 		s.Now(),
 		id,
@@ -61,12 +67,12 @@ func (s *codeMonitorStore) UpdateWebhookAction(ctx context.Context, id int64, en
 
 const createWebhookActionQuery = `
 INSERT INTO cm_webhooks
-(monitor, enabled, include_results, url, created_by, created_at, changed_by, changed_at)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, enabled, include_results, url, payload_template, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*WebhookAction, error) {
+func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url, payloadTemplate string) (*WebhookAction, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
@@ -75,6 +81,7 @@ func (s *codeMonitorStore) CreateWebhookAction(ctx context.Context, monitorID in
 		enabled,
 		includeResults,
 		url,
+		payloadTemplate,
 		a.UID,
 		now,
 		a.UID,
@@ -169,6 +176,7 @@ var webhookActionColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_webhooks.enabled"),
 	sqlf.Sprintf("cm_webhooks.url"),
 	sqlf.Sprintf("cm_webhooks.include_results"),
+	sqlf.Sprintf("cm_webhooks.payload_template"),
 	sqlf.Sprintf("cm_webhooks.created_by"),
 	sqlf.Sprintf("cm_webhooks.created_at"),
 	sqlf.Sprintf("cm_webhooks.changed_by"),
@@ -197,6 +205,7 @@ func scanWebhookAction(scanner dbutil.Scanner) (*WebhookAction, error) {
 		&w.Enabled,
 		&w.URL,
 		&w.IncludeResults,
+		&w.PayloadTemplate,
 		&w.CreatedBy,
 		&w.CreatedAt,
 		&w.ChangedBy,
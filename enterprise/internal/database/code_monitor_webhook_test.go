@@ -28,7 +28,7 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		s := CodeMonitors(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
 		got, err := s.GetWebhookAction(ctx, action.ID)
@@ -45,13 +45,14 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		s := CodeMonitors(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
-		updated, err := s.UpdateWebhookAction(ctx, action.ID, false, false, url2)
+		updated, err := s.UpdateWebhookAction(ctx, action.ID, false, false, url2, `{"text":"{{.MonitorDescription}}"}`)
 		require.NoError(t, err)
 		require.Equal(t, false, updated.Enabled)
 		require.Equal(t, url2, updated.URL)
+		require.Equal(t, `{"text":"{{.MonitorDescription}}"}`, updated.PayloadTemplate)
 
 		got, err := s.GetWebhookAction(ctx, action.ID)
 		require.NoError(t, err)
@@ -65,7 +66,7 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		_, _, ctx := newTestUser(ctx, t, db)
 		s := CodeMonitors(db)
 
-		_, err := s.UpdateWebhookAction(ctx, 383838, false, false, url2)
+		_, err := s.UpdateWebhookAction(ctx, 383838, false, false, url2, "")
 		require.Error(t, err)
 	})
 
@@ -77,10 +78,10 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		s := CodeMonitors(db)
 		fixtures := s.insertTestMonitor(ctx, t)
 
-		action1, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action1, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
-		action2, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		action2, err := s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
 		err = s.DeleteWebhookActions(ctx, fixtures.monitor.ID, action1.ID)
@@ -105,7 +106,7 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 0, count)
 
-		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
 		count, err = s.CountWebhookActions(ctx, fixtures.monitor.ID)
@@ -125,10 +126,10 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		require.NoError(t, err)
 		require.Len(t, actions, 0)
 
-		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1)
+		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url1, "")
 		require.NoError(t, err)
 
-		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url2)
+		_, err = s.CreateWebhookAction(ctx, fixtures.monitor.ID, true, false, url2, "")
 		require.NoError(t, err)
 
 		actions2, err := s.ListWebhookActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
@@ -150,15 +151,15 @@ func TestCodeMonitorStoreWebhooks(t *testing.T) {
 		fixtures := s.insertTestMonitor(ctx1, t)
 		_ = s.insertTestMonitor(ctx2, t)
 
-		wa, err := s.CreateWebhookAction(ctx1, fixtures.monitor.ID, true, true, "https://true.com")
+		wa, err := s.CreateWebhookAction(ctx1, fixtures.monitor.ID, true, true, "https://true.com", "")
 		require.NoError(t, err)
 
 		// User1 can update it
-		_, err = s.UpdateWebhookAction(ctx1, wa.ID, true, true, "https://false.com")
+		_, err = s.UpdateWebhookAction(ctx1, wa.ID, true, true, "https://false.com", "")
 		require.NoError(t, err)
 
 		// User2 cannot update it
-		_, err = s.UpdateWebhookAction(ctx2, wa.ID, true, true, "https://truer.com")
+		_, err = s.UpdateWebhookAction(ctx2, wa.ID, true, true, "https://truer.com", "")
 		require.Error(t, err)
 
 		wa, err = s.GetWebhookAction(ctx1, wa.ID)
@@ -58,8 +58,8 @@ type CodeMonitorStore interface {
 	GetEmailAction(ctx context.Context, emailID int64) (*EmailAction, error)
 	ListEmailActions(context.Context, ListActionsOpts) ([]*EmailAction, error)
 
-	UpdateWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url string) (*WebhookAction, error)
-	CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url string) (*WebhookAction, error)
+	UpdateWebhookAction(_ context.Context, id int64, enabled, includeResults bool, url, payloadTemplate string) (*WebhookAction, error)
+	CreateWebhookAction(ctx context.Context, monitorID int64, enabled, includeResults bool, url, payloadTemplate string) (*WebhookAction, error)
 	DeleteWebhookActions(ctx context.Context, monitorID int64, ids ...int64) error
 	CountWebhookActions(ctx context.Context, monitorID int64) (int, error)
 	GetWebhookAction(ctx context.Context, id int64) (*WebhookAction, error)
@@ -72,6 +72,16 @@ type CodeMonitorStore interface {
 	GetSlackWebhookAction(ctx context.Context, id int64) (*SlackWebhookAction, error)
 	ListSlackWebhookActions(context.Context, ListActionsOpts) ([]*SlackWebhookAction, error)
 
+	UpdateIssueAction(_ context.Context, id int64, _ *IssueActionArgs) (*IssueAction, error)
+	CreateIssueAction(ctx context.Context, monitorID int64, _ *IssueActionArgs) (*IssueAction, error)
+	DeleteIssueActions(ctx context.Context, monitorID int64, ids ...int64) error
+	CountIssueActions(ctx context.Context, monitorID int64) (int, error)
+	GetIssueAction(ctx context.Context, id int64) (*IssueAction, error)
+	ListIssueActions(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	ClaimIssueMatches(ctx context.Context, issueID int64, keys []string) ([]string, error)
+	SetIssueMatchesURL(ctx context.Context, issueID int64, issueURL string, keys ...string) error
+	DeleteIssueMatches(ctx context.Context, issueID int64, keys ...string) error
+
 	CreateRecipient(ctx context.Context, emailID int64, userID, orgID *int32) (*Recipient, error)
 	DeleteRecipients(ctx context.Context, emailID int64) error
 	ListRecipients(context.Context, ListRecipientsOpts) ([]*Recipient, error)
@@ -82,6 +92,7 @@ type CodeMonitorStore interface {
 	GetActionJobMetadata(ctx context.Context, jobID int32) (*ActionJobMetadata, error)
 	GetActionJob(ctx context.Context, jobID int32) (*ActionJob, error)
 	EnqueueActionJobsForMonitor(ctx context.Context, monitorID int64, triggerJob int32) ([]*ActionJob, error)
+	EnqueueInsightAlertActionJobs(ctx context.Context, actions []InsightAlertAction) ([]*ActionJob, error)
 
 	// HasAnyLastSearched returns whether there have ever been any repo-aware code monitor
 	// searches executed for this code monitor. This should only be needed during the transition
@@ -90,6 +101,14 @@ type CodeMonitorStore interface {
 	HasAnyLastSearched(ctx context.Context, monitorID int64) (bool, error)
 	UpsertLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID, lastSearched []string) error
 	GetLastSearched(ctx context.Context, monitorID int64, repoID api.RepoID) ([]string, error)
+
+	// The match fingerprints of code monitors with content search queries identify
+	// the file and line matches that were found before, so that only new matches
+	// trigger the actions of the monitor.
+	ListMatchFingerprints(ctx context.Context, monitorID int64, fingerprints []string) ([]string, error)
+	UpsertMatchFingerprints(ctx context.Context, monitorID int64, fingerprints []string) error
+	DeleteMatchFingerprints(ctx context.Context, monitorID int64) error
+	DeleteStaleMatchFingerprints(ctx context.Context, retentionInDays int) error
 }
 
 // codeMonitorStore exposes methods to read and write codemonitors domain models
@@ -30,12 +30,18 @@ import (
 // github.com/sourcegraph/sourcegraph/enterprise/internal/database) used for
 // unit testing.
 type MockCodeMonitorStore struct {
+	// ClaimIssueMatchesFunc is an instance of a mock function object
+	// controlling the behavior of the method ClaimIssueMatches.
+	ClaimIssueMatchesFunc *CodeMonitorStoreClaimIssueMatchesFunc
 	// ClockFunc is an instance of a mock function object controlling the
 	// behavior of the method Clock.
 	ClockFunc *CodeMonitorStoreClockFunc
 	// CountActionJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountActionJobs.
 	CountActionJobsFunc *CodeMonitorStoreCountActionJobsFunc
+	// CountIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountIssueActions.
+	CountIssueActionsFunc *CodeMonitorStoreCountIssueActionsFunc
 	// CountMonitorsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountMonitors.
 	CountMonitorsFunc *CodeMonitorStoreCountMonitorsFunc
@@ -54,6 +60,9 @@ type MockCodeMonitorStore struct {
 	// CreateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateEmailAction.
 	CreateEmailActionFunc *CodeMonitorStoreCreateEmailActionFunc
+	// CreateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateIssueAction.
+	CreateIssueActionFunc *CodeMonitorStoreCreateIssueActionFunc
 	// CreateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateMonitor.
 	CreateMonitorFunc *CodeMonitorStoreCreateMonitorFunc
@@ -72,6 +81,15 @@ type MockCodeMonitorStore struct {
 	// DeleteEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteEmailActions.
 	DeleteEmailActionsFunc *CodeMonitorStoreDeleteEmailActionsFunc
+	// DeleteIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteIssueActions.
+	DeleteIssueActionsFunc *CodeMonitorStoreDeleteIssueActionsFunc
+	// DeleteIssueMatchesFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteIssueMatches.
+	DeleteIssueMatchesFunc *CodeMonitorStoreDeleteIssueMatchesFunc
+	// DeleteMatchFingerprintsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteMatchFingerprints.
+	DeleteMatchFingerprintsFunc *CodeMonitorStoreDeleteMatchFingerprintsFunc
 	// DeleteMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteMonitor.
 	DeleteMonitorFunc *CodeMonitorStoreDeleteMonitorFunc
@@ -85,6 +103,10 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// DeleteSlackWebhookActions.
 	DeleteSlackWebhookActionsFunc *CodeMonitorStoreDeleteSlackWebhookActionsFunc
+	// DeleteStaleMatchFingerprintsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// DeleteStaleMatchFingerprints.
+	DeleteStaleMatchFingerprintsFunc *CodeMonitorStoreDeleteStaleMatchFingerprintsFunc
 	// DeleteWebhookActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteWebhookActions.
 	DeleteWebhookActionsFunc *CodeMonitorStoreDeleteWebhookActionsFunc
@@ -95,6 +117,10 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// EnqueueActionJobsForMonitor.
 	EnqueueActionJobsForMonitorFunc *CodeMonitorStoreEnqueueActionJobsForMonitorFunc
+	// EnqueueInsightAlertActionJobsFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// EnqueueInsightAlertActionJobs.
+	EnqueueInsightAlertActionJobsFunc *CodeMonitorStoreEnqueueInsightAlertActionJobsFunc
 	// EnqueueQueryTriggerJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method EnqueueQueryTriggerJobs.
 	EnqueueQueryTriggerJobsFunc *CodeMonitorStoreEnqueueQueryTriggerJobsFunc
@@ -110,6 +136,9 @@ type MockCodeMonitorStore struct {
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
+	// GetIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetIssueAction.
+	GetIssueActionFunc *CodeMonitorStoreGetIssueActionFunc
 	// GetLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSearched.
 	GetLastSearchedFunc *CodeMonitorStoreGetLastSearchedFunc
@@ -141,6 +170,12 @@ type MockCodeMonitorStore struct {
 	// ListEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListEmailActions.
 	ListEmailActionsFunc *CodeMonitorStoreListEmailActionsFunc
+	// ListIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListIssueActions.
+	ListIssueActionsFunc *CodeMonitorStoreListIssueActionsFunc
+	// ListMatchFingerprintsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListMatchFingerprints.
+	ListMatchFingerprintsFunc *CodeMonitorStoreListMatchFingerprintsFunc
 	// ListMonitorsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListMonitors.
 	ListMonitorsFunc *CodeMonitorStoreListMonitorsFunc
@@ -163,6 +198,9 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// ResetQueryTriggerTimestamps.
 	ResetQueryTriggerTimestampsFunc *CodeMonitorStoreResetQueryTriggerTimestampsFunc
+	// SetIssueMatchesURLFunc is an instance of a mock function object
+	// controlling the behavior of the method SetIssueMatchesURL.
+	SetIssueMatchesURLFunc *CodeMonitorStoreSetIssueMatchesURLFunc
 	// SetQueryTriggerNextRunFunc is an instance of a mock function object
 	// controlling the behavior of the method SetQueryTriggerNextRun.
 	SetQueryTriggerNextRunFunc *CodeMonitorStoreSetQueryTriggerNextRunFunc
@@ -172,6 +210,9 @@ type MockCodeMonitorStore struct {
 	// UpdateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateEmailAction.
 	UpdateEmailActionFunc *CodeMonitorStoreUpdateEmailActionFunc
+	// UpdateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateIssueAction.
+	UpdateIssueActionFunc *CodeMonitorStoreUpdateIssueActionFunc
 	// UpdateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateMonitor.
 	UpdateMonitorFunc *CodeMonitorStoreUpdateMonitorFunc
@@ -194,6 +235,9 @@ type MockCodeMonitorStore struct {
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
+	// UpsertMatchFingerprintsFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertMatchFingerprints.
+	UpsertMatchFingerprintsFunc *CodeMonitorStoreUpsertMatchFingerprintsFunc
 }
 
 // NewMockCodeMonitorStore creates a new mock of the CodeMonitorStore
@@ -201,6 +245,11 @@ type MockCodeMonitorStore struct {
 // overwritten.
 func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 	return &MockCodeMonitorStore{
+		ClaimIssueMatchesFunc: &CodeMonitorStoreClaimIssueMatchesFunc{
+			defaultHook: func(context.Context, int64, []string) (r0 []string, r1 error) {
+				return
+			},
+		},
 		ClockFunc: &CodeMonitorStoreClockFunc{
 			defaultHook: func() (r0 func() time.Time) {
 				return
@@ -211,6 +260,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, int32) (r0 int32, r1 error) {
 				return
@@ -241,6 +295,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, *IssueActionArgs) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, MonitorArgs) (r0 *Monitor, r1 error) {
 				return
@@ -262,7 +321,7 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *WebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (r0 *WebhookAction, r1 error) {
 				return
 			},
 		},
@@ -271,6 +330,21 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
+		DeleteIssueMatchesFunc: &CodeMonitorStoreDeleteIssueMatchesFunc{
+			defaultHook: func(context.Context, int64, ...string) (r0 error) {
+				return
+			},
+		},
+		DeleteMatchFingerprintsFunc: &CodeMonitorStoreDeleteMatchFingerprintsFunc{
+			defaultHook: func(context.Context, int64) (r0 error) {
+				return
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -291,6 +365,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteStaleMatchFingerprintsFunc: &CodeMonitorStoreDeleteStaleMatchFingerprintsFunc{
+			defaultHook: func(context.Context, int) (r0 error) {
+				return
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
 				return
@@ -306,6 +385,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		EnqueueInsightAlertActionJobsFunc: &CodeMonitorStoreEnqueueInsightAlertActionJobsFunc{
+			defaultHook: func(context.Context, []InsightAlertAction) (r0 []*ActionJob, r1 error) {
+				return
+			},
+		},
 		EnqueueQueryTriggerJobsFunc: &CodeMonitorStoreEnqueueQueryTriggerJobsFunc{
 			defaultHook: func(context.Context) (r0 []*TriggerJob, r1 error) {
 				return
@@ -331,6 +415,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
 				return
@@ -381,6 +470,16 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) (r0 []*IssueAction, r1 error) {
+				return
+			},
+		},
+		ListMatchFingerprintsFunc: &CodeMonitorStoreListMatchFingerprintsFunc{
+			defaultHook: func(context.Context, int64, []string) (r0 []string, r1 error) {
+				return
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) (r0 []*Monitor, r1 error) {
 				return
@@ -416,6 +515,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		SetIssueMatchesURLFunc: &CodeMonitorStoreSetIssueMatchesURLFunc{
+			defaultHook: func(context.Context, int64, string, ...string) (r0 error) {
+				return
+			},
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: func(context.Context, int64, time.Time, time.Time) (r0 error) {
 				return
@@ -431,6 +535,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, *IssueActionArgs) (r0 *IssueAction, r1 error) {
+				return
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, MonitorArgs) (r0 *Monitor, r1 error) {
 				return
@@ -457,7 +566,7 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (r0 *WebhookAction, r1 error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (r0 *WebhookAction, r1 error) {
 				return
 			},
 		},
@@ -466,6 +575,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpsertMatchFingerprintsFunc: &CodeMonitorStoreUpsertMatchFingerprintsFunc{
+			defaultHook: func(context.Context, int64, []string) (r0 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -473,6 +587,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 // interface. All methods panic on invocation, unless overwritten.
 func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 	return &MockCodeMonitorStore{
+		ClaimIssueMatchesFunc: &CodeMonitorStoreClaimIssueMatchesFunc{
+			defaultHook: func(context.Context, int64, []string) ([]string, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ClaimIssueMatches")
+			},
+		},
 		ClockFunc: &CodeMonitorStoreClockFunc{
 			defaultHook: func() func() time.Time {
 				panic("unexpected invocation of MockCodeMonitorStore.Clock")
@@ -483,6 +602,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountActionJobs")
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountIssueActions")
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, int32) (int32, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountMonitors")
@@ -513,6 +637,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateEmailAction")
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, *IssueActionArgs) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateIssueAction")
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, MonitorArgs) (*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateMonitor")
@@ -534,7 +663,7 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		CreateWebhookActionFunc: &CodeMonitorStoreCreateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateWebhookAction")
 			},
 		},
@@ -543,6 +672,21 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteEmailActions")
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteIssueActions")
+			},
+		},
+		DeleteIssueMatchesFunc: &CodeMonitorStoreDeleteIssueMatchesFunc{
+			defaultHook: func(context.Context, int64, ...string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteIssueMatches")
+			},
+		},
+		DeleteMatchFingerprintsFunc: &CodeMonitorStoreDeleteMatchFingerprintsFunc{
+			defaultHook: func(context.Context, int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteMatchFingerprints")
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteMonitor")
@@ -563,6 +707,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteSlackWebhookActions")
 			},
 		},
+		DeleteStaleMatchFingerprintsFunc: &CodeMonitorStoreDeleteStaleMatchFingerprintsFunc{
+			defaultHook: func(context.Context, int) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteStaleMatchFingerprints")
+			},
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: func(context.Context, int64, ...int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteWebhookActions")
@@ -578,6 +727,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.EnqueueActionJobsForMonitor")
 			},
 		},
+		EnqueueInsightAlertActionJobsFunc: &CodeMonitorStoreEnqueueInsightAlertActionJobsFunc{
+			defaultHook: func(context.Context, []InsightAlertAction) ([]*ActionJob, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.EnqueueInsightAlertActionJobs")
+			},
+		},
 		EnqueueQueryTriggerJobsFunc: &CodeMonitorStoreEnqueueQueryTriggerJobsFunc{
 			defaultHook: func(context.Context) ([]*TriggerJob, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.EnqueueQueryTriggerJobs")
@@ -603,6 +757,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetIssueAction")
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetLastSearched")
@@ -653,6 +812,16 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListEmailActions")
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListIssueActions")
+			},
+		},
+		ListMatchFingerprintsFunc: &CodeMonitorStoreListMatchFingerprintsFunc{
+			defaultHook: func(context.Context, int64, []string) ([]string, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListMatchFingerprints")
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, ListMonitorsOpts) ([]*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListMonitors")
@@ -688,6 +857,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ResetQueryTriggerTimestamps")
 			},
 		},
+		SetIssueMatchesURLFunc: &CodeMonitorStoreSetIssueMatchesURLFunc{
+			defaultHook: func(context.Context, int64, string, ...string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.SetIssueMatchesURL")
+			},
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: func(context.Context, int64, time.Time, time.Time) error {
 				panic("unexpected invocation of MockCodeMonitorStore.SetQueryTriggerNextRun")
@@ -703,6 +877,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateEmailAction")
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, *IssueActionArgs) (*IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateIssueAction")
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, MonitorArgs) (*Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateMonitor")
@@ -729,7 +908,7 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateWebhookActionFunc: &CodeMonitorStoreUpdateWebhookActionFunc{
-			defaultHook: func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+			defaultHook: func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateWebhookAction")
 			},
 		},
@@ -738,6 +917,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
 			},
 		},
+		UpsertMatchFingerprintsFunc: &CodeMonitorStoreUpsertMatchFingerprintsFunc{
+			defaultHook: func(context.Context, int64, []string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertMatchFingerprints")
+			},
+		},
 	}
 }
 
@@ -746,12 +930,18 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 // implementation, unless overwritten.
 func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 	return &MockCodeMonitorStore{
+		ClaimIssueMatchesFunc: &CodeMonitorStoreClaimIssueMatchesFunc{
+			defaultHook: i.ClaimIssueMatches,
+		},
 		ClockFunc: &CodeMonitorStoreClockFunc{
 			defaultHook: i.Clock,
 		},
 		CountActionJobsFunc: &CodeMonitorStoreCountActionJobsFunc{
 			defaultHook: i.CountActionJobs,
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: i.CountIssueActions,
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: i.CountMonitors,
 		},
@@ -770,6 +960,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		CreateEmailActionFunc: &CodeMonitorStoreCreateEmailActionFunc{
 			defaultHook: i.CreateEmailAction,
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: i.CreateIssueAction,
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: i.CreateMonitor,
 		},
@@ -788,6 +981,15 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: i.DeleteEmailActions,
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: i.DeleteIssueActions,
+		},
+		DeleteIssueMatchesFunc: &CodeMonitorStoreDeleteIssueMatchesFunc{
+			defaultHook: i.DeleteIssueMatches,
+		},
+		DeleteMatchFingerprintsFunc: &CodeMonitorStoreDeleteMatchFingerprintsFunc{
+			defaultHook: i.DeleteMatchFingerprints,
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: i.DeleteMonitor,
 		},
@@ -800,6 +1002,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		DeleteSlackWebhookActionsFunc: &CodeMonitorStoreDeleteSlackWebhookActionsFunc{
 			defaultHook: i.DeleteSlackWebhookActions,
 		},
+		DeleteStaleMatchFingerprintsFunc: &CodeMonitorStoreDeleteStaleMatchFingerprintsFunc{
+			defaultHook: i.DeleteStaleMatchFingerprints,
+		},
 		DeleteWebhookActionsFunc: &CodeMonitorStoreDeleteWebhookActionsFunc{
 			defaultHook: i.DeleteWebhookActions,
 		},
@@ -809,6 +1014,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		EnqueueActionJobsForMonitorFunc: &CodeMonitorStoreEnqueueActionJobsForMonitorFunc{
 			defaultHook: i.EnqueueActionJobsForMonitor,
 		},
+		EnqueueInsightAlertActionJobsFunc: &CodeMonitorStoreEnqueueInsightAlertActionJobsFunc{
+			defaultHook: i.EnqueueInsightAlertActionJobs,
+		},
 		EnqueueQueryTriggerJobsFunc: &CodeMonitorStoreEnqueueQueryTriggerJobsFunc{
 			defaultHook: i.EnqueueQueryTriggerJobs,
 		},
@@ -824,6 +1032,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: i.GetIssueAction,
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: i.GetLastSearched,
 		},
@@ -854,6 +1065,12 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: i.ListEmailActions,
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: i.ListIssueActions,
+		},
+		ListMatchFingerprintsFunc: &CodeMonitorStoreListMatchFingerprintsFunc{
+			defaultHook: i.ListMatchFingerprints,
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: i.ListMonitors,
 		},
@@ -875,6 +1092,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: i.ResetQueryTriggerTimestamps,
 		},
+		SetIssueMatchesURLFunc: &CodeMonitorStoreSetIssueMatchesURLFunc{
+			defaultHook: i.SetIssueMatchesURL,
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: i.SetQueryTriggerNextRun,
 		},
@@ -884,6 +1104,9 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpdateEmailActionFunc: &CodeMonitorStoreUpdateEmailActionFunc{
 			defaultHook: i.UpdateEmailAction,
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: i.UpdateIssueAction,
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: i.UpdateMonitor,
 		},
@@ -905,7 +1128,124 @@ func NewMockCodeMonitorStoreFrom(i CodeMonitorStore) *MockCodeMonitorStore {
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
+		UpsertMatchFingerprintsFunc: &CodeMonitorStoreUpsertMatchFingerprintsFunc{
+			defaultHook: i.UpsertMatchFingerprints,
+		},
+	}
+}
+
+// CodeMonitorStoreClaimIssueMatchesFunc describes the behavior when the
+// ClaimIssueMatches method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreClaimIssueMatchesFunc struct {
+	defaultHook func(context.Context, int64, []string) ([]string, error)
+	hooks       []func(context.Context, int64, []string) ([]string, error)
+	history     []CodeMonitorStoreClaimIssueMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// ClaimIssueMatches delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ClaimIssueMatches(v0 context.Context, v1 int64, v2 []string) ([]string, error) {
+	r0, r1 := m.ClaimIssueMatchesFunc.nextHook()(v0, v1, v2)
+	m.ClaimIssueMatchesFunc.appendCall(CodeMonitorStoreClaimIssueMatchesFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ClaimIssueMatches
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreClaimIssueMatchesFunc) SetDefaultHook(hook func(context.Context, int64, []string) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ClaimIssueMatches method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreClaimIssueMatchesFunc) PushHook(hook func(context.Context, int64, []string) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreClaimIssueMatchesFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreClaimIssueMatchesFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int64, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreClaimIssueMatchesFunc) nextHook() func(context.Context, int64, []string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
 	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreClaimIssueMatchesFunc) appendCall(r0 CodeMonitorStoreClaimIssueMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreClaimIssueMatchesFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreClaimIssueMatchesFunc) History() []CodeMonitorStoreClaimIssueMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreClaimIssueMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreClaimIssueMatchesFuncCall is an object that describes an
+// invocation of method ClaimIssueMatches on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreClaimIssueMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreClaimIssueMatchesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreClaimIssueMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
 // CodeMonitorStoreClockFunc describes the behavior when the Clock method of
@@ -1118,36 +1458,37 @@ func (c CodeMonitorStoreCountActionJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCountMonitorsFunc describes the behavior when the
-// CountMonitors method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreCountIssueActionsFunc describes the behavior when the
+// CountIssueActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreCountMonitorsFunc struct {
-	defaultHook func(context.Context, int32) (int32, error)
-	hooks       []func(context.Context, int32) (int32, error)
-	history     []CodeMonitorStoreCountMonitorsFuncCall
+type CodeMonitorStoreCountIssueActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountIssueActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// CountMonitors delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CountMonitors(v0 context.Context, v1 int32) (int32, error) {
-	r0, r1 := m.CountMonitorsFunc.nextHook()(v0, v1)
-	m.CountMonitorsFunc.appendCall(CodeMonitorStoreCountMonitorsFuncCall{v0, v1, r0, r1})
+// CountIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountIssueActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountIssueActionsFunc.nextHook()(v0, v1)
+	m.CountIssueActionsFunc.appendCall(CodeMonitorStoreCountIssueActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the CountMonitors method
-// of the parent MockCodeMonitorStore instance is invoked and the hook queue
-// is empty.
-func (f *CodeMonitorStoreCountMonitorsFunc) SetDefaultHook(hook func(context.Context, int32) (int32, error)) {
+// SetDefaultHook sets function that is called when the CountIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// CountMonitors method of the parent MockCodeMonitorStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreCountMonitorsFunc) PushHook(hook func(context.Context, int32) (int32, error)) {
+// CountIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1155,20 +1496,20 @@ func (f *CodeMonitorStoreCountMonitorsFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreCountMonitorsFunc) SetDefaultReturn(r0 int32, r1 error) {
-	f.SetDefaultHook(func(context.Context, int32) (int32, error) {
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreCountMonitorsFunc) PushReturn(r0 int32, r1 error) {
-	f.PushHook(func(context.Context, int32) (int32, error) {
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCountMonitorsFunc) nextHook() func(context.Context, int32) (int32, error) {
+func (f *CodeMonitorStoreCountIssueActionsFunc) nextHook() func(context.Context, int64) (int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1181,36 +1522,36 @@ func (f *CodeMonitorStoreCountMonitorsFunc) nextHook() func(context.Context, int
 	return hook
 }
 
-func (f *CodeMonitorStoreCountMonitorsFunc) appendCall(r0 CodeMonitorStoreCountMonitorsFuncCall) {
+func (f *CodeMonitorStoreCountIssueActionsFunc) appendCall(r0 CodeMonitorStoreCountIssueActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreCountMonitorsFuncCall
+// History returns a sequence of CodeMonitorStoreCountIssueActionsFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreCountMonitorsFunc) History() []CodeMonitorStoreCountMonitorsFuncCall {
+func (f *CodeMonitorStoreCountIssueActionsFunc) History() []CodeMonitorStoreCountIssueActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreCountMonitorsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreCountIssueActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreCountMonitorsFuncCall is an object that describes an
-// invocation of method CountMonitors on an instance of
+// CodeMonitorStoreCountIssueActionsFuncCall is an object that describes an
+// invocation of method CountIssueActions on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreCountMonitorsFuncCall struct {
+type CodeMonitorStoreCountIssueActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int32
+	Result0 int
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -1218,40 +1559,150 @@ type CodeMonitorStoreCountMonitorsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreCountMonitorsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreCountMonitorsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreCountQueryTriggerJobsFunc describes the behavior when the
-// CountQueryTriggerJobs method of the parent MockCodeMonitorStore instance
-// is invoked.
-type CodeMonitorStoreCountQueryTriggerJobsFunc struct {
-	defaultHook func(context.Context, int64) (int32, error)
-	hooks       []func(context.Context, int64) (int32, error)
-	history     []CodeMonitorStoreCountQueryTriggerJobsFuncCall
+// CodeMonitorStoreCountMonitorsFunc describes the behavior when the
+// CountMonitors method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCountMonitorsFunc struct {
+	defaultHook func(context.Context, int32) (int32, error)
+	hooks       []func(context.Context, int32) (int32, error)
+	history     []CodeMonitorStoreCountMonitorsFuncCall
 	mutex       sync.Mutex
 }
 
-// CountQueryTriggerJobs delegates to the next hook function in the queue
-// and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CountQueryTriggerJobs(v0 context.Context, v1 int64) (int32, error) {
-	r0, r1 := m.CountQueryTriggerJobsFunc.nextHook()(v0, v1)
-	m.CountQueryTriggerJobsFunc.appendCall(CodeMonitorStoreCountQueryTriggerJobsFuncCall{v0, v1, r0, r1})
+// CountMonitors delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountMonitors(v0 context.Context, v1 int32) (int32, error) {
+	r0, r1 := m.CountMonitorsFunc.nextHook()(v0, v1)
+	m.CountMonitorsFunc.appendCall(CodeMonitorStoreCountMonitorsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the
-// CountQueryTriggerJobs method of the parent MockCodeMonitorStore instance
-// is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreCountQueryTriggerJobsFunc) SetDefaultHook(hook func(context.Context, int64) (int32, error)) {
-	f.defaultHook = hook
-}
+// SetDefaultHook sets function that is called when the CountMonitors method
+// of the parent MockCodeMonitorStore instance is invoked and the hook queue
+// is empty.
+func (f *CodeMonitorStoreCountMonitorsFunc) SetDefaultHook(hook func(context.Context, int32) (int32, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountMonitors method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreCountMonitorsFunc) PushHook(hook func(context.Context, int32) (int32, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountMonitorsFunc) SetDefaultReturn(r0 int32, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32) (int32, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountMonitorsFunc) PushReturn(r0 int32, r1 error) {
+	f.PushHook(func(context.Context, int32) (int32, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountMonitorsFunc) nextHook() func(context.Context, int32) (int32, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountMonitorsFunc) appendCall(r0 CodeMonitorStoreCountMonitorsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCountMonitorsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCountMonitorsFunc) History() []CodeMonitorStoreCountMonitorsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountMonitorsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountMonitorsFuncCall is an object that describes an
+// invocation of method CountMonitors on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCountMonitorsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int32
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountMonitorsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountMonitorsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreCountQueryTriggerJobsFunc describes the behavior when the
+// CountQueryTriggerJobs method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreCountQueryTriggerJobsFunc struct {
+	defaultHook func(context.Context, int64) (int32, error)
+	hooks       []func(context.Context, int64) (int32, error)
+	history     []CodeMonitorStoreCountQueryTriggerJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountQueryTriggerJobs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountQueryTriggerJobs(v0 context.Context, v1 int64) (int32, error) {
+	r0, r1 := m.CountQueryTriggerJobsFunc.nextHook()(v0, v1)
+	m.CountQueryTriggerJobsFunc.appendCall(CodeMonitorStoreCountQueryTriggerJobsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// CountQueryTriggerJobs method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreCountQueryTriggerJobsFunc) SetDefaultHook(hook func(context.Context, int64) (int32, error)) {
+	f.defaultHook = hook
+}
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
 // CountQueryTriggerJobs method of the parent MockCodeMonitorStore instance
@@ -1788,6 +2239,120 @@ func (c CodeMonitorStoreCreateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCreateIssueActionFunc describes the behavior when the
+// CreateIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCreateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, *IssueActionArgs) (*IssueAction, error)
+	hooks       []func(context.Context, int64, *IssueActionArgs) (*IssueAction, error)
+	history     []CodeMonitorStoreCreateIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateIssueAction(v0 context.Context, v1 int64, v2 *IssueActionArgs) (*IssueAction, error) {
+	r0, r1 := m.CreateIssueActionFunc.nextHook()(v0, v1, v2)
+	m.CreateIssueActionFunc.appendCall(CodeMonitorStoreCreateIssueActionFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, *IssueActionArgs) (*IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushHook(hook func(context.Context, int64, *IssueActionArgs) (*IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, *IssueActionArgs) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, *IssueActionArgs) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateIssueActionFunc) nextHook() func(context.Context, int64, *IssueActionArgs) (*IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateIssueActionFunc) appendCall(r0 CodeMonitorStoreCreateIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCreateIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCreateIssueActionFunc) History() []CodeMonitorStoreCreateIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateIssueActionFuncCall is an object that describes an
+// invocation of method CreateIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCreateIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *IssueActionArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCreateMonitorFunc describes the behavior when the
 // CreateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -2254,24 +2819,24 @@ func (c CodeMonitorStoreCreateSlackWebhookActionFuncCall) Results() []interface{
 // CreateWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
 type CodeMonitorStoreCreateWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error)
 	history     []CodeMonitorStoreCreateWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // CreateWebhookAction delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
-	r0, r1 := m.CreateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.CreateWebhookActionFunc.appendCall(CodeMonitorStoreCreateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) CreateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 string) (*WebhookAction, error) {
+	r0, r1 := m.CreateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CreateWebhookActionFunc.appendCall(CodeMonitorStoreCreateWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the CreateWebhookAction
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -2280,7 +2845,7 @@ func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultHook(hook func(conte
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2289,19 +2854,19 @@ func (f *CodeMonitorStoreCreateWebhookActionFunc) PushHook(hook func(context.Con
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreCreateWebhookActionFunc) SetDefaultReturn(r0 *WebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreCreateWebhookActionFunc) PushReturn(r0 *WebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+func (f *CodeMonitorStoreCreateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2350,6 +2915,9 @@ type CodeMonitorStoreCreateWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *WebhookAction
@@ -2361,7 +2929,7 @@ type CodeMonitorStoreCreateWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreCreateWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -2481,36 +3049,37 @@ func (c CodeMonitorStoreDeleteEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
-// DeleteMonitor method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreDeleteIssueActionsFunc describes the behavior when the
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreDeleteMonitorFunc struct {
-	defaultHook func(context.Context, int64) error
-	hooks       []func(context.Context, int64) error
-	history     []CodeMonitorStoreDeleteMonitorFuncCall
+type CodeMonitorStoreDeleteIssueActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteIssueActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// DeleteMonitor delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) DeleteMonitor(v0 context.Context, v1 int64) error {
-	r0 := m.DeleteMonitorFunc.nextHook()(v0, v1)
-	m.DeleteMonitorFunc.appendCall(CodeMonitorStoreDeleteMonitorFuncCall{v0, v1, r0})
+// DeleteIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteIssueActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteIssueActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteIssueActionsFunc.appendCall(CodeMonitorStoreDeleteIssueActionsFuncCall{v0, v1, v2, r0})
 	return r0
 }
 
-// SetDefaultHook sets function that is called when the DeleteMonitor method
-// of the parent MockCodeMonitorStore instance is invoked and the hook queue
-// is empty.
-func (f *CodeMonitorStoreDeleteMonitorFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+// SetDefaultHook sets function that is called when the DeleteIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DeleteMonitor method of the parent MockCodeMonitorStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreDeleteMonitorFunc) PushHook(hook func(context.Context, int64) error) {
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2518,20 +3087,20 @@ func (f *CodeMonitorStoreDeleteMonitorFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreDeleteMonitorFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64) error {
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreDeleteMonitorFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64) error {
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreDeleteMonitorFunc) nextHook() func(context.Context, int64) error {
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2544,49 +3113,393 @@ func (f *CodeMonitorStoreDeleteMonitorFunc) nextHook() func(context.Context, int
 	return hook
 }
 
-func (f *CodeMonitorStoreDeleteMonitorFunc) appendCall(r0 CodeMonitorStoreDeleteMonitorFuncCall) {
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) appendCall(r0 CodeMonitorStoreDeleteIssueActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreDeleteMonitorFuncCall
+// History returns a sequence of CodeMonitorStoreDeleteIssueActionsFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreDeleteMonitorFunc) History() []CodeMonitorStoreDeleteMonitorFuncCall {
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) History() []CodeMonitorStoreDeleteIssueActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreDeleteMonitorFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreDeleteIssueActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreDeleteMonitorFuncCall is an object that describes an
-// invocation of method DeleteMonitor on an instance of
+// CodeMonitorStoreDeleteIssueActionsFuncCall is an object that describes an
+// invocation of method DeleteIssueActions on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreDeleteMonitorFuncCall struct {
+type CodeMonitorStoreDeleteIssueActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
 	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation.
-func (c CodeMonitorStoreDeleteMonitorFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
-}
-
-// Results returns an interface slice containing the results of this
-// invocation.
-func (c CodeMonitorStoreDeleteMonitorFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
-}
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteIssueMatchesFunc describes the behavior when the
+// DeleteIssueMatches method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteIssueMatchesFunc struct {
+	defaultHook func(context.Context, int64, ...string) error
+	hooks       []func(context.Context, int64, ...string) error
+	history     []CodeMonitorStoreDeleteIssueMatchesFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteIssueMatches delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteIssueMatches(v0 context.Context, v1 int64, v2 ...string) error {
+	r0 := m.DeleteIssueMatchesFunc.nextHook()(v0, v1, v2...)
+	m.DeleteIssueMatchesFunc.appendCall(CodeMonitorStoreDeleteIssueMatchesFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteIssueMatches
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteIssueMatchesFunc) SetDefaultHook(hook func(context.Context, int64, ...string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteIssueMatches method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteIssueMatchesFunc) PushHook(hook func(context.Context, int64, ...string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteIssueMatchesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteIssueMatchesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteIssueMatchesFunc) nextHook() func(context.Context, int64, ...string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteIssueMatchesFunc) appendCall(r0 CodeMonitorStoreDeleteIssueMatchesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreDeleteIssueMatchesFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreDeleteIssueMatchesFunc) History() []CodeMonitorStoreDeleteIssueMatchesFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteIssueMatchesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteIssueMatchesFuncCall is an object that describes an
+// invocation of method DeleteIssueMatches on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteIssueMatchesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteIssueMatchesFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteIssueMatchesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteMatchFingerprintsFunc describes the behavior when
+// the DeleteMatchFingerprints method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteMatchFingerprintsFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []CodeMonitorStoreDeleteMatchFingerprintsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteMatchFingerprints delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteMatchFingerprints(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteMatchFingerprintsFunc.nextHook()(v0, v1)
+	m.DeleteMatchFingerprintsFunc.appendCall(CodeMonitorStoreDeleteMatchFingerprintsFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteMatchFingerprints method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteMatchFingerprintsFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteMatchFingerprints method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteMatchFingerprintsFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteMatchFingerprintsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteMatchFingerprintsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteMatchFingerprintsFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteMatchFingerprintsFunc) appendCall(r0 CodeMonitorStoreDeleteMatchFingerprintsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteMatchFingerprintsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteMatchFingerprintsFunc) History() []CodeMonitorStoreDeleteMatchFingerprintsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteMatchFingerprintsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteMatchFingerprintsFuncCall is an object that
+// describes an invocation of method DeleteMatchFingerprints on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteMatchFingerprintsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteMatchFingerprintsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteMatchFingerprintsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
+// DeleteMonitor method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteMonitorFunc struct {
+	defaultHook func(context.Context, int64) error
+	hooks       []func(context.Context, int64) error
+	history     []CodeMonitorStoreDeleteMonitorFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteMonitor delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteMonitor(v0 context.Context, v1 int64) error {
+	r0 := m.DeleteMonitorFunc.nextHook()(v0, v1)
+	m.DeleteMonitorFunc.appendCall(CodeMonitorStoreDeleteMonitorFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteMonitor method
+// of the parent MockCodeMonitorStore instance is invoked and the hook queue
+// is empty.
+func (f *CodeMonitorStoreDeleteMonitorFunc) SetDefaultHook(hook func(context.Context, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteMonitor method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreDeleteMonitorFunc) PushHook(hook func(context.Context, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteMonitorFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteMonitorFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteMonitorFunc) nextHook() func(context.Context, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteMonitorFunc) appendCall(r0 CodeMonitorStoreDeleteMonitorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreDeleteMonitorFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreDeleteMonitorFunc) History() []CodeMonitorStoreDeleteMonitorFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteMonitorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteMonitorFuncCall is an object that describes an
+// invocation of method DeleteMonitor on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteMonitorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteMonitorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteMonitorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
 
 // CodeMonitorStoreDeleteOldTriggerJobsFunc describes the behavior when the
 // DeleteOldTriggerJobs method of the parent MockCodeMonitorStore instance
@@ -2793,49 +3706,168 @@ type CodeMonitorStoreDeleteRecipientsFuncCall struct {
 	Result0 error
 }
 
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c CodeMonitorStoreDeleteRecipientsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreDeleteRecipientsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteRecipientsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreDeleteSlackWebhookActionsFunc describes the behavior when
+// the DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreDeleteSlackWebhookActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteSlackWebhookActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteSlackWebhookActions delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteSlackWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteSlackWebhookActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteSlackWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteSlackWebhookActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreDeleteSlackWebhookActionsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) History() []CodeMonitorStoreDeleteSlackWebhookActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteSlackWebhookActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteSlackWebhookActionsFuncCall is an object that
+// describes an invocation of method DeleteSlackWebhookActions on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreDeleteSlackWebhookActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreDeleteRecipientsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// CodeMonitorStoreDeleteSlackWebhookActionsFunc describes the behavior when
-// the DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
-// instance is invoked.
-type CodeMonitorStoreDeleteSlackWebhookActionsFunc struct {
-	defaultHook func(context.Context, int64, ...int64) error
-	hooks       []func(context.Context, int64, ...int64) error
-	history     []CodeMonitorStoreDeleteSlackWebhookActionsFuncCall
+// CodeMonitorStoreDeleteStaleMatchFingerprintsFunc describes the behavior
+// when the DeleteStaleMatchFingerprints method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreDeleteStaleMatchFingerprintsFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall
 	mutex       sync.Mutex
 }
 
-// DeleteSlackWebhookActions delegates to the next hook function in the
+// DeleteStaleMatchFingerprints delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) DeleteSlackWebhookActions(v0 context.Context, v1 int64, v2 ...int64) error {
-	r0 := m.DeleteSlackWebhookActionsFunc.nextHook()(v0, v1, v2...)
-	m.DeleteSlackWebhookActionsFunc.appendCall(CodeMonitorStoreDeleteSlackWebhookActionsFuncCall{v0, v1, v2, r0})
+func (m *MockCodeMonitorStore) DeleteStaleMatchFingerprints(v0 context.Context, v1 int) error {
+	r0 := m.DeleteStaleMatchFingerprintsFunc.nextHook()(v0, v1)
+	m.DeleteStaleMatchFingerprintsFunc.appendCall(CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall{v0, v1, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the
-// DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// DeleteStaleMatchFingerprints method of the parent MockCodeMonitorStore
 // instance is invoked and the hook queue is empty.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+func (f *CodeMonitorStoreDeleteStaleMatchFingerprintsFunc) SetDefaultHook(hook func(context.Context, int) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// DeleteSlackWebhookActions method of the parent MockCodeMonitorStore
+// DeleteStaleMatchFingerprints method of the parent MockCodeMonitorStore
 // instance invokes the hook at the front of the queue and discards it.
 // After the queue is empty, the default hook function is invoked for any
 // future action.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+func (f *CodeMonitorStoreDeleteStaleMatchFingerprintsFunc) PushHook(hook func(context.Context, int) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -2843,20 +3875,20 @@ func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushHook(hook func(conte
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+func (f *CodeMonitorStoreDeleteStaleMatchFingerprintsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, ...int64) error {
+func (f *CodeMonitorStoreDeleteStaleMatchFingerprintsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+func (f *CodeMonitorStoreDeleteStaleMatchFingerprintsFunc) nextHook() func(context.Context, int) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -2869,58 +3901,48 @@ func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) nextHook() func(context.
 	return hook
 }
 
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) appendCall(r0 CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) {
+func (f *CodeMonitorStoreDeleteStaleMatchFingerprintsFunc) appendCall(r0 CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
 // History returns a sequence of
-// CodeMonitorStoreDeleteSlackWebhookActionsFuncCall objects describing the
-// invocations of this function.
-func (f *CodeMonitorStoreDeleteSlackWebhookActionsFunc) History() []CodeMonitorStoreDeleteSlackWebhookActionsFuncCall {
+// CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreDeleteStaleMatchFingerprintsFunc) History() []CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreDeleteSlackWebhookActionsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreDeleteSlackWebhookActionsFuncCall is an object that
-// describes an invocation of method DeleteSlackWebhookActions on an
+// CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall is an object that
+// describes an invocation of method DeleteStaleMatchFingerprints on an
 // instance of MockCodeMonitorStore.
-type CodeMonitorStoreDeleteSlackWebhookActionsFuncCall struct {
+type CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
-	// Arg2 is a slice containing the values of the variadic arguments
-	// passed to this method invocation.
-	Arg2 []int64
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
 }
 
 // Args returns an interface slice containing the arguments of this
-// invocation. The variadic slice argument is flattened in this array such
-// that one positional argument and three variadic arguments would result in
-// a slice of four, not two.
-func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Args() []interface{} {
-	trailing := []interface{}{}
-	for _, val := range c.Arg2 {
-		trailing = append(trailing, val)
-	}
-
-	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+// invocation.
+func (c CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreDeleteSlackWebhookActionsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreDeleteStaleMatchFingerprintsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -3260,6 +4282,118 @@ func (c CodeMonitorStoreEnqueueActionJobsForMonitorFuncCall) Results() []interfa
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreEnqueueInsightAlertActionJobsFunc describes the behavior
+// when the EnqueueInsightAlertActionJobs method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreEnqueueInsightAlertActionJobsFunc struct {
+	defaultHook func(context.Context, []InsightAlertAction) ([]*ActionJob, error)
+	hooks       []func(context.Context, []InsightAlertAction) ([]*ActionJob, error)
+	history     []CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// EnqueueInsightAlertActionJobs delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) EnqueueInsightAlertActionJobs(v0 context.Context, v1 []InsightAlertAction) ([]*ActionJob, error) {
+	r0, r1 := m.EnqueueInsightAlertActionJobsFunc.nextHook()(v0, v1)
+	m.EnqueueInsightAlertActionJobsFunc.appendCall(CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// EnqueueInsightAlertActionJobs method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreEnqueueInsightAlertActionJobsFunc) SetDefaultHook(hook func(context.Context, []InsightAlertAction) ([]*ActionJob, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EnqueueInsightAlertActionJobs method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreEnqueueInsightAlertActionJobsFunc) PushHook(hook func(context.Context, []InsightAlertAction) ([]*ActionJob, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreEnqueueInsightAlertActionJobsFunc) SetDefaultReturn(r0 []*ActionJob, r1 error) {
+	f.SetDefaultHook(func(context.Context, []InsightAlertAction) ([]*ActionJob, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreEnqueueInsightAlertActionJobsFunc) PushReturn(r0 []*ActionJob, r1 error) {
+	f.PushHook(func(context.Context, []InsightAlertAction) ([]*ActionJob, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreEnqueueInsightAlertActionJobsFunc) nextHook() func(context.Context, []InsightAlertAction) ([]*ActionJob, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreEnqueueInsightAlertActionJobsFunc) appendCall(r0 CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreEnqueueInsightAlertActionJobsFunc) History() []CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall is an object that
+// describes an invocation of method EnqueueInsightAlertActionJobs on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []InsightAlertAction
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*ActionJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreEnqueueInsightAlertActionJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreEnqueueQueryTriggerJobsFunc describes the behavior when
 // the EnqueueQueryTriggerJobs method of the parent MockCodeMonitorStore
 // instance is invoked.
@@ -3658,26 +4792,136 @@ func (f *CodeMonitorStoreGetActionJobMetadataFunc) appendCall(r0 CodeMonitorStor
 // invocations of this function.
 func (f *CodeMonitorStoreGetActionJobMetadataFunc) History() []CodeMonitorStoreGetActionJobMetadataFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetActionJobMetadataFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
+// an invocation of method GetActionJobMetadata on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *ActionJobMetadata
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreGetEmailActionFunc describes the behavior when the
+// GetEmailAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetEmailActionFunc struct {
+	defaultHook func(context.Context, int64) (*EmailAction, error)
+	hooks       []func(context.Context, int64) (*EmailAction, error)
+	history     []CodeMonitorStoreGetEmailActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetEmailAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetEmailAction(v0 context.Context, v1 int64) (*EmailAction, error) {
+	r0, r1 := m.GetEmailActionFunc.nextHook()(v0, v1)
+	m.GetEmailActionFunc.appendCall(CodeMonitorStoreGetEmailActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetEmailAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultHook(hook func(context.Context, int64) (*EmailAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetEmailAction method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreGetEmailActionFunc) PushHook(hook func(context.Context, int64) (*EmailAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultReturn(r0 *EmailAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*EmailAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetEmailActionFunc) PushReturn(r0 *EmailAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*EmailAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetEmailActionFunc) nextHook() func(context.Context, int64) (*EmailAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetEmailActionFunc) appendCall(r0 CodeMonitorStoreGetEmailActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreGetEmailActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetEmailActionFunc) History() []CodeMonitorStoreGetEmailActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetEmailActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetActionJobMetadataFuncCall is an object that describes
-// an invocation of method GetActionJobMetadata on an instance of
+// CodeMonitorStoreGetEmailActionFuncCall is an object that describes an
+// invocation of method GetEmailAction on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
+type CodeMonitorStoreGetEmailActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int32
+	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *ActionJobMetadata
+	Result0 *EmailAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3685,46 +4929,46 @@ type CodeMonitorStoreGetActionJobMetadataFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetEmailActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetActionJobMetadataFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreGetEmailActionFunc describes the behavior when the
-// GetEmailAction method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreGetIssueActionFunc describes the behavior when the
+// GetIssueAction method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreGetEmailActionFunc struct {
-	defaultHook func(context.Context, int64) (*EmailAction, error)
-	hooks       []func(context.Context, int64) (*EmailAction, error)
-	history     []CodeMonitorStoreGetEmailActionFuncCall
+type CodeMonitorStoreGetIssueActionFunc struct {
+	defaultHook func(context.Context, int64) (*IssueAction, error)
+	hooks       []func(context.Context, int64) (*IssueAction, error)
+	history     []CodeMonitorStoreGetIssueActionFuncCall
 	mutex       sync.Mutex
 }
 
-// GetEmailAction delegates to the next hook function in the queue and
+// GetIssueAction delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) GetEmailAction(v0 context.Context, v1 int64) (*EmailAction, error) {
-	r0, r1 := m.GetEmailActionFunc.nextHook()(v0, v1)
-	m.GetEmailActionFunc.appendCall(CodeMonitorStoreGetEmailActionFuncCall{v0, v1, r0, r1})
+func (m *MockCodeMonitorStore) GetIssueAction(v0 context.Context, v1 int64) (*IssueAction, error) {
+	r0, r1 := m.GetIssueActionFunc.nextHook()(v0, v1)
+	m.GetIssueActionFunc.appendCall(CodeMonitorStoreGetIssueActionFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetEmailAction
+// SetDefaultHook sets function that is called when the GetIssueAction
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultHook(hook func(context.Context, int64) (*EmailAction, error)) {
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultHook(hook func(context.Context, int64) (*IssueAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetEmailAction method of the parent MockCodeMonitorStore instance invokes
+// GetIssueAction method of the parent MockCodeMonitorStore instance invokes
 // the hook at the front of the queue and discards it. After the queue is
 // empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreGetEmailActionFunc) PushHook(hook func(context.Context, int64) (*EmailAction, error)) {
+func (f *CodeMonitorStoreGetIssueActionFunc) PushHook(hook func(context.Context, int64) (*IssueAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -3732,20 +4976,20 @@ func (f *CodeMonitorStoreGetEmailActionFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreGetEmailActionFunc) SetDefaultReturn(r0 *EmailAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (*EmailAction, error) {
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreGetEmailActionFunc) PushReturn(r0 *EmailAction, r1 error) {
-	f.PushHook(func(context.Context, int64) (*EmailAction, error) {
+func (f *CodeMonitorStoreGetIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*IssueAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreGetEmailActionFunc) nextHook() func(context.Context, int64) (*EmailAction, error) {
+func (f *CodeMonitorStoreGetIssueActionFunc) nextHook() func(context.Context, int64) (*IssueAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -3758,27 +5002,27 @@ func (f *CodeMonitorStoreGetEmailActionFunc) nextHook() func(context.Context, in
 	return hook
 }
 
-func (f *CodeMonitorStoreGetEmailActionFunc) appendCall(r0 CodeMonitorStoreGetEmailActionFuncCall) {
+func (f *CodeMonitorStoreGetIssueActionFunc) appendCall(r0 CodeMonitorStoreGetIssueActionFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreGetEmailActionFuncCall
+// History returns a sequence of CodeMonitorStoreGetIssueActionFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreGetEmailActionFunc) History() []CodeMonitorStoreGetEmailActionFuncCall {
+func (f *CodeMonitorStoreGetIssueActionFunc) History() []CodeMonitorStoreGetIssueActionFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreGetEmailActionFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreGetIssueActionFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreGetEmailActionFuncCall is an object that describes an
-// invocation of method GetEmailAction on an instance of
+// CodeMonitorStoreGetIssueActionFuncCall is an object that describes an
+// invocation of method GetIssueAction on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreGetEmailActionFuncCall struct {
+type CodeMonitorStoreGetIssueActionFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -3787,7 +5031,7 @@ type CodeMonitorStoreGetEmailActionFuncCall struct {
 	Arg1 int64
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 *EmailAction
+	Result0 *IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -3795,13 +5039,13 @@ type CodeMonitorStoreGetEmailActionFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreGetEmailActionFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreGetIssueActionFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreGetIssueActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -4558,52 +5802,273 @@ func (f *CodeMonitorStoreHandleFunc) History() []CodeMonitorStoreHandleFuncCall
 type CodeMonitorStoreHandleFuncCall struct {
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 basestore.TransactableHandle
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreHasAnyLastSearchedFunc describes the behavior when the
+// HasAnyLastSearched method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreHasAnyLastSearchedFunc struct {
+	defaultHook func(context.Context, int64) (bool, error)
+	hooks       []func(context.Context, int64) (bool, error)
+	history     []CodeMonitorStoreHasAnyLastSearchedFuncCall
+	mutex       sync.Mutex
+}
+
+// HasAnyLastSearched delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) HasAnyLastSearched(v0 context.Context, v1 int64) (bool, error) {
+	r0, r1 := m.HasAnyLastSearchedFunc.nextHook()(v0, v1)
+	m.HasAnyLastSearchedFunc.appendCall(CodeMonitorStoreHasAnyLastSearchedFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the HasAnyLastSearched
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreHasAnyLastSearchedFunc) SetDefaultHook(hook func(context.Context, int64) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// HasAnyLastSearched method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreHasAnyLastSearchedFunc) PushHook(hook func(context.Context, int64) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreHasAnyLastSearchedFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreHasAnyLastSearchedFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int64) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreHasAnyLastSearchedFunc) nextHook() func(context.Context, int64) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreHasAnyLastSearchedFunc) appendCall(r0 CodeMonitorStoreHasAnyLastSearchedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreHasAnyLastSearchedFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreHasAnyLastSearchedFunc) History() []CodeMonitorStoreHasAnyLastSearchedFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreHasAnyLastSearchedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreHasAnyLastSearchedFuncCall is an object that describes an
+// invocation of method HasAnyLastSearched on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreHasAnyLastSearchedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreHasAnyLastSearchedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreHasAnyLastSearchedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// CodeMonitorStoreListActionJobsFunc describes the behavior when the
+// ListActionJobs method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreListActionJobsFunc struct {
+	defaultHook func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)
+	hooks       []func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)
+	history     []CodeMonitorStoreListActionJobsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListActionJobs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListActionJobs(v0 context.Context, v1 ListActionJobsOpts) ([]*ActionJob, error) {
+	r0, r1 := m.ListActionJobsFunc.nextHook()(v0, v1)
+	m.ListActionJobsFunc.appendCall(CodeMonitorStoreListActionJobsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListActionJobs
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListActionJobsFunc) SetDefaultHook(hook func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListActionJobs method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreListActionJobsFunc) PushHook(hook func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListActionJobsFunc) SetDefaultReturn(r0 []*ActionJob, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListActionJobsFunc) PushReturn(r0 []*ActionJob, r1 error) {
+	f.PushHook(func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListActionJobsFunc) nextHook() func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListActionJobsFunc) appendCall(r0 CodeMonitorStoreListActionJobsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreListActionJobsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreListActionJobsFunc) History() []CodeMonitorStoreListActionJobsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListActionJobsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListActionJobsFuncCall is an object that describes an
+// invocation of method ListActionJobs on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListActionJobsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 ListActionJobsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*ActionJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
 }
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreHandleFuncCall) Args() []interface{} {
-	return []interface{}{}
+func (c CodeMonitorStoreListActionJobsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreHandleFuncCall) Results() []interface{} {
-	return []interface{}{c.Result0}
+func (c CodeMonitorStoreListActionJobsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreHasAnyLastSearchedFunc describes the behavior when the
-// HasAnyLastSearched method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreListEmailActionsFunc describes the behavior when the
+// ListEmailActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreHasAnyLastSearchedFunc struct {
-	defaultHook func(context.Context, int64) (bool, error)
-	hooks       []func(context.Context, int64) (bool, error)
-	history     []CodeMonitorStoreHasAnyLastSearchedFuncCall
+type CodeMonitorStoreListEmailActionsFunc struct {
+	defaultHook func(context.Context, ListActionsOpts) ([]*EmailAction, error)
+	hooks       []func(context.Context, ListActionsOpts) ([]*EmailAction, error)
+	history     []CodeMonitorStoreListEmailActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// HasAnyLastSearched delegates to the next hook function in the queue and
+// ListEmailActions delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) HasAnyLastSearched(v0 context.Context, v1 int64) (bool, error) {
-	r0, r1 := m.HasAnyLastSearchedFunc.nextHook()(v0, v1)
-	m.HasAnyLastSearchedFunc.appendCall(CodeMonitorStoreHasAnyLastSearchedFuncCall{v0, v1, r0, r1})
+func (m *MockCodeMonitorStore) ListEmailActions(v0 context.Context, v1 ListActionsOpts) ([]*EmailAction, error) {
+	r0, r1 := m.ListEmailActionsFunc.nextHook()(v0, v1)
+	m.ListEmailActionsFunc.appendCall(CodeMonitorStoreListEmailActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the HasAnyLastSearched
+// SetDefaultHook sets function that is called when the ListEmailActions
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreHasAnyLastSearchedFunc) SetDefaultHook(hook func(context.Context, int64) (bool, error)) {
+func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*EmailAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// HasAnyLastSearched method of the parent MockCodeMonitorStore instance
+// ListEmailActions method of the parent MockCodeMonitorStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreHasAnyLastSearchedFunc) PushHook(hook func(context.Context, int64) (bool, error)) {
+func (f *CodeMonitorStoreListEmailActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*EmailAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4611,20 +6076,20 @@ func (f *CodeMonitorStoreHasAnyLastSearchedFunc) PushHook(hook func(context.Cont
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreHasAnyLastSearchedFunc) SetDefaultReturn(r0 bool, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64) (bool, error) {
+func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultReturn(r0 []*EmailAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreHasAnyLastSearchedFunc) PushReturn(r0 bool, r1 error) {
-	f.PushHook(func(context.Context, int64) (bool, error) {
+func (f *CodeMonitorStoreListEmailActionsFunc) PushReturn(r0 []*EmailAction, r1 error) {
+	f.PushHook(func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreHasAnyLastSearchedFunc) nextHook() func(context.Context, int64) (bool, error) {
+func (f *CodeMonitorStoreListEmailActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4637,36 +6102,36 @@ func (f *CodeMonitorStoreHasAnyLastSearchedFunc) nextHook() func(context.Context
 	return hook
 }
 
-func (f *CodeMonitorStoreHasAnyLastSearchedFunc) appendCall(r0 CodeMonitorStoreHasAnyLastSearchedFuncCall) {
+func (f *CodeMonitorStoreListEmailActionsFunc) appendCall(r0 CodeMonitorStoreListEmailActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreHasAnyLastSearchedFuncCall
+// History returns a sequence of CodeMonitorStoreListEmailActionsFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreHasAnyLastSearchedFunc) History() []CodeMonitorStoreHasAnyLastSearchedFuncCall {
+func (f *CodeMonitorStoreListEmailActionsFunc) History() []CodeMonitorStoreListEmailActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreHasAnyLastSearchedFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListEmailActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreHasAnyLastSearchedFuncCall is an object that describes an
-// invocation of method HasAnyLastSearched on an instance of
+// CodeMonitorStoreListEmailActionsFuncCall is an object that describes an
+// invocation of method ListEmailActions on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreHasAnyLastSearchedFuncCall struct {
+type CodeMonitorStoreListEmailActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int64
+	Arg1 ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 bool
+	Result0 []*EmailAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4674,46 +6139,47 @@ type CodeMonitorStoreHasAnyLastSearchedFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreHasAnyLastSearchedFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListEmailActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreHasAnyLastSearchedFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListActionJobsFunc describes the behavior when the
-// ListActionJobs method of the parent MockCodeMonitorStore instance is
+// CodeMonitorStoreListIssueActionsFunc describes the behavior when the
+// ListIssueActions method of the parent MockCodeMonitorStore instance is
 // invoked.
-type CodeMonitorStoreListActionJobsFunc struct {
-	defaultHook func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)
-	hooks       []func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)
-	history     []CodeMonitorStoreListActionJobsFuncCall
+type CodeMonitorStoreListIssueActionsFunc struct {
+	defaultHook func(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	hooks       []func(context.Context, ListActionsOpts) ([]*IssueAction, error)
+	history     []CodeMonitorStoreListIssueActionsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListActionJobs delegates to the next hook function in the queue and
+// ListIssueActions delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListActionJobs(v0 context.Context, v1 ListActionJobsOpts) ([]*ActionJob, error) {
-	r0, r1 := m.ListActionJobsFunc.nextHook()(v0, v1)
-	m.ListActionJobsFunc.appendCall(CodeMonitorStoreListActionJobsFuncCall{v0, v1, r0, r1})
+func (m *MockCodeMonitorStore) ListIssueActions(v0 context.Context, v1 ListActionsOpts) ([]*IssueAction, error) {
+	r0, r1 := m.ListIssueActionsFunc.nextHook()(v0, v1)
+	m.ListIssueActionsFunc.appendCall(CodeMonitorStoreListIssueActionsFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListActionJobs
+// SetDefaultHook sets function that is called when the ListIssueActions
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreListActionJobsFunc) SetDefaultHook(hook func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)) {
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*IssueAction, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListActionJobs method of the parent MockCodeMonitorStore instance invokes
-// the hook at the front of the queue and discards it. After the queue is
-// empty, the default hook function is invoked for any future action.
-func (f *CodeMonitorStoreListActionJobsFunc) PushHook(hook func(context.Context, ListActionJobsOpts) ([]*ActionJob, error)) {
+// ListIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreListIssueActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*IssueAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4721,20 +6187,20 @@ func (f *CodeMonitorStoreListActionJobsFunc) PushHook(hook func(context.Context,
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListActionJobsFunc) SetDefaultReturn(r0 []*ActionJob, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultReturn(r0 []*IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListActionJobsFunc) PushReturn(r0 []*ActionJob, r1 error) {
-	f.PushHook(func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) PushReturn(r0 []*IssueAction, r1 error) {
+	f.PushHook(func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListActionJobsFunc) nextHook() func(context.Context, ListActionJobsOpts) ([]*ActionJob, error) {
+func (f *CodeMonitorStoreListIssueActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*IssueAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4747,36 +6213,36 @@ func (f *CodeMonitorStoreListActionJobsFunc) nextHook() func(context.Context, Li
 	return hook
 }
 
-func (f *CodeMonitorStoreListActionJobsFunc) appendCall(r0 CodeMonitorStoreListActionJobsFuncCall) {
+func (f *CodeMonitorStoreListIssueActionsFunc) appendCall(r0 CodeMonitorStoreListIssueActionsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreListActionJobsFuncCall
+// History returns a sequence of CodeMonitorStoreListIssueActionsFuncCall
 // objects describing the invocations of this function.
-func (f *CodeMonitorStoreListActionJobsFunc) History() []CodeMonitorStoreListActionJobsFuncCall {
+func (f *CodeMonitorStoreListIssueActionsFunc) History() []CodeMonitorStoreListIssueActionsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListActionJobsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListIssueActionsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListActionJobsFuncCall is an object that describes an
-// invocation of method ListActionJobs on an instance of
+// CodeMonitorStoreListIssueActionsFuncCall is an object that describes an
+// invocation of method ListIssueActions on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreListActionJobsFuncCall struct {
+type CodeMonitorStoreListIssueActionsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListActionJobsOpts
+	Arg1 ListActionsOpts
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*ActionJob
+	Result0 []*IssueAction
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4784,47 +6250,47 @@ type CodeMonitorStoreListActionJobsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListActionJobsFuncCall) Args() []interface{} {
+func (c CodeMonitorStoreListIssueActionsFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListActionJobsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListIssueActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// CodeMonitorStoreListEmailActionsFunc describes the behavior when the
-// ListEmailActions method of the parent MockCodeMonitorStore instance is
-// invoked.
-type CodeMonitorStoreListEmailActionsFunc struct {
-	defaultHook func(context.Context, ListActionsOpts) ([]*EmailAction, error)
-	hooks       []func(context.Context, ListActionsOpts) ([]*EmailAction, error)
-	history     []CodeMonitorStoreListEmailActionsFuncCall
+// CodeMonitorStoreListMatchFingerprintsFunc describes the behavior when the
+// ListMatchFingerprints method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreListMatchFingerprintsFunc struct {
+	defaultHook func(context.Context, int64, []string) ([]string, error)
+	hooks       []func(context.Context, int64, []string) ([]string, error)
+	history     []CodeMonitorStoreListMatchFingerprintsFuncCall
 	mutex       sync.Mutex
 }
 
-// ListEmailActions delegates to the next hook function in the queue and
-// stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) ListEmailActions(v0 context.Context, v1 ListActionsOpts) ([]*EmailAction, error) {
-	r0, r1 := m.ListEmailActionsFunc.nextHook()(v0, v1)
-	m.ListEmailActionsFunc.appendCall(CodeMonitorStoreListEmailActionsFuncCall{v0, v1, r0, r1})
+// ListMatchFingerprints delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListMatchFingerprints(v0 context.Context, v1 int64, v2 []string) ([]string, error) {
+	r0, r1 := m.ListMatchFingerprintsFunc.nextHook()(v0, v1, v2)
+	m.ListMatchFingerprintsFunc.appendCall(CodeMonitorStoreListMatchFingerprintsFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the ListEmailActions
-// method of the parent MockCodeMonitorStore instance is invoked and the
-// hook queue is empty.
-func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultHook(hook func(context.Context, ListActionsOpts) ([]*EmailAction, error)) {
+// SetDefaultHook sets function that is called when the
+// ListMatchFingerprints method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreListMatchFingerprintsFunc) SetDefaultHook(hook func(context.Context, int64, []string) ([]string, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// ListEmailActions method of the parent MockCodeMonitorStore instance
+// ListMatchFingerprints method of the parent MockCodeMonitorStore instance
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreListEmailActionsFunc) PushHook(hook func(context.Context, ListActionsOpts) ([]*EmailAction, error)) {
+func (f *CodeMonitorStoreListMatchFingerprintsFunc) PushHook(hook func(context.Context, int64, []string) ([]string, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -4832,20 +6298,20 @@ func (f *CodeMonitorStoreListEmailActionsFunc) PushHook(hook func(context.Contex
 
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
-func (f *CodeMonitorStoreListEmailActionsFunc) SetDefaultReturn(r0 []*EmailAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
+func (f *CodeMonitorStoreListMatchFingerprintsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, []string) ([]string, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
-func (f *CodeMonitorStoreListEmailActionsFunc) PushReturn(r0 []*EmailAction, r1 error) {
-	f.PushHook(func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
+func (f *CodeMonitorStoreListMatchFingerprintsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int64, []string) ([]string, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreListEmailActionsFunc) nextHook() func(context.Context, ListActionsOpts) ([]*EmailAction, error) {
+func (f *CodeMonitorStoreListMatchFingerprintsFunc) nextHook() func(context.Context, int64, []string) ([]string, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -4858,36 +6324,40 @@ func (f *CodeMonitorStoreListEmailActionsFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *CodeMonitorStoreListEmailActionsFunc) appendCall(r0 CodeMonitorStoreListEmailActionsFuncCall) {
+func (f *CodeMonitorStoreListMatchFingerprintsFunc) appendCall(r0 CodeMonitorStoreListMatchFingerprintsFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of CodeMonitorStoreListEmailActionsFuncCall
-// objects describing the invocations of this function.
-func (f *CodeMonitorStoreListEmailActionsFunc) History() []CodeMonitorStoreListEmailActionsFuncCall {
+// History returns a sequence of
+// CodeMonitorStoreListMatchFingerprintsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreListMatchFingerprintsFunc) History() []CodeMonitorStoreListMatchFingerprintsFuncCall {
 	f.mutex.Lock()
-	history := make([]CodeMonitorStoreListEmailActionsFuncCall, len(f.history))
+	history := make([]CodeMonitorStoreListMatchFingerprintsFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// CodeMonitorStoreListEmailActionsFuncCall is an object that describes an
-// invocation of method ListEmailActions on an instance of
+// CodeMonitorStoreListMatchFingerprintsFuncCall is an object that describes
+// an invocation of method ListMatchFingerprints on an instance of
 // MockCodeMonitorStore.
-type CodeMonitorStoreListEmailActionsFuncCall struct {
+type CodeMonitorStoreListMatchFingerprintsFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 ListActionsOpts
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []*EmailAction
+	Result0 []string
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
 	Result1 error
@@ -4895,13 +6365,13 @@ type CodeMonitorStoreListEmailActionsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c CodeMonitorStoreListEmailActionsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+func (c CodeMonitorStoreListMatchFingerprintsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreListMatchFingerprintsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
@@ -5655,15 +7125,136 @@ type CodeMonitorStoreResetQueryTriggerTimestampsFuncCall struct {
 	Result0 error
 }
 
-// Args returns an interface slice containing the arguments of this
-// invocation.
-func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// CodeMonitorStoreSetIssueMatchesURLFunc describes the behavior when the
+// SetIssueMatchesURL method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreSetIssueMatchesURLFunc struct {
+	defaultHook func(context.Context, int64, string, ...string) error
+	hooks       []func(context.Context, int64, string, ...string) error
+	history     []CodeMonitorStoreSetIssueMatchesURLFuncCall
+	mutex       sync.Mutex
+}
+
+// SetIssueMatchesURL delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) SetIssueMatchesURL(v0 context.Context, v1 int64, v2 string, v3 ...string) error {
+	r0 := m.SetIssueMatchesURLFunc.nextHook()(v0, v1, v2, v3...)
+	m.SetIssueMatchesURLFunc.appendCall(CodeMonitorStoreSetIssueMatchesURLFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetIssueMatchesURL
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreSetIssueMatchesURLFunc) SetDefaultHook(hook func(context.Context, int64, string, ...string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetIssueMatchesURL method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreSetIssueMatchesURLFunc) PushHook(hook func(context.Context, int64, string, ...string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreSetIssueMatchesURLFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, string, ...string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreSetIssueMatchesURLFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, string, ...string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreSetIssueMatchesURLFunc) nextHook() func(context.Context, int64, string, ...string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreSetIssueMatchesURLFunc) appendCall(r0 CodeMonitorStoreSetIssueMatchesURLFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreSetIssueMatchesURLFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreSetIssueMatchesURLFunc) History() []CodeMonitorStoreSetIssueMatchesURLFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreSetIssueMatchesURLFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreSetIssueMatchesURLFuncCall is an object that describes an
+// invocation of method SetIssueMatchesURL on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreSetIssueMatchesURLFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is a slice containing the values of the variadic arguments
+	// passed to this method invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreSetIssueMatchesURLFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg3 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1, c.Arg2}, trailing...)
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Results() []interface{} {
+func (c CodeMonitorStoreSetIssueMatchesURLFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
@@ -6001,6 +7592,120 @@ func (c CodeMonitorStoreUpdateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateIssueActionFunc describes the behavior when the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpdateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, *IssueActionArgs) (*IssueAction, error)
+	hooks       []func(context.Context, int64, *IssueActionArgs) (*IssueAction, error)
+	history     []CodeMonitorStoreUpdateIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateIssueAction(v0 context.Context, v1 int64, v2 *IssueActionArgs) (*IssueAction, error) {
+	r0, r1 := m.UpdateIssueActionFunc.nextHook()(v0, v1, v2)
+	m.UpdateIssueActionFunc.appendCall(CodeMonitorStoreUpdateIssueActionFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, *IssueActionArgs) (*IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushHook(hook func(context.Context, int64, *IssueActionArgs) (*IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultReturn(r0 *IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, *IssueActionArgs) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushReturn(r0 *IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, *IssueActionArgs) (*IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) nextHook() func(context.Context, int64, *IssueActionArgs) (*IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) appendCall(r0 CodeMonitorStoreUpdateIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpdateIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) History() []CodeMonitorStoreUpdateIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateIssueActionFuncCall is an object that describes an
+// invocation of method UpdateIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpdateIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *IssueActionArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreUpdateMonitorFunc describes the behavior when the
 // UpdateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -6580,24 +8285,24 @@ func (c CodeMonitorStoreUpdateTriggerJobWithResultsFuncCall) Results() []interfa
 // UpdateWebhookAction method of the parent MockCodeMonitorStore instance is
 // invoked.
 type CodeMonitorStoreUpdateWebhookActionFunc struct {
-	defaultHook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
-	hooks       []func(context.Context, int64, bool, bool, string) (*WebhookAction, error)
+	defaultHook func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error)
+	hooks       []func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error)
 	history     []CodeMonitorStoreUpdateWebhookActionFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateWebhookAction delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string) (*WebhookAction, error) {
-	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockCodeMonitorStore) UpdateWebhookAction(v0 context.Context, v1 int64, v2 bool, v3 bool, v4 string, v5 string) (*WebhookAction, error) {
+	r0, r1 := m.UpdateWebhookActionFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.UpdateWebhookActionFunc.appendCall(CodeMonitorStoreUpdateWebhookActionFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the UpdateWebhookAction
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error)) {
 	f.defaultHook = hook
 }
 
@@ -6606,7 +8311,7 @@ func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultHook(hook func(conte
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string) (*WebhookAction, error)) {
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -6615,19 +8320,19 @@ func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushHook(hook func(context.Con
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateWebhookActionFunc) SetDefaultReturn(r0 *WebhookAction, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+	f.SetDefaultHook(func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateWebhookActionFunc) PushReturn(r0 *WebhookAction, r1 error) {
-	f.PushHook(func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+	f.PushHook(func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreUpdateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string) (*WebhookAction, error) {
+func (f *CodeMonitorStoreUpdateWebhookActionFunc) nextHook() func(context.Context, int64, bool, bool, string, string) (*WebhookAction, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -6676,6 +8381,9 @@ type CodeMonitorStoreUpdateWebhookActionFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 string
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *WebhookAction
@@ -6687,7 +8395,7 @@ type CodeMonitorStoreUpdateWebhookActionFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreUpdateWebhookActionFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -6810,6 +8518,118 @@ func (c CodeMonitorStoreUpsertLastSearchedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreUpsertMatchFingerprintsFunc describes the behavior when
+// the UpsertMatchFingerprints method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpsertMatchFingerprintsFunc struct {
+	defaultHook func(context.Context, int64, []string) error
+	hooks       []func(context.Context, int64, []string) error
+	history     []CodeMonitorStoreUpsertMatchFingerprintsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertMatchFingerprints delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertMatchFingerprints(v0 context.Context, v1 int64, v2 []string) error {
+	r0 := m.UpsertMatchFingerprintsFunc.nextHook()(v0, v1, v2)
+	m.UpsertMatchFingerprintsFunc.appendCall(CodeMonitorStoreUpsertMatchFingerprintsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpsertMatchFingerprints method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpsertMatchFingerprintsFunc) SetDefaultHook(hook func(context.Context, int64, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertMatchFingerprints method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpsertMatchFingerprintsFunc) PushHook(hook func(context.Context, int64, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertMatchFingerprintsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertMatchFingerprintsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertMatchFingerprintsFunc) nextHook() func(context.Context, int64, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertMatchFingerprintsFunc) appendCall(r0 CodeMonitorStoreUpsertMatchFingerprintsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpsertMatchFingerprintsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpsertMatchFingerprintsFunc) History() []CodeMonitorStoreUpsertMatchFingerprintsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertMatchFingerprintsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertMatchFingerprintsFuncCall is an object that
+// describes an invocation of method UpsertMatchFingerprints on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpsertMatchFingerprintsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertMatchFingerprintsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertMatchFingerprintsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockCodeownersStore is a mock implementation of the CodeownersStore
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/internal/database) used for
@@ -7857,6 +9677,9 @@ type MockEnterpriseDB struct {
 	// object controlling the behavior of the method
 	// BitbucketProjectPermissions.
 	BitbucketProjectPermissionsFunc *EnterpriseDBBitbucketProjectPermissionsFunc
+	// BlameOwnershipSignalsFunc is an instance of a mock function object
+	// controlling the behavior of the method BlameOwnershipSignals.
+	BlameOwnershipSignalsFunc *EnterpriseDBBlameOwnershipSignalsFunc
 	// CodeMonitorsFunc is an instance of a mock function object controlling
 	// the behavior of the method CodeMonitors.
 	CodeMonitorsFunc *EnterpriseDBCodeMonitorsFunc
@@ -8068,6 +9891,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		BlameOwnershipSignalsFunc: &EnterpriseDBBlameOwnershipSignalsFunc{
+			defaultHook: func() (r0 database.BlameOwnershipSignalStore) {
+				return
+			},
+		},
 		CodeMonitorsFunc: &EnterpriseDBCodeMonitorsFunc{
 			defaultHook: func() (r0 CodeMonitorStore) {
 				return
@@ -8395,6 +10223,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.BitbucketProjectPermissions")
 			},
 		},
+		BlameOwnershipSignalsFunc: &EnterpriseDBBlameOwnershipSignalsFunc{
+			defaultHook: func() database.BlameOwnershipSignalStore {
+				panic("unexpected invocation of MockEnterpriseDB.BlameOwnershipSignals")
+			},
+		},
 		CodeMonitorsFunc: &EnterpriseDBCodeMonitorsFunc{
 			defaultHook: func() CodeMonitorStore {
 				panic("unexpected invocation of MockEnterpriseDB.CodeMonitors")
@@ -8711,6 +10544,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		BitbucketProjectPermissionsFunc: &EnterpriseDBBitbucketProjectPermissionsFunc{
 			defaultHook: i.BitbucketProjectPermissions,
 		},
+		BlameOwnershipSignalsFunc: &EnterpriseDBBlameOwnershipSignalsFunc{
+			defaultHook: i.BlameOwnershipSignals,
+		},
 		CodeMonitorsFunc: &EnterpriseDBCodeMonitorsFunc{
 			defaultHook: i.CodeMonitors,
 		},
@@ -9485,6 +11321,108 @@ func (c EnterpriseDBBitbucketProjectPermissionsFuncCall) Results() []interface{}
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBBlameOwnershipSignalsFunc describes the behavior when the
+// BlameOwnershipSignals method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBBlameOwnershipSignalsFunc struct {
+	defaultHook func() database.BlameOwnershipSignalStore
+	hooks       []func() database.BlameOwnershipSignalStore
+	history     []EnterpriseDBBlameOwnershipSignalsFuncCall
+	mutex       sync.Mutex
+}
+
+// BlameOwnershipSignals delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) BlameOwnershipSignals() database.BlameOwnershipSignalStore {
+	r0 := m.BlameOwnershipSignalsFunc.nextHook()()
+	m.BlameOwnershipSignalsFunc.appendCall(EnterpriseDBBlameOwnershipSignalsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// BlameOwnershipSignals method of the parent MockEnterpriseDB instance is
+// invoked and the hook queue is empty.
+func (f *EnterpriseDBBlameOwnershipSignalsFunc) SetDefaultHook(hook func() database.BlameOwnershipSignalStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// BlameOwnershipSignals method of the parent MockEnterpriseDB instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnterpriseDBBlameOwnershipSignalsFunc) PushHook(hook func() database.BlameOwnershipSignalStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBBlameOwnershipSignalsFunc) SetDefaultReturn(r0 database.BlameOwnershipSignalStore) {
+	f.SetDefaultHook(func() database.BlameOwnershipSignalStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBBlameOwnershipSignalsFunc) PushReturn(r0 database.BlameOwnershipSignalStore) {
+	f.PushHook(func() database.BlameOwnershipSignalStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBBlameOwnershipSignalsFunc) nextHook() func() database.BlameOwnershipSignalStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBBlameOwnershipSignalsFunc) appendCall(r0 EnterpriseDBBlameOwnershipSignalsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBBlameOwnershipSignalsFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBBlameOwnershipSignalsFunc) History() []EnterpriseDBBlameOwnershipSignalsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBBlameOwnershipSignalsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBBlameOwnershipSignalsFuncCall is an object that describes an
+// invocation of method BlameOwnershipSignals on an instance of
+// MockEnterpriseDB.
+type EnterpriseDBBlameOwnershipSignalsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.BlameOwnershipSignalStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBBlameOwnershipSignalsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBBlameOwnershipSignalsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBCodeMonitorsFunc describes the behavior when the CodeMonitors
 // method of the parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBCodeMonitorsFunc struct {
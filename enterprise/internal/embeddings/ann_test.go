@@ -0,0 +1,274 @@
+package embeddings
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/types"
+)
+
+// getClusteredEmbeddingIndex returns an index of normalized embeddings that are
+// scattered around numClusters random centers, like the embeddings of similar
+// code, and numQueries queries drawn from the same distribution.
+func getClusteredEmbeddingIndex(rng *rand.Rand, numRows, numQueries, numClusters, columnDimension int, noise float32) (EmbeddingIndex, [][]int8) {
+	centers := make([][]float32, numClusters)
+	for i := range centers {
+		centers[i] = make([]float32, columnDimension)
+		for j := range centers[i] {
+			centers[i][j] = float32(rng.NormFloat64())
+		}
+		centers[i] = normalize(centers[i])
+	}
+
+	sample := func() []int8 {
+		center := centers[rng.Intn(numClusters)]
+		v := make([]float32, columnDimension)
+		for j := range v {
+			v[j] = center[j] + noise*float32(rng.NormFloat64())
+		}
+		return Quantize(normalize(v))
+	}
+
+	index := EmbeddingIndex{
+		ColumnDimension: columnDimension,
+		RowMetadata:     make([]RepoEmbeddingRowMetadata, numRows),
+	}
+	for i := 0; i < numRows; i++ {
+		index.Embeddings = append(index.Embeddings, sample()...)
+		index.RowMetadata[i] = RepoEmbeddingRowMetadata{FileName: fmt.Sprintf("%d.go", i)}
+	}
+
+	queries := make([][]int8, numQueries)
+	for i := range queries {
+		queries[i] = sample()
+	}
+	return index, queries
+}
+
+func TestBuildANNIndex(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+
+	t.Run("small indexes", func(t *testing.T) {
+		index, _ := getClusteredEmbeddingIndex(rng, 100, 0, 4, 16, 0.1)
+		index.BuildANNIndex()
+		require.Nil(t, index.ANN)
+	})
+
+	t.Run("lists", func(t *testing.T) {
+		index, _ := getClusteredEmbeddingIndex(rng, 2*minRowsForANNIndex, 0, 50, 16, 0.1)
+		index.BuildANNIndex()
+		ivf := index.ANN
+		require.NotNil(t, ivf)
+
+		numLists := ivf.numLists()
+		require.Equal(t, 141, numLists)
+		require.Len(t, ivf.Centroids, numLists*index.ColumnDimension)
+		require.Equal(t, int32(0), ivf.ListOffsets[0])
+		require.Equal(t, int32(len(index.RowMetadata)), ivf.ListOffsets[numLists])
+
+		// Every row is in exactly one list, the one with the most similar centroid.
+		rows := make([]int, 0, len(index.RowMetadata))
+		for list := 0; list < numLists; list++ {
+			require.LessOrEqual(t, ivf.ListOffsets[list], ivf.ListOffsets[list+1])
+			for _, row := range ivf.list(list) {
+				rows = append(rows, int(row))
+				var maxScore int32 = math.MinInt32
+				for other := 0; other < numLists; other++ {
+					maxScore = max32(maxScore, Dot(index.Row(int(row)), ivf.centroid(other, index.ColumnDimension)))
+				}
+				require.Equal(t, maxScore, Dot(index.Row(int(row)), ivf.centroid(list, index.ColumnDimension)))
+			}
+		}
+		sort.Ints(rows)
+		for i, row := range rows {
+			require.Equal(t, i, row)
+		}
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		index, _ := getClusteredEmbeddingIndex(rng, minRowsForANNIndex, 0, 20, 16, 0.1)
+		index.BuildANNIndex()
+		first := index.ANN
+		index.BuildANNIndex()
+		require.Equal(t, first, index.ANN)
+	})
+
+	t.Run("changing rows updates the index", func(t *testing.T) {
+		index, _ := getClusteredEmbeddingIndex(rng, minRowsForANNIndex, 0, 20, 16, 0.1)
+		index.BuildANNIndex()
+		centroids := index.ANN.Centroids
+
+		other, _ := getClusteredEmbeddingIndex(rng, 100, 0, 20, 16, 0.1)
+		index.append(other)
+		index.filter(map[string]struct{}{"0.go": {}, "42.go": {}}, types.RepoPathRanks{})
+		index.UpdateANNIndex()
+
+		// The lists are not clustered again.
+		ivf := index.ANN
+		require.Equal(t, centroids, ivf.Centroids)
+
+		// Every row is in exactly one list, the one with the most similar centroid.
+		numLists := ivf.numLists()
+		rows := make([]int, 0, len(index.RowMetadata))
+		for list := 0; list < numLists; list++ {
+			for _, row := range ivf.list(list) {
+				rows = append(rows, int(row))
+				var maxScore int32 = math.MinInt32
+				for other := 0; other < numLists; other++ {
+					maxScore = max32(maxScore, Dot(index.Row(int(row)), ivf.centroid(other, index.ColumnDimension)))
+				}
+				require.Equal(t, maxScore, Dot(index.Row(int(row)), ivf.centroid(list, index.ColumnDimension)))
+			}
+		}
+		sort.Ints(rows)
+		require.Len(t, rows, len(index.RowMetadata))
+		for i, row := range rows {
+			require.Equal(t, i, row)
+		}
+	})
+
+	t.Run("changing many rows rebuilds the index", func(t *testing.T) {
+		index, _ := getClusteredEmbeddingIndex(rng, minRowsForANNIndex, 0, 20, 16, 0.1)
+		index.BuildANNIndex()
+
+		other, _ := getClusteredEmbeddingIndex(rng, 4*minRowsForANNIndex, 0, 20, 16, 0.1)
+		index.append(other)
+		index.UpdateANNIndex()
+		require.Equal(t, numANNLists(5*minRowsForANNIndex), index.ANN.numLists())
+
+		// Indexes which become too small do not keep their index.
+		toRemove := map[string]struct{}{}
+		for i := 100; i < 4*minRowsForANNIndex; i++ {
+			toRemove[fmt.Sprintf("%d.go", i)] = struct{}{}
+		}
+		index.filter(toRemove, types.RepoPathRanks{})
+		index.UpdateANNIndex()
+		require.Nil(t, index.ANN)
+	})
+}
+
+// TestANNRecall measures the recall of the approximate nearest neighbor index, the
+// share of the exact nearest neighbors it finds, on embeddings that are clustered
+// to different degrees.
+func TestANNRecall(t *testing.T) {
+	const (
+		numRows     = 50_000
+		numQueries  = 100
+		numResults  = 10
+		numClusters = 200
+	)
+
+	for _, tc := range []struct {
+		noise     float32
+		minRecall float64
+	}{
+		{noise: 0.1, minRecall: 0.99},
+		{noise: 0.2, minRecall: 0.9},
+	} {
+		t.Run(fmt.Sprintf("noise=%.1f", tc.noise), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(0))
+			index, queries := getClusteredEmbeddingIndex(rng, numRows, numQueries, numClusters, 64, tc.noise)
+			index.BuildANNIndex()
+			require.NotNil(t, index.ANN)
+
+			recall := func(numProbes int) float64 {
+				found := 0
+				for _, query := range queries {
+					exact := index.exactSimilaritySearch(query, numResults, WorkerOptions{NumWorkers: 1}, SearchOptions{})
+					// Rows with the same score as the last exact result are also correct.
+					minScore := exact[0].scoreDetails.Score
+					for _, nn := range exact {
+						if nn.scoreDetails.Score < minScore {
+							minScore = nn.scoreDetails.Score
+						}
+					}
+
+					approximate := index.approximateSimilaritySearch(query, numResults, numProbes, WorkerOptions{NumWorkers: 1}, SearchOptions{})
+					require.Len(t, approximate, numResults)
+					for _, nn := range approximate {
+						if nn.scoreDetails.Score >= minScore {
+							found++
+						}
+					}
+				}
+				return float64(found) / float64(numQueries*numResults)
+			}
+
+			for _, numProbes := range []int{1, 8, 32} {
+				t.Logf("probes=%d recall=%.3f", numProbes, recall(numProbes))
+			}
+
+			r := recall(0)
+			t.Logf("default probes=%d recall=%.3f", index.ANN.defaultNumProbes(), r)
+			require.GreaterOrEqual(t, r, tc.minRecall)
+
+			// Probing all lists is an exact search.
+			require.Equal(t, 1.0, recall(index.ANN.numLists()))
+		})
+	}
+}
+
+func TestSimilaritySearchExact(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	index, queries := getClusteredEmbeddingIndex(rng, minRowsForANNIndex, 10, 20, 16, 0.2)
+	index.BuildANNIndex()
+	require.NotNil(t, index.ANN)
+
+	for _, query := range queries {
+		results := index.SimilaritySearch(query, 10, WorkerOptions{NumWorkers: 1}, SearchOptions{Exact: true}, "", "")
+		exact := index.exactSimilaritySearch(query, 10, WorkerOptions{NumWorkers: 1}, SearchOptions{})
+		sort.Slice(exact, func(i, j int) bool { return exact[i].scoreDetails.Score > exact[j].scoreDetails.Score })
+		require.Len(t, results, 10)
+		for i, r := range results {
+			require.Equal(t, exact[i].scoreDetails, r.ScoreDetails)
+		}
+	}
+}
+
+func TestApproximateSimilaritySearchWorkers(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	index, queries := getClusteredEmbeddingIndex(rng, minRowsForANNIndex, 10, 20, 16, 0.2)
+	index.BuildANNIndex()
+	require.NotNil(t, index.ANN)
+
+	top := func(neighbors []nearestNeighbor) []int32 {
+		sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].scoreDetails.Score > neighbors[j].scoreDetails.Score })
+		scores := make([]int32, 0, 10)
+		for _, nn := range neighbors[:10] {
+			scores = append(scores, nn.scoreDetails.Score)
+		}
+		return scores
+	}
+
+	for _, query := range queries {
+		want := top(index.approximateSimilaritySearch(query, 10, 0, WorkerOptions{NumWorkers: 1}, SearchOptions{}))
+		got := top(index.approximateSimilaritySearch(query, 10, 0, WorkerOptions{NumWorkers: 4}, SearchOptions{}))
+		require.Equal(t, want, got)
+	}
+}
+
+func max32(a, b int32) int32 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func BenchmarkSimilaritySearchANN(b *testing.B) {
+	rng := rand.New(rand.NewSource(0))
+	index, queries := getClusteredEmbeddingIndex(rng, 50_000, 100, 200, 1536, 0.1)
+	index.BuildANNIndex()
+
+	for _, exact := range []bool{true, false} {
+		b.Run(fmt.Sprintf("exact=%t", exact), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				index.SimilaritySearch(queries[n%len(queries)], 20, WorkerOptions{NumWorkers: 1}, SearchOptions{Exact: exact}, "", "")
+			}
+		})
+	}
+}
@@ -266,6 +266,9 @@ var repoEmbeddingJobStatsColumns = []*sqlf.Query{
 	sqlf.Sprintf("repo_embedding_job_stats.text_chunks_embedded"),
 	sqlf.Sprintf("repo_embedding_job_stats.text_files_skipped"),
 	sqlf.Sprintf("repo_embedding_job_stats.text_bytes_embedded"),
+	sqlf.Sprintf("repo_embedding_job_stats.files_added"),
+	sqlf.Sprintf("repo_embedding_job_stats.files_modified"),
+	sqlf.Sprintf("repo_embedding_job_stats.files_deleted"),
 }
 
 func scanRepoEmbeddingStats(s dbutil.Scanner) (EmbedRepoStats, error) {
@@ -284,6 +287,9 @@ func scanRepoEmbeddingStats(s dbutil.Scanner) (EmbedRepoStats, error) {
 		&stats.TextIndexStats.ChunksEmbedded,
 		dbutil.JSONMessage(&stats.TextIndexStats.FilesSkipped),
 		&stats.TextIndexStats.BytesEmbedded,
+		&stats.FilesAdded,
+		&stats.FilesModified,
+		&stats.FilesDeleted,
 	)
 	return stats, err
 }
@@ -323,11 +329,14 @@ func (s *repoEmbeddingJobsStore) UpdateRepoEmbeddingJobStats(ctx context.Context
 		text_files_embedded,
 		text_chunks_embedded,
 		text_files_skipped,
-		text_bytes_embedded
+		text_bytes_embedded,
+		files_added,
+		files_modified,
+		files_deleted
 	) VALUES (
-		%s, %s, %s, %s,
-		%s, %s, %s, %s,
-		%s, %s, %s, %s
+		%s, %s, %s, %s, %s,
+		%s, %s, %s, %s, %s,
+		%s, %s, %s, %s, %s
 	)
 	ON CONFLICT (job_id) DO UPDATE
 	SET
@@ -341,7 +350,10 @@ func (s *repoEmbeddingJobsStore) UpdateRepoEmbeddingJobStats(ctx context.Context
 		text_files_embedded = %s,
 		text_chunks_embedded = %s,
 		text_files_skipped = %s,
-		text_bytes_embedded = %s
+		text_bytes_embedded = %s,
+		files_added = %s,
+		files_modified = %s,
+		files_deleted = %s
 	`
 
 	q := sqlf.Sprintf(
@@ -359,6 +371,9 @@ func (s *repoEmbeddingJobsStore) UpdateRepoEmbeddingJobStats(ctx context.Context
 		stats.TextIndexStats.ChunksEmbedded,
 		dbutil.JSONMessage(&stats.TextIndexStats.FilesSkipped),
 		stats.TextIndexStats.BytesEmbedded,
+		stats.FilesAdded,
+		stats.FilesModified,
+		stats.FilesDeleted,
 
 		stats.IsIncremental,
 		stats.CodeIndexStats.FilesScheduled,
@@ -371,6 +386,9 @@ func (s *repoEmbeddingJobsStore) UpdateRepoEmbeddingJobStats(ctx context.Context
 		stats.TextIndexStats.ChunksEmbedded,
 		dbutil.JSONMessage(&stats.TextIndexStats.FilesSkipped),
 		stats.TextIndexStats.BytesEmbedded,
+		stats.FilesAdded,
+		stats.FilesModified,
+		stats.FilesDeleted,
 	)
 
 	return s.Exec(ctx, q)
@@ -136,6 +136,17 @@ func TestRepoEmbeddingJobsStore(t *testing.T) {
 		stats, err = store.GetRepoEmbeddingJobStats(ctx, jobs[0].ID)
 		require.NoError(t, err)
 		require.Equal(t, updatedStats, stats)
+
+		updatedStats.IsIncremental = true
+		updatedStats.FilesAdded = 3
+		updatedStats.FilesModified = 5
+		updatedStats.FilesDeleted = 2
+		err = store.UpdateRepoEmbeddingJobStats(ctx, jobs[0].ID, &updatedStats)
+		require.NoError(t, err)
+
+		stats, err = store.GetRepoEmbeddingJobStats(ctx, jobs[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, updatedStats, stats)
 	})
 }
 
@@ -46,6 +46,12 @@ type EmbedRepoStats struct {
 
 	// IsIncremental indicates whether the embedding job should reindex changed files
 	IsIncremental bool
+
+	// The number of files added, modified and deleted since the previously
+	// indexed revision. Only set if IsIncremental is true.
+	FilesAdded    int
+	FilesModified int
+	FilesDeleted  int
 }
 
 func (e *EmbedRepoStats) ToFields() []log.Field {
@@ -53,6 +59,9 @@ func (e *EmbedRepoStats) ToFields() []log.Field {
 		log.Object("codeIndex", e.CodeIndexStats.ToFields()...),
 		log.Object("textIndex", e.TextIndexStats.ToFields()...),
 		log.Bool("isIncremental", e.IsIncremental),
+		log.Int("filesAdded", e.FilesAdded),
+		log.Int("filesModified", e.FilesModified),
+		log.Int("filesDeleted", e.FilesDeleted),
 	}
 }
 
@@ -0,0 +1,260 @@
+// Package custom implements an embeddings client for self-hosted embedding
+// servers, so that embeddings can be generated without access to the internet.
+// The server either implements the OpenAI embeddings API, or a simple JSON
+// protocol.
+package custom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+const (
+	protocolOpenAI = "openai"
+	protocolJSON   = "json"
+)
+
+// dimensionsProbe is the text embedded to discover the dimensions of the
+// embeddings of the model, if they are not configured.
+const dimensionsProbe = "dimensions"
+
+const discoverDimensionsTimeout = time.Minute
+
+// retryBaseDelay is the delay before the first retry of a failed request. It is
+// doubled for every further retry.
+var retryBaseDelay = time.Second
+
+func NewClient(cli httpcli.Doer, config *conftypes.EmbeddingsConfig) *customEmbeddingsClient {
+	custom := config.Custom
+	if custom == nil {
+		custom = &schema.CustomEmbeddingsProvider{}
+	}
+	protocol := custom.Protocol
+	if protocol == "" {
+		protocol = protocolOpenAI
+	}
+
+	return &customEmbeddingsClient{
+		cli:         cli,
+		endpoint:    config.Endpoint,
+		accessToken: config.AccessToken,
+		model:       config.Model,
+		protocol:    protocol,
+		headers:     custom.Headers,
+		batchSize:   custom.BatchSize,
+		dimensions:  config.Dimensions,
+	}
+}
+
+type customEmbeddingsClient struct {
+	cli         httpcli.Doer
+	endpoint    string
+	accessToken string
+	model       string
+	protocol    string
+	headers     map[string]string
+	batchSize   int
+
+	// mu protects dimensions, which are discovered on first use if they are not
+	// configured.
+	mu         sync.Mutex
+	dimensions int
+}
+
+// GetDimensions returns the configured dimensions. If none are configured, it
+// embeds a short text and returns the dimensions of the result.
+func (c *customEmbeddingsClient) GetDimensions() (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.dimensions > 0 {
+		return c.dimensions, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), discoverDimensionsTimeout)
+	defer cancel()
+
+	embeddings, err := c.getEmbeddings(ctx, []string{dimensionsProbe})
+	if err != nil {
+		return 0, errors.Wrap(err, "discovering the dimensions of the embeddings, set embeddings.dimensions to skip discovery")
+	}
+	c.dimensions = len(embeddings)
+	return c.dimensions, nil
+}
+
+// GetModelIdentifier returns the identifier of the model, which is compared to
+// the model of existing indexes. Unlike the model name sent to the server, it is
+// lowercase, such that changing the case of the configured model does not make
+// existing indexes incompatible.
+func (c *customEmbeddingsClient) GetModelIdentifier() string {
+	return fmt.Sprintf("custom/%s", strings.ToLower(c.model))
+}
+
+// GetEmbeddingsWithRetries embeds the given texts in batches of at most the
+// configured batch size. Each batch is retried up to maxRetries times.
+func (c *customEmbeddingsClient) GetEmbeddingsWithRetries(ctx context.Context, texts []string, maxRetries int) ([]float32, error) {
+	for _, text := range texts {
+		if text == "" {
+			// Many servers reject empty inputs, so fail fast to avoid making
+			// tons of retryable requests.
+			return nil, errors.New("cannot generate embeddings for an empty string")
+		}
+	}
+
+	batchSize := c.batchSize
+	if batchSize <= 0 {
+		batchSize = len(texts)
+	}
+
+	var embeddings []float32
+	for start := 0; start < len(texts); start += batchSize {
+		end := start + batchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+
+		batchEmbeddings, err := c.getEmbeddingsWithRetries(ctx, texts[start:end], maxRetries)
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, batchEmbeddings...)
+	}
+	return embeddings, nil
+}
+
+func (c *customEmbeddingsClient) getEmbeddingsWithRetries(ctx context.Context, texts []string, maxRetries int) ([]float32, error) {
+	embeddings, err := c.getEmbeddings(ctx, texts)
+	if err == nil {
+		return embeddings, nil
+	}
+
+	for i := 0; i < maxRetries; i++ {
+		// Exponential delay
+		delay := retryBaseDelay * time.Duration(math.Pow(2, float64(i)))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		embeddings, err = c.getEmbeddings(ctx, texts)
+		if err == nil {
+			return embeddings, nil
+		}
+	}
+
+	return nil, err
+}
+
+// getEmbeddings returns the concatenated embeddings of the texts, in the order of
+// the texts.
+func (c *customEmbeddingsClient) getEmbeddings(ctx context.Context, texts []string) ([]float32, error) {
+	var vectors [][]float32
+	switch c.protocol {
+	case protocolOpenAI:
+		var response openaiEmbeddingsResponse
+		if err := c.do(ctx, openaiEmbeddingsRequest{Model: c.model, Input: texts}, &response); err != nil {
+			return nil, err
+		}
+		// Ensure embedding responses are sorted in the original order.
+		sort.Slice(response.Data, func(i, j int) bool {
+			return response.Data[i].Index < response.Data[j].Index
+		})
+		for _, data := range response.Data {
+			vectors = append(vectors, data.Embedding)
+		}
+
+	case protocolJSON:
+		var response jsonEmbeddingsResponse
+		if err := c.do(ctx, jsonEmbeddingsRequest{Model: c.model, Texts: texts}, &response); err != nil {
+			return nil, err
+		}
+		vectors = response.Embeddings
+
+	default:
+		return nil, errors.Newf("unsupported protocol %q", c.protocol)
+	}
+
+	if len(vectors) != len(texts) {
+		return nil, errors.Newf("expected %d embeddings, got %d", len(texts), len(vectors))
+	}
+
+	dimensions := len(vectors[0])
+	embeddings := make([]float32, 0, len(vectors)*dimensions)
+	for _, vector := range vectors {
+		if len(vector) == 0 || len(vector) != dimensions {
+			return nil, errors.Newf("expected embeddings with %d dimensions, got %d", dimensions, len(vector))
+		}
+		embeddings = append(embeddings, vector...)
+	}
+	return embeddings, nil
+}
+
+func (c *customEmbeddingsClient) do(ctx context.Context, request, response any) error {
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("embeddings: %s %q: failed with status %d: %s", req.Method, req.URL.String(), resp.StatusCode, string(respBody))
+	}
+
+	return json.NewDecoder(resp.Body).Decode(response)
+}
+
+type openaiEmbeddingsRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openaiEmbeddingsResponse struct {
+	Data []openaiEmbeddingsResponseData `json:"data"`
+}
+
+type openaiEmbeddingsResponseData struct {
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
+
+type jsonEmbeddingsRequest struct {
+	Model string   `json:"model"`
+	Texts []string `json:"texts"`
+}
+
+type jsonEmbeddingsResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+}
@@ -0,0 +1,201 @@
+package custom
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// fakeServer is an in-process embedding server. It embeds a text into a vector
+// of the given dimensions whose first element is the length of the text.
+type fakeServer struct {
+	dimensions int
+	// failures is the number of requests that fail before requests succeed.
+	failures int
+
+	mu       sync.Mutex
+	requests []*http.Request
+	models   []string
+	batches  [][]string
+}
+
+func (f *fakeServer) embed(text string) []float32 {
+	v := make([]float32, f.dimensions)
+	v[0] = float32(len(text))
+	return v
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.requests = append(f.requests, r)
+	if f.failures > 0 {
+		f.failures--
+		http.Error(w, "model is loading", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req struct {
+		Model string   `json:"model"`
+		Input []string `json:"input"`
+		Texts []string `json:"texts"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	f.models = append(f.models, req.Model)
+
+	if req.Texts != nil {
+		f.batches = append(f.batches, req.Texts)
+		resp := jsonEmbeddingsResponse{}
+		for _, text := range req.Texts {
+			resp.Embeddings = append(resp.Embeddings, f.embed(text))
+		}
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	f.batches = append(f.batches, req.Input)
+	resp := openaiEmbeddingsResponse{}
+	// Respond in reverse order, the client has to sort by index.
+	for i := len(req.Input) - 1; i >= 0; i-- {
+		resp.Data = append(resp.Data, openaiEmbeddingsResponseData{Index: i, Embedding: f.embed(req.Input[i])})
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func newTestClient(t *testing.T, f *fakeServer, config conftypes.EmbeddingsConfig) *customEmbeddingsClient {
+	t.Helper()
+
+	s := httptest.NewServer(f)
+	t.Cleanup(s.Close)
+
+	config.Endpoint = s.URL
+	return NewClient(s.Client(), &config)
+}
+
+func TestCustomClient(t *testing.T) {
+	retryBaseDelay = 0
+	ctx := context.Background()
+
+	for _, protocol := range []string{"", protocolOpenAI, protocolJSON} {
+		t.Run("protocol "+protocol, func(t *testing.T) {
+			f := &fakeServer{dimensions: 4}
+			c := newTestClient(t, f, conftypes.EmbeddingsConfig{
+				Model:       "bge-small-en",
+				AccessToken: "secret",
+				Custom:      &schema.CustomEmbeddingsProvider{Protocol: protocol},
+			})
+
+			embeddings, err := c.GetEmbeddingsWithRetries(ctx, []string{"a", "bb", "ccc"}, 0)
+			require.NoError(t, err)
+			require.Equal(t, []float32{1, 0, 0, 0, 2, 0, 0, 0, 3, 0, 0, 0}, embeddings)
+
+			require.Len(t, f.requests, 1)
+			require.Equal(t, "Bearer secret", f.requests[0].Header.Get("Authorization"))
+			require.Equal(t, "custom/bge-small-en", c.GetModelIdentifier())
+		})
+	}
+
+	t.Run("model case", func(t *testing.T) {
+		f := &fakeServer{dimensions: 2}
+		c := newTestClient(t, f, conftypes.EmbeddingsConfig{Model: "BAAI/bge-small-en"})
+
+		_, err := c.GetEmbeddingsWithRetries(ctx, []string{"a"}, 0)
+		require.NoError(t, err)
+		require.Equal(t, []string{"BAAI/bge-small-en"}, f.models)
+		require.Equal(t, "custom/baai/bge-small-en", c.GetModelIdentifier())
+	})
+
+	t.Run("batching", func(t *testing.T) {
+		f := &fakeServer{dimensions: 2}
+		c := newTestClient(t, f, conftypes.EmbeddingsConfig{
+			Model:  "bge-small-en",
+			Custom: &schema.CustomEmbeddingsProvider{BatchSize: 2, Headers: map[string]string{"X-Api-Key": "key"}},
+		})
+
+		embeddings, err := c.GetEmbeddingsWithRetries(ctx, []string{"a", "bb", "ccc", "dddd", "eeeee"}, 0)
+		require.NoError(t, err)
+		require.Equal(t, []float32{1, 0, 2, 0, 3, 0, 4, 0, 5, 0}, embeddings)
+		require.Equal(t, [][]string{{"a", "bb"}, {"ccc", "dddd"}, {"eeeee"}}, f.batches)
+		require.Equal(t, "key", f.requests[0].Header.Get("X-Api-Key"))
+		require.Empty(t, f.requests[0].Header.Get("Authorization"))
+	})
+
+	t.Run("dimension discovery", func(t *testing.T) {
+		f := &fakeServer{dimensions: 384}
+		c := newTestClient(t, f, conftypes.EmbeddingsConfig{Model: "bge-small-en"})
+
+		dimensions, err := c.GetDimensions()
+		require.NoError(t, err)
+		require.Equal(t, 384, dimensions)
+
+		// The dimensions are only discovered once.
+		dimensions, err = c.GetDimensions()
+		require.NoError(t, err)
+		require.Equal(t, 384, dimensions)
+		require.Len(t, f.requests, 1)
+	})
+
+	t.Run("configured dimensions", func(t *testing.T) {
+		f := &fakeServer{dimensions: 384}
+		c := newTestClient(t, f, conftypes.EmbeddingsConfig{Model: "bge-small-en", Dimensions: 768})
+
+		dimensions, err := c.GetDimensions()
+		require.NoError(t, err)
+		require.Equal(t, 768, dimensions)
+		require.Empty(t, f.requests)
+	})
+
+	t.Run("retries", func(t *testing.T) {
+		f := &fakeServer{dimensions: 2, failures: 2}
+		c := newTestClient(t, f, conftypes.EmbeddingsConfig{Model: "bge-small-en"})
+
+		embeddings, err := c.GetEmbeddingsWithRetries(ctx, []string{"a"}, 2)
+		require.NoError(t, err)
+		require.Equal(t, []float32{1, 0}, embeddings)
+		require.Len(t, f.requests, 3)
+	})
+
+	t.Run("too many failures", func(t *testing.T) {
+		f := &fakeServer{dimensions: 2, failures: 3}
+		c := newTestClient(t, f, conftypes.EmbeddingsConfig{Model: "bge-small-en"})
+
+		_, err := c.GetEmbeddingsWithRetries(ctx, []string{"a"}, 2)
+		require.ErrorContains(t, err, "failed with status 503: model is loading")
+	})
+
+	t.Run("errors on empty embedding string", func(t *testing.T) {
+		f := &fakeServer{dimensions: 2}
+		c := newTestClient(t, f, conftypes.EmbeddingsConfig{Model: "bge-small-en"})
+
+		_, err := c.GetEmbeddingsWithRetries(ctx, []string{"a", ""}, 10)
+		require.ErrorContains(t, err, "empty string")
+		require.Empty(t, f.requests)
+	})
+
+	t.Run("errors on missing embeddings", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			json.NewEncoder(w).Encode(jsonEmbeddingsResponse{Embeddings: [][]float32{{1, 2}}})
+		}))
+		defer s.Close()
+		c := NewClient(s.Client(), &conftypes.EmbeddingsConfig{
+			Endpoint: s.URL,
+			Model:    "bge-small-en",
+			Custom:   &schema.CustomEmbeddingsProvider{Protocol: protocolJSON},
+		})
+
+		_, err := c.GetEmbeddingsWithRetries(ctx, []string{"a", "b"}, 0)
+		require.ErrorContains(t, err, "expected 2 embeddings, got 1")
+	})
+}
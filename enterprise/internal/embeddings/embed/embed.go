@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings"
 	bgrepo "github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings/background/repo"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings/embed/client"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings/embed/client/custom"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings/embed/client/openai"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/embeddings/embed/client/sourcegraph"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/paths"
@@ -25,6 +26,8 @@ func NewEmbeddingsClient(config *conftypes.EmbeddingsConfig) (client.EmbeddingsC
 		return sourcegraph.NewClient(config), nil
 	case "openai":
 		return openai.NewClient(httpcli.ExternalClient, config), nil
+	case "custom":
+		return custom.NewClient(httpcli.ExternalDoer, config), nil
 	default:
 		return nil, errors.Newf("invalid provider %q", config.Provider)
 	}
@@ -91,6 +94,9 @@ func EmbedRepo(
 		TextIndexStats: bgrepo.NewEmbedFilesStats(len(textFileNames)),
 		IsIncremental:  isIncremental,
 	}
+	if isIncremental {
+		stats.FilesAdded, stats.FilesModified, stats.FilesDeleted = diffStats(toIndex, toRemove)
+	}
 
 	reportCodeProgress := func(codeIndexStats bgrepo.EmbedFilesStats) {
 		stats.CodeIndexStats = codeIndexStats
@@ -126,6 +132,23 @@ func EmbedRepo(
 	return index, toRemove, &stats, nil
 }
 
+// diffStats counts the files added, modified and deleted by a diff. Modified files
+// are both re-embedded and removed from the previous index.
+func diffStats(toIndex []FileEntry, toRemove []string) (added, modified, deleted int) {
+	removed := make(map[string]struct{}, len(toRemove))
+	for _, file := range toRemove {
+		removed[file] = struct{}{}
+	}
+	for _, file := range toIndex {
+		if _, ok := removed[file.Name]; ok {
+			modified++
+		} else {
+			added++
+		}
+	}
+	return added, modified, len(toRemove) - modified
+}
+
 type EmbedRepoOpts struct {
 	RepoName          api.RepoName
 	Revision          api.CommitID
@@ -236,6 +236,50 @@ func TestEmbedRepo(t *testing.T) {
 		`)
 	})
 
+	t.Run("incremental", func(t *testing.T) {
+		rl := listReader{
+			FileReader: reader,
+			FileLister: staticLister{{Name: "a.go", Size: 350}, {Name: "b.md", Size: 350}, {Name: "c.java", Size: 350}},
+			// a.go was added, b.md was modified and old.go was deleted.
+			FileDiffer: staticDiffer{
+				toIndex:  []FileEntry{{Name: "a.go", Size: 350}, {Name: "b.md", Size: 350}},
+				toRemove: []string{"b.md", "old.go"},
+			},
+		}
+
+		optsCopy := opts
+		optsCopy.IndexedRevision = "cafebabe"
+		index, toRemove, stats, err := EmbedRepo(ctx, client, contextService, rl, mockRepoPathRanks, optsCopy, logger, noopReport)
+		require.NoError(t, err)
+		require.Equal(t, []string{"b.md", "old.go"}, toRemove)
+		require.Len(t, index.CodeIndex.RowMetadata, 2)
+		require.Len(t, index.TextIndex.RowMetadata, 2)
+
+		require.True(t, stats.IsIncremental)
+		require.Equal(t, 1, stats.FilesAdded)
+		require.Equal(t, 1, stats.FilesModified)
+		require.Equal(t, 1, stats.FilesDeleted)
+		require.Equal(t, 1, stats.CodeIndexStats.FilesScheduled)
+		require.Equal(t, 1, stats.TextIndexStats.FilesScheduled)
+	})
+
+	t.Run("incremental falls back to full index", func(t *testing.T) {
+		rl := listReader{
+			FileReader: reader,
+			FileLister: staticLister{{Name: "a.go", Size: 350}, {Name: "b.md", Size: 350}, {Name: "c.java", Size: 350}},
+			FileDiffer: staticDiffer{err: errors.New("unknown revision")},
+		}
+
+		optsCopy := opts
+		optsCopy.IndexedRevision = "cafebabe"
+		_, toRemove, stats, err := EmbedRepo(ctx, client, contextService, rl, mockRepoPathRanks, optsCopy, logger, noopReport)
+		require.NoError(t, err)
+		require.Empty(t, toRemove)
+		require.False(t, stats.IsIncremental)
+		require.Zero(t, stats.FilesAdded+stats.FilesModified+stats.FilesDeleted)
+		require.Equal(t, 2, stats.CodeIndexStats.FilesScheduled)
+	})
+
 	t.Run("embeddings limited", func(t *testing.T) {
 		optsCopy := opts
 		optsCopy.MaxCodeEmbeddings = 3
@@ -323,3 +367,13 @@ type listReader struct {
 	FileLister
 	FileDiffer
 }
+
+type staticDiffer struct {
+	toIndex  []FileEntry
+	toRemove []string
+	err      error
+}
+
+func (d staticDiffer) Diff(_ context.Context, _ api.CommitID) ([]FileEntry, []string, error) {
+	return d.toIndex, d.toRemove, d.err
+}
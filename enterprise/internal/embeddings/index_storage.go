@@ -19,10 +19,11 @@ import (
 // way that affects how it's decoded, we add a new format version and update CurrentFormatVersion to the latest.
 type IndexFormatVersion int
 
-const CurrentFormatVersion = EmbeddingModelVersion
+const CurrentFormatVersion = ANNIndexVersion
 const (
 	InitialVersion        IndexFormatVersion = iota // The initial format, before we started tracking format versions
 	EmbeddingModelVersion                           // Added the model name used to create embeddings
+	ANNIndexVersion                                 // Added the approximate nearest neighbor index of each embedding index
 )
 
 func DownloadIndex[T any](ctx context.Context, uploadStore uploadstore.Store, key string) (_ *T, err error) {
@@ -106,6 +107,9 @@ func UpdateRepoEmbeddingIndex(
 	previous.CodeIndex.append(new.CodeIndex)
 	previous.TextIndex.append(new.TextIndex)
 
+	// the rows changed, so the ANN indexes have to be updated
+	previous.UpdateANNIndexes()
+
 	// re-upload
 	return UploadRepoEmbeddingIndex(ctx, uploadStore, key, previous)
 }
@@ -218,6 +222,19 @@ func (d *decoder) decode() (*RepoEmbeddingIndex, error) {
 			}
 			ei.Embeddings = append(ei.Embeddings, Quantize(embeddingSlice)...)
 		}
+
+		if d.formatVersion >= ANNIndexVersion {
+			var hasANN bool
+			if err := d.dec.Decode(&hasANN); err != nil {
+				return nil, err
+			}
+			if hasANN {
+				ei.ANN = &IVFIndex{}
+				if err := d.dec.Decode(ei.ANN); err != nil {
+					return nil, err
+				}
+			}
+		}
 	}
 
 	return rei, nil
@@ -294,6 +311,17 @@ func (e *encoder) encode(rei *RepoEmbeddingIndex) error {
 				return err
 			}
 		}
+
+		if e.formatVersion >= ANNIndexVersion {
+			if err := e.enc.Encode(ei.ANN != nil); err != nil {
+				return err
+			}
+			if ei.ANN != nil {
+				if err := e.enc.Encode(ei.ANN); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
 	return nil
@@ -31,6 +31,10 @@ func (s *noOpUploadStore) Get(ctx context.Context, key string) (io.ReadCloser, e
 	return nil, nil
 }
 
+func (s *noOpUploadStore) Exists(ctx context.Context, key string) (bool, error) {
+	return false, nil
+}
+
 func (s *noOpUploadStore) Upload(ctx context.Context, key string, r io.Reader) (int64, error) {
 	p := make([]byte, 1024)
 	totalRead := 0
@@ -80,6 +84,11 @@ func (s *mockUploadStore) Get(ctx context.Context, key string) (io.ReadCloser, e
 	return io.NopCloser(bytes.NewReader(file)), nil
 }
 
+func (s *mockUploadStore) Exists(ctx context.Context, key string) (bool, error) {
+	_, ok := s.files[key]
+	return ok, nil
+}
+
 func (s *mockUploadStore) Upload(ctx context.Context, key string, r io.Reader) (int64, error) {
 	file, err := io.ReadAll(r)
 	if err != nil {
@@ -129,6 +138,67 @@ func TestRepoEmbeddingIndexStorage(t *testing.T) {
 	require.Equal(t, index, downloadedIndex)
 }
 
+func TestRepoEmbeddingIndexStorageWithANNIndex(t *testing.T) {
+	rng := rand.New(rand.NewSource(0))
+	codeIndex, _ := getClusteredEmbeddingIndex(rng, minRowsForANNIndex, 0, 20, 8, 0.1)
+	textIndex, _ := getClusteredEmbeddingIndex(rng, 10, 0, 2, 8, 0.1)
+	index := &RepoEmbeddingIndex{
+		RepoName:  api.RepoName("repo"),
+		Revision:  api.CommitID("commit"),
+		CodeIndex: codeIndex,
+		TextIndex: textIndex,
+	}
+	index.BuildANNIndexes()
+	require.NotNil(t, index.CodeIndex.ANN)
+	require.Nil(t, index.TextIndex.ANN)
+
+	ctx := context.Background()
+	uploadStore := newMockUploadStore()
+
+	err := UploadRepoEmbeddingIndex(ctx, uploadStore, "index", index)
+	require.NoError(t, err)
+
+	downloadedIndex, err := DownloadRepoEmbeddingIndex(ctx, uploadStore, "index")
+	require.NoError(t, err)
+
+	require.Equal(t, index, downloadedIndex)
+}
+
+func TestDecodeIndexWithoutANNIndex(t *testing.T) {
+	index := &RepoEmbeddingIndex{
+		RepoName:        api.RepoName("repo"),
+		Revision:        api.CommitID("commit"),
+		EmbeddingsModel: "model",
+		CodeIndex: EmbeddingIndex{
+			Embeddings:      []int8{0, 1, 2},
+			ColumnDimension: 3,
+			RowMetadata:     []RepoEmbeddingRowMetadata{{FileName: "a.go", StartLine: 0, EndLine: 1}},
+		},
+		TextIndex: EmbeddingIndex{
+			Embeddings:      []int8{10, 21, 32},
+			ColumnDimension: 3,
+			RowMetadata:     []RepoEmbeddingRowMetadata{{FileName: "b.py", StartLine: 0, EndLine: 1}},
+		},
+	}
+
+	ctx := context.Background()
+	uploadStore := newMockUploadStore()
+	var buf bytes.Buffer
+
+	// Indexes created before the ANN index was added can still be decoded.
+	enc := newEncoder(gob.NewEncoder(&buf), EmbeddingModelVersion, embeddingsChunkSize)
+	err := enc.encode(index)
+	require.NoError(t, err)
+
+	_, err = uploadStore.Upload(ctx, "index", &buf)
+	require.NoError(t, err)
+
+	downloadedIndex, err := DownloadRepoEmbeddingIndex(ctx, uploadStore, "index")
+	require.NoError(t, err)
+
+	require.Equal(t, index, downloadedIndex)
+}
+
 func TestIndexFormatVersion(t *testing.T) {
 	index := &RepoEmbeddingIndex{
 		RepoName: api.RepoName("repo"),
@@ -81,6 +81,7 @@ type WorkerOptions struct {
 }
 
 // SimilaritySearch finds the `nResults` most similar rows to a query vector. It uses the cosine similarity metric.
+// If the index has an approximate nearest neighbor index, only the rows of the lists closest to the query are scored.
 // IMPORTANT: The vectors in the embedding index have to be normalized for similarity search to work correctly.
 func (index *EmbeddingIndex) SimilaritySearch(
 	query []int8,
@@ -97,6 +98,38 @@ func (index *EmbeddingIndex) SimilaritySearch(
 	numRows := len(index.RowMetadata)
 	// Cannot request more results than there are rows.
 	numResults = min(numRows, numResults)
+
+	var neighbors []nearestNeighbor
+	if index.ANN != nil && annSearchEnabled && !opts.Exact {
+		neighbors = index.approximateSimilaritySearch(query, numResults, annNumProbes, workerOptions, opts)
+	} else {
+		neighbors = index.exactSimilaritySearch(query, numResults, workerOptions, opts)
+	}
+	// Sort the neighbors according to the score (descending).
+	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].scoreDetails.Score > neighbors[j].scoreDetails.Score })
+
+	// Take top neighbors and return them as results.
+	results := make([]EmbeddingSearchResult, numResults)
+
+	for idx := 0; idx < min(numResults, len(neighbors)); idx++ {
+		metadata := index.RowMetadata[neighbors[idx].index]
+		results[idx] = EmbeddingSearchResult{
+			RepoName:     repoName,
+			Revision:     revision,
+			FileName:     metadata.FileName,
+			StartLine:    metadata.StartLine,
+			EndLine:      metadata.EndLine,
+			ScoreDetails: neighbors[idx].scoreDetails,
+		}
+	}
+
+	return results
+}
+
+// exactSimilaritySearch scores every row of the index against the query and
+// returns the neighbors of the numResults rows with the highest scores, unsorted.
+func (index *EmbeddingIndex) exactSimilaritySearch(query []int8, numResults int, workerOptions WorkerOptions, opts SearchOptions) []nearestNeighbor {
+	numRows := len(index.RowMetadata)
 	// We need at least 1 worker.
 	numWorkers := max(1, workerOptions.NumWorkers)
 
@@ -126,25 +159,7 @@ func (index *EmbeddingIndex) SimilaritySearch(
 			neighbors = append(neighbors, heap.neighbors...)
 		}
 	}
-	// And re-sort it according to the score (descending).
-	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].scoreDetails.Score > neighbors[j].scoreDetails.Score })
-
-	// Take top neighbors and return them as results.
-	results := make([]EmbeddingSearchResult, numResults)
-
-	for idx := 0; idx < min(numResults, len(neighbors)); idx++ {
-		metadata := index.RowMetadata[neighbors[idx].index]
-		results[idx] = EmbeddingSearchResult{
-			RepoName:     repoName,
-			Revision:     revision,
-			FileName:     metadata.FileName,
-			StartLine:    metadata.StartLine,
-			EndLine:      metadata.EndLine,
-			ScoreDetails: neighbors[idx].scoreDetails,
-		}
-	}
-
-	return results
+	return neighbors
 }
 
 func (index *EmbeddingIndex) partialSimilaritySearch(query []int8, numResults int, partialRows partialRows, opts SearchOptions) *nearestNeighborsHeap {
@@ -218,4 +233,7 @@ func max(a, b int) int {
 
 type SearchOptions struct {
 	UseDocumentRanks bool
+	// Exact disables the approximate nearest neighbor index, so that every row of
+	// the index is scored.
+	Exact bool
 }
@@ -13,6 +13,9 @@ type EmbeddingIndex struct {
 	ColumnDimension int
 	RowMetadata     []RepoEmbeddingRowMetadata
 	Ranks           []float32
+	// ANN is the approximate nearest neighbor index of the rows. It is nil for
+	// small indexes, and for indexes whose rows changed since it was built.
+	ANN *IVFIndex
 }
 
 // Row returns the embeddings for the nth row in the index
@@ -21,7 +24,11 @@ func (index *EmbeddingIndex) Row(n int) []int8 {
 }
 
 func (index *EmbeddingIndex) EstimateSize() int64 {
-	return int64(len(index.Embeddings) + len(index.RowMetadata)*(16+8+8) + len(index.Ranks)*4)
+	size := int64(len(index.Embeddings) + len(index.RowMetadata)*(16+8+8) + len(index.Ranks)*4)
+	if index.ANN != nil {
+		size += index.ANN.EstimateSize()
+	}
+	return size
 }
 
 // Filter removes all files from the index that are in the set and updates the ranks
@@ -30,11 +37,23 @@ func (index *EmbeddingIndex) filter(set map[string]struct{}, ranks types.RepoPat
 	// "ranks".
 	index.Ranks = make([]float32, 0, len(index.RowMetadata))
 
+	// newRows maps the rows to their row after filtering, for the ANN index.
+	var newRows []int32
+	if index.ANN != nil {
+		newRows = make([]int32, len(index.RowMetadata))
+	}
+
 	cursor := 0
 	for i, s := range index.RowMetadata {
 		if _, ok := set[s.FileName]; ok {
+			if newRows != nil {
+				newRows[i] = -1
+			}
 			continue
 		}
+		if newRows != nil {
+			newRows[i] = int32(cursor)
+		}
 		index.RowMetadata[cursor] = s
 
 		// Ranks might have changed since the index was created, so we need to update
@@ -49,12 +68,19 @@ func (index *EmbeddingIndex) filter(set map[string]struct{}, ranks types.RepoPat
 	index.RowMetadata = index.RowMetadata[:cursor]
 	index.Ranks = index.Ranks[:cursor]
 	index.Embeddings = index.Embeddings[:cursor*index.ColumnDimension]
+	if index.ANN != nil {
+		index.ANN.remapRows(newRows)
+	}
 }
 
 func (index *EmbeddingIndex) append(other EmbeddingIndex) {
+	firstRow := len(index.RowMetadata)
 	index.RowMetadata = append(index.RowMetadata, other.RowMetadata...)
 	index.Ranks = append(index.Ranks, other.Ranks...)
 	index.Embeddings = append(index.Embeddings, other.Embeddings...)
+	if index.ANN != nil {
+		index.ANN.addRows(index, firstRow)
+	}
 }
 
 type RepoEmbeddingRowMetadata struct {
@@ -75,6 +101,20 @@ func (i *RepoEmbeddingIndex) EstimateSize() int64 {
 	return i.CodeIndex.EstimateSize() + i.TextIndex.EstimateSize()
 }
 
+// BuildANNIndexes builds the approximate nearest neighbor indexes of the code and
+// text indexes.
+func (i *RepoEmbeddingIndex) BuildANNIndexes() {
+	i.CodeIndex.BuildANNIndex()
+	i.TextIndex.BuildANNIndex()
+}
+
+// UpdateANNIndexes updates the approximate nearest neighbor indexes of the code
+// and text indexes after rows were removed or added.
+func (i *RepoEmbeddingIndex) UpdateANNIndexes() {
+	i.CodeIndex.UpdateANNIndex()
+	i.TextIndex.UpdateANNIndex()
+}
+
 func (i *RepoEmbeddingIndex) IsModelCompatible(model string) bool {
 	return i.EmbeddingsModel == "" || i.EmbeddingsModel == model
 }
@@ -0,0 +1,35 @@
+package types
+
+import "encoding/json"
+
+// StepCacheFile is the name of the step cache file.
+const StepCacheFile = "step-cache.json"
+
+// StepCacheHitFile is the name of the file the cached step result is written to
+// when the step cache has a result for the step.
+const StepCacheHitFile = "step-cache-hit.json"
+
+// StepCacheImagesFile is the name of the file that maps the images of the steps
+// to their digests. The file is part of the job when the step cache is used, with
+// empty digests, and the executor resolves the digests before running the job.
+const StepCacheImagesFile = "step-cache-images.json"
+
+// StepCacheBucket is the files store bucket that holds the cached step results.
+const StepCacheBucket = "batch-changes-step-cache"
+
+// StepCacheImages maps the images of the steps to the digests they resolve to. An
+// empty digest means the digest of the image could not be resolved.
+type StepCacheImages map[string]string
+
+// StepCache is the JSON file that is written to the working directory when the
+// result of a step can be cached.
+type StepCache struct {
+	// Key is the key of the step result in the step cache.
+	Key string `json:"key"`
+	// NextStep is the key of the step to continue with when the step result is
+	// cached.
+	NextStep string `json:"nextStep"`
+	// Result is the step result to store in the step cache. It is only set once
+	// the step has run.
+	Result json.RawMessage `json:"result,omitempty"`
+}
@@ -24,3 +24,7 @@ func FormatPostKey(index int) string {
 func IsPreStepKey(key string) bool {
 	return strings.HasSuffix(key, ".pre")
 }
+
+func IsPostStepKey(key string) bool {
+	return strings.HasSuffix(key, ".post")
+}
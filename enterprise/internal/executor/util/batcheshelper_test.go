@@ -27,3 +27,8 @@ func TestIsPreStepKey(t *testing.T) {
 	actual := util.IsPreStepKey("step.1.pre")
 	assert.True(t, actual)
 }
+
+func TestIsPostStepKey(t *testing.T) {
+	assert.True(t, util.IsPostStepKey("step.1.post"))
+	assert.False(t, util.IsPostStepKey("step.1.pre"))
+}
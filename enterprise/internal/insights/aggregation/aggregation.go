@@ -168,12 +168,101 @@ func countRepoMetadata(r result.Match, repo *sTypes.Repo) (map[MatchKey]int, err
 	return matches, nil
 }
 
+// commitDateLayout is the layout of the groups of the commit date aggregation modes.
+const commitDateLayout = "2006-01-02"
+
+// commitDateBucket returns the start of the week (starting on Monday) or of the month the given time falls in.
+func commitDateBucket(mode types.SearchAggregationMode, t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if mode == types.COMMIT_MONTH_AGGREGATION_MODE {
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	// time.Weekday starts on Sunday, weeks start on Monday.
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// CommitDateRange returns the range of commit dates [start, end) covered by a group of a commit date aggregation mode.
+func CommitDateRange(mode types.SearchAggregationMode, group string) (start time.Time, end time.Time, err error) {
+	start, err = time.Parse(commitDateLayout, group)
+	if err != nil {
+		return time.Time{}, time.Time{}, errors.Wrap(err, "invalid commit date group")
+	}
+	switch mode {
+	case types.COMMIT_WEEK_AGGREGATION_MODE:
+		return start, start.AddDate(0, 0, 7), nil
+	case types.COMMIT_MONTH_AGGREGATION_MODE:
+		return start, start.AddDate(0, 1, 0), nil
+	default:
+		return time.Time{}, time.Time{}, errors.Newf("unsupported commit date aggregation mode: %s", mode)
+	}
+}
+
+func countCommitDateFunc(mode types.SearchAggregationMode) AggregationCountFunc {
+	return func(r result.Match, _ *sTypes.Repo) (map[MatchKey]int, error) {
+		match, ok := r.(*result.CommitMatch)
+		if !ok {
+			return nil, nil
+		}
+		// Commit searches filter on the committer date with before: and after:, so bucket by the same date.
+		date := match.Commit.Author.Date
+		if match.Commit.Committer != nil && !match.Commit.Committer.Date.IsZero() {
+			date = match.Commit.Committer.Date
+		}
+		if date.IsZero() {
+			return nil, nil
+		}
+		return map[MatchKey]int{{
+			RepoID: int32(r.RepoName().ID),
+			Repo:   string(r.RepoName().Name),
+			Group:  commitDateBucket(mode, date).Format(commitDateLayout),
+		}: r.ResultCount()}, nil
+	}
+}
+
+// FileOwnersSource returns the owners of a file as text references that the has.owner() search filter accepts.
+type FileOwnersSource interface {
+	FileOwners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID, path string) ([]string, error)
+}
+
+// NewOwnerCountFunc returns a counting function for the owner aggregation mode. File matches are grouped by the
+// owners returned by the given source, which must be the same ownership data that the has.owner() filter uses so
+// that drilling down into a group matches the files that were counted for it. Files without owners are grouped
+// under types.NO_OWNER_TEXT.
+func NewOwnerCountFunc(ctx context.Context, owners FileOwnersSource) AggregationCountFunc {
+	return func(r result.Match, _ *sTypes.Repo) (map[MatchKey]int, error) {
+		match, ok := r.(*result.FileMatch)
+		if !ok {
+			return nil, nil
+		}
+
+		fileOwners, err := owners.FileOwners(ctx, match.Repo.Name, match.Repo.ID, match.CommitID, match.Path)
+		if err != nil {
+			return nil, errors.Wrap(err, "FileOwners")
+		}
+		if len(fileOwners) == 0 {
+			fileOwners = []string{types.NO_OWNER_TEXT}
+		}
+
+		matches := map[MatchKey]int{}
+		for _, owner := range fileOwners {
+			matchKey := MatchKey{Repo: string(r.RepoName().Name), RepoID: int32(r.RepoName().ID), Group: owner}
+			matches[matchKey] = r.ResultCount()
+		}
+		return matches, nil
+	}
+}
+
+// GetCountFuncForMode returns the counting function for an aggregation mode. The owner aggregation mode depends on
+// ownership data, so its counting function is created with NewOwnerCountFunc instead.
 func GetCountFuncForMode(query, patternType string, mode types.SearchAggregationMode) (AggregationCountFunc, error) {
 	modeCountTypes := map[types.SearchAggregationMode]AggregationCountFunc{
 		types.REPO_AGGREGATION_MODE:          countRepo,
 		types.PATH_AGGREGATION_MODE:          countPath,
 		types.AUTHOR_AGGREGATION_MODE:        countAuthor,
 		types.REPO_METADATA_AGGREGATION_MODE: countRepoMetadata,
+		types.COMMIT_WEEK_AGGREGATION_MODE:   countCommitDateFunc(types.COMMIT_WEEK_AGGREGATION_MODE),
+		types.COMMIT_MONTH_AGGREGATION_MODE:  countCommitDateFunc(types.COMMIT_MONTH_AGGREGATION_MODE),
 	}
 
 	if mode == types.CAPTURE_GROUP_AGGREGATION_MODE {
@@ -277,12 +366,12 @@ func (r *searchAggregationResults) Send(event streaming.SearchEvent) {
 			return
 		default:
 			groups, err := r.countFunc(match, repos[match.RepoName().ID])
+			// delegate error handling to the passed in tabulator
+			if err != nil {
+				r.tabulator(nil, err)
+				continue
+			}
 			for groupKey, count := range groups {
-				// delegate error handling to the passed in tabulator
-				if err != nil {
-					r.tabulator(nil, err)
-					continue
-				}
 				current := combined[groupKey]
 				combined[groupKey] = current + count
 			}
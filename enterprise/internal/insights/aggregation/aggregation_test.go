@@ -101,7 +101,7 @@ func commitMatch(repo, author string, date time.Time, repoID, numRanges int32, c
 
 	return &result.CommitMatch{
 		Commit: gitdomain.Commit{
-			Author:    gitdomain.Signature{Name: author},
+			Author:    gitdomain.Signature{Name: author, Date: date},
 			Committer: &gitdomain.Signature{},
 			Message:   gitdomain.Message(content),
 		},
@@ -752,3 +752,105 @@ func TestAggregationCancelation(t *testing.T) {
 		})
 	}
 }
+
+func TestCommitDateAggregation(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mode        types.SearchAggregationMode
+		searchEvent streaming.SearchEvent
+		want        autogold.Value
+	}{
+		{
+			"No date for content match",
+			types.COMMIT_WEEK_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{contentMatch("myRepo", "file.go", 1, "a", "b")},
+			},
+			autogold.Expect(map[string]int{}),
+		},
+		{
+			"counts by week",
+			types.COMMIT_WEEK_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					// Friday, Sunday and Monday
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					commitMatch("repoA", "Author B", sampleDate.AddDate(0, 0, 2), 1, 2, "a"),
+					commitMatch("repoB", "Author B", sampleDate.AddDate(0, 0, 3), 2, 2, "a"),
+				},
+			},
+			autogold.Expect(map[string]int{"2022-03-28": 4, "2022-04-04": 2}),
+		},
+		{
+			"counts by month",
+			types.COMMIT_MONTH_AGGREGATION_MODE,
+			streaming.SearchEvent{
+				Results: []result.Match{
+					commitMatch("repoA", "Author A", sampleDate, 1, 2, "a"),
+					commitMatch("repoA", "Author B", sampleDate.AddDate(0, 0, 29), 1, 2, "a"),
+					commitMatch("repoB", "Author B", sampleDate.AddDate(0, 0, -1), 2, 2, "a"),
+				},
+			},
+			autogold.Expect(map[string]int{"2022-03-01": 2, "2022-04-01": 4}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			aggregator := testAggregator{results: make(map[string]int)}
+			countFunc, _ := GetCountFuncForMode("", "", tc.mode)
+			sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc, tc.mode, nil)
+			sra.Send(tc.searchEvent)
+			tc.want.Equal(t, aggregator.results)
+		})
+	}
+}
+
+func TestCommitDateRange(t *testing.T) {
+	start, end, err := CommitDateRange(types.COMMIT_WEEK_AGGREGATION_MODE, "2022-03-28")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect([]string{"2022-03-28", "2022-04-04"}).Equal(t, []string{start.Format(commitDateLayout), end.Format(commitDateLayout)})
+
+	start, end, err = CommitDateRange(types.COMMIT_MONTH_AGGREGATION_MODE, "2022-12-01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect([]string{"2022-12-01", "2023-01-01"}).Equal(t, []string{start.Format(commitDateLayout), end.Format(commitDateLayout)})
+
+	if _, _, err := CommitDateRange(types.COMMIT_WEEK_AGGREGATION_MODE, "No owner"); err == nil {
+		t.Error("expected an error for an invalid group")
+	}
+}
+
+type fakeFileOwners struct {
+	owners map[api.RepoID]map[string][]string
+}
+
+func (s fakeFileOwners) FileOwners(_ context.Context, _ api.RepoName, repoID api.RepoID, _ api.CommitID, path string) ([]string, error) {
+	return s.owners[repoID][path], nil
+}
+
+func TestOwnerAggregation(t *testing.T) {
+	owners := fakeFileOwners{owners: map[api.RepoID]map[string][]string{
+		1: {
+			"main.go":      {"@backend", "alice@example.com"},
+			"web/index.ts": {"@frontend"},
+		},
+	}}
+
+	aggregator := testAggregator{results: make(map[string]int)}
+	countFunc := NewOwnerCountFunc(context.Background(), owners)
+	sra := newTestSearchResultsAggregator(context.Background(), aggregator.AddResult, countFunc, types.OWNER_AGGREGATION_MODE, nil)
+	sra.Send(streaming.SearchEvent{
+		Results: []result.Match{
+			contentMatch("myRepo", "main.go", 1, "a", "b"),
+			pathMatch("myRepo", "web/index.ts", 1),
+			pathMatch("myRepo", "README.md", 1),
+			contentMatch("myRepo2", "main.go", 2, "a"),
+			commitMatch("myRepo", "Author A", sampleDate, 1, 2, "a"),
+		},
+	})
+
+	autogold.Expect(map[string]int{"@backend": 2, "@frontend": 1, "No owner": 2, "alice@example.com": 2}).Equal(t, aggregator.results)
+}
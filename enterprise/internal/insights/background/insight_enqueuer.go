@@ -80,7 +80,8 @@ func (ie *InsightEnqueuer) discoverAndEnqueueInsights(
 
 	ie.logger.Info("enqueuing indexed insight recordings")
 	// this job will do the work of both recording (permanent) queries, and snapshot (ephemeral) queries. We want to try both, so if either has a soft-failure we will attempt both.
-	recordingArgs := store.GetDataSeriesArgs{NextRecordingBefore: ie.now(), ExcludeJustInTime: true}
+	// Derived series are computed from the points of other series when they are read, so they are never searched.
+	recordingArgs := store.GetDataSeriesArgs{NextRecordingBefore: ie.now(), ExcludeJustInTime: true, ExcludeDerived: true}
 	recordingSeries, err := insightStore.GetDataSeries(ctx, recordingArgs)
 	if err != nil {
 		return errors.Wrap(err, "indexed insight recorder: unable to fetch series for recordings")
@@ -91,7 +92,7 @@ func (ie *InsightEnqueuer) discoverAndEnqueueInsights(
 	}
 
 	ie.logger.Info("enqueuing indexed insight snapshots")
-	snapshotArgs := store.GetDataSeriesArgs{NextSnapshotBefore: ie.now(), ExcludeJustInTime: true}
+	snapshotArgs := store.GetDataSeriesArgs{NextSnapshotBefore: ie.now(), ExcludeJustInTime: true, ExcludeDerived: true}
 	snapshotSeries, err := insightStore.GetDataSeries(ctx, snapshotArgs)
 	if err != nil {
 		return errors.Wrap(err, "indexed insight recorder: unable to fetch series for snapshots")
@@ -0,0 +1,100 @@
+package queryrunner
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// evaluateAlerts evaluates the alerts on a series after a recording of the series, and enqueues the notifications of
+// the alerts that are triggered by it. Each alert is evaluated at most once per recording.
+func (r *workHandler) evaluateAlerts(ctx context.Context, series *types.InsightSeries, recordTime time.Time) error {
+	alerts, err := r.metadadataStore.GetSeriesAlerts(ctx, series.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetSeriesAlerts")
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	totals, err := r.insightsStore.GetRecentSeriesTotals(ctx, *series, recordTime, 2)
+	if err != nil {
+		return errors.Wrap(err, "GetRecentSeriesTotals")
+	}
+
+	var errs error
+	for _, alert := range alerts {
+		if alert.LastEvaluatedAt != nil && !alert.LastEvaluatedAt.Before(recordTime.Truncate(time.Microsecond)) {
+			continue
+		}
+		triggered, ok := triggeredAlert(*series, alert, totals)
+		// The alert is stamped before its notifications are enqueued, so that it does not notify twice for the same
+		// recording. Notifications that cannot be delivered are retried by the code monitor action runner.
+		if err := r.metadadataStore.StampSeriesAlert(ctx, alert.ID, recordTime, ok); err != nil {
+			errs = errors.Append(errs, errors.Wrap(err, "StampSeriesAlert"))
+			continue
+		}
+		if ok {
+			r.logger.Debug("insights series alert triggered", log.Int("alertId", alert.ID), log.String("seriesId", series.SeriesID))
+			if err := background.EnqueueInsightAlert(ctx, edb.CodeMonitors(r.mainAppDB), alertChannels(alert), triggered); err != nil {
+				errs = errors.Append(errs, errors.Wrapf(err, "enqueue alert %d", alert.ID))
+			}
+		}
+	}
+	return errs
+}
+
+// triggeredAlert returns the alert to deliver if the condition of an alert is met by the totals of the latest
+// recordings of a series, most recent first.
+func triggeredAlert(series types.InsightSeries, alert types.InsightSeriesAlert, totals []store.SeriesTotal) (background.InsightAlert, bool) {
+	if len(totals) == 0 {
+		return background.InsightAlert{}, false
+	}
+	current := totals[0]
+	var previous *float64
+	if len(totals) > 1 {
+		previous = &totals[1].Value
+	}
+
+	var met bool
+	switch alert.Condition {
+	case types.AlertAbove:
+		// Only alert when the value crosses the threshold, rather than after every recording that stays above it.
+		met = current.Value > alert.Threshold && (previous == nil || *previous <= alert.Threshold)
+	case types.AlertIncrease:
+		met = previous != nil && current.Value-*previous > alert.Threshold
+	}
+	if !met {
+		return background.InsightAlert{}, false
+	}
+
+	return background.InsightAlert{
+		SeriesID:  series.SeriesID,
+		Query:     series.Query,
+		Condition: string(alert.Condition),
+		Threshold: alert.Threshold,
+		Time:      current.Time,
+		Value:     current.Value,
+		Previous:  previous,
+	}, true
+}
+
+// alertChannels returns the channels the notifications of an alert are delivered through, which are the same as
+// the channels of code monitor notifications.
+func alertChannels(alert types.InsightSeriesAlert) background.InsightAlertChannels {
+	channels := background.InsightAlertChannels{
+		SlackWebhookURL: alert.SlackWebhookURL,
+		WebhookURL:      alert.WebhookURL,
+	}
+	if alert.Email {
+		channels.EmailUserID = &alert.UserID
+	}
+	return channels
+}
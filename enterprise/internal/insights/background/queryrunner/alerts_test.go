@@ -0,0 +1,70 @@
+package queryrunner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestTriggeredAlert(t *testing.T) {
+	series := types.InsightSeries{SeriesID: "s1", Query: "deprecatedFunc("}
+	now := time.Date(2023, time.June, 8, 0, 0, 0, 0, time.UTC)
+
+	totals := func(values ...float64) []store.SeriesTotal {
+		var out []store.SeriesTotal
+		for i, value := range values {
+			out = append(out, store.SeriesTotal{Time: now.AddDate(0, 0, -7*i), Value: value})
+		}
+		return out
+	}
+	above := types.InsightSeriesAlert{Condition: types.AlertAbove, Threshold: 100}
+	increase := types.InsightSeriesAlert{Condition: types.AlertIncrease}
+
+	cases := []struct {
+		name   string
+		alert  types.InsightSeriesAlert
+		totals []store.SeriesTotal
+		want   autogold.Value
+	}{
+		{name: "no recordings", alert: above, want: autogold.Expect(false)},
+		{name: "first recording above", alert: above, totals: totals(101), want: autogold.Expect(true)},
+		{name: "crosses threshold", alert: above, totals: totals(101, 100), want: autogold.Expect(true)},
+		{name: "stays above threshold", alert: above, totals: totals(120, 101), want: autogold.Expect(false)},
+		{name: "below threshold", alert: above, totals: totals(100, 120), want: autogold.Expect(false)},
+		{name: "first recording increase", alert: increase, totals: totals(5), want: autogold.Expect(false)},
+		{name: "increased", alert: increase, totals: totals(6, 5), want: autogold.Expect(true)},
+		{name: "unchanged", alert: increase, totals: totals(5, 5), want: autogold.Expect(false)},
+		{
+			name:   "increased less than threshold",
+			alert:  types.InsightSeriesAlert{Condition: types.AlertIncrease, Threshold: 10},
+			totals: totals(15, 5),
+			want:   autogold.Expect(false),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, got := triggeredAlert(series, tc.alert, tc.totals)
+			tc.want.Equal(t, got)
+		})
+	}
+
+	t.Run("alert", func(t *testing.T) {
+		got, _ := triggeredAlert(series, increase, totals(6, 5))
+		if got.Previous == nil || *got.Previous != 5 {
+			t.Fatalf("unexpected previous value %v", got.Previous)
+		}
+		got.Previous = nil
+		autogold.Expect(background.InsightAlert{
+			SeriesID:  "s1",
+			Query:     "deprecatedFunc(",
+			Condition: "INCREASE",
+			Time:      time.Date(2023, time.June, 8, 0, 0, 0, 0, time.UTC),
+			Value:     6,
+		}).Equal(t, got)
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -29,6 +30,7 @@ type workHandler struct {
 	insightsStore   *store.Store
 	repoStore       discovery.RepoStore
 	metadadataStore *store.InsightStore
+	mainAppDB       database.DB
 	limiter         *ratelimit.InstrumentedLimiter
 	logger          log.Logger
 
@@ -142,7 +144,19 @@ func (r *workHandler) Handle(ctx context.Context, logger log.Logger, record *Job
 		return err
 	}
 
-	return r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime)
+	if err := r.persistRecordings(ctx, &job.SearchJob, series, recordings, recordTime); err != nil {
+		return err
+	}
+
+	// Alerts are evaluated after each recording of a series. The values of derived series are computed from their
+	// operands at read time, so they do not support alerts.
+	if job.PersistMode == string(store.RecordMode) && series.GenerationMethod != types.Derived {
+		if alertErr := r.evaluateAlerts(ctx, series, recordTime); alertErr != nil {
+			// The recording succeeded, so we don't fail the job and search again.
+			logger.Error("insights series alert evaluation failed", log.String("seriesId", series.SeriesID), log.Error(alertErr))
+		}
+	}
+	return nil
 }
 
 func TranslateIncompleteReasons(err error) store.IncompleteReason {
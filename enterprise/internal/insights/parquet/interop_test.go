@@ -0,0 +1,189 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// The interop tests check that files written by Writer can be read by pyarrow, the Python bindings of the Apache
+// Arrow parquet implementation, and that files written by pyarrow can be read by Reader. They are skipped if pyarrow
+// is not installed.
+
+func requirePyarrow(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not on path: ", err)
+	}
+	if err := exec.Command("python3", "-c", "import pyarrow.parquet").Run(); err != nil {
+		t.Skip("pyarrow not installed: ", err)
+	}
+}
+
+const pyarrowReadScript = `
+import json, sys
+import pyarrow as pa, pyarrow.parquet as pq
+
+table = pq.read_table(sys.argv[1])
+schema, columns = [], {}
+for field in table.schema:
+    column = table.column(field.name)
+    typ = str(field.type)
+    if pa.types.is_timestamp(field.type):
+        typ = "timestamp"
+        column = column.cast(pa.timestamp("ms")).cast(pa.int64())
+    schema.append(field.name + ": " + typ + ("" if field.nullable else " not null"))
+    columns[field.name] = column.to_pylist()
+print(json.dumps({"schema": schema, "columns": columns}))
+`
+
+func TestInteropPyarrowReadsWriter(t *testing.T) {
+	requirePyarrow(t)
+
+	path := filepath.Join(t.TempDir(), "points.parquet")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := NewWriter(f, []Column{
+		{Name: "s", Type: String},
+		{Name: "i", Type: Int64, Optional: true},
+		{Name: "d", Type: Double},
+		{Name: "b", Type: Boolean},
+		{Name: "t", Type: Timestamp, Optional: true},
+	})
+	ts := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	i := int64(-3)
+	rows := [][]any{
+		{"a", nil, 1.5, true, ts},
+		{"bc", &i, -2.0, false, nil},
+	}
+	for _, row := range rows {
+		if err := w.Write(row...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command("python3", "-c", pyarrowReadScript, path).Output()
+	if err != nil {
+		t.Fatalf("pyarrow failed to read the file: %v", err)
+	}
+	var got struct {
+		Schema  []string
+		Columns map[string][]any
+	}
+	if err := json.Unmarshal(out, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	wantSchema := []string{"s: string not null", "i: int64", "d: double not null", "b: bool not null", "t: timestamp"}
+	if diff := cmp.Diff(wantSchema, got.Schema); diff != "" {
+		t.Errorf("unexpected schema (-want +got):\n%s", diff)
+	}
+	wantColumns := map[string][]any{
+		"s": {"a", "bc"},
+		"i": {nil, -3.0},
+		"d": {1.5, -2.0},
+		"b": {true, false},
+		"t": {float64(ts.UnixMilli()), nil},
+	}
+	if diff := cmp.Diff(wantColumns, got.Columns); diff != "" {
+		t.Errorf("unexpected columns (-want +got):\n%s", diff)
+	}
+}
+
+const pyarrowWriteScript = `
+import datetime, sys
+import pyarrow as pa, pyarrow.parquet as pq
+
+table = pa.table({
+    "s": pa.array(["a", "bc", "a", None], pa.string()),
+    "i": pa.array([1, None, -3, 4], pa.int64()),
+    "i32": pa.array([1, 2, 3, 4], pa.int32()),
+    "d": pa.array([1.5, 2.0, -1.0, 0.0], pa.float64()),
+    "b": pa.array([True, False, None, True]),
+    "t": pa.array([datetime.datetime(2023, 1, 1), None, datetime.datetime(2023, 2, 1, 12), datetime.datetime(2023, 3, 1)], pa.timestamp("us")),
+})
+pq.write_table(table, sys.argv[1], compression=sys.argv[2], data_page_version=sys.argv[3], use_dictionary=sys.argv[4] == "true", row_group_size=3)
+`
+
+func TestInteropReaderReadsPyarrow(t *testing.T) {
+	requirePyarrow(t)
+
+	jan := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2023, time.February, 1, 12, 0, 0, 0, time.UTC)
+	mar := time.Date(2023, time.March, 1, 0, 0, 0, 0, time.UTC)
+	wantColumns := []Column{
+		{Name: "s", Type: String, Optional: true},
+		{Name: "i", Type: Int64, Optional: true},
+		{Name: "i32", Type: Int64, Optional: true},
+		{Name: "d", Type: Double, Optional: true},
+		{Name: "b", Type: Boolean, Optional: true},
+		{Name: "t", Type: Timestamp, Optional: true},
+	}
+	wantRows := [][]any{
+		{"a", int64(1), int64(1), 1.5, true, jan},
+		{"bc", nil, int64(2), 2.0, false, nil},
+		{"a", int64(-3), int64(3), -1.0, nil, feb},
+		{nil, int64(4), int64(4), 0.0, true, mar},
+	}
+
+	for _, tc := range []struct {
+		compression     string
+		dataPageVersion string
+		dictionary      string
+	}{
+		{"snappy", "1.0", "true"},
+		{"gzip", "1.0", "true"},
+		{"none", "1.0", "false"},
+		{"snappy", "2.0", "true"},
+		{"none", "2.0", "false"},
+	} {
+		name := tc.compression + " pages v" + tc.dataPageVersion + " dictionary " + tc.dictionary
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "points.parquet")
+			if out, err := exec.Command("python3", "-c", pyarrowWriteScript, path, tc.compression, tc.dataPageVersion, tc.dictionary).CombinedOutput(); err != nil {
+				t.Fatalf("pyarrow failed to write the file: %v\n%s", err, out)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(wantColumns, r.Columns()); diff != "" {
+				t.Errorf("unexpected columns (-want +got):\n%s", diff)
+			}
+			var rows [][]any
+			for {
+				row, err := r.Read()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				rows = append(rows, row)
+			}
+			if diff := cmp.Diff(wantRows, rows); diff != "" {
+				t.Errorf("unexpected rows (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,413 @@
+// Package parquet reads and writes tabular data as Apache Parquet files.
+//
+// The writer supports a flat schema of required or optional columns, and writes uncompressed, plain encoded data
+// pages. The reader supports the same flat schemas as written by other implementations, including dictionary
+// encoded and snappy or gzip compressed pages. Written rows are buffered and flushed as a row group once enough of
+// them are written, so that large data sets can be streamed without holding them in memory.
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Type is the type of the values of a column.
+type Type int
+
+const (
+	// String columns hold string values, or *string values for optional columns.
+	String Type = iota
+	// Int64 columns hold int64 values, or *int64 values for optional columns.
+	Int64
+	// Double columns hold float64 values, or *float64 values for optional columns.
+	Double
+	// Boolean columns hold bool values, or *bool values for optional columns.
+	Boolean
+	// Timestamp columns hold time.Time values, or *time.Time values for optional columns. They are stored with
+	// millisecond precision.
+	Timestamp
+)
+
+// Column describes a column of a parquet file.
+type Column struct {
+	Name     string
+	Type     Type
+	Optional bool
+}
+
+// rowGroupSize is the number of rows buffered before they are written as a row group.
+const rowGroupSize = 10_000
+
+const magic = "PAR1"
+
+// Parquet physical types, converted types, repetition types, encodings and page types.
+const (
+	physicalBoolean   = 0
+	physicalInt64     = 2
+	physicalDouble    = 5
+	physicalByteArray = 6
+
+	convertedUTF8            = 0
+	convertedTimestampMillis = 9
+
+	repetitionRequired = 0
+	repetitionOptional = 1
+
+	encodingPlain = 0
+	encodingRLE   = 3
+
+	pageTypeData = 0
+)
+
+// Writer writes rows to a parquet file. Close must be called to write the footer of the file.
+type Writer struct {
+	w       io.Writer
+	offset  int64
+	columns []Column
+
+	// values and bools hold the non-null values of the buffered rows of each column, and defined holds whether each
+	// of the buffered rows has a value for each column.
+	values  []bytes.Buffer
+	bools   [][]bool
+	defined [][]bool
+	rows    int
+
+	totalRows int64
+	rowGroups []rowGroup
+	err       error
+}
+
+type rowGroup struct {
+	numRows int64
+	chunks  []columnChunk
+}
+
+type columnChunk struct {
+	offset    int64
+	size      int64
+	numValues int64
+}
+
+// NewWriter returns a writer that writes a parquet file with the given columns to w.
+func NewWriter(w io.Writer, columns []Column) *Writer {
+	return &Writer{
+		w:       w,
+		columns: columns,
+		values:  make([]bytes.Buffer, len(columns)),
+		bools:   make([][]bool, len(columns)),
+		defined: make([][]bool, len(columns)),
+	}
+}
+
+// Write buffers a row, which holds one value for each column of the writer, and flushes a row group once enough
+// rows are buffered.
+func (pw *Writer) Write(row ...any) error {
+	if pw.err != nil {
+		return pw.err
+	}
+	if len(row) != len(pw.columns) {
+		return errors.Newf("row has %d values, expected %d", len(row), len(pw.columns))
+	}
+	for i, column := range pw.columns {
+		if err := pw.appendValue(i, column, row[i]); err != nil {
+			return errors.Wrapf(err, "column %q", column.Name)
+		}
+	}
+	pw.rows++
+	if pw.rows >= rowGroupSize {
+		return pw.flush()
+	}
+	return nil
+}
+
+func (pw *Writer) appendValue(i int, column Column, value any) error {
+	value, ok := deref(value)
+	if !ok {
+		if !column.Optional {
+			return errors.New("null value for required column")
+		}
+		pw.defined[i] = append(pw.defined[i], false)
+		return nil
+	}
+
+	buf := &pw.values[i]
+	switch column.Type {
+	case String:
+		v, ok := value.(string)
+		if !ok {
+			return errors.Newf("unexpected value of type %T", value)
+		}
+		binary.Write(buf, binary.LittleEndian, uint32(len(v)))
+		buf.WriteString(v)
+	case Int64:
+		v, ok := value.(int64)
+		if !ok {
+			return errors.Newf("unexpected value of type %T", value)
+		}
+		binary.Write(buf, binary.LittleEndian, v)
+	case Double:
+		v, ok := value.(float64)
+		if !ok {
+			return errors.Newf("unexpected value of type %T", value)
+		}
+		binary.Write(buf, binary.LittleEndian, math.Float64bits(v))
+	case Boolean:
+		v, ok := value.(bool)
+		if !ok {
+			return errors.Newf("unexpected value of type %T", value)
+		}
+		pw.bools[i] = append(pw.bools[i], v)
+	case Timestamp:
+		v, ok := value.(time.Time)
+		if !ok {
+			return errors.Newf("unexpected value of type %T", value)
+		}
+		binary.Write(buf, binary.LittleEndian, v.UnixMilli())
+	default:
+		return errors.Newf("unsupported column type %d", column.Type)
+	}
+	pw.defined[i] = append(pw.defined[i], true)
+	return nil
+}
+
+// deref returns the value that value points to if it is a pointer, and false if the value is null.
+func deref(value any) (any, bool) {
+	switch v := value.(type) {
+	case nil:
+		return nil, false
+	case *string:
+		if v == nil {
+			return nil, false
+		}
+		return *v, true
+	case *int64:
+		if v == nil {
+			return nil, false
+		}
+		return *v, true
+	case *float64:
+		if v == nil {
+			return nil, false
+		}
+		return *v, true
+	case *bool:
+		if v == nil {
+			return nil, false
+		}
+		return *v, true
+	case *time.Time:
+		if v == nil {
+			return nil, false
+		}
+		return *v, true
+	}
+	return value, true
+}
+
+// flush writes the buffered rows as a row group with one data page per column.
+func (pw *Writer) flush() error {
+	if pw.rows == 0 {
+		return nil
+	}
+	if pw.offset == 0 {
+		if err := pw.write([]byte(magic)); err != nil {
+			return err
+		}
+	}
+
+	group := rowGroup{numRows: int64(pw.rows)}
+	for i, column := range pw.columns {
+		var page bytes.Buffer
+		if column.Optional {
+			levels := encodeDefinitionLevels(pw.defined[i])
+			binary.Write(&page, binary.LittleEndian, uint32(len(levels)))
+			page.Write(levels)
+		}
+		if column.Type == Boolean {
+			page.Write(encodeBooleans(pw.bools[i]))
+		} else {
+			page.Write(pw.values[i].Bytes())
+		}
+
+		var header thriftWriter
+		header.structBegin()
+		header.i32Field(1, pageTypeData)
+		header.i32Field(2, int32(page.Len()))
+		header.i32Field(3, int32(page.Len()))
+		header.structField(5)
+		header.i32Field(1, int32(pw.rows))
+		header.i32Field(2, encodingPlain)
+		header.i32Field(3, encodingRLE)
+		header.i32Field(4, encodingRLE)
+		header.structEnd()
+		header.structEnd()
+
+		chunk := columnChunk{
+			offset:    pw.offset,
+			size:      int64(header.buf.Len() + page.Len()),
+			numValues: int64(pw.rows),
+		}
+		if err := pw.write(header.buf.Bytes()); err != nil {
+			return err
+		}
+		if err := pw.write(page.Bytes()); err != nil {
+			return err
+		}
+		group.chunks = append(group.chunks, chunk)
+
+		pw.values[i].Reset()
+		pw.bools[i] = pw.bools[i][:0]
+		pw.defined[i] = pw.defined[i][:0]
+	}
+
+	pw.rowGroups = append(pw.rowGroups, group)
+	pw.totalRows += int64(pw.rows)
+	pw.rows = 0
+	return nil
+}
+
+// Close flushes the buffered rows and writes the footer of the file. It does not close the underlying writer.
+func (pw *Writer) Close() error {
+	if pw.err != nil {
+		return pw.err
+	}
+	if err := pw.flush(); err != nil {
+		return err
+	}
+	if pw.offset == 0 {
+		if err := pw.write([]byte(magic)); err != nil {
+			return err
+		}
+	}
+
+	metadata := pw.fileMetadata()
+	var footer bytes.Buffer
+	footer.Write(metadata)
+	binary.Write(&footer, binary.LittleEndian, uint32(len(metadata)))
+	footer.WriteString(magic)
+	return pw.write(footer.Bytes())
+}
+
+func (pw *Writer) fileMetadata() []byte {
+	var t thriftWriter
+	t.structBegin()
+	t.i32Field(1, 1) // version
+
+	t.listField(2, thriftStruct, len(pw.columns)+1)
+	t.structBegin()
+	t.stringField(4, "schema")
+	t.i32Field(5, int32(len(pw.columns)))
+	t.structEnd()
+	for _, column := range pw.columns {
+		t.structBegin()
+		t.i32Field(1, physicalType(column.Type))
+		if column.Optional {
+			t.i32Field(3, repetitionOptional)
+		} else {
+			t.i32Field(3, repetitionRequired)
+		}
+		t.stringField(4, column.Name)
+		switch column.Type {
+		case String:
+			t.i32Field(6, convertedUTF8)
+		case Timestamp:
+			t.i32Field(6, convertedTimestampMillis)
+		}
+		t.structEnd()
+	}
+
+	t.i64Field(3, pw.totalRows)
+
+	t.listField(4, thriftStruct, len(pw.rowGroups))
+	for _, group := range pw.rowGroups {
+		t.structBegin()
+		t.listField(1, thriftStruct, len(group.chunks))
+		var totalSize int64
+		for i, chunk := range group.chunks {
+			totalSize += chunk.size
+			t.structBegin()
+			t.i64Field(2, chunk.offset)
+			t.structField(3)
+			t.i32Field(1, physicalType(pw.columns[i].Type))
+			t.listField(2, thriftI32, 2)
+			t.varint(encodingPlain)
+			t.varint(encodingRLE)
+			t.listField(3, thriftBinary, 1)
+			t.binary(pw.columns[i].Name)
+			t.i32Field(4, 0) // uncompressed
+			t.i64Field(5, chunk.numValues)
+			t.i64Field(6, chunk.size)
+			t.i64Field(7, chunk.size)
+			t.i64Field(9, chunk.offset)
+			t.structEnd()
+			t.structEnd()
+		}
+		t.i64Field(2, totalSize)
+		t.i64Field(3, group.numRows)
+		t.structEnd()
+	}
+
+	t.stringField(6, "sourcegraph code insights")
+	t.structEnd()
+	return t.buf.Bytes()
+}
+
+func (pw *Writer) write(b []byte) error {
+	n, err := pw.w.Write(b)
+	pw.offset += int64(n)
+	if err != nil {
+		pw.err = err
+	}
+	return err
+}
+
+func physicalType(t Type) int32 {
+	switch t {
+	case Int64, Timestamp:
+		return physicalInt64
+	case Double:
+		return physicalDouble
+	case Boolean:
+		return physicalBoolean
+	default:
+		return physicalByteArray
+	}
+}
+
+// encodeDefinitionLevels encodes the definition levels of an optional column, which has a maximum level of 1, with
+// the RLE encoding of the RLE/bit-packing hybrid.
+func encodeDefinitionLevels(defined []bool) []byte {
+	var buf bytes.Buffer
+	var scratch [binary.MaxVarintLen64]byte
+	for i := 0; i < len(defined); {
+		j := i
+		for j < len(defined) && defined[j] == defined[i] {
+			j++
+		}
+		n := binary.PutUvarint(scratch[:], uint64(j-i)<<1)
+		buf.Write(scratch[:n])
+		if defined[i] {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+		i = j
+	}
+	return buf.Bytes()
+}
+
+// encodeBooleans plain encodes boolean values, which are bit-packed starting with the least significant bit.
+func encodeBooleans(values []bool) []byte {
+	b := make([]byte, (len(values)+7)/8)
+	for i, v := range values {
+		if v {
+			b[i/8] |= 1 << (i % 8)
+		}
+	}
+	return b
+}
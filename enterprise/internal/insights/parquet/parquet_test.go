@@ -0,0 +1,239 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hexops/autogold/v2"
+)
+
+func TestWriter(t *testing.T) {
+	columns := []Column{
+		{Name: "name", Type: String},
+		{Name: "count", Type: Int64, Optional: true},
+		{Name: "ok", Type: Boolean},
+	}
+
+	t.Run("file layout", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, columns)
+		count := int64(2)
+		if err := w.Write("a", &count, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Write("b", nil, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		b := buf.Bytes()
+		if !bytes.HasPrefix(b, []byte(magic)) || !bytes.HasSuffix(b, []byte(magic)) {
+			t.Fatalf("file is not framed by %q", magic)
+		}
+		footerLength := binary.LittleEndian.Uint32(b[len(b)-8:])
+		metadata := b[len(b)-8-int(footerLength) : len(b)-8]
+		if !bytes.Equal(metadata, w.fileMetadata()) {
+			t.Fatal("footer length does not point to the file metadata")
+		}
+		if w.totalRows != 2 || len(w.rowGroups) != 1 || len(w.rowGroups[0].chunks) != len(columns) {
+			t.Fatalf("unexpected row groups %+v", w.rowGroups)
+		}
+		// column chunks start where the previous one ends
+		offset := int64(len(magic))
+		for _, chunk := range w.rowGroups[0].chunks {
+			if chunk.offset != offset {
+				t.Fatalf("unexpected chunk offset %d, want %d", chunk.offset, offset)
+			}
+			offset += chunk.size
+		}
+	})
+
+	t.Run("row groups", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, columns)
+		for i := 0; i < rowGroupSize+1; i++ {
+			if err := w.Write("a", nil, true); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect([]int64{10000, 1}).Equal(t, []int64{w.rowGroups[0].numRows, w.rowGroups[1].numRows})
+	})
+
+	t.Run("invalid rows", func(t *testing.T) {
+		w := NewWriter(&bytes.Buffer{}, columns)
+		autogold.Expect("row has 1 values, expected 3").Equal(t, w.Write("a").Error())
+		autogold.Expect(`column "name": null value for required column`).Equal(t, w.Write(nil, nil, true).Error())
+		autogold.Expect(`column "count": unexpected value of type int`).Equal(t, w.Write("a", 1, true).Error())
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := NewWriter(&buf, columns).Close(); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.HasPrefix(buf.Bytes(), []byte(magic)) || !bytes.HasSuffix(buf.Bytes(), []byte(magic)) {
+			t.Fatalf("file is not framed by %q", magic)
+		}
+	})
+}
+
+func TestValueEncodings(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, []Column{
+		{Name: "s", Type: String},
+		{Name: "d", Type: Double},
+		{Name: "t", Type: Timestamp},
+	})
+	if err := w.Write("ab", 1.5, time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect([]string{"020000006162", "000000000000f83f", "00c8a06a85010000"}).Equal(t, []string{
+		hex.EncodeToString(w.values[0].Bytes()),
+		hex.EncodeToString(w.values[1].Bytes()),
+		hex.EncodeToString(w.values[2].Bytes()),
+	})
+}
+
+func TestEncodeDefinitionLevels(t *testing.T) {
+	levels := encodeDefinitionLevels([]bool{true, true, true, false, true})
+	// runs of 3 defined, 1 null and 1 defined value
+	autogold.Expect("060102000201").Equal(t, hex.EncodeToString(levels))
+}
+
+func TestEncodeBooleans(t *testing.T) {
+	values := []bool{true, false, true, true, false, false, false, false, true}
+	autogold.Expect("0d01").Equal(t, hex.EncodeToString(encodeBooleans(values)))
+}
+
+func TestThriftWriter(t *testing.T) {
+	var w thriftWriter
+	w.structBegin()
+	w.i32Field(1, 1)
+	w.i64Field(3, -1)
+	w.stringField(20, "ab")
+	w.listField(21, thriftI32, 2)
+	w.varint(0)
+	w.varint(3)
+	w.structEnd()
+	// field 1 (delta 1), field 3 (delta 2), field 20 (delta 17, so the id is written in full) and field 21 (delta 1)
+	autogold.Expect("1502260108280261621925000600").Equal(t, hex.EncodeToString(w.buf.Bytes()))
+}
+
+func TestReader(t *testing.T) {
+	columns := []Column{
+		{Name: "s", Type: String},
+		{Name: "i", Type: Int64, Optional: true},
+		{Name: "d", Type: Double},
+		{Name: "b", Type: Boolean},
+		{Name: "t", Type: Timestamp, Optional: true},
+	}
+	ts := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	i := int64(-3)
+
+	t.Run("round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, columns)
+		var want [][]any
+		// enough rows for two row groups
+		for n := 0; n < rowGroupSize+2; n++ {
+			row := []any{"a", nil, 1.5, n%3 == 0, nil}
+			if n%2 == 0 {
+				row = []any{"bc", i, -2.0, false, ts}
+			}
+			if err := w.Write(row...); err != nil {
+				t.Fatal(err)
+			}
+			want = append(want, row)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		autogold.Expect(columns).Equal(t, r.Columns())
+		for n := 0; ; n++ {
+			row, err := r.Read()
+			if err == io.EOF {
+				if n != len(want) {
+					t.Fatalf("read %d rows, want %d", n, len(want))
+				}
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want[n], row); diff != "" {
+				t.Fatalf("unexpected row %d (-want +got):\n%s", n, diff)
+			}
+		}
+	})
+
+	t.Run("not a parquet file", func(t *testing.T) {
+		_, err := NewReader(strings.NewReader("series_id,time,value"), 20)
+		autogold.Expect("not a parquet file").Equal(t, err.Error())
+	})
+}
+
+func TestDecodeHybrid(t *testing.T) {
+	// an RLE run of 3 values of 5, followed by a bit-packed group of 8 values of bit width 3
+	data := []byte{0b110, 5, 0b11, 0b10001000, 0b11000110, 0b11111010}
+	values, err := decodeHybrid(data, 3, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect([]uint32{5, 5, 5, 0, 1, 2, 3, 4, 5, 6, 7}).Equal(t, values)
+
+	_, err = decodeHybrid(data, 3, 12)
+	autogold.Expect("data ends before all values are decoded").Equal(t, err.Error())
+}
+
+func TestThriftReader(t *testing.T) {
+	var w thriftWriter
+	w.structBegin()
+	w.i32Field(1, 1)
+	w.i64Field(3, -1)
+	w.stringField(20, "ab")
+	w.listField(21, thriftI32, 2)
+	w.varint(0)
+	w.varint(3)
+	w.structEnd()
+
+	r := &thriftReader{b: w.buf.Bytes()}
+	var got []string
+	err := r.readStruct(func(id int16, typ byte) (bool, error) {
+		switch id {
+		case 3:
+			v, err := r.varint()
+			got = append(got, fmt.Sprintf("%d: %d", id, v))
+			return true, err
+		case 20:
+			v, err := r.binary()
+			got = append(got, fmt.Sprintf("%d: %s", id, v))
+			return true, err
+		}
+		// other fields are skipped
+		return false, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect([]string{"3: -1", "20: ab"}).Equal(t, got)
+	if r.pos != len(r.b) {
+		t.Fatalf("read %d of %d bytes", r.pos, len(r.b))
+	}
+}
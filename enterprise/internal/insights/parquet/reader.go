@@ -0,0 +1,751 @@
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"math"
+	"time"
+
+	"github.com/golang/snappy"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Parquet physical types, converted types, codecs, encodings and page types that are only read.
+const (
+	physicalInt32 = 1
+	physicalInt96 = 3
+	physicalFloat = 4
+
+	convertedDecimal         = 5
+	convertedDate            = 6
+	convertedTimeMillis      = 7
+	convertedTimeMicros      = 8
+	convertedTimestampMicros = 10
+
+	repetitionRepeated = 2
+
+	codecUncompressed = 0
+	codecSnappy       = 1
+	codecGzip         = 2
+
+	encodingPlainDictionary = 2
+	encodingRLEDictionary   = 8
+
+	pageTypeDictionary = 2
+	pageTypeDataV2     = 3
+)
+
+// Reader reads the rows of a parquet file with a flat schema of required or optional columns, such as the files
+// written by Writer. Besides plain encoded, uncompressed pages, it reads the dictionary encoding, version 2 data pages
+// and the snappy and gzip compression that other writers use by default. Row groups are read one at a time.
+type Reader struct {
+	r         io.ReaderAt
+	size      int64
+	columns   []Column
+	schemas   []columnSchema
+	rowGroups []rowGroupMetadata
+
+	// chunks read the column chunks of the current row group, which has remaining rows left to read.
+	nextRowGroup int
+	chunks       []*chunkReader
+	remaining    int64
+}
+
+// columnSchema is the schema of a column as it is stored in the file.
+type columnSchema struct {
+	physicalType int32
+	// timeUnit is the unit of the values of timestamp columns.
+	timeUnit time.Duration
+}
+
+type rowGroupMetadata struct {
+	numRows int64
+	chunks  []chunkMetadata
+}
+
+type chunkMetadata struct {
+	codec  int32
+	offset int64
+	size   int64
+}
+
+// NewReader returns a reader for the parquet file of the given size that r reads from.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	if size < int64(2*len(magic)+4) {
+		return nil, errors.New("not a parquet file")
+	}
+	var header [4]byte
+	if _, err := r.ReadAt(header[:], 0); err != nil {
+		return nil, errors.Wrap(err, "reading header")
+	}
+	var footer [8]byte
+	if _, err := r.ReadAt(footer[:], size-8); err != nil {
+		return nil, errors.Wrap(err, "reading footer")
+	}
+	if string(header[:]) != magic || string(footer[4:]) != magic {
+		return nil, errors.New("not a parquet file")
+	}
+	metadataSize := int64(binary.LittleEndian.Uint32(footer[:4]))
+	if metadataSize > size-int64(2*len(magic)+4) {
+		return nil, errors.New("invalid file metadata length")
+	}
+	metadata := make([]byte, metadataSize)
+	if _, err := r.ReadAt(metadata, size-8-metadataSize); err != nil {
+		return nil, errors.Wrap(err, "reading file metadata")
+	}
+
+	reader := &Reader{r: r, size: size}
+	if err := reader.readFileMetadata(&thriftReader{b: metadata}); err != nil {
+		return nil, errors.Wrap(err, "invalid file metadata")
+	}
+	return reader, nil
+}
+
+// Columns returns the columns of the file.
+func (pr *Reader) Columns() []Column {
+	return pr.columns
+}
+
+// Read returns the next row, which holds one value for each column of the file, or io.EOF once all rows are read.
+// Values have the types documented for the type of their column, and null values are nil.
+func (pr *Reader) Read() ([]any, error) {
+	for pr.remaining == 0 {
+		if pr.nextRowGroup >= len(pr.rowGroups) {
+			return nil, io.EOF
+		}
+		if err := pr.openRowGroup(pr.rowGroups[pr.nextRowGroup]); err != nil {
+			return nil, errors.Wrapf(err, "row group %d", pr.nextRowGroup)
+		}
+		pr.nextRowGroup++
+	}
+
+	row := make([]any, len(pr.columns))
+	for i, chunk := range pr.chunks {
+		value, err := chunk.read()
+		if err != nil {
+			return nil, errors.Wrapf(err, "column %q", pr.columns[i].Name)
+		}
+		row[i] = value
+	}
+	pr.remaining--
+	return row, nil
+}
+
+func (pr *Reader) openRowGroup(group rowGroupMetadata) error {
+	if len(group.chunks) != len(pr.columns) {
+		return errors.Newf("row group has %d column chunks, expected %d", len(group.chunks), len(pr.columns))
+	}
+	pr.chunks = pr.chunks[:0]
+	for i, chunk := range group.chunks {
+		if chunk.offset < 0 || chunk.size < 0 || chunk.offset+chunk.size > pr.size {
+			return errors.Newf("column %q is out of bounds of the file", pr.columns[i].Name)
+		}
+		data := make([]byte, chunk.size)
+		if _, err := pr.r.ReadAt(data, chunk.offset); err != nil {
+			return errors.Wrapf(err, "reading column %q", pr.columns[i].Name)
+		}
+		pr.chunks = append(pr.chunks, &chunkReader{
+			column: pr.columns[i],
+			schema: pr.schemas[i],
+			codec:  chunk.codec,
+			data:   data,
+		})
+	}
+	pr.remaining = group.numRows
+	return nil
+}
+
+// schemaElement holds the fields of a parquet SchemaElement that the reader supports.
+type schemaElement struct {
+	physicalType  *int32
+	repetition    int32
+	name          string
+	numChildren   int32
+	convertedType *int32
+	// timeUnit is set if the logical type of the element is a timestamp.
+	timeUnit time.Duration
+}
+
+func (pr *Reader) readFileMetadata(t *thriftReader) error {
+	var elements []schemaElement
+	err := t.readStruct(func(id int16, typ byte) (bool, error) {
+		switch {
+		case id == 2 && typ == thriftList:
+			return true, readList(t, func() error {
+				element, err := readSchemaElement(t)
+				elements = append(elements, element)
+				return err
+			})
+		case id == 4 && typ == thriftList:
+			return true, readList(t, func() error {
+				group, err := readRowGroup(t)
+				pr.rowGroups = append(pr.rowGroups, group)
+				return err
+			})
+		}
+		return false, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(elements) == 0 || int(elements[0].numChildren) != len(elements)-1 {
+		return errors.New("only flat schemas are supported")
+	}
+	for _, element := range elements[1:] {
+		column, schema, err := columnFromSchemaElement(element)
+		if err != nil {
+			return errors.Wrapf(err, "column %q", element.name)
+		}
+		pr.columns = append(pr.columns, column)
+		pr.schemas = append(pr.schemas, schema)
+	}
+	return nil
+}
+
+// columnFromSchemaElement returns the column of a leaf schema element. Integers are read as Int64 columns and floats
+// as Double columns.
+func columnFromSchemaElement(element schemaElement) (Column, columnSchema, error) {
+	if element.numChildren > 0 || element.physicalType == nil {
+		return Column{}, columnSchema{}, errors.New("only flat schemas are supported")
+	}
+	if element.repetition == repetitionRepeated {
+		return Column{}, columnSchema{}, errors.New("repeated columns are not supported")
+	}
+	if element.convertedType != nil {
+		switch *element.convertedType {
+		case convertedDecimal, convertedDate, convertedTimeMillis, convertedTimeMicros:
+			return Column{}, columnSchema{}, errors.Newf("converted type %d is not supported", *element.convertedType)
+		}
+	}
+
+	column := Column{Name: element.name, Optional: element.repetition == repetitionOptional}
+	schema := columnSchema{physicalType: *element.physicalType}
+	switch *element.physicalType {
+	case physicalBoolean:
+		column.Type = Boolean
+	case physicalInt32:
+		column.Type = Int64
+	case physicalInt64:
+		column.Type = Int64
+		schema.timeUnit = element.timeUnit
+		if element.convertedType != nil {
+			switch *element.convertedType {
+			case convertedTimestampMillis:
+				schema.timeUnit = time.Millisecond
+			case convertedTimestampMicros:
+				schema.timeUnit = time.Microsecond
+			}
+		}
+		if schema.timeUnit != 0 {
+			column.Type = Timestamp
+		}
+	case physicalFloat, physicalDouble:
+		column.Type = Double
+	case physicalByteArray:
+		column.Type = String
+	case physicalInt96:
+		return Column{}, columnSchema{}, errors.New("INT96 timestamps are not supported")
+	default:
+		return Column{}, columnSchema{}, errors.Newf("physical type %d is not supported", *element.physicalType)
+	}
+	return column, schema, nil
+}
+
+func readSchemaElement(t *thriftReader) (element schemaElement, err error) {
+	err = t.readStruct(func(id int16, typ byte) (bool, error) {
+		var err error
+		switch id {
+		case 1:
+			var v int32
+			v, err = t.i32()
+			element.physicalType = &v
+		case 3:
+			element.repetition, err = t.i32()
+		case 4:
+			var name []byte
+			name, err = t.binary()
+			element.name = string(name)
+		case 5:
+			element.numChildren, err = t.i32()
+		case 6:
+			var v int32
+			v, err = t.i32()
+			element.convertedType = &v
+		case 10:
+			element.timeUnit, err = readLogicalTimeUnit(t)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	return element, err
+}
+
+// readLogicalTimeUnit reads a LogicalType union, returning the unit of timestamps and zero for other logical types.
+func readLogicalTimeUnit(t *thriftReader) (unit time.Duration, err error) {
+	err = t.readStruct(func(id int16, typ byte) (bool, error) {
+		if id != 8 || typ != thriftStruct {
+			return false, nil
+		}
+		// TimestampType
+		return true, t.readStruct(func(id int16, typ byte) (bool, error) {
+			if id != 2 || typ != thriftStruct {
+				return false, nil
+			}
+			// TimeUnit
+			return true, t.readStruct(func(id int16, typ byte) (bool, error) {
+				switch id {
+				case 1:
+					unit = time.Millisecond
+				case 2:
+					unit = time.Microsecond
+				case 3:
+					unit = time.Nanosecond
+				}
+				return false, nil
+			})
+		})
+	})
+	return unit, err
+}
+
+func readRowGroup(t *thriftReader) (group rowGroupMetadata, err error) {
+	err = t.readStruct(func(id int16, typ byte) (bool, error) {
+		var err error
+		switch {
+		case id == 1 && typ == thriftList:
+			err = readList(t, func() error {
+				chunk, err := readColumnChunk(t)
+				group.chunks = append(group.chunks, chunk)
+				return err
+			})
+		case id == 3:
+			group.numRows, err = t.varint()
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	return group, err
+}
+
+func readColumnChunk(t *thriftReader) (chunk chunkMetadata, err error) {
+	var dataPageOffset, dictionaryPageOffset int64
+	err = t.readStruct(func(id int16, typ byte) (bool, error) {
+		switch {
+		case id == 1:
+			return false, errors.New("column chunks in other files are not supported")
+		case id == 3 && typ == thriftStruct:
+			// ColumnMetaData
+			return true, t.readStruct(func(id int16, typ byte) (bool, error) {
+				var err error
+				switch id {
+				case 4:
+					chunk.codec, err = t.i32()
+				case 7:
+					chunk.size, err = t.varint()
+				case 9:
+					dataPageOffset, err = t.varint()
+				case 11:
+					dictionaryPageOffset, err = t.varint()
+				default:
+					return false, nil
+				}
+				return true, err
+			})
+		}
+		return false, nil
+	})
+	// The chunk starts with the dictionary page if there is one. Some writers set the dictionary page offset to 0
+	// for chunks without a dictionary.
+	chunk.offset = dataPageOffset
+	if dictionaryPageOffset > 0 && dictionaryPageOffset < dataPageOffset {
+		chunk.offset = dictionaryPageOffset
+	}
+	return chunk, err
+}
+
+// readList reads a list of structs, calling readElement for each of them.
+func readList(t *thriftReader, readElement func() error) error {
+	elemType, size, err := t.list()
+	if err != nil {
+		return err
+	}
+	if elemType != thriftStruct {
+		return errors.Newf("unexpected list element type %d", elemType)
+	}
+	for i := 0; i < size; i++ {
+		if err := readElement(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// chunkReader reads the values of a column chunk, decoding one page at a time.
+type chunkReader struct {
+	column Column
+	schema columnSchema
+	codec  int32
+	data   []byte
+
+	dictionary []any
+	// values holds the values of the current page, with nil for null values.
+	values []any
+	next   int
+}
+
+func (c *chunkReader) read() (any, error) {
+	for c.next >= len(c.values) {
+		if err := c.readPage(); err != nil {
+			return nil, err
+		}
+	}
+	value := c.values[c.next]
+	c.next++
+	return value, nil
+}
+
+// pageHeader holds the fields of a parquet PageHeader and its data or dictionary page header that the reader
+// supports.
+type pageHeader struct {
+	typ            int32
+	compressedSize int32
+	numValues      int32
+	encoding       int32
+	// The sizes of the levels of version 2 data pages, which are not compressed.
+	definitionLevelsSize int32
+	repetitionLevelsSize int32
+	valuesCompressed     bool
+}
+
+func (c *chunkReader) readPage() error {
+	if len(c.data) == 0 {
+		return errors.New("column chunk ends before the end of the row group")
+	}
+	t := &thriftReader{b: c.data}
+	header, err := readPageHeader(t)
+	if err != nil {
+		return errors.Wrap(err, "invalid page header")
+	}
+	if header.compressedSize < 0 || int(header.compressedSize) > len(c.data)-t.pos {
+		return errors.New("page is out of bounds of the column chunk")
+	}
+	body := c.data[t.pos : t.pos+int(header.compressedSize)]
+	c.data = c.data[t.pos+int(header.compressedSize):]
+
+	switch header.typ {
+	case pageTypeDictionary:
+		data, err := decompress(c.codec, body)
+		if err != nil {
+			return err
+		}
+		c.dictionary, err = c.decodePlain(data, int(header.numValues))
+		return err
+	case pageTypeData:
+		data, err := decompress(c.codec, body)
+		if err != nil {
+			return err
+		}
+		var defined []bool
+		if c.column.Optional {
+			if len(data) < 4 {
+				return errors.New("page ends before its definition levels")
+			}
+			size := binary.LittleEndian.Uint32(data)
+			if uint64(size) > uint64(len(data)-4) {
+				return errors.New("page ends before its definition levels")
+			}
+			if defined, err = decodeDefinitionLevels(data[4:4+size], int(header.numValues)); err != nil {
+				return err
+			}
+			data = data[4+size:]
+		}
+		return c.decodeValues(header, data, defined)
+	case pageTypeDataV2:
+		levelsSize := int(header.repetitionLevelsSize) + int(header.definitionLevelsSize)
+		if header.repetitionLevelsSize != 0 {
+			return errors.New("repetition levels are not supported")
+		}
+		if levelsSize < 0 || levelsSize > len(body) {
+			return errors.New("page ends before its definition levels")
+		}
+		var defined []bool
+		if c.column.Optional {
+			if defined, err = decodeDefinitionLevels(body[:levelsSize], int(header.numValues)); err != nil {
+				return err
+			}
+		}
+		data := body[levelsSize:]
+		if header.valuesCompressed {
+			if data, err = decompress(c.codec, data); err != nil {
+				return err
+			}
+		}
+		return c.decodeValues(header, data, defined)
+	default:
+		// Index pages are not needed to read the values.
+		return nil
+	}
+}
+
+func readPageHeader(t *thriftReader) (header pageHeader, err error) {
+	header.valuesCompressed = true
+	readDataPageHeader := func(v2 bool) (bool, error) {
+		return true, t.readStruct(func(id int16, typ byte) (bool, error) {
+			var err error
+			switch {
+			case id == 1:
+				header.numValues, err = t.i32()
+			case id == 2 && !v2, id == 4 && v2:
+				header.encoding, err = t.i32()
+			case id == 5 && v2:
+				header.definitionLevelsSize, err = t.i32()
+			case id == 6 && v2:
+				header.repetitionLevelsSize, err = t.i32()
+			case id == 7 && v2:
+				header.valuesCompressed = typ == thriftBooleanTrue
+			default:
+				return false, nil
+			}
+			return true, err
+		})
+	}
+	err = t.readStruct(func(id int16, typ byte) (bool, error) {
+		var err error
+		switch {
+		case id == 1:
+			header.typ, err = t.i32()
+		case id == 3:
+			header.compressedSize, err = t.i32()
+		case (id == 5 || id == 7) && typ == thriftStruct:
+			// DataPageHeader and DictionaryPageHeader share the fields that are read.
+			return readDataPageHeader(false)
+		case id == 8 && typ == thriftStruct:
+			return readDataPageHeader(true)
+		default:
+			return false, nil
+		}
+		return true, err
+	})
+	return header, err
+}
+
+func decompress(codec int32, data []byte) ([]byte, error) {
+	switch codec {
+	case codecUncompressed:
+		return data, nil
+	case codecSnappy:
+		decoded, err := snappy.Decode(nil, data)
+		return decoded, errors.Wrap(err, "snappy")
+	case codecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrap(err, "gzip")
+		}
+		decoded, err := io.ReadAll(r)
+		return decoded, errors.Wrap(err, "gzip")
+	default:
+		return nil, errors.Newf("compression codec %d is not supported", codec)
+	}
+}
+
+// decodeDefinitionLevels decodes the definition levels of n values of an optional column, which are encoded with
+// the RLE/bit-packing hybrid encoding with a bit width of 1.
+func decodeDefinitionLevels(data []byte, n int) ([]bool, error) {
+	levels, err := decodeHybrid(data, 1, n)
+	if err != nil {
+		return nil, errors.Wrap(err, "definition levels")
+	}
+	defined := make([]bool, n)
+	for i, level := range levels {
+		defined[i] = level == 1
+	}
+	return defined, nil
+}
+
+// decodeValues decodes the values of a data page. defined holds whether each value of the page is defined, and is nil
+// for required columns.
+func (c *chunkReader) decodeValues(header pageHeader, data []byte, defined []bool) error {
+	numDefined := int(header.numValues)
+	if defined != nil {
+		numDefined = 0
+		for _, d := range defined {
+			if d {
+				numDefined++
+			}
+		}
+	}
+
+	var values []any
+	var err error
+	switch header.encoding {
+	case encodingPlain:
+		values, err = c.decodePlain(data, numDefined)
+	case encodingPlainDictionary, encodingRLEDictionary:
+		if len(data) == 0 {
+			return errors.New("page ends before its dictionary indexes")
+		}
+		var indexes []uint32
+		if indexes, err = decodeHybrid(data[1:], int(data[0]), numDefined); err != nil {
+			return errors.Wrap(err, "dictionary indexes")
+		}
+		values = make([]any, numDefined)
+		for i, index := range indexes {
+			if int(index) >= len(c.dictionary) {
+				return errors.Newf("dictionary index %d is out of bounds", index)
+			}
+			values[i] = c.dictionary[index]
+		}
+	case encodingRLE:
+		if c.schema.physicalType != physicalBoolean || len(data) < 4 {
+			return errors.New("invalid RLE encoded page")
+		}
+		var bits []uint32
+		if bits, err = decodeHybrid(data[4:], 1, numDefined); err != nil {
+			return err
+		}
+		values = make([]any, numDefined)
+		for i, bit := range bits {
+			values[i] = bit == 1
+		}
+	default:
+		return errors.Newf("encoding %d is not supported", header.encoding)
+	}
+	if err != nil {
+		return err
+	}
+
+	if defined != nil {
+		all := make([]any, len(defined))
+		next := 0
+		for i, d := range defined {
+			if d {
+				all[i] = values[next]
+				next++
+			}
+		}
+		values = all
+	}
+	c.values, c.next = values, 0
+	return nil
+}
+
+// decodePlain decodes n plain encoded values.
+func (c *chunkReader) decodePlain(data []byte, n int) ([]any, error) {
+	errShort := errors.New("page ends before its values")
+	values := make([]any, 0, n)
+	pos := 0
+	fixed := func(size int) ([]byte, error) {
+		if len(data)-pos < size {
+			return nil, errShort
+		}
+		b := data[pos : pos+size]
+		pos += size
+		return b, nil
+	}
+	for i := 0; i < n; i++ {
+		switch c.schema.physicalType {
+		case physicalBoolean:
+			if i/8 >= len(data) {
+				return nil, errShort
+			}
+			values = append(values, data[i/8]&(1<<(i%8)) != 0)
+		case physicalInt32:
+			b, err := fixed(4)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, int64(int32(binary.LittleEndian.Uint32(b))))
+		case physicalInt64:
+			b, err := fixed(8)
+			if err != nil {
+				return nil, err
+			}
+			v := int64(binary.LittleEndian.Uint64(b))
+			if c.schema.timeUnit != 0 {
+				values = append(values, time.Unix(0, 0).Add(time.Duration(v)*c.schema.timeUnit).UTC())
+			} else {
+				values = append(values, v)
+			}
+		case physicalFloat:
+			b, err := fixed(4)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, float64(math.Float32frombits(binary.LittleEndian.Uint32(b))))
+		case physicalDouble:
+			b, err := fixed(8)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, math.Float64frombits(binary.LittleEndian.Uint64(b)))
+		case physicalByteArray:
+			b, err := fixed(4)
+			if err != nil {
+				return nil, err
+			}
+			if b, err = fixed(int(binary.LittleEndian.Uint32(b))); err != nil {
+				return nil, err
+			}
+			values = append(values, string(b))
+		}
+	}
+	return values, nil
+}
+
+// decodeHybrid decodes n values of the given bit width that are encoded with the RLE/bit-packing hybrid encoding.
+func decodeHybrid(data []byte, bitWidth int, n int) ([]uint32, error) {
+	if bitWidth > 32 {
+		return nil, errors.Newf("invalid bit width %d", bitWidth)
+	}
+	errShort := errors.New("data ends before all values are decoded")
+	values := make([]uint32, 0, n)
+	for len(values) < n {
+		header, size := binary.Uvarint(data)
+		if size <= 0 {
+			return nil, errShort
+		}
+		data = data[size:]
+
+		if header&1 == 0 {
+			// RLE run of a single value, stored in the least number of bytes that hold the bit width.
+			count := header >> 1
+			valueSize := (bitWidth + 7) / 8
+			if len(data) < valueSize {
+				return nil, errShort
+			}
+			var value uint32
+			for i := 0; i < valueSize; i++ {
+				value |= uint32(data[i]) << (8 * i)
+			}
+			data = data[valueSize:]
+			for ; count > 0 && len(values) < n; count-- {
+				values = append(values, value)
+			}
+			continue
+		}
+
+		// bit-packed groups of 8 values, starting with the least significant bit.
+		groups := int(header >> 1)
+		if groups*bitWidth > len(data) {
+			return nil, errShort
+		}
+		for i := 0; i < groups*8 && len(values) < n; i++ {
+			var value uint32
+			for bit := 0; bit < bitWidth; bit++ {
+				offset := i*bitWidth + bit
+				if data[offset/8]&(1<<(offset%8)) != 0 {
+					value |= 1 << bit
+				}
+			}
+			values = append(values, value)
+		}
+		data = data[groups*bitWidth:]
+	}
+	return values, nil
+}
@@ -0,0 +1,278 @@
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Thrift compact protocol type identifiers, as used in field and list headers.
+const (
+	thriftBooleanTrue  = 1
+	thriftBooleanFalse = 2
+	thriftByte         = 3
+	thriftI16          = 4
+	thriftI32          = 5
+	thriftI64          = 6
+	thriftDouble       = 7
+	thriftBinary       = 8
+	thriftList         = 9
+	thriftSet          = 10
+	thriftMap          = 11
+	thriftStruct       = 12
+)
+
+// thriftWriter encodes the parquet metadata structures with the thrift compact protocol. Only the subset of the
+// protocol used by parquet file metadata is supported.
+type thriftWriter struct {
+	buf bytes.Buffer
+	// lastField holds the id of the last field written for each struct that is being written, since field ids are
+	// encoded as deltas.
+	lastField []int16
+}
+
+func (t *thriftWriter) structBegin() {
+	t.lastField = append(t.lastField, 0)
+}
+
+func (t *thriftWriter) structEnd() {
+	t.buf.WriteByte(0) // stop field
+	t.lastField = t.lastField[:len(t.lastField)-1]
+}
+
+func (t *thriftWriter) fieldHeader(id int16, typ byte) {
+	last := &t.lastField[len(t.lastField)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		t.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		t.buf.WriteByte(typ)
+		t.varint(int64(id))
+	}
+	*last = id
+}
+
+func (t *thriftWriter) i32Field(id int16, v int32) {
+	t.fieldHeader(id, thriftI32)
+	t.varint(int64(v))
+}
+
+func (t *thriftWriter) i64Field(id int16, v int64) {
+	t.fieldHeader(id, thriftI64)
+	t.varint(v)
+}
+
+func (t *thriftWriter) stringField(id int16, v string) {
+	t.fieldHeader(id, thriftBinary)
+	t.binary(v)
+}
+
+func (t *thriftWriter) structField(id int16) {
+	t.fieldHeader(id, thriftStruct)
+	t.structBegin()
+}
+
+func (t *thriftWriter) listField(id int16, elemType byte, size int) {
+	t.fieldHeader(id, thriftList)
+	t.listHeader(elemType, size)
+}
+
+func (t *thriftWriter) listHeader(elemType byte, size int) {
+	if size < 15 {
+		t.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		t.buf.WriteByte(0xf0 | elemType)
+		t.uvarint(uint64(size))
+	}
+}
+
+func (t *thriftWriter) binary(v string) {
+	t.uvarint(uint64(len(v)))
+	t.buf.WriteString(v)
+}
+
+// varint writes a zigzag encoded integer.
+func (t *thriftWriter) varint(v int64) {
+	t.uvarint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (t *thriftWriter) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], v)
+	t.buf.Write(b[:n])
+}
+
+// errThriftEOF is returned when thrift encoded data ends unexpectedly.
+var errThriftEOF = errors.New("unexpected end of thrift data")
+
+// thriftReader decodes thrift compact protocol structures from a buffer. Fields that the caller does not handle are
+// skipped, so that metadata written by newer writers can be read.
+type thriftReader struct {
+	b   []byte
+	pos int
+}
+
+// readStruct reads the fields of a struct, calling field for each of them. field must read the value of the field,
+// or return false to skip it.
+func (t *thriftReader) readStruct(field func(id int16, typ byte) (bool, error)) error {
+	var last int16
+	for {
+		header, err := t.byte()
+		if err != nil {
+			return err
+		}
+		if header == 0 {
+			return nil
+		}
+		typ := header & 0x0f
+		id := last + int16(header>>4)
+		if header>>4 == 0 {
+			v, err := t.varint()
+			if err != nil {
+				return err
+			}
+			id = int16(v)
+		}
+		last = id
+		handled, err := field(id, typ)
+		if err != nil {
+			return err
+		}
+		if !handled {
+			if err := t.skip(typ); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (t *thriftReader) byte() (byte, error) {
+	if t.pos >= len(t.b) {
+		return 0, errThriftEOF
+	}
+	b := t.b[t.pos]
+	t.pos++
+	return b, nil
+}
+
+func (t *thriftReader) uvarint() (uint64, error) {
+	v, n := binary.Uvarint(t.b[t.pos:])
+	if n <= 0 {
+		return 0, errThriftEOF
+	}
+	t.pos += n
+	return v, nil
+}
+
+// varint reads a zigzag encoded integer.
+func (t *thriftReader) varint() (int64, error) {
+	v, err := t.uvarint()
+	return int64(v>>1) ^ -int64(v&1), err
+}
+
+func (t *thriftReader) i32() (int32, error) {
+	v, err := t.varint()
+	return int32(v), err
+}
+
+func (t *thriftReader) binary() ([]byte, error) {
+	n, err := t.uvarint()
+	if err != nil {
+		return nil, err
+	}
+	if n > uint64(len(t.b)-t.pos) {
+		return nil, errThriftEOF
+	}
+	b := t.b[t.pos : t.pos+int(n)]
+	t.pos += int(n)
+	return b, nil
+}
+
+// list reads a list header, returning the type and number of its elements.
+func (t *thriftReader) list() (byte, int, error) {
+	header, err := t.byte()
+	if err != nil {
+		return 0, 0, err
+	}
+	size := uint64(header >> 4)
+	if size == 15 {
+		if size, err = t.uvarint(); err != nil {
+			return 0, 0, err
+		}
+	}
+	if size > uint64(len(t.b)-t.pos) {
+		// every element takes at least one byte.
+		return 0, 0, errThriftEOF
+	}
+	return header & 0x0f, int(size), nil
+}
+
+// skip skips a value of the given type.
+func (t *thriftReader) skip(typ byte) error {
+	switch typ {
+	case thriftBooleanTrue, thriftBooleanFalse:
+		// booleans fields are encoded in the field type, and list elements in a single byte, which is skipped by
+		// the list itself.
+		return nil
+	case thriftByte:
+		_, err := t.byte()
+		return err
+	case thriftI16, thriftI32, thriftI64:
+		_, err := t.uvarint()
+		return err
+	case thriftDouble:
+		if len(t.b)-t.pos < 8 {
+			return errThriftEOF
+		}
+		t.pos += 8
+		return nil
+	case thriftBinary:
+		_, err := t.binary()
+		return err
+	case thriftList, thriftSet:
+		elemType, size, err := t.list()
+		if err != nil {
+			return err
+		}
+		for i := 0; i < size; i++ {
+			if err := t.skipElement(elemType); err != nil {
+				return err
+			}
+		}
+		return nil
+	case thriftMap:
+		size, err := t.uvarint()
+		if err != nil {
+			return err
+		}
+		if size == 0 {
+			return nil
+		}
+		types, err := t.byte()
+		if err != nil {
+			return err
+		}
+		for i := uint64(0); i < size; i++ {
+			if err := t.skipElement(types >> 4); err != nil {
+				return err
+			}
+			if err := t.skipElement(types & 0x0f); err != nil {
+				return err
+			}
+		}
+		return nil
+	case thriftStruct:
+		return t.readStruct(func(int16, byte) (bool, error) { return false, nil })
+	default:
+		return errors.Newf("unknown thrift type %d", typ)
+	}
+}
+
+// skipElement skips an element of a container, in which booleans take a byte.
+func (t *thriftReader) skipElement(typ byte) error {
+	if typ == thriftBooleanTrue || typ == thriftBooleanFalse {
+		_, err := t.byte()
+		return err
+	}
+	return t.skip(typ)
+}
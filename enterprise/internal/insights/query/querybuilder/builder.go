@@ -427,6 +427,61 @@ func AddRepoMetadataFilter(query BasicQuery, repoMeta string) (BasicQuery, error
 	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
 }
 
+// AddOwnerFilter restricts a query to the files owned by the given owner, or to the files without an owner.
+func AddOwnerFilter(query BasicQuery, owner string) (BasicQuery, error) {
+	if owner == types.NO_OWNER_TEXT {
+		return addPredicateFilter(query, searchquery.FieldFile, "has.owner()", true)
+	}
+	return addPredicateFilter(query, searchquery.FieldFile, fmt.Sprint("has.owner(", owner, ")"), false)
+}
+
+// AddCommitDateFilter restricts a commit or diff query to the commits with a committer date in [start, end).
+func AddCommitDateFilter(query BasicQuery, start, end time.Time) (BasicQuery, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", err
+	}
+
+	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+2)
+		modified = append(modified, basic.Parameters...)
+		// after: and before: are both exclusive.
+		modified = append(modified, searchquery.Parameter{
+			Field:      searchquery.FieldAfter,
+			Value:      start.Add(-time.Second).UTC().Format(time.RFC3339),
+			Annotation: searchquery.Annotation{},
+		}, searchquery.Parameter{
+			Field:      searchquery.FieldBefore,
+			Value:      end.UTC().Format(time.RFC3339),
+			Annotation: searchquery.Annotation{},
+		})
+		return basic.MapParameters(modified)
+	})
+
+	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
+}
+
+func addPredicateFilter(query BasicQuery, field, predicate string, negated bool) (BasicQuery, error) {
+	plan, err := searchquery.Pipeline(searchquery.Init(string(query), searchquery.SearchTypeLiteral))
+	if err != nil {
+		return "", err
+	}
+
+	mutatedQuery := searchquery.MapPlan(plan, func(basic searchquery.Basic) searchquery.Basic {
+		modified := make([]searchquery.Parameter, 0, len(basic.Parameters)+1)
+		modified = append(modified, basic.Parameters...)
+		modified = append(modified, searchquery.Parameter{
+			Field:      field,
+			Value:      predicate,
+			Negated:    negated,
+			Annotation: searchquery.Annotation{},
+		})
+		return basic.MapParameters(modified)
+	})
+
+	return BasicQuery(searchquery.StringHuman(mutatedQuery.ToQ())), nil
+}
+
 func buildFilterText(raw string) string {
 	quoted := regexp.QuoteMeta(raw)
 	if strings.Contains(raw, " ") {
@@ -657,6 +657,47 @@ func Test_addRepoMetadataFilter(t *testing.T) {
 	}
 }
 
+func Test_addOwnerFilter(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		owner string
+		want  autogold.Value
+	}{
+		{
+			name:  "owner handle",
+			input: "myquery repo:supergreat",
+			owner: "@backend",
+			want:  autogold.Expect(BasicQuery("repo:supergreat file:has.owner(@backend) myquery")),
+		},
+		{
+			name:  "no owner",
+			input: "myquery",
+			owner: "No owner",
+			want:  autogold.Expect(BasicQuery("-file:has.owner() myquery")),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := AddOwnerFilter(BasicQuery(test.input), test.owner)
+			if err != nil {
+				test.want.Equal(t, err.Error())
+			} else {
+				test.want.Equal(t, got)
+			}
+		})
+	}
+}
+
+func Test_addCommitDateFilter(t *testing.T) {
+	start := time.Date(2022, time.March, 28, 0, 0, 0, 0, time.UTC)
+	got, err := AddCommitDateFilter(BasicQuery("type:commit myquery"), start, start.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect(BasicQuery("type:commit after:2022-03-27T23:59:59Z before:2022-04-04T00:00:00Z myquery")).Equal(t, got)
+}
+
 func TestRepositoryScopeQuery(t *testing.T) {
 	tests := []struct {
 		name  string
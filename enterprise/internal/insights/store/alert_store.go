@@ -0,0 +1,118 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// CreateSeriesAlert creates an alert on a series and returns it with its generated fields populated.
+func (s *InsightStore) CreateSeriesAlert(ctx context.Context, alert types.InsightSeriesAlert) (types.InsightSeriesAlert, error) {
+	q := sqlf.Sprintf(createSeriesAlertSql,
+		alert.SeriesID,
+		alert.Condition,
+		alert.Threshold,
+		alert.UserID,
+		alert.Email,
+		alert.SlackWebhookURL,
+		alert.WebhookURL,
+		s.Now(),
+	)
+	alerts, err := scanSeriesAlerts(s.Query(ctx, q))
+	if err != nil {
+		return types.InsightSeriesAlert{}, err
+	}
+	return alerts[0], nil
+}
+
+// GetSeriesAlerts returns the alerts on the series with the given id, in the order they were created.
+func (s *InsightStore) GetSeriesAlerts(ctx context.Context, seriesID int) ([]types.InsightSeriesAlert, error) {
+	return scanSeriesAlerts(s.Query(ctx, sqlf.Sprintf(getSeriesAlertsSql, sqlf.Sprintf("series_id = %s", seriesID))))
+}
+
+// GetSeriesAlertByID returns the alert with the given id, or nil if it does not exist.
+func (s *InsightStore) GetSeriesAlertByID(ctx context.Context, id int) (*types.InsightSeriesAlert, error) {
+	alerts, err := scanSeriesAlerts(s.Query(ctx, sqlf.Sprintf(getSeriesAlertsSql, sqlf.Sprintf("id = %s", id))))
+	if err != nil || len(alerts) == 0 {
+		return nil, err
+	}
+	return &alerts[0], nil
+}
+
+// DeleteSeriesAlert deletes the alert with the given id.
+func (s *InsightStore) DeleteSeriesAlert(ctx context.Context, id int) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteSeriesAlertSql, id))
+}
+
+// StampSeriesAlert records that an alert was evaluated for the recording at the given time, and whether it was
+// triggered by it.
+func (s *InsightStore) StampSeriesAlert(ctx context.Context, id int, recordingTime time.Time, triggered bool) error {
+	return s.Exec(ctx, sqlf.Sprintf(stampSeriesAlertSql, recordingTime, triggered, recordingTime, id))
+}
+
+func scanSeriesAlerts(rows *sql.Rows, queryErr error) (_ []types.InsightSeriesAlert, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	results := make([]types.InsightSeriesAlert, 0)
+	for rows.Next() {
+		var temp types.InsightSeriesAlert
+		var lastEvaluatedAt, lastTriggeredAt sql.NullTime
+		if err := rows.Scan(
+			&temp.ID,
+			&temp.SeriesID,
+			&temp.Condition,
+			&temp.Threshold,
+			&temp.UserID,
+			&temp.Email,
+			&temp.SlackWebhookURL,
+			&temp.WebhookURL,
+			&temp.CreatedAt,
+			&lastEvaluatedAt,
+			&lastTriggeredAt,
+		); err != nil {
+			return nil, err
+		}
+		if lastEvaluatedAt.Valid {
+			temp.LastEvaluatedAt = &lastEvaluatedAt.Time
+		}
+		if lastTriggeredAt.Valid {
+			temp.LastTriggeredAt = &lastTriggeredAt.Time
+		}
+		results = append(results, temp)
+	}
+	return results, nil
+}
+
+const seriesAlertColumns = `id, series_id, condition, threshold, user_id, email, slack_webhook_url, webhook_url, created_at, last_evaluated_at, last_triggered_at`
+
+const createSeriesAlertSql = `
+INSERT INTO insight_series_alerts (series_id, condition, threshold, user_id, email, slack_webhook_url, webhook_url, created_at)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+RETURNING ` + seriesAlertColumns + `;
+`
+
+const getSeriesAlertsSql = `
+SELECT ` + seriesAlertColumns + `
+FROM insight_series_alerts
+WHERE %s
+ORDER BY id;
+`
+
+const deleteSeriesAlertSql = `
+DELETE FROM insight_series_alerts WHERE id = %s;
+`
+
+const stampSeriesAlertSql = `
+UPDATE insight_series_alerts
+SET last_evaluated_at = %s,
+    last_triggered_at = CASE WHEN %s THEN %s ELSE last_triggered_at END
+WHERE id = %s;
+`
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DerivedOperandSeries returns the plain search series among siblings with the given series ID, which is an operand of
+// a derived series. The siblings are the other series of the insight view of the derived series.
+func DerivedOperandSeries(siblings []types.InsightViewSeries, seriesID string) (types.InsightViewSeries, bool) {
+	for _, sibling := range siblings {
+		if sibling.GenerationMethod == types.Derived || sibling.GeneratedFromCaptureGroups || sibling.GroupBy != nil || sibling.JustInTime {
+			continue
+		}
+		if sibling.SeriesID == seriesID {
+			return sibling, true
+		}
+	}
+	return types.InsightViewSeries{}, false
+}
+
+// DerivedSeriesPoints computes the points of a derived series from the points of its operand series, keyed by their
+// series ID. The numerator and denominator at a given time are the sums of the points of the operand series at that
+// time. A zero denominator yields a zero value.
+func DerivedSeriesPoints(definition types.InsightViewSeries, operandPoints map[string][]SeriesPoint) []SeriesPoint {
+	type operands struct {
+		numerator, denominator float64
+	}
+	byTime := make(map[time.Time]*operands)
+	var times []time.Time
+	add := func(seriesIDs []string, value func(current *operands) *float64) {
+		for _, seriesID := range seriesIDs {
+			for _, point := range operandPoints[seriesID] {
+				current, ok := byTime[point.Time]
+				if !ok {
+					current = &operands{}
+					byTime[point.Time] = current
+					times = append(times, point.Time)
+				}
+				*value(current) += point.Value
+			}
+		}
+	}
+	add(definition.DerivedNumerator, func(current *operands) *float64 { return &current.numerator })
+	add(definition.DerivedDenominator, func(current *operands) *float64 { return &current.denominator })
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+
+	points := make([]SeriesPoint, 0, len(times))
+	for _, t := range times {
+		current := byTime[t]
+		var value float64
+		if current.denominator != 0 {
+			value = current.numerator / current.denominator
+			if definition.DerivedOperation != nil && *definition.DerivedOperation == types.Percentage {
+				value *= 100
+			}
+		}
+		points = append(points, SeriesPoint{
+			SeriesID: definition.SeriesID,
+			Time:     t,
+			Value:    value,
+		})
+	}
+	return points
+}
+
+// LoadDerivedSeriesPoints returns the points of a derived series, computed from the points of its operand series among
+// siblings that match opts. Operand series that are no longer among siblings contribute nothing.
+func (s *Store) LoadDerivedSeriesPoints(ctx context.Context, definition types.InsightViewSeries, siblings []types.InsightViewSeries, opts SeriesPointsOpts) ([]SeriesPoint, error) {
+	operandPoints := make(map[string][]SeriesPoint)
+	for _, seriesID := range append(append([]string{}, definition.DerivedNumerator...), definition.DerivedDenominator...) {
+		if _, ok := operandPoints[seriesID]; ok {
+			continue
+		}
+		operand, ok := DerivedOperandSeries(siblings, seriesID)
+		if !ok {
+			operandPoints[seriesID] = nil
+			continue
+		}
+		operandOpts := opts
+		operandOpts.SeriesID = &operand.SeriesID
+		points, err := s.SeriesPoints(ctx, operandOpts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "SeriesPoints for operand seriesID: %s", operand.SeriesID)
+		}
+		operandPoints[seriesID] = points
+	}
+	return DerivedSeriesPoints(definition, operandPoints), nil
+}
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
+)
+
+func TestDerivedSeriesPoints(t *testing.T) {
+	t1 := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	t2 := time.Date(2023, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	operandPoints := map[string][]SeriesPoint{
+		"go":   {{Time: t1, Value: 3}, {Time: t2, Value: 1}},
+		"rust": {{Time: t1, Value: 1}},
+		"all":  {{Time: t1, Value: 4}, {Time: t2, Value: 0}},
+	}
+
+	stringify := func(points []SeriesPoint) []string {
+		var s []string
+		for _, point := range points {
+			s = append(s, fmt.Sprintf("%s %s %v", point.SeriesID, point.Time.Format(time.DateOnly), point.Value))
+		}
+		return s
+	}
+
+	t.Run("percentage", func(t *testing.T) {
+		operation := types.Percentage
+		definition := types.InsightViewSeries{
+			SeriesID:           "derived",
+			DerivedOperation:   &operation,
+			DerivedNumerator:   []string{"go", "rust"},
+			DerivedDenominator: []string{"all"},
+		}
+		autogold.Expect([]string{"derived 2023-01-01 100", "derived 2023-02-01 0"}).Equal(t, stringify(DerivedSeriesPoints(definition, operandPoints)))
+	})
+
+	t.Run("ratio", func(t *testing.T) {
+		operation := types.Ratio
+		definition := types.InsightViewSeries{
+			SeriesID:           "derived",
+			DerivedOperation:   &operation,
+			DerivedNumerator:   []string{"go"},
+			DerivedDenominator: []string{"all"},
+		}
+		autogold.Expect([]string{"derived 2023-01-01 0.75", "derived 2023-02-01 0"}).Equal(t, stringify(DerivedSeriesPoints(definition, operandPoints)))
+	})
+
+	t.Run("removed operand", func(t *testing.T) {
+		operation := types.Ratio
+		definition := types.InsightViewSeries{
+			SeriesID:           "derived",
+			DerivedOperation:   &operation,
+			DerivedNumerator:   []string{"go", "c"},
+			DerivedDenominator: []string{"all"},
+		}
+		autogold.Expect([]string{"derived 2023-01-01 0.75", "derived 2023-02-01 0"}).Equal(t, stringify(DerivedSeriesPoints(definition, operandPoints)))
+	})
+}
+
+func TestDerivedOperandSeries(t *testing.T) {
+	siblings := []types.InsightViewSeries{
+		{SeriesID: "derived", GenerationMethod: types.Derived},
+		{SeriesID: "capture", Query: "lang:go", GenerationMethod: types.SearchCompute, GeneratedFromCaptureGroups: true},
+		{SeriesID: "search", Query: "lang:go", GenerationMethod: types.Search},
+		// Series of the same view can have the same query, so operands are matched by ID.
+		{SeriesID: "search-2", Query: "lang:go", GenerationMethod: types.Search},
+	}
+	got, ok := DerivedOperandSeries(siblings, "search-2")
+	autogold.Expect("search-2 true").Equal(t, fmt.Sprintf("%s %v", got.SeriesID, ok))
+	_, ok = DerivedOperandSeries(siblings, "capture")
+	autogold.Expect(false).Equal(t, ok)
+	_, ok = DerivedOperandSeries(siblings, "lang:go")
+	autogold.Expect(false).Equal(t, ok)
+}
@@ -284,6 +284,7 @@ type GetDataSeriesArgs struct {
 	SeriesID            string
 	GlobalOnly          bool
 	ExcludeJustInTime   bool
+	ExcludeDerived      bool
 }
 
 func (s *InsightStore) GetDataSeries(ctx context.Context, args GetDataSeriesArgs) ([]types.InsightSeries, error) {
@@ -319,6 +320,9 @@ func (s *InsightStore) GetDataSeries(ctx context.Context, args GetDataSeriesArgs
 	if args.ExcludeJustInTime {
 		preds = append(preds, sqlf.Sprintf("just_in_time = false"))
 	}
+	if args.ExcludeDerived {
+		preds = append(preds, sqlf.Sprintf("generation_method != %s", types.Derived))
+	}
 
 	q := sqlf.Sprintf(getInsightDataSeriesSql, sqlf.Join(preds, "\n AND"))
 	return scanDataSeries(s.Query(ctx, q))
@@ -414,6 +418,9 @@ func scanDataSeries(rows *sql.Rows, queryErr error) (_ []types.InsightSeries, er
 			&temp.BackfillAttempts,
 			&temp.SupportsAugmentation,
 			&temp.RepositoryCriteria,
+			&temp.DerivedOperation,
+			pq.Array(&temp.DerivedNumerator),
+			pq.Array(&temp.DerivedDenominator),
 		); err != nil {
 			return []types.InsightSeries{}, err
 		}
@@ -488,6 +495,9 @@ func scanInsightViewSeries(rows *sql.Rows, queryErr error) (_ []types.InsightVie
 			&temp.BackfillAttempts,
 			&temp.SupportsAugmentation,
 			&temp.RepositoryCriteria,
+			&temp.DerivedOperation,
+			pq.Array(&temp.DerivedNumerator),
+			pq.Array(&temp.DerivedDenominator),
 		); err != nil {
 			return []types.InsightViewSeries{}, err
 		}
@@ -734,6 +744,9 @@ func (s *InsightStore) CreateSeries(ctx context.Context, series types.InsightSer
 		series.GenerationMethod,
 		series.GroupBy,
 		series.RepositoryCriteria,
+		series.DerivedOperation,
+		pq.Array(series.DerivedNumerator),
+		pq.Array(series.DerivedDenominator),
 	))
 	var id int
 	err := row.Scan(&id)
@@ -969,8 +982,9 @@ const createInsightSeriesSql = `
 INSERT INTO insight_series (series_id, query, created_at, oldest_historical_at, last_recorded_at,
                             next_recording_after, last_snapshot_at, next_snapshot_after, repositories,
 							sample_interval_unit, sample_interval_value, generated_from_capture_groups,
-							just_in_time, generation_method, group_by, needs_migration, repository_criteria)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, false, %s)
+							just_in_time, generation_method, group_by, needs_migration, repository_criteria,
+							derived_operation, derived_numerator, derived_denominator)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, false, %s, %s, %s, %s)
 RETURNING id;`
 
 const getInsightByViewSql = `
@@ -980,7 +994,8 @@ i.next_recording_after, i.backfill_queued_at, i.last_snapshot_at, i.next_snapsho
 i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, iv.series_num_samples,
-i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria
+i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria,
+i.derived_operation, i.derived_numerator, i.derived_denominator
 FROM (%s) iv
          JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
          JOIN insight_series i ON ivs.insight_series_id = i.id
@@ -995,7 +1010,8 @@ i.next_recording_after, i.backfill_queued_at, i.last_snapshot_at, i.next_snapsho
 i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, iv.series_num_samples,
-i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria
+i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria,
+i.derived_operation, i.derived_numerator, i.derived_denominator
 FROM dashboard_insight_view as dbiv
 		 JOIN insight_view iv ON iv.id = dbiv.insight_view_id
          JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
@@ -1009,7 +1025,8 @@ const getInsightDataSeriesSql = `
 SELECT id, series_id, query, created_at, oldest_historical_at, last_recorded_at, next_recording_after,
 last_snapshot_at, next_snapshot_after, (CASE WHEN deleted_at IS NULL THEN TRUE ELSE FALSE END) AS enabled,
 sample_interval_unit, sample_interval_value, generated_from_capture_groups,
-just_in_time, generation_method, repositories, group_by, backfill_attempts, supports_augmentation, repository_criteria
+just_in_time, generation_method, repositories, group_by, backfill_attempts, supports_augmentation, repository_criteria,
+derived_operation, derived_numerator, derived_denominator
 FROM insight_series
 WHERE %s
 `
@@ -1036,7 +1053,8 @@ SELECT iv.id, 0 as dashboard_insight_id, iv.unique_id, iv.title, iv.description,
        i.sample_interval_unit, i.sample_interval_value, iv.default_filter_include_repo_regex, iv.default_filter_exclude_repo_regex,
 	   iv.other_threshold, iv.presentation_type, i.generated_from_capture_groups, i.just_in_time, i.generation_method, iv.is_frozen,
 	   default_filter_search_contexts, iv.series_sort_mode, iv.series_sort_direction, iv.series_limit, iv.series_num_samples,
-	   i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria
+	   i.group_by, i.backfill_attempts, i.supports_augmentation, i.repository_criteria,
+	   i.derived_operation, i.derived_numerator, i.derived_denominator
 
 FROM insight_view iv
 JOIN insight_view_series ivs ON iv.id = ivs.insight_view_id
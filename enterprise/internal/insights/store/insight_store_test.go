@@ -2727,3 +2727,86 @@ func TestHardDeleteSeries(t *testing.T) {
 		t.Errorf("expected 1 recording times to remain for series2")
 	}
 }
+
+func TestSeriesAlerts(t *testing.T) {
+	logger := logtest.Scoped(t)
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	now := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	store := NewInsightStore(insightsDB)
+	store.Now = func() time.Time {
+		return now
+	}
+
+	series, err := store.CreateSeries(ctx, types.InsightSeries{
+		SeriesID:           "series1",
+		Query:              "query1",
+		CreatedAt:          now,
+		OldestHistoricalAt: now,
+		LastRecordedAt:     now,
+		NextRecordingAfter: now,
+		LastSnapshotAt:     now,
+		NextSnapshotAfter:  now,
+		BackfillQueuedAt:   now,
+		SampleIntervalUnit: string(types.Week),
+		GenerationMethod:   types.Search,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	webhook := "https://example.com/hook"
+	created, err := store.CreateSeriesAlert(ctx, types.InsightSeriesAlert{
+		SeriesID:   series.ID,
+		Condition:  types.AlertIncrease,
+		Threshold:  5,
+		UserID:     1,
+		WebhookURL: &webhook,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recordingTime := now.Add(time.Hour)
+	if err := store.StampSeriesAlert(ctx, created.ID, recordingTime, true); err != nil {
+		t.Fatal(err)
+	}
+	alerts, err := store.GetSeriesAlerts(ctx, series.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 1 {
+		t.Fatalf("expected one alert, got %d", len(alerts))
+	}
+	got := alerts[0]
+	if got.Condition != types.AlertIncrease || got.Threshold != 5 || got.WebhookURL == nil || *got.WebhookURL != webhook || got.Email {
+		t.Errorf("unexpected alert %+v", got)
+	}
+	if got.LastEvaluatedAt == nil || !got.LastEvaluatedAt.Equal(recordingTime) || got.LastTriggeredAt == nil || !got.LastTriggeredAt.Equal(recordingTime) {
+		t.Errorf("unexpected stamps %v %v", got.LastEvaluatedAt, got.LastTriggeredAt)
+	}
+
+	// evaluating without triggering keeps the time the alert was last triggered
+	if err := store.StampSeriesAlert(ctx, created.ID, recordingTime.Add(time.Hour), false); err != nil {
+		t.Fatal(err)
+	}
+	alert, err := store.GetSeriesAlertByID(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !alert.LastEvaluatedAt.Equal(recordingTime.Add(time.Hour)) || !alert.LastTriggeredAt.Equal(recordingTime) {
+		t.Errorf("unexpected stamps %v %v", alert.LastEvaluatedAt, alert.LastTriggeredAt)
+	}
+
+	if err := store.DeleteSeriesAlert(ctx, created.ID); err != nil {
+		t.Fatal(err)
+	}
+	alert, err = store.GetSeriesAlertByID(ctx, created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alert != nil {
+		t.Errorf("alert was not deleted")
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/RoaringBitmap/roaring"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/insights/types"
@@ -628,6 +629,76 @@ func (s *Store) RecordSeriesPoints(ctx context.Context, pts []RecordSeriesPointA
 	return nil
 }
 
+// UpsertSeriesPoints stores multiple data points atomically, replacing the points that are already recorded for the
+// same series, repository, time and capture. Use this in favour of RecordSeriesPoints if the points may have been
+// recorded before, e.g. when importing points.
+func (s *Store) UpsertSeriesPoints(ctx context.Context, pts []RecordSeriesPointArgs) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	type keys struct {
+		seriesIDs []string
+		repoIDs   []sql.NullInt64
+		times     []time.Time
+		captures  []sql.NullString
+	}
+	keysByTable := map[string]*keys{}
+	for _, pt := range pts {
+		var table string
+		switch pt.PersistMode {
+		case RecordMode:
+			table = recordingTable
+		case SnapshotMode:
+			table = snapshotsTable
+		default:
+			return errors.Newf("unsupported insights series point persist mode: %v", pt.PersistMode)
+		}
+		k, ok := keysByTable[table]
+		if !ok {
+			k = &keys{}
+			keysByTable[table] = k
+		}
+		var repoID sql.NullInt64
+		if pt.RepoID != nil {
+			repoID = sql.NullInt64{Int64: int64(*pt.RepoID), Valid: true}
+		}
+		var capture sql.NullString
+		if pt.Point.Capture != nil {
+			capture = sql.NullString{String: *pt.Point.Capture, Valid: true}
+		}
+		k.seriesIDs = append(k.seriesIDs, pt.SeriesID)
+		k.repoIDs = append(k.repoIDs, repoID)
+		k.times = append(k.times, pt.Point.Time.UTC())
+		k.captures = append(k.captures, capture)
+	}
+
+	for table, k := range keysByTable {
+		if err := tx.Exec(ctx, sqlf.Sprintf(
+			deleteSeriesPointsByKeySql,
+			sqlf.Sprintf(table),
+			pq.Array(k.seriesIDs),
+			pq.Array(k.repoIDs),
+			pq.Array(k.times),
+			pq.Array(k.captures),
+		)); err != nil {
+			return errors.Wrap(err, "deleting existing points")
+		}
+	}
+	return tx.RecordSeriesPoints(ctx, pts)
+}
+
+const deleteSeriesPointsByKeySql = `
+DELETE FROM %s sp
+USING unnest(%s::text[], %s::integer[], %s::timestamptz[], %s::text[]) AS k(series_id, repo_id, time, capture)
+WHERE sp.series_id = k.series_id
+	AND sp.repo_id IS NOT DISTINCT FROM k.repo_id
+	AND sp.time = k.time
+	AND sp.capture IS NOT DISTINCT FROM k.capture
+`
+
 func (s *Store) SetInsightSeriesRecordingTimes(ctx context.Context, seriesRecordingTimes []types.InsightSeriesRecordingTimes) (err error) {
 	if len(seriesRecordingTimes) == 0 {
 		return nil
@@ -714,6 +785,44 @@ const getOffsetNRecordingTimeSql = `
 select recording_time from insight_series_recording_times where %s order by recording_time desc offset %s limit 1
 `
 
+// SeriesTotal is the sum of the values of a series over all repositories and captures at a recording time.
+type SeriesTotal struct {
+	Time  time.Time
+	Value float64
+}
+
+// GetRecentSeriesTotals returns the totals of the latest n recordings of a series (excluding snapshots) up to and
+// including the given time, most recent first. Recordings without any points have a total of zero.
+//
+// 🚨 SECURITY: The totals include every repository, so they must not be shown to users without access to all of
+// them.
+func (s *Store) GetRecentSeriesTotals(ctx context.Context, series types.InsightSeries, until time.Time, n int) ([]SeriesTotal, error) {
+	var totals []SeriesTotal
+	err := s.query(ctx, sqlf.Sprintf(getRecentSeriesTotalsSql, series.ID, until.UTC(), n, series.SeriesID), func(sc scanner) error {
+		var total SeriesTotal
+		if err := sc.Scan(&total.Time, &total.Value); err != nil {
+			return err
+		}
+		totals = append(totals, total)
+		return nil
+	})
+	return totals, err
+}
+
+const getRecentSeriesTotalsSql = `
+WITH recordings AS (
+	SELECT recording_time FROM insight_series_recording_times
+	WHERE insight_series_id = %s AND snapshot IS FALSE AND recording_time <= %s
+	ORDER BY recording_time DESC
+	LIMIT %s
+)
+SELECT r.recording_time, COALESCE(SUM(sp.value), 0)
+FROM recordings r
+LEFT JOIN series_points sp ON sp.series_id = %s AND sp.time = r.recording_time
+GROUP BY r.recording_time
+ORDER BY r.recording_time DESC;
+`
+
 // RecordSeriesPointsAndRecordingTimes is a wrapper around the RecordSeriesPoints and SetInsightSeriesRecordingTimes
 // functions. It makes the assumption that this is called per-series, so all the points will share the same SeriesID.
 // Use this in favour of RecordSeriesPoints if recording times are known.
@@ -979,6 +1088,67 @@ func (s *Store) GetAllDataForInsightViewID(ctx context.Context, opts ExportOpts)
 	return results, nil
 }
 
+// RecordedSeriesPoint is a point recorded for a series, with the repository and capture it was recorded for.
+type RecordedSeriesPoint struct {
+	SeriesID string
+	Time     time.Time
+	Value    float64
+	RepoID   *api.RepoID
+	RepoName *string
+	Capture  *string
+	// Snapshot is true for the points of the most recent snapshot of the series, which are replaced by the next
+	// snapshot, and false for recorded points.
+	Snapshot bool
+}
+
+// StreamRecordedSeriesPoints calls fn for each point recorded for the given series, including archived points, ordered
+// by series and time. Points of repositories the user in the context is not authorized to see are excluded.
+func (s *Store) StreamRecordedSeriesPoints(ctx context.Context, seriesIDs []string, fn func(RecordedSeriesPoint) error) error {
+	// 🚨 SECURITY: callers must ensure the series belong to insights that are visible to the user in the context.
+	// We enforce repo permissions here as we store repository data at this level.
+	denylist, err := s.permStore.GetUnauthorizedRepoIDs(ctx)
+	if err != nil {
+		return errors.Wrap(err, "GetUnauthorizedRepoIDs")
+	}
+	preds := []*sqlf.Query{sqlf.Sprintf("sp.series_id = ANY(%s)", pq.Array(seriesIDs))}
+	if len(denylist) > 0 {
+		excludedRepoIDs := make([]*sqlf.Query, 0, len(denylist))
+		for _, repoID := range denylist {
+			excludedRepoIDs = append(excludedRepoIDs, sqlf.Sprintf("%d", repoID))
+		}
+		preds = append(preds, sqlf.Sprintf("(sp.repo_id IS NULL OR sp.repo_id NOT IN (%s))", sqlf.Join(excludedRepoIDs, ",")))
+	}
+
+	return s.query(ctx, sqlf.Sprintf(streamRecordedSeriesPointsSql, sqlf.Join(preds, "AND")), func(sc scanner) error {
+		var point RecordedSeriesPoint
+		if err := sc.Scan(
+			&point.SeriesID,
+			&point.Time,
+			&point.Value,
+			&point.RepoID,
+			&point.RepoName,
+			&point.Capture,
+			&point.Snapshot,
+		); err != nil {
+			return err
+		}
+		return fn(point)
+	})
+}
+
+const streamRecordedSeriesPointsSql = `
+SELECT sp.series_id, sp.time, sp.value, sp.repo_id, rn.name, sp.capture, sp.snapshot FROM (
+	SELECT series_id, time, value, repo_id, repo_name_id, capture, false AS snapshot FROM archived_series_points
+	UNION ALL
+	SELECT series_id, time, value, repo_id, repo_name_id, capture, false AS snapshot FROM series_points
+	UNION ALL
+	SELECT series_id, time, value, repo_id, repo_name_id, capture, true AS snapshot FROM series_points_snapshots
+) sp
+LEFT OUTER JOIN repo_names rn ON rn.id = sp.repo_name_id
+WHERE %s
+ORDER BY sp.series_id, sp.time, rn.name, sp.capture;
+`
+
 const exportCodeInsightsDataSql = `
 select iv.title, ivs.label, i.query, isrt.recording_time, rn.name, coalesce(sp.value, 0) as value, sp.capture 
 from %s isrt
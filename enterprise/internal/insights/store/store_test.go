@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"testing"
@@ -537,6 +538,124 @@ func TestDeleteSnapshots(t *testing.T) {
 	autogold.Expect(gotRecordingTimes).Equal(t, wantRecordingTimes)
 }
 
+func TestStreamRecordedSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	clock := timeutil.Now
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(insightsDB, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+
+	records := []RecordSeriesPointArgs{
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: current, Value: 1},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: SnapshotMode,
+		},
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: current.Add(-time.Hour * 24 * 14), Value: 2, Capture: optionalString("a")},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "two",
+			Point:       SeriesPoint{Time: current, Value: 3},
+			RepoName:    optionalString("repo2"),
+			RepoID:      optionalRepoID(4),
+			PersistMode: RecordMode,
+		},
+	}
+	if err := store.RecordSeriesPoints(ctx, records); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	err := store.StreamRecordedSeriesPoints(ctx, []string{"one"}, func(point RecordedSeriesPoint) error {
+		got = append(got, fmt.Sprintf("%s %s %v %d %s %q %v", point.SeriesID, point.Time.UTC().Format(time.RFC3339), point.Value, *point.RepoID, *point.RepoName, pointers.Deref(point.Capture, ""), point.Snapshot))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect([]string{
+		`one 2021-08-27T10:00:00Z 2 3 repo1 "a" false`,
+		`one 2021-09-10T10:00:00Z 1 3 repo1 "" true`,
+	}).Equal(t, got)
+}
+
+func TestUpsertSeriesPoints(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	clock := timeutil.Now
+	insightsDB := edb.NewInsightsDB(dbtest.NewInsightsDB(logger, t), logger)
+	postgres := database.NewDB(logger, dbtest.NewDB(logger, t))
+	permStore := NewInsightPermissionStore(postgres)
+	store := NewWithClock(insightsDB, permStore, clock)
+
+	optionalString := func(v string) *string { return &v }
+	optionalRepoID := func(v api.RepoID) *api.RepoID { return &v }
+
+	current := time.Date(2021, time.September, 10, 10, 0, 0, 0, time.UTC)
+
+	records := []RecordSeriesPointArgs{
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: current, Value: 1},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: RecordMode,
+		},
+		{
+			SeriesID:    "one",
+			Point:       SeriesPoint{Time: current, Value: 2, Capture: optionalString("a")},
+			RepoName:    optionalString("repo1"),
+			RepoID:      optionalRepoID(3),
+			PersistMode: RecordMode,
+		},
+	}
+	if err := store.UpsertSeriesPoints(ctx, records); err != nil {
+		t.Fatal(err)
+	}
+	// Upserting the points again replaces them, including the point without a capture.
+	records[0].Point.Value = 10
+	records[1].Point.Value = 20
+	if err := store.UpsertSeriesPoints(ctx, records); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	err := store.StreamRecordedSeriesPoints(ctx, []string{"one"}, func(point RecordedSeriesPoint) error {
+		got = append(got, fmt.Sprintf("%s %s %v %q", point.SeriesID, point.Time.UTC().Format(time.RFC3339), point.Value, pointers.Deref(point.Capture, "")))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(got)
+	autogold.Expect([]string{
+		`one 2021-09-10T10:00:00Z 10 ""`,
+		`one 2021-09-10T10:00:00Z 20 "a"`,
+	}).Equal(t, got)
+}
+
 func TestValues(t *testing.T) {
 	ids := []api.RepoID{1, 2, 3, 4, 5, 6}
 	got := values(ids)
@@ -43,6 +43,9 @@ type InsightViewSeries struct {
 	SupportsAugmentation          bool
 	RepositoryCriteria            *string
 	SeriesNumSamples              *int32
+	DerivedOperation              *DerivedOperation
+	DerivedNumerator              []string
+	DerivedDenominator            []string
 }
 
 type Insight struct {
@@ -111,6 +114,9 @@ type InsightSeries struct {
 	BackfillAttempts           int32
 	SupportsAugmentation       bool
 	RepositoryCriteria         *string
+	DerivedOperation           *DerivedOperation
+	DerivedNumerator           []string
+	DerivedDenominator         []string
 }
 
 type IntervalUnit string
@@ -131,6 +137,45 @@ const (
 	SearchCompute  GenerationMethod = "search-compute"
 	LanguageStats  GenerationMethod = "language-stats"
 	MappingCompute GenerationMethod = "mapping-compute"
+	// Derived series are not recorded. They are computed from the points of their operand series, which are other
+	// series of their insight view, when the series is read or exported.
+	Derived GenerationMethod = "derived"
+)
+
+// DerivedOperation is the operation that combines the sum of the numerator series and the sum of the denominator
+// series of a derived series into a single value.
+type DerivedOperation string
+
+const (
+	Percentage DerivedOperation = "PERCENTAGE" // 100 * numerator / denominator
+	Ratio      DerivedOperation = "RATIO"      // numerator / denominator
+)
+
+// InsightSeriesAlert is a threshold rule on the total value of a series, that is evaluated after each recording of
+// the series and notifies its recipient when the rule is met.
+type InsightSeriesAlert struct {
+	ID              int
+	SeriesID        int // the id of the insight_series row
+	Condition       AlertCondition
+	Threshold       float64
+	UserID          int32 // the user that created the alert, who receives the email notifications
+	Email           bool
+	SlackWebhookURL *string
+	WebhookURL      *string
+	CreatedAt       time.Time
+	LastEvaluatedAt *time.Time
+	LastTriggeredAt *time.Time
+}
+
+// AlertCondition is the condition under which an alert on a series is triggered.
+type AlertCondition string
+
+const (
+	// AlertAbove triggers when the value of a series rises above the threshold.
+	AlertAbove AlertCondition = "ABOVE"
+	// AlertIncrease triggers when the value of a series increased by more than the threshold since the previous
+	// recording.
+	AlertIncrease AlertCondition = "INCREASE"
 )
 
 type Dashboard struct {
@@ -214,9 +259,12 @@ const (
 	AUTHOR_AGGREGATION_MODE        SearchAggregationMode = "AUTHOR"
 	CAPTURE_GROUP_AGGREGATION_MODE SearchAggregationMode = "CAPTURE_GROUP"
 	REPO_METADATA_AGGREGATION_MODE SearchAggregationMode = "REPO_METADATA"
+	OWNER_AGGREGATION_MODE         SearchAggregationMode = "OWNER"
+	COMMIT_WEEK_AGGREGATION_MODE   SearchAggregationMode = "COMMIT_WEEK"
+	COMMIT_MONTH_AGGREGATION_MODE  SearchAggregationMode = "COMMIT_MONTH"
 )
 
-var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, REPO_METADATA_AGGREGATION_MODE}
+var SearchAggregationModes = []SearchAggregationMode{REPO_AGGREGATION_MODE, PATH_AGGREGATION_MODE, AUTHOR_AGGREGATION_MODE, CAPTURE_GROUP_AGGREGATION_MODE, REPO_METADATA_AGGREGATION_MODE, OWNER_AGGREGATION_MODE, COMMIT_WEEK_AGGREGATION_MODE, COMMIT_MONTH_AGGREGATION_MODE}
 
 type AggregationNotAvailableReasonType string
 
@@ -230,4 +278,5 @@ const (
 
 const (
 	NO_REPO_METADATA_TEXT = "No metadata"
+	NO_OWNER_TEXT         = "No owner"
 )
@@ -0,0 +1,435 @@
+package notebooks
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Info strings of the fenced code blocks used to represent notebook blocks in
+// Markdown. Markdown blocks are represented by the Markdown text itself.
+const (
+	markdownQueryFence   = "sourcegraph-query"
+	markdownFileFence    = "sourcegraph-file"
+	markdownSymbolFence  = "sourcegraph-symbol"
+	markdownComputeFence = "sourcegraph-compute"
+	markdownInsightFence = "sourcegraph-insight"
+
+	// markdownLegacyQueryFence is the info string used for query blocks by
+	// notebooks exported from the web app.
+	markdownLegacyQueryFence = "sourcegraph"
+)
+
+// blobPathSeparator separates the repository (and revision) from the file path
+// in file and symbol block locations, e.g.
+// github.com/sourcegraph/sourcegraph@main/-/blob/README.md?L1-10.
+const blobPathSeparator = "/-/blob/"
+
+// BlocksToMarkdown serializes notebook blocks to Markdown. Every block is
+// preceded by a block marker, an HTML comment with the type and ID of the
+// block that is not rendered. Markdown blocks are written as-is, every other
+// block type is written as a fenced code block with a sourcegraph-* info
+// string. The result can be converted back to the same blocks with
+// MarkdownToBlocks.
+func BlocksToMarkdown(blocks NotebookBlocks) string {
+	parts := make([]string, 0, len(blocks))
+	for _, block := range blocks {
+		var part string
+		switch block.Type {
+		case NotebookMarkdownBlockType:
+			part = strings.TrimRight(block.MarkdownInput.Text, "\n")
+		case NotebookQueryBlockType:
+			part = fencedBlock(markdownQueryFence, block.QueryInput.Text)
+		case NotebookFileBlockType:
+			input := block.FileInput
+			part = fencedBlock(markdownFileFence, blobLocation(input.RepositoryName, input.Revision, input.FilePath, input.LineRange))
+		case NotebookSymbolBlockType:
+			input := block.SymbolInput
+			params := url.Values{
+				"symbolName":          []string{input.SymbolName},
+				"symbolContainerName": []string{input.SymbolContainerName},
+				"symbolKind":          []string{input.SymbolKind},
+				"lineContext":         []string{strconv.Itoa(int(input.LineContext))},
+			}
+			part = fencedBlock(markdownSymbolFence, blobLocation(input.RepositoryName, input.Revision, input.FilePath, nil)+"#"+params.Encode())
+		case NotebookComputeBlockType:
+			part = fencedBlock(markdownComputeFence, block.ComputeInput.Text)
+		case NotebookInsightBlockType:
+			params := url.Values{"insightViewId": []string{block.InsightInput.InsightViewID}}
+			if len(block.InsightInput.SeriesIDs) > 0 {
+				params["seriesId"] = block.InsightInput.SeriesIDs
+			}
+			part = fencedBlock(markdownInsightFence, params.Encode())
+		default:
+			continue
+		}
+		marker := blockMarker(block)
+		if part != "" {
+			marker += "\n" + part
+		}
+		parts = append(parts, marker)
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+// blockMarker returns the HTML comment written before a block, e.g.
+// <!-- sourcegraph-block type=query id=1 -->. Markers delimit blocks, such that
+// adjacent Markdown blocks are not merged and fenced code blocks in Markdown
+// blocks are kept as Markdown, and carry the IDs of blocks across round trips.
+func blockMarker(block NotebookBlock) string {
+	return fmt.Sprintf("<!-- sourcegraph-block type=%s id=%s -->", block.Type, url.QueryEscape(block.ID))
+}
+
+var blockMarkerPattern = lazyregexp.New(`^<!-- sourcegraph-block type=(\S+) id=(\S*) -->\s*$`)
+
+// parseBlockMarker returns the type and ID of the block marker on line, or ok
+// false if line is not a block marker.
+func parseBlockMarker(line string) (blockType NotebookBlockType, id string, ok bool) {
+	matches := blockMarkerPattern.FindStringSubmatch(line)
+	if matches == nil {
+		return "", "", false
+	}
+	id, err := url.QueryUnescape(matches[2])
+	if err != nil {
+		return "", "", false
+	}
+	return NotebookBlockType(matches[1]), id, true
+}
+
+// fencedBlock returns a fenced code block containing content. The fence is
+// longer than any run of backticks in content, so the content cannot close it.
+func fencedBlock(infoString, content string) string {
+	fenceLength := 3
+	run := 0
+	for _, r := range content {
+		if r != '`' {
+			run = 0
+			continue
+		}
+		run++
+		if run >= fenceLength {
+			fenceLength = run + 1
+		}
+	}
+	fence := strings.Repeat("`", fenceLength)
+	return fence + infoString + "\n" + strings.TrimRight(content, "\n") + "\n" + fence
+}
+
+func blobLocation(repositoryName string, revision *string, filePath string, lineRange *LineRange) string {
+	var b strings.Builder
+	b.WriteString(repositoryName)
+	if revision != nil && *revision != "" {
+		b.WriteString("@")
+		b.WriteString(*revision)
+	}
+	b.WriteString(blobPathSeparator)
+	b.WriteString(filePath)
+	if lineRange != nil {
+		b.WriteString("?L")
+		b.WriteString(serializeLineRange(*lineRange))
+	}
+	return b.String()
+}
+
+// serializeLineRange and parseLineRange use the same line range format as the
+// web app, where the start line is 0-based and the end line is exclusive.
+func serializeLineRange(lineRange LineRange) string {
+	if lineRange.StartLine+1 == lineRange.EndLine {
+		return strconv.Itoa(int(lineRange.StartLine + 1))
+	}
+	return fmt.Sprintf("%d-%d", lineRange.StartLine+1, lineRange.EndLine)
+}
+
+var lineRangePattern = lazyregexp.New(`^(\d+)(?:-(\d+))?$`)
+
+func parseLineRange(value string) (*LineRange, error) {
+	matches := lineRangePattern.FindStringSubmatch(value)
+	if matches == nil {
+		return nil, errors.Errorf("invalid line range: %q", value)
+	}
+	start, err := strconv.ParseInt(matches[1], 10, 32)
+	if err != nil || start < 1 {
+		return nil, errors.Errorf("invalid line range: %q", value)
+	}
+	end := start
+	if matches[2] != "" {
+		end, err = strconv.ParseInt(matches[2], 10, 32)
+		if err != nil || end < start {
+			return nil, errors.Errorf("invalid line range: %q", value)
+		}
+	}
+	return &LineRange{StartLine: int32(start - 1), EndLine: int32(end)}, nil
+}
+
+var fencePattern = lazyregexp.New("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+
+// MarkdownToBlocks parses Markdown, as produced by BlocksToMarkdown, into
+// notebook blocks. Each block marker starts a new block with the type and ID
+// of the marker. The content following a Markdown block marker is a single
+// Markdown block, even if it contains fenced code blocks with a sourcegraph-*
+// info string.
+//
+// Markdown without block markers, such as files written by hand, is parsed by
+// converting fenced code blocks with a sourcegraph-* info string to the
+// corresponding block type and keeping everything else as Markdown blocks.
+// Blocks without a marker get IDs derived from their position, so that parsing
+// the same file twice yields the same IDs.
+func MarkdownToBlocks(markdown string) (NotebookBlocks, error) {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	blocks := NotebookBlocks{}
+	start := 0
+	var marker *NotebookBlock
+	addSection := func(end int) error {
+		sectionLines := lines[start:end]
+		if marker == nil {
+			sectionBlocks, err := parseUnmarkedBlocks(sectionLines, start)
+			blocks = append(blocks, sectionBlocks...)
+			return err
+		}
+		if marker.Type == NotebookMarkdownBlockType {
+			block := *marker
+			block.MarkdownInput = &NotebookMarkdownBlockInput{Text: strings.TrimRight(strings.Join(sectionLines, "\n"), "\n")}
+			blocks = append(blocks, block)
+			return nil
+		}
+		// Blocks added by hand after a marked block do not have a marker of
+		// their own, so only the first block of the section is marked.
+		sectionBlocks, err := parseUnmarkedBlocks(sectionLines, start)
+		if err != nil {
+			return err
+		}
+		if len(sectionBlocks) == 0 || sectionBlocks[0].Type != marker.Type {
+			return errors.Errorf("line %d: expected a %s block after the block marker", start, marker.Type)
+		}
+		sectionBlocks[0].ID = marker.ID
+		blocks = append(blocks, sectionBlocks...)
+		return nil
+	}
+
+	for i := 0; i < len(lines); i++ {
+		if matches := fencePattern.FindStringSubmatch(lines[i]); matches != nil {
+			// Block markers in fenced code blocks are part of the code block,
+			// unless the code block is not closed.
+			if end := findClosingFence(lines, i+1, matches[1]); end < len(lines) {
+				i = end
+			}
+			continue
+		}
+		blockType, id, ok := parseBlockMarker(lines[i])
+		if !ok {
+			continue
+		}
+		if err := addSection(i); err != nil {
+			return nil, err
+		}
+		marker = &NotebookBlock{ID: id, Type: blockType}
+		start = i + 1
+	}
+	if err := addSection(len(lines)); err != nil {
+		return nil, err
+	}
+
+	assignPositionalIDs(blocks)
+	return blocks, nil
+}
+
+// assignPositionalIDs sets the ID of blocks without an ID to their position,
+// starting at 1, or to a variant of it if the position is the ID of another
+// block.
+func assignPositionalIDs(blocks NotebookBlocks) {
+	ids := make(map[string]struct{}, len(blocks))
+	for _, block := range blocks {
+		if block.ID != "" {
+			ids[block.ID] = struct{}{}
+		}
+	}
+	for i := range blocks {
+		if blocks[i].ID != "" {
+			continue
+		}
+		id := strconv.Itoa(i + 1)
+		for suffix := 2; ; suffix++ {
+			if _, ok := ids[id]; !ok {
+				break
+			}
+			id = fmt.Sprintf("%d-%d", i+1, suffix)
+		}
+		blocks[i].ID = id
+		ids[id] = struct{}{}
+	}
+}
+
+// parseUnmarkedBlocks parses lines without block markers into blocks without
+// IDs. Fenced code blocks with a sourcegraph-* info string are converted to
+// the corresponding block type, everything else is kept as Markdown blocks.
+// offset is the index of the first line in the document, for error messages.
+func parseUnmarkedBlocks(lines []string, offset int) (NotebookBlocks, error) {
+	blocks := NotebookBlocks{}
+
+	var markdownLines []string
+	flushMarkdown := func() {
+		text := strings.Trim(strings.Join(markdownLines, "\n"), "\n")
+		markdownLines = markdownLines[:0]
+		if strings.TrimSpace(text) == "" {
+			return
+		}
+		blocks = append(blocks, NotebookBlock{Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: text}})
+	}
+
+	for i := 0; i < len(lines); i++ {
+		matches := fencePattern.FindStringSubmatch(lines[i])
+		if matches == nil {
+			markdownLines = append(markdownLines, lines[i])
+			continue
+		}
+
+		fence, infoString := matches[1], matches[2]
+		end := findClosingFence(lines, i+1, fence)
+		if !isNotebookFence(infoString) {
+			// Regular fenced code blocks are part of the surrounding Markdown.
+			// An unterminated fence runs until the end of the section.
+			last := end
+			if last == len(lines) {
+				last = len(lines) - 1
+			}
+			markdownLines = append(markdownLines, lines[i:last+1]...)
+			i = last
+			continue
+		}
+		if end == len(lines) {
+			return nil, errors.Errorf("line %d: unterminated %s block", offset+i+1, infoString)
+		}
+
+		block, err := parseFencedBlock(infoString, strings.Join(lines[i+1:end], "\n"))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", offset+i+1)
+		}
+		flushMarkdown()
+		blocks = append(blocks, block)
+		i = end
+	}
+	flushMarkdown()
+
+	return blocks, nil
+}
+
+// findClosingFence returns the index of the line closing the fenced code
+// block opened with fence, or len(lines) if the block is not closed.
+func findClosingFence(lines []string, start int, fence string) int {
+	for i := start; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " ")
+		if len(lines[i])-len(line) > 3 {
+			continue
+		}
+		trimmed := strings.TrimRight(line, " \t")
+		if len(trimmed) >= len(fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			return i
+		}
+	}
+	return len(lines)
+}
+
+func isNotebookFence(infoString string) bool {
+	switch infoString {
+	case markdownQueryFence, markdownLegacyQueryFence, markdownFileFence, markdownSymbolFence, markdownComputeFence, markdownInsightFence:
+		return true
+	}
+	return false
+}
+
+func parseFencedBlock(infoString, content string) (NotebookBlock, error) {
+	switch infoString {
+	case markdownQueryFence, markdownLegacyQueryFence:
+		return NotebookBlock{Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: content}}, nil
+
+	case markdownComputeFence:
+		return NotebookBlock{Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Text: content}}, nil
+
+	case markdownFileFence:
+		location, rawLineRange, _ := strings.Cut(strings.TrimSpace(content), "?L")
+		repositoryName, revision, filePath, err := parseBlobLocation(location)
+		if err != nil {
+			return NotebookBlock{}, err
+		}
+		input := &NotebookFileBlockInput{RepositoryName: repositoryName, Revision: revision, FilePath: filePath}
+		if rawLineRange != "" {
+			if input.LineRange, err = parseLineRange(rawLineRange); err != nil {
+				return NotebookBlock{}, err
+			}
+		}
+		return NotebookBlock{Type: NotebookFileBlockType, FileInput: input}, nil
+
+	case markdownSymbolFence:
+		location, rawParams, _ := strings.Cut(strings.TrimSpace(content), "#")
+		// Symbol blocks follow the symbol around the file, so a line range
+		// is not needed to locate it.
+		location, _, _ = strings.Cut(location, "?")
+		repositoryName, revision, filePath, err := parseBlobLocation(location)
+		if err != nil {
+			return NotebookBlock{}, err
+		}
+		params, err := url.ParseQuery(rawParams)
+		if err != nil {
+			return NotebookBlock{}, errors.Wrap(err, "invalid symbol parameters")
+		}
+		if params.Get("symbolName") == "" {
+			return NotebookBlock{}, errors.New("symbol block is missing a symbol name")
+		}
+		lineContext := int64(3)
+		if raw := params.Get("lineContext"); raw != "" {
+			if lineContext, err = strconv.ParseInt(raw, 10, 32); err != nil {
+				return NotebookBlock{}, errors.Errorf("invalid symbol line context: %q", raw)
+			}
+		}
+		return NotebookBlock{Type: NotebookSymbolBlockType, SymbolInput: &NotebookSymbolBlockInput{
+			RepositoryName:      repositoryName,
+			Revision:            revision,
+			FilePath:            filePath,
+			LineContext:         int32(lineContext),
+			SymbolName:          params.Get("symbolName"),
+			SymbolContainerName: params.Get("symbolContainerName"),
+			SymbolKind:          params.Get("symbolKind"),
+		}}, nil
+
+	case markdownInsightFence:
+		params, err := url.ParseQuery(strings.TrimSpace(content))
+		if err != nil {
+			return NotebookBlock{}, errors.Wrap(err, "invalid insight parameters")
+		}
+		return NotebookBlock{Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{
+			InsightViewID: params.Get("insightViewId"),
+			SeriesIDs:     params["seriesId"],
+		}}, nil
+	}
+	return NotebookBlock{}, errors.Errorf("unknown block type %q", infoString)
+}
+
+// parseBlobLocation parses a location of the form
+// <repository>[@<revision>]/-/blob/<path>. Absolute Sourcegraph URLs are
+// accepted as well.
+func parseBlobLocation(location string) (repositoryName string, revision *string, filePath string, err error) {
+	if u, err := url.Parse(location); err == nil && u.Scheme != "" && u.Host != "" {
+		location = strings.TrimPrefix(u.Path, "/")
+	}
+
+	repoAndRevision, filePath, ok := strings.Cut(location, blobPathSeparator)
+	if !ok || filePath == "" {
+		return "", nil, "", errors.Errorf("invalid file location: %q", location)
+	}
+	repositoryName, rev, hasRevision := strings.Cut(repoAndRevision, "@")
+	if repositoryName == "" {
+		return "", nil, "", errors.Errorf("invalid file location: %q", location)
+	}
+	if hasRevision && rev != "" {
+		revision = &rev
+	}
+	return repositoryName, revision, filePath, nil
+}
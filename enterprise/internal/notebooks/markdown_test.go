@@ -0,0 +1,275 @@
+package notebooks
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hexops/autogold/v2"
+)
+
+func testBlocks() NotebookBlocks {
+	revision := "main"
+	return NotebookBlocks{
+		{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "# Title\n\nSome `code` and a list:\n\n* a\n* b"}},
+		{ID: "2", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "repo:a b"}},
+		{ID: "3", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{
+			RepositoryName: "github.com/sourcegraph/sourcegraph",
+			FilePath:       "client/web/index.ts",
+			Revision:       &revision,
+			LineRange:      &LineRange{StartLine: 9, EndLine: 20},
+		}},
+		{ID: "4", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{
+			RepositoryName: "github.com/sourcegraph/sourcegraph",
+			FilePath:       "README.md",
+		}},
+		{ID: "5", Type: NotebookSymbolBlockType, SymbolInput: &NotebookSymbolBlockInput{
+			RepositoryName:      "github.com/sourcegraph/sourcegraph",
+			FilePath:            "cmd/frontend/main.go",
+			Revision:            &revision,
+			LineContext:         3,
+			SymbolName:          "main",
+			SymbolContainerName: "main",
+			SymbolKind:          "FUNCTION",
+		}},
+		{ID: "6", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Text: "content:output((\\w+) -> $1) type:file"}},
+		{ID: "7", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{InsightViewID: "aW5zaWdodF92aWV3OiIxIg==", SeriesIDs: []string{"s1", "s2"}}},
+		{ID: "8", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "## Query with backticks"}},
+		{ID: "9", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "content:\"```\""}},
+	}
+}
+
+func TestBlocksToMarkdown(t *testing.T) {
+	autogold.Expect("<!-- sourcegraph-block type=md id=1 -->\n# Title\n\nSome `code` and a list:\n\n* a\n* b\n\n<!-- sourcegraph-block type=query id=2 -->\n```sourcegraph-query\nrepo:a b\n```\n\n<!-- sourcegraph-block type=file id=3 -->\n```sourcegraph-file\ngithub.com/sourcegraph/sourcegraph@main/-/blob/client/web/index.ts?L10-20\n```\n\n<!-- sourcegraph-block type=file id=4 -->\n```sourcegraph-file\ngithub.com/sourcegraph/sourcegraph/-/blob/README.md\n```\n\n<!-- sourcegraph-block type=symbol id=5 -->\n```sourcegraph-symbol\ngithub.com/sourcegraph/sourcegraph@main/-/blob/cmd/frontend/main.go#lineContext=3&symbolContainerName=main&symbolKind=FUNCTION&symbolName=main\n```\n\n<!-- sourcegraph-block type=compute id=6 -->\n```sourcegraph-compute\ncontent:output((\\w+) -> $1) type:file\n```\n\n<!-- sourcegraph-block type=insight id=7 -->\n```sourcegraph-insight\ninsightViewId=aW5zaWdodF92aWV3OiIxIg%3D%3D&seriesId=s1&seriesId=s2\n```\n\n<!-- sourcegraph-block type=md id=8 -->\n## Query with backticks\n\n<!-- sourcegraph-block type=query id=9 -->\n````sourcegraph-query\ncontent:\"```\"\n````\n").Equal(t, BlocksToMarkdown(testBlocks()))
+}
+
+func TestMarkdownRoundTrip(t *testing.T) {
+	blocks, err := MarkdownToBlocks(BlocksToMarkdown(testBlocks()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(testBlocks(), blocks); diff != "" {
+		t.Fatalf("unexpected blocks (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarkdownRoundTripKeepsBlockBoundaries(t *testing.T) {
+	blocks := NotebookBlocks{
+		{ID: "a3f1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "First paragraph"}},
+		{ID: "b7c2", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "Second paragraph"}},
+		{ID: "c9d4", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "Example:\n\n```sourcegraph-query\nrepo:a\n```"}},
+		{ID: "d2e8", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: ""}},
+		{ID: "id with spaces", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "repo:b"}},
+	}
+	got, err := MarkdownToBlocks(BlocksToMarkdown(blocks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(blocks, got); diff != "" {
+		t.Fatalf("unexpected blocks (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarkdownToBlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     NotebookBlocks
+	}{
+		{
+			name:     "empty",
+			markdown: "",
+			want:     NotebookBlocks{},
+		},
+		{
+			name:     "only markdown",
+			markdown: "# Title\n\nParagraph\n",
+			want: NotebookBlocks{
+				{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "# Title\n\nParagraph"}},
+			},
+		},
+		{
+			name:     "web app query fence",
+			markdown: "```sourcegraph\nrepo:a b\n```",
+			want: NotebookBlocks{
+				{ID: "1", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "repo:a b"}},
+			},
+		},
+		{
+			name:     "regular code blocks are markdown",
+			markdown: "# Example\n\n````md\n```sourcegraph-query\nrepo:a\n```\n````\n\n```go\nfunc main() {}\n```\n",
+			want: NotebookBlocks{
+				{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "# Example\n\n````md\n```sourcegraph-query\nrepo:a\n```\n````\n\n```go\nfunc main() {}\n```"}},
+			},
+		},
+		{
+			name:     "absolute file url and single line",
+			markdown: "~~~sourcegraph-file\nhttps://sourcegraph.com/github.com/sourcegraph/sourcegraph@feature/-/blob/client/web/index.ts?L101\n~~~",
+			want: NotebookBlocks{
+				{ID: "1", Type: NotebookFileBlockType, FileInput: &NotebookFileBlockInput{
+					RepositoryName: "github.com/sourcegraph/sourcegraph",
+					FilePath:       "client/web/index.ts",
+					Revision:       strPtr("feature"),
+					LineRange:      &LineRange{StartLine: 100, EndLine: 101},
+				}},
+			},
+		},
+		{
+			name:     "crlf line endings",
+			markdown: "Text\r\n\r\n```sourcegraph-compute\r\ncontent:output(a -> b)\r\n```\r\n",
+			want: NotebookBlocks{
+				{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "Text"}},
+				{ID: "2", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{Text: "content:output(a -> b)"}},
+			},
+		},
+		{
+			name:     "blocks added after marked blocks",
+			markdown: "<!-- sourcegraph-block type=query id=2 -->\n```sourcegraph-query\nrepo:a\n```\n\nAdded by hand\n\n<!-- sourcegraph-block type=md id=1 -->\nText\n",
+			want: NotebookBlocks{
+				{ID: "2", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "repo:a"}},
+				{ID: "2-2", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "Added by hand"}},
+				{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "Text"}},
+			},
+		},
+		{
+			name:     "markers in code blocks",
+			markdown: "<!-- sourcegraph-block type=md id=1 -->\n```md\n<!-- sourcegraph-block type=md id=2 -->\n```\n",
+			want: NotebookBlocks{
+				{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "```md\n<!-- sourcegraph-block type=md id=2 -->\n```"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarkdownToBlocks(tt.markdown)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected blocks (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMarkdownToBlocksErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     autogold.Value
+	}{
+		{
+			name:     "unterminated block",
+			markdown: "# Title\n\n```sourcegraph-query\nrepo:a",
+			want:     autogold.Expect("line 3: unterminated sourcegraph-query block"),
+		},
+		{
+			name:     "invalid file location",
+			markdown: "```sourcegraph-file\ngithub.com/sourcegraph/sourcegraph\n```",
+			want:     autogold.Expect(`line 1: invalid file location: "github.com/sourcegraph/sourcegraph"`),
+		},
+		{
+			name:     "invalid line range",
+			markdown: "```sourcegraph-file\ngithub.com/sourcegraph/sourcegraph/-/blob/a.go?L10-2\n```",
+			want:     autogold.Expect(`line 1: invalid line range: "10-2"`),
+		},
+		{
+			name:     "marker type mismatch",
+			markdown: "Intro\n\n<!-- sourcegraph-block type=file id=1 -->\n```sourcegraph-query\nrepo:a\n```",
+			want:     autogold.Expect("line 3: expected a file block after the block marker"),
+		},
+		{
+			name:     "symbol without name",
+			markdown: "```sourcegraph-symbol\ngithub.com/sourcegraph/sourcegraph/-/blob/a.go#symbolKind=FUNCTION\n```",
+			want:     autogold.Expect("line 1: symbol block is missing a symbol name"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := MarkdownToBlocks(tt.markdown)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			tt.want.Equal(t, err.Error())
+		})
+	}
+}
+
+func TestNotebookTitleFromPath(t *testing.T) {
+	for path, want := range map[string]string{
+		"docs/Onboarding.snb.md": "Onboarding",
+		"a b.snb.md":             "a b",
+		".snb.md":                "New Notebook",
+	} {
+		if got := NotebookTitleFromPath(path); got != want {
+			t.Errorf("NotebookTitleFromPath(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestMarshalSnapshot(t *testing.T) {
+	snapshot, err := MarshalSnapshot(&Notebook{ID: 1, Title: "Title", Blocks: testBlocks()[:2], Public: true}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect(`{
+  "title": "Title",
+  "blocks": [
+    {
+      "id": "1",
+      "type": "md",
+      "markdownInput": {
+        "text": "# Title\n\nSome `+"`code`"+` and a list:\n\n* a\n* b"
+      }
+    },
+    {
+      "id": "2",
+      "type": "query",
+      "queryInput": {
+        "text": "repo:a b"
+      }
+    }
+  ]
+}`).Equal(t, string(snapshot))
+
+	snapshot, err = MarshalSnapshot(&Notebook{Title: "Title", Blocks: testBlocks()[5:6]}, map[string]*NotebookBlockTable{
+		"6": {Columns: []string{"repository", "path", "value"}, Rows: [][]string{{"a", "b.go", "c"}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	autogold.Expect(`{
+  "title": "Title",
+  "blocks": [
+    {
+      "id": "6",
+      "type": "compute",
+      "computeInput": {
+        "text": "content:output((\\w+) -> $1) type:file"
+      }
+    }
+  ],
+  "outputs": {
+    "6": {
+      "columns": [
+        "repository",
+        "path",
+        "value"
+      ],
+      "rows": [
+        [
+          "a",
+          "b.go",
+          "c"
+        ]
+      ],
+      "limitHit": false
+    }
+  }
+}`).Equal(t, string(snapshot))
+}
+
+func strPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,32 @@
+package notebooks
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// NotebookSnapshot is a self-contained JSON representation of the content of
+// a notebook, used to export notebooks.
+type NotebookSnapshot struct {
+	Title  string         `json:"title"`
+	Blocks NotebookBlocks `json:"blocks"`
+
+	// Outputs holds the output of executed compute and insight blocks at the
+	// time of the export, keyed by block ID.
+	Outputs map[string]*NotebookBlockTable `json:"outputs,omitempty"`
+}
+
+// MarshalSnapshot returns the JSON snapshot of the notebook, including the
+// given outputs of executed blocks.
+func MarshalSnapshot(n *Notebook, outputs map[string]*NotebookBlockTable) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	// Queries commonly contain characters such as `->` that would otherwise be
+	// escaped, which makes exported snapshots hard to read.
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(NotebookSnapshot{Title: n.Title, Blocks: n.Blocks, Outputs: outputs}); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
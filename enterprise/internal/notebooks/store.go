@@ -11,6 +11,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -46,6 +47,7 @@ type ListNotebooksOptions struct {
 	StarredByUserID   int32
 	NamespaceUserID   int32
 	NamespaceOrgID    int32
+	HasSource         bool // if true, only notebooks imported from a repository are listed.
 	OrderBy           NotebooksOrderByOption
 	OrderByDescending bool
 }
@@ -72,6 +74,7 @@ type NotebooksStore interface {
 	GetNotebook(ctx context.Context, notebookID int64) (*Notebook, error)
 	CreateNotebook(ctx context.Context, notebook *Notebook) (*Notebook, error)
 	UpdateNotebook(ctx context.Context, notebook *Notebook) (*Notebook, error)
+	UpdateNotebookSource(ctx context.Context, notebookID int64, blocks NotebookBlocks, commit api.CommitID) (*Notebook, error)
 	DeleteNotebook(ctx context.Context, notebookID int64) error
 	ListNotebooks(ctx context.Context, pageOpts ListNotebooksPageOptions, opts ListNotebooksOptions) ([]*Notebook, error)
 	CountNotebooks(ctx context.Context, opts ListNotebooksOptions) (int64, error)
@@ -117,6 +120,9 @@ var notebookColumns = []*sqlf.Query{
 	sqlf.Sprintf("notebooks.namespace_org_id"),
 	sqlf.Sprintf("notebooks.created_at"),
 	sqlf.Sprintf("notebooks.updated_at"),
+	sqlf.Sprintf("notebooks.source_repo_id"),
+	sqlf.Sprintf("notebooks.source_path"),
+	sqlf.Sprintf("notebooks.source_commit"),
 }
 
 func notebooksPermissionsCondition(ctx context.Context) *sqlf.Query {
@@ -196,6 +202,9 @@ func scanNotebook(scanner dbutil.Scanner) (*Notebook, error) {
 		&dbutil.NullInt32{N: &n.NamespaceOrgID},
 		&n.CreatedAt,
 		&n.UpdatedAt,
+		&dbutil.NullInt32{N: (*int32)(&n.SourceRepoID)},
+		&dbutil.NullString{S: &n.SourcePath},
+		&dbutil.NullString{S: (*string)(&n.SourceCommit)},
 	)
 	if err != nil {
 		return nil, err
@@ -247,6 +256,9 @@ func getNotebooksQueryCondition(opts ListNotebooksOptions) (*sqlf.Query, error)
 	if opts.StarredByUserID != 0 {
 		conds = append(conds, sqlf.Sprintf("notebook_stars.user_id = %d", opts.StarredByUserID))
 	}
+	if opts.HasSource {
+		conds = append(conds, sqlf.Sprintf("notebooks.source_repo_id IS NOT NULL"))
+	}
 	if opts.Query != "" {
 		conds = append(
 			conds,
@@ -333,7 +345,7 @@ func (s *notebooksStore) GetNotebook(ctx context.Context, id int64) (*Notebook,
 }
 
 const insertNotebookFmtStr = `
-INSERT INTO notebooks (title, blocks, public, creator_user_id, updater_user_id, namespace_user_id, namespace_org_id) VALUES (%s, %s, %s, %s, %s, %s, %s)
+INSERT INTO notebooks (title, blocks, public, creator_user_id, updater_user_id, namespace_user_id, namespace_org_id, source_repo_id, source_path, source_commit) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -353,6 +365,9 @@ func (s *notebooksStore) CreateNotebook(ctx context.Context, n *Notebook) (*Note
 			dbutil.NullInt32Column(n.UpdaterUserID),
 			dbutil.NullInt32Column(n.NamespaceUserID),
 			dbutil.NullInt32Column(n.NamespaceOrgID),
+			dbutil.NullInt32Column(int32(n.SourceRepoID)),
+			dbutil.NullStringColumn(n.SourcePath),
+			dbutil.NullStringColumn(string(n.SourceCommit)),
 			sqlf.Join(notebookColumns, ","),
 		),
 	)
@@ -403,6 +418,42 @@ func (s *notebooksStore) UpdateNotebook(ctx context.Context, n *Notebook) (*Note
 	return scanNotebook(row)
 }
 
+const updateNotebookSourceFmtStr = `
+UPDATE notebooks
+SET
+	blocks = %s,
+	source_commit = %s,
+	updated_at = now()
+WHERE id = %d AND source_repo_id IS NOT NULL
+RETURNING %s
+`
+
+// UpdateNotebookSource replaces the blocks of a notebook imported from a
+// repository with the blocks read from its source file at the given commit.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
+func (s *notebooksStore) UpdateNotebookSource(ctx context.Context, notebookID int64, blocks NotebookBlocks, commit api.CommitID) (*Notebook, error) {
+	err := validateNotebookBlocks(blocks)
+	if err != nil {
+		return nil, err
+	}
+	row := s.QueryRow(
+		ctx,
+		sqlf.Sprintf(
+			updateNotebookSourceFmtStr,
+			blocks,
+			string(commit),
+			notebookID,
+			sqlf.Join(notebookColumns, ","),
+		),
+	)
+	notebook, err := scanNotebook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotebookNotFound
+	}
+	return notebook, err
+}
+
 func scanNotebookStar(scanner dbutil.Scanner) (*NotebookStar, error) {
 	star := &NotebookStar{}
 	err := scanner.Scan(&star.NotebookID, &star.UserID, &star.CreatedAt)
@@ -0,0 +1,138 @@
+package notebooks
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NotebookFileExtension is the file extension of notebooks stored in
+// repositories.
+const NotebookFileExtension = ".snb.md"
+
+// ErrSourceNotAccessible is returned by SyncNotebookSource if the notebook can
+// no longer be synced with its source file without exposing it to users who
+// cannot read the source repository.
+var ErrSourceNotAccessible = errors.New("notebook source is not accessible")
+
+// ErrInvalidSource is returned by ReadSourceBlocks and SyncNotebookSource if
+// the source file of a notebook is not a valid notebook.
+var ErrInvalidSource = errors.New("notebook source is invalid")
+
+// ValidateSourceVisibility returns an error if a notebook imported from repo
+// would be public although repo is private.
+func ValidateSourceVisibility(repo *types.Repo, public bool) error {
+	if public && repo.Private {
+		return errors.Errorf("notebooks imported from the private repository %s cannot be public", repo.Name)
+	}
+	return nil
+}
+
+// NotebookTitleFromPath returns the title of a notebook imported from the file
+// at filePath, i.e. the file name without the notebook file extension.
+func NotebookTitleFromPath(filePath string) string {
+	title := strings.TrimSpace(strings.TrimSuffix(path.Base(filePath), NotebookFileExtension))
+	if title == "" {
+		return "New Notebook"
+	}
+	return title
+}
+
+// ResolveSourceCommit returns the commit at the head of the default branch of
+// the repository, from which notebooks imported from the repository are
+// synced.
+func ResolveSourceCommit(ctx context.Context, client gitserver.Client, repo api.RepoName) (api.CommitID, error) {
+	_, commit, err := client.GetDefaultBranch(ctx, repo, true)
+	if err != nil {
+		return "", err
+	}
+	if commit == "" {
+		return "", errors.Errorf("repository %s is empty or not cloned yet", repo)
+	}
+	return commit, nil
+}
+
+// ReadSourceBlocks reads the notebook file at filePath and commit in the
+// repository and parses it into notebook blocks. ErrInvalidSource is returned
+// if the file cannot be parsed or its blocks are invalid.
+func ReadSourceBlocks(ctx context.Context, client gitserver.Client, repo api.RepoName, commit api.CommitID, filePath string) (NotebookBlocks, error) {
+	if !strings.HasSuffix(filePath, NotebookFileExtension) {
+		return nil, errors.Errorf("notebook files must have the %s extension: %s", NotebookFileExtension, filePath)
+	}
+	content, err := client.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, repo, commit, filePath)
+	if err != nil {
+		return nil, err
+	}
+	blocks, err := MarkdownToBlocks(string(content))
+	if err == nil {
+		err = validateNotebookBlocks(blocks)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(ErrInvalidSource, "parsing %s: %s", filePath, err)
+	}
+	return blocks, nil
+}
+
+// SyncNotebookSource updates the blocks of a notebook imported from a
+// repository to the content of its source file at the head of the default
+// branch. The notebook is returned as-is if the default branch did not move
+// since the last sync.
+//
+// The source file is read as the creator of the notebook. ErrSourceNotAccessible
+// is returned if the creator can no longer read the source repository, if the
+// source file no longer exists, or if the notebook is public but the source
+// repository became private. ErrInvalidSource is returned if the source file
+// is not a valid notebook.
+//
+// 🚨 SECURITY: The caller must ensure that the actor has permission to update the notebook.
+func SyncNotebookSource(ctx context.Context, db database.DB, client gitserver.Client, n *Notebook) (*Notebook, error) {
+	if n.SourceRepoID == 0 {
+		return nil, errors.Errorf("notebook %d was not imported from a repository", n.ID)
+	}
+	if n.CreatorUserID == 0 {
+		return nil, errors.Wrapf(ErrSourceNotAccessible, "notebook %d has no creator", n.ID)
+	}
+
+	// 🚨 SECURITY: Repos().Get and ReadFile check the permissions of the
+	// notebook creator, who imported the notebook, instead of those of the
+	// caller.
+	ctx = actor.WithActor(ctx, actor.FromUser(n.CreatorUserID))
+
+	repo, err := db.Repos().Get(ctx, n.SourceRepoID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, errors.Wrapf(ErrSourceNotAccessible, "repository %d", n.SourceRepoID)
+		}
+		return nil, err
+	}
+	if err := ValidateSourceVisibility(repo, n.Public); err != nil {
+		return nil, errors.Wrap(ErrSourceNotAccessible, err.Error())
+	}
+
+	commit, err := ResolveSourceCommit(ctx, client, repo.Name)
+	if err != nil {
+		return nil, err
+	}
+	if commit == n.SourceCommit {
+		return n, nil
+	}
+
+	blocks, err := ReadSourceBlocks(ctx, client, repo.Name, commit, n.SourcePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.Wrapf(ErrSourceNotAccessible, "file %s", n.SourcePath)
+		}
+		return nil, err
+	}
+	return Notebooks(db).UpdateNotebookSource(ctx, n.ID, blocks, commit)
+}
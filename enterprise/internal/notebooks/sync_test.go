@@ -0,0 +1,167 @@
+package notebooks
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestSyncNotebookSource(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+
+	user, err := db.Users().Create(ctx, database.NewUser{Username: "u", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &types.Repo{Name: "github.com/sourcegraph/notebooks"}
+	if err := db.Repos().Create(ctx, repo); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[api.CommitID]string{
+		"c1": "# Notebook\n\n```sourcegraph-query\nrepo:a\n```\n",
+		"c2": "# Notebook\n\n```sourcegraph-query\nrepo:b\n```\n",
+	}
+	head := api.CommitID("c1")
+	client := gitserver.NewMockClient()
+	client.GetDefaultBranchFunc.SetDefaultHook(func(context.Context, api.RepoName, bool) (string, api.CommitID, error) {
+		return "main", head, nil
+	})
+	client.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, commit api.CommitID, _ string) ([]byte, error) {
+		return []byte(files[commit]), nil
+	})
+
+	blocks, err := ReadSourceBlocks(ctx, client, repo.Name, head, "docs/notebook.snb.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	notebook, err := Notebooks(db).CreateNotebook(ctx, notebookByUser(&Notebook{
+		Title:        "notebook",
+		Blocks:       blocks,
+		SourceRepoID: repo.ID,
+		SourcePath:   "docs/notebook.snb.md",
+		SourceCommit: head,
+	}, user.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The default branch did not move, the notebook is left untouched.
+	synced, err := SyncNotebookSource(ctx, db, client, notebook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if synced != notebook {
+		t.Fatal("expected notebook to be unchanged")
+	}
+	if got := len(client.ReadFileFunc.History()); got != 1 {
+		t.Fatalf("expected file to be read once, got %d", got)
+	}
+
+	head = "c2"
+	synced, err = SyncNotebookSource(ctx, db, client, notebook)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if synced.SourceCommit != "c2" {
+		t.Fatalf("expected source commit c2, got %s", synced.SourceCommit)
+	}
+	want := NotebookBlocks{
+		{ID: "1", Type: NotebookMarkdownBlockType, MarkdownInput: &NotebookMarkdownBlockInput{Text: "# Notebook"}},
+		{ID: "2", Type: NotebookQueryBlockType, QueryInput: &NotebookQueryBlockInput{Text: "repo:b"}},
+	}
+	if diff := cmp.Diff(want, synced.Blocks); diff != "" {
+		t.Fatalf("unexpected blocks (-want +got):\n%s", diff)
+	}
+	if synced.SourceRepoID != repo.ID || synced.SourcePath != "docs/notebook.snb.md" {
+		t.Fatalf("expected source to be kept, got repo %d and path %q", synced.SourceRepoID, synced.SourcePath)
+	}
+
+	// Notebooks that were not imported from a repository cannot be synced.
+	if _, err := SyncNotebookSource(ctx, db, client, &Notebook{ID: 42}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Public notebooks are no longer synced once their source repository is
+	// private.
+	privateRepo := &types.Repo{Name: "github.com/sourcegraph/private", Private: true}
+	if err := db.Repos().Create(ctx, privateRepo); err != nil {
+		t.Fatal(err)
+	}
+	public := *synced
+	public.Public = true
+	public.SourceRepoID = privateRepo.ID
+	public.SourceCommit = "c1"
+	if _, err := SyncNotebookSource(ctx, db, client, &public); !errors.Is(err, ErrSourceNotAccessible) {
+		t.Fatalf("expected ErrSourceNotAccessible, got %v", err)
+	}
+
+	// Notebooks whose source file was deleted or is no longer a valid
+	// notebook are not synced.
+	files["c3"] = "```sourcegraph-file\ngithub.com/sourcegraph/sourcegraph\n```\n"
+	head = "c3"
+	if _, err := SyncNotebookSource(ctx, db, client, synced); !errors.Is(err, ErrInvalidSource) {
+		t.Fatalf("expected ErrInvalidSource, got %v", err)
+	}
+	client.ReadFileFunc.SetDefaultReturn(nil, &os.PathError{Op: "open", Path: "docs/notebook.snb.md", Err: os.ErrNotExist})
+	if _, err := SyncNotebookSource(ctx, db, client, synced); !errors.Is(err, ErrSourceNotAccessible) {
+		t.Fatalf("expected ErrSourceNotAccessible, got %v", err)
+	}
+}
+
+func TestSyncNotebookSourceRequiresCreator(t *testing.T) {
+	client := gitserver.NewMockClient()
+	_, err := SyncNotebookSource(context.Background(), nil, client, &Notebook{ID: 1, SourceRepoID: 1, SourcePath: "a.snb.md"})
+	if !errors.Is(err, ErrSourceNotAccessible) {
+		t.Fatalf("expected ErrSourceNotAccessible, got %v", err)
+	}
+	if len(client.ReadFileFunc.History()) != 0 {
+		t.Fatal("expected file not to be read")
+	}
+}
+
+func TestValidateSourceVisibility(t *testing.T) {
+	if err := ValidateSourceVisibility(&types.Repo{Name: "public"}, true); err != nil {
+		t.Fatalf("expected no error for a public repository, got %v", err)
+	}
+	if err := ValidateSourceVisibility(&types.Repo{Name: "private", Private: true}, false); err != nil {
+		t.Fatalf("expected no error for a private notebook, got %v", err)
+	}
+	if err := ValidateSourceVisibility(&types.Repo{Name: "private", Private: true}, true); err == nil {
+		t.Fatal("expected error for a public notebook of a private repository")
+	}
+}
+
+func TestReadSourceBlocksInvalidSource(t *testing.T) {
+	client := gitserver.NewMockClient()
+	client.ReadFileFunc.SetDefaultReturn([]byte("```sourcegraph-insight\nseriesId=s1\n```\n"), nil)
+	_, err := ReadSourceBlocks(context.Background(), client, "repo", "c1", "a.snb.md")
+	if !errors.Is(err, ErrInvalidSource) {
+		t.Fatalf("expected ErrInvalidSource, got %v", err)
+	}
+}
+
+func TestReadSourceBlocksRequiresNotebookExtension(t *testing.T) {
+	client := gitserver.NewMockClient()
+	if _, err := ReadSourceBlocks(context.Background(), client, "repo", "c1", "README.md"); err == nil {
+		t.Fatal("expected error")
+	}
+	if len(client.ReadFileFunc.History()) != 0 {
+		t.Fatal("expected file not to be read")
+	}
+}
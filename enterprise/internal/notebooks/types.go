@@ -2,6 +2,8 @@ package notebooks
 
 import (
 	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
 type NotebookBlockType string
@@ -11,6 +13,8 @@ const (
 	NotebookMarkdownBlockType NotebookBlockType = "md"
 	NotebookFileBlockType     NotebookBlockType = "file"
 	NotebookSymbolBlockType   NotebookBlockType = "symbol"
+	NotebookComputeBlockType  NotebookBlockType = "compute"
+	NotebookInsightBlockType  NotebookBlockType = "insight"
 )
 
 type NotebookQueryBlockInput struct {
@@ -46,6 +50,20 @@ type NotebookSymbolBlockInput struct {
 	SymbolKind          string  `json:"symbolKind"`
 }
 
+type NotebookComputeBlockInput struct {
+	// Text is a compute query, e.g. `content:output(...)` or `content:replace(...)`.
+	Text string `json:"text"`
+}
+
+type NotebookInsightBlockInput struct {
+	// InsightViewID is the GraphQL ID of the embedded insight view.
+	InsightViewID string `json:"insightViewId"`
+
+	// SeriesIDs optionally restricts the block to a subset of the series of
+	// the insight view. If empty, all series are included.
+	SeriesIDs []string `json:"seriesIds,omitempty"`
+}
+
 type NotebookBlock struct {
 	ID            string                      `json:"id"`
 	Type          NotebookBlockType           `json:"type"`
@@ -53,10 +71,22 @@ type NotebookBlock struct {
 	MarkdownInput *NotebookMarkdownBlockInput `json:"markdownInput,omitempty"`
 	FileInput     *NotebookFileBlockInput     `json:"fileInput,omitempty"`
 	SymbolInput   *NotebookSymbolBlockInput   `json:"symbolInput,omitempty"`
+	ComputeInput  *NotebookComputeBlockInput  `json:"computeInput,omitempty"`
+	InsightInput  *NotebookInsightBlockInput  `json:"insightInput,omitempty"`
 }
 
 type NotebookBlocks []NotebookBlock
 
+// NotebookBlockTable is the output of a compute or insight block, computed
+// server-side by executing the block.
+type NotebookBlockTable struct {
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+
+	// LimitHit is true if the rows were truncated.
+	LimitHit bool `json:"limitHit"`
+}
+
 type Notebook struct {
 	ID              int64
 	Title           string
@@ -68,6 +98,14 @@ type Notebook struct {
 	NamespaceOrgID  int32 // if non-zero, the owner is this organization. NamespaceUserID/NamespaceOrgID are mutually exclusive.
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+
+	// SourceRepoID and SourcePath are set if the notebook was imported from a
+	// .snb.md file in a repository. The blocks of such notebooks are kept in
+	// sync with the file on the default branch of the repository, and
+	// SourceCommit is the commit the blocks were last synced from.
+	SourceRepoID api.RepoID
+	SourcePath   string
+	SourceCommit api.CommitID
 }
 
 type NotebookStar struct {
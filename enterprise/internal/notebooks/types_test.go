@@ -12,6 +12,8 @@ func TestNotebookBlockMarshalling(t *testing.T) {
 	markdownBlockInput := NotebookMarkdownBlockInput{Text: "# Title"}
 	revision := "main"
 	fileBlockInput := NotebookFileBlockInput{RepositoryName: "sourcegraph/sourcegraph", FilePath: "a/b.ts", Revision: &revision, LineRange: &LineRange{1, 10}}
+	computeBlockInput := NotebookComputeBlockInput{Text: "content:output(a -> b)"}
+	insightBlockInput := NotebookInsightBlockInput{InsightViewID: "aW5zaWdodF92aWV3OiIxIg==", SeriesIDs: []string{"s1"}}
 
 	tests := []struct {
 		block NotebookBlock
@@ -29,6 +31,14 @@ func TestNotebookBlockMarshalling(t *testing.T) {
 			block: NotebookBlock{ID: "id1", Type: NotebookFileBlockType, FileInput: &fileBlockInput},
 			want:  autogold.Expect(`{"id":"id1","type":"file","fileInput":{"repositoryName":"sourcegraph/sourcegraph","filePath":"a/b.ts","revision":"main","lineRange":{"startLine":1,"endLine":10}}}`),
 		},
+		{
+			block: NotebookBlock{ID: "id1", Type: NotebookComputeBlockType, ComputeInput: &computeBlockInput},
+			want:  autogold.Expect(`{"id":"id1","type":"compute","computeInput":{"text":"content:output(a -\u003e b)"}}`),
+		},
+		{
+			block: NotebookBlock{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &insightBlockInput},
+			want:  autogold.Expect(`{"id":"id1","type":"insight","insightInput":{"insightViewId":"aW5zaWdodF92aWV3OiIxIg==","seriesIds":["s1"]}}`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -45,6 +55,8 @@ func TestNotebookBlockUnmarshalling(t *testing.T) {
 	markdownBlockInput := NotebookMarkdownBlockInput{Text: "# Title"}
 	revision := "main"
 	fileBlockInput := NotebookFileBlockInput{RepositoryName: "sourcegraph/sourcegraph", FilePath: "a/b.ts", Revision: &revision, LineRange: &LineRange{1, 10}}
+	computeBlockInput := NotebookComputeBlockInput{Text: "content:output(a -> b)"}
+	insightBlockInput := NotebookInsightBlockInput{InsightViewID: "aW5zaWdodF92aWV3OiIxIg==", SeriesIDs: []string{"s1"}}
 
 	tests := []struct {
 		json string
@@ -62,6 +74,14 @@ func TestNotebookBlockUnmarshalling(t *testing.T) {
 			json: `{"id":"id1","type":"file","fileInput":{"repositoryName":"sourcegraph/sourcegraph","filePath":"a/b.ts","revision":"main","lineRange":{"startLine":1,"endLine":10}}}`,
 			want: autogold.Expect(NotebookBlock{ID: "id1", Type: NotebookFileBlockType, FileInput: &fileBlockInput}),
 		},
+		{
+			json: `{"id":"id1","type":"compute","computeInput":{"text":"content:output(a -> b)"}}`,
+			want: autogold.Expect(NotebookBlock{ID: "id1", Type: NotebookComputeBlockType, ComputeInput: &computeBlockInput}),
+		},
+		{
+			json: `{"id":"id1","type":"insight","insightInput":{"insightViewId":"aW5zaWdodF92aWV3OiIxIg==","seriesIds":["s1"]}}`,
+			want: autogold.Expect(NotebookBlock{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &insightBlockInput}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1,12 +1,17 @@
 package notebooks
 
-import "github.com/sourcegraph/sourcegraph/lib/errors"
+import (
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/compute"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
 
 func validateNotebookBlock(block NotebookBlock) error {
 	if block.Type != NotebookQueryBlockType &&
 		block.Type != NotebookMarkdownBlockType &&
 		block.Type != NotebookFileBlockType &&
-		block.Type != NotebookSymbolBlockType {
+		block.Type != NotebookSymbolBlockType &&
+		block.Type != NotebookComputeBlockType &&
+		block.Type != NotebookInsightBlockType {
 		return errors.Errorf("invalid block type: %s", string(block.Type))
 	}
 
@@ -18,12 +23,26 @@ func validateNotebookBlock(block NotebookBlock) error {
 		return errors.Errorf("invalid file block with id: %s", block.ID)
 	} else if block.Type == NotebookSymbolBlockType && block.SymbolInput == nil {
 		return errors.Errorf("invalid symbol block with id: %s", block.ID)
+	} else if block.Type == NotebookComputeBlockType && block.ComputeInput == nil {
+		return errors.Errorf("invalid compute block with id: %s", block.ID)
+	} else if block.Type == NotebookInsightBlockType && block.InsightInput == nil {
+		return errors.Errorf("invalid insight block with id: %s", block.ID)
 	}
 
 	if block.Type == NotebookSymbolBlockType && block.SymbolInput != nil && block.SymbolInput.LineContext < 0 {
 		return errors.Errorf("symbol block line context cannot be negative, block id: %s", block.ID)
 	}
 
+	if block.Type == NotebookComputeBlockType && block.ComputeInput != nil {
+		if _, err := compute.Parse(block.ComputeInput.Text); err != nil {
+			return errors.Errorf("invalid compute query in block with id: %s: %s", block.ID, err)
+		}
+	}
+
+	if block.Type == NotebookInsightBlockType && block.InsightInput != nil && block.InsightInput.InsightViewID == "" {
+		return errors.Errorf("insight block is missing an insight view id, block id: %s", block.ID)
+	}
+
 	return nil
 }
 
@@ -22,6 +22,14 @@ func TestNotebookBlocksValidation(t *testing.T) {
 		{blocks: NotebookBlocks{
 			{ID: "id1", SymbolInput: &NotebookSymbolBlockInput{LineContext: -10}, Type: NotebookSymbolBlockType},
 		}, wantErr: "symbol block line context cannot be negative, block id: id1"},
+		{blocks: NotebookBlocks{{ID: "id1", Type: NotebookComputeBlockType}}, wantErr: "invalid compute block with id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{"content:output(a or b -> $1)"}},
+		}, wantErr: "invalid compute query in block with id: id1: compute endpoint cannot currently support expressions in patterns containing 'and', 'or', 'not' (or negation) right now!"},
+		{blocks: NotebookBlocks{{ID: "id1", Type: NotebookInsightBlockType}}, wantErr: "invalid insight block with id: id1"},
+		{blocks: NotebookBlocks{
+			{ID: "id1", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{}},
+		}, wantErr: "insight block is missing an insight view id, block id: id1"},
 	}
 
 	for _, tt := range tests {
@@ -33,3 +41,13 @@ func TestNotebookBlocksValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestNotebookComputeAndInsightBlocksValidation(t *testing.T) {
+	blocks := NotebookBlocks{
+		{ID: "id1", Type: NotebookComputeBlockType, ComputeInput: &NotebookComputeBlockInput{"content:output((\\w+) -> $1) repo:a"}},
+		{ID: "id2", Type: NotebookInsightBlockType, InsightInput: &NotebookInsightBlockInput{InsightViewID: "aW5zaWdodF92aWV3OiIxIg=="}},
+	}
+	if err := validateNotebookBlocks(blocks); err != nil {
+		t.Fatalf("expected no error, got %s", err)
+	}
+}
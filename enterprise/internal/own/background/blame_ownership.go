@@ -0,0 +1,221 @@
+package background
+
+import (
+	"context"
+	"math"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	logger "github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// blameOwnershipHalfLife is the age at which a line counts half as much
+	// towards ownership as a line that was just changed.
+	blameOwnershipHalfLife = 365 * 24 * time.Hour
+	// maxBlameOwnershipFiles is the maximum number of files blamed per
+	// repository, as blaming every file of large repositories is expensive.
+	maxBlameOwnershipFiles = 10000
+	// maxBlameOwnersPerPath is the maximum number of owners stored for every
+	// file and directory.
+	maxBlameOwnersPerPath = 5
+	// minBlameOwnershipShare is the minimum share of lines an author needs to
+	// be considered an owner.
+	minBlameOwnershipShare = 0.1
+)
+
+func handleBlameOwnership(ctx context.Context, lgr logger.Logger, repoId api.RepoID, db database.DB, subRepoPermsCache *rcache.Cache) error {
+	// 🚨 SECURITY: we use the internal actor because the background indexer is not associated with any user, and needs
+	// to see all repos and files
+	internalCtx := actor.WithInternalActor(ctx)
+
+	indexer := newBlameOwnershipIndexer(gitserver.NewClient(), db, lgr, subRepoPermsCache)
+	return indexer.indexRepo(internalCtx, repoId, authz.DefaultSubRepoPermsChecker)
+}
+
+type blameOwnershipIndexer struct {
+	client            gitserver.Client
+	db                database.DB
+	logger            logger.Logger
+	subRepoPermsCache rcache.Cache
+	now               func() time.Time
+}
+
+func newBlameOwnershipIndexer(client gitserver.Client, db database.DB, lgr logger.Logger, subRepoPermsCache *rcache.Cache) *blameOwnershipIndexer {
+	return &blameOwnershipIndexer{client: client, db: db, logger: lgr, subRepoPermsCache: *subRepoPermsCache, now: time.Now}
+}
+
+var blameOwnershipFilesCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: "src",
+	Name:      "own_blame_ownership_files_indexed_total",
+})
+
+func (r *blameOwnershipIndexer) indexRepo(ctx context.Context, repoId api.RepoID, checker authz.SubRepoPermissionChecker) error {
+	// If the repo has sub-repo perms enabled, skip indexing.
+	isSubRepoPermsRepo, err := isSubRepoPermsRepo(ctx, repoId, r.subRepoPermsCache, checker)
+	if err != nil {
+		return errcode.MakeNonRetryable(err)
+	} else if isSubRepoPermsRepo {
+		r.logger.Debug("skipping own blame ownership signal due to the repo having subrepo perms enabled", logger.Int32("repoID", int32(repoId)))
+		return nil
+	}
+
+	repo, err := r.db.Repos().Get(ctx, repoId)
+	if err != nil {
+		return errors.Wrap(err, "repoStore.Get")
+	}
+	commitID, err := r.client.ResolveRevision(ctx, repo.Name, "HEAD", gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+	if err != nil {
+		return errcode.MakeNonRetryable(errors.Wrapf(err, "cannot resolve HEAD"))
+	}
+	files, err := r.client.LsFiles(ctx, checker, repo.Name, commitID)
+	if err != nil {
+		return errors.Wrap(err, "ls-files")
+	}
+	if len(files) > maxBlameOwnershipFiles {
+		r.logger.Info("only indexing blame ownership of some files",
+			logger.Int("repo_id", int(repoId)),
+			logger.Int("files", len(files)),
+			logger.Int("limit", maxBlameOwnershipFiles))
+		files = files[:maxBlameOwnershipFiles]
+	}
+
+	blames := make(map[string][]*gitserver.Hunk, len(files))
+	for _, file := range files {
+		hunks, err := r.client.BlameFile(ctx, checker, repo.Name, file, &gitserver.BlameOptions{NewestCommit: commitID})
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// Submodules and files that cannot be blamed are skipped, they
+			// should not prevent indexing the rest of the repository.
+			if !os.IsNotExist(err) {
+				r.logger.Warn("cannot blame file", logger.String("file", file), logger.Error(err))
+			}
+			continue
+		}
+		blames[file] = hunks
+	}
+
+	signals := computeBlameOwnership(blames, r.now())
+	if err := r.db.BlameOwnershipSignals().ReplaceSignals(ctx, repoId, signals); err != nil {
+		return errors.Wrap(err, "ReplaceSignals")
+	}
+	r.logger.Info("blame ownership indexed", logger.Int("files", len(blames)), logger.Int("repo_id", int(repoId)))
+	blameOwnershipFilesCounter.Add(float64(len(blames)))
+	return nil
+}
+
+type blameAuthor struct {
+	name, email string
+}
+
+type blameAuthorLines struct {
+	lines int
+	// weight is the number of lines, weighted by age.
+	weight float64
+}
+
+// computeBlameOwnership attributes the surviving lines of the given blamed
+// files to their authors, for every file and every directory containing them.
+// Every line is weighted by age, halving its weight every
+// blameOwnershipHalfLife. Only the authors with the largest shares are
+// returned for every path.
+func computeBlameOwnership(blames map[string][]*gitserver.Hunk, now time.Time) []database.BlameOwnership {
+	byPath := map[string]map[blameAuthor]*blameAuthorLines{}
+	add := func(p string, author blameAuthor, lines int, weight float64) {
+		authors, ok := byPath[p]
+		if !ok {
+			authors = map[blameAuthor]*blameAuthorLines{}
+			byPath[p] = authors
+		}
+		a, ok := authors[author]
+		if !ok {
+			a = &blameAuthorLines{}
+			authors[author] = a
+		}
+		a.lines += lines
+		a.weight += weight
+	}
+
+	for file, hunks := range blames {
+		for _, h := range hunks {
+			lines := h.EndLine - h.StartLine
+			if lines <= 0 {
+				continue
+			}
+			author := blameAuthor{name: h.Author.Name, email: h.Author.Email}
+			age := now.Sub(h.Author.Date)
+			if age < 0 {
+				age = 0
+			}
+			weight := float64(lines) * math.Pow(0.5, float64(age)/float64(blameOwnershipHalfLife))
+
+			// Attribute lines to the file and all its parent directories,
+			// including the repository root designated by "".
+			for p := file; ; p = path.Dir(p) {
+				if p == "." {
+					p = ""
+				}
+				add(p, author, lines, weight)
+				if p == "" {
+					break
+				}
+			}
+		}
+	}
+
+	var signals []database.BlameOwnership
+	for p, authors := range byPath {
+		var total float64
+		for _, a := range authors {
+			total += a.weight
+		}
+		if total == 0 {
+			continue
+		}
+		var owners []database.BlameOwnership
+		for author, a := range authors {
+			share := a.weight / total
+			if share < minBlameOwnershipShare {
+				continue
+			}
+			owners = append(owners, database.BlameOwnership{
+				FilePath:    p,
+				AuthorName:  author.name,
+				AuthorEmail: author.email,
+				LinesCount:  a.lines,
+				Share:       share,
+			})
+		}
+		sort.Slice(owners, func(i, j int) bool {
+			if owners[i].Share != owners[j].Share {
+				return owners[i].Share > owners[j].Share
+			}
+			return owners[i].AuthorEmail < owners[j].AuthorEmail
+		})
+		if len(owners) > maxBlameOwnersPerPath {
+			owners = owners[:maxBlameOwnersPerPath]
+		}
+		signals = append(signals, owners...)
+	}
+	// Make the order deterministic.
+	sort.SliceStable(signals, func(i, j int) bool {
+		return signals[i].FilePath < signals[j].FilePath
+	})
+	return signals
+}
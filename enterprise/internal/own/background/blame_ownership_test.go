@@ -0,0 +1,122 @@
+package background
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var blameNow = time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+func blameHunk(start, lines int, name string, age time.Duration) *gitserver.Hunk {
+	return &gitserver.Hunk{
+		StartLine: start,
+		EndLine:   start + lines,
+		Author: gitdomain.Signature{
+			Name:  name,
+			Email: name + "@example.com",
+			Date:  blameNow.Add(-age),
+		},
+	}
+}
+
+func TestComputeBlameOwnership(t *testing.T) {
+	got := computeBlameOwnership(map[string][]*gitserver.Hunk{
+		"src/a.go": {
+			blameHunk(1, 30, "alice", 0),
+			blameHunk(31, 10, "bob", 0),
+		},
+		"src/b.go": {
+			// Lines changed a year ago count half.
+			blameHunk(1, 40, "bob", blameOwnershipHalfLife),
+			// Less than minBlameOwnershipShare of the file.
+			blameHunk(41, 1, "carol", 0),
+		},
+		"README.md": {
+			blameHunk(1, 20, "alice", 0),
+		},
+	}, blameNow)
+
+	want := []database.BlameOwnership{
+		{FilePath: "", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 50, Share: 50.0 / 81},
+		{FilePath: "", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 50, Share: 30.0 / 81},
+		{FilePath: "README.md", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 20, Share: 1},
+		{FilePath: "src", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 30, Share: 30.0 / 61},
+		{FilePath: "src", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 50, Share: 30.0 / 61},
+		{FilePath: "src/a.go", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 30, Share: 0.75},
+		{FilePath: "src/a.go", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 10, Share: 0.25},
+		{FilePath: "src/b.go", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 40, Share: 20.0 / 21},
+	}
+	require.Len(t, got, len(want))
+	for i := range want {
+		assert.InDelta(t, want[i].Share, got[i].Share, 1e-9, want[i].FilePath)
+		got[i].Share = want[i].Share
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestBlameOwnershipIndexer(t *testing.T) {
+	rcache.SetupForTest(t)
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	repoID := api.RepoID(1)
+
+	repos := database.NewMockRepoStore()
+	repos.GetFunc.SetDefaultReturn(&types.Repo{ID: repoID, Name: "own/repo1"}, nil)
+	blameStore := database.NewMockBlameOwnershipSignalStore()
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.BlameOwnershipSignalsFunc.SetDefaultReturn(blameStore)
+
+	client := gitserver.NewMockClient()
+	client.ResolveRevisionFunc.SetDefaultReturn("deadbeef", nil)
+	client.LsFilesFunc.SetDefaultReturn([]string{"a.go", "submodule"}, nil)
+	client.BlameFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, path string, opts *gitserver.BlameOptions) ([]*gitserver.Hunk, error) {
+		assert.Equal(t, api.CommitID("deadbeef"), opts.NewestCommit)
+		if path != "a.go" {
+			return nil, &gitdomain.RevisionNotFoundError{Repo: "own/repo1", Spec: path}
+		}
+		return []*gitserver.Hunk{blameHunk(1, 10, "alice", 0)}, nil
+	})
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoIDFunc.SetDefaultReturn(false, nil)
+	indexer := newBlameOwnershipIndexer(client, db, logger, rcache.New("testing_own_signals"))
+	indexer.now = func() time.Time { return blameNow }
+	require.NoError(t, indexer.indexRepo(ctx, repoID, checker))
+
+	require.Len(t, blameStore.ReplaceSignalsFunc.History(), 1)
+	call := blameStore.ReplaceSignalsFunc.History()[0]
+	assert.Equal(t, repoID, call.Arg1)
+	assert.Equal(t, []database.BlameOwnership{
+		{FilePath: "", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 10, Share: 1},
+		{FilePath: "a.go", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 10, Share: 1},
+	}, call.Arg2)
+}
+
+func TestBlameOwnershipIndexerSkipsReposWithSubRepoPerms(t *testing.T) {
+	rcache.SetupForTest(t)
+	logger := logtest.Scoped(t)
+	db := database.NewMockDB()
+	client := gitserver.NewMockClient()
+
+	checker := authz.NewMockSubRepoPermissionChecker()
+	checker.EnabledFunc.SetDefaultReturn(true)
+	checker.EnabledForRepoIDFunc.SetDefaultReturn(true, nil)
+	indexer := newBlameOwnershipIndexer(client, db, logger, rcache.New("testing_own_signals"))
+	require.NoError(t, indexer.indexRepo(context.Background(), 1, checker))
+	assert.Empty(t, client.BlameFileFunc.History())
+}
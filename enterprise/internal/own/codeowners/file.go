@@ -36,6 +36,9 @@ type Ruleset struct {
 	rules        []*CompiledRule
 	source       RulesetSource
 	codeHostType string
+	// ruleSources holds the sources of rules that were not defined in source,
+	// like rules from per-directory OWNERS files.
+	ruleSources map[*codeownerspb.Rule]RulesetSource
 }
 
 func NewRuleset(source RulesetSource, proto *codeownerspb.File) *Ruleset {
@@ -49,6 +52,22 @@ func NewRuleset(source RulesetSource, proto *codeownerspb.File) *Ruleset {
 	return f
 }
 
+// NewOwnersRuleset returns the ruleset for the Chromium-style OWNERS files of
+// the given repository at the given commit, as parsed by ParseOwners. The
+// source of every rule is the OWNERS file it was defined in.
+func NewOwnersRuleset(repo api.RepoID, commit api.CommitID, files map[string][]byte, readFile func(path string) ([]byte, error)) (*Ruleset, error) {
+	proto, sources, err := parseOwners(files, readFile)
+	if err != nil {
+		return nil, err
+	}
+	rs := NewRuleset(GitRulesetSource{Repo: repo, Commit: commit, Path: OwnersFileName}, proto)
+	rs.ruleSources = make(map[*codeownerspb.Rule]RulesetSource, len(sources))
+	for rule, path := range sources {
+		rs.ruleSources[rule] = GitRulesetSource{Repo: repo, Commit: commit, Path: path}
+	}
+	return rs, nil
+}
+
 func (r *Ruleset) GetFile() *codeownerspb.File {
 	return r.proto
 }
@@ -57,6 +76,15 @@ func (r *Ruleset) GetSource() RulesetSource {
 	return r.source
 }
 
+// GetRuleSource returns the source the given rule of this ruleset was
+// defined in.
+func (r *Ruleset) GetRuleSource(rule *codeownerspb.Rule) RulesetSource {
+	if src, ok := r.ruleSources[rule]; ok {
+		return src
+	}
+	return r.source
+}
+
 func (r *Ruleset) GetCodeHostType() string {
 	return r.codeHostType
 }
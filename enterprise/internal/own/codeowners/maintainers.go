@@ -0,0 +1,152 @@
+package codeowners
+
+import (
+	"bufio"
+	"io"
+	"sort"
+	"strings"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+)
+
+// MaintainersFileName is the name of the Linux kernel style file listing
+// the maintainers of a repository, at the root of the repository.
+const MaintainersFileName = "MAINTAINERS"
+
+// ParseMaintainers parses a Linux kernel style MAINTAINERS file and returns
+// a ruleset proto describing the ownership of the repository.
+//
+// The file is a sequence of sections, each starting with a title line and
+// followed by tagged lines like `M: Jane Doe <jane@example.com>`. Only tags
+// at the start of a line are taken into account, so that indented examples
+// in the preamble of the file are ignored:
+//   - `M:` (maintainer) and `R:` (reviewer) lines are the owners of the
+//     section, as a name and email, or just an email.
+//   - `F:` lines are the files of the section. A trailing `/` matches all
+//     files in and below a directory, and `*` wildcards only match files
+//     within a directory. A path without either matches the file or the
+//     directory with this path.
+//
+// Exclusions (`X:`), regular expressions (`N:`) and content keywords (`K:`)
+// cannot be expressed as ownership rules, and are ignored.
+//
+// Sections listing the same files share the ownership of these files. Rules
+// are ordered from the least to the most specific pattern, so that files are
+// owned by the section that lists them most precisely.
+func ParseMaintainers(in io.Reader) (*codeownerspb.File, error) {
+	var rules []*codeownerspb.Rule
+	byPattern := map[string]*codeownerspb.Rule{}
+	addRule := func(pattern string, owners []*codeownerspb.Owner, line int32) {
+		rule, ok := byPattern[pattern]
+		if !ok {
+			rule = &codeownerspb.Rule{Pattern: pattern, LineNumber: line}
+			byPattern[pattern] = rule
+			rules = append(rules, rule)
+		}
+		for _, o := range owners {
+			if !containsOwner(rule.Owner, o) {
+				rule.Owner = append(rule.Owner, o)
+			}
+		}
+	}
+
+	type filePattern struct {
+		pattern string
+		line    int32
+	}
+	var owners []*codeownerspb.Owner
+	var files []filePattern
+	endSection := func() {
+		for _, f := range files {
+			addRule(f.pattern, owners, f.line)
+		}
+		owners, files = nil, nil
+	}
+
+	scanner := bufio.NewScanner(in)
+	lineNumber := int32(0)
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		tag, value, ok := maintainersTag(line)
+		if !ok {
+			// Any other non-empty line at the start of a line is the title
+			// of the next section.
+			if line != "" && line == strings.TrimLeft(line, " \t") {
+				endSection()
+			}
+			continue
+		}
+		switch tag {
+		case 'M', 'R':
+			if value != "" {
+				owners = append(owners, parseMaintainer(value))
+			}
+		case 'F':
+			for _, pattern := range maintainersPatterns(value) {
+				files = append(files, filePattern{pattern: pattern, line: lineNumber})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	endSection()
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		return patternDepth(rules[i].Pattern) < patternDepth(rules[j].Pattern)
+	})
+	return &codeownerspb.File{Rule: rules}, nil
+}
+
+// maintainersTag returns the tag and value of a tagged line of a
+// MAINTAINERS file, like `F: drivers/net/`.
+func maintainersTag(line string) (byte, string, bool) {
+	if len(line) < 2 || line[0] < 'A' || line[0] > 'Z' || line[1] != ':' {
+		return 0, "", false
+	}
+	return line[0], strings.TrimSpace(line[2:]), true
+}
+
+// parseMaintainer parses a maintainer like `Jane Doe <jane@example.com>`.
+// Names are not quoted in MAINTAINERS files, so the email is extracted
+// without parsing the name, which can contain special characters.
+func parseMaintainer(value string) *codeownerspb.Owner {
+	if i := strings.LastIndex(value, "<"); i >= 0 && strings.HasSuffix(value, ">") {
+		return &codeownerspb.Owner{Email: strings.TrimSpace(value[i+1 : len(value)-1])}
+	}
+	return ParseOwner(value)
+}
+
+// maintainersPatterns returns the rule patterns for an `F:` line.
+func maintainersPatterns(value string) []string {
+	if value == "" {
+		return nil
+	}
+	pattern := "/" + strings.TrimPrefix(value, "/")
+	if strings.HasSuffix(pattern, "/") || strings.Contains(pattern, "*") {
+		return []string{pattern}
+	}
+	// Whether the path is a file or a directory is unknown, so match both.
+	return []string{pattern, pattern + "/"}
+}
+
+// patternDepth returns how specific an anchored pattern is. Deeper patterns
+// are more specific, and a directory is less specific than the files at the
+// same depth, like `/drivers/net/` and `/drivers/Makefile`.
+func patternDepth(pattern string) int {
+	depth := 2 * len(strings.Split(strings.Trim(pattern, "/"), "/"))
+	if strings.HasSuffix(pattern, "/") {
+		depth--
+	}
+	return depth
+}
+
+func containsOwner(owners []*codeownerspb.Owner, o *codeownerspb.Owner) bool {
+	for _, existing := range owners {
+		if existing.GetEmail() == o.GetEmail() && existing.GetHandle() == o.GetHandle() {
+			return true
+		}
+	}
+	return false
+}
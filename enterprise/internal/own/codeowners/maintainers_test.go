@@ -0,0 +1,81 @@
+package codeowners_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
+)
+
+const kernelMaintainers = `List of maintainers
+
+Descriptions of section entries and preferred order
+---------------------------------------------------
+
+	M: *Mail* patches to: FullName <address@domain>
+	F: *Files* and directories wildcard patterns.
+
+Maintainers List
+----------------
+
+THE REST
+M:	Linus Torvalds <torvalds@linux-foundation.org>
+L:	linux-kernel@vger.kernel.org
+S:	Buried alive in reporters
+F:	*
+F:	*/
+
+NETWORKING DRIVERS
+M:	"David S. Miller" <davem@davemloft.net>
+R:	net-reviewer@example.com
+S:	Maintained
+F:	drivers/net/
+X:	drivers/net/wireless/
+
+NETWORKING DRIVERS (WIRELESS)
+M:	Kalle Valo <kvalo@kernel.org>
+F:	drivers/net/wireless/
+F:	include/linux/ieee80211.h
+
+IEEE 802.11 HEADERS
+M:	J. Berg <johannes@sipsolutions.net>
+F:	include/linux/ieee80211.h
+F:	drivers/net/Kconfig
+`
+
+func TestParseMaintainers(t *testing.T) {
+	got, err := codeowners.ParseMaintainers(strings.NewReader(kernelMaintainers))
+	require.NoError(t, err)
+
+	want := `/*/ torvalds@linux-foundation.org
+/* torvalds@linux-foundation.org
+/drivers/net/ davem@davemloft.net net-reviewer@example.com
+/drivers/net/wireless/ kvalo@kernel.org
+/include/linux/ieee80211.h/ kvalo@kernel.org johannes@sipsolutions.net
+/drivers/net/Kconfig/ johannes@sipsolutions.net
+/include/linux/ieee80211.h kvalo@kernel.org johannes@sipsolutions.net
+/drivers/net/Kconfig johannes@sipsolutions.net
+`
+	assert.Equal(t, want, codeowners.NewRuleset(nil, got).Repr())
+
+	rs := codeowners.NewRuleset(nil, got)
+	for path, want := range map[string][]string{
+		"README":                       {"torvalds@linux-foundation.org"},
+		"kernel/sched/core.c":          {"torvalds@linux-foundation.org"},
+		"drivers/net/loopback.c":       {"davem@davemloft.net", "net-reviewer@example.com"},
+		"drivers/net/Kconfig":          {"johannes@sipsolutions.net"},
+		"drivers/net/wireless/ath/a.c": {"kvalo@kernel.org"},
+		"include/linux/ieee80211.h":    {"kvalo@kernel.org", "johannes@sipsolutions.net"},
+	} {
+		rule := rs.Match(path)
+		require.NotNil(t, rule, path)
+		var got []string
+		for _, o := range rule.GetOwner() {
+			got = append(got, o.GetEmail())
+		}
+		assert.Equal(t, want, got, path)
+	}
+}
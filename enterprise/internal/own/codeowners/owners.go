@@ -0,0 +1,344 @@
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// OwnersFileName is the name of the per-directory ownership files used by
+// Chromium and Gerrit (find-owners and code-owners plugins).
+const OwnersFileName = "OWNERS"
+
+// ParseOwners parses the Chromium-style OWNERS files of a repository and
+// returns a single ruleset proto describing the ownership of the whole
+// repository.
+//
+// files maps the repository-relative path of every OWNERS file to its
+// contents. Files referenced by `file:` or `include` directives that are not
+// in files are read with readFile, if given. Includes that do not exist are
+// ignored.
+//
+// OWNERS files apply to the directory they are in, and to all its
+// subdirectories. The owners of a directory are the owners listed in its
+// OWNERS file, plus the owners of the parent directory unless `set noparent`
+// is used. This hierarchy is flattened into rules ordered from the root of
+// the repository to the deepest directories, so that the last matching rule
+// yields all the owners of a file, as with CODEOWNERS files:
+//   - `set noparent` stops inheriting owners from parent directories.
+//   - `per-file <glob>=<owners>` adds owners for files directly within the
+//     directory matching the glob. The directive can list several globs and
+//     owners separated by commas, and can contain `set noparent`.
+//   - `file://<path>` or `file:<path>` includes the owners (but neither the
+//     `per-file` rules nor `set noparent`) of another file. Paths starting
+//     with `/` are relative to the repository root, other paths to the
+//     directory of the OWNERS file.
+//   - `include <path>` includes all the directives of another file.
+//   - `*` allows anyone to approve changes, so it does not grant ownership
+//     to anyone in particular.
+func ParseOwners(files map[string][]byte, readFile func(path string) ([]byte, error)) (*codeownerspb.File, error) {
+	f, _, err := parseOwners(files, readFile)
+	return f, err
+}
+
+// parseOwners is like ParseOwners, but also returns the path of the OWNERS
+// file every rule was defined in.
+func parseOwners(files map[string][]byte, readFile func(path string) ([]byte, error)) (*codeownerspb.File, map[*codeownerspb.Rule]string, error) {
+	r := &ownersResolver{
+		contents: files,
+		readFile: readFile,
+		parsed:   map[string]*ownersFile{},
+	}
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		if path.Base(p) == OwnersFileName {
+			paths = append(paths, cleanOwnersPath(p))
+		}
+	}
+	// Parents need to be resolved before their subdirectories, and their
+	// rules need to come first.
+	sort.Slice(paths, func(i, j int) bool {
+		di, dj := strings.Count(paths[i], "/"), strings.Count(paths[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return paths[i] < paths[j]
+	})
+
+	var rules []*codeownerspb.Rule
+	sources := map[*codeownerspb.Rule]string{}
+	dirOwners := map[string][]string{}
+	for _, p := range paths {
+		file, err := r.resolve(p, nil)
+		if err != nil {
+			return nil, nil, err
+		}
+		if file == nil {
+			continue
+		}
+
+		dir := path.Dir(p)
+		owners := file.owners
+		if !file.noparent {
+			owners = appendUnique(append([]string{}, parentOwners(dirOwners, dir)...), owners...)
+		}
+		dirOwners[dir] = owners
+
+		rule := newOwnersRule(dirPattern(dir), owners, file.line)
+		rules = append(rules, rule)
+		sources[rule] = p
+
+		for _, pf := range file.perFile {
+			perFileOwners := pf.owners
+			if !pf.noparent {
+				perFileOwners = appendUnique(append([]string{}, owners...), perFileOwners...)
+			}
+			for _, glob := range pf.globs {
+				rule := newOwnersRule(path.Join("/", dir, glob), perFileOwners, pf.line)
+				rules = append(rules, rule)
+				sources[rule] = p
+			}
+		}
+	}
+	return &codeownerspb.File{Rule: rules}, sources, nil
+}
+
+func newOwnersRule(pattern string, owners []string, line int32) *codeownerspb.Rule {
+	rule := &codeownerspb.Rule{Pattern: pattern, LineNumber: line}
+	for _, o := range owners {
+		rule.Owner = append(rule.Owner, ParseOwner(o))
+	}
+	return rule
+}
+
+// dirPattern returns the pattern matching all files within the given
+// directory and its subdirectories.
+func dirPattern(dir string) string {
+	if dir == "." {
+		return "*"
+	}
+	return "/" + dir + "/"
+}
+
+// parentOwners returns the owners of the closest parent directory of dir
+// that has an OWNERS file.
+func parentOwners(dirOwners map[string][]string, dir string) []string {
+	for dir != "." {
+		dir = path.Dir(dir)
+		if owners, ok := dirOwners[dir]; ok {
+			return owners
+		}
+	}
+	return nil
+}
+
+// ownersFile is the parsed contents of an OWNERS file.
+type ownersFile struct {
+	ownersDirectives
+	perFile []*perFileDirective
+}
+
+// ownersDirectives are the directives that can appear both at the top level
+// of an OWNERS file and within `per-file` rules.
+type ownersDirectives struct {
+	// owners are the emails or handles of the owners, without duplicates.
+	owners   []string
+	noparent bool
+	// line is the line number of the first directive.
+	line int32
+}
+
+type perFileDirective struct {
+	ownersDirectives
+	globs []string
+}
+
+// ownersResolver parses OWNERS files and resolves the files they include.
+type ownersResolver struct {
+	contents map[string][]byte
+	readFile func(path string) ([]byte, error)
+	parsed   map[string]*ownersFile
+}
+
+// resolve returns the parsed OWNERS file at the given path with all its
+// includes resolved, or nil if it does not exist. including holds the files
+// that are currently being resolved, to break include cycles.
+func (r *ownersResolver) resolve(p string, including []string) (*ownersFile, error) {
+	if f, ok := r.parsed[p]; ok {
+		return f, nil
+	}
+	for _, i := range including {
+		if i == p {
+			return nil, nil
+		}
+	}
+
+	content, ok := r.contents[p]
+	if !ok {
+		if r.readFile == nil {
+			return nil, nil
+		}
+		var err error
+		content, err = r.readFile(p)
+		if os.IsNotExist(err) {
+			r.parsed[p] = nil
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "reading %s", p)
+		}
+	}
+
+	f, err := r.parse(p, content, append(including, p))
+	if err != nil {
+		return nil, err
+	}
+	r.parsed[p] = f
+	return f, nil
+}
+
+var errInvalidOwnersLine = errors.New("invalid OWNERS line")
+
+func (r *ownersResolver) parse(p string, content []byte, including []string) (*ownersFile, error) {
+	f := &ownersFile{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := int32(0)
+	for scanner.Scan() {
+		lineNumber++
+		line := scanner.Text()
+		if i := strings.IndexRune(line, commentStart); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		var err error
+		if rest, ok := strings.CutPrefix(line, "per-file"); ok && rest != strings.TrimLeft(rest, " \t") {
+			err = r.parsePerFile(p, f, strings.TrimSpace(rest), lineNumber, including)
+		} else if rest, ok := strings.CutPrefix(line, "include"); ok && rest != strings.TrimLeft(rest, " \t") {
+			err = r.include(p, f, strings.TrimSpace(rest), lineNumber, including)
+		} else {
+			err = r.parseDirective(p, &f.ownersDirectives, line, lineNumber, including)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s:%d", p, lineNumber)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// parsePerFile parses a `per-file` directive, without the keyword, such as
+// `*.md,*.txt=docs@example.com,set noparent`.
+func (r *ownersResolver) parsePerFile(p string, f *ownersFile, directive string, lineNumber int32, including []string) error {
+	globs, directives, ok := strings.Cut(directive, "=")
+	if !ok {
+		return errInvalidOwnersLine
+	}
+	pf := &perFileDirective{}
+	for _, glob := range strings.Split(globs, ",") {
+		if glob = strings.TrimSpace(glob); glob != "" {
+			pf.globs = append(pf.globs, glob)
+		}
+	}
+	if len(pf.globs) == 0 {
+		return errInvalidOwnersLine
+	}
+	for _, d := range strings.Split(directives, ",") {
+		if d = strings.TrimSpace(d); d == "" {
+			continue
+		}
+		if err := r.parseDirective(p, &pf.ownersDirectives, d, lineNumber, including); err != nil {
+			return err
+		}
+	}
+	pf.line = lineNumber
+	f.perFile = append(f.perFile, pf)
+	return nil
+}
+
+// parseDirective parses a single owner, `set noparent` or `file:` include.
+func (r *ownersResolver) parseDirective(p string, d *ownersDirectives, directive string, lineNumber int32, including []string) error {
+	if d.line == 0 {
+		d.line = lineNumber
+	}
+	switch {
+	case directive == "set noparent":
+		d.noparent = true
+	case directive == "*":
+		// Anyone can approve, so there is no owner in particular.
+	case strings.HasPrefix(directive, "file:"):
+		included, err := r.resolve(includePath(p, strings.TrimPrefix(directive, "file:")), including)
+		if err != nil {
+			return err
+		}
+		if included != nil {
+			d.owners = appendUnique(d.owners, included.owners...)
+		}
+	case strings.ContainsAny(directive, " \t="):
+		return errInvalidOwnersLine
+	default:
+		d.owners = appendUnique(d.owners, directive)
+	}
+	return nil
+}
+
+// include merges all the directives of the included file into f.
+func (r *ownersResolver) include(p string, f *ownersFile, includedPath string, lineNumber int32, including []string) error {
+	// Includes from other projects, like `project:branch:path`, are not
+	// supported.
+	if strings.Contains(includedPath, ":") {
+		return nil
+	}
+	included, err := r.resolve(includePath(p, includedPath), including)
+	if err != nil || included == nil {
+		return err
+	}
+	if f.line == 0 {
+		f.line = lineNumber
+	}
+	f.owners = appendUnique(f.owners, included.owners...)
+	f.noparent = f.noparent || included.noparent
+	f.perFile = append(f.perFile, included.perFile...)
+	return nil
+}
+
+// includePath returns the repository-relative path of a file included from
+// the OWNERS file at p.
+func includePath(p, included string) string {
+	included = strings.TrimSpace(included)
+	if strings.HasPrefix(included, "/") {
+		return cleanOwnersPath(included)
+	}
+	return cleanOwnersPath(path.Join(path.Dir(p), included))
+}
+
+func cleanOwnersPath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
+
+func appendUnique(owners []string, more ...string) []string {
+	for _, o := range more {
+		found := false
+		for _, existing := range owners {
+			if existing == o {
+				found = true
+				break
+			}
+		}
+		if !found {
+			owners = append(owners, o)
+		}
+	}
+	return owners
+}
@@ -0,0 +1,171 @@
+package codeowners_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
+	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
+)
+
+var chromiumOwners = map[string][]byte{
+	"OWNERS": []byte(`# Top-level owners.
+root@example.com
+*
+per-file *.md=docs@example.com
+`),
+	"base/OWNERS": []byte(`base@example.com
+root@example.com  # Already inherited.
+
+per-file BUILD.gn,*.gni=build@example.com, set noparent
+`),
+	"base/allocator/OWNERS": []byte(`set noparent
+file://base/allocator/COMMON_OWNERS
+per-file *.h=headers@example.com
+`),
+	"base/allocator/partition/OWNERS": []byte(`partition@example.com
+`),
+	"third_party/blink/OWNERS": []byte(`include /build/OWNERS.setnoparent
+file:../../base/OWNERS
+`),
+}
+
+var includedFiles = map[string]string{
+	"base/allocator/COMMON_OWNERS": "allocator@example.com\nset noparent\nper-file *.cc=ignored@example.com\n",
+	"build/OWNERS.setnoparent":     "set noparent\nblink@example.com\nper-file *.idl=idl@example.com\n",
+}
+
+func readIncludedFile(path string) ([]byte, error) {
+	content, ok := includedFiles[path]
+	if !ok {
+		return nil, os.ErrNotExist
+	}
+	return []byte(content), nil
+}
+
+func TestParseOwners(t *testing.T) {
+	got, err := codeowners.ParseOwners(chromiumOwners, readIncludedFile)
+	require.NoError(t, err)
+
+	want := &codeownerspb.File{
+		Rule: []*codeownerspb.Rule{
+			{
+				Pattern:    "*",
+				Owner:      []*codeownerspb.Owner{{Email: "root@example.com"}},
+				LineNumber: 2,
+			},
+			{
+				Pattern:    "/*.md",
+				Owner:      []*codeownerspb.Owner{{Email: "root@example.com"}, {Email: "docs@example.com"}},
+				LineNumber: 4,
+			},
+			{
+				Pattern:    "/base/",
+				Owner:      []*codeownerspb.Owner{{Email: "root@example.com"}, {Email: "base@example.com"}},
+				LineNumber: 1,
+			},
+			{
+				Pattern:    "/base/BUILD.gn",
+				Owner:      []*codeownerspb.Owner{{Email: "build@example.com"}},
+				LineNumber: 4,
+			},
+			{
+				Pattern:    "/base/*.gni",
+				Owner:      []*codeownerspb.Owner{{Email: "build@example.com"}},
+				LineNumber: 4,
+			},
+			{
+				Pattern:    "/base/allocator/",
+				Owner:      []*codeownerspb.Owner{{Email: "allocator@example.com"}},
+				LineNumber: 1,
+			},
+			{
+				Pattern:    "/base/allocator/*.h",
+				Owner:      []*codeownerspb.Owner{{Email: "allocator@example.com"}, {Email: "headers@example.com"}},
+				LineNumber: 3,
+			},
+			{
+				Pattern:    "/third_party/blink/",
+				Owner:      []*codeownerspb.Owner{{Email: "blink@example.com"}, {Email: "base@example.com"}, {Email: "root@example.com"}},
+				LineNumber: 1,
+			},
+			{
+				Pattern:    "/third_party/blink/*.idl",
+				Owner:      []*codeownerspb.Owner{{Email: "blink@example.com"}, {Email: "base@example.com"}, {Email: "root@example.com"}, {Email: "idl@example.com"}},
+				LineNumber: 3,
+			},
+			{
+				Pattern:    "/base/allocator/partition/",
+				Owner:      []*codeownerspb.Owner{{Email: "allocator@example.com"}, {Email: "partition@example.com"}},
+				LineNumber: 1,
+			},
+		},
+	}
+	assert.Equal(t, codeowners.NewRuleset(nil, want).Repr(), codeowners.NewRuleset(nil, got).Repr())
+	for i, rule := range got.GetRule() {
+		assert.Equal(t, want.GetRule()[i].GetLineNumber(), rule.GetLineNumber(), rule.GetPattern())
+	}
+}
+
+func TestOwnersRulesetMatch(t *testing.T) {
+	rs, err := codeowners.NewOwnersRuleset(1, "SHA", chromiumOwners, readIncludedFile)
+	require.NoError(t, err)
+
+	for path, want := range map[string][]string{
+		"README.md":                           {"root@example.com", "docs@example.com"},
+		"docs/README.md":                      {"root@example.com"},
+		"base/logging.cc":                     {"root@example.com", "base@example.com"},
+		"base/BUILD.gn":                       {"build@example.com"},
+		"base/files/BUILD.gn":                 {"root@example.com", "base@example.com"},
+		"base/allocator/allocator.cc":         {"allocator@example.com"},
+		"base/allocator/allocator.h":          {"allocator@example.com", "headers@example.com"},
+		"base/allocator/partition/alloc.cc":   {"allocator@example.com", "partition@example.com"},
+		"base/allocator/partition/nested/a.h": {"allocator@example.com", "partition@example.com"},
+	} {
+		rule := rs.Match(path)
+		require.NotNil(t, rule, path)
+		var got []string
+		for _, o := range rule.GetOwner() {
+			got = append(got, o.GetEmail())
+		}
+		assert.Equal(t, want, got, path)
+	}
+
+	// Every rule points at the OWNERS file it was defined in.
+	source := rs.GetRuleSource(rs.Match("base/allocator/allocator.h"))
+	assert.Equal(t, codeowners.GitRulesetSource{Repo: 1, Commit: "SHA", Path: "base/allocator/OWNERS"}, source)
+}
+
+func TestParseOwnersIncludeCycle(t *testing.T) {
+	got, err := codeowners.ParseOwners(map[string][]byte{
+		"OWNERS":   []byte("include a/OWNERS\nroot@example.com\n"),
+		"a/OWNERS": []byte("file://OWNERS\na@example.com\n"),
+	}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "* a@example.com root@example.com\n/a/ a@example.com root@example.com\n", codeowners.NewRuleset(nil, got).Repr())
+}
+
+func TestParseOwnersMissingInclude(t *testing.T) {
+	got, err := codeowners.ParseOwners(map[string][]byte{
+		"OWNERS": []byte("file://does/not/exist\ninclude chromium/src:main:/OWNERS\nroot@example.com\n"),
+	}, readIncludedFile)
+	require.NoError(t, err)
+	assert.Equal(t, "* root@example.com\n", codeowners.NewRuleset(nil, got).Repr())
+}
+
+func TestParseOwnersInvalidLine(t *testing.T) {
+	for _, line := range []string{
+		"set something",
+		"per-file *.md",
+		"per-file =docs@example.com",
+		"root@example.com other@example.com",
+	} {
+		_, err := codeowners.ParseOwners(map[string][]byte{
+			"a/OWNERS": []byte("root@example.com\n" + line),
+		}, nil)
+		assert.ErrorContains(t, err, "a/OWNERS:2", line)
+	}
+}
@@ -296,6 +296,43 @@ func TestApplyCodeOwnershipFiltering(t *testing.T) {
 				},
 			}),
 		},
+		{
+			name: "selects results with blame owner if there is no other owner",
+			args: args{
+				includeOwners: []string{"blame@example.com"},
+				excludeOwners: []string{},
+				matches: []result.Match{
+					&result.FileMatch{
+						File: result.File{
+							Path: "src/main/README.md",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							// Owned through CODEOWNERS, so blame owners
+							// are not considered.
+							Path: "src/main/main.go",
+						},
+					},
+					&result.FileMatch{
+						File: result.File{
+							Path: "src/main/notOwned.md",
+						},
+					},
+				},
+				repoContent: map[string]string{
+					"CODEOWNERS": "*.go @codeowner",
+				},
+			},
+			setup: blameOwnerSetup("blame@example.com", "src/main/README.md", "src/main/main.go"),
+			want: autogold.Expect([]result.Match{
+				&result.FileMatch{
+					File: result.File{
+						Path: "src/main/README.md",
+					},
+				},
+			}),
+		},
 		{
 			name: "selects results with AND-ed include owners specified",
 			args: args{
@@ -563,6 +600,7 @@ func TestApplyCodeOwnershipFiltering(t *testing.T) {
 			assignedTeamsStore := database.NewMockAssignedTeamsStore()
 			assignedTeamsStore.ListAssignedTeamsForRepoFunc.SetDefaultReturn(nil, nil)
 			db.AssignedTeamsFunc.SetDefaultReturn(assignedTeamsStore)
+			db.OwnSignalConfigurationsFunc.SetDefaultReturn(database.NewMockSignalConfigurationStore())
 			userExternalAccountsStore := database.NewMockUserExternalAccountsStore()
 			userExternalAccountsStore.ListFunc.SetDefaultReturn(nil, nil)
 			db.UserExternalAccountsFunc.SetDefaultReturn(userExternalAccountsStore)
@@ -622,3 +660,23 @@ func assignedOwnerSetup(path string, user *types.User) func(*edb.MockEnterpriseD
 		db.AssignedOwnersFunc.SetDefaultReturn(assignedOwnersStore)
 	}
 }
+
+func blameOwnerSetup(email string, paths ...string) func(*edb.MockEnterpriseDB) {
+	return func(db *edb.MockEnterpriseDB) {
+		var blameOwners []database.BlameOwnership
+		for _, p := range paths {
+			blameOwners = append(blameOwners, database.BlameOwnership{
+				FilePath:    p,
+				AuthorEmail: email,
+				LinesCount:  10,
+				Share:       1,
+			})
+		}
+		configStore := database.NewMockSignalConfigurationStore()
+		configStore.IsEnabledFunc.SetDefaultReturn(true, nil)
+		db.OwnSignalConfigurationsFunc.SetDefaultReturn(configStore)
+		blameStore := database.NewMockBlameOwnershipSignalStore()
+		blameStore.ListBlameOwnersForRepoFunc.SetDefaultReturn(blameOwners, nil)
+		db.BlameOwnershipSignalsFunc.SetDefaultReturn(blameStore)
+	}
+}
@@ -27,11 +27,17 @@ type RulesCache struct {
 	rules         map[RulesKey]*codeowners.Ruleset
 	assigned      map[AssignedKey]own.AssignedOwners
 	assignedTeams map[AssignedKey]own.AssignedTeams
+	blameOwners   map[AssignedKey]own.BlameOwners
+	userNames     map[int32]string
+	teamNames     map[int32]string
 	ownService    own.Service
+	db            database.DB
 
 	rulesMu         sync.RWMutex
 	assignedMu      sync.RWMutex
 	assignedTeamsMu sync.RWMutex
+	blameOwnersMu   sync.RWMutex
+	namesMu         sync.Mutex
 }
 
 func NewRulesCache(gs gitserver.Client, db database.DB) RulesCache {
@@ -39,7 +45,11 @@ func NewRulesCache(gs gitserver.Client, db database.DB) RulesCache {
 		rules:         make(map[RulesKey]*codeowners.Ruleset),
 		assigned:      make(map[AssignedKey]own.AssignedOwners),
 		assignedTeams: make(map[AssignedKey]own.AssignedTeams),
+		blameOwners:   make(map[AssignedKey]own.BlameOwners),
+		userNames:     make(map[int32]string),
+		teamNames:     make(map[int32]string),
 		ownService:    own.NewService(gs, db),
+		db:            db,
 	}
 }
 
@@ -56,13 +66,78 @@ func (c *RulesCache) GetFromCacheOrFetch(ctx context.Context, repoName api.RepoN
 	if err != nil {
 		return repoOwnershipData{}, err
 	}
+	blameOwners, err := c.BlameOwners(ctx, repoID, commitID)
+	if err != nil {
+		return repoOwnershipData{}, err
+	}
 	return repoOwnershipData{
 		assigned:      assigned,
 		assignedTeams: assignedTeams,
 		codeowners:    codeowners,
+		blameOwners:   blameOwners,
 	}, nil
 }
 
+// FileOwners returns the owners of the file at path, taken from the same ownership data
+// that has.owner() filters on. Every owner is returned as a text reference that
+// has.owner() resolves back to that owner: a handle or an email for CODEOWNERS
+// and blame owners, and the username or team name for assigned owners.
+func (c *RulesCache) FileOwners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID, path string) ([]string, error) {
+	data, err := c.GetFromCacheOrFetch(ctx, repoName, repoID, commitID)
+	if err != nil {
+		return nil, err
+	}
+	var owners []string
+	for _, ref := range data.Match(path).References() {
+		var owner string
+		switch {
+		case ref.Handle != "":
+			owner = "@" + ref.Handle
+		case ref.Email != "":
+			owner = ref.Email
+		case ref.UserID != 0:
+			owner, err = c.userName(ctx, ref.UserID)
+		case ref.TeamID != 0:
+			owner, err = c.teamName(ctx, ref.TeamID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if owner != "" {
+			owners = append(owners, owner)
+		}
+	}
+	return owners, nil
+}
+
+func (c *RulesCache) userName(ctx context.Context, id int32) (string, error) {
+	c.namesMu.Lock()
+	defer c.namesMu.Unlock()
+	if name, ok := c.userNames[id]; ok {
+		return name, nil
+	}
+	user, err := c.db.Users().GetByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	c.userNames[id] = "@" + user.Username
+	return c.userNames[id], nil
+}
+
+func (c *RulesCache) teamName(ctx context.Context, id int32) (string, error) {
+	c.namesMu.Lock()
+	defer c.namesMu.Unlock()
+	if name, ok := c.teamNames[id]; ok {
+		return name, nil
+	}
+	team, err := c.db.Teams().GetTeamByID(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	c.teamNames[id] = "@" + team.Name
+	return c.teamNames[id], nil
+}
+
 func (c *RulesCache) AssignedOwners(ctx context.Context, repoID api.RepoID, commitID api.CommitID) (own.AssignedOwners, error) {
 	c.assignedMu.RLock()
 	key := AssignedKey{repoID}
@@ -105,6 +180,27 @@ func (c *RulesCache) AssignedTeams(ctx context.Context, repoID api.RepoID, commi
 	return c.assignedTeams[key], nil
 }
 
+func (c *RulesCache) BlameOwners(ctx context.Context, repoID api.RepoID, commitID api.CommitID) (own.BlameOwners, error) {
+	c.blameOwnersMu.RLock()
+	key := AssignedKey{repoID}
+	if v, ok := c.blameOwners[key]; ok {
+		defer c.blameOwnersMu.RUnlock()
+		return v, nil
+	}
+	c.blameOwnersMu.RUnlock()
+	c.blameOwnersMu.Lock()
+	defer c.blameOwnersMu.Unlock()
+	if _, ok := c.blameOwners[key]; !ok {
+		blameOwners, err := c.ownService.BlameOwnership(ctx, repoID, commitID)
+		if err != nil {
+			// Error is picked up on a call site and in most cases a search alert is created.
+			return nil, err
+		}
+		c.blameOwners[key] = blameOwners
+	}
+	return c.blameOwners[key], nil
+}
+
 func (c *RulesCache) Codeowners(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*codeowners.Ruleset, error) {
 	c.rulesMu.RLock()
 	key := RulesKey{repoName, commitID}
@@ -134,6 +230,7 @@ type repoOwnershipData struct {
 	codeowners    *codeowners.Ruleset
 	assigned      own.AssignedOwners
 	assignedTeams own.AssignedTeams
+	blameOwners   own.BlameOwners
 }
 
 func (o repoOwnershipData) Match(path string) fileOwnershipData {
@@ -141,17 +238,23 @@ func (o repoOwnershipData) Match(path string) fileOwnershipData {
 	if o.codeowners != nil {
 		rule = o.codeowners.Match(path)
 	}
-	return fileOwnershipData{
+	d := fileOwnershipData{
 		rule:           rule,
 		assignedOwners: o.assigned.Match(path),
 		assignedTeams:  o.assignedTeams.Match(path),
 	}
+	// Blame owners are only used for files that have no owner otherwise.
+	if d.Empty() {
+		d.blameOwners = o.blameOwners.Match(path)
+	}
+	return d
 }
 
 type fileOwnershipData struct {
 	rule           *codeownerspb.Rule
 	assignedOwners []database.AssignedOwnerSummary
 	assignedTeams  []database.AssignedTeamSummary
+	blameOwners    []database.BlameOwnership
 }
 
 func (d fileOwnershipData) References() []own.Reference {
@@ -165,6 +268,9 @@ func (d fileOwnershipData) References() []own.Reference {
 	for _, o := range d.assignedTeams {
 		rs = append(rs, own.Reference{TeamID: o.OwnerTeamID})
 	}
+	for _, o := range d.blameOwners {
+		rs = append(rs, own.Reference{Email: o.AuthorEmail})
+	}
 	return rs
 }
 
@@ -182,6 +288,9 @@ func (d fileOwnershipData) NonEmpty() bool {
 	if len(d.assignedTeams) > 0 {
 		return true
 	}
+	if len(d.blameOwners) > 0 {
+		return true
+	}
 	return false
 }
 
@@ -204,6 +313,11 @@ func (d fileOwnershipData) IsWithin(bag own.Bag) bool {
 			return true
 		}
 	}
+	for _, o := range d.blameOwners {
+		if bag.Contains(own.Reference{Email: o.AuthorEmail}) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -223,5 +337,8 @@ func (d fileOwnershipData) String() string {
 	for _, o := range d.assignedTeams {
 		references = append(references, fmt.Sprintf("#%d", o.OwnerTeamID))
 	}
+	for _, o := range d.blameOwners {
+		references = append(references, o.AuthorEmail)
+	}
 	return fmt.Sprintf("[%s]", strings.Join(references, ", "))
 }
@@ -0,0 +1,102 @@
+package search
+
+import (
+	"context"
+	"io/fs"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/assert"
+
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRulesCacheFileOwners(t *testing.T) {
+	ctx := context.Background()
+	user := &types.User{ID: 1, Username: "bob"}
+	team := &types.Team{ID: 2, Name: "frontend"}
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, file string) ([]byte, error) {
+		if file == "CODEOWNERS" {
+			return []byte("*.go @backend alice@example.com\n"), nil
+		}
+		return nil, fs.ErrNotExist
+	})
+
+	codeownersStore := edb.NewMockCodeownersStore()
+	codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(nil, nil)
+	db := edb.NewMockEnterpriseDB()
+	db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+	usersStore := database.NewMockUserStore()
+	usersStore.GetByIDFunc.SetDefaultHook(func(_ context.Context, id int32) (*types.User, error) {
+		if id == user.ID {
+			return user, nil
+		}
+		return nil, database.NewUserNotFoundErr()
+	})
+	usersStore.GetByUsernameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.User, error) {
+		if name == user.Username {
+			return user, nil
+		}
+		return nil, database.NewUserNotFoundErr()
+	})
+	usersStore.GetByVerifiedEmailFunc.SetDefaultReturn(nil, nil)
+	db.UsersFunc.SetDefaultReturn(usersStore)
+	usersEmailsStore := database.NewMockUserEmailsStore()
+	usersEmailsStore.GetVerifiedEmailsFunc.SetDefaultReturn(nil, nil)
+	db.UserEmailsFunc.SetDefaultReturn(usersEmailsStore)
+	userExternalAccountsStore := database.NewMockUserExternalAccountsStore()
+	userExternalAccountsStore.ListFunc.SetDefaultReturn(nil, nil)
+	db.UserExternalAccountsFunc.SetDefaultReturn(userExternalAccountsStore)
+	teamsStore := database.NewMockTeamStore()
+	teamsStore.GetTeamByIDFunc.SetDefaultReturn(team, nil)
+	teamsStore.GetTeamByNameFunc.SetDefaultHook(func(_ context.Context, name string) (*types.Team, error) {
+		if name == team.Name {
+			return team, nil
+		}
+		return nil, database.TeamNotFoundError{}
+	})
+	db.TeamsFunc.SetDefaultReturn(teamsStore)
+	assignedOwnersStore := database.NewMockAssignedOwnersStore()
+	assignedOwnersStore.ListAssignedOwnersForRepoFunc.SetDefaultReturn([]*database.AssignedOwnerSummary{{OwnerUserID: user.ID, FilePath: "web"}}, nil)
+	db.AssignedOwnersFunc.SetDefaultReturn(assignedOwnersStore)
+	assignedTeamsStore := database.NewMockAssignedTeamsStore()
+	assignedTeamsStore.ListAssignedTeamsForRepoFunc.SetDefaultReturn([]*database.AssignedTeamSummary{{OwnerTeamID: team.ID, FilePath: "web"}}, nil)
+	db.AssignedTeamsFunc.SetDefaultReturn(assignedTeamsStore)
+	db.OwnSignalConfigurationsFunc.SetDefaultReturn(database.NewMockSignalConfigurationStore())
+	repoStore := database.NewMockRepoStore()
+	repoStore.GetFunc.SetDefaultReturn(&types.Repo{ExternalRepo: api.ExternalRepoSpec{ServiceType: "github"}}, nil)
+	db.ReposFunc.SetDefaultReturn(repoStore)
+
+	rules := NewRulesCache(gitserverClient, db)
+	data, err := rules.GetFromCacheOrFetch(ctx, "repo", 1, "deadbeef")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := map[string][]string{}
+	for _, path := range []string{"main.go", "web/index.ts", "README.md"} {
+		owners, err := rules.FileOwners(ctx, "repo", 1, "deadbeef", path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[path] = owners
+		// Every owner must be matched by has.owner() for the same file.
+		for _, owner := range owners {
+			bag := own.ByTextReference(ctx, db, owner)
+			assert.True(t, data.Match(path).IsWithin(bag), "has.owner(%s) does not match %s", owner, path)
+		}
+	}
+	autogold.Expect(map[string][]string{
+		"README.md":    nil,
+		"main.go":      {"@backend", "alice@example.com"},
+		"web/index.ts": {"@bob", "@frontend"},
+	}).Equal(t, got)
+}
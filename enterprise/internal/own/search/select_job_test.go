@@ -32,6 +32,7 @@ func TestGetCodeOwnersFromMatches(t *testing.T) {
 		db.CodeownersFunc.SetDefaultReturn(codeownersStore)
 		db.AssignedOwnersFunc.SetDefaultReturn(database.NewMockAssignedOwnersStore())
 		db.AssignedTeamsFunc.SetDefaultReturn(database.NewMockAssignedTeamsStore())
+		db.OwnSignalConfigurationsFunc.SetDefaultReturn(database.NewMockSignalConfigurationStore())
 		db.ReposFunc.SetDefaultReturn(repoStore)
 		return db
 	}
@@ -102,6 +103,7 @@ func TestGetCodeOwnersFromMatches(t *testing.T) {
 		db.TeamsFunc.SetDefaultReturn(mockTeamStore)
 		db.AssignedOwnersFunc.SetDefaultReturn(database.NewMockAssignedOwnersStore())
 		db.AssignedTeamsFunc.SetDefaultReturn(database.NewMockAssignedTeamsStore())
+		db.OwnSignalConfigurationsFunc.SetDefaultReturn(database.NewMockSignalConfigurationStore())
 		db.UserExternalAccountsFunc.SetDefaultReturn(database.NewMockUserExternalAccountsStore())
 
 		personOwnerByHandle := newTestUser("testUserHandle")
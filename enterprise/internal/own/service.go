@@ -1,23 +1,29 @@
 package own
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
+	"io"
 	"os"
 	"strings"
 
+	lru "github.com/hashicorp/golang-lru/v2"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/own/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 )
 
 // Service gives access to code ownership data.
-// At this point only data from CODEOWNERS file is presented, if available.
+// At this point only data from CODEOWNERS, OWNERS or MAINTAINERS files is presented, if available.
 type Service interface {
 	// RulesetForRepo returns a CODEOWNERS file ruleset from a given repository at given commit ID.
 	// If a CODEOWNERS file has been manually ingested for the repository, it will prioritise returning that file.
@@ -39,6 +45,13 @@ type Service interface {
 	// team of 'src/test' in a given repo transitively owns all files within the
 	// directory tree at that root like 'src/test/com/sourcegraph/Test.java'.
 	AssignedTeams(context.Context, api.RepoID, api.CommitID) (AssignedTeams, error)
+
+	// BlameOwnership returns the owners inferred from git blame for given repo,
+	// as computed by the blame ownership background job. Unlike assigned
+	// ownership, it is not inherited down the file tree, as every file and
+	// directory has its own blame owners. It returns no owners if the signal
+	// is disabled.
+	BlameOwnership(context.Context, api.RepoID, api.CommitID) (BlameOwners, error)
 }
 
 type AssignedOwners map[string][]database.AssignedOwnerSummary
@@ -61,6 +74,13 @@ func (at AssignedTeams) Match(path string) []database.AssignedTeamSummary {
 	return match(at, path)
 }
 
+type BlameOwners map[string][]database.BlameOwnership
+
+// Match returns the blame owners of the given file or directory.
+func (bo BlameOwners) Match(path string) []database.BlameOwnership {
+	return bo[path]
+}
+
 func match[T any](assigned map[string][]T, path string) []T {
 	var summaries []T
 	for lastSlash := len(path); lastSlash != -1; lastSlash = strings.LastIndex(path, "/") {
@@ -105,7 +125,10 @@ var codeownersLocations = []string{
 }
 
 // RulesetForRepo makes a best effort attempt to return a CODEOWNERS file ruleset
-// from one of the possible codeownersLocations, or the ingested codeowners files. It returns nil if no match is found.
+// from one of the possible codeownersLocations, or the ingested codeowners files.
+// If there is no CODEOWNERS file, the ruleset is built from the Chromium-style
+// OWNERS files of the repository, or else from its MAINTAINERS file. It returns
+// nil if no match is found.
 func (s *service) RulesetForRepo(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*codeowners.Ruleset, error) {
 	ingestedCodeowners, err := s.db.Codeowners().GetCodeownersForRepo(ctx, repoID)
 	if err != nil && !errcode.IsNotFound(err) {
@@ -135,6 +158,12 @@ func (s *service) RulesetForRepo(ctx context.Context, repoName api.RepoName, rep
 			}
 			return nil, err
 		}
+		if rs == nil {
+			rs, err = s.fallbackRuleset(ctx, repoName, repoID, commitID)
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 	if rs == nil {
 		return nil, nil
@@ -149,6 +178,113 @@ func (s *service) RulesetForRepo(ctx context.Context, repoName api.RepoName, rep
 	return rs, nil
 }
 
+// noFallbackRulesetCacheSize is the number of commits for which the absence of
+// OWNERS and MAINTAINERS files is remembered.
+const noFallbackRulesetCacheSize = 10000
+
+type repoCommit struct {
+	repo   api.RepoID
+	commit api.CommitID
+}
+
+// noFallbackRuleset holds the commits that have neither OWNERS nor MAINTAINERS
+// files, so that repositories without ownership files are not listed on every
+// lookup. It is shared by all services, as they are often created per request,
+// and never needs to be invalidated, as the files of a commit do not change.
+var noFallbackRuleset, _ = lru.New[repoCommit, struct{}](noFallbackRulesetCacheSize)
+
+// fallbackRuleset returns the ruleset for the OWNERS files of the repository,
+// or else for its MAINTAINERS file. It returns nil if there are none.
+func (s *service) fallbackRuleset(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*codeowners.Ruleset, error) {
+	key := repoCommit{repo: repoID, commit: commitID}
+	if noFallbackRuleset.Contains(key) {
+		return nil, nil
+	}
+	rs, err := s.ownersRuleset(ctx, repoName, repoID, commitID)
+	if err != nil || rs != nil {
+		return rs, err
+	}
+	rs, err = s.maintainersRuleset(ctx, repoName, repoID, commitID)
+	if err != nil || rs != nil {
+		return rs, err
+	}
+	noFallbackRuleset.Add(key, struct{}{})
+	return nil, nil
+}
+
+// maintainersRuleset returns the ruleset for the MAINTAINERS file at the root
+// of the repository, or nil if there is none.
+func (s *service) maintainersRuleset(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*codeowners.Ruleset, error) {
+	content, err := s.gitserverClient.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, repoName, commitID, codeowners.MaintainersFileName)
+	if os.IsNotExist(err) || (err == nil && content == nil) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	pbfile, err := codeowners.ParseMaintainers(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	return codeowners.NewRuleset(codeowners.GitRulesetSource{Repo: repoID, Commit: commitID, Path: codeowners.MaintainersFileName}, pbfile), nil
+}
+
+// ownersRuleset returns the ruleset for the Chromium-style OWNERS files of the
+// repository, or nil if there are none. All OWNERS files are fetched in a
+// single archive, other files they include are read one by one.
+func (s *service) ownersRuleset(ctx context.Context, repoName api.RepoName, repoID api.RepoID, commitID api.CommitID) (*codeowners.Ruleset, error) {
+	paths, err := s.gitserverClient.LsFiles(
+		ctx,
+		authz.DefaultSubRepoPermsChecker,
+		repoName,
+		commitID,
+		gitdomain.PathspecLiteral(codeowners.OwnersFileName),
+		gitdomain.PathspecSuffix("/"+codeowners.OwnersFileName),
+	)
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+
+	pathspecs := make([]gitdomain.Pathspec, 0, len(paths))
+	for _, p := range paths {
+		pathspecs = append(pathspecs, gitdomain.PathspecLiteral(p))
+	}
+	rc, err := s.gitserverClient.ArchiveReader(ctx, authz.DefaultSubRepoPermsChecker, repoName, gitserver.ArchiveOptions{
+		Treeish:   string(commitID),
+		Format:    gitserver.ArchiveFormatTar,
+		Pathspecs: pathspecs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		files[header.Name] = content
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	return codeowners.NewOwnersRuleset(repoID, commitID, files, func(name string) ([]byte, error) {
+		return s.gitserverClient.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, repoName, commitID, name)
+	})
+}
+
 func (s *service) AssignedOwnership(ctx context.Context, repoID api.RepoID, _ api.CommitID) (AssignedOwners, error) {
 	summaries, err := s.db.AssignedOwners().ListAssignedOwnersForRepo(ctx, repoID)
 	if err != nil {
@@ -176,3 +312,22 @@ func (s *service) AssignedTeams(ctx context.Context, repoID api.RepoID, _ api.Co
 	}
 	return assignedTeams, nil
 }
+
+func (s *service) BlameOwnership(ctx context.Context, repoID api.RepoID, _ api.CommitID) (BlameOwners, error) {
+	enabled, err := s.db.OwnSignalConfigurations().IsEnabled(ctx, types.SignalBlameOwnership)
+	if err != nil {
+		return nil, err
+	}
+	blameOwners := BlameOwners{}
+	if !enabled {
+		return blameOwners, nil
+	}
+	signals, err := s.db.BlameOwnershipSignals().ListBlameOwnersForRepo(ctx, repoID)
+	if err != nil {
+		return nil, err
+	}
+	for _, signal := range signals {
+		blameOwners[signal.FilePath] = append(blameOwners[signal.FilePath], signal)
+	}
+	return blameOwners, nil
+}
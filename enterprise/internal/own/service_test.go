@@ -1,9 +1,13 @@
 package own
 
 import (
+	"archive/tar"
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 
 	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
 	codeownerspb "github.com/sourcegraph/sourcegraph/enterprise/internal/own/codeowners/v1"
@@ -119,6 +124,114 @@ func TestOwnersCannotFindFile(t *testing.T) {
 	assert.Nil(t, got)
 }
 
+// ArchiveReader returns a tar archive of the files matching the given literal
+// pathspecs.
+func (fs repoFiles) ArchiveReader(_ context.Context, _ authz.SubRepoPermissionChecker, repoName api.RepoName, opts gitserver.ArchiveOptions) (io.ReadCloser, error) {
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, pathspec := range opts.Pathspecs {
+		file := strings.TrimPrefix(string(pathspec), ":(literal)")
+		content, ok := fs[repoPath{Repo: repoName, CommitID: api.CommitID(opts.Treeish), Path: file}]
+		if !ok {
+			continue
+		}
+		if err := w.WriteHeader(&tar.Header{Name: file, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return io.NopCloser(&buf), nil
+}
+
+func TestOwnersServesOwnersFiles(t *testing.T) {
+	noFallbackRuleset.Purge()
+	repo := repoFiles{
+		{"repo", "SHA", "OWNERS"}:              "root@example.com\n",
+		{"repo", "SHA", "src/OWNERS"}:          "set noparent\nfile://build/COMMON_OWNERS\n",
+		{"repo", "SHA", "build/COMMON_OWNERS"}: "build@example.com\n",
+	}
+	git := gitserver.NewMockClient()
+	git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
+	git.ArchiveReaderFunc.SetDefaultHook(repo.ArchiveReader)
+	git.LsFilesFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, repoName api.RepoName, commitID api.CommitID, pathspecs ...gitdomain.Pathspec) ([]string, error) {
+		assert.Equal(t, []gitdomain.Pathspec{":(literal)OWNERS", "*/OWNERS"}, pathspecs)
+		return []string{"OWNERS", "src/OWNERS"}, nil
+	})
+
+	codeownersStore := edb.NewMockCodeownersStore()
+	codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(nil, edb.CodeownersFileNotFoundError{})
+	reposStore := database.NewMockRepoStore()
+	reposStore.GetFunc.SetDefaultReturn(&types2.Repo{ExternalRepo: api.ExternalRepoSpec{ServiceType: "gerrit"}}, nil)
+	db := edb.NewMockEnterpriseDB()
+	db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+	db.ReposFunc.SetDefaultReturn(reposStore)
+
+	got, err := NewService(git, db).RulesetForRepo(context.Background(), "repo", 1, "SHA")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "* root@example.com\n/src/ build@example.com\n", got.Repr())
+	assert.Equal(t, codeowners.GitRulesetSource{Repo: 1, Commit: "SHA", Path: "src/OWNERS"}, got.GetRuleSource(got.Match("src/main.cc")))
+}
+
+func TestOwnersServesMaintainersFile(t *testing.T) {
+	noFallbackRuleset.Purge()
+	repo := repoFiles{
+		{"repo", "SHA", "MAINTAINERS"}: "THE REST\nM:\tJane Doe <jane@example.com>\nF:\t*\nF:\t*/\n",
+	}
+	git := gitserver.NewMockClient()
+	git.ReadFileFunc.SetDefaultHook(repo.ReadFile)
+
+	codeownersStore := edb.NewMockCodeownersStore()
+	codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(nil, edb.CodeownersFileNotFoundError{})
+	reposStore := database.NewMockRepoStore()
+	reposStore.GetFunc.SetDefaultReturn(&types2.Repo{ExternalRepo: api.ExternalRepoSpec{ServiceType: "github"}}, nil)
+	db := edb.NewMockEnterpriseDB()
+	db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+	db.ReposFunc.SetDefaultReturn(reposStore)
+
+	got, err := NewService(git, db).RulesetForRepo(context.Background(), "repo", 1, "SHA")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, "/*/ jane@example.com\n/* jane@example.com\n", got.Repr())
+	assert.Equal(t, codeowners.GitRulesetSource{Repo: 1, Commit: "SHA", Path: "MAINTAINERS"}, got.GetSource())
+}
+
+func TestOwnersCachesMissingFallbackFiles(t *testing.T) {
+	noFallbackRuleset.Purge()
+	git := gitserver.NewMockClient()
+	git.ReadFileFunc.SetDefaultReturn(nil, os.ErrNotExist)
+
+	codeownersStore := edb.NewMockCodeownersStore()
+	codeownersStore.GetCodeownersForRepoFunc.SetDefaultReturn(nil, edb.CodeownersFileNotFoundError{})
+	db := edb.NewMockEnterpriseDB()
+	db.CodeownersFunc.SetDefaultReturn(codeownersStore)
+
+	for i := 0; i < 2; i++ {
+		got, err := NewService(git, db).RulesetForRepo(context.Background(), "repo", 1, "SHA")
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	}
+	// OWNERS files are listed, and MAINTAINERS read, for the first lookup only.
+	assert.Len(t, git.LsFilesFunc.History(), 1)
+	var maintainersReads int
+	for _, call := range git.ReadFileFunc.History() {
+		if call.Arg4 == codeowners.MaintainersFileName {
+			maintainersReads++
+		}
+	}
+	assert.Equal(t, 1, maintainersReads)
+
+	// Other commits are looked up again.
+	_, err := NewService(git, db).RulesetForRepo(context.Background(), "repo", 1, "OTHER")
+	require.NoError(t, err)
+	assert.Len(t, git.LsFilesFunc.History(), 2)
+}
+
 func TestOwnersServesIngestedFile(t *testing.T) {
 	t.Run("return manually ingested codeowners file", func(t *testing.T) {
 		codeownersProto := &codeownerspb.File{
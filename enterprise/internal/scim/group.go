@@ -0,0 +1,230 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Group is a team that is provisioned from a group of the identity provider.
+type Group struct {
+	types.Team
+	Members []*types.User
+}
+
+func (g *Group) ToResource() scim.Resource {
+	members := make([]interface{}, 0, len(g.Members))
+	for _, member := range g.Members {
+		members = append(members, map[string]interface{}{
+			"value":   strconv.FormatInt(int64(member.ID), 10),
+			"display": member.Username,
+		})
+	}
+
+	displayName := g.DisplayName
+	if displayName == "" {
+		displayName = g.Name
+	}
+	attributes := scim.ResourceAttributes{
+		AttrDisplayName: displayName,
+		AttrMembers:     members,
+	}
+	if g.SCIMExternalID != "" {
+		attributes[AttrExternalId] = g.SCIMExternalID
+	}
+
+	return scim.Resource{
+		ID:         strconv.FormatInt(int64(g.ID), 10),
+		ExternalID: getOptionalExternalID(attributes),
+		Attributes: attributes,
+		Meta: scim.Meta{
+			Created:      &g.CreatedAt,
+			LastModified: &g.UpdatedAt,
+		},
+	}
+}
+
+// memberIDs returns the user IDs of the members of the group.
+func (g *Group) memberIDs() []int32 {
+	ids := make([]int32, 0, len(g.Members))
+	for _, member := range g.Members {
+		ids = append(ids, member.ID)
+	}
+	return ids
+}
+
+// extractMemberIDs extracts the user IDs of the group members from the given attributes.
+func extractMemberIDs(attributes scim.ResourceAttributes) ([]int32, error) {
+	items, _ := attributes[AttrMembers].([]interface{})
+	seen := make(map[int32]struct{}, len(items))
+	ids := make([]int32, 0, len(items))
+	for _, item := range items {
+		member, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		value, _ := member["value"].(string)
+		id, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, scimerrors.ScimErrorBadParams([]string{"invalid member " + strconv.Quote(value)})
+		}
+		if _, ok := seen[int32(id)]; ok {
+			continue
+		}
+		seen[int32(id)] = struct{}{}
+		ids = append(ids, int32(id))
+	}
+	return ids, nil
+}
+
+// getGroupFromDB returns the SCIM-controlled team with the given ID, along with its members.
+// When it fails, it returns an error that's safe to return to the client as a SCIM error.
+func getGroupFromDB(ctx context.Context, db database.DB, idStr string) (*Group, error) {
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		return nil, scimerrors.ScimErrorResourceNotFound(idStr)
+	}
+
+	team, err := db.Teams().GetTeamByID(ctx, int32(id))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, scimerrors.ScimErrorResourceNotFound(idStr)
+		}
+		return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	// Teams that are not provisioned through SCIM are not exposed as groups.
+	if !team.SCIMControlled {
+		return nil, scimerrors.ScimErrorResourceNotFound(idStr)
+	}
+
+	group, err := loadGroup(ctx, db, team)
+	if err != nil {
+		return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	return group, nil
+}
+
+// loadGroup returns a group for the given team, loading the members of the team.
+func loadGroup(ctx context.Context, db database.DB, team *types.Team) (*Group, error) {
+	members, _, err := db.Teams().ListTeamMembers(ctx, database.ListTeamMembersOpts{TeamID: team.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "list team members")
+	}
+	group := &Group{Team: *team}
+	if len(members) == 0 {
+		return group, nil
+	}
+
+	userIDs := make([]int32, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	group.Members, err = db.Users().List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+	if err != nil {
+		return nil, errors.Wrap(err, "list team members")
+	}
+	return group, nil
+}
+
+// createTeam creates a SCIM-controlled team for a group. The name of the team is derived from the
+// display name of the group, with a random suffix if the name is already taken.
+func createTeam(ctx context.Context, store database.TeamStore, displayName, externalID string) (*types.Team, error) {
+	name, err := auth.NormalizeUsername(displayName)
+	if err != nil {
+		// Empty name after normalization. Generate a random one, it's the best we can do.
+		name, err = auth.AddRandomSuffix("")
+		if err != nil {
+			return nil, scimerrors.ScimErrorBadParams([]string{"invalid displayName"})
+		}
+	}
+
+	team := &types.Team{
+		Name:           name,
+		DisplayName:    displayName,
+		ReadOnly:       true,
+		SCIMControlled: true,
+		SCIMExternalID: externalID,
+	}
+	created, err := store.CreateTeam(ctx, team)
+	if errors.Is(err, database.ErrTeamNameAlreadyExists) {
+		if team.Name, err = auth.AddRandomSuffix(name); err != nil {
+			return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "could not normalize team name").Error()}
+		}
+		created, err = store.CreateTeam(ctx, team)
+	}
+	if err != nil {
+		if errors.Is(err, database.ErrTeamNameAlreadyExists) {
+			return nil, scimerrors.ScimError{Status: http.StatusConflict, Detail: err.Error()}
+		}
+		return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	return created, nil
+}
+
+// setTeamMembers adds and removes members of the team such that exactly the users with the given
+// IDs are members. before are the IDs of the current members of the team.
+func setTeamMembers(ctx context.Context, db database.DB, teamID int32, before, after []int32) error {
+	added, removed := diffMemberIDs(before, after)
+
+	if len(added) > 0 {
+		// Only existing users can become members.
+		users, err := db.Users().List(ctx, &database.UsersListOptions{UserIDs: added})
+		if err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+		}
+		if len(users) != len(added) {
+			return scimerrors.ScimErrorBadParams([]string{"members contain unknown users"})
+		}
+
+		members := make([]*types.TeamMember, 0, len(added))
+		for _, id := range added {
+			members = append(members, &types.TeamMember{TeamID: teamID, UserID: id})
+		}
+		if err := db.Teams().CreateTeamMember(ctx, members...); err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+		}
+	}
+
+	if len(removed) > 0 {
+		members := make([]*types.TeamMember, 0, len(removed))
+		for _, id := range removed {
+			members = append(members, &types.TeamMember{TeamID: teamID, UserID: id})
+		}
+		if err := db.Teams().DeleteTeamMember(ctx, members...); err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+		}
+	}
+
+	return nil
+}
+
+// diffMemberIDs returns the IDs that are in after but not in before, and the IDs that are in
+// before but not in after.
+func diffMemberIDs(before, after []int32) (added, removed []int32) {
+	beforeSet := make(map[int32]struct{}, len(before))
+	for _, id := range before {
+		beforeSet[id] = struct{}{}
+	}
+	afterSet := make(map[int32]struct{}, len(after))
+	for _, id := range after {
+		afterSet[id] = struct{}{}
+		if _, ok := beforeSet[id]; !ok {
+			added = append(added, id)
+		}
+	}
+	for _, id := range before {
+		if _, ok := afterSet[id]; !ok {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
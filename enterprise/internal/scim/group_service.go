@@ -0,0 +1,295 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const AttrMembers = "members"
+
+// NewGroupResourceHandler returns a new ResourceHandler for groups, which are mapped to teams.
+func NewGroupResourceHandler(ctx context.Context, observationCtx *observation.Context, db database.DB) *ResourceHandler {
+	groupSCIMService := &GroupSCIMService{
+		db: db,
+	}
+	return &ResourceHandler{
+		ctx:              ctx,
+		observationCtx:   observationCtx,
+		coreSchema:       groupSCIMService.Schema(),
+		schemaExtensions: groupSCIMService.SchemaExtensions(),
+		service:          groupSCIMService,
+	}
+}
+
+type GroupSCIMService struct {
+	db database.DB
+}
+
+func (g *GroupSCIMService) getLogger() log.Logger {
+	return log.Scoped("scim.group", "scim service for group")
+}
+
+func (g *GroupSCIMService) Get(ctx context.Context, id string) (scim.Resource, error) {
+	group, err := getGroupFromDB(ctx, g.db, id)
+	if err != nil {
+		return scim.Resource{}, err
+	}
+	return group.ToResource(), nil
+}
+
+func (g *GroupSCIMService) GetAll(ctx context.Context, start int, count *int) (totalCount int, entities []scim.Resource, err error) {
+	// Calculate offset
+	var offset int
+	if start > 0 {
+		offset = start - 1
+	}
+
+	opts := database.ListTeamsOpts{SCIMControlled: true}
+	if count != nil {
+		opts.LimitOffset = &database.LimitOffset{Limit: *count, Offset: offset}
+	}
+	teams, _, err := g.db.Teams().ListTeams(ctx, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+	entities = make([]scim.Resource, 0, len(teams))
+	for _, team := range teams {
+		group, err := loadGroup(ctx, g.db, team)
+		if err != nil {
+			return 0, nil, err
+		}
+		entities = append(entities, group.ToResource())
+	}
+
+	// Get total count
+	if count == nil {
+		return len(teams), entities, nil
+	}
+	total, err := g.db.Teams().CountTeams(ctx, database.ListTeamsOpts{SCIMControlled: true})
+	return int(total), entities, err
+}
+
+func (g *GroupSCIMService) Update(ctx context.Context, id string, applySCIMUpdates func(getResource func() scim.Resource) (updated scim.Resource, _ error)) (finalResource scim.Resource, _ error) {
+	var resourceAfterUpdate scim.Resource
+	err := g.db.WithTransact(ctx, func(tx database.DB) error {
+		group, txErr := getGroupFromDB(ctx, tx, id)
+		if txErr != nil {
+			return txErr
+		}
+
+		resourceAfterUpdate, txErr = applySCIMUpdates(group.ToResource)
+		if txErr != nil {
+			return txErr
+		}
+
+		displayName := extractStringAttribute(resourceAfterUpdate.Attributes, AttrDisplayName)
+		if displayName == "" {
+			return scimerrors.ScimErrorBadParams([]string{"displayName missing"})
+		}
+		externalID := extractStringAttribute(resourceAfterUpdate.Attributes, AttrExternalId)
+		memberIDs, txErr := extractMemberIDs(resourceAfterUpdate.Attributes)
+		if txErr != nil {
+			return txErr
+		}
+
+		if displayName != group.DisplayName || externalID != group.SCIMExternalID {
+			team := group.Team
+			team.DisplayName = displayName
+			team.SCIMExternalID = externalID
+			if txErr = tx.Teams().UpdateTeam(ctx, &team); txErr != nil {
+				return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: txErr.Error()}
+			}
+		}
+		if txErr = setTeamMembers(ctx, tx, group.ID, group.memberIDs(), memberIDs); txErr != nil {
+			return txErr
+		}
+		if txErr = g.syncOrgMembers(ctx, tx, group.ID, group.DisplayName, displayName, group.memberIDs(), memberIDs); txErr != nil {
+			return txErr
+		}
+
+		// Return the group as it is stored, including the display names of new members.
+		group, txErr = getGroupFromDB(ctx, tx, id)
+		if txErr != nil {
+			return txErr
+		}
+		resourceAfterUpdate = group.ToResource()
+		return nil
+	})
+
+	if err != nil {
+		multiErr, ok := err.(errors.MultiError)
+		if !ok || len(multiErr.Errors()) == 0 {
+			return scim.Resource{}, err
+		}
+		return scim.Resource{}, multiErr.Errors()[len(multiErr.Errors())-1]
+	}
+	return resourceAfterUpdate, nil
+}
+
+func (g *GroupSCIMService) Create(ctx context.Context, attributes scim.ResourceAttributes) (scim.Resource, error) {
+	displayName := extractStringAttribute(attributes, AttrDisplayName)
+	if displayName == "" {
+		return scim.Resource{}, scimerrors.ScimErrorBadParams([]string{"displayName missing"})
+	}
+	memberIDs, err := extractMemberIDs(attributes)
+	if err != nil {
+		return scim.Resource{}, err
+	}
+
+	var group *Group
+	err = g.db.WithTransact(ctx, func(tx database.DB) error {
+		team, err := createTeam(ctx, tx.Teams(), displayName, extractStringAttribute(attributes, AttrExternalId))
+		if err != nil {
+			return err
+		}
+		if err := setTeamMembers(ctx, tx, team.ID, nil, memberIDs); err != nil {
+			return err
+		}
+		if err := g.syncOrgMembers(ctx, tx, team.ID, "", displayName, nil, memberIDs); err != nil {
+			return err
+		}
+
+		group, err = getGroupFromDB(ctx, tx, strconv.Itoa(int(team.ID)))
+		return err
+	})
+	if err != nil {
+		multiErr, ok := err.(errors.MultiError)
+		if !ok || len(multiErr.Errors()) == 0 {
+			return scim.Resource{}, err
+		}
+		return scim.Resource{}, multiErr.Errors()[len(multiErr.Errors())-1]
+	}
+
+	return group.ToResource(), nil
+}
+
+func (g *GroupSCIMService) Delete(ctx context.Context, id string) error {
+	err := g.db.WithTransact(ctx, func(tx database.DB) error {
+		group, err := getGroupFromDB(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// Members of the group leave the organization of the group along with the team.
+		if err := g.syncOrgMembers(ctx, tx, group.ID, group.DisplayName, "", group.memberIDs(), nil); err != nil {
+			return err
+		}
+		return tx.Teams().DeleteTeam(ctx, group.ID)
+	})
+	if err != nil {
+		return errors.Wrap(err, "delete group")
+	}
+
+	return nil
+}
+
+// Organizations
+
+// groupOrganization returns the organization that the group with the given display name is mapped
+// to in the site config, or nil if the group is not mapped to an existing organization.
+func (g *GroupSCIMService) groupOrganization(ctx context.Context, db database.DB, displayName string) (*types.Org, error) {
+	orgName, ok := conf.Get().ScimGroupOrganizations[displayName]
+	if displayName == "" || !ok {
+		return nil, nil
+	}
+
+	org, err := db.Orgs().GetByName(ctx, orgName)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			g.getLogger().Warn("organization of SCIM group not found", log.String("group", displayName), log.String("org", orgName))
+			return nil, nil
+		}
+		return nil, err
+	}
+	return org, nil
+}
+
+// syncOrgMembers adds and removes members of the organizations that a group is mapped to, such
+// that the members of the group after an update are members of the organization of the group.
+// Members of the group before the update are removed from the organization of the group before
+// the update if they are no longer members, or if the group is mapped to another organization.
+//
+// Memberships that SCIM creates are marked as such, and only those are removed again: members
+// who joined the organization otherwise, or who are members of another SCIM group that is mapped
+// to the organization, stay members.
+func (g *GroupSCIMService) syncOrgMembers(ctx context.Context, db database.DB, teamID int32, displayNameBefore, displayNameAfter string, before, after []int32) error {
+	orgBefore, err := g.groupOrganization(ctx, db, displayNameBefore)
+	if err != nil {
+		return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	orgAfter, err := g.groupOrganization(ctx, db, displayNameAfter)
+	if err != nil {
+		return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+
+	added, removed := diffMemberIDs(before, after)
+	if orgBefore == nil || orgAfter == nil || orgBefore.ID != orgAfter.ID {
+		added, removed = after, before
+	}
+
+	if orgBefore != nil {
+		for _, userID := range removed {
+			if err := removeSCIMOrgMember(ctx, db, orgBefore, teamID, userID); err != nil {
+				return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+			}
+		}
+	}
+	if orgAfter != nil {
+		for _, userID := range added {
+			_, err := db.OrgMembers().GetByOrgIDAndUserID(ctx, orgAfter.ID, userID)
+			if err == nil {
+				continue
+			}
+			if !errcode.IsNotFound(err) {
+				return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+			}
+			if _, err := db.OrgMembers().CreateSCIMControlled(ctx, orgAfter.ID, userID); err != nil {
+				return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+			}
+		}
+	}
+
+	return nil
+}
+
+// removeSCIMOrgMember removes a user who left the group of the team with the given ID from the
+// organization of the group, if the membership was created by SCIM and no other SCIM group that
+// is mapped to the organization contains the user.
+func removeSCIMOrgMember(ctx context.Context, db database.DB, org *types.Org, teamID, userID int32) error {
+	membership, err := db.OrgMembers().GetByOrgIDAndUserID(ctx, org.ID, userID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !membership.SCIMControlled {
+		return nil
+	}
+
+	teams, _, err := db.Teams().ListTeams(ctx, database.ListTeamsOpts{ForUserMember: userID, SCIMControlled: true})
+	if err != nil {
+		return err
+	}
+	groupOrganizations := conf.Get().ScimGroupOrganizations
+	for _, team := range teams {
+		if orgName, ok := groupOrganizations[team.DisplayName]; ok && team.ID != teamID && team.DisplayName != "" && orgName == org.Name {
+			return nil
+		}
+	}
+
+	return db.OrgMembers().Remove(ctx, org.ID, userID)
+}
@@ -0,0 +1,191 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/elimity-com/scim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// createMockGroupDB returns a mock database with three users, a SCIM-controlled team with the
+// first two users as members, a team that is not SCIM-controlled, and an organization.
+func createMockGroupDB() (db *database.MockDB, teamMembers, orgMembers, scimOrgMembers map[int32][]int32) {
+	db = getMockDB([]*types.UserForSCIM{
+		{User: types.User{ID: 1, Username: "user1"}},
+		{User: types.User{ID: 2, Username: "user2"}},
+		{User: types.User{ID: 3, Username: "user3"}},
+	}, map[int32][]*database.UserEmail{})
+	teamMembers = map[int32][]int32{1: {1, 2}}
+	orgMembers = map[int32][]int32{}
+	scimOrgMembers = map[int32][]int32{}
+	addMockTeams(db, []*types.Team{
+		{ID: 1, Name: "engineering", DisplayName: "Engineering", ReadOnly: true, SCIMControlled: true, SCIMExternalID: "eng"},
+		{ID: 2, Name: "local-team", DisplayName: "Local team"},
+	}, teamMembers, []*types.Org{{ID: 1, Name: "eng-org"}}, orgMembers, scimOrgMembers)
+	return db, teamMembers, orgMembers, scimOrgMembers
+}
+
+func TestGroupResourceHandler_Get(t *testing.T) {
+	db, _, _, _ := createMockGroupDB()
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	group, err := groupResourceHandler.Get(&http.Request{}, "1")
+	require.NoError(t, err)
+	assert.Equal(t, "1", group.ID)
+	assert.Equal(t, "eng", group.ExternalID.Value())
+	assert.Equal(t, "Engineering", group.Attributes[AttrDisplayName])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"value": "1", "display": "user1"},
+		map[string]interface{}{"value": "2", "display": "user2"},
+	}, group.Attributes[AttrMembers])
+
+	// Teams that are not provisioned through SCIM are not groups.
+	_, err = groupResourceHandler.Get(&http.Request{}, "2")
+	assert.Error(t, err)
+
+	page, err := groupResourceHandler.GetAll(&http.Request{}, scim.ListRequestParams{StartIndex: 1, Count: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, page.TotalResults)
+	require.Len(t, page.Resources, 1)
+	assert.Equal(t, "1", page.Resources[0].ID)
+}
+
+func TestGroupResourceHandler_Create(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ScimGroupOrganizations: map[string]string{"Platform Team": "eng-org"},
+	}})
+	defer conf.Mock(nil)
+
+	db, teamMembers, orgMembers, scimOrgMembers := createMockGroupDB()
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	group, err := groupResourceHandler.Create(createDummyRequest(), scim.ResourceAttributes{
+		AttrDisplayName: "Platform Team",
+		AttrExternalId:  "platform",
+		AttrMembers: []interface{}{
+			map[string]interface{}{"value": "2"},
+			map[string]interface{}{"value": "3"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "3", group.ID)
+	assert.Equal(t, "platform", group.ExternalID.Value())
+	assert.Equal(t, "Platform Team", group.Attributes[AttrDisplayName])
+	assert.Equal(t, []int32{2, 3}, teamMembers[3])
+	assert.Equal(t, []int32{2, 3}, orgMembers[1])
+	assert.Equal(t, []int32{2, 3}, scimOrgMembers[1])
+
+	team, err := db.Teams().GetTeamByID(context.Background(), 3)
+	require.NoError(t, err)
+	assert.Equal(t, "Platform-Team", team.Name)
+	assert.True(t, team.ReadOnly)
+	assert.True(t, team.SCIMControlled)
+
+	// Members must be existing users.
+	_, err = groupResourceHandler.Create(createDummyRequest(), scim.ResourceAttributes{
+		AttrDisplayName: "Unknown members",
+		AttrMembers:     []interface{}{map[string]interface{}{"value": "42"}},
+	})
+	assert.Error(t, err)
+}
+
+func TestGroupResourceHandler_Patch(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ScimGroupOrganizations: map[string]string{"Engineering": "eng-org"},
+	}})
+	defer conf.Mock(nil)
+
+	db, teamMembers, orgMembers, scimOrgMembers := createMockGroupDB()
+	orgMembers[1] = []int32{1, 2}
+	scimOrgMembers[1] = []int32{1, 2}
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	group, err := groupResourceHandler.Patch(createDummyRequest(), "1", []scim.PatchOperation{
+		{Op: "add", Path: createPath(AttrMembers, nil), Value: []interface{}{map[string]interface{}{"value": "3"}}},
+		{Op: "remove", Path: parseStringPath(`members[value eq "1"]`)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"value": "2", "display": "user2"},
+		map[string]interface{}{"value": "3", "display": "user3"},
+	}, group.Attributes[AttrMembers])
+	assert.Equal(t, []int32{2, 3}, teamMembers[1])
+	assert.Equal(t, []int32{2, 3}, orgMembers[1])
+
+	// Renaming the group to a name that is not mapped removes the members from the organization.
+	group, err = groupResourceHandler.Patch(createDummyRequest(), "1", []scim.PatchOperation{
+		{Op: "replace", Path: createPath(AttrDisplayName, nil), Value: "Engineering (old)"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Engineering (old)", group.Attributes[AttrDisplayName])
+	assert.Equal(t, []int32{2, 3}, teamMembers[1])
+	assert.Empty(t, orgMembers[1])
+}
+
+func TestGroupResourceHandler_Replace(t *testing.T) {
+	db, teamMembers, _, _ := createMockGroupDB()
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	group, err := groupResourceHandler.Replace(createDummyRequest(), "1", scim.ResourceAttributes{
+		AttrDisplayName: "Engineering",
+		AttrMembers:     []interface{}{map[string]interface{}{"value": "3"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "", group.ExternalID.Value())
+	assert.Equal(t, []int32{3}, teamMembers[1])
+}
+
+func TestGroupResourceHandler_Delete(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ScimGroupOrganizations: map[string]string{"Engineering": "eng-org"},
+	}})
+	defer conf.Mock(nil)
+
+	db, _, orgMembers, scimOrgMembers := createMockGroupDB()
+	orgMembers[1] = []int32{1, 2, 3}
+	scimOrgMembers[1] = []int32{1, 2}
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	require.NoError(t, groupResourceHandler.Delete(&http.Request{}, "1"))
+	// The third user joined the organization without SCIM.
+	assert.Equal(t, []int32{3}, orgMembers[1])
+	_, err := groupResourceHandler.Get(&http.Request{}, "1")
+	assert.Error(t, err)
+
+	// Teams that are not provisioned through SCIM can't be deleted.
+	assert.Error(t, groupResourceHandler.Delete(&http.Request{}, "2"))
+}
+
+func TestGroupResourceHandler_OrgMembersOfOtherGroups(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ScimGroupOrganizations: map[string]string{"Engineering": "eng-org", "Platform Team": "eng-org"},
+	}})
+	defer conf.Mock(nil)
+
+	db, _, orgMembers, scimOrgMembers := createMockGroupDB()
+	orgMembers[1] = []int32{1, 2}
+	scimOrgMembers[1] = []int32{1, 2}
+	groupResourceHandler := NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+
+	_, err := groupResourceHandler.Create(createDummyRequest(), scim.ResourceAttributes{
+		AttrDisplayName: "Platform Team",
+		AttrMembers:     []interface{}{map[string]interface{}{"value": "2"}},
+	})
+	require.NoError(t, err)
+
+	// The second user is still a member of another group that is mapped to the organization.
+	require.NoError(t, groupResourceHandler.Delete(&http.Request{}, "1"))
+	assert.Equal(t, []int32{2}, orgMembers[1])
+
+	require.NoError(t, groupResourceHandler.Delete(&http.Request{}, "3"))
+	assert.Empty(t, orgMembers[1])
+}
@@ -62,9 +62,11 @@ func newHandler(ctx context.Context, db database.DB, observationCtx *observation
 	}
 
 	var userResourceHandler = NewUserResourceHandler(ctx, observationCtx, db)
+	var groupResourceHandler = NewGroupResourceHandler(ctx, observationCtx, db)
 
 	resourceTypes := []scim.ResourceType{
 		createResourceType("User", "/Users", "User Account", userResourceHandler),
+		createResourceType("Group", "/Groups", "Group", groupResourceHandler),
 	}
 
 	server := scim.Server{
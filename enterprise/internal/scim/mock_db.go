@@ -44,6 +44,17 @@ func getMockDB(users []*types.UserForSCIM, usersEmails map[int32][]*database.Use
 		return applyLimitOffset(users, opt.LimitOffset)
 	})
 	userStore.CountForSCIMFunc.SetDefaultReturn(len(users), nil)
+	userStore.ListFunc.SetDefaultHook(func(ctx context.Context, opt *database.UsersListOptions) ([]*types.User, error) {
+		var filteredUsers []*types.User
+		for _, user := range users {
+			for _, id := range opt.UserIDs {
+				if user.ID == id {
+					filteredUsers = append(filteredUsers, &user.User)
+				}
+			}
+		}
+		return filteredUsers, nil
+	})
 	userStore.GetByUsernameFunc.SetDefaultHook(func(ctx context.Context, username string) (*types.User, error) {
 		for _, user := range users {
 			if user.Username == username {
@@ -220,6 +231,168 @@ func getMockDB(users []*types.UserForSCIM, usersEmails map[int32][]*database.Use
 	return db
 }
 
+// addMockTeams adds teams and organizations to the given mock database. Members of teams and
+// organizations are keyed by team and organization ID, and the maps are updated in place.
+// scimOrgMembers holds the subset of organization members whose membership was granted
+// through SCIM.
+// Note: IDs of teams must be ascending.
+func addMockTeams(db *database.MockDB, teams []*types.Team, teamMembers map[int32][]int32, orgs []*types.Org, orgMembers, scimOrgMembers map[int32][]int32) {
+	teamStore := database.NewMockTeamStore()
+	teamStore.GetTeamByIDFunc.SetDefaultHook(func(ctx context.Context, id int32) (*types.Team, error) {
+		for _, team := range teams {
+			if team.ID == id {
+				t := *team
+				return &t, nil
+			}
+		}
+		return nil, database.TeamNotFoundError{}
+	})
+	teamStore.ListTeamsFunc.SetDefaultHook(func(ctx context.Context, opts database.ListTeamsOpts) ([]*types.Team, int32, error) {
+		var filteredTeams []*types.Team
+		for _, team := range teams {
+			if opts.SCIMControlled && !team.SCIMControlled {
+				continue
+			}
+			if opts.ForUserMember != 0 && !containsID(teamMembers[team.ID], opts.ForUserMember) {
+				continue
+			}
+			filteredTeams = append(filteredTeams, team)
+		}
+		if opts.LimitOffset != nil {
+			start := opts.Offset
+			end := start + opts.Limit
+			if end > len(filteredTeams) {
+				end = len(filteredTeams)
+			}
+			filteredTeams = filteredTeams[start:end]
+		}
+		return filteredTeams, 0, nil
+	})
+	teamStore.CountTeamsFunc.SetDefaultHook(func(ctx context.Context, opts database.ListTeamsOpts) (int32, error) {
+		var count int32
+		for _, team := range teams {
+			if !opts.SCIMControlled || team.SCIMControlled {
+				count++
+			}
+		}
+		return count, nil
+	})
+	teamStore.ListTeamMembersFunc.SetDefaultHook(func(ctx context.Context, opts database.ListTeamMembersOpts) ([]*types.TeamMember, *database.TeamMemberListCursor, error) {
+		var members []*types.TeamMember
+		for _, userID := range teamMembers[opts.TeamID] {
+			members = append(members, &types.TeamMember{TeamID: opts.TeamID, UserID: userID})
+		}
+		return members, nil, nil
+	})
+	teamStore.CreateTeamFunc.SetDefaultHook(func(ctx context.Context, team *types.Team) (*types.Team, error) {
+		nextID := int32(1)
+		for _, t := range teams {
+			if strings.EqualFold(t.Name, team.Name) {
+				return nil, database.ErrTeamNameAlreadyExists
+			}
+			nextID = t.ID + 1
+		}
+		for _, org := range orgs {
+			if strings.EqualFold(org.Name, team.Name) {
+				return nil, database.ErrTeamNameAlreadyExists
+			}
+		}
+		teamToCreate := *team
+		teamToCreate.ID = nextID
+		teams = append(teams, &teamToCreate)
+		return &teamToCreate, nil
+	})
+	teamStore.UpdateTeamFunc.SetDefaultHook(func(ctx context.Context, team *types.Team) error {
+		for _, t := range teams {
+			if t.ID == team.ID {
+				t.DisplayName = team.DisplayName
+				t.SCIMExternalID = team.SCIMExternalID
+				return nil
+			}
+		}
+		return database.TeamNotFoundError{}
+	})
+	teamStore.DeleteTeamFunc.SetDefaultHook(func(ctx context.Context, id int32) error {
+		for i, t := range teams {
+			if t.ID == id {
+				teams = append(teams[:i], teams[i+1:]...)
+				delete(teamMembers, id)
+				return nil
+			}
+		}
+		return database.TeamNotFoundError{}
+	})
+	teamStore.CreateTeamMemberFunc.SetDefaultHook(func(ctx context.Context, members ...*types.TeamMember) error {
+		for _, member := range members {
+			teamMembers[member.TeamID] = append(teamMembers[member.TeamID], member.UserID)
+		}
+		return nil
+	})
+	teamStore.DeleteTeamMemberFunc.SetDefaultHook(func(ctx context.Context, members ...*types.TeamMember) error {
+		for _, member := range members {
+			teamMembers[member.TeamID] = removeID(teamMembers[member.TeamID], member.UserID)
+		}
+		return nil
+	})
+
+	orgStore := database.NewMockOrgStore()
+	orgStore.GetByNameFunc.SetDefaultHook(func(ctx context.Context, name string) (*types.Org, error) {
+		for _, org := range orgs {
+			if org.Name == name {
+				return org, nil
+			}
+		}
+		return nil, &database.OrgNotFoundError{}
+	})
+
+	orgMemberStore := database.NewMockOrgMemberStore()
+	orgMemberStore.GetByOrgIDAndUserIDFunc.SetDefaultHook(func(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		if !containsID(orgMembers[orgID], userID) {
+			return nil, &database.ErrOrgMemberNotFound{}
+		}
+		return &types.OrgMembership{OrgID: orgID, UserID: userID, SCIMControlled: containsID(scimOrgMembers[orgID], userID)}, nil
+	})
+	orgMemberStore.CreateFunc.SetDefaultHook(func(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		orgMembers[orgID] = append(orgMembers[orgID], userID)
+		return &types.OrgMembership{OrgID: orgID, UserID: userID}, nil
+	})
+	orgMemberStore.CreateSCIMControlledFunc.SetDefaultHook(func(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		orgMembers[orgID] = append(orgMembers[orgID], userID)
+		scimOrgMembers[orgID] = append(scimOrgMembers[orgID], userID)
+		return &types.OrgMembership{OrgID: orgID, UserID: userID, SCIMControlled: true}, nil
+	})
+	orgMemberStore.RemoveFunc.SetDefaultHook(func(ctx context.Context, orgID, userID int32) error {
+		orgMembers[orgID] = removeID(orgMembers[orgID], userID)
+		scimOrgMembers[orgID] = removeID(scimOrgMembers[orgID], userID)
+		return nil
+	})
+
+	db.TeamsFunc.SetDefaultReturn(teamStore)
+	db.OrgsFunc.SetDefaultReturn(orgStore)
+	db.OrgMembersFunc.SetDefaultReturn(orgMemberStore)
+}
+
+// containsID returns true if id is one of the given IDs.
+func containsID(ids []int32, id int32) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// removeID returns the given IDs without id.
+func removeID(ids []int32, id int32) []int32 {
+	remaining := make([]int32, 0, len(ids))
+	for _, i := range ids {
+		if i != id {
+			remaining = append(remaining, i)
+		}
+	}
+	return remaining
+}
+
 // applyLimitOffset returns a slice of users based on the limit and offset
 func applyLimitOffset(users []*types.UserForSCIM, limitOffset *database.LimitOffset) ([]*types.UserForSCIM, error) {
 	// Return all users
@@ -44,3 +44,13 @@ func PackageFiltersJob(
 		background.NewPackagesFilterApplicator(obsctx, db),
 	}
 }
+
+func LockfileIndexerJob(
+	obsctx *observation.Context,
+	db database.DB,
+	gitserverClient gitserver.Client,
+) goroutine.CombinedRoutine {
+	return []goroutine.BackgroundRoutine{
+		background.NewLockfileIndexer(obsctx, db, gitserverClient),
+	}
+}
@@ -0,0 +1,146 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/lockfiles"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// lockfileIndexerBatchSize is the number of repositories indexed per run.
+	lockfileIndexerBatchSize = 100
+	// lockfileReindexInterval is the minimum time between two runs over the same repository.
+	lockfileReindexInterval = 12 * time.Hour
+)
+
+type lockfileIndexerJob struct {
+	store      store.Store
+	gitClient  gitserver.Client
+	operations *operations
+	logger     log.Logger
+}
+
+// NewLockfileIndexer returns a routine that periodically extracts the dependencies declared by
+// the lockfiles on the default branch of every cloned repository, backing the
+// `repo:has.dependency()` search predicate.
+func NewLockfileIndexer(
+	obsctx *observation.Context,
+	db database.DB,
+	gitClient gitserver.Client,
+) goroutine.BackgroundRoutine {
+	job := lockfileIndexerJob{
+		store:      store.New(obsctx, db),
+		gitClient:  gitClient,
+		operations: newOperations(obsctx),
+		logger:     obsctx.Logger.Scoped("lockfileIndexer", "extracts dependencies from repository lockfiles"),
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		actor.WithInternalActor(context.Background()),
+		goroutine.HandlerFunc(job.handle),
+		goroutine.WithName("codeintel.lockfile-indexer"),
+		goroutine.WithDescription("extracts the dependencies declared by lockfiles on the default branch of repositories"),
+		goroutine.WithInterval(time.Minute),
+	)
+}
+
+func (j *lockfileIndexerJob) handle(ctx context.Context) (err error) {
+	ctx, _, endObservation := j.operations.handleLockfileIndexer.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	candidates, err := j.store.ListLockfileIndexCandidates(ctx, time.Now().Add(-lockfileReindexInterval), lockfileIndexerBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "failed to list lockfile index candidates")
+	}
+
+	for _, candidate := range candidates {
+		if err := j.indexRepository(ctx, candidate); err != nil {
+			if errors.Is(err, ctx.Err()) {
+				return err
+			}
+
+			// Don't let a single broken repository block the rest of the batch.
+			j.logger.Warn("failed to index lockfiles",
+				log.String("repo", candidate.RepositoryName),
+				log.Error(err),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (j *lockfileIndexerJob) indexRepository(ctx context.Context, candidate shared.LockfileIndexCandidate) (err error) {
+	ctx, _, endObservation := j.operations.indexLockfiles.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("repo", candidate.RepositoryName),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	repo := api.RepoName(candidate.RepositoryName)
+
+	_, commit, err := j.gitClient.GetDefaultBranch(ctx, repo, true)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve default branch")
+	}
+	if commit == "" {
+		// Empty repository; record the visit so we don't retry until the next interval.
+		return j.store.UpsertLockfileReferences(ctx, candidate.RepositoryID, "", nil)
+	}
+	if string(commit) == candidate.Commit {
+		return j.store.MarkLockfileIndexed(ctx, candidate.RepositoryID)
+	}
+
+	names := lockfiles.Names()
+	pathspecs := make([]gitdomain.Pathspec, 0, len(names))
+	for _, name := range names {
+		pathspecs = append(pathspecs, gitdomain.PathspecSuffix(name))
+	}
+
+	paths, err := j.gitClient.LsFiles(ctx, authz.DefaultSubRepoPermsChecker, repo, commit, pathspecs...)
+	if err != nil {
+		return errors.Wrap(err, "failed to list lockfiles")
+	}
+
+	deps := make(map[string][]shared.MinimialVersionedPackageRepo, len(paths))
+	for _, path := range paths {
+		// Suffix pathspecs also match e.g. `not-a-yarn.lock`.
+		if !lockfiles.IsLockfile(path) {
+			continue
+		}
+
+		contents, err := j.gitClient.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, repo, commit, path)
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %q", path)
+		}
+
+		pkgs, err := lockfiles.Parse(path, bytes.NewReader(contents))
+		if err != nil {
+			// A malformed lockfile shouldn't prevent indexing the others.
+			j.logger.Debug("skipping unparseable lockfile",
+				log.String("repo", candidate.RepositoryName),
+				log.String("path", path),
+				log.Error(err),
+			)
+			continue
+		}
+		deps[path] = pkgs
+	}
+
+	return j.store.UpsertLockfileReferences(ctx, candidate.RepositoryID, string(commit), deps)
+}
@@ -13,6 +13,8 @@ import (
 type operations struct {
 	handleCrateSyncer        *observation.Operation
 	packagesFilterApplicator *observation.Operation
+	handleLockfileIndexer    *observation.Operation
+	indexLockfiles           *observation.Operation
 
 	packagesUpdated prometheus.Counter
 	versionsUpdated prometheus.Counter
@@ -64,6 +66,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 	return &operations{
 		handleCrateSyncer:        op("HandleCrateSyncer"),
 		packagesFilterApplicator: op("HandlePackagesFilterApplicator"),
+		handleLockfileIndexer:    op("HandleLockfileIndexer"),
+		indexLockfiles:           op("IndexLockfiles"),
 
 		packagesUpdated: counter(
 			"src_codeintel_background_filtered_packages_updated",
@@ -0,0 +1,186 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ListLockfileIndexCandidates returns cloned repositories whose lockfiles have never been indexed
+// or were last indexed before the given time, least recently indexed first.
+func (s *store) ListLockfileIndexCandidates(ctx context.Context, indexedBefore time.Time, limit int) (candidates []shared.LockfileIndexCandidate, err error) {
+	ctx, _, endObservation := s.operations.listLockfileIndexCandidates.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("limit", limit),
+	}})
+	defer func() {
+		endObservation(1, observation.Args{Attrs: []attribute.KeyValue{
+			attribute.Int("numCandidates", len(candidates)),
+		}})
+	}()
+
+	return basestore.NewSliceScanner(scanLockfileIndexCandidate)(s.db.Query(ctx, sqlf.Sprintf(listLockfileIndexCandidatesQuery, indexedBefore, limit)))
+}
+
+const listLockfileIndexCandidatesQuery = `
+SELECT r.id, r.name, li.commit_bytea
+FROM repo r
+JOIN gitserver_repos gr ON gr.repo_id = r.id
+LEFT JOIN codeintel_lockfile_indexes li ON li.repository_id = r.id
+WHERE
+	r.deleted_at IS NULL AND
+	r.blocked IS NULL AND
+	gr.clone_status = 'cloned' AND
+	(li.indexed_at IS NULL OR li.indexed_at < %s)
+ORDER BY li.indexed_at NULLS FIRST, r.id
+LIMIT %s
+`
+
+func scanLockfileIndexCandidate(s dbutil.Scanner) (candidate shared.LockfileIndexCandidate, err error) {
+	var commit dbutil.CommitBytea
+	if err := s.Scan(&candidate.RepositoryID, &candidate.RepositoryName, &commit); err != nil {
+		return shared.LockfileIndexCandidate{}, err
+	}
+	candidate.Commit = string(commit)
+	return candidate, nil
+}
+
+// MarkLockfileIndexed bumps the time at which the lockfiles of the given repository were last
+// indexed without changing the recorded dependencies. This is used when the indexed revision has
+// not changed since the previous run.
+func (s *store) MarkLockfileIndexed(ctx context.Context, repositoryID int) (err error) {
+	ctx, _, endObservation := s.operations.markLockfileIndexed.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.db.Exec(ctx, sqlf.Sprintf(markLockfileIndexedQuery, repositoryID))
+}
+
+const markLockfileIndexedQuery = `
+UPDATE codeintel_lockfile_indexes
+SET indexed_at = NOW()
+WHERE repository_id = %s
+`
+
+// UpsertLockfileReferences replaces the dependencies recorded for the given repository with the
+// dependencies declared by the given lockfiles at the given commit. The lockfiles map is keyed by
+// the path of the lockfile relative to the repository root.
+func (s *store) UpsertLockfileReferences(ctx context.Context, repositoryID int, commit string, lockfiles map[string][]shared.MinimialVersionedPackageRepo) (err error) {
+	ctx, _, endObservation := s.operations.upsertLockfileReferences.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", repositoryID),
+		attribute.String("commit", commit),
+		attribute.Int("numLockfiles", len(lockfiles)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.db.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	indexID, _, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(upsertLockfileIndexQuery, repositoryID, dbutil.CommitBytea(commit))))
+	if err != nil {
+		return errors.Wrap(err, "failed to upsert lockfile index")
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(deleteLockfileReferencesQuery, indexID)); err != nil {
+		return errors.Wrap(err, "failed to delete stale lockfile references")
+	}
+
+	paths := make([]string, 0, len(lockfiles))
+	for path := range lockfiles {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	err = batch.WithInserter(
+		ctx,
+		tx.Handle(),
+		"codeintel_lockfile_references",
+		batch.MaxNumPostgresParameters,
+		[]string{"lockfile_index_id", "repository_id", "lockfile", "scheme", "name", "version"},
+		func(inserter *batch.Inserter) error {
+			for _, path := range paths {
+				for _, dep := range lockfiles[path] {
+					if err := inserter.Insert(ctx, indexID, repositoryID, path, dep.Scheme, dep.Name, dep.Version); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert lockfile references")
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(linkLockfileReferencesQuery, indexID)); err != nil {
+		return errors.Wrap(err, "failed to link lockfile references to package repo references")
+	}
+
+	return nil
+}
+
+const upsertLockfileIndexQuery = `
+INSERT INTO codeintel_lockfile_indexes (repository_id, commit_bytea, indexed_at)
+VALUES (%s, %s, NOW())
+ON CONFLICT (repository_id) DO UPDATE
+SET
+	commit_bytea = EXCLUDED.commit_bytea,
+	indexed_at = EXCLUDED.indexed_at
+RETURNING id
+`
+
+const deleteLockfileReferencesQuery = `
+DELETE FROM codeintel_lockfile_references
+WHERE lockfile_index_id = %s
+`
+
+const linkLockfileReferencesQuery = `
+UPDATE codeintel_lockfile_references lr
+SET package_repo_ref_id = dr.id
+FROM lsif_dependency_repos dr
+WHERE
+	lr.lockfile_index_id = %s AND
+	dr.scheme = lr.scheme AND
+	dr.name = lr.name
+`
+
+// ListLockfileDependencyVersions returns the distinct versions of the given package that are
+// declared by the lockfile of any repository. An empty scheme matches packages of every scheme.
+func (s *store) ListLockfileDependencyVersions(ctx context.Context, scheme, name string) (versions []string, err error) {
+	ctx, _, endObservation := s.operations.listLockfileDependencyVersions.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("scheme", scheme),
+		attribute.String("name", name),
+	}})
+	defer func() {
+		endObservation(1, observation.Args{Attrs: []attribute.KeyValue{
+			attribute.Int("numVersions", len(versions)),
+		}})
+	}()
+
+	conds := []*sqlf.Query{sqlf.Sprintf("name = %s", name)}
+	if scheme != "" {
+		conds = append(conds, sqlf.Sprintf("scheme = %s", scheme))
+	}
+
+	return basestore.ScanStrings(s.db.Query(ctx, sqlf.Sprintf(listLockfileDependencyVersionsQuery, sqlf.Join(conds, "AND"))))
+}
+
+const listLockfileDependencyVersionsQuery = `
+SELECT DISTINCT version
+FROM codeintel_lockfile_references
+WHERE %s
+ORDER BY version
+`
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestLockfileReferences(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	logger := logtest.Scoped(t)
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+
+	for _, query := range []string{
+		`INSERT INTO repo (id, name) VALUES (1, 'github.com/foo/bar'), (2, 'github.com/foo/baz'), (3, 'github.com/foo/bonk')`,
+		`UPDATE gitserver_repos SET clone_status = 'cloned' WHERE repo_id IN (1, 2)`,
+		`INSERT INTO lsif_dependency_repos (id, scheme, name) VALUES (42, 'npm', 'lodash')`,
+	} {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	candidates, err := store.ListLockfileIndexCandidates(ctx, time.Now(), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCandidates := []shared.LockfileIndexCandidate{
+		{RepositoryID: 1, RepositoryName: "github.com/foo/bar"},
+		{RepositoryID: 2, RepositoryName: "github.com/foo/baz"},
+	}
+	if diff := cmp.Diff(wantCandidates, candidates); diff != "" {
+		t.Fatalf("unexpected candidates (-want +got):\n%s", diff)
+	}
+
+	commit := "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	lockfiles := map[string][]shared.MinimialVersionedPackageRepo{
+		"package-lock.json": {
+			{Scheme: "npm", Name: "lodash", Version: "4.17.20"},
+		},
+		"web/yarn.lock": {
+			{Scheme: "npm", Name: "lodash", Version: "4.17.21"},
+			{Scheme: "npm", Name: "react", Version: "18.2.0"},
+		},
+	}
+	if err := store.UpsertLockfileReferences(ctx, 1, commit, lockfiles); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpsertLockfileReferences(ctx, 2, commit, map[string][]shared.MinimialVersionedPackageRepo{
+		"go.sum": {{Scheme: "go", Name: "lodash", Version: "v1.0.0"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Freshly indexed repositories are no longer candidates
+	candidates, err = store.ListLockfileIndexCandidates(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(candidates) != 0 {
+		t.Fatalf("unexpected candidates: %v", candidates)
+	}
+
+	for _, testCase := range []struct {
+		scheme   string
+		expected []string
+	}{
+		{scheme: "", expected: []string{"4.17.20", "4.17.21", "v1.0.0"}},
+		{scheme: "npm", expected: []string{"4.17.20", "4.17.21"}},
+		{scheme: "rust-analyzer", expected: nil},
+	} {
+		versions, err := store.ListLockfileDependencyVersions(ctx, testCase.scheme, "lodash")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(testCase.expected, versions); diff != "" {
+			t.Errorf("unexpected versions for scheme %q (-want +got):\n%s", testCase.scheme, diff)
+		}
+	}
+
+	var linked int
+	if err := db.QueryRowContext(ctx, `SELECT COUNT(*) FROM codeintel_lockfile_references WHERE package_repo_ref_id = 42`).Scan(&linked); err != nil {
+		t.Fatal(err)
+	}
+	if linked != 2 {
+		t.Errorf("unexpected number of linked references: want=%d have=%d", 2, linked)
+	}
+
+	// Re-indexing replaces the previous references
+	if err := store.UpsertLockfileReferences(ctx, 1, commit, nil); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := store.ListLockfileDependencyVersions(ctx, "npm", "lodash")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 0 {
+		t.Errorf("unexpected versions after re-indexing: %v", versions)
+	}
+}
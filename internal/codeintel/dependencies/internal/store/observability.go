@@ -20,6 +20,11 @@ type operations struct {
 
 	shouldRefilterPackageRepoRefs *observation.Operation
 	updateAllBlockedStatuses      *observation.Operation
+
+	listLockfileIndexCandidates    *observation.Operation
+	markLockfileIndexed            *observation.Operation
+	upsertLockfileReferences       *observation.Operation
+	listLockfileDependencyVersions *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -55,5 +60,10 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 		shouldRefilterPackageRepoRefs: op("ShouldRefilterPackageRepoRefs"),
 		updateAllBlockedStatuses:      op("UpdateAllBlockedStatuses"),
+
+		listLockfileIndexCandidates:    op("ListLockfileIndexCandidates"),
+		markLockfileIndexed:            op("MarkLockfileIndexed"),
+		upsertLockfileReferences:       op("UpsertLockfileReferences"),
+		listLockfileDependencyVersions: op("ListLockfileDependencyVersions"),
 	}
 }
@@ -39,6 +39,11 @@ type Store interface {
 
 	ShouldRefilterPackageRepoRefs(ctx context.Context) (exists bool, err error)
 	UpdateAllBlockedStatuses(ctx context.Context, pkgs []shared.PackageRepoReference, startTime time.Time) (pkgsUpdated, versionsUpdated int, err error)
+
+	ListLockfileIndexCandidates(ctx context.Context, indexedBefore time.Time, limit int) ([]shared.LockfileIndexCandidate, error)
+	MarkLockfileIndexed(ctx context.Context, repositoryID int) error
+	UpsertLockfileReferences(ctx context.Context, repositoryID int, commit string, lockfiles map[string][]shared.MinimialVersionedPackageRepo) error
+	ListLockfileDependencyVersions(ctx context.Context, scheme, name string) ([]string, error)
 }
 
 // store manages the database tables for package dependencies.
@@ -0,0 +1,30 @@
+package lockfiles
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+// parseGoSum parses a go.sum file, which lists the checksum of every module version in
+// the build graph, both for the full module and for its go.mod file alone:
+//
+//	golang.org/x/net v0.10.0 h1:X2//UzNDwYmtCLn7To6G58Wr6f5ahEAQgKNzv9Y951M=
+//	golang.org/x/net v0.10.0/go.mod h1:0qNGK6F8kojg2nk9dLZ2mShWaEBan6FAoqfSigmmuDg=
+func parseGoSum(r io.Reader) ([]shared.MinimialVersionedPackageRepo, error) {
+	var deps []shared.MinimialVersionedPackageRepo
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 {
+			continue
+		}
+		version := strings.TrimSuffix(fields[1], "/go.mod")
+		deps = append(deps, newDependency(shared.GoPackagesScheme, fields[0], version))
+	}
+
+	return deps, scanner.Err()
+}
@@ -0,0 +1,103 @@
+// Package lockfiles extracts the resolved package versions a repository depends on
+// from the lockfiles and manifests checked into it.
+package lockfiles
+
+import (
+	"io"
+	"path"
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type parseFunc func(r io.Reader) ([]shared.MinimialVersionedPackageRepo, error)
+
+// parsers maps the file names of supported lockfiles to their parser.
+var parsers = map[string]parseFunc{
+	"package-lock.json": parsePackageLockJSON,
+	"yarn.lock":         parseYarnLock,
+	"go.sum":            parseGoSum,
+	"Cargo.lock":        parseCargoLock,
+	"poetry.lock":       parsePoetryLock,
+	"Gemfile.lock":      parseGemfileLock,
+}
+
+// ecosystemSchemes maps the ecosystem names accepted by the `repo:has.dependency()`
+// search predicate to the package scheme the dependencies are stored under.
+var ecosystemSchemes = map[string]string{
+	"npm":    shared.NpmPackagesScheme,
+	"go":     shared.GoPackagesScheme,
+	"rust":   shared.RustPackagesScheme,
+	"python": shared.PythonPackagesScheme,
+	"ruby":   shared.RubyPackagesScheme,
+}
+
+// Names returns the file names of all supported lockfiles, sorted.
+func Names() []string {
+	names := make([]string, 0, len(parsers))
+	for name := range parsers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsLockfile returns true if the file at the given path is a supported lockfile.
+func IsLockfile(filePath string) bool {
+	_, ok := parsers[path.Base(filePath)]
+	return ok
+}
+
+// SchemeForEcosystem returns the package scheme for the given ecosystem name.
+func SchemeForEcosystem(ecosystem string) (string, bool) {
+	scheme, ok := ecosystemSchemes[ecosystem]
+	return scheme, ok
+}
+
+// Parse returns the deduplicated package versions declared in the lockfile at the given
+// path. Entries that do not refer to a published package (local paths, git URLs, workspace
+// links) are skipped.
+func Parse(filePath string, r io.Reader) ([]shared.MinimialVersionedPackageRepo, error) {
+	parse, ok := parsers[path.Base(filePath)]
+	if !ok {
+		return nil, errors.Newf("unsupported lockfile %q", filePath)
+	}
+
+	deps, err := parse(r)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse %q", filePath)
+	}
+
+	return dedupe(deps), nil
+}
+
+func dedupe(deps []shared.MinimialVersionedPackageRepo) []shared.MinimialVersionedPackageRepo {
+	sort.Slice(deps, func(i, j int) bool {
+		if deps[i].Name != deps[j].Name {
+			return deps[i].Name < deps[j].Name
+		}
+		return deps[i].Version < deps[j].Version
+	})
+
+	deduped := deps[:0]
+	for i, dep := range deps {
+		if dep.Name == "" || dep.Version == "" {
+			continue
+		}
+		if i > 0 && dep == deps[i-1] {
+			continue
+		}
+		deduped = append(deduped, dep)
+	}
+	return deduped
+}
+
+func newDependency(scheme, name, version string) shared.MinimialVersionedPackageRepo {
+	return shared.MinimialVersionedPackageRepo{
+		Scheme:  scheme,
+		Name:    reposource.PackageName(name),
+		Version: version,
+	}
+}
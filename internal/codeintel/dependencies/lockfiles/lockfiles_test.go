@@ -0,0 +1,236 @@
+package lockfiles
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse(t *testing.T) {
+	type dep struct{ Scheme, Name, Version string }
+
+	testCases := []struct {
+		path     string
+		contents string
+		expected []dep
+	}{
+		{
+			path: "package-lock.json",
+			contents: `{
+  "lockfileVersion": 3,
+  "packages": {
+    "": {"name": "root", "version": "1.0.0"},
+    "node_modules/lodash": {"version": "4.17.20"},
+    "node_modules/@types/node": {"version": "18.16.3"},
+    "node_modules/a/node_modules/lodash": {"version": "4.17.21"},
+    "node_modules/local": {"resolved": "packages/local", "link": true},
+    "node_modules/from-git": {"version": "git+ssh://git@github.com/a/b.git#deadbeef"},
+    "packages/local": {"version": "0.0.1"}
+  }
+}`,
+			expected: []dep{
+				{"npm", "@types/node", "18.16.3"},
+				{"npm", "lodash", "4.17.20"},
+				{"npm", "lodash", "4.17.21"},
+			},
+		},
+		{
+			path: "web/package-lock.json",
+			contents: `{
+  "lockfileVersion": 1,
+  "dependencies": {
+    "lodash": {"version": "4.17.20"},
+    "a": {
+      "version": "1.0.0",
+      "dependencies": {"lodash": {"version": "4.17.21"}}
+    }
+  }
+}`,
+			expected: []dep{
+				{"npm", "a", "1.0.0"},
+				{"npm", "lodash", "4.17.20"},
+				{"npm", "lodash", "4.17.21"},
+			},
+		},
+		{
+			path: "yarn.lock",
+			contents: `# THIS IS AN AUTOGENERATED FILE. DO NOT EDIT THIS FILE DIRECTLY.
+# yarn lockfile v1
+
+
+"@babel/code-frame@^7.0.0", "@babel/code-frame@^7.10.4":
+  version "7.12.13"
+  resolved "https://registry.yarnpkg.com/@babel/code-frame/-/code-frame-7.12.13.tgz"
+  dependencies:
+    "@babel/highlight" "^7.12.13"
+
+lodash@^4.17.20:
+  version "4.17.21"
+
+from-git@git+https://github.com/a/b.git:
+  version "1.0.0"
+`,
+			expected: []dep{
+				{"npm", "@babel/code-frame", "7.12.13"},
+				{"npm", "lodash", "4.17.21"},
+			},
+		},
+		{
+			path: "yarn.lock",
+			contents: `__metadata:
+  version: 6
+  cacheKey: 8
+
+"lodash@npm:^4.17.20, lodash@npm:^4.17.21":
+  version: 4.17.21
+  resolution: "lodash@npm:4.17.21"
+  checksum: eb835a2e51d381e561e508ce932ea50a8e5a68f4ebdd771ea240d3048244a8d13658acbd502cd4829768c56f2e16bdd4340b9ea141297d472517b83868e677f7
+
+"resolve@patch:resolve@^1.20.0#~builtin<compat/resolve>":
+  version: 1.22.1
+  resolution: "resolve@patch:resolve@npm%3A1.22.1#~builtin<compat/resolve>::version=1.22.1&hash=c3c19d"
+
+"root@workspace:.":
+  version: 0.0.0-use.local
+`,
+			expected: []dep{
+				{"npm", "lodash", "4.17.21"},
+			},
+		},
+		{
+			path: "go.sum",
+			contents: `github.com/sourcegraph/log v0.0.0-20230523201558-ad2d71a9e2a4 h1:zmsDDb/PnoJ7ky+qD1FYzfDNCQsIXYNrWhzeDMFz3rU=
+github.com/sourcegraph/log v0.0.0-20230523201558-ad2d71a9e2a4/go.mod h1:IDp09QkoqS8Z3CyN2RW6vXjgABkNpDbyjLIHNQwQ8P8=
+golang.org/x/net v0.9.0/go.mod h1:d48xBJpPfHeWQsugry2m+kC02ZBRGRgulfHnEXEuWns=
+golang.org/x/net v0.10.0 h1:X2//UzNDwYmtCLn7To6G58Wr6f5ahEAQgKNzv9Y951M=
+`,
+			expected: []dep{
+				{"go", "github.com/sourcegraph/log", "v0.0.0-20230523201558-ad2d71a9e2a4"},
+				{"go", "golang.org/x/net", "v0.10.0"},
+				{"go", "golang.org/x/net", "v0.9.0"},
+			},
+		},
+		{
+			path: "Cargo.lock",
+			contents: `# This file is automatically @generated by Cargo.
+version = 3
+
+[[package]]
+name = "my-crate"
+version = "0.1.0"
+dependencies = [
+ "serde",
+]
+
+[[package]]
+name = "serde"
+version = "1.0.164"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+checksum = "9e8c8cbaf0c2d5e9d1d5e8d3e0a7b2b5c0e7f9f0e2e8c5b1c4d0d1b7c0a9e8f7"
+`,
+			expected: []dep{
+				{"rust-analyzer", "serde", "1.0.164"},
+			},
+		},
+		{
+			path: "poetry.lock",
+			contents: `[[package]]
+name = "Requests"
+version = "2.31.0"
+description = "Python HTTP for Humans."
+optional = false
+python-versions = ">=3.7"
+
+[package.dependencies]
+certifi = ">=2017.4.17"
+
+[package.extras]
+socks = ["PySocks (>=1.5.6,!=1.5.7)"]
+
+[[package]]
+name = "certifi"
+version = "2023.5.7"
+
+[metadata]
+lock-version = "2.0"
+content-hash = "abc"
+`,
+			expected: []dep{
+				{"python", "certifi", "2023.5.7"},
+				{"python", "requests", "2.31.0"},
+			},
+		},
+		{
+			path: "Gemfile.lock",
+			contents: `GIT
+  remote: https://github.com/rails/rails.git
+  revision: 0123456789abcdef
+  specs:
+    rails (7.1.0.alpha)
+
+GEM
+  remote: https://rubygems.org/
+  specs:
+    actionpack (7.0.4)
+      rack (~> 2.0, >= 2.2.0)
+    nokogiri (1.14.2-x86_64-linux)
+      racc (~> 1.4)
+    rack (2.2.7)
+
+PLATFORMS
+  x86_64-linux
+
+DEPENDENCIES
+  actionpack
+
+BUNDLED WITH
+   2.4.10
+`,
+			expected: []dep{
+				{"scip-ruby", "actionpack", "7.0.4"},
+				{"scip-ruby", "nokogiri", "1.14.2"},
+				{"scip-ruby", "rack", "2.2.7"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.path, func(t *testing.T) {
+			deps, err := Parse(testCase.path, strings.NewReader(testCase.contents))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			var actual []dep
+			for _, d := range deps {
+				actual = append(actual, dep{d.Scheme, string(d.Name), d.Version})
+			}
+			if diff := cmp.Diff(testCase.expected, actual); diff != "" {
+				t.Errorf("unexpected dependencies (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseUnsupported(t *testing.T) {
+	if _, err := Parse("requirements.txt", strings.NewReader("requests==2.31.0")); err == nil {
+		t.Fatal("expected error for unsupported lockfile")
+	}
+}
+
+func TestIsLockfile(t *testing.T) {
+	for path, expected := range map[string]bool{
+		"package-lock.json":          true,
+		"client/web/yarn.lock":       true,
+		"go.sum":                     true,
+		"crates/Cargo.lock":          true,
+		"package.json":               false,
+		"docs/go.sum.md":             false,
+		"vendor/poetry.lock.example": false,
+	} {
+		if actual := IsLockfile(path); actual != expected {
+			t.Errorf("unexpected result for %q: want=%v have=%v", path, expected, actual)
+		}
+	}
+}
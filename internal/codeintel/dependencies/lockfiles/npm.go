@@ -0,0 +1,142 @@
+package lockfiles
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+type packageLockJSON struct {
+	// Packages is set by lockfileVersion 2 and 3 and is keyed by install path,
+	// e.g. `node_modules/a/node_modules/b`.
+	Packages map[string]packageLockPackage `json:"packages"`
+
+	// Dependencies is set by lockfileVersion 1 and 2 and is a tree keyed by
+	// package name.
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+type packageLockPackage struct {
+	Version string `json:"version"`
+	Link    bool   `json:"link"`
+}
+
+type packageLockDependency struct {
+	Version      string                           `json:"version"`
+	Dependencies map[string]packageLockDependency `json:"dependencies"`
+}
+
+func parsePackageLockJSON(r io.Reader) ([]shared.MinimialVersionedPackageRepo, error) {
+	var lockfile packageLockJSON
+	if err := json.NewDecoder(r).Decode(&lockfile); err != nil {
+		return nil, err
+	}
+
+	var deps []shared.MinimialVersionedPackageRepo
+	if len(lockfile.Packages) > 0 {
+		for installPath, pkg := range lockfile.Packages {
+			// The empty key describes the root project itself.
+			i := strings.LastIndex(installPath, "node_modules/")
+			if i < 0 || pkg.Link || !isNpmRegistryVersion(pkg.Version) {
+				continue
+			}
+			deps = append(deps, newDependency(shared.NpmPackagesScheme, installPath[i+len("node_modules/"):], pkg.Version))
+		}
+		return deps, nil
+	}
+
+	var visit func(map[string]packageLockDependency)
+	visit = func(dependencies map[string]packageLockDependency) {
+		for name, dep := range dependencies {
+			if isNpmRegistryVersion(dep.Version) {
+				deps = append(deps, newDependency(shared.NpmPackagesScheme, name, dep.Version))
+			}
+			visit(dep.Dependencies)
+		}
+	}
+	visit(lockfile.Dependencies)
+
+	return deps, nil
+}
+
+// parseYarnLock parses both the classic (v1) and berry (v2+) yarn.lock formats,
+// which share the same overall shape:
+//
+//	"@babel/core@^7.0.0", "@babel/core@^7.1.0":
+//	  version "7.1.2"
+//
+//	"lodash@npm:^4.17.20":
+//	  version: 4.17.21
+func parseYarnLock(r io.Reader) ([]shared.MinimialVersionedPackageRepo, error) {
+	var (
+		deps    []shared.MinimialVersionedPackageRepo
+		current string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !strings.HasPrefix(line, " ") {
+			current = yarnPackageName(strings.TrimSuffix(line, ":"))
+			continue
+		}
+
+		if current == "" || strings.HasPrefix(line, "    ") {
+			continue
+		}
+
+		field := strings.TrimSpace(line)
+		if !strings.HasPrefix(field, "version") {
+			continue
+		}
+		version := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(field, "version"), ":"))
+		version = strings.Trim(version, `"`)
+		if isNpmRegistryVersion(version) {
+			deps = append(deps, newDependency(shared.NpmPackagesScheme, current, version))
+		}
+		current = ""
+	}
+
+	return deps, scanner.Err()
+}
+
+// yarnPackageName returns the package name of the first descriptor in a yarn.lock
+// entry header, or an empty string if the entry does not refer to a registry package.
+func yarnPackageName(header string) string {
+	descriptor, _, _ := strings.Cut(header, ",")
+	descriptor = strings.Trim(strings.TrimSpace(descriptor), `"`)
+	if descriptor == "__metadata" {
+		return ""
+	}
+
+	// Skip the leading @ of scoped packages.
+	i := strings.Index(strings.TrimPrefix(descriptor, "@"), "@")
+	if i < 0 {
+		return ""
+	}
+	if strings.HasPrefix(descriptor, "@") {
+		i++
+	}
+	name, rangeSpec := descriptor[:i], descriptor[i+1:]
+
+	// Berry prefixes ranges with the protocol. Only the npm protocol refers to
+	// registry packages; patch:, workspace:, link:, portal: etc. do not.
+	if protocol, _, ok := strings.Cut(rangeSpec, ":"); ok && protocol != "npm" {
+		return ""
+	}
+
+	return name
+}
+
+// isNpmRegistryVersion returns false for versions that point to git repositories,
+// tarballs or local directories rather than the package registry.
+func isNpmRegistryVersion(version string) bool {
+	return version != "" && !strings.Contains(version, ":") && !strings.Contains(version, "/")
+}
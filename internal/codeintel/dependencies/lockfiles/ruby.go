@@ -0,0 +1,51 @@
+package lockfiles
+
+import (
+	"bufio"
+	"io"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+// parseGemfileLock parses the gems resolved from a rubygems source in a Gemfile.lock.
+// Gems sourced from GIT or PATH sections are skipped.
+//
+//	GEM
+//	  remote: https://rubygems.org/
+//	  specs:
+//	    actionpack (7.0.4)
+//	      rack (~> 2.0, >= 2.2.0)
+//	    nokogiri (1.14.2-x86_64-linux)
+func parseGemfileLock(r io.Reader) ([]shared.MinimialVersionedPackageRepo, error) {
+	var (
+		deps    []shared.MinimialVersionedPackageRepo
+		section string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" && !strings.HasPrefix(line, " ") {
+			section = line
+			continue
+		}
+
+		// Resolved gems are indented by exactly four spaces, their own
+		// dependency constraints by six.
+		if section != "GEM" || !strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "     ") {
+			continue
+		}
+
+		name, version, ok := strings.Cut(strings.TrimSpace(line), " (")
+		if !ok {
+			continue
+		}
+		version = strings.TrimSuffix(version, ")")
+		// Strip the platform suffix from platform-specific gems.
+		version, _, _ = strings.Cut(version, "-")
+		deps = append(deps, newDependency(shared.RubyPackagesScheme, name, version))
+	}
+
+	return deps, scanner.Err()
+}
@@ -0,0 +1,86 @@
+package lockfiles
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/shared"
+)
+
+// parseCargoLock parses a Cargo.lock file. Crates without a source are members of the
+// workspace itself rather than dependencies.
+func parseCargoLock(r io.Reader) ([]shared.MinimialVersionedPackageRepo, error) {
+	packages, err := scanTOMLPackages(r)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]shared.MinimialVersionedPackageRepo, 0, len(packages))
+	for _, pkg := range packages {
+		if !strings.HasPrefix(pkg["source"], "registry+") {
+			continue
+		}
+		deps = append(deps, newDependency(shared.RustPackagesScheme, pkg["name"], pkg["version"]))
+	}
+	return deps, nil
+}
+
+// parsePoetryLock parses a poetry.lock file.
+func parsePoetryLock(r io.Reader) ([]shared.MinimialVersionedPackageRepo, error) {
+	packages, err := scanTOMLPackages(r)
+	if err != nil {
+		return nil, err
+	}
+
+	deps := make([]shared.MinimialVersionedPackageRepo, 0, len(packages))
+	for _, pkg := range packages {
+		deps = append(deps, newDependency(shared.PythonPackagesScheme, strings.ToLower(pkg["name"]), pkg["version"]))
+	}
+	return deps, nil
+}
+
+// scanTOMLPackages returns the top-level string keys of every `[[package]]` table in
+// the given TOML document. Both Cargo.lock and poetry.lock are generated files with a
+// flat and predictable layout, so this avoids needing a full TOML parser:
+//
+//	[[package]]
+//	name = "serde"
+//	version = "1.0.164"
+//	source = "registry+https://github.com/rust-lang/crates.io-index"
+func scanTOMLPackages(r io.Reader) ([]map[string]string, error) {
+	var (
+		packages []map[string]string
+		current  map[string]string
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "[") {
+			// Any table header ends the current package's top-level keys,
+			// including sub-tables like [package.dependencies].
+			current = nil
+			if line == "[[package]]" {
+				current = map[string]string{}
+				packages = append(packages, current)
+			}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		if unquoted, err := strconv.Unquote(strings.TrimSpace(value)); err == nil {
+			current[strings.TrimSpace(key)] = unquoted
+		}
+	}
+
+	return packages, scanner.Err()
+}
@@ -322,3 +322,15 @@ func (s *Service) PackagesOrVersionsMatchingFilter(ctx context.Context, filter s
 
 	return matchingPkgs, totalCount, hasMore, nil
 }
+
+// ListLockfileDependencyVersions returns the distinct versions of the given package that are declared
+// by the lockfile of any indexed repository. An empty scheme matches packages of every scheme.
+func (s *Service) ListLockfileDependencyVersions(ctx context.Context, scheme, name string) (_ []string, err error) {
+	ctx, _, endObservation := s.operations.listLockfileDependencyVersions.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("packageScheme", scheme),
+		attribute.String("name", name),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.store.ListLockfileDependencyVersions(ctx, scheme, name)
+}
@@ -69,3 +69,11 @@ type PackageRepoFilter struct {
 	DeletedAt *time.Time
 	UpdatedAt time.Time
 }
+
+// LockfileIndexCandidate is a repository whose lockfiles are due to be (re-)indexed.
+type LockfileIndexCandidate struct {
+	RepositoryID   int
+	RepositoryName string
+	// Commit is the revision indexed on the previous run, if any.
+	Commit string
+}
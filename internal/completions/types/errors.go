@@ -70,6 +70,11 @@ func NewErrStatusNotOK(source string, resp *http.Response) error {
 	}
 }
 
+// StatusCode returns the status code the server responded with.
+func (e *ErrStatusNotOK) StatusCode() int {
+	return e.statusCode
+}
+
 func IsErrStatusNotOK(err error) (*ErrStatusNotOK, bool) {
 	if err == nil {
 		return nil, false
@@ -43,6 +43,15 @@ func embeddingsConfigValidator(q conftypes.SiteConfigQuerier) Problems {
 		}
 	}
 
+	if embeddingsConf.Provider == string(conftypes.EmbeddingsProviderNameCustom) {
+		if embeddingsConf.Endpoint == "" && embeddingsConf.Url == "" {
+			problems = append(problems, "\"embeddings.endpoint\" is required for the custom provider")
+		}
+		if embeddingsConf.Model == "" {
+			problems = append(problems, "\"embeddings.model\" is required for the custom provider")
+		}
+	}
+
 	minimumIntervalString := embeddingsConf.MinimumInterval
 	_, err := time.ParseDuration(minimumIntervalString)
 	if err != nil && minimumIntervalString != "" {
@@ -50,7 +59,8 @@ func embeddingsConfigValidator(q conftypes.SiteConfigQuerier) Problems {
 	}
 
 	if evaluatedConfig := GetEmbeddingsConfig(q.SiteConfig()); evaluatedConfig != nil {
-		if evaluatedConfig.Dimensions <= 0 {
+		// The custom provider discovers the dimensions from the server.
+		if evaluatedConfig.Dimensions <= 0 && evaluatedConfig.Provider != conftypes.EmbeddingsProviderNameCustom {
 			problems = append(problems, "Could not set a default \"embeddings.dimensions\", please configure one manually")
 		}
 	}
@@ -694,112 +694,205 @@ func GetCompletionsConfig(siteConfig schema.SiteConfiguration) (c *conftypes.Com
 		completionsConfig.ChatModel = completionsConfig.Model
 	}
 
-	if completionsConfig.Provider == string(conftypes.CompletionsProviderNameSourcegraph) {
+	if !applyCompletionsProviderDefaults(completionsConfig, siteConfig) {
+		return nil
+	}
+
+	// Make sure models are always treated case-insensitive.
+	lowerCompletionsModels(completionsConfig)
+
+	// If after trying to set default we still have not all models configured, completions are
+	// not available.
+	if !hasCompletionsModels(completionsConfig) {
+		return nil
+	}
+
+	computedConfig := &conftypes.CompletionsConfig{
+		Provider:                         conftypes.CompletionsProviderName(completionsConfig.Provider),
+		AccessToken:                      completionsConfig.AccessToken,
+		ChatModel:                        completionsConfig.ChatModel,
+		ChatModelMaxTokens:               completionsConfig.ChatModelMaxTokens,
+		FastChatModel:                    completionsConfig.FastChatModel,
+		FastChatModelMaxTokens:           completionsConfig.FastChatModelMaxTokens,
+		CompletionModel:                  completionsConfig.CompletionModel,
+		CompletionModelMaxTokens:         completionsConfig.CompletionModelMaxTokens,
+		Endpoint:                         completionsConfig.Endpoint,
+		PerUserDailyLimit:                completionsConfig.PerUserDailyLimit,
+		PerUserCodeCompletionsDailyLimit: completionsConfig.PerUserCodeCompletionsDailyLimit,
+	}
+	if computedConfig.Provider == conftypes.CompletionsProviderNameCustom {
+		computedConfig.Custom = completionsConfig.Custom
+	}
+
+	for _, fallback := range completionsConfig.Fallbacks {
+		if backend := getCompletionsFallback(fallback, siteConfig); backend != nil {
+			computedConfig.Fallbacks = append(computedConfig.Fallbacks, *backend)
+		}
+	}
+	if len(computedConfig.Fallbacks) > 0 {
+		computedConfig.Routing = conftypes.CompletionsRouting(completionsConfig.Routing)
+		if computedConfig.Routing == "" {
+			computedConfig.Routing = conftypes.CompletionsRoutingFailover
+		}
+	}
+
+	return computedConfig
+}
+
+// getCompletionsFallback evaluates the configuration of a fallback completions
+// provider. It returns nil if the fallback cannot be used, e.g. because it is
+// missing an access token.
+func getCompletionsFallback(fallback *schema.CompletionsFallback, siteConfig schema.SiteConfiguration) *conftypes.CompletionsBackend {
+	if fallback == nil {
+		return nil
+	}
+
+	c := &schema.Completions{
+		Provider:        fallback.Provider,
+		Endpoint:        fallback.Endpoint,
+		AccessToken:     fallback.AccessToken,
+		ChatModel:       fallback.ChatModel,
+		FastChatModel:   fallback.FastChatModel,
+		CompletionModel: fallback.CompletionModel,
+	}
+	if !applyCompletionsProviderDefaults(c, siteConfig) {
+		return nil
+	}
+	lowerCompletionsModels(c)
+	if !hasCompletionsModels(c) {
+		return nil
+	}
+
+	backend := &conftypes.CompletionsBackend{
+		Provider:        conftypes.CompletionsProviderName(c.Provider),
+		Endpoint:        c.Endpoint,
+		AccessToken:     c.AccessToken,
+		ChatModel:       c.ChatModel,
+		FastChatModel:   c.FastChatModel,
+		CompletionModel: c.CompletionModel,
+	}
+	if backend.Provider == conftypes.CompletionsProviderNameCustom {
+		backend.Custom = fallback.Custom
+	}
+	return backend
+}
+
+// applyCompletionsProviderDefaults sets the default endpoint, access token and
+// models of the configured provider. It returns false if the provider cannot be
+// used with the given configuration.
+func applyCompletionsProviderDefaults(c *schema.Completions, siteConfig schema.SiteConfiguration) bool {
+	if c.Provider == string(conftypes.CompletionsProviderNameSourcegraph) {
 		// If no endpoint is configured, use a default value.
-		if completionsConfig.Endpoint == "" {
-			completionsConfig.Endpoint = "https://cody-gateway.sourcegraph.com"
+		if c.Endpoint == "" {
+			c.Endpoint = "https://cody-gateway.sourcegraph.com"
 		}
 
 		// Set the access token, either use the configured one, or generate one for the platform.
-		completionsConfig.AccessToken = getSourcegraphProviderAccessToken(completionsConfig.AccessToken, siteConfig)
+		c.AccessToken = getSourcegraphProviderAccessToken(c.AccessToken, siteConfig)
 		// If we weren't able to generate an access token of some sort, authing with
 		// Cody Gateway is not possible and we cannot use completions.
-		if completionsConfig.AccessToken == "" {
-			return nil
+		if c.AccessToken == "" {
+			return false
 		}
 
 		// Set a default chat model.
-		if completionsConfig.ChatModel == "" {
-			completionsConfig.ChatModel = "anthropic/claude-v1"
+		if c.ChatModel == "" {
+			c.ChatModel = "anthropic/claude-v1"
 		}
 
 		// Set a default fast chat model.
-		if completionsConfig.FastChatModel == "" {
-			completionsConfig.FastChatModel = "anthropic/claude-instant-v1"
+		if c.FastChatModel == "" {
+			c.FastChatModel = "anthropic/claude-instant-v1"
 		}
 
 		// Set a default completions model.
-		if completionsConfig.CompletionModel == "" {
-			completionsConfig.CompletionModel = "anthropic/claude-instant-v1"
+		if c.CompletionModel == "" {
+			c.CompletionModel = "anthropic/claude-instant-v1"
 		}
-	} else if completionsConfig.Provider == string(conftypes.CompletionsProviderNameOpenAI) {
+	} else if c.Provider == string(conftypes.CompletionsProviderNameOpenAI) {
 		// If no endpoint is configured, use a default value.
-		if completionsConfig.Endpoint == "" {
-			completionsConfig.Endpoint = "https://api.openai.com/v1/chat/completions"
+		if c.Endpoint == "" {
+			c.Endpoint = "https://api.openai.com/v1/chat/completions"
 		}
 
 		// If not access token is set, we cannot talk to OpenAI. Bail.
-		if completionsConfig.AccessToken == "" {
-			return nil
+		if c.AccessToken == "" {
+			return false
 		}
 
 		// Set a default chat model.
-		if completionsConfig.ChatModel == "" {
-			completionsConfig.ChatModel = "gpt-4"
+		if c.ChatModel == "" {
+			c.ChatModel = "gpt-4"
 		}
 
 		// Set a default fast chat model.
-		if completionsConfig.FastChatModel == "" {
-			completionsConfig.FastChatModel = "gpt-3.5-turbo"
+		if c.FastChatModel == "" {
+			c.FastChatModel = "gpt-3.5-turbo"
 		}
 
 		// Set a default completions model.
-		if completionsConfig.CompletionModel == "" {
-			completionsConfig.CompletionModel = "gpt-3.5-turbo"
+		if c.CompletionModel == "" {
+			c.CompletionModel = "gpt-3.5-turbo"
 		}
-	} else if completionsConfig.Provider == string(conftypes.CompletionsProviderNameAnthropic) {
+	} else if c.Provider == string(conftypes.CompletionsProviderNameAnthropic) {
 		// If no endpoint is configured, use a default value.
-		if completionsConfig.Endpoint == "" {
-			completionsConfig.Endpoint = "https://api.anthropic.com/v1/complete"
+		if c.Endpoint == "" {
+			c.Endpoint = "https://api.anthropic.com/v1/complete"
 		}
 
 		// If not access token is set, we cannot talk to Anthropic. Bail.
-		if completionsConfig.AccessToken == "" {
-			return nil
+		if c.AccessToken == "" {
+			return false
 		}
 
 		// Set a default chat model.
-		if completionsConfig.ChatModel == "" {
-			completionsConfig.ChatModel = "claude-v1"
+		if c.ChatModel == "" {
+			c.ChatModel = "claude-v1"
 		}
 
 		// Set a default fast chat model.
-		if completionsConfig.FastChatModel == "" {
-			completionsConfig.FastChatModel = "claude-instant-v1"
+		if c.FastChatModel == "" {
+			c.FastChatModel = "claude-instant-v1"
 		}
 
 		// Set a default completions model.
-		if completionsConfig.CompletionModel == "" {
-			completionsConfig.CompletionModel = "claude-instant-v1"
+		if c.CompletionModel == "" {
+			c.CompletionModel = "claude-instant-v1"
+		}
+	} else if c.Provider == string(conftypes.CompletionsProviderNameCustom) {
+		// There is no sensible default endpoint for a self-hosted model server.
+		if c.Endpoint == "" {
+			return false
 		}
-	}
-
-	// Make sure models are always treated case-insensitive.
-	completionsConfig.ChatModel = strings.ToLower(completionsConfig.ChatModel)
-	completionsConfig.FastChatModel = strings.ToLower(completionsConfig.FastChatModel)
-	completionsConfig.CompletionModel = strings.ToLower(completionsConfig.CompletionModel)
 
-	// If after trying to set default we still have not all models configured, completions are
-	// not available.
-	if completionsConfig.ChatModel == "" || completionsConfig.FastChatModel == "" || completionsConfig.CompletionModel == "" {
-		return nil
+		// A self-hosted model server usually serves a single model, so use the
+		// chat model for everything unless configured otherwise.
+		if c.FastChatModel == "" {
+			c.FastChatModel = c.ChatModel
+		}
+		if c.CompletionModel == "" {
+			c.CompletionModel = c.ChatModel
+		}
 	}
 
-	computedConfig := &conftypes.CompletionsConfig{
-		Provider:                         conftypes.CompletionsProviderName(completionsConfig.Provider),
-		AccessToken:                      completionsConfig.AccessToken,
-		ChatModel:                        completionsConfig.ChatModel,
-		ChatModelMaxTokens:               completionsConfig.ChatModelMaxTokens,
-		FastChatModel:                    completionsConfig.FastChatModel,
-		FastChatModelMaxTokens:           completionsConfig.FastChatModelMaxTokens,
-		CompletionModel:                  completionsConfig.CompletionModel,
-		CompletionModelMaxTokens:         completionsConfig.CompletionModelMaxTokens,
-		Endpoint:                         completionsConfig.Endpoint,
-		PerUserDailyLimit:                completionsConfig.PerUserDailyLimit,
-		PerUserCodeCompletionsDailyLimit: completionsConfig.PerUserCodeCompletionsDailyLimit,
+	return true
+}
+
+// lowerCompletionsModels lowercases the models, so that they are treated
+// case-insensitively. Models of the custom provider are kept as configured, since
+// they are passed to and looked up in the models of self-hosted model servers,
+// whose model IDs can be case-sensitive.
+func lowerCompletionsModels(c *schema.Completions) {
+	if c.Provider == string(conftypes.CompletionsProviderNameCustom) {
+		return
 	}
+	c.ChatModel = strings.ToLower(c.ChatModel)
+	c.FastChatModel = strings.ToLower(c.FastChatModel)
+	c.CompletionModel = strings.ToLower(c.CompletionModel)
+}
 
-	return computedConfig
+func hasCompletionsModels(c *schema.Completions) bool {
+	return c.ChatModel != "" && c.FastChatModel != "" && c.CompletionModel != ""
 }
 
 // GetEmbeddingsConfig evaluates a complete embeddings configuration based on
@@ -922,6 +1015,14 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		if embeddingsConfig.Dimensions <= 0 && embeddingsConfig.Model == "text-embedding-ada-002" {
 			embeddingsConfig.Dimensions = 1536
 		}
+	} else if embeddingsConfig.Provider == string(conftypes.EmbeddingsProviderNameCustom) {
+		// There is no sensible default endpoint for a self-hosted embedding
+		// server, and the model identifies the embeddings of existing indexes.
+		// The model is passed to the server as configured, since model names of
+		// self-hosted servers can be case-sensitive.
+		if embeddingsConfig.Endpoint == "" || embeddingsConfig.Model == "" {
+			return nil
+		}
 	} else {
 		// Unknown provider value.
 		return nil
@@ -940,6 +1041,9 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		MaxTextEmbeddingsPerRepo:   embeddingsConfig.MaxTextEmbeddingsPerRepo,
 		PolicyRepositoryMatchLimit: embeddingsConfig.PolicyRepositoryMatchLimit,
 	}
+	if computedConfig.Provider == conftypes.EmbeddingsProviderNameCustom {
+		computedConfig.Custom = embeddingsConfig.Custom
+	}
 	d, err := time.ParseDuration(embeddingsConfig.MinimumInterval)
 	if err != nil {
 		computedConfig.MinimumInterval = defaultMinimumInterval
@@ -533,6 +533,87 @@ func TestGetCompletionsConfig(t *testing.T) {
 				Endpoint:        "https://api.openai.com/v1/chat/completions",
 			},
 		},
+		{
+			name: "completions with fallbacks",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Completions: &schema.Completions{
+					Provider:    "anthropic",
+					AccessToken: "asdf",
+					Fallbacks: []*schema.CompletionsFallback{
+						// Missing access token, ignored.
+						{Provider: "openai"},
+						{Provider: "openai", AccessToken: "qwer", ChatModel: "GPT-4-32k"},
+						{Provider: "sourcegraph"},
+					},
+				},
+			},
+			wantConfig: &conftypes.CompletionsConfig{
+				ChatModel:       "claude-v1",
+				FastChatModel:   "claude-instant-v1",
+				CompletionModel: "claude-instant-v1",
+				AccessToken:     "asdf",
+				Provider:        "anthropic",
+				Endpoint:        "https://api.anthropic.com/v1/complete",
+				Fallbacks: []conftypes.CompletionsBackend{
+					{
+						Provider:        "openai",
+						Endpoint:        "https://api.openai.com/v1/chat/completions",
+						AccessToken:     "qwer",
+						ChatModel:       "gpt-4-32k",
+						FastChatModel:   "gpt-3.5-turbo",
+						CompletionModel: "gpt-3.5-turbo",
+					},
+					{
+						Provider:        "sourcegraph",
+						Endpoint:        "https://cody-gateway.sourcegraph.com",
+						AccessToken:     licenseAccessToken,
+						ChatModel:       "anthropic/claude-v1",
+						FastChatModel:   "anthropic/claude-instant-v1",
+						CompletionModel: "anthropic/claude-instant-v1",
+					},
+				},
+				Routing: "failover",
+			},
+		},
+		{
+			name: "custom completions without endpoint",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Completions: &schema.Completions{
+					Provider:  "custom",
+					ChatModel: "llama-2-13b",
+				},
+			},
+			wantDisabled: true,
+		},
+		{
+			name: "custom completions",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Completions: &schema.Completions{
+					Provider:  "custom",
+					ChatModel: "Llama-2-13b",
+					Endpoint:  "http://llm.internal:8000/v1/chat/completions",
+					Custom: &schema.CustomCompletionsProvider{
+						StreamFormat: "ndjson",
+					},
+				},
+			},
+			wantConfig: &conftypes.CompletionsConfig{
+				ChatModel:       "Llama-2-13b",
+				FastChatModel:   "Llama-2-13b",
+				CompletionModel: "Llama-2-13b",
+				Provider:        "custom",
+				Endpoint:        "http://llm.internal:8000/v1/chat/completions",
+				Custom: &schema.CustomCompletionsProvider{
+					StreamFormat: "ndjson",
+				},
+			},
+		},
 		{
 			name: "zero-config cody gateway completions without license key",
 			siteConfig: schema.SiteConfiguration{
@@ -828,6 +909,54 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 			},
 			wantDisabled: true,
 		},
+		{
+			name: "Custom provider",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider: "custom",
+					Endpoint: "http://embeddings.internal:8080/v1/embeddings",
+					Model:    "BAAI/bge-small-en",
+					Custom:   &schema.CustomEmbeddingsProvider{Protocol: "json", BatchSize: 32},
+				},
+			},
+			wantConfig: &conftypes.EmbeddingsConfig{
+				Provider:                   "custom",
+				Model:                      "BAAI/bge-small-en",
+				Endpoint:                   "http://embeddings.internal:8080/v1/embeddings",
+				Incremental:                true,
+				MinimumInterval:            24 * time.Hour,
+				MaxCodeEmbeddingsPerRepo:   3_072_000,
+				MaxTextEmbeddingsPerRepo:   512_000,
+				PolicyRepositoryMatchLimit: pointers.Ptr(5000),
+				Custom:                     &schema.CustomEmbeddingsProvider{Protocol: "json", BatchSize: 32},
+			},
+		},
+		{
+			name: "Custom provider without endpoint",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider: "custom",
+					Model:    "bge-small-en",
+				},
+			},
+			wantDisabled: true,
+		},
+		{
+			name: "Custom provider without model",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider: "custom",
+					Endpoint: "http://embeddings.internal:8080/v1/embeddings",
+				},
+			},
+			wantDisabled: true,
+		},
 		{
 			name:       "App default config",
 			deployType: deploy.App,
@@ -1,6 +1,10 @@
 package conftypes
 
-import "time"
+import (
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
 
 type CompletionsConfig struct {
 	ChatModel          string
@@ -17,14 +21,47 @@ type CompletionsConfig struct {
 	Endpoint                         string
 	PerUserDailyLimit                int
 	PerUserCodeCompletionsDailyLimit int
+
+	// Custom configures the protocol spoken by the "custom" provider. It is
+	// only set for that provider and may be nil, in which case the defaults apply.
+	Custom *schema.CustomCompletionsProvider
+
+	// Fallbacks are the providers to use when the provider above is unavailable,
+	// in order of preference.
+	Fallbacks []CompletionsBackend
+	// Routing is how requests are spread across the provider above and its
+	// fallbacks. It is only set if there are fallbacks.
+	Routing CompletionsRouting
 }
 
+// CompletionsBackend is a completions provider used in addition to the main
+// provider of a CompletionsConfig.
+type CompletionsBackend struct {
+	Provider    CompletionsProviderName
+	Endpoint    string
+	AccessToken string
+
+	ChatModel       string
+	FastChatModel   string
+	CompletionModel string
+
+	Custom *schema.CustomCompletionsProvider
+}
+
+type CompletionsRouting string
+
+const (
+	CompletionsRoutingFailover   CompletionsRouting = "failover"
+	CompletionsRoutingRoundRobin CompletionsRouting = "round-robin"
+)
+
 type CompletionsProviderName string
 
 const (
 	CompletionsProviderNameAnthropic   CompletionsProviderName = "anthropic"
 	CompletionsProviderNameOpenAI      CompletionsProviderName = "openai"
 	CompletionsProviderNameSourcegraph CompletionsProviderName = "sourcegraph"
+	CompletionsProviderNameCustom      CompletionsProviderName = "custom"
 )
 
 type EmbeddingsConfig struct {
@@ -39,6 +76,10 @@ type EmbeddingsConfig struct {
 	MaxCodeEmbeddingsPerRepo   int
 	MaxTextEmbeddingsPerRepo   int
 	PolicyRepositoryMatchLimit *int
+
+	// Custom configures the protocol spoken by the "custom" provider. It is
+	// only set for that provider and may be nil, in which case the defaults apply.
+	Custom *schema.CustomEmbeddingsProvider
 }
 
 type EmbeddingsProviderName string
@@ -46,4 +87,5 @@ type EmbeddingsProviderName string
 const (
 	EmbeddingsProviderNameOpenAI      EmbeddingsProviderName = "openai"
 	EmbeddingsProviderNameSourcegraph EmbeddingsProviderName = "sourcegraph"
+	EmbeddingsProviderNameCustom      EmbeddingsProviderName = "custom"
 )
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type BlameOwnershipSignalStore interface {
+	// ReplaceSignals replaces all the blame ownership signals of the given
+	// repository with the given ones.
+	ReplaceSignals(ctx context.Context, repoID api.RepoID, signals []BlameOwnership) error
+	// FindBlameOwners returns the blame owners of the given file or directory,
+	// ordered by decreasing share.
+	FindBlameOwners(ctx context.Context, repoID api.RepoID, path string) ([]BlameOwnership, error)
+	// ListBlameOwnersForRepo returns the blame owners of all the files and
+	// directories of the given repository.
+	ListBlameOwnersForRepo(ctx context.Context, repoID api.RepoID) ([]BlameOwnership, error)
+	WithTransact(context.Context, func(store BlameOwnershipSignalStore) error) error
+}
+
+func BlameOwnershipSignalStoreWith(other basestore.ShareableStore) BlameOwnershipSignalStore {
+	return &blameOwnershipSignalStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+// BlameOwnership is the share of an author of the lines of a file, or of all
+// the files within a directory, as attributed by git blame.
+type BlameOwnership struct {
+	// FilePath has no forward slash at the beginning, and the empty string
+	// designates the repository root.
+	FilePath    string
+	AuthorName  string
+	AuthorEmail string
+	// LinesCount is the number of surviving lines last changed by the author.
+	LinesCount int
+	// Share is the fraction of the lines attributed to the author, between 0
+	// and 1. Lines are weighted by age, so that recent changes count more.
+	Share float64
+}
+
+type blameOwnershipSignalStore struct {
+	*basestore.Store
+}
+
+func (s *blameOwnershipSignalStore) WithTransact(ctx context.Context, f func(store BlameOwnershipSignalStore) error) error {
+	return s.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		return f(BlameOwnershipSignalStoreWith(tx))
+	})
+}
+
+const clearBlameOwnershipSignalsFmtstr = `
+	DELETE FROM own_signal_blame_ownership
+	WHERE file_path_id IN (SELECT id FROM repo_paths WHERE repo_id = %s)
+`
+
+// ReplaceSignals makes sure all the given paths and authors exist in the
+// database, and stores the signals in `own_signal_blame_ownership` in place
+// of the previous ones, in a single transaction.
+func (s *blameOwnershipSignalStore) ReplaceSignals(ctx context.Context, repoID api.RepoID, signals []BlameOwnership) error {
+	return s.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		if err := tx.Exec(ctx, sqlf.Sprintf(clearBlameOwnershipSignalsFmtstr, repoID)); err != nil {
+			return errors.Wrap(err, "cannot clear signals")
+		}
+
+		paths := make([]string, 0, len(signals))
+		for _, signal := range signals {
+			paths = append(paths, signal.FilePath)
+		}
+		pathIDs, err := ensureRepoPaths(ctx, tx, paths, repoID)
+		if err != nil {
+			return errors.Wrap(err, "cannot insert repo paths")
+		}
+
+		type author struct{ name, email string }
+		authorIDs := map[author]int{}
+		values := make(chan []any, len(signals))
+		for i, signal := range signals {
+			a := author{signal.AuthorName, signal.AuthorEmail}
+			authorID, ok := authorIDs[a]
+			if !ok {
+				authorID, err = ensureCommitAuthor(ctx, tx, a.name, a.email)
+				if err != nil {
+					return errors.Wrap(err, "cannot insert commit author")
+				}
+				authorIDs[a] = authorID
+			}
+			values <- []any{authorID, pathIDs[i], signal.LinesCount, signal.Share}
+		}
+		close(values)
+
+		return batch.InsertValues(
+			ctx,
+			tx.Handle(),
+			"own_signal_blame_ownership",
+			batch.MaxNumPostgresParameters,
+			[]string{"commit_author_id", "file_path_id", "lines_count", "share"},
+			values,
+		)
+	})
+}
+
+const findBlameOwnersFmtstr = `
+	SELECT p.absolute_path, a.name, a.email, b.lines_count, b.share
+	FROM own_signal_blame_ownership AS b
+	INNER JOIN commit_authors AS a
+	ON a.id = b.commit_author_id
+	INNER JOIN repo_paths AS p
+	ON p.id = b.file_path_id
+	WHERE %s
+	ORDER BY b.share DESC, a.email
+`
+
+func (s *blameOwnershipSignalStore) FindBlameOwners(ctx context.Context, repoID api.RepoID, path string) ([]BlameOwnership, error) {
+	q := sqlf.Sprintf(findBlameOwnersFmtstr, sqlf.Sprintf("p.repo_id = %s AND p.absolute_path = %s", repoID, path))
+	return scanBlameOwnerships(s.Query(ctx, q))
+}
+
+func (s *blameOwnershipSignalStore) ListBlameOwnersForRepo(ctx context.Context, repoID api.RepoID) ([]BlameOwnership, error) {
+	q := sqlf.Sprintf(findBlameOwnersFmtstr, sqlf.Sprintf("p.repo_id = %s", repoID))
+	return scanBlameOwnerships(s.Query(ctx, q))
+}
+
+var scanBlameOwnerships = basestore.NewSliceScanner(func(scanner dbutil.Scanner) (BlameOwnership, error) {
+	var b BlameOwnership
+	err := scanner.Scan(&b.FilePath, &b.AuthorName, &b.AuthorEmail, &b.LinesCount, &b.Share)
+	return b, err
+})
@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestBlameOwnershipSignalStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	store := BlameOwnershipSignalStoreWith(db)
+
+	ctx := context.Background()
+	repo := mustCreate(ctx, t, db, &types.Repo{Name: "a/b"})
+	otherRepo := mustCreate(ctx, t, db, &types.Repo{Name: "a/c"})
+
+	signals := []BlameOwnership{
+		{FilePath: "", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 30, Share: 0.6},
+		{FilePath: "", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 20, Share: 0.4},
+		{FilePath: "dir", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 20, Share: 1},
+		{FilePath: "dir/file.go", AuthorName: "bob", AuthorEmail: "bob@example.com", LinesCount: 20, Share: 1},
+		{FilePath: "file.go", AuthorName: "alice", AuthorEmail: "alice@example.com", LinesCount: 30, Share: 1},
+	}
+	require.NoError(t, store.ReplaceSignals(ctx, repo.ID, signals))
+	require.NoError(t, store.ReplaceSignals(ctx, otherRepo.ID, signals[:1]))
+
+	got, err := store.FindBlameOwners(ctx, repo.ID, "")
+	require.NoError(t, err)
+	assert.Equal(t, signals[:2], got)
+
+	got, err = store.FindBlameOwners(ctx, repo.ID, "dir/file.go")
+	require.NoError(t, err)
+	assert.Equal(t, signals[3:4], got)
+
+	got, err = store.ListBlameOwnersForRepo(ctx, repo.ID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, signals, got)
+
+	// Replacing signals removes the previous ones of the repo only.
+	replaced := []BlameOwnership{
+		{FilePath: "file.go", AuthorName: "carol", AuthorEmail: "carol@example.com", LinesCount: 5, Share: 1},
+	}
+	require.NoError(t, store.ReplaceSignals(ctx, repo.ID, replaced))
+	got, err = store.ListBlameOwnersForRepo(ctx, repo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, replaced, got)
+
+	got, err = store.ListBlameOwnersForRepo(ctx, otherRepo.ID)
+	require.NoError(t, err)
+	assert.Equal(t, signals[:1], got)
+}
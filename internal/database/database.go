@@ -42,6 +42,7 @@ type DB interface {
 	OutboundWebhookLogs(encryption.Key) OutboundWebhookLogStore
 	OwnershipStats() OwnershipStatsStore
 	RecentContributionSignals() RecentContributionSignalStore
+	BlameOwnershipSignals() BlameOwnershipSignalStore
 	Permissions() PermissionStore
 	PermissionSyncJobs() PermissionSyncJobStore
 	Phabricator() PhabricatorStore
@@ -216,6 +217,10 @@ func (d *db) RecentContributionSignals() RecentContributionSignalStore {
 	return RecentContributionSignalStoreWith(d.Store)
 }
 
+func (d *db) BlameOwnershipSignals() BlameOwnershipSignalStore {
+	return BlameOwnershipSignalStoreWith(d.Store)
+}
+
 func (d *db) Permissions() PermissionStore {
 	return PermissionsWith(d.Store)
 }
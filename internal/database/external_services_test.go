@@ -196,6 +196,18 @@ func TestExternalServicesStore_ValidateConfig(t *testing.T) {
 			config:  `{"url": "https://dev.azure.com", "token": "token", "username": "username", "orgs":[]}`,
 			wantErr: "<nil>",
 		},
+		{
+			name:    "0 errors - Mercurial",
+			kind:    extsvc.VariantMercurial.AsKind(),
+			config:  `{"url": "https://hg.mycompany.org", "repos": ["a", "ssh://hg@hg.mycompany.org/b"]}`,
+			wantErr: "<nil>",
+		},
+		{
+			name:    "1 error - Mercurial",
+			kind:    extsvc.VariantMercurial.AsKind(),
+			config:  `{"url": "https://hg.mycompany.org", "repos": ["a", "file:///srv/hg/b"]}`,
+			wantErr: `repos.1: scheme "file" not one of http, https or ssh`,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
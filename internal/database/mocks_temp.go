@@ -4960,6 +4960,549 @@ func (c BitbucketProjectPermissionsStoreWithTransactFuncCall) Results() []interf
 	return []interface{}{c.Result0}
 }
 
+// MockBlameOwnershipSignalStore is a mock implementation of the
+// BlameOwnershipSignalStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockBlameOwnershipSignalStore struct {
+	// FindBlameOwnersFunc is an instance of a mock function object
+	// controlling the behavior of the method FindBlameOwners.
+	FindBlameOwnersFunc *BlameOwnershipSignalStoreFindBlameOwnersFunc
+	// ListBlameOwnersForRepoFunc is an instance of a mock function object
+	// controlling the behavior of the method ListBlameOwnersForRepo.
+	ListBlameOwnersForRepoFunc *BlameOwnershipSignalStoreListBlameOwnersForRepoFunc
+	// ReplaceSignalsFunc is an instance of a mock function object
+	// controlling the behavior of the method ReplaceSignals.
+	ReplaceSignalsFunc *BlameOwnershipSignalStoreReplaceSignalsFunc
+	// WithTransactFunc is an instance of a mock function object controlling
+	// the behavior of the method WithTransact.
+	WithTransactFunc *BlameOwnershipSignalStoreWithTransactFunc
+}
+
+// NewMockBlameOwnershipSignalStore creates a new mock of the
+// BlameOwnershipSignalStore interface. All methods return zero values for
+// all results, unless overwritten.
+func NewMockBlameOwnershipSignalStore() *MockBlameOwnershipSignalStore {
+	return &MockBlameOwnershipSignalStore{
+		FindBlameOwnersFunc: &BlameOwnershipSignalStoreFindBlameOwnersFunc{
+			defaultHook: func(context.Context, api.RepoID, string) (r0 []BlameOwnership, r1 error) {
+				return
+			},
+		},
+		ListBlameOwnersForRepoFunc: &BlameOwnershipSignalStoreListBlameOwnersForRepoFunc{
+			defaultHook: func(context.Context, api.RepoID) (r0 []BlameOwnership, r1 error) {
+				return
+			},
+		},
+		ReplaceSignalsFunc: &BlameOwnershipSignalStoreReplaceSignalsFunc{
+			defaultHook: func(context.Context, api.RepoID, []BlameOwnership) (r0 error) {
+				return
+			},
+		},
+		WithTransactFunc: &BlameOwnershipSignalStoreWithTransactFunc{
+			defaultHook: func(context.Context, func(store BlameOwnershipSignalStore) error) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockBlameOwnershipSignalStore creates a new mock of the
+// BlameOwnershipSignalStore interface. All methods panic on invocation,
+// unless overwritten.
+func NewStrictMockBlameOwnershipSignalStore() *MockBlameOwnershipSignalStore {
+	return &MockBlameOwnershipSignalStore{
+		FindBlameOwnersFunc: &BlameOwnershipSignalStoreFindBlameOwnersFunc{
+			defaultHook: func(context.Context, api.RepoID, string) ([]BlameOwnership, error) {
+				panic("unexpected invocation of MockBlameOwnershipSignalStore.FindBlameOwners")
+			},
+		},
+		ListBlameOwnersForRepoFunc: &BlameOwnershipSignalStoreListBlameOwnersForRepoFunc{
+			defaultHook: func(context.Context, api.RepoID) ([]BlameOwnership, error) {
+				panic("unexpected invocation of MockBlameOwnershipSignalStore.ListBlameOwnersForRepo")
+			},
+		},
+		ReplaceSignalsFunc: &BlameOwnershipSignalStoreReplaceSignalsFunc{
+			defaultHook: func(context.Context, api.RepoID, []BlameOwnership) error {
+				panic("unexpected invocation of MockBlameOwnershipSignalStore.ReplaceSignals")
+			},
+		},
+		WithTransactFunc: &BlameOwnershipSignalStoreWithTransactFunc{
+			defaultHook: func(context.Context, func(store BlameOwnershipSignalStore) error) error {
+				panic("unexpected invocation of MockBlameOwnershipSignalStore.WithTransact")
+			},
+		},
+	}
+}
+
+// NewMockBlameOwnershipSignalStoreFrom creates a new mock of the
+// MockBlameOwnershipSignalStore interface. All methods delegate to the
+// given implementation, unless overwritten.
+func NewMockBlameOwnershipSignalStoreFrom(i BlameOwnershipSignalStore) *MockBlameOwnershipSignalStore {
+	return &MockBlameOwnershipSignalStore{
+		FindBlameOwnersFunc: &BlameOwnershipSignalStoreFindBlameOwnersFunc{
+			defaultHook: i.FindBlameOwners,
+		},
+		ListBlameOwnersForRepoFunc: &BlameOwnershipSignalStoreListBlameOwnersForRepoFunc{
+			defaultHook: i.ListBlameOwnersForRepo,
+		},
+		ReplaceSignalsFunc: &BlameOwnershipSignalStoreReplaceSignalsFunc{
+			defaultHook: i.ReplaceSignals,
+		},
+		WithTransactFunc: &BlameOwnershipSignalStoreWithTransactFunc{
+			defaultHook: i.WithTransact,
+		},
+	}
+}
+
+// BlameOwnershipSignalStoreFindBlameOwnersFunc describes the behavior when
+// the FindBlameOwners method of the parent MockBlameOwnershipSignalStore
+// instance is invoked.
+type BlameOwnershipSignalStoreFindBlameOwnersFunc struct {
+	defaultHook func(context.Context, api.RepoID, string) ([]BlameOwnership, error)
+	hooks       []func(context.Context, api.RepoID, string) ([]BlameOwnership, error)
+	history     []BlameOwnershipSignalStoreFindBlameOwnersFuncCall
+	mutex       sync.Mutex
+}
+
+// FindBlameOwners delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockBlameOwnershipSignalStore) FindBlameOwners(v0 context.Context, v1 api.RepoID, v2 string) ([]BlameOwnership, error) {
+	r0, r1 := m.FindBlameOwnersFunc.nextHook()(v0, v1, v2)
+	m.FindBlameOwnersFunc.appendCall(BlameOwnershipSignalStoreFindBlameOwnersFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the FindBlameOwners
+// method of the parent MockBlameOwnershipSignalStore instance is invoked
+// and the hook queue is empty.
+func (f *BlameOwnershipSignalStoreFindBlameOwnersFunc) SetDefaultHook(hook func(context.Context, api.RepoID, string) ([]BlameOwnership, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// FindBlameOwners method of the parent MockBlameOwnershipSignalStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *BlameOwnershipSignalStoreFindBlameOwnersFunc) PushHook(hook func(context.Context, api.RepoID, string) ([]BlameOwnership, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BlameOwnershipSignalStoreFindBlameOwnersFunc) SetDefaultReturn(r0 []BlameOwnership, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, string) ([]BlameOwnership, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BlameOwnershipSignalStoreFindBlameOwnersFunc) PushReturn(r0 []BlameOwnership, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID, string) ([]BlameOwnership, error) {
+		return r0, r1
+	})
+}
+
+func (f *BlameOwnershipSignalStoreFindBlameOwnersFunc) nextHook() func(context.Context, api.RepoID, string) ([]BlameOwnership, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BlameOwnershipSignalStoreFindBlameOwnersFunc) appendCall(r0 BlameOwnershipSignalStoreFindBlameOwnersFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// BlameOwnershipSignalStoreFindBlameOwnersFuncCall objects describing the
+// invocations of this function.
+func (f *BlameOwnershipSignalStoreFindBlameOwnersFunc) History() []BlameOwnershipSignalStoreFindBlameOwnersFuncCall {
+	f.mutex.Lock()
+	history := make([]BlameOwnershipSignalStoreFindBlameOwnersFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BlameOwnershipSignalStoreFindBlameOwnersFuncCall is an object that
+// describes an invocation of method FindBlameOwners on an instance of
+// MockBlameOwnershipSignalStore.
+type BlameOwnershipSignalStoreFindBlameOwnersFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []BlameOwnership
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BlameOwnershipSignalStoreFindBlameOwnersFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BlameOwnershipSignalStoreFindBlameOwnersFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// BlameOwnershipSignalStoreListBlameOwnersForRepoFunc describes the
+// behavior when the ListBlameOwnersForRepo method of the parent
+// MockBlameOwnershipSignalStore instance is invoked.
+type BlameOwnershipSignalStoreListBlameOwnersForRepoFunc struct {
+	defaultHook func(context.Context, api.RepoID) ([]BlameOwnership, error)
+	hooks       []func(context.Context, api.RepoID) ([]BlameOwnership, error)
+	history     []BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall
+	mutex       sync.Mutex
+}
+
+// ListBlameOwnersForRepo delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockBlameOwnershipSignalStore) ListBlameOwnersForRepo(v0 context.Context, v1 api.RepoID) ([]BlameOwnership, error) {
+	r0, r1 := m.ListBlameOwnersForRepoFunc.nextHook()(v0, v1)
+	m.ListBlameOwnersForRepoFunc.appendCall(BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListBlameOwnersForRepo method of the parent MockBlameOwnershipSignalStore
+// instance is invoked and the hook queue is empty.
+func (f *BlameOwnershipSignalStoreListBlameOwnersForRepoFunc) SetDefaultHook(hook func(context.Context, api.RepoID) ([]BlameOwnership, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListBlameOwnersForRepo method of the parent MockBlameOwnershipSignalStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *BlameOwnershipSignalStoreListBlameOwnersForRepoFunc) PushHook(hook func(context.Context, api.RepoID) ([]BlameOwnership, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BlameOwnershipSignalStoreListBlameOwnersForRepoFunc) SetDefaultReturn(r0 []BlameOwnership, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID) ([]BlameOwnership, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BlameOwnershipSignalStoreListBlameOwnersForRepoFunc) PushReturn(r0 []BlameOwnership, r1 error) {
+	f.PushHook(func(context.Context, api.RepoID) ([]BlameOwnership, error) {
+		return r0, r1
+	})
+}
+
+func (f *BlameOwnershipSignalStoreListBlameOwnersForRepoFunc) nextHook() func(context.Context, api.RepoID) ([]BlameOwnership, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BlameOwnershipSignalStoreListBlameOwnersForRepoFunc) appendCall(r0 BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall objects
+// describing the invocations of this function.
+func (f *BlameOwnershipSignalStoreListBlameOwnersForRepoFunc) History() []BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall {
+	f.mutex.Lock()
+	history := make([]BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall is an object that
+// describes an invocation of method ListBlameOwnersForRepo on an instance
+// of MockBlameOwnershipSignalStore.
+type BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []BlameOwnership
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BlameOwnershipSignalStoreListBlameOwnersForRepoFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// BlameOwnershipSignalStoreReplaceSignalsFunc describes the behavior when
+// the ReplaceSignals method of the parent MockBlameOwnershipSignalStore
+// instance is invoked.
+type BlameOwnershipSignalStoreReplaceSignalsFunc struct {
+	defaultHook func(context.Context, api.RepoID, []BlameOwnership) error
+	hooks       []func(context.Context, api.RepoID, []BlameOwnership) error
+	history     []BlameOwnershipSignalStoreReplaceSignalsFuncCall
+	mutex       sync.Mutex
+}
+
+// ReplaceSignals delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockBlameOwnershipSignalStore) ReplaceSignals(v0 context.Context, v1 api.RepoID, v2 []BlameOwnership) error {
+	r0 := m.ReplaceSignalsFunc.nextHook()(v0, v1, v2)
+	m.ReplaceSignalsFunc.appendCall(BlameOwnershipSignalStoreReplaceSignalsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ReplaceSignals
+// method of the parent MockBlameOwnershipSignalStore instance is invoked
+// and the hook queue is empty.
+func (f *BlameOwnershipSignalStoreReplaceSignalsFunc) SetDefaultHook(hook func(context.Context, api.RepoID, []BlameOwnership) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReplaceSignals method of the parent MockBlameOwnershipSignalStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *BlameOwnershipSignalStoreReplaceSignalsFunc) PushHook(hook func(context.Context, api.RepoID, []BlameOwnership) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BlameOwnershipSignalStoreReplaceSignalsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, []BlameOwnership) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BlameOwnershipSignalStoreReplaceSignalsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, []BlameOwnership) error {
+		return r0
+	})
+}
+
+func (f *BlameOwnershipSignalStoreReplaceSignalsFunc) nextHook() func(context.Context, api.RepoID, []BlameOwnership) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BlameOwnershipSignalStoreReplaceSignalsFunc) appendCall(r0 BlameOwnershipSignalStoreReplaceSignalsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// BlameOwnershipSignalStoreReplaceSignalsFuncCall objects describing the
+// invocations of this function.
+func (f *BlameOwnershipSignalStoreReplaceSignalsFunc) History() []BlameOwnershipSignalStoreReplaceSignalsFuncCall {
+	f.mutex.Lock()
+	history := make([]BlameOwnershipSignalStoreReplaceSignalsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BlameOwnershipSignalStoreReplaceSignalsFuncCall is an object that
+// describes an invocation of method ReplaceSignals on an instance of
+// MockBlameOwnershipSignalStore.
+type BlameOwnershipSignalStoreReplaceSignalsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []BlameOwnership
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BlameOwnershipSignalStoreReplaceSignalsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BlameOwnershipSignalStoreReplaceSignalsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// BlameOwnershipSignalStoreWithTransactFunc describes the behavior when the
+// WithTransact method of the parent MockBlameOwnershipSignalStore instance
+// is invoked.
+type BlameOwnershipSignalStoreWithTransactFunc struct {
+	defaultHook func(context.Context, func(store BlameOwnershipSignalStore) error) error
+	hooks       []func(context.Context, func(store BlameOwnershipSignalStore) error) error
+	history     []BlameOwnershipSignalStoreWithTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// WithTransact delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockBlameOwnershipSignalStore) WithTransact(v0 context.Context, v1 func(store BlameOwnershipSignalStore) error) error {
+	r0 := m.WithTransactFunc.nextHook()(v0, v1)
+	m.WithTransactFunc.appendCall(BlameOwnershipSignalStoreWithTransactFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WithTransact method
+// of the parent MockBlameOwnershipSignalStore instance is invoked and the
+// hook queue is empty.
+func (f *BlameOwnershipSignalStoreWithTransactFunc) SetDefaultHook(hook func(context.Context, func(store BlameOwnershipSignalStore) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithTransact method of the parent MockBlameOwnershipSignalStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *BlameOwnershipSignalStoreWithTransactFunc) PushHook(hook func(context.Context, func(store BlameOwnershipSignalStore) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *BlameOwnershipSignalStoreWithTransactFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, func(store BlameOwnershipSignalStore) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *BlameOwnershipSignalStoreWithTransactFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, func(store BlameOwnershipSignalStore) error) error {
+		return r0
+	})
+}
+
+func (f *BlameOwnershipSignalStoreWithTransactFunc) nextHook() func(context.Context, func(store BlameOwnershipSignalStore) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *BlameOwnershipSignalStoreWithTransactFunc) appendCall(r0 BlameOwnershipSignalStoreWithTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// BlameOwnershipSignalStoreWithTransactFuncCall objects describing the
+// invocations of this function.
+func (f *BlameOwnershipSignalStoreWithTransactFunc) History() []BlameOwnershipSignalStoreWithTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]BlameOwnershipSignalStoreWithTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// BlameOwnershipSignalStoreWithTransactFuncCall is an object that describes
+// an invocation of method WithTransact on an instance of
+// MockBlameOwnershipSignalStore.
+type BlameOwnershipSignalStoreWithTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 func(store BlameOwnershipSignalStore) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c BlameOwnershipSignalStoreWithTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c BlameOwnershipSignalStoreWithTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockConfStore is a mock implementation of the ConfStore interface (from
 // the package github.com/sourcegraph/sourcegraph/internal/database) used
 // for unit testing.
@@ -5863,6 +6406,9 @@ type MockDB struct {
 	// object controlling the behavior of the method
 	// BitbucketProjectPermissions.
 	BitbucketProjectPermissionsFunc *DBBitbucketProjectPermissionsFunc
+	// BlameOwnershipSignalsFunc is an instance of a mock function object
+	// controlling the behavior of the method BlameOwnershipSignals.
+	BlameOwnershipSignalsFunc *DBBlameOwnershipSignalsFunc
 	// ConfFunc is an instance of a mock function object controlling the
 	// behavior of the method Conf.
 	ConfFunc *DBConfFunc
@@ -6059,6 +6605,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		BlameOwnershipSignalsFunc: &DBBlameOwnershipSignalsFunc{
+			defaultHook: func() (r0 BlameOwnershipSignalStore) {
+				return
+			},
+		},
 		ConfFunc: &DBConfFunc{
 			defaultHook: func() (r0 ConfStore) {
 				return
@@ -6361,6 +6912,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.BitbucketProjectPermissions")
 			},
 		},
+		BlameOwnershipSignalsFunc: &DBBlameOwnershipSignalsFunc{
+			defaultHook: func() BlameOwnershipSignalStore {
+				panic("unexpected invocation of MockDB.BlameOwnershipSignals")
+			},
+		},
 		ConfFunc: &DBConfFunc{
 			defaultHook: func() ConfStore {
 				panic("unexpected invocation of MockDB.Conf")
@@ -6651,6 +7207,9 @@ func NewMockDBFrom(i DB) *MockDB {
 		BitbucketProjectPermissionsFunc: &DBBitbucketProjectPermissionsFunc{
 			defaultHook: i.BitbucketProjectPermissions,
 		},
+		BlameOwnershipSignalsFunc: &DBBlameOwnershipSignalsFunc{
+			defaultHook: i.BlameOwnershipSignals,
+		},
 		ConfFunc: &DBConfFunc{
 			defaultHook: i.Conf,
 		},
@@ -7406,6 +7965,105 @@ func (c DBBitbucketProjectPermissionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBBlameOwnershipSignalsFunc describes the behavior when the
+// BlameOwnershipSignals method of the parent MockDB instance is invoked.
+type DBBlameOwnershipSignalsFunc struct {
+	defaultHook func() BlameOwnershipSignalStore
+	hooks       []func() BlameOwnershipSignalStore
+	history     []DBBlameOwnershipSignalsFuncCall
+	mutex       sync.Mutex
+}
+
+// BlameOwnershipSignals delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDB) BlameOwnershipSignals() BlameOwnershipSignalStore {
+	r0 := m.BlameOwnershipSignalsFunc.nextHook()()
+	m.BlameOwnershipSignalsFunc.appendCall(DBBlameOwnershipSignalsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// BlameOwnershipSignals method of the parent MockDB instance is invoked and
+// the hook queue is empty.
+func (f *DBBlameOwnershipSignalsFunc) SetDefaultHook(hook func() BlameOwnershipSignalStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// BlameOwnershipSignals method of the parent MockDB instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBBlameOwnershipSignalsFunc) PushHook(hook func() BlameOwnershipSignalStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBBlameOwnershipSignalsFunc) SetDefaultReturn(r0 BlameOwnershipSignalStore) {
+	f.SetDefaultHook(func() BlameOwnershipSignalStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBBlameOwnershipSignalsFunc) PushReturn(r0 BlameOwnershipSignalStore) {
+	f.PushHook(func() BlameOwnershipSignalStore {
+		return r0
+	})
+}
+
+func (f *DBBlameOwnershipSignalsFunc) nextHook() func() BlameOwnershipSignalStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBBlameOwnershipSignalsFunc) appendCall(r0 DBBlameOwnershipSignalsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBBlameOwnershipSignalsFuncCall objects
+// describing the invocations of this function.
+func (f *DBBlameOwnershipSignalsFunc) History() []DBBlameOwnershipSignalsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBBlameOwnershipSignalsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBBlameOwnershipSignalsFuncCall is an object that describes an invocation
+// of method BlameOwnershipSignals on an instance of MockDB.
+type DBBlameOwnershipSignalsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 BlameOwnershipSignalStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBBlameOwnershipSignalsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBBlameOwnershipSignalsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBConfFunc describes the behavior when the Conf method of the parent
 // MockDB instance is invoked.
 type DBConfFunc struct {
@@ -32489,6 +33147,9 @@ type MockOrgMemberStore struct {
 	// function object controlling the behavior of the method
 	// CreateMembershipInOrgsForAllUsers.
 	CreateMembershipInOrgsForAllUsersFunc *OrgMemberStoreCreateMembershipInOrgsForAllUsersFunc
+	// CreateSCIMControlledFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateSCIMControlled.
+	CreateSCIMControlledFunc *OrgMemberStoreCreateSCIMControlledFunc
 	// GetByOrgIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetByOrgID.
 	GetByOrgIDFunc *OrgMemberStoreGetByOrgIDFunc
@@ -32534,6 +33195,11 @@ func NewMockOrgMemberStore() *MockOrgMemberStore {
 				return
 			},
 		},
+		CreateSCIMControlledFunc: &OrgMemberStoreCreateSCIMControlledFunc{
+			defaultHook: func(context.Context, int32, int32) (r0 *types.OrgMembership, r1 error) {
+				return
+			},
+		},
 		GetByOrgIDFunc: &OrgMemberStoreGetByOrgIDFunc{
 			defaultHook: func(context.Context, int32) (r0 []*types.OrgMembership, r1 error) {
 				return
@@ -32596,6 +33262,11 @@ func NewStrictMockOrgMemberStore() *MockOrgMemberStore {
 				panic("unexpected invocation of MockOrgMemberStore.CreateMembershipInOrgsForAllUsers")
 			},
 		},
+		CreateSCIMControlledFunc: &OrgMemberStoreCreateSCIMControlledFunc{
+			defaultHook: func(context.Context, int32, int32) (*types.OrgMembership, error) {
+				panic("unexpected invocation of MockOrgMemberStore.CreateSCIMControlled")
+			},
+		},
 		GetByOrgIDFunc: &OrgMemberStoreGetByOrgIDFunc{
 			defaultHook: func(context.Context, int32) ([]*types.OrgMembership, error) {
 				panic("unexpected invocation of MockOrgMemberStore.GetByOrgID")
@@ -32653,6 +33324,9 @@ func NewMockOrgMemberStoreFrom(i OrgMemberStore) *MockOrgMemberStore {
 		CreateMembershipInOrgsForAllUsersFunc: &OrgMemberStoreCreateMembershipInOrgsForAllUsersFunc{
 			defaultHook: i.CreateMembershipInOrgsForAllUsers,
 		},
+		CreateSCIMControlledFunc: &OrgMemberStoreCreateSCIMControlledFunc{
+			defaultHook: i.CreateSCIMControlled,
+		},
 		GetByOrgIDFunc: &OrgMemberStoreGetByOrgIDFunc{
 			defaultHook: i.GetByOrgID,
 		},
@@ -33015,6 +33689,120 @@ func (c OrgMemberStoreCreateMembershipInOrgsForAllUsersFuncCall) Results() []int
 	return []interface{}{c.Result0}
 }
 
+// OrgMemberStoreCreateSCIMControlledFunc describes the behavior when the
+// CreateSCIMControlled method of the parent MockOrgMemberStore instance is
+// invoked.
+type OrgMemberStoreCreateSCIMControlledFunc struct {
+	defaultHook func(context.Context, int32, int32) (*types.OrgMembership, error)
+	hooks       []func(context.Context, int32, int32) (*types.OrgMembership, error)
+	history     []OrgMemberStoreCreateSCIMControlledFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateSCIMControlled delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockOrgMemberStore) CreateSCIMControlled(v0 context.Context, v1 int32, v2 int32) (*types.OrgMembership, error) {
+	r0, r1 := m.CreateSCIMControlledFunc.nextHook()(v0, v1, v2)
+	m.CreateSCIMControlledFunc.appendCall(OrgMemberStoreCreateSCIMControlledFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateSCIMControlled
+// method of the parent MockOrgMemberStore instance is invoked and the hook
+// queue is empty.
+func (f *OrgMemberStoreCreateSCIMControlledFunc) SetDefaultHook(hook func(context.Context, int32, int32) (*types.OrgMembership, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateSCIMControlled method of the parent MockOrgMemberStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OrgMemberStoreCreateSCIMControlledFunc) PushHook(hook func(context.Context, int32, int32) (*types.OrgMembership, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OrgMemberStoreCreateSCIMControlledFunc) SetDefaultReturn(r0 *types.OrgMembership, r1 error) {
+	f.SetDefaultHook(func(context.Context, int32, int32) (*types.OrgMembership, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OrgMemberStoreCreateSCIMControlledFunc) PushReturn(r0 *types.OrgMembership, r1 error) {
+	f.PushHook(func(context.Context, int32, int32) (*types.OrgMembership, error) {
+		return r0, r1
+	})
+}
+
+func (f *OrgMemberStoreCreateSCIMControlledFunc) nextHook() func(context.Context, int32, int32) (*types.OrgMembership, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OrgMemberStoreCreateSCIMControlledFunc) appendCall(r0 OrgMemberStoreCreateSCIMControlledFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OrgMemberStoreCreateSCIMControlledFuncCall
+// objects describing the invocations of this function.
+func (f *OrgMemberStoreCreateSCIMControlledFunc) History() []OrgMemberStoreCreateSCIMControlledFuncCall {
+	f.mutex.Lock()
+	history := make([]OrgMemberStoreCreateSCIMControlledFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OrgMemberStoreCreateSCIMControlledFuncCall is an object that describes an
+// invocation of method CreateSCIMControlled on an instance of
+// MockOrgMemberStore.
+type OrgMemberStoreCreateSCIMControlledFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.OrgMembership
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OrgMemberStoreCreateSCIMControlledFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OrgMemberStoreCreateSCIMControlledFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // OrgMemberStoreGetByOrgIDFunc describes the behavior when the GetByOrgID
 // method of the parent MockOrgMemberStore instance is invoked.
 type OrgMemberStoreGetByOrgIDFunc struct {
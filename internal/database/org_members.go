@@ -19,6 +19,9 @@ type OrgMemberStore interface {
 	AutocompleteMembersSearch(ctx context.Context, OrgID int32, query string) ([]*types.OrgMemberAutocompleteSearchItem, error)
 	WithTransact(context.Context, func(OrgMemberStore) error) error
 	Create(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error)
+	// CreateSCIMControlled creates a membership that is granted because the user is a
+	// member of a SCIM group that is mapped to the organization.
+	CreateSCIMControlled(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error)
 	GetByUserID(ctx context.Context, userID int32) ([]*types.OrgMembership, error)
 	GetByOrgIDAndUserID(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error)
 	MemberCount(ctx context.Context, orgID int32) (int, error)
@@ -47,14 +50,18 @@ func (m *orgMemberStore) WithTransact(ctx context.Context, f func(OrgMemberStore
 }
 
 func (m *orgMemberStore) Create(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error) {
-	om := types.OrgMembership{
-		OrgID:  orgID,
-		UserID: userID,
-	}
+	return m.create(ctx, types.OrgMembership{OrgID: orgID, UserID: userID})
+}
+
+func (m *orgMemberStore) CreateSCIMControlled(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+	return m.create(ctx, types.OrgMembership{OrgID: orgID, UserID: userID, SCIMControlled: true})
+}
+
+func (m *orgMemberStore) create(ctx context.Context, om types.OrgMembership) (*types.OrgMembership, error) {
 	err := m.Handle().QueryRowContext(
 		ctx,
-		"INSERT INTO org_members(org_id, user_id) VALUES($1, $2) RETURNING id, created_at, updated_at",
-		om.OrgID, om.UserID).Scan(&om.ID, &om.CreatedAt, &om.UpdatedAt)
+		"INSERT INTO org_members(org_id, user_id, scim_controlled) VALUES($1, $2, $3) RETURNING id, created_at, updated_at",
+		om.OrgID, om.UserID, om.SCIMControlled).Scan(&om.ID, &om.CreatedAt, &om.UpdatedAt)
 	if err != nil {
 		var e *pgconn.PgError
 		if errors.As(err, &e) && e.ConstraintName == "org_members_org_id_user_id_key" {
@@ -153,7 +160,7 @@ func (m *orgMemberStore) getOneBySQL(ctx context.Context, query string, args ...
 }
 
 func (m *orgMemberStore) getBySQL(ctx context.Context, query string, args ...any) ([]*types.OrgMembership, error) {
-	rows, err := m.Handle().QueryContext(ctx, "SELECT org_members.id, org_members.org_id, org_members.user_id, org_members.created_at, org_members.updated_at FROM org_members "+query, args...)
+	rows, err := m.Handle().QueryContext(ctx, "SELECT org_members.id, org_members.org_id, org_members.user_id, org_members.created_at, org_members.updated_at, org_members.scim_controlled FROM org_members "+query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +169,7 @@ func (m *orgMemberStore) getBySQL(ctx context.Context, query string, args ...any
 	defer rows.Close()
 	for rows.Next() {
 		m := types.OrgMembership{}
-		err := rows.Scan(&m.ID, &m.OrgID, &m.UserID, &m.CreatedAt, &m.UpdatedAt)
+		err := rows.Scan(&m.ID, &m.OrgID, &m.UserID, &m.CreatedAt, &m.UpdatedAt, &m.SCIMControlled)
 		if err != nil {
 			return nil, err
 		}
@@ -113,6 +113,51 @@ func TestOrgMembers_CreateMembershipInOrgsForAllUsers(t *testing.T) {
 	}
 }
 
+func TestOrgMembers_CreateSCIMControlled(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	org, err := db.Orgs().Create(ctx, "org", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user1, err := db.Users().Create(ctx, NewUser{Username: "u1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user2, err := db.Users().Create(ctx, NewUser{Username: "u2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.OrgMembers().Create(ctx, org.ID, user1.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.OrgMembers().CreateSCIMControlled(ctx, org.ID, user2.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		userID int32
+		want   bool
+	}{
+		{userID: user1.ID, want: false},
+		{userID: user2.ID, want: true},
+	} {
+		membership, err := db.OrgMembers().GetByOrgIDAndUserID(ctx, org.ID, test.userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if membership.SCIMControlled != test.want {
+			t.Errorf("user %d: got SCIMControlled %t, want %t", test.userID, membership.SCIMControlled, test.want)
+		}
+	}
+}
+
 func TestOrgMembers_MemberCount(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
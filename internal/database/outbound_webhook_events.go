@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+)
+
+// EnqueueOutboundWebhookEvent creates an outbound webhook job for the given
+// event type and payload. Event types and payloads are defined in
+// internal/webhooks/outbound/events.
+//
+// No job is created if no outbound webhook is subscribed to the event type.
+//
+// The job is created in a savepoint on the given store, so that it is only
+// dispatched if any enclosing transaction commits, while a failure to create
+// the job does not abort that transaction. Errors are logged, not returned:
+// webhooks are fire and forget from the point of view of the calling code.
+func EnqueueOutboundWebhookEvent(ctx context.Context, logger log.Logger, store basestore.ShareableStore, eventType string, payload any) {
+	logger = logger.With(log.String("event_type", eventType))
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("error marshalling webhook payload", log.Error(err))
+		return
+	}
+
+	err = basestore.NewWithHandle(store.Handle()).WithTransact(ctx, func(tx *basestore.Store) error {
+		key := keyring.Default().OutboundWebhookKey
+		subscribed, err := OutboundWebhooksWith(tx, key).Count(ctx, OutboundWebhookCountOpts{
+			EventTypes: []FilterEventType{{EventType: eventType}},
+		})
+		if err != nil || subscribed == 0 {
+			return err
+		}
+
+		_, err = OutboundWebhookJobsWith(tx, key).Create(ctx, eventType, nil, data)
+		return err
+	})
+	if err != nil {
+		logger.Error("error enqueuing webhook job", log.Error(err))
+	}
+}
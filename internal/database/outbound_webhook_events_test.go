@@ -0,0 +1,114 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
+)
+
+func TestEnqueueOutboundWebhookEvent(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+
+	admin := createTestUserWithoutRoles(t, db, "admin", true)
+	jobs := db.OutboundWebhookJobs(nil)
+
+	assertLastJob := func(t *testing.T, eventType string, payload events.User) {
+		t.Helper()
+
+		job, err := jobs.GetLast(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, eventType, job.EventType)
+
+		want, err := json.Marshal(payload)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), decryptedValue(t, ctx, job.Payload))
+	}
+
+	t.Run("no subscribers", func(t *testing.T) {
+		_, err := db.Users().Create(ctx, NewUser{Username: "unsubscribed"})
+		require.NoError(t, err)
+
+		_, err = jobs.GetLast(ctx)
+		assert.True(t, errcode.IsNotFound(err))
+	})
+
+	require.NoError(t, db.OutboundWebhooks(nil).Create(ctx, newTestWebhook(t, admin,
+		ScopedEventType{EventType: events.UserCreated},
+		ScopedEventType{EventType: events.UserDeleted},
+		ScopedEventType{EventType: events.UserRoleChanged},
+	)))
+
+	user, err := db.Users().Create(ctx, NewUser{Username: "u1"})
+	require.NoError(t, err)
+
+	t.Run("user created", func(t *testing.T) {
+		assertLastJob(t, events.UserCreated, events.User{
+			ID:       events.MarshalUserID(user.ID),
+			Username: "u1",
+		})
+	})
+
+	role := createTestRoleForUserRole(ctx, "TESTROLE", t, db)
+	otherRole := createTestRoleForUserRole(ctx, "OTHERTESTROLE", t, db)
+
+	t.Run("role assigned", func(t *testing.T) {
+		require.NoError(t, db.UserRoles().Assign(ctx, AssignUserRoleOpts{UserID: user.ID, RoleID: role.ID}))
+		assertLastJob(t, events.UserRoleChanged, events.User{
+			ID:         events.MarshalUserID(user.ID),
+			RolesAdded: events.MarshalRoleIDs([]int32{role.ID}),
+		})
+	})
+
+	t.Run("roles set", func(t *testing.T) {
+		// System roles of the user are kept, such that only the given roles change.
+		userRoles, err := db.UserRoles().GetByUserID(ctx, GetUserRoleOpts{UserID: user.ID})
+		require.NoError(t, err)
+		roles := []int32{otherRole.ID}
+		for _, userRole := range userRoles {
+			if userRole.RoleID != role.ID {
+				roles = append(roles, userRole.RoleID)
+			}
+		}
+
+		require.NoError(t, db.UserRoles().SetRolesForUser(ctx, SetRolesForUserOpts{UserID: user.ID, Roles: roles}))
+		assertLastJob(t, events.UserRoleChanged, events.User{
+			ID:           events.MarshalUserID(user.ID),
+			RolesAdded:   events.MarshalRoleIDs([]int32{otherRole.ID}),
+			RolesRemoved: events.MarshalRoleIDs([]int32{role.ID}),
+		})
+	})
+
+	t.Run("role revoked", func(t *testing.T) {
+		require.NoError(t, db.UserRoles().Revoke(ctx, RevokeUserRoleOpts{UserID: user.ID, RoleID: otherRole.ID}))
+		assertLastJob(t, events.UserRoleChanged, events.User{
+			ID:           events.MarshalUserID(user.ID),
+			RolesRemoved: events.MarshalRoleIDs([]int32{otherRole.ID}),
+		})
+	})
+
+	t.Run("user deleted", func(t *testing.T) {
+		require.NoError(t, db.Users().Delete(ctx, user.ID))
+		assertLastJob(t, events.UserDeleted, events.User{
+			ID: events.MarshalUserID(user.ID),
+		})
+	})
+
+	t.Run("user hard deleted", func(t *testing.T) {
+		require.NoError(t, db.Users().HardDelete(ctx, user.ID))
+		assertLastJob(t, events.UserDeleted, events.User{
+			ID:         events.MarshalUserID(user.ID),
+			HardDelete: true,
+		})
+	})
+}
@@ -537,6 +537,8 @@ func scanRepo(logger log.Logger, rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = new(pagure.Project)
 	case extsvc.TypeOther:
 		r.Metadata = new(extsvc.OtherRepoMetadata)
+	case extsvc.VariantMercurial.AsType():
+		r.Metadata = new(extsvc.MercurialRepoMetadata)
 	case extsvc.TypeJVMPackages:
 		r.Metadata = new(reposource.MavenMetadata)
 	case extsvc.TypeNpmPackages:
@@ -592,6 +594,10 @@ type ReposListOptions struct {
 	// A set of filters to select only repos with the given set of topics
 	TopicFilters []RepoTopicFilter
 
+	// A set of filters to select only repos whose lockfiles declare the given
+	// dependencies
+	DependencyFilters []RepoDependencyFilter
+
 	// CaseSensitivePatterns determines if IncludePatterns and ExcludePattern are treated
 	// with case sensitivity or not.
 	CaseSensitivePatterns bool
@@ -744,6 +750,19 @@ type RepoTopicFilter struct {
 	Negated bool
 }
 
+type RepoDependencyFilter struct {
+	// Scheme is the package scheme of the dependency. If empty, dependencies
+	// of any scheme match.
+	Scheme string
+	Name   string
+	// If Versions is non-nil, this filter will select only repos that depend
+	// on one of the given versions. An empty non-nil slice matches no version.
+	Versions []string
+	// If negated is true, this filter will select only repos
+	// that do _not_ have the associated dependency
+	Negated bool
+}
+
 type RepoListOrderBy []RepoListSort
 
 func (r RepoListOrderBy) SQL() *sqlf.Query {
@@ -1205,6 +1224,28 @@ func (s *repoStore) listSQL(ctx context.Context, tr *trace.Trace, opt ReposListO
 		where = append(where, sqlf.Join(ands, "AND"))
 	}
 
+	if len(opt.DependencyFilters) > 0 {
+		var ands []*sqlf.Query
+		for _, filter := range opt.DependencyFilters {
+			conds := []*sqlf.Query{
+				sqlf.Sprintf("lr.repository_id = repo.id"),
+				sqlf.Sprintf("lr.name = %s", filter.Name),
+			}
+			if filter.Scheme != "" {
+				conds = append(conds, sqlf.Sprintf("lr.scheme = %s", filter.Scheme))
+			}
+			if filter.Versions != nil {
+				conds = append(conds, sqlf.Sprintf("lr.version = ANY(%s)", pq.Array(filter.Versions)))
+			}
+			cond := sqlf.Sprintf("EXISTS (SELECT 1 FROM codeintel_lockfile_references lr WHERE %s)", sqlf.Join(conds, "AND"))
+			if filter.Negated {
+				cond = sqlf.Sprintf("NOT %s", cond)
+			}
+			ands = append(ands, cond)
+		}
+		where = append(where, sqlf.Join(ands, "AND"))
+	}
+
 	baseConds := sqlf.Sprintf("TRUE")
 	if !opt.IncludeDeleted {
 		baseConds = sqlf.Sprintf("repo.deleted_at IS NULL")
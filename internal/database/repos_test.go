@@ -1385,6 +1385,74 @@ func TestRepos_List_topics(t *testing.T) {
 	}
 }
 
+func TestRepos_List_dependencies(t *testing.T) {
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := actor.WithInternalActor(context.Background())
+
+	ids := func(id int) func(r *types.Repo) {
+		return func(r *types.Repo) {
+			r.ExternalRepo.ID = strconv.Itoa(id)
+			r.Name = api.RepoName(strconv.Itoa(id))
+		}
+	}
+
+	r1 := typestest.MakeGithubRepo().With(ids(1))
+	r2 := typestest.MakeGithubRepo().With(ids(2))
+	r3 := typestest.MakeGithubRepo().With(ids(3))
+	if err := db.Repos().Create(ctx, r1, r2, r3); err != nil {
+		t.Fatal(err)
+	}
+
+	addDependencies := func(repo *types.Repo, deps ...[3]string) {
+		var indexID int
+		if err := db.Handle().QueryRowContext(ctx, `
+			INSERT INTO codeintel_lockfile_indexes (repository_id, commit_bytea) VALUES ($1, '\x00') RETURNING id
+		`, repo.ID).Scan(&indexID); err != nil {
+			t.Fatal(err)
+		}
+		for _, dep := range deps {
+			if _, err := db.Handle().ExecContext(ctx, `
+				INSERT INTO codeintel_lockfile_references (lockfile_index_id, repository_id, lockfile, scheme, name, version)
+				VALUES ($1, $2, 'lockfile', $3, $4, $5)
+			`, indexID, repo.ID, dep[0], dep[1], dep[2]); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	addDependencies(r1, [3]string{"npm", "lodash", "4.17.20"}, [3]string{"go", "github.com/sourcegraph/log", "v0.1.0"})
+	addDependencies(r2, [3]string{"npm", "lodash", "4.17.21"})
+
+	tests := []struct {
+		name string
+		opt  ReposListOptions
+		want []*types.Repo
+	}{
+		{"lodash", ReposListOptions{DependencyFilters: []RepoDependencyFilter{{Name: "lodash"}}}, []*types.Repo{r1, r2}},
+		{"npm lodash", ReposListOptions{DependencyFilters: []RepoDependencyFilter{{Scheme: "npm", Name: "lodash"}}}, []*types.Repo{r1, r2}},
+		{"go lodash", ReposListOptions{DependencyFilters: []RepoDependencyFilter{{Scheme: "go", Name: "lodash"}}}, nil},
+		{"lodash versions", ReposListOptions{DependencyFilters: []RepoDependencyFilter{{Name: "lodash", Versions: []string{"4.17.21"}}}}, []*types.Repo{r2}},
+		{"lodash no versions", ReposListOptions{DependencyFilters: []RepoDependencyFilter{{Name: "lodash", Versions: []string{}}}}, nil},
+		{"not lodash", ReposListOptions{DependencyFilters: []RepoDependencyFilter{{Name: "lodash", Negated: true}}}, []*types.Repo{r3}},
+		{
+			"lodash not log",
+			ReposListOptions{DependencyFilters: []RepoDependencyFilter{{Name: "lodash"}, {Name: "github.com/sourcegraph/log", Negated: true}}},
+			[]*types.Repo{r2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repos, err := db.Repos().List(ctx, test.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			require.Equal(t, test.want, repos)
+		})
+	}
+}
+
 func TestRepos_ListMinimalRepos(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
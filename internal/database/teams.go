@@ -31,6 +31,8 @@ type ListTeamsOpts struct {
 	Search string
 	// List teams that a specific user is a member of.
 	ForUserMember int32
+	// Only return teams that are provisioned through SCIM.
+	SCIMControlled bool
 }
 
 func (opts ListTeamsOpts) SQL() (where, joins, ctes []*sqlf.Query) {
@@ -49,6 +51,9 @@ func (opts ListTeamsOpts) SQL() (where, joins, ctes []*sqlf.Query) {
 		term := "%" + opts.Search + "%"
 		where = append(where, sqlf.Sprintf("(teams.name ILIKE %s OR teams.display_name ILIKE %s)", term, term))
 	}
+	if opts.SCIMControlled {
+		where = append(where, sqlf.Sprintf("teams.scim_controlled"))
+	}
 	if opts.ForUserMember != 0 {
 		joins = append(joins, sqlf.Sprintf("JOIN team_members ON team_members.team_id = teams.id"))
 		where = append(where, sqlf.Sprintf("team_members.user_id = %s", opts.ForUserMember))
@@ -401,6 +406,8 @@ func (s *teamStore) CreateTeam(ctx context.Context, team *types.Team) (*types.Te
 		dbutil.NewNullInt32(team.CreatorID),
 		team.CreatedAt,
 		team.UpdatedAt,
+		team.SCIMControlled,
+		dbutil.NewNullString(team.SCIMExternalID),
 		sqlf.Join(teamColumns, ","),
 	)
 
@@ -449,7 +456,7 @@ func (s *teamStore) CreateTeam(ctx context.Context, team *types.Team) (*types.Te
 const createTeamQueryFmtstr = `
 INSERT INTO teams
 (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -471,6 +478,7 @@ func (s *teamStore) UpdateTeam(ctx context.Context, team *types.Team) error {
 		updateTeamQueryFmtstr,
 		dbutil.NewNullString(team.DisplayName),
 		dbutil.NewNullInt32(team.ParentTeamID),
+		dbutil.NewNullString(team.SCIMExternalID),
 		team.UpdatedAt,
 		sqlf.Join(conds, "AND"),
 		sqlf.Join(teamColumns, ","),
@@ -485,6 +493,7 @@ UPDATE
 SET
 	display_name = %s,
 	parent_team_id = %s,
+	scim_external_id = %s,
 	updated_at = %s
 WHERE
 	%s
@@ -624,6 +633,8 @@ var teamColumns = []*sqlf.Query{
 	sqlf.Sprintf("teams.creator_id"),
 	sqlf.Sprintf("teams.created_at"),
 	sqlf.Sprintf("teams.updated_at"),
+	sqlf.Sprintf("teams.scim_controlled"),
+	sqlf.Sprintf("teams.scim_external_id"),
 }
 
 var teamInsertColumns = []*sqlf.Query{
@@ -634,6 +645,8 @@ var teamInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("creator_id"),
 	sqlf.Sprintf("created_at"),
 	sqlf.Sprintf("updated_at"),
+	sqlf.Sprintf("scim_controlled"),
+	sqlf.Sprintf("scim_external_id"),
 }
 
 var teamMemberColumns = []*sqlf.Query{
@@ -673,6 +686,8 @@ func scanTeam(sc dbutil.Scanner, t *types.Team) error {
 		&dbutil.NullInt32{N: &t.CreatorID},
 		&t.CreatedAt,
 		&t.UpdatedAt,
+		&t.SCIMControlled,
+		&dbutil.NullString{S: &t.SCIMExternalID},
 	)
 }
 
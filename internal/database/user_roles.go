@@ -6,10 +6,12 @@ import (
 	"fmt"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -148,6 +150,8 @@ func (r *userRoleStore) Assign(ctx context.Context, opts AssignUserRoleOpts) err
 		}
 		return errors.Wrap(err, "scanning user role")
 	}
+
+	r.enqueueRoleChangedEvent(ctx, opts.UserID, []int32{opts.RoleID}, nil)
 	return nil
 }
 
@@ -169,7 +173,7 @@ func (r *userRoleStore) AssignSystemRole(ctx context.Context, opts AssignSystemR
 		sqlf.Join(userRoleColumns, ", "),
 	)
 
-	_, err := scanUserRole(r.QueryRow(ctx, q))
+	ur, err := scanUserRole(r.QueryRow(ctx, q))
 	if err != nil {
 		// If there are no rows returned, it means that the user has already being assigned the role.
 		// In that case, we don't need to return an error.
@@ -178,16 +182,30 @@ func (r *userRoleStore) AssignSystemRole(ctx context.Context, opts AssignSystemR
 		}
 		return errors.Wrap(err, "scanning user role")
 	}
+
+	r.enqueueRoleChangedEvent(ctx, opts.UserID, []int32{ur.RoleID}, nil)
 	return nil
 }
 
 func (r *userRoleStore) BulkAssignRolesToUser(ctx context.Context, opts BulkAssignRolesToUserOpts) error {
+	added, err := r.bulkAssignRolesToUser(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	r.enqueueRoleChangedEvent(ctx, opts.UserID, added, nil)
+	return nil
+}
+
+// bulkAssignRolesToUser assigns the given roles to a user, and returns the IDs
+// of the roles that weren't previously assigned.
+func (r *userRoleStore) bulkAssignRolesToUser(ctx context.Context, opts BulkAssignRolesToUserOpts) ([]int32, error) {
 	if opts.UserID == 0 {
-		return errors.New("missing user id")
+		return nil, errors.New("missing user id")
 	}
 
 	if len(opts.Roles) == 0 {
-		return errors.New("missing role ids")
+		return nil, errors.New("missing role ids")
 	}
 
 	var urs []*sqlf.Query
@@ -204,18 +222,25 @@ func (r *userRoleStore) BulkAssignRolesToUser(ctx context.Context, opts BulkAssi
 	)
 
 	var scanUserRoles = basestore.NewSliceScanner(scanUserRole)
-	_, err := scanUserRoles(r.Query(ctx, q))
+	userRoles, err := scanUserRoles(r.Query(ctx, q))
 	if err != nil {
 		// If there are no rows returned, it means that the user has already being assigned the role.
 		// In that case, we don't need to return an error.
 		if err == sql.ErrNoRows {
-			return nil
+			return nil, nil
 		}
-		return err
+		return nil, err
 	}
-	return nil
+
+	added := make([]int32, 0, len(userRoles))
+	for _, ur := range userRoles {
+		added = append(added, ur.RoleID)
+	}
+	return added, nil
 }
 
+// BulkAssignSystemRolesToUser doesn't enqueue a user:role_changed webhook event:
+// it is only used when creating users, which is covered by user:created.
 func (r *userRoleStore) BulkAssignSystemRolesToUser(ctx context.Context, opts BulkAssignSystemRolesToUserOpts) error {
 	if opts.UserID == 0 {
 		return errors.New("user id is required")
@@ -268,6 +293,7 @@ func (e *UserRoleNotFoundErr) NotFound() bool {
 const revokeUserRoleQueryFmtStr = `
 DELETE FROM user_roles
 WHERE %s
+RETURNING role_id
 `
 
 func (r *userRoleStore) Revoke(ctx context.Context, opts RevokeUserRoleOpts) error {
@@ -301,6 +327,7 @@ func (r *userRoleStore) Revoke(ctx context.Context, opts RevokeUserRoleOpts) err
 		}, "failed to revoke user role")
 	}
 
+	r.enqueueRoleChangedEvent(ctx, opts.UserID, nil, []int32{opts.RoleID})
 	return nil
 }
 
@@ -320,21 +347,34 @@ func (r *userRoleStore) RevokeSystemRole(ctx context.Context, opts RevokeSystemR
 		sqlf.Sprintf("user_id = %s AND role_id = (%s)", opts.UserID, roleQuery),
 	)
 
-	_, err := r.ExecResult(ctx, q)
+	removed, err := basestore.ScanInt32s(r.Query(ctx, q))
 	if err != nil {
 		return errors.Wrap(err, "running delete query")
 	}
 
+	r.enqueueRoleChangedEvent(ctx, opts.UserID, nil, removed)
 	return nil
 }
 
 func (r *userRoleStore) BulkRevokeRolesForUser(ctx context.Context, opts BulkRevokeRolesForUserOpts) error {
+	removed, err := r.bulkRevokeRolesForUser(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	r.enqueueRoleChangedEvent(ctx, opts.UserID, nil, removed)
+	return nil
+}
+
+// bulkRevokeRolesForUser revokes the given roles from a user, and returns the
+// IDs of the roles that were previously assigned.
+func (r *userRoleStore) bulkRevokeRolesForUser(ctx context.Context, opts BulkRevokeRolesForUserOpts) ([]int32, error) {
 	if opts.UserID == 0 {
-		return errors.New("missing user id")
+		return nil, errors.New("missing user id")
 	}
 
 	if len(opts.Roles) == 0 {
-		return errors.New("missing roles")
+		return nil, errors.New("missing roles")
 	}
 
 	var preds []*sqlf.Query
@@ -352,7 +392,7 @@ func (r *userRoleStore) BulkRevokeRolesForUser(ctx context.Context, opts BulkRev
 		sqlf.Join(preds, " AND "),
 	)
 
-	return r.Exec(ctx, q)
+	return basestore.ScanInt32s(r.Query(ctx, q))
 }
 
 func (r *userRoleStore) GetByUserID(ctx context.Context, opts GetUserRoleOpts) ([]*types.UserRole, error) {
@@ -436,7 +476,9 @@ func (r *userRoleStore) SetRolesForUser(ctx context.Context, opts SetRolesForUse
 		return errors.New("missing user id")
 	}
 
-	return r.WithTransact(ctx, func(tx UserRoleStore) error {
+	return r.Store.WithTransact(ctx, func(txStore *basestore.Store) error {
+		tx := &userRoleStore{Store: txStore}
+
 		// look up the current roles assigned to the user. We use this to determine which roles to assign and revoke.
 		userRoles, err := tx.GetByUserID(ctx, GetUserRoleOpts{UserID: opts.UserID})
 		if err != nil {
@@ -483,8 +525,9 @@ func (r *userRoleStore) SetRolesForUser(ctx context.Context, opts SetRolesForUse
 		}
 
 		// If we have new permissions to be added, we insert into the database via the transaction created earlier.
+		var added, removed []int32
 		if len(toBeAdded) > 0 {
-			if err = tx.BulkAssignRolesToUser(ctx, BulkAssignRolesToUserOpts{
+			if added, err = tx.bulkAssignRolesToUser(ctx, BulkAssignRolesToUserOpts{
 				UserID: opts.UserID,
 				Roles:  toBeAdded,
 			}); err != nil {
@@ -494,7 +537,7 @@ func (r *userRoleStore) SetRolesForUser(ctx context.Context, opts SetRolesForUse
 
 		// If we have new permissions to be removed, we remove from the database via the transaction created earlier.
 		if len(toBeDeleted) > 0 {
-			if err = tx.BulkRevokeRolesForUser(ctx, BulkRevokeRolesForUserOpts{
+			if removed, err = tx.bulkRevokeRolesForUser(ctx, BulkRevokeRolesForUserOpts{
 				UserID: opts.UserID,
 				Roles:  toBeDeleted,
 			}); err != nil {
@@ -502,6 +545,22 @@ func (r *userRoleStore) SetRolesForUser(ctx context.Context, opts SetRolesForUse
 			}
 		}
 
+		// Both changes are reported in a single event.
+		tx.enqueueRoleChangedEvent(ctx, opts.UserID, added, removed)
 		return nil
 	})
 }
+
+// enqueueRoleChangedEvent enqueues a user:role_changed outbound webhook event,
+// provided that any roles were actually added or removed.
+func (r *userRoleStore) enqueueRoleChangedEvent(ctx context.Context, userID int32, added, removed []int32) {
+	if len(added) == 0 && len(removed) == 0 {
+		return
+	}
+
+	EnqueueOutboundWebhookEvent(ctx, log.Scoped("userRoleStore", "database userRoleStore"), r.Store, events.UserRoleChanged, events.User{
+		ID:           events.MarshalUserID(userID),
+		RolesAdded:   events.MarshalRoleIDs(added),
+		RolesRemoved: events.MarshalRoleIDs(removed),
+	})
+}
@@ -32,6 +32,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -430,6 +431,12 @@ func (u *userStore) CreateInTransaction(ctx context.Context, info NewUser, spec
 		}
 	}
 
+	EnqueueOutboundWebhookEvent(ctx, u.logger, u.Store, events.UserCreated, events.User{
+		ID:        events.MarshalUserID(user.ID),
+		Username:  user.Username,
+		SiteAdmin: user.SiteAdmin,
+	})
+
 	return user, nil
 }
 
@@ -644,6 +651,7 @@ func (u *userStore) DeleteList(ctx context.Context, ids []int32) (err error) {
 	}
 
 	logUserDeletionEvents(ctx, NewDBWith(u.logger, u), ids, SecurityEventNameAccountDeleted)
+	enqueueUserDeletedEvents(ctx, u.logger, tx, ids, false)
 
 	return nil
 }
@@ -732,10 +740,20 @@ func (u *userStore) HardDeleteList(ctx context.Context, ids []int32) (err error)
 	}
 
 	logUserDeletionEvents(ctx, NewDBWith(u.logger, u), ids, SecurityEventNameAccountNuked)
+	enqueueUserDeletedEvents(ctx, u.logger, tx, ids, true)
 
 	return nil
 }
 
+func enqueueUserDeletedEvents(ctx context.Context, logger log.Logger, store basestore.ShareableStore, ids []int32, hard bool) {
+	for _, id := range ids {
+		EnqueueOutboundWebhookEvent(ctx, logger, store, events.UserDeleted, events.User{
+			ID:         events.MarshalUserID(id),
+			HardDelete: hard,
+		})
+	}
+}
+
 func logUserDeletionEvents(ctx context.Context, db DB, ids []int32, name SecurityEventName) {
 	// The actor deleting the user could be a different user, for example a site
 	// admin
@@ -170,6 +170,17 @@ type RestCommit struct {
 	Verification Verification        `json:"verification"`
 }
 
+// An Issue in a Repository, from the REST API.
+type RestIssue struct {
+	ID      int64  `json:"id"`
+	NodeID  string `json:"node_id"`
+	Number  int64  `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	HTMLURL string `json:"html_url"`
+}
+
 type Verification struct {
 	Verified  bool   `json:"verified"`
 	Reason    string `json:"reason"`
@@ -862,6 +862,22 @@ func (c *V3Client) UpdateRef(ctx context.Context, owner, repo, ref, commit strin
 	return &updatedRef, nil
 }
 
+// CreateIssue creates an issue in the given repository.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#create-an-issue
+func (c *V3Client) CreateIssue(ctx context.Context, owner, repo, title, body string) (*RestIssue, error) {
+	payload := struct {
+		Title string `json:"title"`
+		Body  string `json:"body,omitempty"`
+	}{Title: title, Body: body}
+
+	var issue RestIssue
+	if _, err := c.post(ctx, "repos/"+owner+"/"+repo+"/issues", payload, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
 // GetAppInstallation gets information of a GitHub App installation.
 //
 // API docs: https://docs.github.com/en/rest/reference/apps#get-an-installation-for-the-authenticated-app
@@ -866,6 +866,37 @@ func TestV3Client_CreateCommit(t *testing.T) {
 	})
 }
 
+func TestV3Client_CreateIssue(t *testing.T) {
+	rcache.SetupForTest(t)
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/sourcegraph/automation-testing/issues", r.URL.Path)
+
+		var payload map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		assert.Equal(t, map[string]string{"title": "New match", "body": "Found a match"}, payload)
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":1,"number":42,"title":"New match","body":"Found a match","state":"open","html_url":"https://github.com/sourcegraph/automation-testing/issues/42"}`))
+	}))
+	defer testServer.Close()
+
+	uri, _ := url.Parse(testServer.URL)
+	cli := NewV3Client(logtest.Scoped(t), "Test", uri, nil, testServer.Client())
+
+	issue, err := cli.CreateIssue(context.Background(), "sourcegraph", "automation-testing", "New match", "Found a match")
+	require.NoError(t, err)
+	assert.Equal(t, &RestIssue{
+		ID:      1,
+		Number:  42,
+		Title:   "New match",
+		Body:    "Found a match",
+		State:   "open",
+		HTMLURL: "https://github.com/sourcegraph/automation-testing/issues/42",
+	}, issue)
+}
+
 func TestV3Client_UpdateRef(t *testing.T) {
 	ctx := context.Background()
 	t.Run("success", func(t *testing.T) {
@@ -0,0 +1,57 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type Issue struct {
+	ID          ID     `json:"id"`
+	IID         ID     `json:"iid"`
+	ProjectID   ID     `json:"project_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	WebURL      string `json:"web_url"`
+}
+
+type CreateIssueOpts struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	// TODO: other fields at
+	// https://docs.gitlab.com/ee/api/issues.html#new-issue as needed.
+}
+
+// CreateIssue creates an issue in the given project.
+func (c *Client) CreateIssue(ctx context.Context, project *Project, opts CreateIssueOpts) (*Issue, error) {
+	if MockCreateIssue != nil {
+		return MockCreateIssue(c, ctx, project, opts)
+	}
+
+	data, err := json.Marshal(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling options")
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%d/issues", project.ID), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to create an issue")
+	}
+
+	resp := &Issue{}
+	if _, code, err := c.do(ctx, req, resp); err != nil {
+		if aerr := c.convertToArchivedError(ctx, err, project); aerr != nil {
+			return nil, aerr
+		}
+
+		return nil, errors.Wrap(errcode.MaybeMakeNonRetryable(code, err), "sending request to create an issue")
+	}
+
+	return resp, nil
+}
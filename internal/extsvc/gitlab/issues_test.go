@@ -0,0 +1,62 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateIssue(t *testing.T) {
+	ctx := context.Background()
+	project := &Project{}
+
+	t.Run("error status code", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusInternalServerError}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "New match"})
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("malformed response", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `this is not valid JSON`,
+		}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "New match"})
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"iid":42,"title":"New match","web_url":"https://gitlab.com/sourcegraph/sourcegraph/-/issues/42"}`,
+		}
+
+		issue, err := client.CreateIssue(ctx, project, CreateIssueOpts{Title: "New match"})
+		if err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+		want := &Issue{
+			IID:    42,
+			Title:  "New match",
+			WebURL: "https://gitlab.com/sourcegraph/sourcegraph/-/issues/42",
+		}
+		if diff := cmp.Diff(issue, want); diff != "" {
+			t.Errorf("unexpected issue: %s", diff)
+		}
+	})
+}
@@ -53,5 +53,8 @@ var MockMergeMergeRequest func(c *Client, ctx context.Context, project *Project,
 // Client.CreateMergeRequestNote
 var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Project, mr *MergeRequest, body string) error
 
+// MockCreateIssue, if non-nil, will be called instead of Client.CreateIssue
+var MockCreateIssue func(c *Client, ctx context.Context, project *Project, opts CreateIssueOpts) (*Issue, error)
+
 // MockGetVersion, if non-nil, will be called instead of Client.GetVersion
 var MockGetVersion func(ctx context.Context) (string, error)
@@ -212,6 +212,9 @@ const (
 
 	// VariantLocalGit is the (api.ExternalRepoSpec).ServiceType for local git repositories
 	VariantLocalGit
+
+	// VariantMercurial is the (api.ExternalRepoSpec).ServiceType value for Mercurial repositories.
+	VariantMercurial
 )
 
 type variantValues struct {
@@ -233,6 +236,7 @@ var variantValuesMap = map[Variant]variantValues{
 	VariantGitolite:        {AsKind: "GITOLITE", AsType: "gitolite", ConfigPrototype: func() any { return &schema.GitoliteConnection{} }, SupportsRepoExclusion: true},
 	VariantGoPackages:      {AsKind: "GOMODULES", AsType: "goModules", ConfigPrototype: func() any { return &schema.GoModulesConnection{} }},
 	VariantJVMPackages:     {AsKind: "JVMPACKAGES", AsType: "jvmPackages", ConfigPrototype: func() any { return &schema.JVMPackagesConnection{} }},
+	VariantMercurial:       {AsKind: "MERCURIAL", AsType: "mercurial", ConfigPrototype: func() any { return &schema.MercurialConnection{} }},
 	VariantNpmPackages:     {AsKind: "NPMPACKAGES", AsType: "npmPackages", ConfigPrototype: func() any { return &schema.NpmPackagesConnection{} }},
 	VariantOther:           {AsKind: "OTHER", AsType: "other", ConfigPrototype: func() any { return &schema.OtherExternalServiceConnection{} }},
 	VariantPagure:          {AsKind: "PAGURE", AsType: "pagure", ConfigPrototype: func() any { return &schema.PagureConnection{} }},
@@ -757,6 +761,12 @@ type OtherRepoMetadata struct {
 	AbsFilePath string
 }
 
+type MercurialRepoMetadata struct {
+	// RelativePath is relative to ServiceID which is the base URL of the
+	// connection. Joining them gives you the clone url.
+	RelativePath string
+}
+
 type LocalGitMetadata struct {
 	// AbsFilePath is the absolute path to the local repository. The path can also
 	// be extracted from the repo's URN, but storing it separately makes it easier
@@ -813,6 +823,8 @@ func uniqueCodeHostIdentifier(kind string, cfg any) (string, error) {
 		rawURL = c.Url
 	case *schema.OtherExternalServiceConnection:
 		rawURL = c.Url
+	case *schema.MercurialConnection:
+		rawURL = c.Url
 	case *schema.GitoliteConnection:
 		rawURL = c.Host
 	case *schema.AWSCodeCommitConnection:
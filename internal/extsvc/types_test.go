@@ -52,6 +52,9 @@ func TestVariantConfigPrototypePointers(t *testing.T) {
 	if y, ok := VariantJVMPackages.ConfigPrototype().(*schema.JVMPackagesConnection); !ok {
 		t.Errorf("wrong type for JVM Packages configuration prototype: %T", y)
 	}
+	if y, ok := VariantMercurial.ConfigPrototype().(*schema.MercurialConnection); !ok {
+		t.Errorf("wrong type for Mercurial configuration prototype: %T", y)
+	}
 	if y, ok := VariantNpmPackages.ConfigPrototype().(*schema.NpmPackagesConnection); !ok {
 		t.Errorf("wrong type for NPM Packages configuration prototype: %T", y)
 	}
@@ -31,14 +31,15 @@ var addrForRepoInvoked = promauto.NewCounterVec(prometheus.CounterOpts{
 	Help: "Number of times gitserver.AddrForRepo was invoked",
 }, []string{"user_agent"})
 
-// NewGitserverAddressesFromConf fetches the current set of gitserver addresses
-// and pinned repos for gitserver.
+// NewGitserverAddressesFromConf fetches the current set of gitserver addresses,
+// pinned repos and the replication factor for gitserver.
 func NewGitserverAddressesFromConf(cfg *conf.Unified) GitserverAddresses {
 	addrs := GitserverAddresses{
 		Addresses: cfg.ServiceConnectionConfig.GitServers,
 	}
 	if cfg.ExperimentalFeatures != nil {
 		addrs.PinnedServers = cfg.ExperimentalFeatures.GitServerPinnedRepos
+		addrs.ReplicationFactor = cfg.ExperimentalFeatures.GitServerReplicationFactor
 	}
 	return addrs
 }
@@ -126,6 +127,22 @@ func (c *testGitserverConns) ConnForRepo(userAgent string, repo api.RepoName) (*
 	return c.conns.ConnForRepo(userAgent, repo)
 }
 
+// AddrsForRepo returns the addresses of the gitservers that the given repo is
+// stored on.
+func (c *testGitserverConns) AddrsForRepo(userAgent string, repo api.RepoName) []string {
+	return c.conns.AddrsForRepo(userAgent, repo)
+}
+
+// ClientForAddr returns a client for the gitserver with the given address.
+func (c *testGitserverConns) ClientForAddr(addr string) (proto.GitserverServiceClient, error) {
+	conn, err := c.conns.ConnForAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.clientFunc(conn), nil
+}
+
 type testConnAndErr struct {
 	address    string
 	conn       *grpc.ClientConn
@@ -154,6 +171,10 @@ type GitserverAddresses struct {
 	// ensures that, even if the number of gitservers changes, these repos will
 	// not be moved.
 	PinnedServers map[string]string
+
+	// The number of gitserver instances that each repo is stored on. Values
+	// smaller than 2 disable replication.
+	ReplicationFactor int
 }
 
 // AddrForRepo returns the gitserver address to use for the given repo name.
@@ -170,6 +191,17 @@ func (g GitserverAddresses) AddrForRepo(userAgent string, repo api.RepoName) str
 	return addrForKey(rs, g.Addresses)
 }
 
+// AddrsForRepo returns the addresses of the gitservers that the given repo is
+// stored on. The first address is the one returned by AddrForRepo, and the
+// replicas are the addresses that follow it in Addresses.
+func (g GitserverAddresses) AddrsForRepo(userAgent string, repo api.RepoName) []string {
+	addr := g.AddrForRepo(userAgent, repo)
+	if g.ReplicationFactor < 2 {
+		return []string{addr}
+	}
+	return replicaAddrs(addr, g.Addresses, g.ReplicationFactor)
+}
+
 // addrForKey returns the gitserver address to use for the given string key,
 // which is hashed for sharding purposes.
 func addrForKey(key string, addrs []string) string {
@@ -178,6 +210,28 @@ func addrForKey(key string, addrs []string) string {
 	return addrs[serverIndex]
 }
 
+// replicaAddrs returns addr followed by the n-1 addresses that follow it in
+// addrs, wrapping around at the end of addrs.
+func replicaAddrs(addr string, addrs []string, n int) []string {
+	start := slices.Index(addrs, addr)
+	if start < 0 {
+		// The repo is pinned to an address that is not in the list. We still
+		// replicate it, starting from the beginning of the list.
+		start = len(addrs) - 1
+	}
+	if n > len(addrs) {
+		n = len(addrs)
+	}
+
+	replicas := []string{addr}
+	for i := 1; len(replicas) < n && i <= len(addrs); i++ {
+		if a := addrs[(start+i)%len(addrs)]; a != addr {
+			replicas = append(replicas, a)
+		}
+	}
+	return replicas
+}
+
 type GitserverConns struct {
 	GitserverAddresses
 	// invariant: there is one conn for every gitserver address
@@ -185,7 +239,10 @@ type GitserverConns struct {
 }
 
 func (g *GitserverConns) ConnForRepo(userAgent string, repo api.RepoName) (*grpc.ClientConn, error) {
-	addr := g.AddrForRepo(userAgent, repo)
+	return g.ConnForAddr(g.AddrForRepo(userAgent, repo))
+}
+
+func (g *GitserverConns) ConnForAddr(addr string) (*grpc.ClientConn, error) {
 	ce, ok := g.grpcConns[addr]
 	if !ok {
 		return nil, errors.Newf("no gRPC connection found for address %q", addr)
@@ -234,6 +291,18 @@ func (a *atomicGitServerConns) ConnForRepo(userAgent string, repo api.RepoName)
 	return a.get().ConnForRepo(userAgent, repo)
 }
 
+func (a *atomicGitServerConns) AddrsForRepo(userAgent string, repo api.RepoName) []string {
+	return a.get().AddrsForRepo(userAgent, repo)
+}
+
+func (a *atomicGitServerConns) ClientForAddr(addr string) (proto.GitserverServiceClient, error) {
+	conn, err := a.get().ConnForAddr(addr)
+	if err != nil {
+		return nil, err
+	}
+	return proto.NewGitserverServiceClient(conn), nil
+}
+
 func (a *atomicGitServerConns) Addresses() []AddressWithClient {
 	conns := a.get()
 	addrs := make([]AddressWithClient, 0, len(conns.Addresses))
@@ -3,6 +3,8 @@ package gitserver
 import (
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
@@ -50,3 +52,54 @@ func TestAddrForRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestAddrsForRepo(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+
+	testCases := []struct {
+		name              string
+		repo              api.RepoName
+		replicationFactor int
+		pinned            map[string]string
+		want              []string
+	}{
+		{
+			name: "no replication",
+			repo: api.RepoName("repo1"),
+			want: []string{"gitserver-3"},
+		},
+		{
+			name:              "replicas wrap around",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-3", "gitserver-1"},
+		},
+		{
+			name:              "replication factor larger than number of gitservers",
+			repo:              api.RepoName("github.com/sourcegraph/sourcegraph"),
+			replicationFactor: 5,
+			want:              []string{"gitserver-2", "gitserver-3", "gitserver-1"},
+		},
+		{
+			name:              "pinned to unknown gitserver",
+			repo:              api.RepoName("repo2"),
+			replicationFactor: 2,
+			pinned:            map[string]string{"repo2": "gitserver-4"},
+			want:              []string{"gitserver-4", "gitserver-1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ga := GitserverAddresses{
+				Addresses:         addrs,
+				PinnedServers:     tc.pinned,
+				ReplicationFactor: tc.replicationFactor,
+			}
+			got := ga.AddrsForRepo("gitserver", tc.repo)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected addresses (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -77,6 +77,11 @@ type ClientSource interface {
 	ConnForRepo(userAgent string, repo api.RepoName) (*grpc.ClientConn, error)
 	// AddrForRepo returns the address of the gitserver for the given repo.
 	AddrForRepo(userAgent string, repo api.RepoName) string
+	// AddrsForRepo returns the addresses of the gitservers that the given repo
+	// is replicated to, starting with the address returned by AddrForRepo.
+	AddrsForRepo(userAgent string, repo api.RepoName) []string
+	// ClientForAddr returns a Client for the gitserver with the given address.
+	ClientForAddr(addr string) (proto.GitserverServiceClient, error)
 	// Address the current list of gitserver addresses.
 	Addresses() []AddressWithClient
 }
@@ -317,6 +322,9 @@ type Client interface {
 	// Repo updates are not guaranteed to occur. If a repo has been updated
 	// recently (within the Since duration specified in the request), the
 	// update won't happen.
+	//
+	// If the repo is replicated, all of its replicas are updated and the
+	// response of the first gitserver that is available is returned.
 	RequestRepoUpdate(context.Context, api.RepoName, time.Duration) (*protocol.RepoUpdateResponse, error)
 
 	// RequestRepoClone is an asynchronous request to clone a repository.
@@ -472,6 +480,14 @@ func (c *clientImplementor) ConnForRepo(repo api.RepoName) (*grpc.ClientConn, er
 	return c.clientSource.ConnForRepo(c.userAgent, repo)
 }
 
+func (c *clientImplementor) AddrsForRepo(repo api.RepoName) []string {
+	return c.clientSource.AddrsForRepo(c.userAgent, repo)
+}
+
+func (c *clientImplementor) ClientForAddr(addr string) (proto.GitserverServiceClient, error) {
+	return c.clientSource.ClientForAddr(addr)
+}
+
 // ArchiveOptions contains options for the Archive func.
 type ArchiveOptions struct {
 	Treeish   string               // the tree or commit to produce an archive for
@@ -556,8 +572,8 @@ func (a *archiveReader) Close() error {
 }
 
 // archiveURL returns a URL from which an archive of the given Git repository can
-// be downloaded from.
-func (c *clientImplementor) archiveURL(repo api.RepoName, opt ArchiveOptions) *url.URL {
+// be downloaded from the gitserver with the given address.
+func (c *clientImplementor) archiveURL(addr string, repo api.RepoName, opt ArchiveOptions) *url.URL {
 	q := url.Values{
 		"repo":    {string(repo)},
 		"treeish": {opt.Treeish},
@@ -568,10 +584,9 @@ func (c *clientImplementor) archiveURL(repo api.RepoName, opt ArchiveOptions) *u
 		q.Add("path", string(pathspec))
 	}
 
-	addrForRepo := c.AddrForRepo(repo)
 	return &url.URL{
 		Scheme:   "http",
-		Host:     addrForRepo,
+		Host:     addr,
 		Path:     "/archive",
 		RawQuery: q.Encode(),
 	}
@@ -605,12 +620,9 @@ func (c *RemoteGitCommand) sendExec(ctx context.Context) (_ io.ReadCloser, err e
 		return nil, err
 	}
 
-	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.execer.ClientForRepo(repoName)
-		if err != nil {
-			return nil, err
-		}
+	addrs := c.execer.AddrsForRepo(repoName)
 
+	if internalgrpc.IsGRPCEnabled(ctx) {
 		req := &proto.ExecRequest{
 			Repo:           string(repoName),
 			EnsureRevision: c.EnsureRevision(),
@@ -619,21 +631,33 @@ func (c *RemoteGitCommand) sendExec(ctx context.Context) (_ io.ReadCloser, err e
 			NoTimeout:      c.noTimeout,
 		}
 
-		stream, err := client.Exec(ctx, req)
-		if err != nil {
-			return nil, err
-		}
-		r := streamio.NewReader(func() ([]byte, error) {
-			msg, err := stream.Recv()
-			if status.Code(err) == codes.Canceled {
-				return nil, context.Canceled
-			} else if err != nil {
+		return withReplicas(ctx, "exec", addrs, func(addr string) (io.ReadCloser, error) {
+			client, err := c.execer.ClientForAddr(addr)
+			if err != nil {
 				return nil, err
 			}
-			return msg.GetData(), nil
-		})
 
-		return &readCloseWrapper{r: r, closeFn: done}, nil
+			stream, err := client.Exec(ctx, req)
+			if err != nil {
+				return nil, err
+			}
+
+			recv, err := recvFirst(stream.Recv)
+			if err != nil {
+				return nil, err
+			}
+			r := streamio.NewReader(func() ([]byte, error) {
+				msg, err := recv()
+				if status.Code(err) == codes.Canceled {
+					return nil, context.Canceled
+				} else if err != nil {
+					return nil, err
+				}
+				return msg.GetData(), nil
+			})
+
+			return &readCloseWrapper{r: r, closeFn: done}, nil
+		})
 
 	} else {
 		req := &protocol.ExecRequest{
@@ -643,28 +667,31 @@ func (c *RemoteGitCommand) sendExec(ctx context.Context) (_ io.ReadCloser, err e
 			Stdin:          c.stdin,
 			NoTimeout:      c.noTimeout,
 		}
-		resp, err := c.execer.httpPost(ctx, repoName, "exec", req)
-		if err != nil {
-			return nil, err
-		}
-
-		switch resp.StatusCode {
-		case http.StatusOK:
-			return &cmdReader{rc: &readCloseWrapper{r: resp.Body, closeFn: done}, trailer: resp.Trailer}, nil
 
-		case http.StatusNotFound:
-			var payload protocol.NotFoundPayload
-			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-				resp.Body.Close()
+		return withReplicas(ctx, "exec", addrs, func(addr string) (io.ReadCloser, error) {
+			resp, err := c.execer.httpPostTo(ctx, addr, repoName, "exec", req)
+			if err != nil {
 				return nil, err
 			}
-			resp.Body.Close()
-			return nil, &gitdomain.RepoNotExistError{Repo: repoName, CloneInProgress: payload.CloneInProgress, CloneProgress: payload.CloneProgress}
 
-		default:
-			resp.Body.Close()
-			return nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
-		}
+			switch resp.StatusCode {
+			case http.StatusOK:
+				return &cmdReader{rc: &readCloseWrapper{r: resp.Body, closeFn: done}, trailer: resp.Trailer}, nil
+
+			case http.StatusNotFound:
+				var payload protocol.NotFoundPayload
+				if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+					resp.Body.Close()
+					return nil, err
+				}
+				resp.Body.Close()
+				return nil, &gitdomain.RepoNotExistError{Repo: repoName, CloneInProgress: payload.CloneInProgress, CloneProgress: payload.CloneProgress}
+
+			default:
+				resp.Body.Close()
+				return nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
+			}
+		})
 	}
 }
 
@@ -762,20 +789,28 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 
 	repoName := protocol.NormalizeRepo(args.Repo)
 
+	addrs := c.AddrsForRepo(repoName)
+
 	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(repoName)
-		if err != nil {
-			return false, err
-		}
+		recv, err := withReplicas(ctx, "search", addrs, func(addr string) (func() (*proto.SearchResponse, error), error) {
+			client, err := c.ClientForAddr(addr)
+			if err != nil {
+				return nil, err
+			}
 
-		cs, err := client.Search(ctx, args.ToProto())
+			cs, err := client.Search(ctx, args.ToProto())
+			if err != nil {
+				return nil, err
+			}
+			return recvFirst(cs.Recv)
+		})
 		if err != nil {
 			return false, convertGitserverError(err)
 		}
 
 		limitHit := false
 		for {
-			msg, err := cs.Recv()
+			msg, err := recv()
 			if err != nil {
 				return limitHit, convertGitserverError(err)
 			}
@@ -791,8 +826,6 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 		}
 	}
 
-	addrForRepo := c.AddrForRepo(repoName)
-
 	protocol.RegisterGob()
 	var buf bytes.Buffer
 	enc := gob.NewEncoder(&buf)
@@ -800,8 +833,10 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 		return false, err
 	}
 
-	uri := "http://" + addrForRepo + "/search"
-	resp, err := c.do(ctx, repoName, "POST", uri, buf.Bytes())
+	resp, err := withReplicas(ctx, "search", addrs, func(addr string) (*http.Response, error) {
+		uri := "http://" + addr + "/search"
+		return c.do(ctx, repoName, "POST", uri, buf.Bytes())
+	})
 	if err != nil {
 		return false, err
 	}
@@ -1167,8 +1202,15 @@ func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.Repo
 		Since: since,
 	}
 
+	// The replicas are kept in sync by updating all of them at once.
+	return onAllReplicas(c.logger, "repo-update", repo, c.AddrsForRepo(repo), func(addr string) (*protocol.RepoUpdateResponse, error) {
+		return c.requestRepoUpdate(ctx, addr, req)
+	})
+}
+
+func (c *clientImplementor) requestRepoUpdate(ctx context.Context, addr string, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
 	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(repo)
+		client, err := c.ClientForAddr(addr)
 		if err != nil {
 			return nil, err
 		}
@@ -1184,7 +1226,7 @@ func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.Repo
 		return &info, nil
 
 	} else {
-		resp, err := c.httpPost(ctx, repo, "repo-update", req)
+		resp, err := c.httpPostTo(ctx, addr, req.Repo, "repo-update", req)
 		if err != nil {
 			return nil, err
 		}
@@ -1204,9 +1246,16 @@ func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.Repo
 }
 
 // RequestRepoClone requests that the gitserver does an asynchronous clone of the repository.
+// The clone is requested from all the gitservers that store the repo.
 func (c *clientImplementor) RequestRepoClone(ctx context.Context, repo api.RepoName) (*protocol.RepoCloneResponse, error) {
+	return onAllReplicas(c.logger, "repo-clone", repo, c.AddrsForRepo(repo), func(addr string) (*protocol.RepoCloneResponse, error) {
+		return c.requestRepoClone(ctx, addr, repo)
+	})
+}
+
+func (c *clientImplementor) requestRepoClone(ctx context.Context, addr string, repo api.RepoName) (*protocol.RepoCloneResponse, error) {
 	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(repo)
+		client, err := c.ClientForAddr(addr)
 		if err != nil {
 			return nil, err
 		}
@@ -1229,7 +1278,7 @@ func (c *clientImplementor) RequestRepoClone(ctx context.Context, repo api.RepoN
 		req := &protocol.RepoCloneRequest{
 			Repo: repo,
 		}
-		resp, err := c.httpPost(ctx, repo, "repo-clone", req)
+		resp, err := c.httpPostTo(ctx, addr, repo, "repo-clone", req)
 		if err != nil {
 			return nil, err
 		}
@@ -1256,12 +1305,34 @@ func (c *clientImplementor) IsRepoCloneable(ctx context.Context, repo api.RepoNa
 		return MockIsRepoCloneable(repo)
 	}
 
-	var resp protocol.IsRepoCloneableResponse
+	resp, err := withReplicas(ctx, "is-repo-cloneable", c.AddrsForRepo(repo), func(addr string) (protocol.IsRepoCloneableResponse, error) {
+		return c.isRepoCloneable(ctx, addr, repo)
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Cloneable {
+		return nil
+	}
 
+	// Treat all 4xx errors as not found, since we have more relaxed
+	// requirements on what a valid URL is we should treat bad requests,
+	// etc as not found.
+	notFound := strings.Contains(resp.Reason, "not found") || strings.Contains(resp.Reason, "The requested URL returned error: 4")
+	return &RepoNotCloneableErr{
+		repo:     repo,
+		reason:   resp.Reason,
+		notFound: notFound,
+		cloned:   resp.Cloned,
+	}
+}
+
+func (c *clientImplementor) isRepoCloneable(ctx context.Context, addr string, repo api.RepoName) (resp protocol.IsRepoCloneableResponse, err error) {
 	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(repo)
+		client, err := c.ClientForAddr(addr)
 		if err != nil {
-			return err
+			return resp, err
 		}
 
 		req := &proto.IsRepoCloneableRequest{
@@ -1270,44 +1341,27 @@ func (c *clientImplementor) IsRepoCloneable(ctx context.Context, repo api.RepoNa
 
 		r, err := client.IsRepoCloneable(ctx, req)
 		if err != nil {
-			return err
+			return resp, err
 		}
 
 		resp.FromProto(r)
-
-	} else {
-
-		req := &protocol.IsRepoCloneableRequest{
-			Repo: repo,
-		}
-		r, err := c.httpPost(ctx, repo, "is-repo-cloneable", req)
-		if err != nil {
-			return err
-		}
-		defer r.Body.Close()
-		if r.StatusCode != http.StatusOK {
-			return errors.Errorf("gitserver error (status code %d): %s", r.StatusCode, readResponseBody(r.Body))
-		}
-
-		if err := json.NewDecoder(r.Body).Decode(&resp); err != nil {
-			return err
-		}
+		return resp, nil
 	}
 
-	if resp.Cloneable {
-		return nil
+	req := &protocol.IsRepoCloneableRequest{
+		Repo: repo,
 	}
-
-	// Treat all 4xx errors as not found, since we have more relaxed
-	// requirements on what a valid URL is we should treat bad requests,
-	// etc as not found.
-	notFound := strings.Contains(resp.Reason, "not found") || strings.Contains(resp.Reason, "The requested URL returned error: 4")
-	return &RepoNotCloneableErr{
-		repo:     repo,
-		reason:   resp.Reason,
-		notFound: notFound,
-		cloned:   resp.Cloned,
+	r, err := c.httpPostTo(ctx, addr, repo, "is-repo-cloneable", req)
+	if err != nil {
+		return resp, err
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		return resp, errors.Errorf("gitserver error (status code %d): %s", r.StatusCode, readResponseBody(r.Body))
 	}
+
+	err = json.NewDecoder(r.Body).Decode(&resp)
+	return resp, err
 }
 
 // RepoNotCloneableErr is the error that happens when a repository can not be cloned.
@@ -1336,31 +1390,42 @@ func (e *RepoNotCloneableErr) Error() string {
 func (c *clientImplementor) RepoCloneProgress(ctx context.Context, repos ...api.RepoName) (*protocol.RepoCloneProgressResponse, error) {
 	numPossibleShards := len(c.Addrs())
 
-	if internalgrpc.IsGRPCEnabled(ctx) {
-		shards := make(map[proto.GitserverServiceClient]*proto.RepoCloneProgressRequest, (len(repos)/numPossibleShards)*2) // 2x because it may not be a perfect division
-		for _, r := range repos {
-			client, err := c.ClientForRepo(r)
-			if err != nil {
-				return nil, err
-			}
-
-			shard := shards[client]
-			if shard == nil {
-				shard = new(proto.RepoCloneProgressRequest)
-				shards[client] = shard
-			}
-
-			shard.Repos = append(shard.Repos, string(r))
+	// Repos are grouped by the gitservers they are stored on, such that the
+	// progress of a group is read from a replica if the gitserver the repos
+	// are assigned to is unavailable.
+	type shard struct {
+		addrs []string
+		repos []api.RepoName
+	}
+	shards := make(map[string]*shard, (len(repos)/numPossibleShards)*2) // 2x because it may not be a perfect division
+	for _, r := range repos {
+		addrs := c.AddrsForRepo(r)
+		key := strings.Join(addrs, ",")
+		sh := shards[key]
+		if sh == nil {
+			sh = &shard{addrs: addrs}
+			shards[key] = sh
 		}
+		sh.repos = append(sh.repos, r)
+	}
 
+	if internalgrpc.IsGRPCEnabled(ctx) {
 		p := pool.NewWithResults[*proto.RepoCloneProgressResponse]().WithContext(ctx)
 
-		for client, req := range shards {
-			client := client
-			req := req
+		for _, sh := range shards {
+			sh := sh
+			req := new(proto.RepoCloneProgressRequest)
+			for _, r := range sh.repos {
+				req.Repos = append(req.Repos, string(r))
+			}
 			p.Go(func(ctx context.Context) (*proto.RepoCloneProgressResponse, error) {
-				return client.RepoCloneProgress(ctx, req)
-
+				return withReplicas(ctx, "repo-clone-progress", sh.addrs, func(addr string) (*proto.RepoCloneProgressResponse, error) {
+					client, err := c.ClientForAddr(addr)
+					if err != nil {
+						return nil, err
+					}
+					return client.RepoCloneProgress(ctx, req)
+				})
 			})
 		}
 
@@ -1385,52 +1450,20 @@ func (c *clientImplementor) RepoCloneProgress(ctx context.Context, repos ...api.
 		return result, nil
 
 	} else {
-
-		shards := make(map[string]*protocol.RepoCloneProgressRequest, (len(repos)/numPossibleShards)*2) // 2x because it may not be a perfect division
-
-		for _, r := range repos {
-			addr := c.AddrForRepo(r)
-			shard := shards[addr]
-
-			if shard == nil {
-				shard = new(protocol.RepoCloneProgressRequest)
-				shards[addr] = shard
-			}
-
-			shard.Repos = append(shard.Repos, r)
-		}
-
 		type op struct {
-			req *protocol.RepoCloneProgressRequest
 			res *protocol.RepoCloneProgressResponse
 			err error
 		}
 
 		ch := make(chan op, len(shards))
-		for _, req := range shards {
-			go func(o op) {
-				var resp *http.Response
-				resp, o.err = c.httpPost(ctx, o.req.Repos[0], "repo-clone-progress", o.req)
-				if o.err != nil {
-					ch <- o
-					return
-				}
-
-				defer resp.Body.Close()
-				if resp.StatusCode != http.StatusOK {
-					o.err = &url.Error{
-						URL: resp.Request.URL.String(),
-						Op:  "RepoCloneProgress",
-						Err: errors.Errorf("RepoCloneProgress: http status %d", resp.StatusCode),
-					}
-					ch <- o
-					return // we never get an error status code AND result
-				}
-
-				o.res = new(protocol.RepoCloneProgressResponse)
-				o.err = json.NewDecoder(resp.Body).Decode(o.res)
-				ch <- o
-			}(op{req: req})
+		for _, sh := range shards {
+			go func(sh *shard) {
+				req := &protocol.RepoCloneProgressRequest{Repos: sh.repos}
+				res, err := withReplicas(ctx, "repo-clone-progress", sh.addrs, func(addr string) (*protocol.RepoCloneProgressResponse, error) {
+					return c.repoCloneProgress(ctx, addr, req)
+				})
+				ch <- op{res: res, err: err}
+			}(sh)
 		}
 
 		var err error
@@ -1454,6 +1487,26 @@ func (c *clientImplementor) RepoCloneProgress(ctx context.Context, repos ...api.
 	}
 }
 
+func (c *clientImplementor) repoCloneProgress(ctx context.Context, addr string, req *protocol.RepoCloneProgressRequest) (*protocol.RepoCloneProgressResponse, error) {
+	resp, err := c.httpPostTo(ctx, addr, req.Repos[0], "repo-clone-progress", req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &url.Error{
+			URL: resp.Request.URL.String(),
+			Op:  "RepoCloneProgress",
+			Err: errors.Errorf("RepoCloneProgress: http status %d", resp.StatusCode),
+		}
+	}
+
+	res := new(protocol.RepoCloneProgressResponse)
+	err = json.NewDecoder(resp.Body).Decode(res)
+	return res, err
+}
+
 func (c *clientImplementor) ReposStats(ctx context.Context) (map[string]*protocol.ReposStats, error) {
 	stats := map[string]*protocol.ReposStats{}
 	var allErr error
@@ -1510,8 +1563,21 @@ func (c *clientImplementor) Remove(ctx context.Context, repo api.RepoName) error
 	// In case the repo has already been deleted from the database we need to pass
 	// the old name in order to land on the correct gitserver instance
 	repo = api.UndeletedRepoName(repo)
+
+	// The repo is removed from all the gitservers that store it, such that its
+	// replicas aren't kept around.
+	var errs error
+	for _, addr := range c.AddrsForRepo(repo) {
+		if err := c.removeFrom(ctx, repo, addr); err != nil {
+			errs = errors.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+func (c *clientImplementor) removeFrom(ctx context.Context, repo api.RepoName, addr string) error {
 	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(repo)
+		client, err := c.ClientForAddr(addr)
 		if err != nil {
 			return err
 		}
@@ -1519,10 +1585,8 @@ func (c *clientImplementor) Remove(ctx context.Context, repo api.RepoName) error
 			Repo: string(repo),
 		})
 		return err
-	} else {
-		addr := c.AddrForRepo(repo)
-		return c.RemoveFrom(ctx, repo, addr)
 	}
+	return c.RemoveFrom(ctx, repo, addr)
 }
 
 func (c *clientImplementor) RemoveFrom(ctx context.Context, repo api.RepoName, from string) error {
@@ -1553,13 +1617,18 @@ func (c *clientImplementor) RemoveFrom(ctx context.Context, repo api.RepoName, f
 // httpPost will apply the MD5 hashing scheme on the repo name to determine the gitserver instance
 // to which the HTTP POST request is sent.
 func (c *clientImplementor) httpPost(ctx context.Context, repo api.RepoName, op string, payload any) (resp *http.Response, err error) {
+	return c.httpPostTo(ctx, c.AddrForRepo(repo), repo, op, payload)
+}
+
+// httpPostTo is like httpPost, but sends the request to the gitserver with the
+// given address rather than the one the repo is assigned to.
+func (c *clientImplementor) httpPostTo(ctx context.Context, addr string, repo api.RepoName, op string, payload any) (resp *http.Response, err error) {
 	b, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	addrForRepo := c.AddrForRepo(repo)
-	uri := "http://" + addrForRepo + "/" + op
+	uri := "http://" + addr + "/" + op
 	return c.do(ctx, repo, "POST", uri, b)
 }
 
@@ -67,6 +67,7 @@ func newMockDB() database.DB {
 		}, nil
 	})
 	db.ReposFunc.SetDefaultReturn(r)
+	db.OutboundWebhooksFunc.SetDefaultReturn(database.NewMockOutboundWebhookStore())
 
 	return db
 }
@@ -2533,119 +2533,125 @@ func (c *clientImplementor) ArchiveReader(
 		return nil, err
 	}
 
-	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.clientSource.ClientForRepo(c.userAgent, repo)
-		if err != nil {
-			return nil, err
-		}
+	addrs := c.clientSource.AddrsForRepo(c.userAgent, repo)
 
+	if internalgrpc.IsGRPCEnabled(ctx) {
 		req := options.ToProto(string(repo)) // HACK: ArchiveOptions doesn't have a repository here, so we have to add it ourselves.
 
-		ctx, cancel := context.WithCancel(ctx)
-
-		stream, err := client.Archive(ctx, req)
-		if err != nil {
-			cancel()
-			return nil, err
-		}
-
-		// first message from the gRPC stream needs to be read to check for errors before continuing
-		// to read the rest of the stream. If the first message is an error, we cancel the stream
-		// and return the error.
-		//
-		// This is necessary to provide parity between the REST and gRPC implementations of
-		// ArchiveReader. Users of cli.ArchiveReader may assume error handling occurs immediately,
-		// as is the case with the HTTP implementation where errors are returned as soon as the
-		// function returns. gRPC is asynchronous, so we have to start consuming messages from
-		// the stream to see any errors from the server. Reading the first message ensures we
-		// handle any errors synchronously, similar to the HTTP implementation.
-
-		firstMessage, firstError := stream.Recv()
-		if firstError != nil {
-			// Hack: The ArchiveReader.Read() implementation handles surfacing the
-			// any "revision not found" errors returned from the invoked git binary.
-			//
-			// In order to maintainparity with the HTTP API, we return this error in the ArchiveReader.Read() method
-			// instead of returning it immediately.
-
-			// We return early only if this isn't a revision not found error.
+		return withReplicas(ctx, "archive", addrs, func(addr string) (io.ReadCloser, error) {
+			client, err := c.clientSource.ClientForAddr(addr)
+			if err != nil {
+				return nil, err
+			}
 
-			err := convertGRPCErrorToGitDomainError(firstError)
+			ctx, cancel := context.WithCancel(ctx)
 
-			var cse *CommandStatusError
-			if !errors.As(err, &cse) || !isRevisionNotFound(cse.Stderr) {
+			stream, err := client.Archive(ctx, req)
+			if err != nil {
 				cancel()
-				return nil, convertGRPCErrorToGitDomainError(err)
+				return nil, err
 			}
-		}
-
-		firstMessageRead := false
 
-		// Create a reader to read from the gRPC stream.
-		r := streamio.NewReader(func() ([]byte, error) {
-			// Check if we've read the first message yet. If not, read it and return.
-			if !firstMessageRead {
-				firstMessageRead = true
-
-				if firstError != nil {
-					return nil, firstError
+			// first message from the gRPC stream needs to be read to check for errors before continuing
+			// to read the rest of the stream. If the first message is an error, we cancel the stream
+			// and return the error.
+			//
+			// This is necessary to provide parity between the REST and gRPC implementations of
+			// ArchiveReader. Users of cli.ArchiveReader may assume error handling occurs immediately,
+			// as is the case with the HTTP implementation where errors are returned as soon as the
+			// function returns. gRPC is asynchronous, so we have to start consuming messages from
+			// the stream to see any errors from the server. Reading the first message ensures we
+			// handle any errors synchronously, similar to the HTTP implementation.
+
+			firstMessage, firstError := stream.Recv()
+			if firstError != nil {
+				// Hack: The ArchiveReader.Read() implementation handles surfacing the
+				// any "revision not found" errors returned from the invoked git binary.
+				//
+				// In order to maintainparity with the HTTP API, we return this error in the ArchiveReader.Read() method
+				// instead of returning it immediately.
+
+				// We return early only if this isn't a revision not found error.
+
+				err := convertGRPCErrorToGitDomainError(firstError)
+
+				var cse *CommandStatusError
+				if !errors.As(err, &cse) || !isRevisionNotFound(cse.Stderr) {
+					cancel()
+					return nil, convertGRPCErrorToGitDomainError(err)
 				}
-
-				return firstMessage.GetData(), nil
 			}
 
-			// Receive the next message from the stream.
-			msg, err := stream.Recv()
-			if err != nil {
-				return nil, convertGRPCErrorToGitDomainError(err)
-			}
+			firstMessageRead := false
 
-			// Return the data from the received message.
-			return msg.GetData(), nil
-		})
+			// Create a reader to read from the gRPC stream.
+			r := streamio.NewReader(func() ([]byte, error) {
+				// Check if we've read the first message yet. If not, read it and return.
+				if !firstMessageRead {
+					firstMessageRead = true
 
-		return &archiveReader{
-			base: &readCloseWrapper{r: r, closeFn: cancel},
-			repo: repo,
-			spec: options.Treeish,
-		}, nil
+					if firstError != nil {
+						return nil, firstError
+					}
 
-	} else {
-		// Fall back to http request
-		u := c.archiveURL(repo, options)
-		resp, err := c.do(ctx, repo, "POST", u.String(), nil)
-		if err != nil {
-			return nil, err
-		}
+					return firstMessage.GetData(), nil
+				}
+
+				// Receive the next message from the stream.
+				msg, err := stream.Recv()
+				if err != nil {
+					return nil, convertGRPCErrorToGitDomainError(err)
+				}
+
+				// Return the data from the received message.
+				return msg.GetData(), nil
+			})
 
-		switch resp.StatusCode {
-		case http.StatusOK:
 			return &archiveReader{
-				base: &cmdReader{
-					rc:      resp.Body,
-					trailer: resp.Trailer,
-				},
+				base: &readCloseWrapper{r: r, closeFn: cancel},
 				repo: repo,
 				spec: options.Treeish,
 			}, nil
-		case http.StatusNotFound:
-			var payload protocol.NotFoundPayload
-			if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
-				resp.Body.Close()
+		})
+
+	} else {
+		// Fall back to http request
+		return withReplicas(ctx, "archive", addrs, func(addr string) (io.ReadCloser, error) {
+			u := c.archiveURL(addr, repo, options)
+			resp, err := c.do(ctx, repo, "POST", u.String(), nil)
+			if err != nil {
 				return nil, err
 			}
-			resp.Body.Close()
-			return nil, &badRequestError{
-				error: &gitdomain.RepoNotExistError{
-					Repo:            repo,
-					CloneInProgress: payload.CloneInProgress,
-					CloneProgress:   payload.CloneProgress,
-				},
+
+			switch resp.StatusCode {
+			case http.StatusOK:
+				return &archiveReader{
+					base: &cmdReader{
+						rc:      resp.Body,
+						trailer: resp.Trailer,
+					},
+					repo: repo,
+					spec: options.Treeish,
+				}, nil
+			case http.StatusNotFound:
+				var payload protocol.NotFoundPayload
+				if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+					resp.Body.Close()
+					return nil, err
+				}
+				resp.Body.Close()
+				return nil, &badRequestError{
+					error: &gitdomain.RepoNotExistError{
+						Repo:            repo,
+						CloneInProgress: payload.CloneInProgress,
+						CloneProgress:   payload.CloneProgress,
+					},
+				}
+			default:
+				resp.Body.Close()
+				return nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
 			}
-		default:
-			resp.Body.Close()
-			return nil, errors.Errorf("unexpected status code: %d", resp.StatusCode)
-		}
+		})
 	}
 }
 
@@ -175,9 +175,9 @@ type RemoteGitCommand struct {
 }
 
 type execer interface {
-	httpPost(ctx context.Context, repo api.RepoName, op string, payload any) (resp *http.Response, err error)
-	AddrForRepo(repo api.RepoName) string
-	ClientForRepo(repo api.RepoName) (proto.GitserverServiceClient, error)
+	httpPostTo(ctx context.Context, addr string, repo api.RepoName, op string, payload any) (resp *http.Response, err error)
+	AddrsForRepo(repo api.RepoName) []string
+	ClientForAddr(addr string) (proto.GitserverServiceClient, error)
 }
 
 // DividedOutput runs the command and returns its standard output and standard error.
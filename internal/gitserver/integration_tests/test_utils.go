@@ -69,6 +69,7 @@ func InitGitserver() {
 		}, nil
 	})
 	db.ReposFunc.SetDefaultReturn(r)
+	db.OutboundWebhooksFunc.SetDefaultReturn(database.NewMockOutboundWebhookStore())
 
 	s := server.Server{
 		Logger:         sglog.Scoped("server", "the gitserver service"),
@@ -0,0 +1,109 @@
+package gitserver
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var replicaFailoverCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_client_replica_failover",
+	Help: "Times that a request was retried on a replica of the repo because a gitserver was unavailable",
+}, []string{"op"})
+
+// withReplicas calls fn with the addresses in addrs in order, until fn returns
+// anything but an error that indicates that the gitserver is unavailable. addrs
+// are the addresses of the gitservers that a repo is replicated to, as returned
+// by AddrsForRepo, such that reads fail over to the replicas of the repo.
+func withReplicas[T any](ctx context.Context, op string, addrs []string, fn func(addr string) (T, error)) (result T, err error) {
+	for i, addr := range addrs {
+		result, err = fn(addr)
+		if !isGitserverUnavailable(err) || i == len(addrs)-1 || ctx.Err() != nil {
+			return result, err
+		}
+		replicaFailoverCounter.WithLabelValues(op).Inc()
+	}
+	return result, err
+}
+
+// onAllReplicas calls fn concurrently with all the addresses in addrs, such
+// that writes are applied to all the replicas of a repo. Failures of replicas
+// are logged. The result of the first gitserver that is available is returned,
+// such that callers still get a result if the gitserver the repo is assigned
+// to is unavailable.
+func onAllReplicas[T any](logger log.Logger, op string, repo api.RepoName, addrs []string, fn func(addr string) (T, error)) (T, error) {
+	if len(addrs) == 1 {
+		return fn(addrs[0])
+	}
+
+	results := make([]T, len(addrs))
+	errs := make([]error, len(addrs))
+	var wg sync.WaitGroup
+	for i, addr := range addrs {
+		i, addr := i, addr
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = fn(addr)
+		}()
+	}
+	wg.Wait()
+
+	for i, err := range errs[1:] {
+		if err != nil {
+			logger.Warn("request to replica failed", log.String("op", op), log.String("repo", string(repo)), log.String("addr", addrs[i+1]), log.Error(err))
+		}
+	}
+
+	for i := range addrs {
+		if !isGitserverUnavailable(errs[i]) {
+			if i > 0 {
+				replicaFailoverCounter.WithLabelValues(op).Inc()
+			}
+			return results[i], errs[i]
+		}
+	}
+	return results[0], errs[0]
+}
+
+// isGitserverUnavailable returns true if err indicates that a gitserver could
+// not be reached, as opposed to a gitserver failing to serve a request.
+func isGitserverUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Code() == codes.Unavailable
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// recvFirst receives the first message of a stream with recv, such that
+// errors of unavailable gitservers are returned before the stream is read and
+// the request can be retried on a replica. The returned function returns the
+// first message, followed by the remaining messages of the stream.
+func recvFirst[T any](recv func() (T, error)) (func() (T, error), error) {
+	first, firstErr := recv()
+	if isGitserverUnavailable(firstErr) {
+		return nil, firstErr
+	}
+
+	firstRead := false
+	return func() (T, error) {
+		if !firstRead {
+			firstRead = true
+			return first, firstErr
+		}
+		return recv()
+	}, nil
+}
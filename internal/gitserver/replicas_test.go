@@ -0,0 +1,130 @@
+package gitserver
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestWithReplicas(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	unavailable := map[string]error{
+		"grpc": status.Error(codes.Unavailable, "connection refused"),
+		"http": &net.OpError{Op: "dial", Err: errors.New("connection refused")},
+	}
+
+	for name, unavailableErr := range unavailable {
+		t.Run(name, func(t *testing.T) {
+			var called []string
+			got, err := withReplicas(context.Background(), "test", addrs, func(addr string) (string, error) {
+				called = append(called, addr)
+				if addr == "gitserver-1" {
+					return "", unavailableErr
+				}
+				return addr, nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != "gitserver-2" {
+				t.Fatalf("got result from %q, want gitserver-2", got)
+			}
+			if diff := cmp.Diff([]string{"gitserver-1", "gitserver-2"}, called); diff != "" {
+				t.Fatalf("unexpected calls (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		var called []string
+		_, err := withReplicas(context.Background(), "test", addrs, func(addr string) (string, error) {
+			called = append(called, addr)
+			return "", status.Error(codes.NotFound, "repo not found")
+		})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if len(called) != 1 {
+			t.Fatalf("called %d gitservers, want 1", len(called))
+		}
+	})
+
+	t.Run("all unavailable", func(t *testing.T) {
+		var called int
+		_, err := withReplicas(context.Background(), "test", addrs, func(addr string) (string, error) {
+			called++
+			return "", unavailable["grpc"]
+		})
+		if !isGitserverUnavailable(err) {
+			t.Fatalf("unexpected error %v", err)
+		}
+		if called != len(addrs) {
+			t.Fatalf("called %d gitservers, want %d", called, len(addrs))
+		}
+	})
+}
+
+func TestOnAllReplicas(t *testing.T) {
+	addrs := []string{"gitserver-1", "gitserver-2", "gitserver-3"}
+	logger := logtest.Scoped(t)
+
+	t.Run("calls all replicas", func(t *testing.T) {
+		var mu sync.Mutex
+		var called []string
+		got, err := onAllReplicas(logger, "test", "repo", addrs, func(addr string) (string, error) {
+			mu.Lock()
+			called = append(called, addr)
+			mu.Unlock()
+			if addr == "gitserver-3" {
+				return "", errors.New("replica failed")
+			}
+			return addr, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "gitserver-1" {
+			t.Fatalf("got result from %q, want gitserver-1", got)
+		}
+		sort.Strings(called)
+		if diff := cmp.Diff(addrs, called); diff != "" {
+			t.Fatalf("unexpected calls (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("first available", func(t *testing.T) {
+		got, err := onAllReplicas(logger, "test", "repo", addrs, func(addr string) (string, error) {
+			if addr == "gitserver-1" {
+				return "", status.Error(codes.Unavailable, "connection refused")
+			}
+			return addr, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != "gitserver-2" {
+			t.Fatalf("got result from %q, want gitserver-2", got)
+		}
+	})
+
+	t.Run("errors of the assigned gitserver are returned", func(t *testing.T) {
+		_, err := onAllReplicas(logger, "test", "repo", addrs, func(addr string) (string, error) {
+			if addr == "gitserver-1" {
+				return "", status.Error(codes.NotFound, "repo not found")
+			}
+			return addr, nil
+		})
+		if status.Code(err) != codes.NotFound {
+			t.Fatalf("unexpected error %v", err)
+		}
+	})
+}
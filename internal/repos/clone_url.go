@@ -89,6 +89,10 @@ func cloneURL(ctx context.Context, parsed any, logger log.Logger, kind string, r
 		}
 	case *schema.LocalGitExternalService:
 		return localCloneURL(repo), nil
+	case *schema.MercurialConnection:
+		if r, ok := repo.Metadata.(*extsvc.MercurialRepoMetadata); ok {
+			return mercurialCloneURL(repo, r), nil
+		}
 	case *schema.GoModulesConnection:
 		return string(repo.Name), nil
 	case *schema.PythonPackagesConnection:
@@ -328,6 +332,10 @@ func otherCloneURL(repo *types.Repo, m *extsvc.OtherRepoMetadata) string {
 	return repo.ExternalRepo.ServiceID + m.RelativePath
 }
 
+func mercurialCloneURL(repo *types.Repo, m *extsvc.MercurialRepoMetadata) string {
+	return repo.ExternalRepo.ServiceID + m.RelativePath
+}
+
 func localCloneURL(repo *types.Repo) string {
 	return repo.ExternalRepo.ServiceID
 }
@@ -0,0 +1,146 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// A MercurialSource yields repositories from a single Mercurial connection
+// configured in Sourcegraph via the external services configuration.
+type MercurialSource struct {
+	svc     *types.ExternalService
+	conn    *schema.MercurialConnection
+	exclude excludeFunc
+}
+
+// NewMercurialSource returns a new MercurialSource from the given external
+// service.
+func NewMercurialSource(ctx context.Context, svc *types.ExternalService) (*MercurialSource, error) {
+	rawConfig, err := svc.Config.Decrypt(ctx)
+	if err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	var c schema.MercurialConnection
+	if err := jsonc.Unmarshal(rawConfig, &c); err != nil {
+		return nil, errors.Wrapf(err, "external service id=%d config error", svc.ID)
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	return &MercurialSource{
+		svc:     svc,
+		conn:    &c,
+		exclude: exclude,
+	}, nil
+}
+
+// CheckConnection at this point assumes availability and relies on errors
+// returned when gitserver clones the repositories.
+func (s MercurialSource) CheckConnection(ctx context.Context) error {
+	return nil
+}
+
+// ListRepos returns all Mercurial repositories configured in the connection.
+func (s MercurialSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	base, err := url.Parse(s.conn.Url)
+	if err != nil {
+		results <- SourceResult{Source: s, Err: err}
+		return
+	}
+
+	urn := s.svc.URN()
+	for _, repo := range s.conn.Repos {
+		cloneURL, err := base.Parse(repo)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		// A repository can override the scheme of the connection URL, which
+		// must not let it clone from the local file system of gitserver.
+		if !isMercurialScheme(cloneURL.Scheme) {
+			results <- SourceResult{Source: s, Err: errors.Errorf("repository %q has unsupported scheme %q", repo, cloneURL.Scheme)}
+			return
+		}
+		r, err := s.mercurialRepoFromCloneURL(urn, cloneURL)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: err}
+			return
+		}
+		if s.exclude(string(r.Name)) {
+			continue
+		}
+
+		results <- SourceResult{Source: s, Repo: r}
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s MercurialSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s MercurialSource) mercurialRepoFromCloneURL(urn string, u *url.URL) (*types.Repo, error) {
+	repoURL := u.String()
+	// Mercurial repositories are named exactly like the repositories of an
+	// Other connection with the same URL.
+	repoSource := reposource.Other{OtherExternalServiceConnection: &schema.OtherExternalServiceConnection{
+		Url:                   s.conn.Url,
+		RepositoryPathPattern: s.conn.RepositoryPathPattern,
+	}}
+	repoName, err := repoSource.CloneURLToRepoName(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	repoURI, err := repoSource.CloneURLToRepoURI(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	u.Path, u.RawQuery = "", ""
+	serviceID := u.String()
+
+	return &types.Repo{
+		Name: repoName,
+		URI:  repoURI,
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          string(repoName),
+			ServiceType: extsvc.VariantMercurial.AsType(),
+			ServiceID:   serviceID,
+		},
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: repoURL,
+			},
+		},
+		Metadata: &extsvc.MercurialRepoMetadata{
+			RelativePath: strings.TrimPrefix(repoURL, serviceID),
+		},
+	}, nil
+}
+
+// isMercurialScheme returns true if repositories can be cloned from URLs with
+// the given scheme.
+func isMercurialScheme(scheme string) bool {
+	switch scheme {
+	case "http", "https", "ssh":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMercurial_ListRepos(t *testing.T) {
+	t.Run("unsupported scheme", func(t *testing.T) {
+		for _, repo := range []string{"file:///etc", "ext::sh -c true"} {
+			config, err := json.Marshal(&schema.MercurialConnection{
+				Url:   "https://hg.example.com/",
+				Repos: []string{"a", repo},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := context.Background()
+			source, err := NewMercurialSource(ctx, &types.ExternalService{
+				ID:     1,
+				Kind:   extsvc.VariantMercurial.AsKind(),
+				Config: extsvc.NewUnencryptedConfig(string(config)),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results := make(chan SourceResult)
+			go func() {
+				defer close(results)
+				source.ListRepos(ctx, results)
+			}()
+			var errs int
+			for r := range results {
+				if r.Err != nil {
+					errs++
+				}
+			}
+			if errs != 1 {
+				t.Errorf("expected an error for repository %q, got %d", repo, errs)
+			}
+		}
+	})
+
+	type repo struct {
+		Name     string
+		CloneURL string
+	}
+
+	cases := []struct {
+		Name string
+		Conn *schema.MercurialConnection
+		Want []repo
+	}{{
+		Name: "simple",
+		Conn: &schema.MercurialConnection{
+			Url:   "https://hg.example.com/",
+			Repos: []string{"a", "b/c"},
+		},
+		Want: []repo{
+			{Name: "hg.example.com/a", CloneURL: "https://hg.example.com/a"},
+			{Name: "hg.example.com/b/c", CloneURL: "https://hg.example.com/b/c"},
+		},
+	}, {
+		Name: "pattern",
+		Conn: &schema.MercurialConnection{
+			Url:                   "ssh://hg@hg.example.com/",
+			Repos:                 []string{"a"},
+			RepositoryPathPattern: "hg/{repo}",
+		},
+		Want: []repo{
+			{Name: "hg/a", CloneURL: "ssh://hg@hg.example.com/a"},
+		},
+	}, {
+		Name: "exclude",
+		Conn: &schema.MercurialConnection{
+			Url:     "https://hg.example.com",
+			Repos:   []string{"keep1", "exclude/exact", "exclude-dir/a", "keep2"},
+			Exclude: []*schema.ExcludedMercurialRepo{{Name: "hg.example.com/exclude/exact"}, {Pattern: "exclude-dir"}},
+		},
+		Want: []repo{
+			{Name: "hg.example.com/keep1", CloneURL: "https://hg.example.com/keep1"},
+			{Name: "hg.example.com/keep2", CloneURL: "https://hg.example.com/keep2"},
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			config, err := json.Marshal(tc.Conn)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := context.Background()
+			svc := &types.ExternalService{
+				ID:     1,
+				Kind:   extsvc.VariantMercurial.AsKind(),
+				Config: extsvc.NewUnencryptedConfig(string(config)),
+			}
+			source, err := NewMercurialSource(ctx, svc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results := make(chan SourceResult)
+			go func() {
+				defer close(results)
+				source.ListRepos(ctx, results)
+			}()
+
+			var got []repo
+			for r := range results {
+				if r.Err != nil {
+					t.Error(r.Err)
+					continue
+				}
+				if r.Repo.ExternalRepo.ServiceType != extsvc.VariantMercurial.AsType() {
+					t.Errorf("unexpected service type %q", r.Repo.ExternalRepo.ServiceType)
+				}
+				cloneURL, err := EncryptableCloneURL(ctx, logtest.Scoped(t), svc.Kind, svc.Config, r.Repo)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, repo{Name: string(r.Repo.Name), CloneURL: cloneURL})
+			}
+
+			if d := cmp.Diff(tc.Want, got); d != "" {
+				t.Fatalf("unexpected repos (-want, +got):\n%s", d)
+			}
+		})
+	}
+}
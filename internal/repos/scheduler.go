@@ -32,8 +32,9 @@ type schedulerConfig struct {
 // RunScheduler runs the worker that schedules git fetches of synced repositories in git-server.
 func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateScheduler) {
 	var (
-		have schedulerConfig
-		stop context.CancelFunc
+		have          schedulerConfig
+		havePlacement replicaPlacement
+		stop          context.CancelFunc
 	)
 
 	logger = logger.Scoped("RunScheduler", "git fetch scheduler")
@@ -41,6 +42,16 @@ func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateSched
 	conf.Watch(func() {
 		c := conf.Get()
 
+		// When replicas are placed on other gitservers, all repos are updated
+		// soon so that the new replicas are cloned, rather than after their
+		// usual interval.
+		placement := newReplicaPlacement(c)
+		if have.running && placement != havePlacement && placement.replicationFactor > 1 {
+			logger.Info("gitserver replica placement changed, prioritising all repos")
+			scheduler.schedule.prioritiseAll()
+		}
+		havePlacement = placement
+
 		want := schedulerConfig{
 			running:               true,
 			autoGitUpdatesEnabled: !c.DisableAutoGitUpdates,
@@ -82,6 +93,21 @@ func RunScheduler(ctx context.Context, logger log.Logger, scheduler *UpdateSched
 	})
 }
 
+// replicaPlacement is the configuration that determines which gitservers the
+// replicas of repos are placed on.
+type replicaPlacement struct {
+	addrs             string
+	replicationFactor int
+}
+
+func newReplicaPlacement(c *conf.Unified) replicaPlacement {
+	addrs := gitserver.NewGitserverAddressesFromConf(c)
+	return replicaPlacement{
+		addrs:             strings.Join(addrs.Addresses, ","),
+		replicationFactor: addrs.ReplicationFactor,
+	}
+}
+
 const (
 	// minDelay is the minimum amount of time between scheduled updates for a single repository.
 	minDelay = 45 * time.Second
@@ -725,6 +751,24 @@ func (s *schedule) upsert(repo configuredRepo) (updated bool) {
 	return false
 }
 
+// prioritiseAll schedules all repos to be updated as if they were newly added,
+// unless they are already due sooner.
+func (s *schedule) prioritiseAll() {
+	due := timeNow().Add(minDelay)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, update := range s.heap {
+		if update.Due.After(due) {
+			update.Due = due
+		}
+	}
+	heap.Init(s)
+
+	s.rescheduleTimer()
+}
+
 func (s *schedule) prioritiseUncloned(uncloned []types.MinimalRepo) {
 	// All non-cloned repos will be due for cloning as if they are newly added
 	// repos.
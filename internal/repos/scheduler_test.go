@@ -849,6 +849,43 @@ func TestUpdateQueue_PrioritiseUncloned(t *testing.T) {
 	assertFront(notcloned.Name)
 }
 
+func TestSchedule_prioritiseAll(t *testing.T) {
+	repo1 := configuredRepo{ID: 1, Name: "repo1"}
+	repo2 := configuredRepo{ID: 2, Name: "repo2"}
+	repo3 := configuredRepo{ID: 3, Name: "repo3"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	s := NewUpdateScheduler(logtest.Scoped(t), database.NewMockDB())
+
+	// repo1 is due soon, the others in the distant future.
+	s.schedule.upsert(repo1)
+	mockTime(defaultTime.Add(time.Hour))
+	s.schedule.upsert(repo2)
+	mockTime(defaultTime.Add(2 * time.Hour))
+	s.schedule.upsert(repo3)
+
+	mockTime(defaultTime.Add(time.Minute))
+	s.schedule.prioritiseAll()
+
+	due := map[api.RepoName]time.Time{}
+	for _, update := range s.schedule.heap {
+		due[update.Repo.Name] = update.Due
+	}
+	want := map[api.RepoName]time.Time{
+		"repo1": defaultTime.Add(minDelay),
+		"repo2": defaultTime.Add(time.Minute + minDelay),
+		"repo3": defaultTime.Add(time.Minute + minDelay),
+	}
+	if diff := cmp.Diff(want, due); diff != "" {
+		t.Fatalf("unexpected due times (-want +got):\n%s", diff)
+	}
+	if front := s.schedule.heap[0].Repo.Name; front != repo1.Name {
+		t.Fatalf("front of schedule is %q, want %q", front, repo1.Name)
+	}
+}
+
 func TestScheduleInsertNew(t *testing.T) {
 	repo1 := types.MinimalRepo{ID: 1, Name: "repo1"}
 	repo2 := types.MinimalRepo{ID: 2, Name: "repo2"}
@@ -82,6 +82,8 @@ func NewSource(ctx context.Context, logger log.Logger, db database.DB, svc *type
 		return NewOtherSource(ctx, svc, cf, logger.Scoped("OtherSource", ""))
 	case extsvc.VariantLocalGit.AsKind():
 		return NewLocalGitSource(ctx, logger.Scoped("LocalSource", "local repo source"), svc)
+	case extsvc.VariantMercurial.AsKind():
+		return NewMercurialSource(ctx, svc)
 	default:
 		return nil, errors.Newf("cannot create source for kind %q", svc.Kind)
 	}
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -450,6 +451,7 @@ func (s *Syncer) notifyDeleted(ctx context.Context, deleted ...api.RepoID) {
 		d.Deleted = append(d.Deleted, &types.Repo{ID: id})
 	}
 	observeDiff(d)
+	s.enqueueWebhookEvents(ctx, events.RepoRemoved, d.Deleted...)
 
 	if s.Synced != nil && d.Len() > 0 {
 		select {
@@ -711,6 +713,14 @@ func (s *Syncer) SyncExternalService(
 	return errs
 }
 
+// enqueueWebhookEvents enqueues an outbound webhook event of the given type for
+// each of the given repos.
+func (s *Syncer) enqueueWebhookEvents(ctx context.Context, eventType string, repos ...*types.Repo) {
+	for _, r := range repos {
+		database.EnqueueOutboundWebhookEvent(ctx, s.ObsvCtx.Logger, s.Store, eventType, events.NewRepo(r.ID, r.Name))
+	}
+}
+
 // syncs a sourced repo of a given external service, returning a diff with a single repo.
 func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *types.Repo) (d Diff, err error) {
 	tx, err := s.Store.Transact(ctx)
@@ -718,6 +728,9 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		return Diff{}, errors.Wrap(err, "syncer: opening transaction")
 	}
 
+	// conflicting is set if we deleted a repo to resolve a naming conflict.
+	var conflicting *types.Repo
+
 	defer func() {
 		observeDiff(d)
 		// We must commit the transaction before publishing to s.Synced
@@ -730,6 +743,11 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 			return
 		}
 
+		if conflicting != nil {
+			s.enqueueWebhookEvents(ctx, events.RepoRemoved, conflicting)
+		}
+		s.enqueueWebhookEvents(ctx, events.RepoAdded, d.Added...)
+
 		if s.Synced != nil && d.Len() > 0 {
 			select {
 			case <-ctx.Done():
@@ -765,7 +783,7 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 
 		// Pick this sourced repo to own the name by deleting the other repo. If it still exists, it'll have a different
 		// name when we source it from the same code host, and it will be re-created.
-		var conflicting, existing *types.Repo
+		var existing *types.Repo
 		for _, r := range stored {
 			if r.ExternalRepo.Equal(&sourced.ExternalRepo) {
 				existing = r
@@ -2,6 +2,7 @@ package repos_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"sort"
@@ -12,6 +13,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
 
@@ -19,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
@@ -32,6 +35,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/types/typestest"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -1297,6 +1301,83 @@ func TestOrphanedRepo(t *testing.T) {
 	assertDeletedRepoCount(ctx, t, store, 1)
 }
 
+func TestSyncerEnqueuesWebhookEvents(t *testing.T) {
+	t.Parallel()
+	store := getTestRepoStore(t)
+	db := database.NewDBWith(logtest.Scoped(t), store)
+
+	ctx := context.Background()
+	now := time.Now()
+
+	admin, err := db.Users().Create(ctx, database.NewUser{Username: "admin"})
+	require.NoError(t, err)
+	webhook := &types.OutboundWebhook{
+		CreatedBy: admin.ID,
+		UpdatedBy: admin.ID,
+		URL:       encryption.NewUnencrypted("https://example.com/"),
+		Secret:    encryption.NewUnencrypted("super secret"),
+	}
+	webhook.EventTypes = []types.OutboundWebhookEventType{
+		webhook.NewEventType(events.RepoAdded, nil),
+		webhook.NewEventType(events.RepoRemoved, nil),
+	}
+	require.NoError(t, db.OutboundWebhooks(nil).Create(ctx, webhook))
+
+	svc := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "Github - Test",
+		Config:      extsvc.NewUnencryptedConfig(basicGitHubConfig),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	require.NoError(t, store.ExternalServiceStore().Upsert(ctx, svc))
+
+	githubRepo := &types.Repo{
+		Name:     "github.com/org/foo",
+		Metadata: &github.Repository{},
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          "foo-external-12345",
+			ServiceID:   "https://github.com/",
+			ServiceType: extsvc.TypeGitHub,
+		},
+	}
+
+	assertLastJob := func(t *testing.T, eventType string, payload events.Repo) {
+		t.Helper()
+
+		job, err := db.OutboundWebhookJobs(nil).GetLast(ctx)
+		require.NoError(t, err)
+		require.Equal(t, eventType, job.EventType)
+
+		want, err := json.Marshal(payload)
+		require.NoError(t, err)
+		have, err := job.Payload.Decrypt(ctx)
+		require.NoError(t, err)
+		require.JSONEq(t, string(want), have)
+	}
+
+	syncer := &repos.Syncer{
+		ObsvCtx: observation.TestContextTB(t),
+		Sourcer: func(ctx context.Context, service *types.ExternalService) (repos.Source, error) {
+			return repos.NewFakeSource(svc, nil, githubRepo), nil
+		},
+		Store: store,
+		Now:   time.Now,
+	}
+	require.NoError(t, syncer.SyncExternalService(ctx, svc.ID, 10*time.Second, noopProgressRecorder))
+
+	added, err := store.RepoStore().GetByName(ctx, githubRepo.Name)
+	require.NoError(t, err)
+	assertLastJob(t, events.RepoAdded, events.NewRepo(added.ID, added.Name))
+
+	syncer.Sourcer = func(ctx context.Context, service *types.ExternalService) (repos.Source, error) {
+		return repos.NewFakeSource(svc, nil), nil
+	}
+	require.NoError(t, syncer.SyncExternalService(ctx, svc.ID, 10*time.Second, noopProgressRecorder))
+
+	assertLastJob(t, events.RepoRemoved, events.NewRepo(added.ID, ""))
+}
+
 func TestCloudDefaultExternalServicesDontSync(t *testing.T) {
 	t.Parallel()
 	store := getTestRepoStore(t)
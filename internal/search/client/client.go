@@ -13,12 +13,14 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
@@ -58,6 +60,7 @@ func New(logger log.Logger, db database.DB, enterpriseJobs jobutil.EnterpriseJob
 		zoekt:                       search.Indexed(),
 		searcherURLs:                search.SearcherURLs(),
 		searcherGRPCConnectionCache: search.SearcherGRPCConnectionCache(),
+		dependencies:                dependencies.NewService(observation.NewContext(logger), db),
 		settingsService:             settings.NewService(db),
 		sourcegraphDotComMode:       envvar.SourcegraphDotComMode(),
 		enterpriseJobs:              enterpriseJobs,
@@ -71,6 +74,7 @@ func MockedZoekt(logger log.Logger, db database.DB, zoektStreamer zoekt.Streamer
 		logger:                logger,
 		db:                    db,
 		zoekt:                 zoektStreamer,
+		dependencies:          dependencies.NewService(observation.NewContext(logger), db),
 		settingsService:       settings.Mock(&schema.Settings{}),
 		sourcegraphDotComMode: envvar.SourcegraphDotComMode(),
 		enterpriseJobs:        jobutil.NewUnimplementedEnterpriseJobs(),
@@ -83,6 +87,7 @@ type searchClient struct {
 	zoekt                       zoekt.Streamer
 	searcherURLs                *endpoint.Map
 	searcherGRPCConnectionCache *defaults.ConnectionCache
+	dependencies                *dependencies.Service
 	settingsService             settings.Service
 	sourcegraphDotComMode       bool
 	enterpriseJobs              jobutil.EnterpriseJobs
@@ -177,6 +182,7 @@ func (s *searchClient) JobClients() job.RuntimeClients {
 		SearcherURLs:                s.searcherURLs,
 		SearcherGRPCConnectionCache: s.searcherGRPCConnectionCache,
 		Gitserver:                   gitserver.NewClient(),
+		Dependencies:                s.dependencies,
 	}
 }
 
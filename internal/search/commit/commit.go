@@ -7,6 +7,7 @@ import (
 
 	"github.com/grafana/regexp"
 	"github.com/sourcegraph/conc/pool"
+	"github.com/sourcegraph/log"
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -33,6 +34,10 @@ type SearchJob struct {
 	IncludeModifiedFiles bool
 	Concurrency          int
 
+	// DiffSymbols, if set, annotates each diff match with the symbols changed by
+	// the commit. This is used to support `type:diff select:symbol`.
+	DiffSymbols bool
+
 	// CodeMonitorSearchWrapper, if set, will wrap the commit search with extra logic specific to code monitors.
 	CodeMonitorSearchWrapper CodeMonitorHook `json:"-"`
 }
@@ -69,9 +74,24 @@ func (j *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 		}
 
 		onMatches := func(in []protocol.CommitMatch) {
-			res := make([]result.Match, 0, len(in))
+			matches := make([]*result.CommitMatch, 0, len(in))
 			for _, protocolMatch := range in {
-				res = append(res, protocolMatchToCommitMatch(repoRev.Repo, j.Diff, protocolMatch))
+				matches = append(matches, protocolMatchToCommitMatch(repoRev.Repo, j.Diff, protocolMatch))
+			}
+			// Symbols are only parsed for queries that select them.
+			if j.DiffSymbols {
+				annotateCommitsChangedSymbols(ctx, parseFilesSymbols, matches, func(cm *result.CommitMatch, err error) {
+					// Symbols are best-effort, we still want to return the match.
+					clients.Logger.Warn("failed to list changed symbols",
+						log.String("repo", string(repoRev.Repo.Name)),
+						log.String("commit", string(cm.Commit.ID)),
+						log.Error(err),
+					)
+				})
+			}
+			res := make([]result.Match, 0, len(matches))
+			for _, cm := range matches {
+				res = append(res, cm)
 			}
 			stream.Send(streaming.SearchEvent{
 				Results: res,
@@ -96,7 +116,7 @@ func (j *SearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream
 		return doSearch(args)
 	}
 
-	repos := searchrepos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt)
+	repos := searchrepos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt, clients.Dependencies)
 	it := repos.Iterator(ctx, j.RepoOpts)
 
 	p := pool.New().WithContext(ctx).WithMaxGoroutines(j.Concurrency).WithFirstError()
@@ -131,6 +151,7 @@ func (j *SearchJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
 	case job.VerbosityMax:
 		res = append(res,
 			attribute.Bool("includeModifiedFiles", j.IncludeModifiedFiles),
+			attribute.Bool("diffSymbols", j.DiffSymbols),
 		)
 		fallthrough
 	case job.VerbosityBasic:
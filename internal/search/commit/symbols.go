@@ -0,0 +1,221 @@
+package commit
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/conc/pool"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// maxSymbolFilesPerCommit bounds the number of files whose changed symbols are
+// annotated for a single commit. The symbols of the remaining files of larger
+// commits are not reported.
+const maxSymbolFilesPerCommit = 20
+
+// symbolsConcurrency bounds the number of commits of a batch of matches whose
+// changed symbols are annotated concurrently.
+const symbolsConcurrency = 8
+
+// listSymbolsFunc returns the symbols defined in the files at the given paths
+// and commit, with 1-indexed lines.
+type listSymbolsFunc func(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) ([]result.Symbol, error)
+
+// parseFilesSymbols parses the blobs of the given files at a commit with the
+// symbols parser. Only the given files are fetched and parsed, the repository
+// is not indexed at the commit.
+func parseFilesSymbols(ctx context.Context, repo api.RepoName, commit api.CommitID, paths []string) ([]result.Symbol, error) {
+	return backend.Symbols.ParseFiles(ctx, search.ParseFilesParameters{
+		Repo:     repo,
+		CommitID: commit,
+		Paths:    paths,
+	})
+}
+
+// annotateCommitsChangedSymbols annotates the changed symbols of a batch of
+// commit matches, at most symbolsConcurrency commits at a time. Symbols are
+// best-effort, so failures are reported to onError and the match is kept.
+func annotateCommitsChangedSymbols(ctx context.Context, listSymbols listSymbolsFunc, matches []*result.CommitMatch, onError func(*result.CommitMatch, error)) {
+	p := pool.New().WithMaxGoroutines(symbolsConcurrency)
+	for _, cm := range matches {
+		cm := cm
+		p.Go(func() {
+			if err := annotateChangedSymbols(ctx, listSymbols, cm); err != nil {
+				onError(cm, err)
+			}
+		})
+	}
+	p.Wait()
+}
+
+// annotateChangedSymbols populates cm.ChangedSymbols by parsing the files touched
+// by the diff before and after the commit and comparing the symbols of both
+// versions. When the diff preview has highlights, only the symbols that contain a
+// highlighted line are reported, so that e.g. `type:diff select:symbol func Foo(`
+// reports Foo rather than every other symbol touched by the commit.
+//
+// The files are parsed with at most one request per version of the commit, which
+// are sent concurrently, and only the first maxSymbolFilesPerCommit changed files
+// are annotated.
+func annotateChangedSymbols(ctx context.Context, listSymbols listSymbolsFunc, cm *result.CommitMatch) error {
+	if cm.DiffPreview == nil {
+		return nil
+	}
+
+	var parent api.CommitID
+	if len(cm.Commit.Parents) > 0 {
+		parent = cm.Commit.Parents[0]
+	}
+
+	var highlighted []changedLines
+	if len(cm.DiffPreview.MatchedRanges) > 0 {
+		highlighted = highlightedLines(cm.Diff, cm.DiffPreview.MatchedRanges)
+	}
+
+	type changedFile struct {
+		file           result.DiffFile
+		removed, added map[int]struct{}
+		before, after  bool
+	}
+
+	var (
+		files                   []changedFile
+		beforePaths, afterPaths []string
+	)
+	for i, file := range cm.Diff {
+		if len(files) == maxSymbolFilesPerCommit {
+			break
+		}
+
+		removed, added := result.DiffChangedLines(file)
+		if highlighted != nil {
+			removed, added = highlighted[i].removed, highlighted[i].added
+		}
+		if len(removed) == 0 && len(added) == 0 {
+			continue
+		}
+
+		f := changedFile{file: file, removed: removed, added: added}
+		if file.OrigName != "/dev/null" && parent != "" && len(removed) > 0 {
+			f.before = true
+			beforePaths = append(beforePaths, file.OrigName)
+		}
+		if file.NewName != "/dev/null" {
+			f.after = true
+			afterPaths = append(afterPaths, file.NewName)
+		}
+		files = append(files, f)
+	}
+
+	symbolsByPath := func(commit api.CommitID, paths []string) (map[string][]result.Symbol, error) {
+		if len(paths) == 0 {
+			return nil, nil
+		}
+		symbols, err := listSymbols(ctx, cm.Repo.Name, commit, paths)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing symbols of %d files at %s", len(paths), commit)
+		}
+		byPath := make(map[string][]result.Symbol, len(paths))
+		for _, symbol := range symbols {
+			byPath[symbol.Path] = append(byPath[symbol.Path], symbol)
+		}
+		return byPath, nil
+	}
+
+	var before, after map[string][]result.Symbol
+	p := pool.New().WithErrors()
+	p.Go(func() (err error) {
+		before, err = symbolsByPath(parent, beforePaths)
+		return err
+	})
+	p.Go(func() (err error) {
+		after, err = symbolsByPath(cm.Commit.ID, afterPaths)
+		return err
+	})
+	if err := p.Wait(); err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		var fileBefore, fileAfter []result.Symbol
+		if f.before {
+			fileBefore = before[f.file.OrigName]
+		}
+		if f.after {
+			fileAfter = after[f.file.NewName]
+		}
+
+		for _, symbol := range result.DiffSymbols(f.removed, f.added, fileBefore, fileAfter) {
+			symbol.Commit = cm.Commit.ID
+			if symbol.Change == result.SymbolRemoved {
+				symbol.Commit = parent
+			}
+			cm.ChangedSymbols = append(cm.ChangedSymbols, symbol)
+		}
+	}
+
+	return nil
+}
+
+type changedLines struct {
+	removed, added map[int]struct{}
+}
+
+// highlightedLines maps the highlighted lines of a formatted diff (see
+// result.FormatDiffFiles) back to the 1-indexed line numbers in the original
+// and new version of each file.
+func highlightedLines(diff []result.DiffFile, ranges result.Ranges) []changedLines {
+	type position struct {
+		file    int
+		line    int
+		removed bool
+	}
+
+	positions := map[int]position{}
+	previewLine := 0
+	for i, file := range diff {
+		previewLine++ // file header
+		for _, hunk := range file.Hunks {
+			previewLine++ // hunk header
+			oldLine, newLine := hunk.OldStart, hunk.NewStart
+			for _, line := range hunk.Lines {
+				switch {
+				case strings.HasPrefix(line, "-"):
+					positions[previewLine] = position{file: i, line: oldLine, removed: true}
+					oldLine++
+				case strings.HasPrefix(line, "+"):
+					positions[previewLine] = position{file: i, line: newLine}
+					newLine++
+				default:
+					oldLine++
+					newLine++
+				}
+				previewLine++
+			}
+		}
+	}
+
+	res := make([]changedLines, len(diff))
+	for i := range res {
+		res[i] = changedLines{removed: map[int]struct{}{}, added: map[int]struct{}{}}
+	}
+	for _, r := range ranges {
+		for line := r.Start.Line; line <= r.End.Line; line++ {
+			pos, ok := positions[line]
+			if !ok {
+				continue
+			}
+			if pos.removed {
+				res[pos.file].removed[pos.line] = struct{}{}
+			} else {
+				res[pos.file].added[pos.line] = struct{}{}
+			}
+		}
+	}
+	return res
+}
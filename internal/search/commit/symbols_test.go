@@ -0,0 +1,156 @@
+package commit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAnnotateChangedSymbols(t *testing.T) {
+	const diff = `a.go a.go
+@@ -1,4 +1,4 @@
+ func foo() {
+-	return 1
++	return 2
+ }
+@@ -10,2 +10,2 @@
+-func bar() {}
++func baz() {}
+`
+
+	symbols := map[api.CommitID][]result.Symbol{
+		"parent": {
+			{Name: "foo", Kind: "function", Path: "a.go", Line: 1},
+			{Name: "bar", Kind: "function", Path: "a.go", Line: 10},
+		},
+		"commit": {
+			{Name: "foo", Kind: "function", Path: "a.go", Line: 1},
+			{Name: "baz", Kind: "function", Path: "a.go", Line: 10},
+		},
+	}
+	listSymbols := func(_ context.Context, _ api.RepoName, commit api.CommitID, paths []string) ([]result.Symbol, error) {
+		require.Equal(t, []string{"a.go"}, paths)
+		return symbols[commit], nil
+	}
+
+	newMatch := func(ranges result.Ranges) *result.CommitMatch {
+		files, err := result.ParseDiffString(diff)
+		require.NoError(t, err)
+		return &result.CommitMatch{
+			Repo:        types.MinimalRepo{Name: "repo"},
+			Commit:      gitdomain.Commit{ID: "commit", Parents: []api.CommitID{"parent"}},
+			Diff:        files,
+			DiffPreview: &result.MatchedString{Content: diff, MatchedRanges: ranges},
+		}
+	}
+
+	type change struct {
+		name   string
+		change result.SymbolChange
+		commit api.CommitID
+	}
+	summarize := func(symbols []result.ChangedSymbol) (res []change) {
+		for _, s := range symbols {
+			res = append(res, change{s.Name, s.Change, s.Commit})
+		}
+		return res
+	}
+
+	t.Run("no highlights", func(t *testing.T) {
+		cm := newMatch(nil)
+		require.NoError(t, annotateChangedSymbols(context.Background(), listSymbols, cm))
+		require.Equal(t, []change{
+			{"foo", result.SymbolModified, "commit"},
+			{"baz", result.SymbolAdded, "commit"},
+			{"bar", result.SymbolRemoved, "parent"},
+		}, summarize(cm.ChangedSymbols))
+	})
+
+	t.Run("highlights restrict symbols", func(t *testing.T) {
+		// Highlight `+func baz() {}` on the (0-indexed) 8th line of the preview.
+		cm := newMatch(result.Ranges{{
+			Start: result.Location{Line: 8, Column: 6},
+			End:   result.Location{Line: 8, Column: 9},
+		}})
+		require.NoError(t, annotateChangedSymbols(context.Background(), listSymbols, cm))
+		require.Equal(t, []change{
+			{"baz", result.SymbolAdded, "commit"},
+		}, summarize(cm.ChangedSymbols))
+	})
+
+	t.Run("files are listed in one request per commit", func(t *testing.T) {
+		var diff strings.Builder
+		for i := 0; i < maxSymbolFilesPerCommit+5; i++ {
+			fmt.Fprintf(&diff, "f%[1]d.go f%[1]d.go\n@@ -1,1 +1,1 @@\n-func a%[1]d() {}\n+func b%[1]d() {}\n", i)
+		}
+		files, err := result.ParseDiffString(diff.String())
+		require.NoError(t, err)
+
+		var mu sync.Mutex
+		calls := map[api.CommitID][]string{}
+		listSymbols := func(_ context.Context, _ api.RepoName, commit api.CommitID, paths []string) ([]result.Symbol, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			require.NotContains(t, calls, commit)
+			calls[commit] = paths
+			return nil, nil
+		}
+
+		cm := &result.CommitMatch{
+			Repo:        types.MinimalRepo{Name: "repo"},
+			Commit:      gitdomain.Commit{ID: "commit", Parents: []api.CommitID{"parent"}},
+			Diff:        files,
+			DiffPreview: &result.MatchedString{Content: diff.String()},
+		}
+		require.NoError(t, annotateChangedSymbols(context.Background(), listSymbols, cm))
+		require.Len(t, calls["parent"], maxSymbolFilesPerCommit)
+		require.Len(t, calls["commit"], maxSymbolFilesPerCommit)
+	})
+
+	t.Run("batches of commits are annotated concurrently", func(t *testing.T) {
+		var (
+			mu       sync.Mutex
+			inFlight int
+			peak     int
+		)
+		release := make(chan struct{})
+		listSymbols := func(_ context.Context, _ api.RepoName, commit api.CommitID, _ []string) ([]result.Symbol, error) {
+			mu.Lock()
+			inFlight++
+			if inFlight > peak {
+				peak = inFlight
+			}
+			if inFlight == 2*symbolsConcurrency {
+				// Both sides of symbolsConcurrency commits are being parsed.
+				close(release)
+			}
+			mu.Unlock()
+			<-release
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			return symbols[commit], nil
+		}
+
+		var matches []*result.CommitMatch
+		for i := 0; i < 3*symbolsConcurrency; i++ {
+			matches = append(matches, newMatch(nil))
+		}
+		annotateCommitsChangedSymbols(context.Background(), listSymbols, matches, func(_ *result.CommitMatch, err error) {
+			t.Error(err)
+		})
+		require.Equal(t, 2*symbolsConcurrency, peak)
+		for _, cm := range matches {
+			require.Len(t, cm.ChangedSymbols, 3)
+		}
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/zoekt"
 	"go.opentelemetry.io/otel/attribute"
 
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -74,4 +75,5 @@ type RuntimeClients struct {
 	SearcherURLs                *endpoint.Map
 	SearcherGRPCConnectionCache *defaults.ConnectionCache
 	Gitserver                   gitserver.Client
+	Dependencies                *dependencies.Service
 }
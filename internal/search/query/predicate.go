@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/Masterminds/semver"
 	"github.com/grafana/regexp"
 	"github.com/grafana/regexp/syntax"
+	"golang.org/x/exp/slices"
 
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -40,6 +42,7 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.key":               func() Predicate { return &RepoHasKeyPredicate{} },
 		"has.meta":              func() Predicate { return &RepoHasMetaPredicate{} },
 		"has.topic":             func() Predicate { return &RepoHasTopicPredicate{} },
+		"has.dependency":        func() Predicate { return &RepoHasDependencyPredicate{} },
 
 		// Deprecated predicates
 		"contains": func() Predicate { return &RepoContainsPredicate{} },
@@ -483,6 +486,105 @@ func (p *RepoHasTopicPredicate) Unmarshal(params string, negated bool) (err erro
 func (p *RepoHasTopicPredicate) Field() string { return FieldRepo }
 func (p *RepoHasTopicPredicate) Name() string  { return "has.topic" }
 
+// DependencyEcosystems is the set of package ecosystems that can be passed to
+// the `ecosystem` argument of the `repo:has.dependency()` predicate.
+var DependencyEcosystems = []string{"npm", "go", "rust", "python", "ruby"}
+
+// RepoHasDependencyPredicate represents the `repo:has.dependency()` predicate,
+// which filters to repos whose lockfiles declare a dependency on a package,
+// optionally restricted to a package ecosystem and a semver range. For example:
+//
+//	repo:has.dependency(lodash)
+//	repo:has.dependency(name:lodash ecosystem:npm version:<4.17.21)
+type RepoHasDependencyPredicate struct {
+	Package   string
+	Ecosystem string
+	Version   string
+	Negated   bool
+}
+
+func (f *RepoHasDependencyPredicate) Unmarshal(params string, negated bool) error {
+	// Package names like `@types/node` or `golang.org/x/net` are not valid
+	// search patterns, so we scan the arguments ourselves rather than
+	// parsing them as a query.
+	for rest := strings.TrimSpace(params); rest != ""; rest = strings.TrimSpace(rest) {
+		var arg string
+		if end := strings.IndexAny(rest, " \t\n"); end >= 0 {
+			arg, rest = rest[:end], rest[end:]
+		} else {
+			arg, rest = rest, ""
+		}
+
+		key, value, ok := strings.Cut(arg, ":")
+		if !ok {
+			if strings.EqualFold(arg, "or") || strings.EqualFold(arg, "and") {
+				return errors.New("the repo:has.dependency() predicate does not support 'and' or 'or' queries")
+			}
+			if err := f.setArg("name", arg); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, `'`) {
+			// Quoted values may contain whitespace, e.g. version:">= 1.0, < 2.0".
+			quoted := arg[len(key)+1:] + rest
+			unquoted, advance, err := ScanDelimited([]byte(quoted), true, rune(quoted[0]))
+			if err != nil {
+				return err
+			}
+			value, rest = unquoted, quoted[advance:]
+		}
+
+		if strings.HasPrefix(key, "-") {
+			return errors.New("the repo:has.dependency() predicate does not support negated values")
+		}
+		if err := f.setArg(strings.ToLower(key), value); err != nil {
+			return err
+		}
+	}
+
+	if f.Package == "" {
+		return errors.New("the repo:has.dependency() predicate requires a package name")
+	}
+
+	f.Negated = negated
+	return nil
+}
+
+func (f *RepoHasDependencyPredicate) setArg(key, value string) error {
+	switch key {
+	case "name":
+		if f.Package != "" {
+			return errors.New("cannot specify name multiple times")
+		}
+		f.Package = value
+	case "ecosystem":
+		if f.Ecosystem != "" {
+			return errors.New("cannot specify ecosystem multiple times")
+		}
+		ecosystem := strings.ToLower(value)
+		if !slices.Contains(DependencyEcosystems, ecosystem) {
+			return errors.Errorf("unsupported ecosystem %q, expected one of %s", value, strings.Join(DependencyEcosystems, ", "))
+		}
+		f.Ecosystem = ecosystem
+	case "version":
+		if f.Version != "" {
+			return errors.New("cannot specify version multiple times")
+		}
+		if _, err := semver.NewConstraint(value); err != nil {
+			return errors.Errorf("the repo:has.dependency() predicate has invalid `version` argument: %w", err)
+		}
+		f.Version = value
+	default:
+		return errors.Errorf("unsupported option %q", key)
+	}
+	return nil
+}
+
+func (f *RepoHasDependencyPredicate) Field() string { return FieldRepo }
+func (f *RepoHasDependencyPredicate) Name() string  { return "has.dependency" }
+
 // RepoContainsPredicate represents the `repo:contains(file:a content:b)` predicate.
 // DEPRECATED: this syntax is deprecated in favor of `repo:contains.file`.
 type RepoContainsPredicate struct {
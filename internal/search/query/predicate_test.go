@@ -147,6 +147,61 @@ func TestRepoHasTopicPredicate(t *testing.T) {
 	})
 }
 
+func TestRepoHasDependencyPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *RepoHasDependencyPredicate
+		}
+
+		valid := []test{
+			{`unnamed name`, `lodash`, &RepoHasDependencyPredicate{Package: "lodash"}},
+			{`name`, `name:lodash`, &RepoHasDependencyPredicate{Package: "lodash"}},
+			{`scoped name`, `@types/node`, &RepoHasDependencyPredicate{Package: "@types/node"}},
+			{`ecosystem`, `name:lodash ecosystem:NPM`, &RepoHasDependencyPredicate{Package: "lodash", Ecosystem: "npm"}},
+			{`version`, `name:lodash version:<4.17.21`, &RepoHasDependencyPredicate{Package: "lodash", Version: "<4.17.21"}},
+			{`quoted version range`, `github.com/sourcegraph/log version:">= 0.1, < 1.0"`, &RepoHasDependencyPredicate{Package: "github.com/sourcegraph/log", Version: ">= 0.1, < 1.0"}},
+			{`all`, `ecosystem:go version:~1.2 name:golang.org/x/net`, &RepoHasDependencyPredicate{Package: "golang.org/x/net", Ecosystem: "go", Version: "~1.2"}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasDependencyPredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`no name`, `ecosystem:npm`, nil},
+			{`duplicate name`, `lodash name:underscore`, nil},
+			{`unknown ecosystem`, `name:lodash ecosystem:cobol`, nil},
+			{`invalid version`, `name:lodash version:latest`, nil},
+			{`negated name`, `-name:lodash`, nil},
+			{`unsupported option`, `name:lodash path:package.json`, nil},
+			{`or`, `lodash or underscore`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RepoHasDependencyPredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
 func TestRepoHasKVPMetaPredicate(t *testing.T) {
 	t.Run("Unmarshal", func(t *testing.T) {
 		type test struct {
@@ -206,6 +206,20 @@ func (p Plan) ToQ() Q {
 	return NewOperator(nodes, Or)
 }
 
+// WithDefaultCount returns a copy of the plan in which every query without a
+// count: parameter has one with the given value.
+func (p Plan) WithDefaultCount(count int) Plan {
+	plan := make(Plan, 0, len(p))
+	for _, basic := range p {
+		if basic.Count() == nil {
+			parameters := append(append(Parameters{}, basic.Parameters...), Parameter{Field: FieldCount, Value: strconv.Itoa(count)})
+			basic = basic.MapParameters(parameters)
+		}
+		plan = append(plan, basic)
+	}
+	return plan
+}
+
 // Basic represents a leaf expression to evaluate in our search engine. A basic
 // query comprises:
 //
@@ -483,6 +497,13 @@ func (p Parameters) RepoHasTopics() (res []RepoHasTopicPredicate) {
 	return res
 }
 
+func (p Parameters) RepoHasDependencies() (res []RepoHasDependencyPredicate) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasDependencyPredicate) {
+		res = append(res, *pred)
+	})
+	return res
+}
+
 func (p Parameters) FileHasOwner() (include, exclude []string) {
 	VisitTypedPredicate(toNodes(p), func(pred *FileHasOwnerPredicate) {
 		if pred.Negated {
@@ -56,3 +56,15 @@ func TestRepoHasKVPs(t *testing.T) {
 
 	require.Equal(t, want, ps.RepoHasKVPs())
 }
+
+func TestPlanWithDefaultCount(t *testing.T) {
+	plan, err := Pipeline(InitLiteral("(repo:a foo) or (repo:b bar count:5)"))
+	require.NoError(t, err)
+
+	got := plan.WithDefaultCount(100)
+	require.Len(t, got, 2)
+	require.Equal(t, "repo:a count:100 foo", got[0].StringHuman())
+	require.Equal(t, "repo:b count:5 bar", got[1].StringHuman())
+	// The original plan is not modified.
+	require.Nil(t, plan[0].Count())
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Masterminds/semver"
 	"github.com/grafana/regexp"
 	regexpsyntax "github.com/grafana/regexp/syntax"
 	"github.com/sourcegraph/conc/pool"
@@ -23,6 +24,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/dependencies/lockfiles"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
@@ -70,22 +73,24 @@ func (r *Resolved) String() string {
 	return fmt.Sprintf("Resolved{RepoRevs=%d BackendsMissing=%d}", len(r.RepoRevs), r.BackendsMissing)
 }
 
-func NewResolver(logger log.Logger, db database.DB, gitserverClient gitserver.Client, searcher *endpoint.Map, zoekt zoekt.Streamer) *Resolver {
+func NewResolver(logger log.Logger, db database.DB, gitserverClient gitserver.Client, searcher *endpoint.Map, zoekt zoekt.Streamer, dependenciesService *dependencies.Service) *Resolver {
 	return &Resolver{
-		logger:    logger,
-		db:        db,
-		gitserver: gitserverClient,
-		zoekt:     zoekt,
-		searcher:  searcher,
+		logger:       logger,
+		db:           db,
+		gitserver:    gitserverClient,
+		zoekt:        zoekt,
+		searcher:     searcher,
+		dependencies: dependenciesService,
 	}
 }
 
 type Resolver struct {
-	logger    log.Logger
-	db        database.DB
-	gitserver gitserver.Client
-	zoekt     zoekt.Streamer
-	searcher  *endpoint.Map
+	logger       log.Logger
+	db           database.DB
+	gitserver    gitserver.Client
+	zoekt        zoekt.Streamer
+	searcher     *endpoint.Map
+	dependencies *dependencies.Service
 }
 
 func (r *Resolver) Iterator(ctx context.Context, opts search.RepoOptions) *iterator.Iterator[Resolved] {
@@ -150,6 +155,11 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 		})
 	}
 
+	dependencyFilters, err := r.dependencyFilters(ctx, op.HasDependencies)
+	if err != nil {
+		return Resolved{}, err
+	}
+
 	options := database.ReposListOptions{
 		IncludePatterns:       includePatterns,
 		ExcludePattern:        query.UnionRegExps(excludePatterns),
@@ -157,6 +167,7 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (_ Resolv
 		CaseSensitivePatterns: op.CaseSensitiveRepoFilters,
 		KVPFilters:            kvpFilters,
 		TopicFilters:          topicFilters,
+		DependencyFilters:     dependencyFilters,
 		Cursors:               op.Cursors,
 		// List N+1 repos so we can see if there are repos omitted due to our repo limit.
 		LimitOffset:  &database.LimitOffset{Limit: limit + 1},
@@ -450,6 +461,59 @@ func (r *Resolver) normalizeRepoRefs(
 
 }
 
+// dependencyFilters converts repo:has.dependency() predicates into database filters. Version
+// constraints are resolved against the versions declared by indexed lockfiles, since they can't be
+// evaluated by the database.
+func (r *Resolver) dependencyFilters(ctx context.Context, predicates []query.RepoHasDependencyPredicate) ([]database.RepoDependencyFilter, error) {
+	if len(predicates) == 0 {
+		return nil, nil
+	}
+
+	filters := make([]database.RepoDependencyFilter, 0, len(predicates))
+	for _, predicate := range predicates {
+		filter := database.RepoDependencyFilter{
+			Name:    predicate.Package,
+			Negated: predicate.Negated,
+		}
+
+		if predicate.Ecosystem != "" {
+			scheme, ok := lockfiles.SchemeForEcosystem(predicate.Ecosystem)
+			if !ok {
+				return nil, errors.Errorf("unsupported dependency ecosystem %q", predicate.Ecosystem)
+			}
+			filter.Scheme = scheme
+		}
+
+		if predicate.Version != "" {
+			constraint, err := semver.NewConstraint(predicate.Version)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid version constraint %q", predicate.Version)
+			}
+
+			if r.dependencies == nil {
+				return nil, errors.New("dependency versions can't be resolved without a dependencies service")
+			}
+			versions, err := r.dependencies.ListLockfileDependencyVersions(ctx, filter.Scheme, filter.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			filter.Versions = []string{}
+			for _, version := range versions {
+				// Versions that aren't valid semver (e.g. Go pseudo-versions are, but
+				// arbitrary git refs aren't) can never satisfy a constraint.
+				if v, err := semver.NewVersion(version); err == nil && constraint.Check(v) {
+					filter.Versions = append(filter.Versions, version)
+				}
+			}
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
 // filterHasCommitAfter filters the revisions on each of a set of RepositoryRevisions to ensure that
 // any repo-level filters (e.g. `repo:contains.commit.after()`) apply to this repo/rev combo.
 func (r *Resolver) filterHasCommitAfter(
@@ -44,6 +44,11 @@ type CommitMatch struct {
 	// * when sub-repo permissions filtering has been enabled,
 	// * when ownership filtering clause is used, and search result is commits.
 	ModifiedFiles []string
+
+	// ChangedSymbols is the set of symbols added, removed or modified by the
+	// diff. It is only populated for diff searches that select symbols, e.g.
+	// `type:diff select:symbol`.
+	ChangedSymbols []ChangedSymbol
 }
 
 func (cm *CommitMatch) Body() MatchedString {
@@ -134,6 +139,17 @@ func (cm *CommitMatch) Select(path filter.SelectPath) Match {
 			return nil
 		}
 		return cm
+	case filter.Symbol:
+		if len(cm.ChangedSymbols) == 0 {
+			return nil
+		}
+		if len(path) > 1 {
+			cm.ChangedSymbols = SelectChangedSymbolKind(cm.ChangedSymbols, path[1])
+			if len(cm.ChangedSymbols) == 0 {
+				return nil
+			}
+		}
+		return cm
 	}
 	return nil
 }
@@ -261,12 +277,18 @@ func (r *CommitMatch) CommitToDiffMatches() []*CommitDiffMatch {
 	matches := make([]*CommitDiffMatch, 0, len(r.Diff))
 	for _, diff := range r.Diff {
 		diff := diff
-		matches = append(matches, &CommitDiffMatch{
+		match := &CommitDiffMatch{
 			Commit:   r.Commit,
 			Repo:     r.Repo,
 			Preview:  r.DiffPreview,
 			DiffFile: &diff,
-		})
+		}
+		for _, symbol := range r.ChangedSymbols {
+			if symbol.Path == diff.OrigName || symbol.Path == diff.NewName {
+				match.ChangedSymbols = append(match.ChangedSymbols, symbol)
+			}
+		}
+		matches = append(matches, match)
 	}
 	return matches
 }
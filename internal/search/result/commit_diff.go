@@ -19,6 +19,10 @@ type CommitDiffMatch struct {
 	Repo    types.MinimalRepo
 	Preview *MatchedString
 	*DiffFile
+
+	// ChangedSymbols is the set of symbols in this file that were added,
+	// removed or modified by the diff. See CommitMatch.ChangedSymbols.
+	ChangedSymbols []ChangedSymbol
 }
 
 func (cd *CommitDiffMatch) RepoName() types.MinimalRepo {
@@ -108,6 +112,17 @@ func (cm *CommitDiffMatch) Select(path filter.SelectPath) Match {
 			return nil
 		}
 		return cm
+	case filter.Symbol:
+		if len(cm.ChangedSymbols) == 0 {
+			return nil
+		}
+		if len(path) > 1 {
+			cm.ChangedSymbols = SelectChangedSymbolKind(cm.ChangedSymbols, path[1])
+			if len(cm.ChangedSymbols) == 0 {
+				return nil
+			}
+		}
+		return cm
 	}
 	return nil
 }
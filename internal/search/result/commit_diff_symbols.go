@@ -0,0 +1,180 @@
+package result
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// SymbolChange describes how a diff affected a symbol.
+type SymbolChange int
+
+const (
+	SymbolModified SymbolChange = iota
+	SymbolAdded
+	SymbolRemoved
+)
+
+func (c SymbolChange) String() string {
+	switch c {
+	case SymbolAdded:
+		return "ADDED"
+	case SymbolRemoved:
+		return "REMOVED"
+	default:
+		return "MODIFIED"
+	}
+}
+
+func symbolChangeFromString(s string) SymbolChange {
+	switch s {
+	case "ADDED":
+		return SymbolAdded
+	case "REMOVED":
+		return SymbolRemoved
+	default:
+		return SymbolModified
+	}
+}
+
+// ChangedSymbol is a symbol that was added, removed or modified by a commit.
+type ChangedSymbol struct {
+	Symbol
+	Change SymbolChange
+
+	// Commit is the revision that Symbol.Path and Symbol.Line refer to. This is the
+	// commit itself for added and modified symbols and its parent for removed symbols.
+	Commit api.CommitID
+}
+
+// URL returns a link to the location of the symbol in the given repository.
+func (cs *ChangedSymbol) URL(repo types.MinimalRepo) *url.URL {
+	rev := string(cs.Commit)
+	return (&SymbolMatch{
+		Symbol: cs.Symbol,
+		File:   &File{Repo: repo, CommitID: cs.Commit, InputRev: &rev, Path: cs.Symbol.Path},
+	}).URL()
+}
+
+// SelectChangedSymbolKind returns the subset of symbols whose kind corresponds to
+// the given `select:symbol.<kind>` field.
+func SelectChangedSymbolKind(symbols []ChangedSymbol, field string) []ChangedSymbol {
+	var res []ChangedSymbol
+	for _, symbol := range symbols {
+		if field == toSelectKind[strings.ToLower(symbol.Kind)] {
+			res = append(res, symbol)
+		}
+	}
+	return res
+}
+
+// DiffChangedLines returns the 1-indexed line numbers of the removed lines (in the
+// original file) and of the added lines (in the new file) of a file diff.
+func DiffChangedLines(file DiffFile) (removed, added map[int]struct{}) {
+	removed = map[int]struct{}{}
+	added = map[int]struct{}{}
+	for _, hunk := range file.Hunks {
+		oldLine, newLine := hunk.OldStart, hunk.NewStart
+		for _, line := range hunk.Lines {
+			switch {
+			case strings.HasPrefix(line, "-"):
+				removed[oldLine] = struct{}{}
+				oldLine++
+			case strings.HasPrefix(line, "+"):
+				added[newLine] = struct{}{}
+				newLine++
+			default:
+				oldLine++
+				newLine++
+			}
+		}
+	}
+	return removed, added
+}
+
+// DiffSymbols compares the symbols of a file before and after a change and
+// returns the symbols that the change added, removed or modified. A symbol is
+// only reported when one of the given removed lines falls within its extent in
+// the original file, or one of the given added lines falls within its extent in
+// the new file. Callers can thus restrict the result to symbols touched by a
+// subset of the changed lines, e.g. those matching a search pattern. Since
+// symbols only record the line they start on, the extent of a symbol is
+// approximated as the lines up to the next symbol that is not nested in it.
+//
+// Symbol lines are expected to be 1-indexed.
+func DiffSymbols(removedLines, addedLines map[int]struct{}, before, after []Symbol) (changed []ChangedSymbol) {
+	type key struct{ parent, name, kind string }
+	keyOf := func(s Symbol) key { return key{s.Parent, s.Name, s.Kind} }
+
+	// Pair up symbols by key in order of appearance, so that overloads with the
+	// same name are compared positionally.
+	beforeByKey := map[key][]int{}
+	for i, s := range before {
+		beforeByKey[keyOf(s)] = append(beforeByKey[keyOf(s)], i)
+	}
+
+	beforeExtents := symbolExtents(before)
+	afterExtents := symbolExtents(after)
+	paired := make([]bool, len(before))
+
+	for i, s := range after {
+		k := keyOf(s)
+		candidates := beforeByKey[k]
+		if len(candidates) == 0 {
+			if overlaps(afterExtents[i], addedLines) {
+				changed = append(changed, ChangedSymbol{Symbol: s, Change: SymbolAdded})
+			}
+			continue
+		}
+		j := candidates[0]
+		beforeByKey[k] = candidates[1:]
+		paired[j] = true
+
+		if overlaps(beforeExtents[j], removedLines) || overlaps(afterExtents[i], addedLines) {
+			changed = append(changed, ChangedSymbol{Symbol: s, Change: SymbolModified})
+		}
+	}
+
+	for j, s := range before {
+		if !paired[j] && overlaps(beforeExtents[j], removedLines) {
+			changed = append(changed, ChangedSymbol{Symbol: s, Change: SymbolRemoved})
+		}
+	}
+
+	return changed
+}
+
+type lineRange struct{ start, end int } // inclusive, end < 0 means unbounded
+
+func symbolExtents(symbols []Symbol) []lineRange {
+	order := make([]int, len(symbols))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return symbols[order[a]].Line < symbols[order[b]].Line })
+
+	extents := make([]lineRange, len(symbols))
+	for pos, i := range order {
+		extent := lineRange{start: symbols[i].Line, end: -1}
+		for _, next := range order[pos+1:] {
+			if symbols[next].Line > symbols[i].Line && symbols[next].Parent != symbols[i].Name {
+				extent.end = symbols[next].Line - 1
+				break
+			}
+		}
+		extents[i] = extent
+	}
+	return extents
+}
+
+func overlaps(r lineRange, lines map[int]struct{}) bool {
+	for line := range lines {
+		if line >= r.start && (r.end < 0 || line <= r.end) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,115 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+)
+
+const symbolsDiffInput = `main.go main.go
+@@ -3,7 +3,7 @@ package main
+ func a() {
+-	return 1
++	return 2
+ }
+ 
+ func b() {
+ 	return 1
+ }
+@@ -12,4 +12,3 @@ func c() {
+ }
+ 
+-func d() {
+-}
++func e() {}
+`
+
+func TestDiffChangedLines(t *testing.T) {
+	files, err := ParseDiffString(symbolsDiffInput)
+	require.NoError(t, err)
+
+	removed, added := DiffChangedLines(files[0])
+	require.Equal(t, map[int]struct{}{4: {}, 14: {}, 15: {}}, removed)
+	require.Equal(t, map[int]struct{}{4: {}, 14: {}}, added)
+}
+
+func TestDiffSymbols(t *testing.T) {
+	before := []Symbol{
+		{Name: "a", Kind: "function", Line: 3},
+		{Name: "b", Kind: "function", Line: 7},
+		{Name: "c", Kind: "function", Line: 10},
+		{Name: "d", Kind: "function", Line: 14},
+	}
+	after := []Symbol{
+		{Name: "a", Kind: "function", Line: 3},
+		{Name: "b", Kind: "function", Line: 7},
+		{Name: "c", Kind: "function", Line: 10},
+		{Name: "e", Kind: "function", Line: 14},
+	}
+
+	files, err := ParseDiffString(symbolsDiffInput)
+	require.NoError(t, err)
+	removed, added := DiffChangedLines(files[0])
+
+	type change struct {
+		name   string
+		change SymbolChange
+	}
+	summarize := func(symbols []ChangedSymbol) (res []change) {
+		for _, s := range symbols {
+			res = append(res, change{s.Name, s.Change})
+		}
+		return res
+	}
+
+	t.Run("all changed lines", func(t *testing.T) {
+		got := summarize(DiffSymbols(removed, added, before, after))
+		require.Equal(t, []change{
+			{"a", SymbolModified},
+			{"e", SymbolAdded},
+			{"d", SymbolRemoved},
+		}, got)
+	})
+
+	t.Run("subset of changed lines", func(t *testing.T) {
+		got := summarize(DiffSymbols(nil, map[int]struct{}{14: {}}, before, after))
+		require.Equal(t, []change{{"e", SymbolAdded}}, got)
+	})
+
+	t.Run("nested symbols", func(t *testing.T) {
+		before := []Symbol{
+			{Name: "T", Kind: "struct", Line: 1},
+			{Name: "x", Parent: "T", Kind: "field", Line: 2},
+			{Name: "y", Parent: "T", Kind: "field", Line: 3},
+			{Name: "f", Kind: "function", Line: 6},
+		}
+		after := []Symbol{
+			{Name: "T", Kind: "struct", Line: 1},
+			{Name: "x", Parent: "T", Kind: "field", Line: 2},
+			{Name: "f", Kind: "function", Line: 5},
+		}
+		got := summarize(DiffSymbols(map[int]struct{}{3: {}}, nil, before, after))
+		require.Equal(t, []change{{"T", SymbolModified}, {"y", SymbolRemoved}}, got)
+	})
+}
+
+func TestCommitMatchSelectSymbol(t *testing.T) {
+	cm := &CommitMatch{
+		DiffPreview: &MatchedString{Content: "diff"},
+		ChangedSymbols: []ChangedSymbol{
+			{Symbol: Symbol{Name: "a", Kind: "function"}, Change: SymbolModified},
+			{Symbol: Symbol{Name: "T", Kind: "struct"}, Change: SymbolAdded},
+		},
+	}
+
+	require.Equal(t, cm, cm.Select(filter.SelectPath{filter.Symbol}))
+
+	got := cm.Select(filter.SelectPath{filter.Symbol, "struct"}).(*CommitMatch)
+	require.Len(t, got.ChangedSymbols, 1)
+	require.Equal(t, "T", got.ChangedSymbols[0].Name)
+
+	require.Nil(t, cm.Select(filter.SelectPath{filter.Symbol, "enum"}))
+	require.Nil(t, (&CommitMatch{}).Select(filter.SelectPath{filter.Symbol}))
+}
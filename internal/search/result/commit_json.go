@@ -31,6 +31,19 @@ type stableCommitMatchJSON struct {
 	MessagePreview  *MatchedString            `json:"messagePreview,omitempty"`
 	DiffPreview     *MatchedString            `json:"diffPreview,omitempty"`
 	ModifiedFiles   []string                  `json:"modifiedFiles,omitempty"`
+	ChangedSymbols  []stableChangedSymbolJSON `json:"changedSymbols,omitempty"`
+}
+
+type stableChangedSymbolJSON struct {
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	Kind       string `json:"kind"`
+	Language   string `json:"language,omitempty"`
+	Parent     string `json:"parent,omitempty"`
+	ParentKind string `json:"parentKind,omitempty"`
+	Change     string `json:"change"`
+	Commit     string `json:"commit"`
 }
 
 type stableSignatureMarshaler struct {
@@ -54,6 +67,21 @@ func (cm CommitMatch) MarshalJSON() ([]byte, error) {
 		parents[i] = string(parent)
 	}
 
+	var changedSymbols []stableChangedSymbolJSON
+	for _, symbol := range cm.ChangedSymbols {
+		changedSymbols = append(changedSymbols, stableChangedSymbolJSON{
+			Name:       symbol.Name,
+			Path:       symbol.Path,
+			Line:       symbol.Line,
+			Kind:       symbol.Kind,
+			Language:   symbol.Language,
+			Parent:     symbol.Parent,
+			ParentKind: symbol.ParentKind,
+			Change:     symbol.Change.String(),
+			Commit:     string(symbol.Commit),
+		})
+	}
+
 	marshaler := stableCommitMatchJSON{
 		RepoID:    int32(cm.Repo.ID),
 		RepoName:  string(cm.Repo.Name),
@@ -72,6 +100,7 @@ func (cm CommitMatch) MarshalJSON() ([]byte, error) {
 		MessagePreview:  cm.MessagePreview,
 		DiffPreview:     cm.DiffPreview,
 		ModifiedFiles:   cm.ModifiedFiles,
+		ChangedSymbols:  changedSymbols,
 	}
 
 	return json.Marshal(marshaler)
@@ -105,6 +134,23 @@ func (cm *CommitMatch) UnmarshalJSON(input []byte) (err error) {
 		}
 	}
 
+	var changedSymbols []ChangedSymbol
+	for _, symbol := range unmarshaler.ChangedSymbols {
+		changedSymbols = append(changedSymbols, ChangedSymbol{
+			Symbol: Symbol{
+				Name:       symbol.Name,
+				Path:       symbol.Path,
+				Line:       symbol.Line,
+				Kind:       symbol.Kind,
+				Language:   symbol.Language,
+				Parent:     symbol.Parent,
+				ParentKind: symbol.ParentKind,
+			},
+			Change: symbolChangeFromString(symbol.Change),
+			Commit: api.CommitID(symbol.Commit),
+		})
+	}
+
 	*cm = CommitMatch{
 		Commit: gitdomain.Commit{
 			ID: api.CommitID(unmarshaler.CommitID),
@@ -128,6 +174,7 @@ func (cm *CommitMatch) UnmarshalJSON(input []byte) (err error) {
 		DiffPreview:    unmarshaler.DiffPreview,
 		Diff:           structuredDiff,
 		ModifiedFiles:  unmarshaler.ModifiedFiles,
+		ChangedSymbols: changedSymbols,
 	}
 	return nil
 }
@@ -163,10 +163,20 @@ type EventCommitMatch struct {
 	Content         string     `json:"content"`
 	// [line, character, length]
 	Ranges [][3]int32 `json:"ranges"`
+	// ChangedSymbols is only set for `type:diff select:symbol` searches.
+	ChangedSymbols []ChangedSymbol `json:"changedSymbols,omitempty"`
 }
 
 func (e *EventCommitMatch) eventMatch() {}
 
+// ChangedSymbol is a symbol added, removed or modified by a commit.
+type ChangedSymbol struct {
+	Symbol
+	Path string `json:"path"`
+	// Change is one of ADDED, REMOVED or MODIFIED.
+	Change string `json:"change"`
+}
+
 type EventPersonMatch struct {
 	// Type is always PersonMatchType. Included here for marshalling.
 	Type MatchType `json:"type"`
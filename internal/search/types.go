@@ -117,6 +117,19 @@ type SymbolsParameters struct {
 	Timeout time.Duration
 }
 
+// ParseFilesParameters are the parameters of a request to parse the symbols of
+// individual files at a commit, without indexing the rest of the repository.
+type ParseFilesParameters struct {
+	// Repo is the name of the repository the files are in.
+	Repo api.RepoName `json:"repo"`
+
+	// CommitID is the commit to read the files at.
+	CommitID api.CommitID `json:"commitID"`
+
+	// Paths are the paths of the files to parse.
+	Paths []string `json:"paths"`
+}
+
 type SymbolsResponse struct {
 	Symbols result.Symbols `json:"symbols,omitempty"`
 	Err     string         `json:"error,omitempty"`
@@ -443,10 +456,11 @@ type RepoOptions struct {
 	Cursors     []*types.Cursor
 
 	// Whether we should depend on Zoekt for resolving repositories
-	UseIndex       query.YesNoOnly
-	HasFileContent []query.RepoHasFileContentArgs
-	HasKVPs        []query.RepoKVPFilter
-	HasTopics      []query.RepoHasTopicPredicate
+	UseIndex        query.YesNoOnly
+	HasFileContent  []query.RepoHasFileContentArgs
+	HasKVPs         []query.RepoKVPFilter
+	HasTopics       []query.RepoHasTopicPredicate
+	HasDependencies []query.RepoHasDependencyPredicate
 
 	// ForkSet indicates whether `fork:` was set explicitly in the query,
 	// or whether the values were set from defaults.
@@ -542,6 +556,24 @@ func (op *RepoOptions) Attributes() []attribute.KeyValue {
 			add(trace.Scoped(fmt.Sprintf("hasTopics[%d]", i), nondefault...)...)
 		}
 	}
+	if len(op.HasDependencies) > 0 {
+		for i, arg := range op.HasDependencies {
+			nondefault := []attribute.KeyValue{}
+			if arg.Package != "" {
+				nondefault = append(nondefault, attribute.String("package", arg.Package))
+			}
+			if arg.Ecosystem != "" {
+				nondefault = append(nondefault, attribute.String("ecosystem", arg.Ecosystem))
+			}
+			if arg.Version != "" {
+				nondefault = append(nondefault, attribute.String("version", arg.Version))
+			}
+			if arg.Negated {
+				nondefault = append(nondefault, attribute.Bool("negated", arg.Negated))
+			}
+			add(trace.Scoped(fmt.Sprintf("hasDependencies[%d]", i), nondefault...)...)
+		}
+	}
 	if op.ForkSet {
 		add(attribute.Bool("forkSet", op.ForkSet))
 	}
@@ -628,6 +660,22 @@ func (op *RepoOptions) String() string {
 			}
 		}
 	}
+	if len(op.HasDependencies) > 0 {
+		for i, arg := range op.HasDependencies {
+			if arg.Package != "" {
+				fmt.Fprintf(&b, "HasDependencies[%d].package: %s\n", i, arg.Package)
+			}
+			if arg.Ecosystem != "" {
+				fmt.Fprintf(&b, "HasDependencies[%d].ecosystem: %s\n", i, arg.Ecosystem)
+			}
+			if arg.Version != "" {
+				fmt.Fprintf(&b, "HasDependencies[%d].version: %s\n", i, arg.Version)
+			}
+			if arg.Negated {
+				fmt.Fprintf(&b, "HasDependencies[%d].negated: %t\n", i, arg.Negated)
+			}
+		}
+	}
 
 	if op.CaseSensitiveRepoFilters {
 		fmt.Fprintf(&b, "CaseSensitiveRepoFilters: %t\n", op.CaseSensitiveRepoFilters)
@@ -189,17 +189,21 @@ func (c *Client) Search(ctx context.Context, args search.SymbolsParameters) (sym
 		return nil, errors.Wrap(err, "executing symbols search request")
 	}
 
-	symbols = response.Symbols
-
 	// 🚨 SECURITY: We have valid results, so we need to apply sub-repo permissions
 	// filtering.
+	return c.filterSubRepoPermissions(ctx, args.Repo, response.Symbols)
+}
+
+// filterSubRepoPermissions filters out the symbols of files the actor can not
+// read in place.
+func (c *Client) filterSubRepoPermissions(ctx context.Context, repo api.RepoName, symbols result.Symbols) (result.Symbols, error) {
 	if c.SubRepoPermsChecker == nil {
-		return symbols, err
+		return symbols, nil
 	}
 
 	checker := c.SubRepoPermsChecker()
 	if !authz.SubRepoEnabled(checker) {
-		return symbols, err
+		return symbols, nil
 	}
 
 	a := actor.FromContext(ctx)
@@ -207,7 +211,7 @@ func (c *Client) Search(ctx context.Context, args search.SymbolsParameters) (sym
 	filtered := symbols[:0]
 	for _, r := range symbols {
 		rc := authz.RepoContent{
-			Repo: args.Repo,
+			Repo: repo,
 			Path: r.Path,
 		}
 		perm, err := authz.ActorPermissions(ctx, checker, a, rc)
@@ -222,6 +226,85 @@ func (c *Client) Search(ctx context.Context, args search.SymbolsParameters) (sym
 	return filtered, nil
 }
 
+// ParseFiles parses the symbols of individual files at a commit. Unlike Search,
+// it does not index the repository at the commit, so it is suited to parsing a
+// few files at many commits, e.g. the files changed by commits.
+func (c *Client) ParseFiles(ctx context.Context, args search.ParseFilesParameters) (symbols result.Symbols, err error) {
+	tr, ctx := trace.New(ctx, "symbols", "ParseFiles",
+		attribute.String("repo", string(args.Repo)),
+		attribute.String("commitID", string(args.CommitID)),
+		attribute.Int("paths", len(args.Paths)))
+	defer tr.FinishWithErr(&err)
+
+	var response search.SymbolsResponse
+
+	if internalgrpc.IsGRPCEnabled(ctx) {
+		response, err = c.parseFilesGRPC(ctx, args)
+	} else {
+		response, err = c.parseFilesJSON(ctx, args)
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "executing symbols parse files request")
+	}
+
+	// 🚨 SECURITY: We have valid results, so we need to apply sub-repo permissions
+	// filtering.
+	return c.filterSubRepoPermissions(ctx, args.Repo, response.Symbols)
+}
+
+func (c *Client) parseFilesGRPC(ctx context.Context, args search.ParseFilesParameters) (search.SymbolsResponse, error) {
+	conn, err := c.getGRPCConn(string(args.Repo))
+	if err != nil {
+		return search.SymbolsResponse{}, errors.Wrap(err, "getting gRPC connection to symbols server")
+	}
+
+	grpcClient := proto.NewSymbolsServiceClient(conn)
+
+	var protoArgs proto.ParseFilesRequest
+	protoArgs.FromInternal(&args)
+
+	protoResponse, err := grpcClient.ParseFiles(ctx, &protoArgs)
+	if err != nil {
+		return search.SymbolsResponse{}, translateGRPCError(err)
+	}
+
+	response := protoResponse.ToInternal()
+	if response.Err != "" {
+		return search.SymbolsResponse{}, errors.New(response.Err)
+	}
+	return response, nil
+}
+
+func (c *Client) parseFilesJSON(ctx context.Context, args search.ParseFilesParameters) (search.SymbolsResponse, error) {
+	resp, err := c.httpPost(ctx, "parse-files", args.Repo, args)
+	if err != nil {
+		return search.SymbolsResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		// best-effort inclusion of body in error message
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 200))
+		return search.SymbolsResponse{}, errors.Errorf(
+			"Symbol.ParseFiles http status %d: %s",
+			resp.StatusCode,
+			string(body),
+		)
+	}
+
+	var response search.SymbolsResponse
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return search.SymbolsResponse{}, err
+	}
+	if response.Err != "" {
+		return search.SymbolsResponse{}, errors.New(response.Err)
+	}
+
+	return response, nil
+}
+
 func (c *Client) searchGRPC(ctx context.Context, args search.SymbolsParameters) (search.SymbolsResponse, error) {
 	conn, err := c.getGRPCConn(string(args.Repo))
 	if err != nil {
@@ -39,6 +39,22 @@ func (x *SearchRequest) ToInternal() search.SymbolsParameters {
 	}
 }
 
+func (x *ParseFilesRequest) FromInternal(p *search.ParseFilesParameters) {
+	*x = ParseFilesRequest{
+		Repo:     string(p.Repo),
+		CommitId: string(p.CommitID),
+		Paths:    p.Paths,
+	}
+}
+
+func (x *ParseFilesRequest) ToInternal() search.ParseFilesParameters {
+	return search.ParseFilesParameters{
+		Repo:     api.RepoName(x.GetRepo()),
+		CommitID: api.CommitID(x.GetCommitId()),
+		Paths:    x.GetPaths(),
+	}
+}
+
 func (x *SearchResponse) FromInternal(r *search.SymbolsResponse) {
 	symbols := make([]*SearchResponse_Symbol, 0, len(r.Symbols))
 
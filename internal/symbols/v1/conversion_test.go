@@ -63,6 +63,27 @@ func Test_Search_SymbolsParameters_ProtoRoundTrip(t *testing.T) {
 	}
 }
 
+func Test_Search_ParseFilesParameters_ProtoRoundTrip(t *testing.T) {
+	var diff string
+
+	f := func(original search.ParseFilesParameters) bool {
+		var originalProto ParseFilesRequest
+		originalProto.FromInternal(&original)
+
+		converted := originalProto.ToInternal()
+
+		if diff = cmp.Diff(original, converted, cmpopts.EquateEmpty()); diff != "" {
+			return false
+		}
+
+		return true
+	}
+
+	if err := quick.Check(f, nil); err != nil {
+		t.Errorf("ParseFilesParameters diff (-want +got):\n%s", diff)
+	}
+}
+
 func Test_Result_Symbol_ProtoRoundTrip(t *testing.T) {
 	var diff string
 
@@ -208,6 +208,74 @@ func (x *SearchResponse) GetError() string {
 	return ""
 }
 
+// ParseFilesRequest is the request to the ParseFiles method, which parses the
+// given files at a commit without indexing the rest of the repository.
+type ParseFilesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// repo is the name of the repository the files are in
+	Repo string `protobuf:"bytes,1,opt,name=repo,proto3" json:"repo,omitempty"`
+	// commit_id is the commit to read the files at
+	CommitId string `protobuf:"bytes,2,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	// paths are the paths of the files to parse
+	Paths []string `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty"`
+}
+
+func (x *ParseFilesRequest) Reset() {
+	*x = ParseFilesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_symbols_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParseFilesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParseFilesRequest) ProtoMessage() {}
+
+func (x *ParseFilesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_symbols_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParseFilesRequest.ProtoReflect.Descriptor instead.
+func (*ParseFilesRequest) Descriptor() ([]byte, []int) {
+	return file_symbols_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ParseFilesRequest) GetRepo() string {
+	if x != nil {
+		return x.Repo
+	}
+	return ""
+}
+
+func (x *ParseFilesRequest) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *ParseFilesRequest) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
 // LocalCodeIntelRequest is the request to the LocalCodeIntel method.
 type LocalCodeIntelRequest struct {
 	state         protoimpl.MessageState
@@ -221,7 +289,7 @@ type LocalCodeIntelRequest struct {
 func (x *LocalCodeIntelRequest) Reset() {
 	*x = LocalCodeIntelRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[2]
+		mi := &file_symbols_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -234,7 +302,7 @@ func (x *LocalCodeIntelRequest) String() string {
 func (*LocalCodeIntelRequest) ProtoMessage() {}
 
 func (x *LocalCodeIntelRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[2]
+	mi := &file_symbols_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -247,7 +315,7 @@ func (x *LocalCodeIntelRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocalCodeIntelRequest.ProtoReflect.Descriptor instead.
 func (*LocalCodeIntelRequest) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{2}
+	return file_symbols_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *LocalCodeIntelRequest) GetRepoCommitPath() *RepoCommitPath {
@@ -268,7 +336,7 @@ type LocalCodeIntelResponse struct {
 func (x *LocalCodeIntelResponse) Reset() {
 	*x = LocalCodeIntelResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[3]
+		mi := &file_symbols_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -281,7 +349,7 @@ func (x *LocalCodeIntelResponse) String() string {
 func (*LocalCodeIntelResponse) ProtoMessage() {}
 
 func (x *LocalCodeIntelResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[3]
+	mi := &file_symbols_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -294,7 +362,7 @@ func (x *LocalCodeIntelResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocalCodeIntelResponse.ProtoReflect.Descriptor instead.
 func (*LocalCodeIntelResponse) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{3}
+	return file_symbols_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *LocalCodeIntelResponse) GetSymbols() []*LocalCodeIntelResponse_Symbol {
@@ -314,7 +382,7 @@ type ListLanguagesRequest struct {
 func (x *ListLanguagesRequest) Reset() {
 	*x = ListLanguagesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[4]
+		mi := &file_symbols_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -327,7 +395,7 @@ func (x *ListLanguagesRequest) String() string {
 func (*ListLanguagesRequest) ProtoMessage() {}
 
 func (x *ListLanguagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[4]
+	mi := &file_symbols_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -340,7 +408,7 @@ func (x *ListLanguagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLanguagesRequest.ProtoReflect.Descriptor instead.
 func (*ListLanguagesRequest) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{4}
+	return file_symbols_proto_rawDescGZIP(), []int{5}
 }
 
 // ListLanguagesResponse is the response from the ListLanguages method.
@@ -359,7 +427,7 @@ type ListLanguagesResponse struct {
 func (x *ListLanguagesResponse) Reset() {
 	*x = ListLanguagesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[5]
+		mi := &file_symbols_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -372,7 +440,7 @@ func (x *ListLanguagesResponse) String() string {
 func (*ListLanguagesResponse) ProtoMessage() {}
 
 func (x *ListLanguagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[5]
+	mi := &file_symbols_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -385,7 +453,7 @@ func (x *ListLanguagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListLanguagesResponse.ProtoReflect.Descriptor instead.
 func (*ListLanguagesResponse) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{5}
+	return file_symbols_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListLanguagesResponse) GetLanguageFileNameMap() map[string]*ListLanguagesResponse_GlobFilePatterns {
@@ -409,7 +477,7 @@ type SymbolInfoRequest struct {
 func (x *SymbolInfoRequest) Reset() {
 	*x = SymbolInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[6]
+		mi := &file_symbols_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -422,7 +490,7 @@ func (x *SymbolInfoRequest) String() string {
 func (*SymbolInfoRequest) ProtoMessage() {}
 
 func (x *SymbolInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[6]
+	mi := &file_symbols_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -435,7 +503,7 @@ func (x *SymbolInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SymbolInfoRequest.ProtoReflect.Descriptor instead.
 func (*SymbolInfoRequest) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{6}
+	return file_symbols_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SymbolInfoRequest) GetRepoCommitPath() *RepoCommitPath {
@@ -466,7 +534,7 @@ type SymbolInfoResponse struct {
 func (x *SymbolInfoResponse) Reset() {
 	*x = SymbolInfoResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[7]
+		mi := &file_symbols_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -479,7 +547,7 @@ func (x *SymbolInfoResponse) String() string {
 func (*SymbolInfoResponse) ProtoMessage() {}
 
 func (x *SymbolInfoResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[7]
+	mi := &file_symbols_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -492,7 +560,7 @@ func (x *SymbolInfoResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SymbolInfoResponse.ProtoReflect.Descriptor instead.
 func (*SymbolInfoResponse) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{7}
+	return file_symbols_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SymbolInfoResponse) GetResult() *SymbolInfoResponse_DefinitionResult {
@@ -520,7 +588,7 @@ type RepoCommitPath struct {
 func (x *RepoCommitPath) Reset() {
 	*x = RepoCommitPath{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[8]
+		mi := &file_symbols_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -533,7 +601,7 @@ func (x *RepoCommitPath) String() string {
 func (*RepoCommitPath) ProtoMessage() {}
 
 func (x *RepoCommitPath) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[8]
+	mi := &file_symbols_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -546,7 +614,7 @@ func (x *RepoCommitPath) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RepoCommitPath.ProtoReflect.Descriptor instead.
 func (*RepoCommitPath) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{8}
+	return file_symbols_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RepoCommitPath) GetRepo() string {
@@ -587,7 +655,7 @@ type Range struct {
 func (x *Range) Reset() {
 	*x = Range{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[9]
+		mi := &file_symbols_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -600,7 +668,7 @@ func (x *Range) String() string {
 func (*Range) ProtoMessage() {}
 
 func (x *Range) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[9]
+	mi := &file_symbols_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -613,7 +681,7 @@ func (x *Range) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Range.ProtoReflect.Descriptor instead.
 func (*Range) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{9}
+	return file_symbols_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Range) GetRow() int32 {
@@ -652,7 +720,7 @@ type Point struct {
 func (x *Point) Reset() {
 	*x = Point{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[10]
+		mi := &file_symbols_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -665,7 +733,7 @@ func (x *Point) String() string {
 func (*Point) ProtoMessage() {}
 
 func (x *Point) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[10]
+	mi := &file_symbols_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -678,7 +746,7 @@ func (x *Point) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Point.ProtoReflect.Descriptor instead.
 func (*Point) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{10}
+	return file_symbols_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *Point) GetRow() int32 {
@@ -706,7 +774,7 @@ type HealthzRequest struct {
 func (x *HealthzRequest) Reset() {
 	*x = HealthzRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[11]
+		mi := &file_symbols_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -719,7 +787,7 @@ func (x *HealthzRequest) String() string {
 func (*HealthzRequest) ProtoMessage() {}
 
 func (x *HealthzRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[11]
+	mi := &file_symbols_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -732,7 +800,7 @@ func (x *HealthzRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthzRequest.ProtoReflect.Descriptor instead.
 func (*HealthzRequest) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{11}
+	return file_symbols_proto_rawDescGZIP(), []int{12}
 }
 
 // TODO@ggilmore: Note - GRPC has its own healthchecking protocol that we should use instead of this.
@@ -746,7 +814,7 @@ type HealthzResponse struct {
 func (x *HealthzResponse) Reset() {
 	*x = HealthzResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[12]
+		mi := &file_symbols_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -759,7 +827,7 @@ func (x *HealthzResponse) String() string {
 func (*HealthzResponse) ProtoMessage() {}
 
 func (x *HealthzResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[12]
+	mi := &file_symbols_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -772,7 +840,7 @@ func (x *HealthzResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthzResponse.ProtoReflect.Descriptor instead.
 func (*HealthzResponse) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{12}
+	return file_symbols_proto_rawDescGZIP(), []int{13}
 }
 
 // Symbol is a code symbol
@@ -807,7 +875,7 @@ type SearchResponse_Symbol struct {
 func (x *SearchResponse_Symbol) Reset() {
 	*x = SearchResponse_Symbol{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[13]
+		mi := &file_symbols_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -820,7 +888,7 @@ func (x *SearchResponse_Symbol) String() string {
 func (*SearchResponse_Symbol) ProtoMessage() {}
 
 func (x *SearchResponse_Symbol) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[13]
+	mi := &file_symbols_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -924,7 +992,7 @@ type LocalCodeIntelResponse_Symbol struct {
 func (x *LocalCodeIntelResponse_Symbol) Reset() {
 	*x = LocalCodeIntelResponse_Symbol{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[14]
+		mi := &file_symbols_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -937,7 +1005,7 @@ func (x *LocalCodeIntelResponse_Symbol) String() string {
 func (*LocalCodeIntelResponse_Symbol) ProtoMessage() {}
 
 func (x *LocalCodeIntelResponse_Symbol) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[14]
+	mi := &file_symbols_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,7 +1018,7 @@ func (x *LocalCodeIntelResponse_Symbol) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LocalCodeIntelResponse_Symbol.ProtoReflect.Descriptor instead.
 func (*LocalCodeIntelResponse_Symbol) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{3, 0}
+	return file_symbols_proto_rawDescGZIP(), []int{4, 0}
 }
 
 func (x *LocalCodeIntelResponse_Symbol) GetName() string {
@@ -992,7 +1060,7 @@ type ListLanguagesResponse_GlobFilePatterns struct {
 func (x *ListLanguagesResponse_GlobFilePatterns) Reset() {
 	*x = ListLanguagesResponse_GlobFilePatterns{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[15]
+		mi := &file_symbols_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1005,7 +1073,7 @@ func (x *ListLanguagesResponse_GlobFilePatterns) String() string {
 func (*ListLanguagesResponse_GlobFilePatterns) ProtoMessage() {}
 
 func (x *ListLanguagesResponse_GlobFilePatterns) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[15]
+	mi := &file_symbols_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1018,7 +1086,7 @@ func (x *ListLanguagesResponse_GlobFilePatterns) ProtoReflect() protoreflect.Mes
 
 // Deprecated: Use ListLanguagesResponse_GlobFilePatterns.ProtoReflect.Descriptor instead.
 func (*ListLanguagesResponse_GlobFilePatterns) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{5, 0}
+	return file_symbols_proto_rawDescGZIP(), []int{6, 0}
 }
 
 func (x *ListLanguagesResponse_GlobFilePatterns) GetPatterns() []string {
@@ -1042,7 +1110,7 @@ type SymbolInfoResponse_Definition struct {
 func (x *SymbolInfoResponse_Definition) Reset() {
 	*x = SymbolInfoResponse_Definition{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[17]
+		mi := &file_symbols_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1055,7 +1123,7 @@ func (x *SymbolInfoResponse_Definition) String() string {
 func (*SymbolInfoResponse_Definition) ProtoMessage() {}
 
 func (x *SymbolInfoResponse_Definition) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[17]
+	mi := &file_symbols_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1068,7 +1136,7 @@ func (x *SymbolInfoResponse_Definition) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SymbolInfoResponse_Definition.ProtoReflect.Descriptor instead.
 func (*SymbolInfoResponse_Definition) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{7, 0}
+	return file_symbols_proto_rawDescGZIP(), []int{8, 0}
 }
 
 func (x *SymbolInfoResponse_Definition) GetRepoCommitPath() *RepoCommitPath {
@@ -1099,7 +1167,7 @@ type SymbolInfoResponse_DefinitionResult struct {
 func (x *SymbolInfoResponse_DefinitionResult) Reset() {
 	*x = SymbolInfoResponse_DefinitionResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_symbols_proto_msgTypes[18]
+		mi := &file_symbols_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1112,7 +1180,7 @@ func (x *SymbolInfoResponse_DefinitionResult) String() string {
 func (*SymbolInfoResponse_DefinitionResult) ProtoMessage() {}
 
 func (x *SymbolInfoResponse_DefinitionResult) ProtoReflect() protoreflect.Message {
-	mi := &file_symbols_proto_msgTypes[18]
+	mi := &file_symbols_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1125,7 +1193,7 @@ func (x *SymbolInfoResponse_DefinitionResult) ProtoReflect() protoreflect.Messag
 
 // Deprecated: Use SymbolInfoResponse_DefinitionResult.ProtoReflect.Descriptor instead.
 func (*SymbolInfoResponse_DefinitionResult) Descriptor() ([]byte, []int) {
-	return file_symbols_proto_rawDescGZIP(), []int{7, 1}
+	return file_symbols_proto_rawDescGZIP(), []int{8, 1}
 }
 
 func (x *SymbolInfoResponse_DefinitionResult) GetDefinition() *SymbolInfoResponse_Definition {
@@ -1193,125 +1261,136 @@ var file_symbols_proto_rawDesc = []byte{
 	0x74, 0x75, 0x72, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6c, 0x69, 0x6d,
 	0x69, 0x74, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x66, 0x69, 0x6c, 0x65,
 	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x22, 0x5d, 0x0a, 0x15, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e,
-	0x74, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65,
-	0x70, 0x6f, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68,
-	0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68,
-	0x22, 0xdd, 0x01, 0x0a, 0x16, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e,
-	0x74, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x73,
-	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x73,
-	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43,
-	0x6f, 0x64, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73,
-	0x1a, 0x7e, 0x0a, 0x06, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x68,
-	0x6f, 0x76, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x03, 0x64, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x11, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52,
-	0x61, 0x6e, 0x67, 0x65, 0x52, 0x03, 0x64, 0x65, 0x66, 0x12, 0x25, 0x0a, 0x04, 0x72, 0x65, 0x66,
-	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
-	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73,
-	0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65,
-	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xb4, 0x02, 0x0a, 0x15, 0x4c, 0x69, 0x73,
-	0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x6f, 0x0a, 0x16, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x5f, 0x66,
-	0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x46, 0x69,
-	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13,
-	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65,
-	0x4d, 0x61, 0x70, 0x1a, 0x2e, 0x0a, 0x10, 0x47, 0x6c, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x65, 0x50,
-	0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65,
-	0x72, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65,
-	0x72, 0x6e, 0x73, 0x1a, 0x7a, 0x0a, 0x18, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x46,
-	0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x48, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x32, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
-	0x73, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x2e, 0x47, 0x6c, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x74,
-	0x65, 0x72, 0x6e, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
-	0x82, 0x01, 0x0a, 0x11, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x63, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70,
-	0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x0e, 0x72, 0x65, 0x70,
-	0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x05, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x79, 0x6d,
-	0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x70,
-	0x6f, 0x69, 0x6e, 0x74, 0x22, 0xff, 0x02, 0x0a, 0x12, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x72,
-	0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x73, 0x79,
-	0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49,
-	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06,
-	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x88, 0x01, 0x01, 0x1a, 0x8a, 0x01, 0x0a, 0x0a, 0x44, 0x65,
-	0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65, 0x70, 0x6f,
-	0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e,
-	0x52, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x0e,
-	0x72, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x2c,
-	0x0a, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
+	0x72, 0x22, 0x5a, 0x0a, 0x11, 0x50, 0x61, 0x72, 0x73, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0x5d, 0x0a,
+	0x15, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6c, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x63,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65,
+	0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x0e, 0x72, 0x65,
+	0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x22, 0xdd, 0x01, 0x0a,
+	0x16, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6c, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49,
+	0x6e, 0x74, 0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x1a, 0x7e, 0x0a, 0x06,
+	0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x68, 0x6f,
+	0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x68, 0x6f, 0x76, 0x65, 0x72,
+	0x12, 0x23, 0x0a, 0x03, 0x64, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e,
 	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65,
-	0x48, 0x00, 0x52, 0x05, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06,
-	0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x1a, 0x82, 0x01, 0x0a, 0x10, 0x44, 0x65, 0x66, 0x69, 0x6e,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x49, 0x0a, 0x0a, 0x64,
-	0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x29, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6d,
-	0x62, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
-	0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69,
-	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x05, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x05, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x88, 0x01,
-	0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x5f,
-	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x50, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6f, 0x43, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x16, 0x0a, 0x06,
-	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f,
-	0x6d, 0x6d, 0x69, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x49, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67,
-	0x65, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03,
-	0x72, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6c,
-	0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e,
-	0x67, 0x74, 0x68, 0x22, 0x31, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03,
-	0x72, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x16,
-	0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06,
-	0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x22, 0x10, 0x0a, 0x0e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
-	0x7a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x11, 0x0a, 0x0f, 0x48, 0x65, 0x61, 0x6c,
-	0x74, 0x68, 0x7a, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0x9b, 0x03, 0x0a, 0x0e,
-	0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x41,
-	0x0a, 0x06, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x12, 0x19, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f,
-	0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
-	0x00, 0x12, 0x59, 0x0a, 0x0e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e,
-	0x74, 0x65, 0x6c, 0x12, 0x21, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31,
-	0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6c, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73,
-	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x74,
-	0x65, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x0d,
-	0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x2e,
-	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c,
-	0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x21, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0a, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x1d, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x03, 0x64, 0x65, 0x66, 0x12, 0x25, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x04, 0x72, 0x65, 0x66, 0x73, 0x22, 0x16, 0x0a, 0x14,
+	0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0xb4, 0x02, 0x0a, 0x15, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6f,
+	0x0a, 0x16, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3a,
+	0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x2e, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61,
+	0x6d, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x13, 0x6c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x4d, 0x61, 0x70, 0x1a,
+	0x2e, 0x0a, 0x10, 0x47, 0x6c, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65,
+	0x72, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73, 0x1a,
+	0x7a, 0x0a, 0x18, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x48, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x73,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e,
+	0x47, 0x6c, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x65, 0x50, 0x61, 0x74, 0x74, 0x65, 0x72, 0x6e, 0x73,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x82, 0x01, 0x0a, 0x11,
 	0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x1e, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53,
-	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x12, 0x1a,
-	0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c,
-	0x74, 0x68, 0x7a, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x79, 0x6d,
+	0x74, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x05, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x05, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x22, 0xff, 0x02, 0x0a, 0x12, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4c, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x88, 0x01, 0x01, 0x1a, 0x8a, 0x01, 0x0a, 0x0a, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x10, 0x72, 0x65, 0x70, 0x6f, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x70, 0x6f,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x2c, 0x0a, 0x05, 0x72, 0x61,
+	0x6e, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x05,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x72, 0x61, 0x6e,
+	0x67, 0x65, 0x1a, 0x82, 0x01, 0x0a, 0x10, 0x44, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x49, 0x0a, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x44, 0x65, 0x66, 0x69,
+	0x6e, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x05, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x48, 0x00, 0x52, 0x05, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x88, 0x01, 0x01, 0x42, 0x08, 0x0a,
+	0x06, 0x5f, 0x68, 0x6f, 0x76, 0x65, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x5f, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x22, 0x50, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6f, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x72, 0x65, 0x70, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x22, 0x49, 0x0a, 0x05, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x10, 0x0a,
+	0x03, 0x72, 0x6f, 0x77, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12,
+	0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x06, 0x63, 0x6f, 0x6c, 0x75, 0x6d, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74,
+	0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x22,
+	0x31, 0x0a, 0x05, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x6f, 0x77, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x72, 0x6f, 0x77, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f,
+	0x6c, 0x75, 0x6d, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x63, 0x6f, 0x6c, 0x75,
+	0x6d, 0x6e, 0x22, 0x10, 0x0a, 0x0e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x11, 0x0a, 0x0f, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32, 0xe6, 0x03, 0x0a, 0x0e, 0x53, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x41, 0x0a, 0x06, 0x53, 0x65,
+	0x61, 0x72, 0x63, 0x68, 0x12, 0x19, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a,
+	0x0e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6c, 0x12,
+	0x21, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6c, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x22, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x43, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x74, 0x65, 0x6c, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x56, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74,
+	0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x73, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x4d, 0x0a, 0x0a, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d,
+	0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x44, 0x0a, 0x07, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x12, 0x1a, 0x2e, 0x73, 0x79, 0x6d,
 	0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72,
-	0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f,
-	0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73,
-	0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x7a, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0a, 0x50, 0x61, 0x72, 0x73, 0x65, 0x46, 0x69,
+	0x6c, 0x65, 0x73, 0x12, 0x1d, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x72, 0x73, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x67, 0x72, 0x61, 0x70, 0x68, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -1326,56 +1405,59 @@ func file_symbols_proto_rawDescGZIP() []byte {
 	return file_symbols_proto_rawDescData
 }
 
-var file_symbols_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_symbols_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_symbols_proto_goTypes = []interface{}{
 	(*SearchRequest)(nil),                          // 0: symbols.v1.SearchRequest
 	(*SearchResponse)(nil),                         // 1: symbols.v1.SearchResponse
-	(*LocalCodeIntelRequest)(nil),                  // 2: symbols.v1.LocalCodeIntelRequest
-	(*LocalCodeIntelResponse)(nil),                 // 3: symbols.v1.LocalCodeIntelResponse
-	(*ListLanguagesRequest)(nil),                   // 4: symbols.v1.ListLanguagesRequest
-	(*ListLanguagesResponse)(nil),                  // 5: symbols.v1.ListLanguagesResponse
-	(*SymbolInfoRequest)(nil),                      // 6: symbols.v1.SymbolInfoRequest
-	(*SymbolInfoResponse)(nil),                     // 7: symbols.v1.SymbolInfoResponse
-	(*RepoCommitPath)(nil),                         // 8: symbols.v1.RepoCommitPath
-	(*Range)(nil),                                  // 9: symbols.v1.Range
-	(*Point)(nil),                                  // 10: symbols.v1.Point
-	(*HealthzRequest)(nil),                         // 11: symbols.v1.HealthzRequest
-	(*HealthzResponse)(nil),                        // 12: symbols.v1.HealthzResponse
-	(*SearchResponse_Symbol)(nil),                  // 13: symbols.v1.SearchResponse.Symbol
-	(*LocalCodeIntelResponse_Symbol)(nil),          // 14: symbols.v1.LocalCodeIntelResponse.Symbol
-	(*ListLanguagesResponse_GlobFilePatterns)(nil), // 15: symbols.v1.ListLanguagesResponse.GlobFilePatterns
-	nil,                                   // 16: symbols.v1.ListLanguagesResponse.LanguageFileNameMapEntry
-	(*SymbolInfoResponse_Definition)(nil), // 17: symbols.v1.SymbolInfoResponse.Definition
-	(*SymbolInfoResponse_DefinitionResult)(nil), // 18: symbols.v1.SymbolInfoResponse.DefinitionResult
-	(*durationpb.Duration)(nil),                 // 19: google.protobuf.Duration
+	(*ParseFilesRequest)(nil),                      // 2: symbols.v1.ParseFilesRequest
+	(*LocalCodeIntelRequest)(nil),                  // 3: symbols.v1.LocalCodeIntelRequest
+	(*LocalCodeIntelResponse)(nil),                 // 4: symbols.v1.LocalCodeIntelResponse
+	(*ListLanguagesRequest)(nil),                   // 5: symbols.v1.ListLanguagesRequest
+	(*ListLanguagesResponse)(nil),                  // 6: symbols.v1.ListLanguagesResponse
+	(*SymbolInfoRequest)(nil),                      // 7: symbols.v1.SymbolInfoRequest
+	(*SymbolInfoResponse)(nil),                     // 8: symbols.v1.SymbolInfoResponse
+	(*RepoCommitPath)(nil),                         // 9: symbols.v1.RepoCommitPath
+	(*Range)(nil),                                  // 10: symbols.v1.Range
+	(*Point)(nil),                                  // 11: symbols.v1.Point
+	(*HealthzRequest)(nil),                         // 12: symbols.v1.HealthzRequest
+	(*HealthzResponse)(nil),                        // 13: symbols.v1.HealthzResponse
+	(*SearchResponse_Symbol)(nil),                  // 14: symbols.v1.SearchResponse.Symbol
+	(*LocalCodeIntelResponse_Symbol)(nil),          // 15: symbols.v1.LocalCodeIntelResponse.Symbol
+	(*ListLanguagesResponse_GlobFilePatterns)(nil), // 16: symbols.v1.ListLanguagesResponse.GlobFilePatterns
+	nil,                                   // 17: symbols.v1.ListLanguagesResponse.LanguageFileNameMapEntry
+	(*SymbolInfoResponse_Definition)(nil), // 18: symbols.v1.SymbolInfoResponse.Definition
+	(*SymbolInfoResponse_DefinitionResult)(nil), // 19: symbols.v1.SymbolInfoResponse.DefinitionResult
+	(*durationpb.Duration)(nil),                 // 20: google.protobuf.Duration
 }
 var file_symbols_proto_depIdxs = []int32{
-	19, // 0: symbols.v1.SearchRequest.timeout:type_name -> google.protobuf.Duration
-	13, // 1: symbols.v1.SearchResponse.symbols:type_name -> symbols.v1.SearchResponse.Symbol
-	8,  // 2: symbols.v1.LocalCodeIntelRequest.repo_commit_path:type_name -> symbols.v1.RepoCommitPath
-	14, // 3: symbols.v1.LocalCodeIntelResponse.symbols:type_name -> symbols.v1.LocalCodeIntelResponse.Symbol
-	16, // 4: symbols.v1.ListLanguagesResponse.language_file_name_map:type_name -> symbols.v1.ListLanguagesResponse.LanguageFileNameMapEntry
-	8,  // 5: symbols.v1.SymbolInfoRequest.repo_commit_path:type_name -> symbols.v1.RepoCommitPath
-	10, // 6: symbols.v1.SymbolInfoRequest.point:type_name -> symbols.v1.Point
-	18, // 7: symbols.v1.SymbolInfoResponse.result:type_name -> symbols.v1.SymbolInfoResponse.DefinitionResult
-	9,  // 8: symbols.v1.LocalCodeIntelResponse.Symbol.def:type_name -> symbols.v1.Range
-	9,  // 9: symbols.v1.LocalCodeIntelResponse.Symbol.refs:type_name -> symbols.v1.Range
-	15, // 10: symbols.v1.ListLanguagesResponse.LanguageFileNameMapEntry.value:type_name -> symbols.v1.ListLanguagesResponse.GlobFilePatterns
-	8,  // 11: symbols.v1.SymbolInfoResponse.Definition.repo_commit_path:type_name -> symbols.v1.RepoCommitPath
-	9,  // 12: symbols.v1.SymbolInfoResponse.Definition.range:type_name -> symbols.v1.Range
-	17, // 13: symbols.v1.SymbolInfoResponse.DefinitionResult.definition:type_name -> symbols.v1.SymbolInfoResponse.Definition
+	20, // 0: symbols.v1.SearchRequest.timeout:type_name -> google.protobuf.Duration
+	14, // 1: symbols.v1.SearchResponse.symbols:type_name -> symbols.v1.SearchResponse.Symbol
+	9,  // 2: symbols.v1.LocalCodeIntelRequest.repo_commit_path:type_name -> symbols.v1.RepoCommitPath
+	15, // 3: symbols.v1.LocalCodeIntelResponse.symbols:type_name -> symbols.v1.LocalCodeIntelResponse.Symbol
+	17, // 4: symbols.v1.ListLanguagesResponse.language_file_name_map:type_name -> symbols.v1.ListLanguagesResponse.LanguageFileNameMapEntry
+	9,  // 5: symbols.v1.SymbolInfoRequest.repo_commit_path:type_name -> symbols.v1.RepoCommitPath
+	11, // 6: symbols.v1.SymbolInfoRequest.point:type_name -> symbols.v1.Point
+	19, // 7: symbols.v1.SymbolInfoResponse.result:type_name -> symbols.v1.SymbolInfoResponse.DefinitionResult
+	10, // 8: symbols.v1.LocalCodeIntelResponse.Symbol.def:type_name -> symbols.v1.Range
+	10, // 9: symbols.v1.LocalCodeIntelResponse.Symbol.refs:type_name -> symbols.v1.Range
+	16, // 10: symbols.v1.ListLanguagesResponse.LanguageFileNameMapEntry.value:type_name -> symbols.v1.ListLanguagesResponse.GlobFilePatterns
+	9,  // 11: symbols.v1.SymbolInfoResponse.Definition.repo_commit_path:type_name -> symbols.v1.RepoCommitPath
+	10, // 12: symbols.v1.SymbolInfoResponse.Definition.range:type_name -> symbols.v1.Range
+	18, // 13: symbols.v1.SymbolInfoResponse.DefinitionResult.definition:type_name -> symbols.v1.SymbolInfoResponse.Definition
 	0,  // 14: symbols.v1.SymbolsService.Search:input_type -> symbols.v1.SearchRequest
-	2,  // 15: symbols.v1.SymbolsService.LocalCodeIntel:input_type -> symbols.v1.LocalCodeIntelRequest
-	4,  // 16: symbols.v1.SymbolsService.ListLanguages:input_type -> symbols.v1.ListLanguagesRequest
-	6,  // 17: symbols.v1.SymbolsService.SymbolInfo:input_type -> symbols.v1.SymbolInfoRequest
-	11, // 18: symbols.v1.SymbolsService.Healthz:input_type -> symbols.v1.HealthzRequest
-	1,  // 19: symbols.v1.SymbolsService.Search:output_type -> symbols.v1.SearchResponse
-	3,  // 20: symbols.v1.SymbolsService.LocalCodeIntel:output_type -> symbols.v1.LocalCodeIntelResponse
-	5,  // 21: symbols.v1.SymbolsService.ListLanguages:output_type -> symbols.v1.ListLanguagesResponse
-	7,  // 22: symbols.v1.SymbolsService.SymbolInfo:output_type -> symbols.v1.SymbolInfoResponse
-	12, // 23: symbols.v1.SymbolsService.Healthz:output_type -> symbols.v1.HealthzResponse
-	19, // [19:24] is the sub-list for method output_type
-	14, // [14:19] is the sub-list for method input_type
+	3,  // 15: symbols.v1.SymbolsService.LocalCodeIntel:input_type -> symbols.v1.LocalCodeIntelRequest
+	5,  // 16: symbols.v1.SymbolsService.ListLanguages:input_type -> symbols.v1.ListLanguagesRequest
+	7,  // 17: symbols.v1.SymbolsService.SymbolInfo:input_type -> symbols.v1.SymbolInfoRequest
+	12, // 18: symbols.v1.SymbolsService.Healthz:input_type -> symbols.v1.HealthzRequest
+	2,  // 19: symbols.v1.SymbolsService.ParseFiles:input_type -> symbols.v1.ParseFilesRequest
+	1,  // 20: symbols.v1.SymbolsService.Search:output_type -> symbols.v1.SearchResponse
+	4,  // 21: symbols.v1.SymbolsService.LocalCodeIntel:output_type -> symbols.v1.LocalCodeIntelResponse
+	6,  // 22: symbols.v1.SymbolsService.ListLanguages:output_type -> symbols.v1.ListLanguagesResponse
+	8,  // 23: symbols.v1.SymbolsService.SymbolInfo:output_type -> symbols.v1.SymbolInfoResponse
+	13, // 24: symbols.v1.SymbolsService.Healthz:output_type -> symbols.v1.HealthzResponse
+	1,  // 25: symbols.v1.SymbolsService.ParseFiles:output_type -> symbols.v1.SearchResponse
+	20, // [20:26] is the sub-list for method output_type
+	14, // [14:20] is the sub-list for method input_type
 	14, // [14:14] is the sub-list for extension type_name
 	14, // [14:14] is the sub-list for extension extendee
 	0,  // [0:14] is the sub-list for field type_name
@@ -1412,7 +1494,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LocalCodeIntelRequest); i {
+			switch v := v.(*ParseFilesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1424,7 +1506,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LocalCodeIntelResponse); i {
+			switch v := v.(*LocalCodeIntelRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1436,7 +1518,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListLanguagesRequest); i {
+			switch v := v.(*LocalCodeIntelResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1448,7 +1530,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListLanguagesResponse); i {
+			switch v := v.(*ListLanguagesRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1460,7 +1542,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SymbolInfoRequest); i {
+			switch v := v.(*ListLanguagesResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1472,7 +1554,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SymbolInfoResponse); i {
+			switch v := v.(*SymbolInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1484,7 +1566,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RepoCommitPath); i {
+			switch v := v.(*SymbolInfoResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1496,7 +1578,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Range); i {
+			switch v := v.(*RepoCommitPath); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1508,7 +1590,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Point); i {
+			switch v := v.(*Range); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1520,7 +1602,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HealthzRequest); i {
+			switch v := v.(*Point); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1532,7 +1614,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HealthzResponse); i {
+			switch v := v.(*HealthzRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1544,7 +1626,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SearchResponse_Symbol); i {
+			switch v := v.(*HealthzResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1556,7 +1638,7 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LocalCodeIntelResponse_Symbol); i {
+			switch v := v.(*SearchResponse_Symbol); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1568,6 +1650,18 @@ func file_symbols_proto_init() {
 			}
 		}
 		file_symbols_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LocalCodeIntelResponse_Symbol); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_symbols_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ListLanguagesResponse_GlobFilePatterns); i {
 			case 0:
 				return &v.state
@@ -1579,7 +1673,7 @@ func file_symbols_proto_init() {
 				return nil
 			}
 		}
-		file_symbols_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+		file_symbols_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SymbolInfoResponse_Definition); i {
 			case 0:
 				return &v.state
@@ -1591,7 +1685,7 @@ func file_symbols_proto_init() {
 				return nil
 			}
 		}
-		file_symbols_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+		file_symbols_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SymbolInfoResponse_DefinitionResult); i {
 			case 0:
 				return &v.state
@@ -1605,16 +1699,16 @@ func file_symbols_proto_init() {
 		}
 	}
 	file_symbols_proto_msgTypes[1].OneofWrappers = []interface{}{}
-	file_symbols_proto_msgTypes[7].OneofWrappers = []interface{}{}
-	file_symbols_proto_msgTypes[17].OneofWrappers = []interface{}{}
+	file_symbols_proto_msgTypes[8].OneofWrappers = []interface{}{}
 	file_symbols_proto_msgTypes[18].OneofWrappers = []interface{}{}
+	file_symbols_proto_msgTypes[19].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_symbols_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,7 @@ const (
 	SymbolsService_ListLanguages_FullMethodName  = "/symbols.v1.SymbolsService/ListLanguages"
 	SymbolsService_SymbolInfo_FullMethodName     = "/symbols.v1.SymbolsService/SymbolInfo"
 	SymbolsService_Healthz_FullMethodName        = "/symbols.v1.SymbolsService/Healthz"
+	SymbolsService_ParseFiles_FullMethodName     = "/symbols.v1.SymbolsService/ParseFiles"
 )
 
 // SymbolsServiceClient is the client API for SymbolsService service.
@@ -35,6 +36,7 @@ type SymbolsServiceClient interface {
 	ListLanguages(ctx context.Context, in *ListLanguagesRequest, opts ...grpc.CallOption) (*ListLanguagesResponse, error)
 	SymbolInfo(ctx context.Context, in *SymbolInfoRequest, opts ...grpc.CallOption) (*SymbolInfoResponse, error)
 	Healthz(ctx context.Context, in *HealthzRequest, opts ...grpc.CallOption) (*HealthzResponse, error)
+	ParseFiles(ctx context.Context, in *ParseFilesRequest, opts ...grpc.CallOption) (*SearchResponse, error)
 }
 
 type symbolsServiceClient struct {
@@ -90,6 +92,15 @@ func (c *symbolsServiceClient) Healthz(ctx context.Context, in *HealthzRequest,
 	return out, nil
 }
 
+func (c *symbolsServiceClient) ParseFiles(ctx context.Context, in *ParseFilesRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, SymbolsService_ParseFiles_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SymbolsServiceServer is the server API for SymbolsService service.
 // All implementations must embed UnimplementedSymbolsServiceServer
 // for forward compatibility
@@ -99,6 +110,7 @@ type SymbolsServiceServer interface {
 	ListLanguages(context.Context, *ListLanguagesRequest) (*ListLanguagesResponse, error)
 	SymbolInfo(context.Context, *SymbolInfoRequest) (*SymbolInfoResponse, error)
 	Healthz(context.Context, *HealthzRequest) (*HealthzResponse, error)
+	ParseFiles(context.Context, *ParseFilesRequest) (*SearchResponse, error)
 	mustEmbedUnimplementedSymbolsServiceServer()
 }
 
@@ -121,6 +133,9 @@ func (UnimplementedSymbolsServiceServer) SymbolInfo(context.Context, *SymbolInfo
 func (UnimplementedSymbolsServiceServer) Healthz(context.Context, *HealthzRequest) (*HealthzResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Healthz not implemented")
 }
+func (UnimplementedSymbolsServiceServer) ParseFiles(context.Context, *ParseFilesRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ParseFiles not implemented")
+}
 func (UnimplementedSymbolsServiceServer) mustEmbedUnimplementedSymbolsServiceServer() {}
 
 // UnsafeSymbolsServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -224,6 +239,24 @@ func _SymbolsService_Healthz_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SymbolsService_ParseFiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParseFilesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SymbolsServiceServer).ParseFiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SymbolsService_ParseFiles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SymbolsServiceServer).ParseFiles(ctx, req.(*ParseFilesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SymbolsService_ServiceDesc is the grpc.ServiceDesc for SymbolsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,6 +284,10 @@ var SymbolsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Healthz",
 			Handler:    _SymbolsService_Healthz_Handler,
 		},
+		{
+			MethodName: "ParseFiles",
+			Handler:    _SymbolsService_ParseFiles_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "symbols.proto",
@@ -104,6 +104,11 @@ func (e *ExternalService) RedactedConfig(ctx context.Context) (string, error) {
 		if err != nil {
 			return "", err
 		}
+	case *schema.MercurialConnection:
+		err = es.redactURL(c.Url, "url")
+		if err != nil {
+			return "", err
+		}
 	case *schema.LocalGitExternalService:
 		// Nothing to redact
 	default:
@@ -270,31 +275,14 @@ func (e *ExternalService) UnredactConfig(ctx context.Context, old *ExternalServi
 		es.unredactString(c.Credentials, o.Credentials, "credentials")
 	case *schema.OtherExternalServiceConnection:
 		o := oldCfg.(*schema.OtherExternalServiceConnection)
-		err := es.unredactURL(c.Url, o.Url, "url")
-		if err != nil {
-			return err
-		}
-		oldParsed, err := url.Parse(o.Url)
-		if err != nil {
+		if err := es.unredactCredentialsURL(c.Url, o.Url); err != nil {
 			return err
 		}
-		newParsed, err := url.Parse(c.Url)
-		if err != nil {
+	case *schema.MercurialConnection:
+		o := oldCfg.(*schema.MercurialConnection)
+		if err := es.unredactCredentialsURL(c.Url, o.Url); err != nil {
 			return err
 		}
-		// compare URLs and see if password changed
-		pwd, ok := newParsed.User.Password()
-
-		// remove UserInfo so we can compare URLs
-		oldParsed.User = nil
-		newParsed.User = nil
-
-		if newParsed.String() != oldParsed.String() {
-			if ok && pwd == RedactedSecret {
-				return errCodeHostIdentityChanged{"url", "password"}
-			}
-		}
-
 	default:
 		// return an error; it's safer to fail than to incorrectly return unsafe data.
 		return errors.Errorf("Unrecognized ExternalServiceConfig for redaction: kind %+v not implemented", reflect.TypeOf(newCfg))
@@ -409,6 +397,36 @@ func (es *edits) unredactURL(new, old string, path ...any) error {
 	return nil
 }
 
+// unredactCredentialsURL unredacts the "url" field of a configuration that
+// includes credentials in its URL.
+func (es *edits) unredactCredentialsURL(new, old string) error {
+	err := es.unredactURL(new, old, "url")
+	if err != nil {
+		return err
+	}
+	oldParsed, err := url.Parse(old)
+	if err != nil {
+		return err
+	}
+	newParsed, err := url.Parse(new)
+	if err != nil {
+		return err
+	}
+	// compare URLs and see if password changed
+	pwd, ok := newParsed.User.Password()
+
+	// remove UserInfo so we can compare URLs
+	oldParsed.User = nil
+	newParsed.User = nil
+
+	if newParsed.String() != oldParsed.String() {
+		if ok && pwd == RedactedSecret {
+			return errCodeHostIdentityChanged{"url", "password"}
+		}
+	}
+	return nil
+}
+
 type edit struct {
 	path  jsonx.Path
 	value any
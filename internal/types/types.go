@@ -953,6 +953,9 @@ type OrgMembership struct {
 	UserID    int32
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// SCIMControlled is true if the membership was granted because the user is a
+	// member of a SCIM group that is mapped to the organization.
+	SCIMControlled bool
 }
 
 type PhabricatorRepo struct {
@@ -2024,6 +2027,10 @@ type Team struct {
 	CreatorID    int32
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	// SCIMControlled is true for teams that are provisioned from groups of
+	// the identity provider through SCIM.
+	SCIMControlled bool
+	SCIMExternalID string
 }
 
 type TeamMember struct {
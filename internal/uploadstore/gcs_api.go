@@ -20,6 +20,7 @@ type gcsBucketHandle interface {
 }
 
 type gcsObjectHandle interface {
+	Attrs(ctx context.Context) (*storage.ObjectAttrs, error)
 	Delete(ctx context.Context) error
 	NewRangeReader(ctx context.Context, offset, length int64) (io.ReadCloser, error)
 	NewWriter(ctx context.Context) io.WriteCloser
@@ -71,6 +72,10 @@ func (s *bucketHandleShim) Objects(ctx context.Context, q *storage.Query) gcsObj
 	return &objectIteratorShim{handle: s.handle.Objects(ctx, q)}
 }
 
+func (s *objectHandleShim) Attrs(ctx context.Context) (*storage.ObjectAttrs, error) {
+	return s.handle.Attrs(ctx)
+}
+
 func (s *objectHandleShim) Delete(ctx context.Context) error {
 	return s.handle.Delete(ctx)
 }
@@ -90,6 +90,22 @@ func (s *gcsStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err er
 	return NewExtraCloser(rc, done), nil
 }
 
+func (s *gcsStore) Exists(ctx context.Context, key string) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.Exists.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if _, err := s.client.Bucket(s.bucket).Object(key).Attrs(ctx); err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to get object attributes")
+	}
+
+	return true, nil
+}
+
 func (s *gcsStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
 	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.String("key", key),
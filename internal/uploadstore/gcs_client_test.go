@@ -115,6 +115,36 @@ func TestGCSGet(t *testing.T) {
 	}
 }
 
+func TestGCSExists(t *testing.T) {
+	gcsClient := NewMockGcsAPI()
+	bucketHandle := NewMockGcsBucketHandle()
+	objectHandle := NewMockGcsObjectHandle()
+	gcsClient.BucketFunc.SetDefaultReturn(bucketHandle)
+	bucketHandle.ObjectFunc.SetDefaultReturn(objectHandle)
+	objectHandle.AttrsFunc.PushReturn(&storage.ObjectAttrs{}, nil)
+	objectHandle.AttrsFunc.PushReturn(nil, storage.ErrObjectNotExist)
+
+	client := testGCSClient(gcsClient, false)
+	for _, want := range []bool{true, false} {
+		exists, err := client.Exists(context.Background(), "test-key")
+		if err != nil {
+			t.Fatalf("unexpected error checking key: %s", err)
+		}
+		if exists != want {
+			t.Errorf("unexpected exists. want=%v have=%v", want, exists)
+		}
+	}
+
+	if calls := bucketHandle.ObjectFunc.History(); len(calls) != 2 {
+		t.Fatalf("unexpected number of Object calls. want=%d have=%d", 2, len(calls))
+	} else if value := calls[0].Arg0; value != "test-key" {
+		t.Errorf("unexpected key argument. want=%s have=%s", "test-key", value)
+	}
+	if calls := objectHandle.NewRangeReaderFunc.History(); len(calls) != 0 {
+		t.Fatalf("unexpected number of NewRangeReader calls. want=%d have=%d", 0, len(calls))
+	}
+}
+
 func TestGCSUpload(t *testing.T) {
 	buf := &bytes.Buffer{}
 
@@ -31,6 +31,14 @@ func (s *lazyStore) Get(ctx context.Context, key string) (io.ReadCloser, error)
 	return s.store.Get(ctx, key)
 }
 
+func (s *lazyStore) Exists(ctx context.Context, key string) (bool, error) {
+	if err := s.initOnce(ctx); err != nil {
+		return false, err
+	}
+
+	return s.store.Exists(ctx, key)
+}
+
 func (s *lazyStore) Upload(ctx context.Context, key string, r io.Reader) (int64, error) {
 	if err := s.initOnce(ctx); err != nil {
 		return 0, err
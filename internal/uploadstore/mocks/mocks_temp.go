@@ -25,6 +25,9 @@ type MockStore struct {
 	// DeleteFunc is an instance of a mock function object controlling the
 	// behavior of the method Delete.
 	DeleteFunc *StoreDeleteFunc
+	// ExistsFunc is an instance of a mock function object controlling the
+	// behavior of the method Exists.
+	ExistsFunc *StoreExistsFunc
 	// ExpireObjectsFunc is an instance of a mock function object
 	// controlling the behavior of the method ExpireObjects.
 	ExpireObjectsFunc *StoreExpireObjectsFunc
@@ -53,6 +56,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		ExistsFunc: &StoreExistsFunc{
+			defaultHook: func(context.Context, string) (r0 bool, r1 error) {
+				return
+			},
+		},
 		ExpireObjectsFunc: &StoreExpireObjectsFunc{
 			defaultHook: func(context.Context, string, time.Duration) (r0 error) {
 				return
@@ -90,6 +98,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.Delete")
 			},
 		},
+		ExistsFunc: &StoreExistsFunc{
+			defaultHook: func(context.Context, string) (bool, error) {
+				panic("unexpected invocation of MockStore.Exists")
+			},
+		},
 		ExpireObjectsFunc: &StoreExpireObjectsFunc{
 			defaultHook: func(context.Context, string, time.Duration) error {
 				panic("unexpected invocation of MockStore.ExpireObjects")
@@ -123,6 +136,9 @@ func NewMockStoreFrom(i uploadstore.Store) *MockStore {
 		DeleteFunc: &StoreDeleteFunc{
 			defaultHook: i.Delete,
 		},
+		ExistsFunc: &StoreExistsFunc{
+			defaultHook: i.Exists,
+		},
 		ExpireObjectsFunc: &StoreExpireObjectsFunc{
 			defaultHook: i.ExpireObjects,
 		},
@@ -359,6 +375,113 @@ func (c StoreDeleteFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreExistsFunc describes the behavior when the Exists method of the
+// parent MockStore instance is invoked.
+type StoreExistsFunc struct {
+	defaultHook func(context.Context, string) (bool, error)
+	hooks       []func(context.Context, string) (bool, error)
+	history     []StoreExistsFuncCall
+	mutex       sync.Mutex
+}
+
+// Exists delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockStore) Exists(v0 context.Context, v1 string) (bool, error) {
+	r0, r1 := m.ExistsFunc.nextHook()(v0, v1)
+	m.ExistsFunc.appendCall(StoreExistsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Exists method of the
+// parent MockStore instance is invoked and the hook queue is empty.
+func (f *StoreExistsFunc) SetDefaultHook(hook func(context.Context, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Exists method of the parent MockStore instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *StoreExistsFunc) PushHook(hook func(context.Context, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreExistsFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreExistsFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreExistsFunc) nextHook() func(context.Context, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreExistsFunc) appendCall(r0 StoreExistsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreExistsFuncCall objects describing the
+// invocations of this function.
+func (f *StoreExistsFunc) History() []StoreExistsFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreExistsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreExistsFuncCall is an object that describes an invocation of method
+// Exists on an instance of MockStore.
+type StoreExistsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreExistsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreExistsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreExpireObjectsFunc describes the behavior when the ExpireObjects
 // method of the parent MockStore instance is invoked.
 type StoreExpireObjectsFunc struct {
@@ -852,6 +852,9 @@ func (c GcsComposerRunFuncCall) Results() []interface{} {
 // github.com/sourcegraph/sourcegraph/internal/uploadstore) used for unit
 // testing.
 type MockGcsObjectHandle struct {
+	// AttrsFunc is an instance of a mock function object controlling the
+	// behavior of the method Attrs.
+	AttrsFunc *GcsObjectHandleAttrsFunc
 	// ComposerFromFunc is an instance of a mock function object controlling
 	// the behavior of the method ComposerFrom.
 	ComposerFromFunc *GcsObjectHandleComposerFromFunc
@@ -871,6 +874,11 @@ type MockGcsObjectHandle struct {
 // overwritten.
 func NewMockGcsObjectHandle() *MockGcsObjectHandle {
 	return &MockGcsObjectHandle{
+		AttrsFunc: &GcsObjectHandleAttrsFunc{
+			defaultHook: func(context.Context) (r0 *storage.ObjectAttrs, r1 error) {
+				return
+			},
+		},
 		ComposerFromFunc: &GcsObjectHandleComposerFromFunc{
 			defaultHook: func(...gcsObjectHandle) (r0 gcsComposer) {
 				return
@@ -898,6 +906,11 @@ func NewMockGcsObjectHandle() *MockGcsObjectHandle {
 // interface. All methods panic on invocation, unless overwritten.
 func NewStrictMockGcsObjectHandle() *MockGcsObjectHandle {
 	return &MockGcsObjectHandle{
+		AttrsFunc: &GcsObjectHandleAttrsFunc{
+			defaultHook: func(context.Context) (*storage.ObjectAttrs, error) {
+				panic("unexpected invocation of MockGcsObjectHandle.Attrs")
+			},
+		},
 		ComposerFromFunc: &GcsObjectHandleComposerFromFunc{
 			defaultHook: func(...gcsObjectHandle) gcsComposer {
 				panic("unexpected invocation of MockGcsObjectHandle.ComposerFrom")
@@ -926,6 +939,7 @@ func NewStrictMockGcsObjectHandle() *MockGcsObjectHandle {
 // github.com/sourcegraph/sourcegraph/internal/uploadstore). It is redefined
 // here as it is unexported in the source package.
 type surrogateMockGcsObjectHandle interface {
+	Attrs(context.Context) (*storage.ObjectAttrs, error)
 	ComposerFrom(...gcsObjectHandle) gcsComposer
 	Delete(context.Context) error
 	NewRangeReader(context.Context, int64, int64) (io.ReadCloser, error)
@@ -937,6 +951,9 @@ type surrogateMockGcsObjectHandle interface {
 // overwritten.
 func NewMockGcsObjectHandleFrom(i surrogateMockGcsObjectHandle) *MockGcsObjectHandle {
 	return &MockGcsObjectHandle{
+		AttrsFunc: &GcsObjectHandleAttrsFunc{
+			defaultHook: i.Attrs,
+		},
 		ComposerFromFunc: &GcsObjectHandleComposerFromFunc{
 			defaultHook: i.ComposerFrom,
 		},
@@ -952,6 +969,111 @@ func NewMockGcsObjectHandleFrom(i surrogateMockGcsObjectHandle) *MockGcsObjectHa
 	}
 }
 
+// GcsObjectHandleAttrsFunc describes the behavior when the Attrs method of
+// the parent MockGcsObjectHandle instance is invoked.
+type GcsObjectHandleAttrsFunc struct {
+	defaultHook func(context.Context) (*storage.ObjectAttrs, error)
+	hooks       []func(context.Context) (*storage.ObjectAttrs, error)
+	history     []GcsObjectHandleAttrsFuncCall
+	mutex       sync.Mutex
+}
+
+// Attrs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGcsObjectHandle) Attrs(v0 context.Context) (*storage.ObjectAttrs, error) {
+	r0, r1 := m.AttrsFunc.nextHook()(v0)
+	m.AttrsFunc.appendCall(GcsObjectHandleAttrsFuncCall{v0, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Attrs method of the
+// parent MockGcsObjectHandle instance is invoked and the hook queue is
+// empty.
+func (f *GcsObjectHandleAttrsFunc) SetDefaultHook(hook func(context.Context) (*storage.ObjectAttrs, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Attrs method of the parent MockGcsObjectHandle instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *GcsObjectHandleAttrsFunc) PushHook(hook func(context.Context) (*storage.ObjectAttrs, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GcsObjectHandleAttrsFunc) SetDefaultReturn(r0 *storage.ObjectAttrs, r1 error) {
+	f.SetDefaultHook(func(context.Context) (*storage.ObjectAttrs, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GcsObjectHandleAttrsFunc) PushReturn(r0 *storage.ObjectAttrs, r1 error) {
+	f.PushHook(func(context.Context) (*storage.ObjectAttrs, error) {
+		return r0, r1
+	})
+}
+
+func (f *GcsObjectHandleAttrsFunc) nextHook() func(context.Context) (*storage.ObjectAttrs, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GcsObjectHandleAttrsFunc) appendCall(r0 GcsObjectHandleAttrsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GcsObjectHandleAttrsFuncCall objects
+// describing the invocations of this function.
+func (f *GcsObjectHandleAttrsFunc) History() []GcsObjectHandleAttrsFuncCall {
+	f.mutex.Lock()
+	history := make([]GcsObjectHandleAttrsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GcsObjectHandleAttrsFuncCall is an object that describes an invocation of
+// method Attrs on an instance of MockGcsObjectHandle.
+type GcsObjectHandleAttrsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *storage.ObjectAttrs
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GcsObjectHandleAttrsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GcsObjectHandleAttrsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GcsObjectHandleComposerFromFunc describes the behavior when the
 // ComposerFrom method of the parent MockGcsObjectHandle instance is
 // invoked.
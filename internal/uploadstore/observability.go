@@ -9,6 +9,7 @@ import (
 
 type Operations struct {
 	Get           *observation.Operation
+	Exists        *observation.Operation
 	Upload        *observation.Operation
 	Compose       *observation.Operation
 	Delete        *observation.Operation
@@ -33,6 +34,7 @@ func NewOperations(observationCtx *observation.Context, domain, storeName string
 
 	return &Operations{
 		Get:           op("Get"),
+		Exists:        op("Exists"),
 		Upload:        op("Upload"),
 		Compose:       op("Compose"),
 		Delete:        op("Delete"),
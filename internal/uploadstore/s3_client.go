@@ -145,6 +145,25 @@ func (s *s3Store) readObjectInto(ctx context.Context, w io.Writer, key string, b
 	return ioCopyHook(w, resp.Body)
 }
 
+func (s *s3Store) Exists(ctx context.Context, key string) (_ bool, err error) {
+	ctx, _, endObservation := s.operations.Exists.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if _, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}); err != nil {
+		if errors.HasType(err, &s3types.NotFound{}) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to head object")
+	}
+
+	return true, nil
+}
+
 func (s *s3Store) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
 	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.String("key", key),
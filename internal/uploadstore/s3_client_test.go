@@ -192,6 +192,34 @@ func fullContentsS3API() *MockS3API {
 	return s3Client
 }
 
+func TestS3Exists(t *testing.T) {
+	s3Client := NewMockS3API()
+	s3Client.HeadObjectFunc.PushReturn(&s3.HeadObjectOutput{}, nil)
+	s3Client.HeadObjectFunc.PushReturn(nil, &s3types.NotFound{})
+
+	client := testS3Client(s3Client, nil)
+	for _, want := range []bool{true, false} {
+		exists, err := client.Exists(context.Background(), "test-key")
+		if err != nil {
+			t.Fatalf("unexpected error checking key: %s", err)
+		}
+		if exists != want {
+			t.Errorf("unexpected exists. want=%v have=%v", want, exists)
+		}
+	}
+
+	if calls := s3Client.HeadObjectFunc.History(); len(calls) != 2 {
+		t.Fatalf("unexpected number of HeadObject calls. want=%d have=%d", 2, len(calls))
+	} else if value := *calls[0].Arg1.Bucket; value != "test-bucket" {
+		t.Errorf("unexpected bucket argument. want=%s have=%s", "test-bucket", value)
+	} else if value := *calls[0].Arg1.Key; value != "test-key" {
+		t.Errorf("unexpected key argument. want=%s have=%s", "test-key", value)
+	}
+	if calls := s3Client.GetObjectFunc.History(); len(calls) != 0 {
+		t.Fatalf("unexpected number of GetObject calls. want=%d have=%d", 0, len(calls))
+	}
+}
+
 func TestS3Upload(t *testing.T) {
 	s3Client := NewMockS3API()
 	uploaderClient := NewMockS3Uploader()
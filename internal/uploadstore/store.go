@@ -17,6 +17,10 @@ type Store interface {
 	// Get returns a reader that streams the content of the object at the given key.
 	Get(ctx context.Context, key string) (io.ReadCloser, error)
 
+	// Exists determines if an object exists at the given key, without reading its
+	// content.
+	Exists(ctx context.Context, key string) (bool, error)
+
 	// Upload writes the content in the given reader to the object at the given key.
 	Upload(ctx context.Context, key string, r io.Reader) (int64, error)
 
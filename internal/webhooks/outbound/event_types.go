@@ -1,6 +1,10 @@
 package outbound
 
-import "sync"
+import (
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
+)
 
 type EventType struct {
 	Key         string
@@ -41,3 +45,11 @@ func RegisterEventType(eventType EventType) {
 
 	registeredEventTypes.types = append(registeredEventTypes.types, eventType)
 }
+
+func init() {
+	// Core event types live in the events package so that they can be used by
+	// packages that cannot import this one, such as the database package.
+	for _, t := range events.Types {
+		RegisterEventType(EventType{Key: t.Key, Description: t.Description})
+	}
+}
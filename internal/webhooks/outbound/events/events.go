@@ -0,0 +1,126 @@
+// Package events defines the outbound webhook event types emitted by core
+// Sourcegraph services, along with the JSON payloads sent for each of them.
+//
+// This package deliberately has no dependencies on the database so that it
+// can be imported from stores as well as from workers. Registration of the
+// event types happens in the outbound package.
+package events
+
+import (
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+const (
+	RepoAdded       = "repo:added"
+	RepoRemoved     = "repo:removed"
+	RepoCloned      = "repo:cloned"
+	RepoCloneFailed = "repo:clone_failed"
+
+	UserCreated     = "user:created"
+	UserDeleted     = "user:deleted"
+	UserRoleChanged = "user:role_changed"
+
+	PermissionSyncCompleted = "permission_sync:completed"
+	PermissionSyncFailed    = "permission_sync:failed"
+
+	CodeMonitorFired = "code_monitor:fired"
+)
+
+// Type describes a single event type. It mirrors outbound.EventType.
+type Type struct {
+	Key         string
+	Description string
+}
+
+// Types is the list of event types defined in this package, in the order they
+// are presented in the webhook admin UI.
+var Types = []Type{
+	{Key: RepoAdded, Description: "sent when a repository is added from a code host connection"},
+	{Key: RepoRemoved, Description: "sent when a repository is removed from a code host connection"},
+	{Key: RepoCloned, Description: "sent when a repository is cloned to gitserver"},
+	{Key: RepoCloneFailed, Description: "sent when an attempt to clone a repository fails"},
+	{Key: UserCreated, Description: "sent when a user is created"},
+	{Key: UserDeleted, Description: "sent when a user is deleted"},
+	{Key: UserRoleChanged, Description: "sent when roles are assigned to or revoked from a user"},
+	{Key: PermissionSyncCompleted, Description: "sent when a permission sync job completes"},
+	{Key: PermissionSyncFailed, Description: "sent when a permission sync job fails"},
+	{Key: CodeMonitorFired, Description: "sent when a code monitor finds new results"},
+}
+
+// Repo is the payload for the repo:* events.
+type Repo struct {
+	ID graphql.ID `json:"id"`
+	// Name is omitted from repo:removed events sent when a full code host sync
+	// removes repositories, since only their IDs are known at that point.
+	Name string `json:"name,omitempty"`
+	// Error is only set for repo:clone_failed events.
+	Error string `json:"error,omitempty"`
+}
+
+// NewRepo builds a Repo payload.
+func NewRepo(id api.RepoID, name api.RepoName) Repo {
+	return Repo{ID: MarshalRepositoryID(id), Name: string(name)}
+}
+
+// User is the payload for the user:* events.
+type User struct {
+	ID       graphql.ID `json:"id"`
+	Username string     `json:"username,omitempty"`
+
+	// SiteAdmin is only set for user:created events.
+	SiteAdmin bool `json:"site_admin,omitempty"`
+
+	// HardDelete is only set for user:deleted events, and is true if the user
+	// and all of their data were removed rather than the user being marked as
+	// deleted.
+	HardDelete bool `json:"hard_delete,omitempty"`
+
+	// RolesAdded and RolesRemoved are only set for user:role_changed events.
+	// They contain the GraphQL IDs of the roles that changed.
+	RolesAdded   []graphql.ID `json:"roles_added,omitempty"`
+	RolesRemoved []graphql.ID `json:"roles_removed,omitempty"`
+}
+
+// PermissionSync is the payload for the permission_sync:* events. Exactly one
+// of Repository and User is set, depending on the kind of sync.
+type PermissionSync struct {
+	JobID      int        `json:"job_id"`
+	Repository graphql.ID `json:"repository,omitempty"`
+	User       graphql.ID `json:"user,omitempty"`
+	Reason     string     `json:"reason,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// CodeMonitor is the payload for the code_monitor:fired event.
+type CodeMonitor struct {
+	ID          graphql.ID `json:"id"`
+	Description string     `json:"description"`
+	Owner       graphql.ID `json:"owner"`
+	Query       string     `json:"query"`
+	ResultCount int        `json:"result_count"`
+}
+
+// The helpers below mirror the marshallers in cmd/frontend/graphqlbackend,
+// which cannot be imported from here.
+
+func MarshalRepositoryID(id api.RepoID) graphql.ID { return relay.MarshalID("Repository", id) }
+
+func MarshalUserID(id int32) graphql.ID { return relay.MarshalID("User", id) }
+
+func MarshalRoleID(id int32) graphql.ID { return relay.MarshalID("Role", id) }
+
+func MarshalCodeMonitorID(id int64) graphql.ID { return relay.MarshalID("CodeMonitor", id) }
+
+func MarshalRoleIDs(ids []int32) []graphql.ID {
+	if len(ids) == 0 {
+		return nil
+	}
+	gids := make([]graphql.ID, len(ids))
+	for i, id := range ids {
+		gids[i] = MarshalRoleID(id)
+	}
+	return gids
+}
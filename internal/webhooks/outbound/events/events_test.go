@@ -0,0 +1,53 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPayloads ensures that the JSON schemas of the payloads don't change
+// unexpectedly, since webhook consumers depend on them.
+func TestPayloads(t *testing.T) {
+	for name, tc := range map[string]struct {
+		payload any
+		want    string
+	}{
+		"repo": {
+			payload: NewRepo(1, "github.com/sourcegraph/sourcegraph"),
+			want:    `{"id":"UmVwb3NpdG9yeTox","name":"github.com/sourcegraph/sourcegraph"}`,
+		},
+		"repo clone failed": {
+			payload: Repo{ID: MarshalRepositoryID(1), Name: "github.com/sourcegraph/sourcegraph", Error: "oops"},
+			want:    `{"id":"UmVwb3NpdG9yeTox","name":"github.com/sourcegraph/sourcegraph","error":"oops"}`,
+		},
+		"user created": {
+			payload: User{ID: MarshalUserID(1), Username: "alice", SiteAdmin: true},
+			want:    `{"id":"VXNlcjox","username":"alice","site_admin":true}`,
+		},
+		"user deleted": {
+			payload: User{ID: MarshalUserID(1), HardDelete: true},
+			want:    `{"id":"VXNlcjox","hard_delete":true}`,
+		},
+		"user role changed": {
+			payload: User{ID: MarshalUserID(1), RolesAdded: MarshalRoleIDs([]int32{1}), RolesRemoved: MarshalRoleIDs([]int32{2})},
+			want:    `{"id":"VXNlcjox","roles_added":["Um9sZTox"],"roles_removed":["Um9sZToy"]}`,
+		},
+		"permission sync": {
+			payload: PermissionSync{JobID: 3, User: MarshalUserID(1), Reason: "REASON_USER_ADDED"},
+			want:    `{"job_id":3,"user":"VXNlcjox","reason":"REASON_USER_ADDED"}`,
+		},
+		"code monitor": {
+			payload: CodeMonitor{ID: MarshalCodeMonitorID(1), Description: "d", Owner: MarshalUserID(1), Query: "q", ResultCount: 2},
+			want:    `{"id":"Q29kZU1vbml0b3I6MQ==","description":"d","owner":"VXNlcjox","query":"q","result_count":2}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := json.Marshal(tc.payload)
+			require.NoError(t, err)
+			assert.JSONEq(t, tc.want, string(have))
+		})
+	}
+}
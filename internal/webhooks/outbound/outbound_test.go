@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound/events"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -95,3 +96,14 @@ func TestCheckAddress(t *testing.T) {
 		}
 	})
 }
+
+func TestCoreEventTypesRegistered(t *testing.T) {
+	registered := map[string]bool{}
+	for _, et := range GetRegisteredEventTypes() {
+		registered[et.Key] = true
+	}
+
+	for _, et := range events.Types {
+		assert.True(t, registered[et.Key], "event type %q is not registered", et.Key)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/lib/batches"
@@ -104,8 +105,21 @@ type CacheKey struct {
 	GlobalEnv []string `json:"-"`
 
 	StepIndex int
+
+	// ImageDigests maps the containers of the steps to the digests of the images
+	// they resolve to, so that the key changes when a tag is moved to another
+	// image. Containers that are pinned to a digest already are not included.
+	// src-cli does not resolve image digests, so keys that include them are
+	// versioned with ImageDigestsKeyVersion. Omit if empty to be backwards
+	// compatible.
+	ImageDigests map[string]string `json:",omitempty"`
 }
 
+// ImageDigestsKeyVersion prefixes the keys that include image digests. These
+// keys are computed differently from the keys of src-cli, so the prefix keeps
+// them from being mistaken for src-cli keys.
+const ImageDigestsKeyVersion = "v2"
+
 // Key converts the key into a string form that can be used to uniquely identify
 // the cache key in a more concise form than the entire Task.
 func (key CacheKey) Key() (string, error) {
@@ -113,6 +127,7 @@ func (key CacheKey) Key() (string, error) {
 	// including key.StepIndex.
 	clone := key
 	clone.Steps = key.Steps[0 : key.StepIndex+1]
+	clone.ImageDigests = imageDigestsForSteps(key.ImageDigests, clone.Steps)
 
 	// Resolve environment only for the subset of Steps.
 	envs, err := resolveStepsEnvironment(key.GlobalEnv, clone.Steps)
@@ -128,14 +143,42 @@ func (key CacheKey) Key() (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if len(clone.ImageDigests) > 0 {
+		return fmt.Sprintf("%s-%s-step-%d", ImageDigestsKeyVersion, hash, key.StepIndex), nil
+	}
 	return fmt.Sprintf("%s-step-%d", hash, key.StepIndex), err
 }
 
+// imageDigestsForSteps returns the digests of the containers of the given steps
+// that are not pinned to a digest.
+func imageDigestsForSteps(digests map[string]string, steps []batches.Step) map[string]string {
+	var stepDigests map[string]string
+	for _, step := range steps {
+		if IsImagePinned(step.Container) {
+			continue
+		}
+		if digest, ok := digests[step.Container]; ok {
+			if stepDigests == nil {
+				stepDigests = make(map[string]string, len(steps))
+			}
+			stepDigests[step.Container] = digest
+		}
+	}
+	return stepDigests
+}
+
+// IsImagePinned returns true if the container refers to its image by digest, as
+// in "alpine@sha256:...". The step cache keys of such containers do not need the
+// digest of the image, and are the same as the keys of src-cli.
+func IsImagePinned(container string) bool {
+	return strings.Contains(container, "@")
+}
+
 func (key CacheKey) Slug() string {
 	return SlugForRepo(key.Repository.Name, key.Repository.BaseRev)
 }
 
-func KeyForWorkspace(batchChangeAttributes *template.BatchChangeAttributes, r batches.Repository, path string, globalEnv []string, onlyFetchWorkspace bool, steps []batches.Step, stepIndex int, retriever MetadataRetriever) Keyer {
+func KeyForWorkspace(batchChangeAttributes *template.BatchChangeAttributes, r batches.Repository, path string, globalEnv []string, onlyFetchWorkspace bool, steps []batches.Step, stepIndex int, retriever MetadataRetriever) CacheKey {
 	sort.Strings(r.FileMatches)
 
 	return CacheKey{
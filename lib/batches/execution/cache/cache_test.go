@@ -95,6 +95,41 @@ func TestKeyer_Key(t *testing.T) {
 			},
 			expectedKey: "wFqInfgY7mpK9F4qpW2iew-step-0",
 		},
+		{
+			name: "image digests",
+			keyer: &CacheKey{
+				Repository:   repo,
+				Steps:        []batches.Step{{Run: "foo", Container: "alpine:3"}},
+				StepIndex:    0,
+				ImageDigests: map[string]string{"alpine:3": "sha256:abc"},
+			},
+			expectedKey: "v2-mOPcwjGGDE6D_e0QquuIhA-step-0",
+		},
+		{
+			name: "image digests of later steps",
+			keyer: &CacheKey{
+				Repository: repo,
+				Steps: []batches.Step{
+					{Run: "foo", Container: "alpine:3"},
+					{Run: "bar", Container: "ubuntu:22.04"},
+				},
+				StepIndex:    0,
+				ImageDigests: map[string]string{"alpine:3": "sha256:abc", "ubuntu:22.04": "sha256:def"},
+			},
+			// The same key as for only the first step.
+			expectedKey: "v2-mOPcwjGGDE6D_e0QquuIhA-step-0",
+		},
+		{
+			name: "pinned image",
+			keyer: &CacheKey{
+				Repository:   repo,
+				Steps:        []batches.Step{{Run: "foo", Container: "alpine@sha256:abc"}},
+				StepIndex:    0,
+				ImageDigests: map[string]string{"alpine@sha256:abc": "sha256:abc"},
+			},
+			// The same key as src-cli computes without image digests.
+			expectedKey: "5ERe-hfUsDvEsS90Jt6Bvg-step-0",
+		},
 		{
 			name: "mount metadata",
 			keyer: &CacheKey{
@@ -224,6 +224,8 @@ type TaskStepMetadata struct {
 
 	Diff    []byte
 	Outputs map[string]any
+	// Cached is true if the result of the step came from the step cache.
+	Cached bool
 
 	ExitCode int
 	Error    string
@@ -240,6 +242,7 @@ func (m TaskStepMetadata) MarshalJSON() ([]byte, error) {
 			Out:       m.Out,
 			Diff:      m.Diff,
 			Outputs:   m.Outputs,
+			Cached:    m.Cached,
 			ExitCode:  m.ExitCode,
 			Error:     m.Error,
 		})
@@ -275,6 +278,7 @@ func (m *TaskStepMetadata) UnmarshalJSON(data []byte) error {
 		m.Out = v2.Out
 		m.Diff = v2.Diff
 		m.Outputs = v2.Outputs
+		m.Cached = v2.Cached
 		m.ExitCode = v2.ExitCode
 		m.Error = v2.Error
 		return nil
@@ -308,6 +312,7 @@ type v2TaskStepMetadata struct {
 	Out       string            `json:"out,omitempty"`
 	Diff      []byte            `json:"diff,omitempty"`
 	Outputs   map[string]any    `json:"outputs,omitempty"`
+	Cached    bool              `json:"cached,omitempty"`
 	ExitCode  int               `json:"exitCode,omitempty"`
 	Error     string            `json:"error,omitempty"`
 }
@@ -21,6 +21,9 @@ type WorkspacesExecutionInput struct {
 	CachedStepResult execution.AfterStepResult `json:"cachedStepResult,omitempty"`
 	// SkippedSteps determines which steps are skipped in the execution.
 	SkippedSteps map[int]struct{} `json:"skippedSteps"`
+	// StepCache determines whether the results of single steps are looked up in
+	// and stored in the step cache, keyed by the inputs of the step.
+	StepCache bool `json:"stepCache,omitempty"`
 }
 
 type WorkspaceRepo struct {
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS insight_series DROP COLUMN IF EXISTS derived_operation;
+ALTER TABLE IF EXISTS insight_series DROP COLUMN IF EXISTS derived_numerator;
+ALTER TABLE IF EXISTS insight_series DROP COLUMN IF EXISTS derived_denominator;
@@ -0,0 +1,7 @@
+ALTER TABLE IF EXISTS insight_series ADD COLUMN IF NOT EXISTS derived_operation TEXT;
+ALTER TABLE IF EXISTS insight_series ADD COLUMN IF NOT EXISTS derived_numerator TEXT[];
+ALTER TABLE IF EXISTS insight_series ADD COLUMN IF NOT EXISTS derived_denominator TEXT[];
+
+COMMENT ON COLUMN insight_series.derived_operation IS 'The operation that combines the numerator and denominator of a derived series, either PERCENTAGE or RATIO.';
+COMMENT ON COLUMN insight_series.derived_numerator IS 'The series IDs of the series of the same insight view whose points are summed into the numerator of a derived series.';
+COMMENT ON COLUMN insight_series.derived_denominator IS 'The series IDs of the series of the same insight view whose points are summed into the denominator of a derived series.';
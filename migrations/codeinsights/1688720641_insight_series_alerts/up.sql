@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS insight_series_alerts (
+    id SERIAL PRIMARY KEY,
+    series_id INTEGER NOT NULL REFERENCES insight_series(id) ON DELETE CASCADE,
+    condition TEXT NOT NULL,
+    threshold DOUBLE PRECISION NOT NULL DEFAULT 0,
+    user_id INTEGER NOT NULL,
+    email BOOLEAN NOT NULL DEFAULT FALSE,
+    slack_webhook_url TEXT,
+    webhook_url TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    last_evaluated_at TIMESTAMP WITH TIME ZONE,
+    last_triggered_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS insight_series_alerts_series_id_idx ON insight_series_alerts (series_id);
+
+COMMENT ON TABLE insight_series_alerts IS 'Threshold rules on the values of insight series, which are evaluated after each recording of the series.';
+COMMENT ON COLUMN insight_series_alerts.condition IS 'Either ABOVE, which triggers when the total value of the series rises above the threshold, or INCREASE, which triggers when it increased by more than the threshold since the previous recording.';
+COMMENT ON COLUMN insight_series_alerts.user_id IS 'The user that created the alert, who receives its email notifications. This references the users table of the main database.';
+COMMENT ON COLUMN insight_series_alerts.last_evaluated_at IS 'The recording time the alert was last evaluated for.';
+COMMENT ON COLUMN insight_series_alerts.last_triggered_at IS 'The recording time the alert was last triggered by.';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS codeintel_lockfile_references;
+DROP TABLE IF EXISTS codeintel_lockfile_indexes;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS codeintel_lockfile_indexes (
+    id SERIAL PRIMARY KEY,
+    repository_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    commit_bytea bytea NOT NULL,
+    indexed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS codeintel_lockfile_indexes_repository_id ON codeintel_lockfile_indexes(repository_id);
+
+COMMENT ON TABLE codeintel_lockfile_indexes IS 'Tracks the revision of each repository whose lockfiles were most recently extracted into codeintel_lockfile_references.';
+COMMENT ON COLUMN codeintel_lockfile_indexes.commit_bytea IS 'A 40-char revhash. Note that this commit may not be resolvable in the future.';
+
+CREATE TABLE IF NOT EXISTS codeintel_lockfile_references (
+    id SERIAL PRIMARY KEY,
+    lockfile_index_id integer NOT NULL REFERENCES codeintel_lockfile_indexes(id) ON DELETE CASCADE,
+    repository_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    lockfile text NOT NULL,
+    scheme text NOT NULL,
+    name text NOT NULL,
+    version text NOT NULL,
+    package_repo_ref_id bigint REFERENCES lsif_dependency_repos(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS codeintel_lockfile_references_lockfile_index_id ON codeintel_lockfile_references(lockfile_index_id);
+CREATE INDEX IF NOT EXISTS codeintel_lockfile_references_scheme_name_repository_id ON codeintel_lockfile_references(scheme, name, repository_id);
+
+COMMENT ON TABLE codeintel_lockfile_references IS 'A package version that a repository depends on, as declared by a lockfile at the revision recorded in codeintel_lockfile_indexes.';
+COMMENT ON COLUMN codeintel_lockfile_references.lockfile IS 'The path of the lockfile declaring the dependency, relative to the repository root.';
+COMMENT ON COLUMN codeintel_lockfile_references.package_repo_ref_id IS 'The package repo reference for this dependency, if one exists.';
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS own_signal_blame_ownership;
+
+DELETE FROM own_signal_configurations
+WHERE name = 'blame-ownership';
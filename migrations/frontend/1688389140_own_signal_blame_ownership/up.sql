@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS own_signal_blame_ownership (
+    id SERIAL PRIMARY KEY,
+    commit_author_id INTEGER NOT NULL REFERENCES commit_authors(id) ON DELETE CASCADE,
+    file_path_id INTEGER NOT NULL REFERENCES repo_paths(id) ON DELETE CASCADE,
+    lines_count INTEGER NOT NULL,
+    share DOUBLE PRECISION NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS own_signal_blame_ownership_file_author
+ON own_signal_blame_ownership
+USING btree (file_path_id, commit_author_id);
+
+COMMENT ON TABLE own_signal_blame_ownership IS 'One entry per author owning a share of the lines of a file or directory, as attributed by git blame.';
+COMMENT ON COLUMN own_signal_blame_ownership.lines_count IS 'The number of lines of the file, or of all the files within the directory, last changed by the author.';
+COMMENT ON COLUMN own_signal_blame_ownership.share IS 'The fraction of lines attributed to the author, weighted by age, between 0 and 1.';
+
+INSERT INTO own_signal_configurations (name, enabled, description)
+VALUES (
+        'blame-ownership',
+        FALSE,
+        'Indexes the share of the lines of every file authored by each contributor, as attributed by git blame.'
+    ) ON CONFLICT DO NOTHING;
@@ -0,0 +1,54 @@
+ALTER TABLE cm_webhooks ADD COLUMN IF NOT EXISTS payload_template TEXT NOT NULL DEFAULT '';
+
+COMMENT ON COLUMN cm_webhooks.payload_template IS 'A Go text/template used to render the request body sent to the webhook URL. When empty, the default JSON payload is sent';
+
+CREATE TABLE IF NOT EXISTS cm_issues (
+    id BIGSERIAL PRIMARY KEY,
+    monitor BIGINT NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL,
+    include_results BOOLEAN NOT NULL DEFAULT FALSE,
+    repository TEXT NOT NULL,
+    title_template TEXT NOT NULL,
+    body_template TEXT NOT NULL DEFAULT '',
+    created_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    changed_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS cm_issues_monitor ON cm_issues USING btree (monitor);
+
+COMMENT ON TABLE cm_issues IS 'Issue actions configured on code monitors';
+COMMENT ON COLUMN cm_issues.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_issues.enabled IS 'Whether this issue action is enabled. When not enabled, the action will not be run when its code monitor generates events';
+COMMENT ON COLUMN cm_issues.repository IS 'The name of the GitHub or GitLab repository the issues are opened in';
+COMMENT ON COLUMN cm_issues.title_template IS 'A Go text/template used to render the title of the issues';
+COMMENT ON COLUMN cm_issues.body_template IS 'A Go text/template used to render the body of the issues. When empty, a default body is used';
+
+CREATE TABLE IF NOT EXISTS cm_issue_matches (
+    id BIGSERIAL PRIMARY KEY,
+    issue BIGINT NOT NULL REFERENCES cm_issues(id) ON DELETE CASCADE,
+    match_key TEXT NOT NULL,
+    issue_url TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS cm_issue_matches_issue_match_key ON cm_issue_matches USING btree (issue, match_key);
+
+COMMENT ON TABLE cm_issue_matches IS 'The matches that issue actions opened issues for, used to not open duplicate issues for the same match';
+COMMENT ON COLUMN cm_issue_matches.match_key IS 'The key identifying the match, the repository name and commit ID';
+COMMENT ON COLUMN cm_issue_matches.issue_url IS 'The URL of the issue that was opened for the match. NULL while the issue is being opened, or if the issue action failed before storing it';
+
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS issue BIGINT REFERENCES cm_issues(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.issue IS 'The ID of the cm_issues action to execute if this is an issue job. Mutually exclusive with email, webhook and slack_webhook';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN issue IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
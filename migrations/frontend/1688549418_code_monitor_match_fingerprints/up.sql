@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS cm_match_fingerprints (
+    monitor_id BIGINT NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    fingerprint TEXT NOT NULL,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (monitor_id, fingerprint)
+);
+
+CREATE INDEX IF NOT EXISTS cm_match_fingerprints_last_seen_at ON cm_match_fingerprints USING btree (last_seen_at);
+
+COMMENT ON TABLE cm_match_fingerprints IS 'The fingerprints of the file and line matches previously found by code monitors with content search queries';
+COMMENT ON COLUMN cm_match_fingerprints.fingerprint IS 'A hash of the repository, path and content of the match, which does not change when the match moves within its file';
+COMMENT ON COLUMN cm_match_fingerprints.last_seen_at IS 'The last time the match was found. Fingerprints that have not been seen for a while are deleted';
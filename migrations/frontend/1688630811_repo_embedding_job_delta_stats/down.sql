@@ -0,0 +1,3 @@
+ALTER TABLE repo_embedding_job_stats DROP COLUMN IF EXISTS files_added;
+ALTER TABLE repo_embedding_job_stats DROP COLUMN IF EXISTS files_modified;
+ALTER TABLE repo_embedding_job_stats DROP COLUMN IF EXISTS files_deleted;
@@ -0,0 +1,7 @@
+ALTER TABLE repo_embedding_job_stats ADD COLUMN IF NOT EXISTS files_added INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE repo_embedding_job_stats ADD COLUMN IF NOT EXISTS files_modified INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE repo_embedding_job_stats ADD COLUMN IF NOT EXISTS files_deleted INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN repo_embedding_job_stats.files_added IS 'The number of files added since the previously indexed revision. Only set for incremental jobs';
+COMMENT ON COLUMN repo_embedding_job_stats.files_modified IS 'The number of files modified since the previously indexed revision. Only set for incremental jobs';
+COMMENT ON COLUMN repo_embedding_job_stats.files_deleted IS 'The number of files deleted since the previously indexed revision. Only set for incremental jobs';
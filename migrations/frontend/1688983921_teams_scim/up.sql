@@ -0,0 +1,5 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS scim_controlled BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS scim_external_id TEXT;
+
+COMMENT ON COLUMN teams.scim_controlled IS 'Whether the team is provisioned from a group of the identity provider through SCIM.';
+COMMENT ON COLUMN teams.scim_external_id IS 'The external ID of the SCIM group of the team, as set by the identity provider.';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS notebooks_source_repo_id_idx;
+
+ALTER TABLE notebooks DROP COLUMN IF EXISTS source_commit;
+ALTER TABLE notebooks DROP COLUMN IF EXISTS source_path;
+ALTER TABLE notebooks DROP COLUMN IF EXISTS source_repo_id;
@@ -0,0 +1,9 @@
+ALTER TABLE notebooks ADD COLUMN IF NOT EXISTS source_repo_id INTEGER REFERENCES repo(id) ON DELETE SET NULL DEFERRABLE;
+ALTER TABLE notebooks ADD COLUMN IF NOT EXISTS source_path TEXT;
+ALTER TABLE notebooks ADD COLUMN IF NOT EXISTS source_commit TEXT;
+
+CREATE INDEX IF NOT EXISTS notebooks_source_repo_id_idx ON notebooks USING btree (source_repo_id) WHERE source_repo_id IS NOT NULL;
+
+COMMENT ON COLUMN notebooks.source_repo_id IS 'The repository the notebook was imported from. The notebook blocks are kept in sync with the source file on the default branch.';
+COMMENT ON COLUMN notebooks.source_path IS 'The path of the .snb.md file the notebook was imported from.';
+COMMENT ON COLUMN notebooks.source_commit IS 'The commit the notebook blocks were last synced from.';
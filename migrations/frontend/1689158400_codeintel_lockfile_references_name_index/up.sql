@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS codeintel_lockfile_references_name_repository_id ON codeintel_lockfile_references(name, repository_id);
@@ -0,0 +1 @@
+ALTER TABLE org_members DROP COLUMN IF EXISTS scim_controlled;
@@ -0,0 +1,3 @@
+ALTER TABLE org_members ADD COLUMN IF NOT EXISTS scim_controlled BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN org_members.scim_controlled IS 'Whether the membership was granted because the user is a member of a SCIM group that is mapped to the organization.';
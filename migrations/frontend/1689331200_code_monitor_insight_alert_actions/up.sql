@@ -0,0 +1,14 @@
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS insight_alert JSONB;
+
+COMMENT ON COLUMN cm_action_jobs.insight_alert IS 'The triggered code insights alert to deliver and the channel to deliver it through, if this is an insight alert job. Mutually exclusive with email, webhook, slack_webhook and issue';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN issue IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN insight_alert IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
@@ -563,11 +563,14 @@ type Completions struct {
 	// CompletionModel description: The model used for code completion. If using the default provider 'sourcegraph', a reasonable default model will be set.
 	CompletionModel string `json:"completionModel,omitempty"`
 	// CompletionModelMaxTokens description: The maximum number of tokens to use as client when talking to completionModel. If not set, clients need to set their own limit.
-	CompletionModelMaxTokens int `json:"completionModelMaxTokens,omitempty"`
+	CompletionModelMaxTokens int                        `json:"completionModelMaxTokens,omitempty"`
+	Custom                   *CustomCompletionsProvider `json:"custom,omitempty"`
 	// Enabled description: DEPRECATED. Use cody.enabled instead to turn Cody on/off.
 	Enabled *bool `json:"enabled,omitempty"`
-	// Endpoint description: The endpoint under which to reach the provider. Currently only used for provider types "sourcegraph", "openai" and "anthropic". The default values are "https://cody-gateway.sourcegraph.com", "https://api.openai.com/v1/chat/completions", and "https://api.anthropic.com/v1/complete" for Sourcegraph, OpenAI, and Anthropic, respectively.
+	// Endpoint description: The endpoint under which to reach the provider. The default values are "https://cody-gateway.sourcegraph.com", "https://api.openai.com/v1/chat/completions", and "https://api.anthropic.com/v1/complete" for Sourcegraph, OpenAI, and Anthropic, respectively. Required for provider type "custom".
 	Endpoint string `json:"endpoint,omitempty"`
+	// Fallbacks description: Additional completions providers to use when the provider above is rate limited or unavailable, in order of preference. A provider that keeps failing is skipped for a while before it is tried again.
+	Fallbacks []*CompletionsFallback `json:"fallbacks,omitempty"`
 	// FastChatModel description: The model used for fast chat completions.
 	FastChatModel string `json:"fastChatModel,omitempty"`
 	// FastChatModelMaxTokens description: The maximum number of tokens to use as client when talking to fastChatModel. If not set, clients need to set their own limit.
@@ -580,6 +583,63 @@ type Completions struct {
 	PerUserDailyLimit int `json:"perUserDailyLimit,omitempty"`
 	// Provider description: The external completions provider. Defaults to 'sourcegraph'.
 	Provider string `json:"provider,omitempty"`
+	// Routing description: How requests are spread across the provider above and its fallbacks. With "failover", requests go to the first available provider. With "round-robin", requests are spread evenly across all providers, and still fail over to the next one.
+	Routing string `json:"routing,omitempty"`
+}
+
+// CompletionsFallback description: A completions provider to fail over to. Models that are not set default to the chat model, or to the provider's default models.
+type CompletionsFallback struct {
+	// AccessToken description: The access token used to authenticate with the provider.
+	AccessToken string `json:"accessToken,omitempty"`
+	// ChatModel description: The model used in place of the chat model.
+	ChatModel string `json:"chatModel,omitempty"`
+	// CompletionModel description: The model used in place of the code completion model.
+	CompletionModel string                     `json:"completionModel,omitempty"`
+	Custom          *CustomCompletionsProvider `json:"custom,omitempty"`
+	// Endpoint description: The endpoint under which to reach the provider. Defaults to the provider's default endpoint.
+	Endpoint string `json:"endpoint,omitempty"`
+	// FastChatModel description: The model used in place of the fast chat model.
+	FastChatModel string `json:"fastChatModel,omitempty"`
+	// Provider description: The external completions provider.
+	Provider string `json:"provider"`
+}
+
+// CustomCompletionsProvider description: Configures how to talk to a self-hosted model server when the completions provider is "custom". The defaults target servers that implement the OpenAI chat completions API, such as vLLM or llama.cpp.
+type CustomCompletionsProvider struct {
+	// CompletionPath description: The dot-separated path to the completion text in non-streaming responses. Array elements are addressed by their index.
+	CompletionPath string `json:"completionPath,omitempty"`
+	// Headers description: Additional HTTP headers sent with every request. If accessToken is set, it is sent as a bearer token in the Authorization header unless that header is set here.
+	Headers map[string]string `json:"headers,omitempty"`
+	// ModelIDField description: The field holding the name of a model in the response of modelsEndpoint.
+	ModelIDField string `json:"modelIDField,omitempty"`
+	// ModelsEndpoint description: An endpoint listing the models served, used to discover the token limit of each model. Requested max tokens are capped to the discovered limit. If not set, no discovery is performed.
+	ModelsEndpoint string `json:"modelsEndpoint,omitempty"`
+	// ModelsPath description: The dot-separated path to the list of models in the response of modelsEndpoint.
+	ModelsPath string `json:"modelsPath,omitempty"`
+	// RequestTemplate description: A Go text/template that renders the JSON request body. The template is executed with the fields Model, Messages (each with Speaker, Role and Text), Prompt, MaxTokens, Temperature, TopK, TopP, StopSequences, Stream and ModelMaxTokens. The `json` function encodes a value as JSON. Defaults to an OpenAI chat completions request.
+	RequestTemplate string `json:"requestTemplate,omitempty"`
+	// StopReasonPath description: The dot-separated path to the stop reason in responses and streamed events.
+	StopReasonPath string `json:"stopReasonPath,omitempty"`
+	// StreamCompletionPath description: The dot-separated path to the completion text in streamed events.
+	StreamCompletionPath string `json:"streamCompletionPath,omitempty"`
+	// StreamCumulative description: Whether each streamed event contains the full completion so far rather than only the newly generated text.
+	StreamCumulative bool `json:"streamCumulative,omitempty"`
+	// StreamDoneSentinel description: The event data that marks the end of a server-sent events stream.
+	StreamDoneSentinel string `json:"streamDoneSentinel,omitempty"`
+	// StreamFormat description: The format of streamed responses: server-sent events, or one JSON object per line.
+	StreamFormat string `json:"streamFormat,omitempty"`
+	// TokenLimitField description: The field holding the token limit of a model in the response of modelsEndpoint.
+	TokenLimitField string `json:"tokenLimitField,omitempty"`
+}
+
+// CustomEmbeddingsProvider description: Configures how to talk to a self-hosted embedding server when the embeddings provider is "custom". The server is reached at the configured endpoint, and the model must be set.
+type CustomEmbeddingsProvider struct {
+	// BatchSize description: The maximum number of texts sent in a single request. Larger batches are split into several requests. If 0, texts are sent in the batches used by the embeddings job.
+	BatchSize int `json:"batchSize,omitempty"`
+	// Headers description: Additional HTTP headers sent with every request. If accessToken is set, it is sent as a bearer token in the Authorization header unless that header is set here.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Protocol description: The protocol spoken by the server. With "openai", requests and responses follow the OpenAI embeddings API, as implemented by servers such as vLLM, llama.cpp or text-embeddings-inference. With "json", the request is {"model": ..., "texts": [...]} and the response is {"embeddings": [[...], ...]}, with one embedding per text in the same order.
+	Protocol string `json:"protocol,omitempty"`
 }
 
 // CustomGitFetchMapping description: Mapping from Git clone URl domain/path to git fetch command. The `domainPath` field contains the Git clone URL domain/path part. The `fetch` field contains the custom git fetch command.
@@ -629,12 +689,13 @@ type EmailTemplates struct {
 // Embeddings description: Configuration for embeddings service.
 type Embeddings struct {
 	// AccessToken description: The access token used to authenticate with the external embedding API service. For provider sourcegraph, this is optional.
-	AccessToken string `json:"accessToken,omitempty"`
-	// Dimensions description: The dimensionality of the embedding vectors. Required field if not using the sourcegraph provider.
+	AccessToken string                    `json:"accessToken,omitempty"`
+	Custom      *CustomEmbeddingsProvider `json:"custom,omitempty"`
+	// Dimensions description: The dimensionality of the embedding vectors. Required field if not using the sourcegraph or custom provider. The custom provider discovers it from the server if not set.
 	Dimensions int `json:"dimensions,omitempty"`
 	// Enabled description: Toggles whether embedding service is enabled.
 	Enabled *bool `json:"enabled,omitempty"`
-	// Endpoint description: The endpoint under which to reach the provider. Sensible default will be used for each provider.
+	// Endpoint description: The endpoint under which to reach the provider. Sensible default will be used for each provider. Required for provider custom.
 	Endpoint string `json:"endpoint,omitempty"`
 	// ExcludedFilePathPatterns description: A list of glob patterns that match file paths you want to exclude from embeddings. This is useful to exclude files with low information value (e.g., SVG files, test fixtures, mocks, auto-generated files, etc.).
 	ExcludedFilePathPatterns []string `json:"excludedFilePathPatterns,omitempty"`
@@ -650,7 +711,7 @@ type Embeddings struct {
 	Model string `json:"model,omitempty"`
 	// PolicyRepositoryMatchLimit description: The maximum number of repositories that can be matched by a global embeddings policy
 	PolicyRepositoryMatchLimit *int `json:"policyRepositoryMatchLimit,omitempty"`
-	// Provider description: The provider to use for generating embeddings. Defaults to sourcegraph.
+	// Provider description: The provider to use for generating embeddings. Defaults to sourcegraph. Use custom for a self-hosted embedding server.
 	Provider string `json:"provider,omitempty"`
 	// Url description: The url to the external embedding API service. Deprecated, use endpoint instead.
 	Url string `json:"url,omitempty"`
@@ -770,6 +831,12 @@ type ExcludedGitoliteRepo struct {
 	// Pattern description: Regular expression which matches against the name of a Gitolite repo to exclude from mirroring.
 	Pattern string `json:"pattern,omitempty"`
 }
+type ExcludedMercurialRepo struct {
+	// Name description: The name of a Mercurial repo ("my-repo") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of a Mercurial repo to exclude from mirroring.
+	Pattern string `json:"pattern,omitempty"`
+}
 type ExcludedOtherRepo struct {
 	// Name description: The name of a Other repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
@@ -811,8 +878,12 @@ type ExperimentalFeatures struct {
 	EnableStorm bool `json:"enableStorm,omitempty"`
 	// EventLogging description: Enables user event logging inside of the Sourcegraph instance. This will allow admins to have greater visibility of user activity, such as frequently viewed pages, frequent searches, and more. These event logs (and any specific user actions) are only stored locally, and never leave this Sourcegraph instance.
 	EventLogging string `json:"eventLogging,omitempty"`
+	// GitServerPartialClones description: JSON array of repositories that gitserver clones as partial clones. A partial clone omits the blobs larger than a size limit from the history of the repository, and gitserver fetches them from the code host when a command needs them. This reduces the disk usage of large repositories with many large files in their history, at the cost of slower reads of those files. The setting is applied when a repository is cloned: existing clones keep their mode until they are recloned.
+	GitServerPartialClones []*PartialCloneMapping `json:"gitServerPartialClones,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
+	// GitServerReplicationFactor description: The number of gitserver instances that each repository is stored on. The first instance is the one the repository is assigned to without replication, and the replicas are the instances that follow it in the list of gitserver addresses. Reads fail over to the replicas when that instance is unavailable, and repo-updater keeps all of them up to date. Values larger than the number of gitserver instances store every repository on every instance.
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
 	// InsightsAlternateLoadingStrategy description: Use an in-memory strategy of loading Code Insights. Should only be used for benchmarking on large instances, not for customer use currently.
@@ -823,6 +894,8 @@ type ExperimentalFeatures struct {
 	InsightsDataRetention *bool `json:"insightsDataRetention,omitempty"`
 	// JvmPackages description: Allow adding JVM package host connections
 	JvmPackages string `json:"jvmPackages,omitempty"`
+	// Mercurial description: Allow adding Mercurial code host connections
+	Mercurial string `json:"mercurial,omitempty"`
 	// NpmPackages description: Allow adding npm package code host connections
 	NpmPackages string `json:"npmPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
@@ -897,12 +970,15 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "enablePermissionsWebhooks")
 	delete(m, "enableStorm")
 	delete(m, "eventLogging")
+	delete(m, "gitServerPartialClones")
 	delete(m, "gitServerPinnedRepos")
+	delete(m, "gitServerReplicationFactor")
 	delete(m, "goPackages")
 	delete(m, "insightsAlternateLoadingStrategy")
 	delete(m, "insightsBackfillerV2")
 	delete(m, "insightsDataRetention")
 	delete(m, "jvmPackages")
+	delete(m, "mercurial")
 	delete(m, "npmPackages")
 	delete(m, "pagure")
 	delete(m, "passwordPolicy")
@@ -1423,6 +1499,21 @@ type MavenRateLimit struct {
 	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 100, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 100 requests immediately, provided that the complexity cost of each request is 1.
 	RequestsPerHour float64 `json:"requestsPerHour"`
 }
+
+// MercurialConnection description: Configuration for a connection to Mercurial repositories. Sourcegraph converts them into Git repositories.
+type MercurialConnection struct {
+	// Exclude description: A list of repositories to never mirror by name after applying repositoryPathPattern. Supports excluding by exact name ({"name": "myrepo"}) or regular expression ({"pattern": ".*secret.*"}).
+	Exclude []*ExcludedMercurialRepo `json:"exclude,omitempty"`
+	Repos   []string                 `json:"repos"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Mercurial base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
+	//
+	// For example, if your Mercurial base URL is https://hg.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://hg.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/hg.example.com/repos/my/repo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Url description: The base URL of the Mercurial server, which the paths in `repos` are relative to. Credentials can be included in the URL.
+	Url string `json:"url"`
+}
 type Mount struct {
 	// Mountpoint description: The path in the container to mount the path on the local machine to.
 	Mountpoint string `json:"mountpoint"`
@@ -1726,6 +1817,14 @@ type ParentSourcegraph struct {
 	Url string `json:"url,omitempty"`
 }
 
+// PartialCloneMapping description: Mapping from Git clone URL domain/path to the blob size limit of the partial clone of the repository.
+type PartialCloneMapping struct {
+	// BlobSizeLimit description: Blobs larger than this size, in bytes with an optional k, m or g suffix, are omitted from the clone. 0 omits all blobs.
+	BlobSizeLimit string `json:"blobSizeLimit,omitempty"`
+	// DomainPath description: Git clone URL domain/path
+	DomainPath string `json:"domainPath"`
+}
+
 // PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
 type PasswordPolicy struct {
 	// Enabled description: Enables password policy
@@ -2593,6 +2692,8 @@ type SiteConfiguration struct {
 	RepoPurgeWorker *RepoPurgeWorker `json:"repoPurgeWorker,omitempty"`
 	// ScimAuthToken description: DISCLAIMER: UNDER DEVELOPMENT. THE ENDPOINT DOES NOT COMPLY WITH THE SCIM STANDARD YET. The SCIM auth token is used to authenticate SCIM requests. If not set, SCIM is disabled.
 	ScimAuthToken string `json:"scim.authToken,omitempty"`
+	// ScimGroupOrganizations description: Maps the display names of SCIM groups to the names of organizations. Members of a mapped group are added to the organization along with the team of the group. They are removed from the organization once they are no longer members of any group that is mapped to it, unless they joined the organization otherwise.
+	ScimGroupOrganizations map[string]string `json:"scim.groupOrganizations,omitempty"`
 	// ScimIdentityProvider description: Identity provider used for SCIM support.  "STANDARD" should be used unless a more specific value is available
 	ScimIdentityProvider string `json:"scim.identityProvider,omitempty"`
 	// SearchIndexSymbolsEnabled description: Whether indexed symbol search is enabled. This is contingent on the indexed search configuration, and is true by default for instances with indexed search enabled. Enabling this will cause every repository to re-index, which is a time consuming (several hours) operation. Additionally, it requires more storage and ram to accommodate the added symbols information in the search index.
@@ -2761,6 +2862,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "repoListUpdateInterval")
 	delete(m, "repoPurgeWorker")
 	delete(m, "scim.authToken")
+	delete(m, "scim.groupOrganizations")
 	delete(m, "scim.identityProvider")
 	delete(m, "search.index.symbols.enabled")
 	delete(m, "search.largeFiles")